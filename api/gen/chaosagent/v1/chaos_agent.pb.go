@@ -0,0 +1,865 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: chaosagent/v1/chaos_agent.proto
+
+// Injection API served by chaos-agent on each Docker host of a multi-host
+// deployment.
+//
+// chaos-runner stays the single coordinator: it discovers targets across
+// every agent, decides what to inject when, and evaluates criteria. An
+// agent only does what needs its host's Docker daemon: listing containers,
+// creating sidecars, and installing and removing faults. A fault is carried
+// as the YAML of one spec.faults entry (pkg/scenario.Fault), with its
+// targets already resolved, so the schema has one source of truth.
+
+package chaosagentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{0}
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hostname      string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	DockerVersion string                 `protobuf:"bytes,2,opt,name=docker_version,json=dockerVersion,proto3" json:"docker_version,omitempty"`
+	AgentVersion  string                 `protobuf:"bytes,3,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PingResponse) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *PingResponse) GetDockerVersion() string {
+	if x != nil {
+		return x.DockerVersion
+	}
+	return ""
+}
+
+func (x *PingResponse) GetAgentVersion() string {
+	if x != nil {
+		return x.AgentVersion
+	}
+	return ""
+}
+
+type ListContainersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainersRequest) Reset() {
+	*x = ListContainersRequest{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainersRequest) ProtoMessage() {}
+
+func (x *ListContainersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainersRequest.ProtoReflect.Descriptor instead.
+func (*ListContainersRequest) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{2}
+}
+
+type ListContainersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Containers    []*Container           `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainersResponse) Reset() {
+	*x = ListContainersResponse{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainersResponse) ProtoMessage() {}
+
+func (x *ListContainersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainersResponse.ProtoReflect.Descriptor instead.
+func (*ListContainersResponse) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListContainersResponse) GetContainers() []*Container {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+type Container struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Docker names, each with its leading "/".
+	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+	// First network IP, empty when the container has none.
+	Ip            string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Container) Reset() {
+	*x = Container{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Container) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container) ProtoMessage() {}
+
+func (x *Container) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container.ProtoReflect.Descriptor instead.
+func (*Container) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Container) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Container) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Container) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+type Target struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Target) Reset() {
+	*x = Target{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Target) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Target) ProtoMessage() {}
+
+func (x *Target) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Target.ProtoReflect.Descriptor instead.
+func (*Target) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Target) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Target) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+type PrepareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Targets       []*PrepareTarget       `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrepareRequest) Reset() {
+	*x = PrepareRequest{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrepareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareRequest) ProtoMessage() {}
+
+func (x *PrepareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareRequest.ProtoReflect.Descriptor instead.
+func (*PrepareRequest) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PrepareRequest) GetTargets() []*PrepareTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type PrepareTarget struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Target *Target                `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	// Give the sidecar the target's PID namespace and the host cgroups, which
+	// stress faults need.
+	CgroupAccess  bool `protobuf:"varint,2,opt,name=cgroup_access,json=cgroupAccess,proto3" json:"cgroup_access,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrepareTarget) Reset() {
+	*x = PrepareTarget{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrepareTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareTarget) ProtoMessage() {}
+
+func (x *PrepareTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareTarget.ProtoReflect.Descriptor instead.
+func (*PrepareTarget) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PrepareTarget) GetTarget() *Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *PrepareTarget) GetCgroupAccess() bool {
+	if x != nil {
+		return x.CgroupAccess
+	}
+	return false
+}
+
+type PrepareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrepareResponse) Reset() {
+	*x = PrepareResponse{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrepareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareResponse) ProtoMessage() {}
+
+func (x *PrepareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareResponse.ProtoReflect.Descriptor instead.
+func (*PrepareResponse) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{8}
+}
+
+type InjectFaultRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One spec.faults entry as YAML, with peers and other target
+	// references already resolved by the runner.
+	FaultYaml     []byte    `protobuf:"bytes,1,opt,name=fault_yaml,json=faultYaml,proto3" json:"fault_yaml,omitempty"`
+	Targets       []*Target `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InjectFaultRequest) Reset() {
+	*x = InjectFaultRequest{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InjectFaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InjectFaultRequest) ProtoMessage() {}
+
+func (x *InjectFaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InjectFaultRequest.ProtoReflect.Descriptor instead.
+func (*InjectFaultRequest) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InjectFaultRequest) GetFaultYaml() []byte {
+	if x != nil {
+		return x.FaultYaml
+	}
+	return nil
+}
+
+func (x *InjectFaultRequest) GetTargets() []*Target {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type InjectFaultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InjectFaultResponse) Reset() {
+	*x = InjectFaultResponse{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InjectFaultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InjectFaultResponse) ProtoMessage() {}
+
+func (x *InjectFaultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InjectFaultResponse.ProtoReflect.Descriptor instead.
+func (*InjectFaultResponse) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{10}
+}
+
+type RemoveFaultRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	FaultType string                 `protobuf:"bytes,1,opt,name=fault_type,json=faultType,proto3" json:"fault_type,omitempty"`
+	Target    *Target                `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// The fault's params, used to find and remove what it installed when the
+	// agent has no record of it (e.g. it restarted since the injection).
+	ParamsYaml    []byte `protobuf:"bytes,3,opt,name=params_yaml,json=paramsYaml,proto3" json:"params_yaml,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFaultRequest) Reset() {
+	*x = RemoveFaultRequest{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFaultRequest) ProtoMessage() {}
+
+func (x *RemoveFaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFaultRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFaultRequest) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RemoveFaultRequest) GetFaultType() string {
+	if x != nil {
+		return x.FaultType
+	}
+	return ""
+}
+
+func (x *RemoveFaultRequest) GetTarget() *Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *RemoveFaultRequest) GetParamsYaml() []byte {
+	if x != nil {
+		return x.ParamsYaml
+	}
+	return nil
+}
+
+type RemoveFaultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFaultResponse) Reset() {
+	*x = RemoveFaultResponse{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFaultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFaultResponse) ProtoMessage() {}
+
+func (x *RemoveFaultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFaultResponse.ProtoReflect.Descriptor instead.
+func (*RemoveFaultResponse) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{12}
+}
+
+type CleanupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupRequest) Reset() {
+	*x = CleanupRequest{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupRequest) ProtoMessage() {}
+
+func (x *CleanupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupRequest.ProtoReflect.Descriptor instead.
+func (*CleanupRequest) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{13}
+}
+
+type CleanupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FaultsRemoved int32                  `protobuf:"varint,1,opt,name=faults_removed,json=faultsRemoved,proto3" json:"faults_removed,omitempty"`
+	// Faults that could not be removed, one message each.
+	Errors        []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupResponse) Reset() {
+	*x = CleanupResponse{}
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupResponse) ProtoMessage() {}
+
+func (x *CleanupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosagent_v1_chaos_agent_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupResponse.ProtoReflect.Descriptor instead.
+func (*CleanupResponse) Descriptor() ([]byte, []int) {
+	return file_chaosagent_v1_chaos_agent_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CleanupResponse) GetFaultsRemoved() int32 {
+	if x != nil {
+		return x.FaultsRemoved
+	}
+	return 0
+}
+
+func (x *CleanupResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+var File_chaosagent_v1_chaos_agent_proto protoreflect.FileDescriptor
+
+const file_chaosagent_v1_chaos_agent_proto_rawDesc = "" +
+	"\n" +
+	"\x1fchaosagent/v1/chaos_agent.proto\x12\rchaosagent.v1\"\r\n" +
+	"\vPingRequest\"v\n" +
+	"\fPingResponse\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12%\n" +
+	"\x0edocker_version\x18\x02 \x01(\tR\rdockerVersion\x12#\n" +
+	"\ragent_version\x18\x03 \x01(\tR\fagentVersion\"\x17\n" +
+	"\x15ListContainersRequest\"R\n" +
+	"\x16ListContainersResponse\x128\n" +
+	"\n" +
+	"containers\x18\x01 \x03(\v2\x18.chaosagent.v1.ContainerR\n" +
+	"containers\"A\n" +
+	"\tContainer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05names\x18\x02 \x03(\tR\x05names\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\"?\n" +
+	"\x06Target\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\"H\n" +
+	"\x0ePrepareRequest\x126\n" +
+	"\atargets\x18\x01 \x03(\v2\x1c.chaosagent.v1.PrepareTargetR\atargets\"c\n" +
+	"\rPrepareTarget\x12-\n" +
+	"\x06target\x18\x01 \x01(\v2\x15.chaosagent.v1.TargetR\x06target\x12#\n" +
+	"\rcgroup_access\x18\x02 \x01(\bR\fcgroupAccess\"\x11\n" +
+	"\x0fPrepareResponse\"d\n" +
+	"\x12InjectFaultRequest\x12\x1d\n" +
+	"\n" +
+	"fault_yaml\x18\x01 \x01(\fR\tfaultYaml\x12/\n" +
+	"\atargets\x18\x02 \x03(\v2\x15.chaosagent.v1.TargetR\atargets\"\x15\n" +
+	"\x13InjectFaultResponse\"\x83\x01\n" +
+	"\x12RemoveFaultRequest\x12\x1d\n" +
+	"\n" +
+	"fault_type\x18\x01 \x01(\tR\tfaultType\x12-\n" +
+	"\x06target\x18\x02 \x01(\v2\x15.chaosagent.v1.TargetR\x06target\x12\x1f\n" +
+	"\vparams_yaml\x18\x03 \x01(\fR\n" +
+	"paramsYaml\"\x15\n" +
+	"\x13RemoveFaultResponse\"\x10\n" +
+	"\x0eCleanupRequest\"P\n" +
+	"\x0fCleanupResponse\x12%\n" +
+	"\x0efaults_removed\x18\x01 \x01(\x05R\rfaultsRemoved\x12\x16\n" +
+	"\x06errors\x18\x02 \x03(\tR\x06errors2\xec\x03\n" +
+	"\n" +
+	"ChaosAgent\x12?\n" +
+	"\x04Ping\x12\x1a.chaosagent.v1.PingRequest\x1a\x1b.chaosagent.v1.PingResponse\x12]\n" +
+	"\x0eListContainers\x12$.chaosagent.v1.ListContainersRequest\x1a%.chaosagent.v1.ListContainersResponse\x12H\n" +
+	"\aPrepare\x12\x1d.chaosagent.v1.PrepareRequest\x1a\x1e.chaosagent.v1.PrepareResponse\x12T\n" +
+	"\vInjectFault\x12!.chaosagent.v1.InjectFaultRequest\x1a\".chaosagent.v1.InjectFaultResponse\x12T\n" +
+	"\vRemoveFault\x12!.chaosagent.v1.RemoveFaultRequest\x1a\".chaosagent.v1.RemoveFaultResponse\x12H\n" +
+	"\aCleanup\x12\x1d.chaosagent.v1.CleanupRequest\x1a\x1e.chaosagent.v1.CleanupResponseBEZCgithub.com/jihwankim/chaos-utils/api/gen/chaosagent/v1;chaosagentv1b\x06proto3"
+
+var (
+	file_chaosagent_v1_chaos_agent_proto_rawDescOnce sync.Once
+	file_chaosagent_v1_chaos_agent_proto_rawDescData []byte
+)
+
+func file_chaosagent_v1_chaos_agent_proto_rawDescGZIP() []byte {
+	file_chaosagent_v1_chaos_agent_proto_rawDescOnce.Do(func() {
+		file_chaosagent_v1_chaos_agent_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chaosagent_v1_chaos_agent_proto_rawDesc), len(file_chaosagent_v1_chaos_agent_proto_rawDesc)))
+	})
+	return file_chaosagent_v1_chaos_agent_proto_rawDescData
+}
+
+var file_chaosagent_v1_chaos_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_chaosagent_v1_chaos_agent_proto_goTypes = []any{
+	(*PingRequest)(nil),            // 0: chaosagent.v1.PingRequest
+	(*PingResponse)(nil),           // 1: chaosagent.v1.PingResponse
+	(*ListContainersRequest)(nil),  // 2: chaosagent.v1.ListContainersRequest
+	(*ListContainersResponse)(nil), // 3: chaosagent.v1.ListContainersResponse
+	(*Container)(nil),              // 4: chaosagent.v1.Container
+	(*Target)(nil),                 // 5: chaosagent.v1.Target
+	(*PrepareRequest)(nil),         // 6: chaosagent.v1.PrepareRequest
+	(*PrepareTarget)(nil),          // 7: chaosagent.v1.PrepareTarget
+	(*PrepareResponse)(nil),        // 8: chaosagent.v1.PrepareResponse
+	(*InjectFaultRequest)(nil),     // 9: chaosagent.v1.InjectFaultRequest
+	(*InjectFaultResponse)(nil),    // 10: chaosagent.v1.InjectFaultResponse
+	(*RemoveFaultRequest)(nil),     // 11: chaosagent.v1.RemoveFaultRequest
+	(*RemoveFaultResponse)(nil),    // 12: chaosagent.v1.RemoveFaultResponse
+	(*CleanupRequest)(nil),         // 13: chaosagent.v1.CleanupRequest
+	(*CleanupResponse)(nil),        // 14: chaosagent.v1.CleanupResponse
+}
+var file_chaosagent_v1_chaos_agent_proto_depIdxs = []int32{
+	4,  // 0: chaosagent.v1.ListContainersResponse.containers:type_name -> chaosagent.v1.Container
+	7,  // 1: chaosagent.v1.PrepareRequest.targets:type_name -> chaosagent.v1.PrepareTarget
+	5,  // 2: chaosagent.v1.PrepareTarget.target:type_name -> chaosagent.v1.Target
+	5,  // 3: chaosagent.v1.InjectFaultRequest.targets:type_name -> chaosagent.v1.Target
+	5,  // 4: chaosagent.v1.RemoveFaultRequest.target:type_name -> chaosagent.v1.Target
+	0,  // 5: chaosagent.v1.ChaosAgent.Ping:input_type -> chaosagent.v1.PingRequest
+	2,  // 6: chaosagent.v1.ChaosAgent.ListContainers:input_type -> chaosagent.v1.ListContainersRequest
+	6,  // 7: chaosagent.v1.ChaosAgent.Prepare:input_type -> chaosagent.v1.PrepareRequest
+	9,  // 8: chaosagent.v1.ChaosAgent.InjectFault:input_type -> chaosagent.v1.InjectFaultRequest
+	11, // 9: chaosagent.v1.ChaosAgent.RemoveFault:input_type -> chaosagent.v1.RemoveFaultRequest
+	13, // 10: chaosagent.v1.ChaosAgent.Cleanup:input_type -> chaosagent.v1.CleanupRequest
+	1,  // 11: chaosagent.v1.ChaosAgent.Ping:output_type -> chaosagent.v1.PingResponse
+	3,  // 12: chaosagent.v1.ChaosAgent.ListContainers:output_type -> chaosagent.v1.ListContainersResponse
+	8,  // 13: chaosagent.v1.ChaosAgent.Prepare:output_type -> chaosagent.v1.PrepareResponse
+	10, // 14: chaosagent.v1.ChaosAgent.InjectFault:output_type -> chaosagent.v1.InjectFaultResponse
+	12, // 15: chaosagent.v1.ChaosAgent.RemoveFault:output_type -> chaosagent.v1.RemoveFaultResponse
+	14, // 16: chaosagent.v1.ChaosAgent.Cleanup:output_type -> chaosagent.v1.CleanupResponse
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_chaosagent_v1_chaos_agent_proto_init() }
+func file_chaosagent_v1_chaos_agent_proto_init() {
+	if File_chaosagent_v1_chaos_agent_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chaosagent_v1_chaos_agent_proto_rawDesc), len(file_chaosagent_v1_chaos_agent_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_chaosagent_v1_chaos_agent_proto_goTypes,
+		DependencyIndexes: file_chaosagent_v1_chaos_agent_proto_depIdxs,
+		MessageInfos:      file_chaosagent_v1_chaos_agent_proto_msgTypes,
+	}.Build()
+	File_chaosagent_v1_chaos_agent_proto = out.File
+	file_chaosagent_v1_chaos_agent_proto_goTypes = nil
+	file_chaosagent_v1_chaos_agent_proto_depIdxs = nil
+}
@@ -0,0 +1,343 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: chaosagent/v1/chaos_agent.proto
+
+// Injection API served by chaos-agent on each Docker host of a multi-host
+// deployment.
+//
+// chaos-runner stays the single coordinator: it discovers targets across
+// every agent, decides what to inject when, and evaluates criteria. An
+// agent only does what needs its host's Docker daemon: listing containers,
+// creating sidecars, and installing and removing faults. A fault is carried
+// as the YAML of one spec.faults entry (pkg/scenario.Fault), with its
+// targets already resolved, so the schema has one source of truth.
+
+package chaosagentv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ChaosAgent_Ping_FullMethodName           = "/chaosagent.v1.ChaosAgent/Ping"
+	ChaosAgent_ListContainers_FullMethodName = "/chaosagent.v1.ChaosAgent/ListContainers"
+	ChaosAgent_Prepare_FullMethodName        = "/chaosagent.v1.ChaosAgent/Prepare"
+	ChaosAgent_InjectFault_FullMethodName    = "/chaosagent.v1.ChaosAgent/InjectFault"
+	ChaosAgent_RemoveFault_FullMethodName    = "/chaosagent.v1.ChaosAgent/RemoveFault"
+	ChaosAgent_Cleanup_FullMethodName        = "/chaosagent.v1.ChaosAgent/Cleanup"
+)
+
+// ChaosAgentClient is the client API for ChaosAgent service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ChaosAgentClient interface {
+	// Ping reports who the agent is and which daemon it drives.
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// ListContainers lists the running containers on the agent's host.
+	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error)
+	// Prepare creates a sidecar for each target that has none, as
+	// chaos-runner's PREPARE state does for local targets. Targets unknown
+	// to the daemon fail with NOT_FOUND.
+	Prepare(ctx context.Context, in *PrepareRequest, opts ...grpc.CallOption) (*PrepareResponse, error)
+	// InjectFault installs one fault on targets prepared earlier. An
+	// unparsable fault fails with INVALID_ARGUMENT.
+	InjectFault(ctx context.Context, in *InjectFaultRequest, opts ...grpc.CallOption) (*InjectFaultResponse, error)
+	// RemoveFault removes one fault of the given type from a container.
+	RemoveFault(ctx context.Context, in *RemoveFaultRequest, opts ...grpc.CallOption) (*RemoveFaultResponse, error)
+	// Cleanup removes every fault the agent installed and not yet removed,
+	// then destroys its sidecars. The runner calls it at the end of each
+	// run; the agent also runs it when it shuts down.
+	Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error)
+}
+
+type chaosAgentClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChaosAgentClient(cc grpc.ClientConnInterface) ChaosAgentClient {
+	return &chaosAgentClient{cc}
+}
+
+func (c *chaosAgentClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, ChaosAgent_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosAgentClient) ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListContainersResponse)
+	err := c.cc.Invoke(ctx, ChaosAgent_ListContainers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosAgentClient) Prepare(ctx context.Context, in *PrepareRequest, opts ...grpc.CallOption) (*PrepareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrepareResponse)
+	err := c.cc.Invoke(ctx, ChaosAgent_Prepare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosAgentClient) InjectFault(ctx context.Context, in *InjectFaultRequest, opts ...grpc.CallOption) (*InjectFaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InjectFaultResponse)
+	err := c.cc.Invoke(ctx, ChaosAgent_InjectFault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosAgentClient) RemoveFault(ctx context.Context, in *RemoveFaultRequest, opts ...grpc.CallOption) (*RemoveFaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveFaultResponse)
+	err := c.cc.Invoke(ctx, ChaosAgent_RemoveFault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosAgentClient) Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupResponse)
+	err := c.cc.Invoke(ctx, ChaosAgent_Cleanup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChaosAgentServer is the server API for ChaosAgent service.
+// All implementations must embed UnimplementedChaosAgentServer
+// for forward compatibility.
+type ChaosAgentServer interface {
+	// Ping reports who the agent is and which daemon it drives.
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// ListContainers lists the running containers on the agent's host.
+	ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error)
+	// Prepare creates a sidecar for each target that has none, as
+	// chaos-runner's PREPARE state does for local targets. Targets unknown
+	// to the daemon fail with NOT_FOUND.
+	Prepare(context.Context, *PrepareRequest) (*PrepareResponse, error)
+	// InjectFault installs one fault on targets prepared earlier. An
+	// unparsable fault fails with INVALID_ARGUMENT.
+	InjectFault(context.Context, *InjectFaultRequest) (*InjectFaultResponse, error)
+	// RemoveFault removes one fault of the given type from a container.
+	RemoveFault(context.Context, *RemoveFaultRequest) (*RemoveFaultResponse, error)
+	// Cleanup removes every fault the agent installed and not yet removed,
+	// then destroys its sidecars. The runner calls it at the end of each
+	// run; the agent also runs it when it shuts down.
+	Cleanup(context.Context, *CleanupRequest) (*CleanupResponse, error)
+	mustEmbedUnimplementedChaosAgentServer()
+}
+
+// UnimplementedChaosAgentServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChaosAgentServer struct{}
+
+func (UnimplementedChaosAgentServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedChaosAgentServer) ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContainers not implemented")
+}
+func (UnimplementedChaosAgentServer) Prepare(context.Context, *PrepareRequest) (*PrepareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Prepare not implemented")
+}
+func (UnimplementedChaosAgentServer) InjectFault(context.Context, *InjectFaultRequest) (*InjectFaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectFault not implemented")
+}
+func (UnimplementedChaosAgentServer) RemoveFault(context.Context, *RemoveFaultRequest) (*RemoveFaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFault not implemented")
+}
+func (UnimplementedChaosAgentServer) Cleanup(context.Context, *CleanupRequest) (*CleanupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cleanup not implemented")
+}
+func (UnimplementedChaosAgentServer) mustEmbedUnimplementedChaosAgentServer() {}
+func (UnimplementedChaosAgentServer) testEmbeddedByValue()                    {}
+
+// UnsafeChaosAgentServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChaosAgentServer will
+// result in compilation errors.
+type UnsafeChaosAgentServer interface {
+	mustEmbedUnimplementedChaosAgentServer()
+}
+
+func RegisterChaosAgentServer(s grpc.ServiceRegistrar, srv ChaosAgentServer) {
+	// If the following call pancis, it indicates UnimplementedChaosAgentServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChaosAgent_ServiceDesc, srv)
+}
+
+func _ChaosAgent_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosAgentServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChaosAgent_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosAgentServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosAgent_ListContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosAgentServer).ListContainers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChaosAgent_ListContainers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosAgentServer).ListContainers(ctx, req.(*ListContainersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosAgent_Prepare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosAgentServer).Prepare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChaosAgent_Prepare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosAgentServer).Prepare(ctx, req.(*PrepareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosAgent_InjectFault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectFaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosAgentServer).InjectFault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChaosAgent_InjectFault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosAgentServer).InjectFault(ctx, req.(*InjectFaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosAgent_RemoveFault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosAgentServer).RemoveFault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChaosAgent_RemoveFault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosAgentServer).RemoveFault(ctx, req.(*RemoveFaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosAgent_Cleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosAgentServer).Cleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChaosAgent_Cleanup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosAgentServer).Cleanup(ctx, req.(*CleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ChaosAgent_ServiceDesc is the grpc.ServiceDesc for ChaosAgent service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChaosAgent_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chaosagent.v1.ChaosAgent",
+	HandlerType: (*ChaosAgentServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _ChaosAgent_Ping_Handler,
+		},
+		{
+			MethodName: "ListContainers",
+			Handler:    _ChaosAgent_ListContainers_Handler,
+		},
+		{
+			MethodName: "Prepare",
+			Handler:    _ChaosAgent_Prepare_Handler,
+		},
+		{
+			MethodName: "InjectFault",
+			Handler:    _ChaosAgent_InjectFault_Handler,
+		},
+		{
+			MethodName: "RemoveFault",
+			Handler:    _ChaosAgent_RemoveFault_Handler,
+		},
+		{
+			MethodName: "Cleanup",
+			Handler:    _ChaosAgent_Cleanup_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "chaosagent/v1/chaos_agent.proto",
+}
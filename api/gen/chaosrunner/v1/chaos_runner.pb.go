@@ -0,0 +1,921 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: chaosrunner/v1/chaos_runner.proto
+
+// Control API for driving chaos-runner from a larger test harness.
+//
+// A test is submitted as scenario YAML (the same document `chaos-runner run`
+// accepts), progress is streamed as state transitions and criterion results,
+// and the final report is the JSON report chaos-runner already writes to
+// disk, carried as bytes so the schema has one source of truth
+// (pkg/reporting/types.go).
+
+package chaosrunnerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SubmitScenarioRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Scenario YAML or JSON, holding exactly one document.
+	ScenarioYaml []byte `protobuf:"bytes,1,opt,name=scenario_yaml,json=scenarioYaml,proto3" json:"scenario_yaml,omitempty"`
+	// ${VAR} substitutions applied before parsing (e.g. ENCLAVE_NAME).
+	Variables map[string]string `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Overrides in the same key=value form as `run --set`.
+	Overrides map[string]string `protobuf:"bytes,3,rep,name=overrides,proto3" json:"overrides,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Validate and resolve targets without injecting.
+	DryRun        bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitScenarioRequest) Reset() {
+	*x = SubmitScenarioRequest{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitScenarioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitScenarioRequest) ProtoMessage() {}
+
+func (x *SubmitScenarioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitScenarioRequest.ProtoReflect.Descriptor instead.
+func (*SubmitScenarioRequest) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitScenarioRequest) GetScenarioYaml() []byte {
+	if x != nil {
+		return x.ScenarioYaml
+	}
+	return nil
+}
+
+func (x *SubmitScenarioRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *SubmitScenarioRequest) GetOverrides() map[string]string {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+func (x *SubmitScenarioRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type SubmitScenarioResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TestId string                 `protobuf:"bytes,1,opt,name=test_id,json=testId,proto3" json:"test_id,omitempty"`
+	// Non-fatal validator findings.
+	Warnings      []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitScenarioResponse) Reset() {
+	*x = SubmitScenarioResponse{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitScenarioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitScenarioResponse) ProtoMessage() {}
+
+func (x *SubmitScenarioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitScenarioResponse.ProtoReflect.Descriptor instead.
+func (*SubmitScenarioResponse) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitScenarioResponse) GetTestId() string {
+	if x != nil {
+		return x.TestId
+	}
+	return ""
+}
+
+func (x *SubmitScenarioResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestId        string                 `protobuf:"bytes,1,opt,name=test_id,json=testId,proto3" json:"test_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamEventsRequest) GetTestId() string {
+	if x != nil {
+		return x.TestId
+	}
+	return ""
+}
+
+type Event struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TestId string                 `protobuf:"bytes,1,opt,name=test_id,json=testId,proto3" json:"test_id,omitempty"`
+	Time   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*Event_State
+	//	*Event_Criterion
+	//	*Event_Fault
+	//	*Event_Finished
+	Event         isEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Event) GetTestId() string {
+	if x != nil {
+		return x.TestId
+	}
+	return ""
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetEvent() isEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *Event) GetState() *StateTransition {
+	if x != nil {
+		if x, ok := x.Event.(*Event_State); ok {
+			return x.State
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetCriterion() *CriterionEvaluated {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Criterion); ok {
+			return x.Criterion
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetFault() *FaultInjected {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Fault); ok {
+			return x.Fault
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetFinished() *TestFinished {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Finished); ok {
+			return x.Finished
+		}
+	}
+	return nil
+}
+
+type isEvent_Event interface {
+	isEvent_Event()
+}
+
+type Event_State struct {
+	State *StateTransition `protobuf:"bytes,3,opt,name=state,proto3,oneof"`
+}
+
+type Event_Criterion struct {
+	Criterion *CriterionEvaluated `protobuf:"bytes,4,opt,name=criterion,proto3,oneof"`
+}
+
+type Event_Fault struct {
+	Fault *FaultInjected `protobuf:"bytes,5,opt,name=fault,proto3,oneof"`
+}
+
+type Event_Finished struct {
+	Finished *TestFinished `protobuf:"bytes,6,opt,name=finished,proto3,oneof"`
+}
+
+func (*Event_State) isEvent_Event() {}
+
+func (*Event_Criterion) isEvent_Event() {}
+
+func (*Event_Fault) isEvent_Event() {}
+
+func (*Event_Finished) isEvent_Event() {}
+
+type StateTransition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Orchestrator state name, e.g. "INJECT", "DETECT".
+	From          string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateTransition) Reset() {
+	*x = StateTransition{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateTransition) ProtoMessage() {}
+
+func (x *StateTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateTransition.ProtoReflect.Descriptor instead.
+func (*StateTransition) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StateTransition) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *StateTransition) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type CriterionEvaluated struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Passed   bool                   `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	Value    float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	Message  string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Critical bool                   `protobuf:"varint,5,opt,name=critical,proto3" json:"critical,omitempty"`
+	// True for samples taken by the during-fault sampler.
+	DuringFault   bool `protobuf:"varint,6,opt,name=during_fault,json=duringFault,proto3" json:"during_fault,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CriterionEvaluated) Reset() {
+	*x = CriterionEvaluated{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CriterionEvaluated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CriterionEvaluated) ProtoMessage() {}
+
+func (x *CriterionEvaluated) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CriterionEvaluated.ProtoReflect.Descriptor instead.
+func (*CriterionEvaluated) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CriterionEvaluated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CriterionEvaluated) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *CriterionEvaluated) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CriterionEvaluated) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CriterionEvaluated) GetCritical() bool {
+	if x != nil {
+		return x.Critical
+	}
+	return false
+}
+
+func (x *CriterionEvaluated) GetDuringFault() bool {
+	if x != nil {
+		return x.DuringFault
+	}
+	return false
+}
+
+type FaultInjected struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         string                 `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Target        string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,4,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FaultInjected) Reset() {
+	*x = FaultInjected{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FaultInjected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultInjected) ProtoMessage() {}
+
+func (x *FaultInjected) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultInjected.ProtoReflect.Descriptor instead.
+func (*FaultInjected) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FaultInjected) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *FaultInjected) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *FaultInjected) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *FaultInjected) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+type TestFinished struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Process exit code `chaos-runner run` would have used: 0 pass,
+	// 1 criteria failure, 2 infrastructure error or interrupted, 3 unknown.
+	ExitCode      int32 `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestFinished) Reset() {
+	*x = TestFinished{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestFinished) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestFinished) ProtoMessage() {}
+
+func (x *TestFinished) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestFinished.ProtoReflect.Descriptor instead.
+func (*TestFinished) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TestFinished) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TestFinished) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TestFinished) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+type StopTestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestId        string                 `protobuf:"bytes,1,opt,name=test_id,json=testId,proto3" json:"test_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopTestRequest) Reset() {
+	*x = StopTestRequest{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopTestRequest) ProtoMessage() {}
+
+func (x *StopTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopTestRequest.ProtoReflect.Descriptor instead.
+func (*StopTestRequest) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StopTestRequest) GetTestId() string {
+	if x != nil {
+		return x.TestId
+	}
+	return ""
+}
+
+type StopTestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopTestResponse) Reset() {
+	*x = StopTestResponse{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopTestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopTestResponse) ProtoMessage() {}
+
+func (x *StopTestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopTestResponse.ProtoReflect.Descriptor instead.
+func (*StopTestResponse) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StopTestResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type GetReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestId        string                 `protobuf:"bytes,1,opt,name=test_id,json=testId,proto3" json:"test_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportRequest) Reset() {
+	*x = GetReportRequest{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportRequest) ProtoMessage() {}
+
+func (x *GetReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportRequest.ProtoReflect.Descriptor instead.
+func (*GetReportRequest) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetReportRequest) GetTestId() string {
+	if x != nil {
+		return x.TestId
+	}
+	return ""
+}
+
+type GetReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The JSON report, byte-for-byte what SaveReport writes.
+	ReportJson    []byte `protobuf:"bytes,1,opt,name=report_json,json=reportJson,proto3" json:"report_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportResponse) Reset() {
+	*x = GetReportResponse{}
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportResponse) ProtoMessage() {}
+
+func (x *GetReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosrunner_v1_chaos_runner_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportResponse.ProtoReflect.Descriptor instead.
+func (*GetReportResponse) Descriptor() ([]byte, []int) {
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetReportResponse) GetReportJson() []byte {
+	if x != nil {
+		return x.ReportJson
+	}
+	return nil
+}
+
+var File_chaosrunner_v1_chaos_runner_proto protoreflect.FileDescriptor
+
+const file_chaosrunner_v1_chaos_runner_proto_rawDesc = "" +
+	"\n" +
+	"!chaosrunner/v1/chaos_runner.proto\x12\x0echaosrunner.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf9\x02\n" +
+	"\x15SubmitScenarioRequest\x12#\n" +
+	"\rscenario_yaml\x18\x01 \x01(\fR\fscenarioYaml\x12R\n" +
+	"\tvariables\x18\x02 \x03(\v24.chaosrunner.v1.SubmitScenarioRequest.VariablesEntryR\tvariables\x12R\n" +
+	"\toverrides\x18\x03 \x03(\v24.chaosrunner.v1.SubmitScenarioRequest.OverridesEntryR\toverrides\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eOverridesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"M\n" +
+	"\x16SubmitScenarioResponse\x12\x17\n" +
+	"\atest_id\x18\x01 \x01(\tR\x06testId\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings\".\n" +
+	"\x13StreamEventsRequest\x12\x17\n" +
+	"\atest_id\x18\x01 \x01(\tR\x06testId\"\xc9\x02\n" +
+	"\x05Event\x12\x17\n" +
+	"\atest_id\x18\x01 \x01(\tR\x06testId\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x127\n" +
+	"\x05state\x18\x03 \x01(\v2\x1f.chaosrunner.v1.StateTransitionH\x00R\x05state\x12B\n" +
+	"\tcriterion\x18\x04 \x01(\v2\".chaosrunner.v1.CriterionEvaluatedH\x00R\tcriterion\x125\n" +
+	"\x05fault\x18\x05 \x01(\v2\x1d.chaosrunner.v1.FaultInjectedH\x00R\x05fault\x12:\n" +
+	"\bfinished\x18\x06 \x01(\v2\x1c.chaosrunner.v1.TestFinishedH\x00R\bfinishedB\a\n" +
+	"\x05event\"5\n" +
+	"\x0fStateTransition\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"\xaf\x01\n" +
+	"\x12CriterionEvaluated\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06passed\x18\x02 \x01(\bR\x06passed\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1a\n" +
+	"\bcritical\x18\x05 \x01(\bR\bcritical\x12!\n" +
+	"\fduring_fault\x18\x06 \x01(\bR\vduringFault\"t\n" +
+	"\rFaultInjected\x12\x14\n" +
+	"\x05phase\x18\x01 \x01(\tR\x05phase\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12!\n" +
+	"\fcontainer_id\x18\x04 \x01(\tR\vcontainerId\"_\n" +
+	"\fTestFinished\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\texit_code\x18\x03 \x01(\x05R\bexitCode\"*\n" +
+	"\x0fStopTestRequest\x12\x17\n" +
+	"\atest_id\x18\x01 \x01(\tR\x06testId\"(\n" +
+	"\x10StopTestResponse\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\"+\n" +
+	"\x10GetReportRequest\x12\x17\n" +
+	"\atest_id\x18\x01 \x01(\tR\x06testId\"4\n" +
+	"\x11GetReportResponse\x12\x1f\n" +
+	"\vreport_json\x18\x01 \x01(\fR\n" +
+	"reportJson2\xdd\x02\n" +
+	"\vChaosRunner\x12_\n" +
+	"\x0eSubmitScenario\x12%.chaosrunner.v1.SubmitScenarioRequest\x1a&.chaosrunner.v1.SubmitScenarioResponse\x12L\n" +
+	"\fStreamEvents\x12#.chaosrunner.v1.StreamEventsRequest\x1a\x15.chaosrunner.v1.Event0\x01\x12M\n" +
+	"\bStopTest\x12\x1f.chaosrunner.v1.StopTestRequest\x1a .chaosrunner.v1.StopTestResponse\x12P\n" +
+	"\tGetReport\x12 .chaosrunner.v1.GetReportRequest\x1a!.chaosrunner.v1.GetReportResponseBGZEgithub.com/jihwankim/chaos-utils/api/gen/chaosrunner/v1;chaosrunnerv1b\x06proto3"
+
+var (
+	file_chaosrunner_v1_chaos_runner_proto_rawDescOnce sync.Once
+	file_chaosrunner_v1_chaos_runner_proto_rawDescData []byte
+)
+
+func file_chaosrunner_v1_chaos_runner_proto_rawDescGZIP() []byte {
+	file_chaosrunner_v1_chaos_runner_proto_rawDescOnce.Do(func() {
+		file_chaosrunner_v1_chaos_runner_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chaosrunner_v1_chaos_runner_proto_rawDesc), len(file_chaosrunner_v1_chaos_runner_proto_rawDesc)))
+	})
+	return file_chaosrunner_v1_chaos_runner_proto_rawDescData
+}
+
+var file_chaosrunner_v1_chaos_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_chaosrunner_v1_chaos_runner_proto_goTypes = []any{
+	(*SubmitScenarioRequest)(nil),  // 0: chaosrunner.v1.SubmitScenarioRequest
+	(*SubmitScenarioResponse)(nil), // 1: chaosrunner.v1.SubmitScenarioResponse
+	(*StreamEventsRequest)(nil),    // 2: chaosrunner.v1.StreamEventsRequest
+	(*Event)(nil),                  // 3: chaosrunner.v1.Event
+	(*StateTransition)(nil),        // 4: chaosrunner.v1.StateTransition
+	(*CriterionEvaluated)(nil),     // 5: chaosrunner.v1.CriterionEvaluated
+	(*FaultInjected)(nil),          // 6: chaosrunner.v1.FaultInjected
+	(*TestFinished)(nil),           // 7: chaosrunner.v1.TestFinished
+	(*StopTestRequest)(nil),        // 8: chaosrunner.v1.StopTestRequest
+	(*StopTestResponse)(nil),       // 9: chaosrunner.v1.StopTestResponse
+	(*GetReportRequest)(nil),       // 10: chaosrunner.v1.GetReportRequest
+	(*GetReportResponse)(nil),      // 11: chaosrunner.v1.GetReportResponse
+	nil,                            // 12: chaosrunner.v1.SubmitScenarioRequest.VariablesEntry
+	nil,                            // 13: chaosrunner.v1.SubmitScenarioRequest.OverridesEntry
+	(*timestamppb.Timestamp)(nil),  // 14: google.protobuf.Timestamp
+}
+var file_chaosrunner_v1_chaos_runner_proto_depIdxs = []int32{
+	12, // 0: chaosrunner.v1.SubmitScenarioRequest.variables:type_name -> chaosrunner.v1.SubmitScenarioRequest.VariablesEntry
+	13, // 1: chaosrunner.v1.SubmitScenarioRequest.overrides:type_name -> chaosrunner.v1.SubmitScenarioRequest.OverridesEntry
+	14, // 2: chaosrunner.v1.Event.time:type_name -> google.protobuf.Timestamp
+	4,  // 3: chaosrunner.v1.Event.state:type_name -> chaosrunner.v1.StateTransition
+	5,  // 4: chaosrunner.v1.Event.criterion:type_name -> chaosrunner.v1.CriterionEvaluated
+	6,  // 5: chaosrunner.v1.Event.fault:type_name -> chaosrunner.v1.FaultInjected
+	7,  // 6: chaosrunner.v1.Event.finished:type_name -> chaosrunner.v1.TestFinished
+	0,  // 7: chaosrunner.v1.ChaosRunner.SubmitScenario:input_type -> chaosrunner.v1.SubmitScenarioRequest
+	2,  // 8: chaosrunner.v1.ChaosRunner.StreamEvents:input_type -> chaosrunner.v1.StreamEventsRequest
+	8,  // 9: chaosrunner.v1.ChaosRunner.StopTest:input_type -> chaosrunner.v1.StopTestRequest
+	10, // 10: chaosrunner.v1.ChaosRunner.GetReport:input_type -> chaosrunner.v1.GetReportRequest
+	1,  // 11: chaosrunner.v1.ChaosRunner.SubmitScenario:output_type -> chaosrunner.v1.SubmitScenarioResponse
+	3,  // 12: chaosrunner.v1.ChaosRunner.StreamEvents:output_type -> chaosrunner.v1.Event
+	9,  // 13: chaosrunner.v1.ChaosRunner.StopTest:output_type -> chaosrunner.v1.StopTestResponse
+	11, // 14: chaosrunner.v1.ChaosRunner.GetReport:output_type -> chaosrunner.v1.GetReportResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_chaosrunner_v1_chaos_runner_proto_init() }
+func file_chaosrunner_v1_chaos_runner_proto_init() {
+	if File_chaosrunner_v1_chaos_runner_proto != nil {
+		return
+	}
+	file_chaosrunner_v1_chaos_runner_proto_msgTypes[3].OneofWrappers = []any{
+		(*Event_State)(nil),
+		(*Event_Criterion)(nil),
+		(*Event_Fault)(nil),
+		(*Event_Finished)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chaosrunner_v1_chaos_runner_proto_rawDesc), len(file_chaosrunner_v1_chaos_runner_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_chaosrunner_v1_chaos_runner_proto_goTypes,
+		DependencyIndexes: file_chaosrunner_v1_chaos_runner_proto_depIdxs,
+		MessageInfos:      file_chaosrunner_v1_chaos_runner_proto_msgTypes,
+	}.Build()
+	File_chaosrunner_v1_chaos_runner_proto = out.File
+	file_chaosrunner_v1_chaos_runner_proto_goTypes = nil
+	file_chaosrunner_v1_chaos_runner_proto_depIdxs = nil
+}
@@ -0,0 +1,271 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: chaosrunner/v1/chaos_runner.proto
+
+// Control API for driving chaos-runner from a larger test harness.
+//
+// A test is submitted as scenario YAML (the same document `chaos-runner run`
+// accepts), progress is streamed as state transitions and criterion results,
+// and the final report is the JSON report chaos-runner already writes to
+// disk, carried as bytes so the schema has one source of truth
+// (pkg/reporting/types.go).
+
+package chaosrunnerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ChaosRunner_SubmitScenario_FullMethodName = "/chaosrunner.v1.ChaosRunner/SubmitScenario"
+	ChaosRunner_StreamEvents_FullMethodName   = "/chaosrunner.v1.ChaosRunner/StreamEvents"
+	ChaosRunner_StopTest_FullMethodName       = "/chaosrunner.v1.ChaosRunner/StopTest"
+	ChaosRunner_GetReport_FullMethodName      = "/chaosrunner.v1.ChaosRunner/GetReport"
+)
+
+// ChaosRunnerClient is the client API for ChaosRunner service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ChaosRunnerClient interface {
+	// SubmitScenario validates and starts a test. Only one test runs at a
+	// time; a second submission while one is active fails with
+	// FAILED_PRECONDITION. Validation errors fail with INVALID_ARGUMENT and
+	// list every validator error in the status message.
+	SubmitScenario(ctx context.Context, in *SubmitScenarioRequest, opts ...grpc.CallOption) (*SubmitScenarioResponse, error)
+	// StreamEvents streams progress for a test until it reaches a terminal
+	// state. Events already emitted are replayed first, so subscribing late
+	// does not lose history.
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// StopTest requests an emergency stop (same as Ctrl+C / the stop file).
+	// Teardown still runs; the returned state is the one at the time of the
+	// request.
+	StopTest(ctx context.Context, in *StopTestRequest, opts ...grpc.CallOption) (*StopTestResponse, error)
+	// GetReport returns the final report once the test is terminal, or
+	// FAILED_PRECONDITION while it is still running.
+	GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error)
+}
+
+type chaosRunnerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChaosRunnerClient(cc grpc.ClientConnInterface) ChaosRunnerClient {
+	return &chaosRunnerClient{cc}
+}
+
+func (c *chaosRunnerClient) SubmitScenario(ctx context.Context, in *SubmitScenarioRequest, opts ...grpc.CallOption) (*SubmitScenarioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitScenarioResponse)
+	err := c.cc.Invoke(ctx, ChaosRunner_SubmitScenario_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosRunnerClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChaosRunner_ServiceDesc.Streams[0], ChaosRunner_StreamEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChaosRunner_StreamEventsClient = grpc.ServerStreamingClient[Event]
+
+func (c *chaosRunnerClient) StopTest(ctx context.Context, in *StopTestRequest, opts ...grpc.CallOption) (*StopTestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopTestResponse)
+	err := c.cc.Invoke(ctx, ChaosRunner_StopTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosRunnerClient) GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReportResponse)
+	err := c.cc.Invoke(ctx, ChaosRunner_GetReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChaosRunnerServer is the server API for ChaosRunner service.
+// All implementations must embed UnimplementedChaosRunnerServer
+// for forward compatibility.
+type ChaosRunnerServer interface {
+	// SubmitScenario validates and starts a test. Only one test runs at a
+	// time; a second submission while one is active fails with
+	// FAILED_PRECONDITION. Validation errors fail with INVALID_ARGUMENT and
+	// list every validator error in the status message.
+	SubmitScenario(context.Context, *SubmitScenarioRequest) (*SubmitScenarioResponse, error)
+	// StreamEvents streams progress for a test until it reaches a terminal
+	// state. Events already emitted are replayed first, so subscribing late
+	// does not lose history.
+	StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error
+	// StopTest requests an emergency stop (same as Ctrl+C / the stop file).
+	// Teardown still runs; the returned state is the one at the time of the
+	// request.
+	StopTest(context.Context, *StopTestRequest) (*StopTestResponse, error)
+	// GetReport returns the final report once the test is terminal, or
+	// FAILED_PRECONDITION while it is still running.
+	GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error)
+	mustEmbedUnimplementedChaosRunnerServer()
+}
+
+// UnimplementedChaosRunnerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChaosRunnerServer struct{}
+
+func (UnimplementedChaosRunnerServer) SubmitScenario(context.Context, *SubmitScenarioRequest) (*SubmitScenarioResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitScenario not implemented")
+}
+func (UnimplementedChaosRunnerServer) StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedChaosRunnerServer) StopTest(context.Context, *StopTestRequest) (*StopTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopTest not implemented")
+}
+func (UnimplementedChaosRunnerServer) GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReport not implemented")
+}
+func (UnimplementedChaosRunnerServer) mustEmbedUnimplementedChaosRunnerServer() {}
+func (UnimplementedChaosRunnerServer) testEmbeddedByValue()                     {}
+
+// UnsafeChaosRunnerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChaosRunnerServer will
+// result in compilation errors.
+type UnsafeChaosRunnerServer interface {
+	mustEmbedUnimplementedChaosRunnerServer()
+}
+
+func RegisterChaosRunnerServer(s grpc.ServiceRegistrar, srv ChaosRunnerServer) {
+	// If the following call pancis, it indicates UnimplementedChaosRunnerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChaosRunner_ServiceDesc, srv)
+}
+
+func _ChaosRunner_SubmitScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosRunnerServer).SubmitScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChaosRunner_SubmitScenario_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosRunnerServer).SubmitScenario(ctx, req.(*SubmitScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosRunner_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChaosRunnerServer).StreamEvents(m, &grpc.GenericServerStream[StreamEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChaosRunner_StreamEventsServer = grpc.ServerStreamingServer[Event]
+
+func _ChaosRunner_StopTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosRunnerServer).StopTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChaosRunner_StopTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosRunnerServer).StopTest(ctx, req.(*StopTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosRunner_GetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosRunnerServer).GetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChaosRunner_GetReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosRunnerServer).GetReport(ctx, req.(*GetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ChaosRunner_ServiceDesc is the grpc.ServiceDesc for ChaosRunner service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChaosRunner_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chaosrunner.v1.ChaosRunner",
+	HandlerType: (*ChaosRunnerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitScenario",
+			Handler:    _ChaosRunner_SubmitScenario_Handler,
+		},
+		{
+			MethodName: "StopTest",
+			Handler:    _ChaosRunner_StopTest_Handler,
+		},
+		{
+			MethodName: "GetReport",
+			Handler:    _ChaosRunner_GetReport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _ChaosRunner_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "chaosrunner/v1/chaos_runner.proto",
+}
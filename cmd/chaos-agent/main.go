@@ -0,0 +1,103 @@
+// chaos-agent serves the fault injection API
+// (api/proto/chaosagent/v1/chaos_agent.proto) for one Docker host, so that
+// chaos-runner can inject faults on a deployment spread over several hosts
+// without access to every daemon. Run one agent per host and list them
+// under agents: in chaos-runner's config.
+//
+// # Usage
+//
+//	CHAOS_AGENT_TOKEN=s3cret chaos-agent \
+//	  --listen        10.0.0.12:7443 \
+//	  --sidecar-image jhkimqd/chaos-utils:latest
+//
+// The agent drives the daemon DOCKER_HOST points at (the local socket by
+// default), with the same injectors and sidecars as chaos-runner. Every
+// call must carry the shared token; the listener itself is plaintext, so
+// bind it to a private network.
+//
+// On SIGINT/SIGTERM the agent removes every fault it still has installed
+// and destroys its sidecars before exiting.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	chaosagentv1 "github.com/jihwankim/chaos-utils/api/gen/chaosagent/v1"
+	"github.com/jihwankim/chaos-utils/pkg/agent"
+	"google.golang.org/grpc"
+)
+
+var version = "dev" // set by build flags
+
+// tokenEnv holds the token when --token is not given, keeping it out of
+// the process list.
+const tokenEnv = "CHAOS_AGENT_TOKEN"
+
+// cleanupTimeout bounds the cleanup on shutdown.
+const cleanupTimeout = 2 * time.Minute
+
+func main() {
+	var (
+		listenAddr   = flag.String("listen", "127.0.0.1:7443", "Address to serve the agent API on")
+		token        = flag.String("token", "", "Shared token callers must present (default $"+tokenEnv+")")
+		sidecarImage = flag.String("sidecar-image", "jhkimqd/chaos-utils:latest", "Image for the sidecars faults run in")
+	)
+	flag.Parse()
+
+	if *token == "" {
+		*token = os.Getenv(tokenEnv)
+	}
+	if *token == "" {
+		fmt.Fprintf(os.Stderr, "error: a token is required (--token or $%s)\n", tokenEnv)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	srv, err := agent.NewServer(*sidecarImage, version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	lis, err := net.Listen("tcp", *listenAddr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to listen on %s: %v\n", *listenAddr, err)
+		os.Exit(1)
+	}
+	gs := grpc.NewServer(grpc.UnaryInterceptor(agent.TokenAuth(*token)))
+	chaosagentv1.RegisterChaosAgentServer(gs, srv)
+
+	go func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+		<-sigCh
+		log.Println("[SHUTDOWN] received signal, removing faults and sidecars...")
+		gs.GracefulStop()
+	}()
+
+	log.Printf("[AGENT] %s serving on %s", version, lis.Addr())
+	serveErr := gs.Serve(lis)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	resp, err := srv.Cleanup(ctx, &chaosagentv1.CleanupRequest{})
+	if err == nil {
+		log.Printf("[SHUTDOWN] removed %d fault(s)", resp.GetFaultsRemoved())
+		for _, e := range resp.GetErrors() {
+			log.Printf("[SHUTDOWN] cleanup: %s", e)
+		}
+	}
+	if err := srv.Close(); err != nil {
+		log.Printf("[SHUTDOWN] %v", err)
+	}
+	if serveErr != nil {
+		log.Fatal(serveErr)
+	}
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+	"github.com/jihwankim/chaos-utils/pkg/reporting"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/parser"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/validator"
+	"github.com/spf13/cobra"
+)
+
+var abCmd = &cobra.Command{
+	Use:   "ab",
+	Args:  cobra.NoArgs,
+	Short: "Run one scenario against two target groups and compare their recovery",
+	Long: `Runs the same faults against two comparable target groups — e.g. a
+validator on the current Bor release and one on the candidate — and prints a
+side-by-side comparison of their restart-to-healthy latencies and criterion
+values, for release qualification.
+
+--target names the scenario target alias the groups replace; --a and --b
+are the selector patterns for each group.
+
+With --mode sequential (the default) the scenario runs twice, once per
+group, --gap apart, each run with the full scenario and its own report.
+With --mode simultaneous it runs once: the alias is split into one target
+per group, and its faults and target-scoped criteria are duplicated with
+the group name appended, so both groups share one fault window.
+
+The comparison is written to <output_dir>/ab-<timestamp>.json. Exits 1 if
+either group failed its criteria, 2 on infrastructure errors.`,
+	Example: `  # Kill validator 1 (bor vX) then validator 2 (bor vY), 3 minutes apart
+  chaos-runner ab --scenario kill.yaml --target bor \
+    --a l2-el-1-bor-heimdall-v2-validator --b l2-el-2-bor-heimdall-v2-validator \
+    --name-a vx --name-b vy
+
+  # Same fault window for both groups
+  chaos-runner ab --scenario latency.yaml --target bor --a l2-el-1-bor --b l2-el-2-bor --mode simultaneous`,
+	RunE: runAB,
+}
+
+func init() {
+	abCmd.Flags().String("scenario", "", "path to scenario YAML file")
+	abCmd.Flags().String("target", "", "scenario target alias the groups replace (default: target of the first fault)")
+	abCmd.Flags().String("a", "", "selector pattern for group A")
+	abCmd.Flags().String("b", "", "selector pattern for group B")
+	abCmd.Flags().String("name-a", "a", "name of group A in reports (lowercase, digits, underscores)")
+	abCmd.Flags().String("name-b", "b", "name of group B in reports (lowercase, digits, underscores)")
+	abCmd.Flags().String("mode", "sequential", "sequential (one run per group) or simultaneous (one run, both groups)")
+	abCmd.Flags().Duration("gap", 2*time.Minute, "recovery gap between the two sequential runs")
+	abCmd.Flags().StringArray("set", []string{}, "override scenario values (e.g., --set duration=10m)")
+	abCmd.Flags().String("enclave", "", "Kurtosis enclave name (overrides config)")
+	abCmd.Flags().String("profile", "", "deployment profile (overrides config)")
+	abCmd.Flags().String("rpc-url", "", "EVM JSON-RPC endpoint for rpc criteria (overrides config and auto-discovery)")
+	abCmd.Flags().StringArray("label", []string{}, "attach run metadata to every report (e.g., --label release=v1.2.0)")
+}
+
+// abGroupResult is one group's side of the comparison.
+type abGroupResult struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+	TestID  string `json:"test_id"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	// Recoveries are restart-to-healthy latencies in seconds, by fault
+	// phase (group suffix removed).
+	Recoveries map[string][]float64 `json:"recoveries_seconds,omitempty"`
+	// Criteria are criterion outcomes by name (group suffix removed).
+	Criteria map[string]abCriterion `json:"criteria,omitempty"`
+}
+
+type abCriterion struct {
+	Passed bool    `json:"passed"`
+	Value  float64 `json:"value"`
+}
+
+// abComparison is the persisted A/B result.
+type abComparison struct {
+	Scenario string           `json:"scenario"`
+	Target   string           `json:"target"`
+	Mode     string           `json:"mode"`
+	Start    time.Time        `json:"start"`
+	Groups   [2]abGroupResult `json:"groups"`
+}
+
+func runAB(cmd *cobra.Command, args []string) error {
+	scenarioPath, _ := cmd.Flags().GetString("scenario")
+	alias, _ := cmd.Flags().GetString("target")
+	mode, _ := cmd.Flags().GetString("mode")
+	gap, _ := cmd.Flags().GetDuration("gap")
+	setFlags, _ := cmd.Flags().GetStringArray("set")
+	enclaveName, _ := cmd.Flags().GetString("enclave")
+	profileName, _ := cmd.Flags().GetString("profile")
+	labelFlags, _ := cmd.Flags().GetStringArray("label")
+	labels, err := parseLabels(labelFlags)
+	if err != nil {
+		return err
+	}
+	var groups [2]scenario.ABGroup
+	groups[0].Pattern, _ = cmd.Flags().GetString("a")
+	groups[1].Pattern, _ = cmd.Flags().GetString("b")
+	groups[0].Name, _ = cmd.Flags().GetString("name-a")
+	groups[1].Name, _ = cmd.Flags().GetString("name-b")
+
+	if scenarioPath == "" {
+		return fmt.Errorf("--scenario flag is required")
+	}
+	if mode != "sequential" && mode != "simultaneous" {
+		return fmt.Errorf("--mode must be sequential or simultaneous, got %q", mode)
+	}
+
+	restoreStdout := func() {}
+	if quiet {
+		restoreStdout = silenceStdout()
+	}
+	defer restoreStdout()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return NewInfraError("failed to load configuration: %w", err)
+	}
+	if enclaveName != "" {
+		cfg.Kurtosis.EnclaveName = enclaveName
+	}
+	if err := resolveProfile(cfg, profileName); err != nil {
+		return NewInfraError("%w", err)
+	}
+
+	// The profile is settled first so presets render for this enclave.
+	p := parser.New(nil)
+	p.Profile = cfg.ActiveProfile()
+	base, err := p.ParseFile(scenarioPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	if len(setFlags) > 0 {
+		if err := parser.ApplyOverrides(base, parseSetFlags(setFlags)); err != nil {
+			return fmt.Errorf("failed to apply overrides: %w", err)
+		}
+	}
+	if alias == "" && len(base.Spec.Faults) > 0 {
+		alias = base.Spec.Faults[0].Target
+	}
+
+	// Build and validate every variant before injecting anything.
+	var runs []*scenario.Scenario
+	if mode == "simultaneous" {
+		split, err := scenario.ABSplit(base, alias, groups[0], groups[1])
+		if err != nil {
+			return err
+		}
+		runs = append(runs, split)
+	} else {
+		for _, g := range groups {
+			v, err := scenario.ABVariant(base, alias, g)
+			if err != nil {
+				return err
+			}
+			runs = append(runs, v)
+		}
+	}
+	for _, s := range runs {
+		if err := validator.New().Validate(s); err != nil {
+			return fmt.Errorf("scenario %s validation failed: %w", s.Metadata.Name, err)
+		}
+	}
+
+	if os.Getenv("PROMETHEUS_URL") == "" {
+		if endpoint, err := config.DiscoverPrometheusEndpoint(cfg.Kurtosis.EnclaveName); err == nil {
+			cfg.Prometheus.URL = endpoint
+		} else {
+			return NewInfraError("Prometheus auto-discovery failed: %w", err)
+		}
+	}
+	rpcURL, _ := cmd.Flags().GetString("rpc-url")
+	resolveRPCURL(cfg, rpcURL)
+
+	logger := reporting.NewLogger(reporting.LoggerConfig{
+		Level:  cliLogLevel(),
+		Format: reporting.LogFormat(cfg.Framework.LogFormat),
+		Output: logOutput(),
+	})
+	storage, err := reporting.NewStorage(cfg.Reporting.OutputDir, cfg.Reporting.KeepLastN, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+	auditSink, err := newAuditSink(cfg.Audit)
+	if err != nil {
+		return NewInfraError("%w", err)
+	}
+	if auditSink != nil {
+		defer auditSink.Close()
+	}
+
+	orch, err := orchestrator.New(cfg)
+	if err != nil {
+		return NewInfraError("failed to create orchestrator: %w", err)
+	}
+	defer func() {
+		if err := orch.Close(context.Background()); err != nil {
+			logger.Warn("Failed to clean up sidecars", "error", err)
+		}
+	}()
+	orch.SetExecTracer(execTracer(logger))
+	if auditSink != nil {
+		orch.SetAuditSink(auditSink)
+	}
+	if heimdallURL, err := config.DiscoverHeimdallEndpoint(cfg.Kurtosis.EnclaveName, cfg.ActiveProfile()); err == nil {
+		orch.SetHeimdallAPI(heimdallURL)
+	}
+
+	progress := reporting.NewProgressReporter(reporting.FormatText, logger)
+	cmp := abComparison{Scenario: base.Metadata.Name, Target: alias, Mode: mode, Start: time.Now()}
+	for i, s := range runs {
+		if i > 0 {
+			fmt.Printf("\nWaiting %s for recovery before group %s...\n", gap, groups[i].Name)
+			time.Sleep(gap)
+		}
+		if mode == "sequential" {
+			fmt.Printf("\n=== A/B group %s: %s → %s ===\n", groups[i].Name, alias, groups[i].Pattern)
+		} else {
+			fmt.Printf("\n=== A/B groups %s and %s, simultaneously ===\n", groups[0].Name, groups[1].Name)
+		}
+
+		stopProgress := followProgress(orch, progress)
+		result, execErr := orch.ExecuteNext(context.Background(), s, scenarioPath)
+		stopProgress()
+		if result == nil {
+			return NewInfraError("chaos test failed: %w", execErr)
+		}
+		report := buildReport(s, result, orch)
+		report.Labels = abLabels(labels, groups, i, mode)
+		if _, err := storage.SaveReport(report); err != nil {
+			logger.Warn("Failed to save report", "error", err)
+		}
+
+		if _, infraFailure := classifyRunError(execErr); infraFailure {
+			return NewInfraError("chaos test failed: %w", execErr)
+		}
+
+		if mode == "sequential" {
+			cmp.Groups[i] = abGroupFrom(groups[i], result, execErr, nil)
+		} else {
+			for j := range groups {
+				cmp.Groups[j] = abGroupFrom(groups[j], result, execErr, groups[:])
+			}
+		}
+	}
+
+	restoreStdout()
+	printABComparison(cmp)
+
+	path := filepath.Join(cfg.Reporting.OutputDir, "ab-"+cmp.Start.Format("20060102-150405")+".json")
+	if data, err := json.MarshalIndent(cmp, "", "  "); err == nil {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			fmt.Printf("⚠ Failed to write comparison: %v\n", err)
+		} else {
+			fmt.Printf("\nComparison written to %s\n", path)
+		}
+	}
+
+	var failed []string
+	for _, g := range cmp.Groups {
+		if !g.Success {
+			failed = append(failed, g.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("A/B group(s) %s did not meet success criteria", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// abLabels tags a run's report with its A/B group(s).
+func abLabels(labels map[string]string, groups [2]scenario.ABGroup, i int, mode string) map[string]string {
+	out := make(map[string]string, len(labels)+2)
+	for k, v := range labels {
+		out[k] = v
+	}
+	out["ab_mode"] = mode
+	if mode == "sequential" {
+		out["ab_group"] = groups[i].Name
+	} else {
+		out["ab_group"] = groups[0].Name + "," + groups[1].Name
+	}
+	return out
+}
+
+// abGroupFrom extracts g's side from a run. With split (simultaneous mode)
+// only the phases and criteria carrying g's suffix are taken, with the
+// suffix removed; criteria carrying no group's suffix are shared.
+func abGroupFrom(g scenario.ABGroup, result *orchestrator.TestResult, execErr error, split []scenario.ABGroup) abGroupResult {
+	gr := abGroupResult{
+		Name:       g.Name,
+		Pattern:    g.Pattern,
+		TestID:     result.TestID,
+		Success:    execErr == nil && result.Success,
+		Message:    result.Message,
+		Recoveries: map[string][]float64{},
+		Criteria:   map[string]abCriterion{},
+	}
+	if execErr != nil {
+		gr.Message = execErr.Error()
+	}
+
+	// own reports whether name belongs to g, and returns it unsuffixed.
+	own := func(name string, shared bool) (string, bool) {
+		if split == nil {
+			return name, true
+		}
+		if base, ok := strings.CutSuffix(name, scenario.ABSuffix(g)); ok {
+			return base, true
+		}
+		for _, other := range split {
+			if strings.HasSuffix(name, scenario.ABSuffix(other)) {
+				return "", false
+			}
+		}
+		return name, shared
+	}
+
+	for phase, recs := range result.Recoveries {
+		if base, ok := own(phase, false); ok {
+			for _, r := range recs {
+				gr.Recoveries[base] = append(gr.Recoveries[base], r.Latency.Seconds())
+			}
+		}
+	}
+	for _, c := range result.CriteriaResults {
+		if base, ok := own(c.Name, true); ok {
+			gr.Criteria[base] = abCriterion{Passed: c.Passed, Value: c.Value}
+		}
+	}
+	if split != nil {
+		// One run: a group passes when all of its own criteria did.
+		gr.Success = true
+		for _, c := range gr.Criteria {
+			gr.Success = gr.Success && c.Passed
+		}
+		if execErr != nil && len(gr.Criteria) == 0 {
+			gr.Success = false
+		}
+	}
+	return gr
+}
+
+// printABComparison prints the side-by-side table.
+func printABComparison(cmp abComparison) {
+	a, b := cmp.Groups[0], cmp.Groups[1]
+	fmt.Printf("\nA/B comparison: %s (%s, target %s)\n", cmp.Scenario, cmp.Mode, cmp.Target)
+	fmt.Printf("  %-40s %18s %18s %12s\n", "", a.Name, b.Name, "B − A")
+	fmt.Printf("  %-40s %18s %18s\n", "outcome", passFail(a.Success), passFail(b.Success))
+
+	phases := unionKeys(a.Recoveries, b.Recoveries)
+	for _, p := range phases {
+		ma, okA := mean(a.Recoveries[p])
+		mb, okB := mean(b.Recoveries[p])
+		fmt.Printf("  %-40s %18s %18s %12s\n", "restart to healthy: "+p, seconds(ma, okA), seconds(mb, okB), delta(ma, mb, okA && okB))
+	}
+
+	for _, name := range unionKeys(a.Criteria, b.Criteria) {
+		ca, okA := a.Criteria[name]
+		cb, okB := b.Criteria[name]
+		fmt.Printf("  %-40s %18s %18s %12s\n", name, criterion(ca, okA), criterion(cb, okB), delta(ca.Value, cb.Value, okA && okB))
+	}
+}
+
+func passFail(ok bool) string {
+	if ok {
+		return "PASS"
+	}
+	return "FAIL"
+}
+
+func criterion(c abCriterion, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	mark := "✓"
+	if !c.Passed {
+		mark = "✗"
+	}
+	return fmt.Sprintf("%s %.4g", mark, c.Value)
+}
+
+func seconds(v float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.1fs", v)
+}
+
+func delta(a, b float64, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%+.4g", b-a)
+}
+
+func mean(vs []float64) (float64, bool) {
+	if len(vs) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, v := range vs {
+		sum += v
+	}
+	return sum / float64(len(vs)), true
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario/builtin"
+	"github.com/spf13/cobra"
+)
+
+var builtinCmd = &cobra.Command{
+	Use:   "builtin",
+	Short: "Inspect the built-in scenario library",
+	Long: `The binary ships a curated set of ready-made Polygon PoS scenarios.
+Run one with "chaos-runner run --builtin <name>"; "--set" and "--enclave"
+apply as they do to scenario files.`,
+}
+
+var builtinListCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.NoArgs,
+	Short: "List the built-in scenarios",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templates, err := builtin.List()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tTAGS\tDESCRIPTION")
+		for _, t := range templates {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name, strings.Join(t.Tags, ","), firstSentence(t.Description))
+		}
+		return w.Flush()
+	},
+}
+
+var builtinShowCmd = &cobra.Command{
+	Use:     "show <name>",
+	Args:    cobra.ExactArgs(1),
+	Short:   "Print a built-in scenario's YAML",
+	Example: `  chaos-runner builtin show checkpoint-stall > my-checkpoint-stall.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := builtin.Get(args[0])
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	},
+}
+
+func init() {
+	builtinCmd.AddCommand(builtinListCmd)
+	builtinCmd.AddCommand(builtinShowCmd)
+}
+
+// firstSentence shortens a multi-line description to a table cell.
+func firstSentence(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if i := strings.Index(s, ". "); i >= 0 {
+		return s[:i+1]
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/parser"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/validator"
+	"github.com/spf13/cobra"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Args:  cobra.NoArgs,
+	Short: "Check a scenario's compatibility with the live enclave",
+	Long: `Validates a scenario, then checks it against the running enclave without
+injecting anything: target selectors resolve, criterion queries and metrics
+exist in Prometheus, endpoints the faults call respond, and each fault is
+supported by its target containers and the Docker daemon.
+
+Exits 0 when every check passes or only warns, 1 when any check fails.`,
+	Example: `  chaos-runner check --scenario scenarios/polygon-chain/network/validator-partition.yaml
+  chaos-runner check --scenario x.yaml --enclave my-enclave --format json`,
+	RunE: runCheck,
+}
+
+func init() {
+	checkCmd.Flags().String("scenario", "", "path to scenario YAML or JSON file (- reads stdin)")
+	checkCmd.Flags().StringArray("values", []string{}, "YAML file of variables for ${...} substitution; repeatable, later files win")
+	checkCmd.Flags().String("enclave", "", "Kurtosis enclave name (overrides config)")
+	checkCmd.Flags().String("profile", "", "deployment profile (overrides config)")
+	checkCmd.Flags().String("rpc-url", "", "EVM JSON-RPC endpoint for rpc criteria (overrides config and auto-discovery)")
+	checkCmd.Flags().String("format", "text", "output format (text, json)")
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	scenarioPath, _ := cmd.Flags().GetString("scenario")
+	if scenarioPath == "" {
+		return fmt.Errorf("--scenario flag is required")
+	}
+	enclaveName, _ := cmd.Flags().GetString("enclave")
+	profileName, _ := cmd.Flags().GetString("profile")
+	outputFormat, _ := cmd.Flags().GetString("format")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return NewInfraError("failed to load configuration: %w", err)
+	}
+	if enclaveName != "" {
+		cfg.Kurtosis.EnclaveName = enclaveName
+	}
+	if err := resolveProfile(cfg, profileName); err != nil {
+		return NewInfraError("%w", err)
+	}
+	if os.Getenv("PROMETHEUS_URL") == "" {
+		if endpoint, err := config.DiscoverPrometheusEndpoint(cfg.Kurtosis.EnclaveName); err == nil {
+			cfg.Prometheus.URL = endpoint
+		} else {
+			return NewInfraError("Prometheus auto-discovery failed: %w", err)
+		}
+	}
+
+	valuesFiles, _ := cmd.Flags().GetStringArray("values")
+	values, err := parser.LoadValues(valuesFiles...)
+	if err != nil {
+		return err
+	}
+	p := parser.New(values)
+	p.Profile = cfg.ActiveProfile()
+	scen, err := p.ParseFile(scenarioPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	if err := validator.New().Validate(scen); err != nil {
+		return fmt.Errorf("scenario validation failed: %w", err)
+	}
+
+	rpcURL, _ := cmd.Flags().GetString("rpc-url")
+	resolveRPCURL(cfg, rpcURL)
+
+	orch, err := orchestrator.New(cfg)
+	if err != nil {
+		return NewInfraError("failed to create orchestrator: %w", err)
+	}
+	if heimdallURL, err := config.DiscoverHeimdallEndpoint(cfg.Kurtosis.EnclaveName, cfg.ActiveProfile()); err == nil {
+		orch.SetHeimdallAPI(heimdallURL)
+	}
+
+	report := orch.CheckCompatibility(context.Background(), scen)
+
+	if outputFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return NewInfraError("failed to encode report: %w", err)
+		}
+	} else {
+		printCompatibility(scen.Metadata.Name, report)
+	}
+
+	if report.Worst() == orchestrator.CompatFail {
+		return fmt.Errorf("scenario %s is not compatible with enclave %s", scen.Metadata.Name, cfg.Kurtosis.EnclaveName)
+	}
+	return nil
+}
+
+// printCompatibility renders the report as a matrix grouped by category.
+func printCompatibility(name string, report *orchestrator.CompatibilityReport) {
+	fmt.Printf("Compatibility: %s\n\n", name)
+
+	symbols := map[orchestrator.CompatStatus]string{
+		orchestrator.CompatPass: "✓ pass",
+		orchestrator.CompatWarn: "⚠ warn",
+		orchestrator.CompatFail: "✗ fail",
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CATEGORY\tSUBJECT\tSTATUS\tDETAIL")
+	for _, c := range report.Checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Category, c.Subject, symbols[c.Status], c.Detail)
+	}
+	w.Flush()
+
+	counts := map[orchestrator.CompatStatus]int{}
+	for _, c := range report.Checks {
+		counts[c.Status]++
+	}
+	fmt.Printf("\n%s: %d pass, %d warn, %d fail\n",
+		strings.ToUpper(string(report.Worst())), counts[orchestrator.CompatPass], counts[orchestrator.CompatWarn], counts[orchestrator.CompatFail])
+}
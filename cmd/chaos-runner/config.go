@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Create, inspect and validate the runner configuration",
+	Long: `Manage the config file (--config, default ./config.yaml). Settings not in
+the file keep their defaults; see pkg/config/config.go for the schema.`,
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Args:  cobra.NoArgs,
+	Short: "Write a commented default configuration",
+	Example: `  chaos-runner config init
+  chaos-runner config init --config ci.yaml --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := configPath()
+		force, _ := cmd.Flags().GetBool("force")
+		if _, err := os.Stat(path); err == nil && !force {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+		if err := os.WriteFile(path, config.DefaultYAML(), 0644); err != nil {
+			return fmt.Errorf("failed to write config file: %w", err)
+		}
+		fmt.Printf("✓ Wrote default configuration to %s\n", path)
+		return nil
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Args:  cobra.NoArgs,
+	Short: "Print the configuration",
+	Long: `Prints the config file merged over the defaults.
+
+With --effective, also applies what "run" would: the PROMETHEUS_URL env var
+or Prometheus auto-discovery, profile detection, EVM RPC discovery, and the
+--enclave and --profile flags given here. Each setting is annotated with where its value
+came from.`,
+	Example: `  chaos-runner config show
+  chaos-runner config show --effective --enclave my-enclave`,
+	RunE: runConfigShow,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Args:  cobra.NoArgs,
+	Short: "Validate the config file",
+	Long: `Checks the config file for unknown keys, which "run" would silently
+ignore, and for invalid values. Exits 1 on the first problem found.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := configPath()
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return fmt.Errorf("%s not found (create one with `chaos-runner config init`)", path)
+		}
+		cfg, err := config.LoadStrict(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Printf("✓ %s is valid\n", path)
+		return nil
+	},
+}
+
+func init() {
+	configInitCmd.Flags().Bool("force", false, "overwrite an existing config file")
+	configShowCmd.Flags().Bool("effective", false, "apply env, flags and discovery, and show each setting's source")
+	configShowCmd.Flags().String("enclave", "", "Kurtosis enclave name (overrides config)")
+	configShowCmd.Flags().String("profile", "", "deployment profile (overrides config)")
+
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	path := configPath()
+	cfg, err := config.Load(path)
+	if err != nil {
+		return fmt.Errorf("failed to load config from %s: %w", path, err)
+	}
+
+	if cfg.RPC.Password != "" {
+		cfg.RPC.Password = "<redacted>"
+	}
+
+	effective, _ := cmd.Flags().GetBool("effective")
+	if !effective {
+		data, err := yaml.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	settings, err := config.FileSettings(path)
+	if err != nil {
+		return err
+	}
+	sources := make(map[string]string, len(settings))
+	for _, s := range settings {
+		sources[s] = path
+	}
+
+	if enclave, _ := cmd.Flags().GetString("enclave"); enclave != "" {
+		cfg.Kurtosis.EnclaveName = enclave
+		sources["kurtosis.enclave_name"] = "flag --enclave"
+	}
+
+	// Mirror resolveProfile without its progress output.
+	if profile, _ := cmd.Flags().GetString("profile"); profile != "" {
+		cfg.Kurtosis.Profile = profile
+		sources["kurtosis.profile"] = "flag --profile"
+	}
+	if cfg.Kurtosis.Profile == "" || cfg.Kurtosis.Profile == config.ProfileAuto {
+		if p, err := config.DetectProfile(cfg.Kurtosis.EnclaveName); err == nil {
+			cfg.Kurtosis.Profile = p.Name
+			sources["kurtosis.profile"] = "detected from enclave"
+		} else {
+			src, ok := sources["kurtosis.profile"]
+			if !ok {
+				src = config.SourceDefault
+			}
+			sources["kurtosis.profile"] = fmt.Sprintf("%s; detection failed, run uses %s", src, config.DefaultProfile().Name)
+		}
+	}
+
+	// Mirror run: the env var wins, otherwise the URL is discovered.
+	if os.Getenv("PROMETHEUS_URL") != "" {
+		sources["prometheus.url"] = "env PROMETHEUS_URL"
+	} else if endpoint, err := config.DiscoverPrometheusEndpoint(cfg.Kurtosis.EnclaveName); err == nil {
+		cfg.Prometheus.URL = endpoint
+		sources["prometheus.url"] = "discovered from enclave"
+	} else {
+		sources["prometheus.url"] = "not discoverable, run would abort"
+	}
+
+	if cfg.RPC.URL == "" {
+		if endpoints, err := config.DiscoverEVMRPCEndpoints(cfg.Kurtosis.EnclaveName, cfg.ActiveProfile()); err == nil {
+			cfg.RPC.URL = endpoints[0]
+			sources["rpc.url"] = "discovered from enclave"
+			if len(cfg.RPC.Fallbacks) == 0 && len(endpoints) > 1 {
+				cfg.RPC.Fallbacks = endpoints[1:]
+				sources["rpc.fallbacks"] = "discovered from enclave"
+			}
+		}
+	}
+
+	data, err := cfg.Annotated(sources)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
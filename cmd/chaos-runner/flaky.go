@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jihwankim/chaos-utils/pkg/reporting"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/parser"
+	"github.com/spf13/cobra"
+)
+
+var flakyCmd = &cobra.Command{
+	Use:   "flaky",
+	Args:  cobra.NoArgs,
+	Short: "Find success criteria that alternate between pass and fail across runs",
+	Long: `Reads the saved reports of the last N runs of one scenario and lists
+the success criteria that both passed and failed among them. A criterion
+that flips on identical runs is more likely a noisy check than a protocol
+regression, which would fail from some run onwards.
+
+--scenario is a scenario name (metadata.name) or a scenario file.
+Interrupted runs and runs that failed before evaluating any criterion are
+skipped. --label narrows the runs to those
+carrying every given label, e.g. the same release.
+
+Each criterion's outcomes are printed oldest first: P passed, F failed,
+? unknown, - not evaluated. Exits 1 when any criterion is flaky.`,
+	Example: `  chaos-runner flaky --scenario validator-partition
+  chaos-runner flaky --scenario scenarios/polygon-chain/network/latency.yaml --last 20
+  chaos-runner flaky --scenario validator-partition --label release=v1.2.0 --format json`,
+	RunE: runFlaky,
+}
+
+func init() {
+	flakyCmd.Flags().String("scenario", "", "scenario name or scenario file")
+	flakyCmd.Flags().Int("last", 10, "number of most recent runs to compare")
+	flakyCmd.Flags().StringArray("label", []string{}, "only compare runs carrying this label (e.g., --label release=v1.2.0)")
+	flakyCmd.Flags().String("dir", "", "directory holding the reports (default: reporting.output_dir)")
+	flakyCmd.Flags().String("format", "text", "output format (text, json)")
+}
+
+func runFlaky(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("scenario")
+	last, _ := cmd.Flags().GetInt("last")
+	dir, _ := cmd.Flags().GetString("dir")
+	format, _ := cmd.Flags().GetString("format")
+	labelFlags, _ := cmd.Flags().GetStringArray("label")
+	labels, err := parseLabels(labelFlags)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		return fmt.Errorf("--scenario flag is required")
+	}
+	if last < 2 {
+		return fmt.Errorf("--last must be at least 2, got %d", last)
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("--format must be text or json, got %q", format)
+	}
+	if info, err := os.Stat(name); err == nil && !info.IsDir() {
+		s, err := parser.New(nil).ParseFile(name)
+		if err != nil {
+			return fmt.Errorf("failed to parse scenario: %w", err)
+		}
+		name = s.Metadata.Name
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return NewInfraError("failed to load configuration: %w", err)
+	}
+	if dir == "" {
+		dir = cfg.Reporting.OutputDir
+	}
+	logger := reporting.NewLogger(reporting.LoggerConfig{
+		Level:  cliLogLevel(),
+		Format: reporting.LogFormat(cfg.Framework.LogFormat),
+		Output: logOutput(),
+	})
+	storage, err := reporting.NewStorage(dir, cfg.Reporting.KeepLastN, logger)
+	if err != nil {
+		return NewInfraError("failed to create storage: %w", err)
+	}
+
+	summaries, err := storage.ListReports()
+	if err != nil {
+		return NewInfraError("%w", err)
+	}
+	var reports []*reporting.TestReport
+	for _, s := range summaries {
+		if len(reports) == last {
+			break
+		}
+		if s.ScenarioName != name || !s.MatchesLabels(labels) {
+			continue
+		}
+		if s.Status != reporting.StatusCompleted && s.Status != reporting.StatusFailed {
+			continue
+		}
+		r, err := storage.LoadReport(s.Filepath)
+		if err != nil {
+			return NewInfraError("%w", err)
+		}
+		if len(r.SuccessCriteria) == 0 {
+			continue
+		}
+		reports = append(reports, r)
+	}
+	if len(reports) < 2 {
+		return NewInfraError("found %d run(s) of %q in %s; at least 2 are needed", len(reports), name, dir)
+	}
+
+	fr := reporting.AnalyzeFlakes(name, reports)
+	if format == "json" {
+		data, err := json.MarshalIndent(fr, "", "  ")
+		if err != nil {
+			return NewInfraError("failed to marshal flake report: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printFlakeReport(fr)
+	}
+
+	if n := len(fr.Flaky()); n > 0 {
+		return fmt.Errorf("%d of %d criteria flaky across %d runs", n, len(fr.Criteria), len(fr.Runs))
+	}
+	return nil
+}
+
+// printFlakeReport prints one line per criterion, flaky ones first.
+func printFlakeReport(fr *reporting.FlakeReport) {
+	fmt.Printf("=== %s: %d runs (%s … %s) ===\n", fr.Scenario, len(fr.Runs), fr.Runs[0], fr.Runs[len(fr.Runs)-1])
+	for _, c := range fr.Criteria {
+		mark := "✓"
+		if c.Flaky {
+			mark = "⚠"
+		}
+		critical := ""
+		if c.Critical {
+			critical = " [critical]"
+		}
+		fmt.Printf("  %s %-40s %s  passed %d, failed %d, flips %d%s\n", mark, c.Name, c.Outcomes, c.Passed, c.Failed, c.Flips, critical)
+	}
+	if n := len(fr.Flaky()); n > 0 {
+		fmt.Printf("⚠ %d flaky criteria\n", n)
+	} else {
+		fmt.Println("✓ No flaky criteria")
+	}
+}
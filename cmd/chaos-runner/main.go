@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
 	"github.com/spf13/cobra"
 )
 
@@ -23,11 +24,25 @@ func NewInfraError(format string, a ...interface{}) *InfraError {
 	return &InfraError{Err: fmt.Errorf(format, a...)}
 }
 
+// UnknownOutcomeError marks a run whose critical criteria could not be
+// judged under treat_unknown_as: unknown. It exits with code 3.
+type UnknownOutcomeError struct {
+	Msg string
+}
+
+func (e *UnknownOutcomeError) Error() string { return e.Msg }
+
 var (
 	// Global flags
 	cfgFile string
-	verbose bool
+	verbose int // -v debug, -vv trace (every docker exec)
+	quiet   bool
+	noEmoji bool
 	version = "dev" // Will be set by build flags
+
+	// restoreOutput undoes --no-emoji's stdout/stderr redirection and
+	// flushes it; main calls it before exiting.
+	restoreOutput = func() {}
 )
 
 var rootCmd = &cobra.Command{
@@ -39,29 +54,69 @@ orchestration, integrated observability, and emergency stop mechanisms.`,
 	Version:       version,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if noEmoji || os.Getenv("CHAOS_NO_EMOJI") != "" {
+			restoreOutput = plainOutput()
+		}
+	},
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ./config.yaml)")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().CountVarP(&verbose, "verbose", "v", "verbose output (-v debug, -vv also logs every docker exec)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "print only errors and the final summary (for CI)")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "plain ASCII output, for terminals and CI logs that garble emoji (or set CHAOS_NO_EMOJI)")
 
 	// Add subcommands
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(soakCmd)
+	rootCmd.AddCommand(abCmd)
+	rootCmd.AddCommand(builtinCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(recoverCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(flakyCmd)
 }
 
 // Commands are defined in separate files:
 // - runCmd in run.go
+// - checkCmd in check.go
+// - soakCmd in soak.go
+// - builtinCmd in builtin.go
+// - configCmd in config.go
+// - recoverCmd in recover.go
+// - serveCmd in serve.go
+// - flakyCmd in flaky.go
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		// Exit code 2 for infrastructure errors (config, connectivity, setup failures).
-		// Exit code 1 for test criteria failures (scenario ran but didn't meet thresholds).
-		// The CI workflow uses this distinction to separate infra breakage from expected test findings.
-		var infraErr *InfraError
-		if errors.As(err, &infraErr) {
-			os.Exit(2)
-		}
-		os.Exit(1)
 	}
+	restoreOutput()
+	if err != nil {
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode maps a command error to the process exit code.
+// Exit code 2 for infrastructure errors (config, connectivity, setup failures)
+// and interrupted runs.
+// Exit code 1 for test criteria failures (scenario ran but didn't meet thresholds).
+// Exit code 3 when critical criteria could not be judged (treat_unknown_as: unknown).
+// The CI workflow uses this distinction to separate infra breakage from expected test findings.
+func exitCode(err error) int {
+	var infraErr *InfraError
+	var interruptedErr *orchestrator.InterruptedError
+	if errors.As(err, &infraErr) || errors.As(err, &interruptedErr) {
+		return 2
+	}
+	var unknownErr *UnknownOutcomeError
+	if errors.As(err, &unknownErr) {
+		return 3
+	}
+	return 1
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+	"github.com/jihwankim/chaos-utils/pkg/reporting"
+)
+
+// progressBuffer is how many events may queue for rendering before the
+// bus drops them.
+const progressBuffer = 256
+
+// followProgress subscribes to orch's progress events and renders them
+// through pr until the returned stop func is called. stop waits for every
+// queued event to be rendered.
+func followProgress(orch *orchestrator.Orchestrator, pr *reporting.ProgressReporter) (stop func()) {
+	bus := orchestrator.NewEventBus()
+	events := bus.Subscribe(progressBuffer)
+	orch.SetObserver(bus)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ev := range events {
+			pr.ReportEvent(progressEvent(ev))
+		}
+	}()
+	return func() {
+		orch.SetObserver(nil)
+		bus.Close()
+		<-done
+	}
+}
+
+// progressEvent converts an orchestrator event for the progress reporter.
+func progressEvent(ev orchestrator.Event) reporting.ProgressEvent {
+	out := reporting.ProgressEvent{Event: string(ev.Kind), Timestamp: ev.Time}
+	switch ev.Kind {
+	case orchestrator.EventStateChanged:
+		out.From, out.To = ev.From.String(), ev.To.String()
+	case orchestrator.EventFaultInjected:
+		out.Phase, out.FaultType = ev.Phase, ev.FaultType
+		out.Target, out.ContainerID = ev.Target.Name, ev.Target.ContainerID
+	case orchestrator.EventCriterionEvaluated:
+		c := ev.Criterion
+		out.Criterion, out.Passed, out.Unknown, out.Critical = c.Name, c.Passed, c.Unknown, c.Critical
+		out.Value, out.Message, out.DuringFault = c.Value, c.Message, ev.DuringFault
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+	"github.com/spf13/cobra"
+)
+
+var recoverCmd = &cobra.Command{
+	Use:   "recover",
+	Args:  cobra.NoArgs,
+	Short: "Clean up faults and sidecars left behind by a crashed run",
+	Long: `Every run mirrors the sidecars it creates and the faults it injects to
+<output_dir>/<test-id>/state.json, and deletes the file once teardown has
+removed them. A file that is still there after the run is over means the
+runner crashed or was killed before it could clean up.
+
+recover reads each such file, removes the recorded faults in reverse order
+(creating a sidecar where one is needed), destroys the sidecars, and deletes
+the file. Whatever cannot be removed is written back for another attempt.
+Some faults cannot be removed without the crashed runner's memory: the
+original limits behind cpu_stress/memory_stress method "limit", and
+stress-ng whose sidecar is gone. Those are reported as not recoverable
+and kept in the file for manual cleanup.
+Files whose runner process is still alive are skipped unless --force is set.
+To finish the crashed run instead of only cleaning up after it, use
+run --resume <test-id>.
+
+Exits 0 when everything was cleaned, 2 when anything remains.`,
+	Example: `  chaos-runner recover
+  chaos-runner recover --dry-run
+  chaos-runner recover --dir ./reports --force`,
+	RunE: runRecover,
+}
+
+func init() {
+	recoverCmd.Flags().String("dir", "", "directory holding run state (default: reporting.output_dir)")
+	recoverCmd.Flags().Bool("force", false, "also clean up runs whose runner process is still alive")
+	recoverCmd.Flags().Bool("dry-run", false, "list orphaned state without cleaning anything")
+}
+
+func runRecover(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	force, _ := cmd.Flags().GetBool("force")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return NewInfraError("failed to load configuration: %w", err)
+	}
+	if dir == "" {
+		dir = cfg.Reporting.OutputDir
+	}
+
+	results, err := orchestrator.Recover(context.Background(), cfg, dir, force, dryRun)
+	if err != nil {
+		return NewInfraError("%w", err)
+	}
+	if len(results) == 0 {
+		fmt.Printf("✓ No orphaned run state in %s\n", dir)
+		return nil
+	}
+
+	unresolved := 0
+	for _, r := range results {
+		name := r.TestID
+		if r.Scenario != "" {
+			name += " (" + r.Scenario + ")"
+		}
+		switch {
+		case r.Skipped != "":
+			fmt.Printf("⊘ %s: %d fault(s), %d sidecar(s) — skipped: %s\n",
+				name, len(r.Remaining.Faults), len(r.Remaining.Sidecars), r.Skipped)
+			for _, f := range r.Remaining.Faults {
+				fmt.Printf("    fault %s (%s) on %s\n", f.Phase, f.Type, f.ContainerID)
+			}
+		case len(r.Errors) > 0:
+			unresolved++
+			fmt.Printf("✗ %s: removed %d fault(s) and %d sidecar(s); %d fault(s) and %d sidecar(s) remain in %s\n",
+				name, r.FaultsRemoved, r.SidecarsRemoved, len(r.Remaining.Faults), len(r.Remaining.Sidecars), r.Path)
+			for _, e := range r.Errors {
+				fmt.Printf("    %v\n", e)
+			}
+		default:
+			fmt.Printf("✓ %s: removed %d fault(s) and %d sidecar(s)\n", name, r.FaultsRemoved, r.SidecarsRemoved)
+		}
+	}
+
+	if unresolved > 0 {
+		return NewInfraError("%d run(s) could not be fully recovered", unresolved)
+	}
+	return nil
+}
@@ -2,15 +2,22 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/jihwankim/chaos-utils/pkg/approval"
 	"github.com/jihwankim/chaos-utils/pkg/config"
 	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+	"github.com/jihwankim/chaos-utils/pkg/gameday"
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
+	"github.com/jihwankim/chaos-utils/pkg/notify"
 	"github.com/jihwankim/chaos-utils/pkg/reporting"
 	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/builtin"
 	"github.com/jihwankim/chaos-utils/pkg/scenario/parser"
 	"github.com/jihwankim/chaos-utils/pkg/scenario/validator"
 	"github.com/spf13/cobra"
@@ -30,29 +37,85 @@ var runCmd = &cobra.Command{
   # Override scenario duration and warmup
   chaos-runner run --scenario scenarios/polygon-chain/cpu-memory/cpu-stress.yaml --set duration=5m --set warmup=30s
 
+  # Keep enclave specifics in a values file, referenced as ${targets.rpc.pattern}
+  chaos-runner run --scenario scenarios/polygon-chain/network/validator-partition.yaml --values devnet.yaml
+
   # Validate a scenario without executing
-  chaos-runner run --scenario scenarios/polygon-chain/applications/bor-heimdall-link-isolation.yaml --dry-run`,
+  chaos-runner run --scenario scenarios/polygon-chain/applications/bor-heimdall-link-isolation.yaml --dry-run
+
+  # Run a scenario from the built-in library (see: chaos-runner builtin list)
+  chaos-runner run --builtin validator-isolation --enclave my-enclave
+
+  # Run the scenarios of a ChaosSuite manifest with one combined report
+  chaos-runner run --suite nightly-suite.yaml --enclave my-enclave
+
+  # Pick up a run whose runner crashed, with the same scenario and overrides
+  chaos-runner run --scenario scenarios/polygon-chain/network/validator-partition.yaml --resume test-1745462606`,
 	RunE: runChaosTest,
 }
 
 func init() {
-	runCmd.Flags().String("scenario", "", "path to scenario YAML file")
+	runCmd.Flags().String("scenario", "", "path to scenario YAML or JSON file (- reads stdin)")
+	runCmd.Flags().String("builtin", "", "run a scenario from the built-in library instead of a file (see: chaos-runner builtin list)")
+	runCmd.Flags().String("suite", "", "run every scenario listed in a ChaosSuite manifest, in order")
+	runCmd.MarkFlagsMutuallyExclusive("scenario", "builtin", "suite")
 	runCmd.Flags().StringArray("set", []string{}, "override scenario values (e.g., --set duration=10m)")
+	runCmd.Flags().StringArray("values", []string{}, "YAML file of variables for ${...} substitution; repeatable, later files win")
+	runCmd.Flags().StringArray("label", []string{}, "attach run metadata to the report (e.g., --label release=v1.2.0 --label ticket=POS-123)")
 	runCmd.Flags().String("enclave", "", "Kurtosis enclave name (overrides config)")
-	runCmd.Flags().String("format", "text", "output format (text, json, tui)")
+	runCmd.Flags().String("profile", "", "deployment profile: auto, pos-heimdall-v2, pos-heimdall-v1, cdk-erigon (overrides config)")
+	runCmd.Flags().String("rpc-url", "", "EVM JSON-RPC endpoint for rpc criteria (overrides config and auto-discovery)")
+	runCmd.Flags().String("format", "text", "output format (text, json, tui, json-status)")
 	runCmd.Flags().Bool("dry-run", false, "validate scenario without executing")
+	runCmd.Flags().Bool("gameday", false, "pause at GameDay gates for operator approval (see gameday in config)")
+	runCmd.Flags().String("resume", "", "continue the crashed run with this test ID from its checkpoint (same scenario and --set overrides)")
+	runCmd.Flags().Bool("verify-enclave", false, "after teardown, scan every enclave container for chaos artifacts (see execution.verify_enclave_cleanup)")
 }
 
-func runChaosTest(cmd *cobra.Command, args []string) error {
+func runChaosTest(cmd *cobra.Command, args []string) (err error) {
+	outputFormat, _ := cmd.Flags().GetString("format")
+	jsonStatus := reporting.OutputFormat(outputFormat) == reporting.FormatJSONStatus
+
+	// -q and --format json-status both send progress output to /dev/null;
+	// errors still reach stderr through main. Quiet brings stdout back for
+	// the final summary, json-status only for its single status line.
+	restoreStdout := func() {}
+	if quiet || jsonStatus {
+		restoreStdout = silenceStdout()
+	}
+	defer restoreStdout()
+	showSummary := func() {
+		if !jsonStatus {
+			restoreStdout()
+		}
+	}
+	status := &runStatus{}
+	if jsonStatus {
+		defer func() {
+			restoreStdout()
+			status.print(err)
+		}()
+	}
+
 	// Get flags
 	scenarioPath, _ := cmd.Flags().GetString("scenario")
-	if scenarioPath == "" {
-		return fmt.Errorf("--scenario flag is required")
+	builtinName, _ := cmd.Flags().GetString("builtin")
+	suitePath, _ := cmd.Flags().GetString("suite")
+	if scenarioPath == "" && builtinName == "" && suitePath == "" {
+		return fmt.Errorf("--scenario, --builtin or --suite flag is required")
+	}
+	resumeID, _ := cmd.Flags().GetString("resume")
+	if resumeID != "" && suitePath != "" {
+		return fmt.Errorf("--resume cannot be combined with --suite")
 	}
 	setFlags, _ := cmd.Flags().GetStringArray("set")
 	enclaveName, _ := cmd.Flags().GetString("enclave")
-	outputFormat, _ := cmd.Flags().GetString("format")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	labelFlags, _ := cmd.Flags().GetStringArray("label")
+	labels, err := parseLabels(labelFlags)
+	if err != nil {
+		return err
+	}
 
 	// Load configuration
 	cfg, err := loadConfig()
@@ -65,6 +128,11 @@ func runChaosTest(cmd *cobra.Command, args []string) error {
 		cfg.Kurtosis.EnclaveName = enclaveName
 	}
 
+	profileName, _ := cmd.Flags().GetString("profile")
+	if err := resolveProfile(cfg, profileName); err != nil {
+		return NewInfraError("%w", err)
+	}
+
 	// Auto-discover Prometheus if not explicitly configured via env var
 	if os.Getenv("PROMETHEUS_URL") == "" {
 		fmt.Println("Prometheus URL not configured, attempting auto-discovery from Kurtosis...")
@@ -77,73 +145,140 @@ func runChaosTest(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize logger
-	logLevel := reporting.LogLevelInfo
-	if verbose {
-		logLevel = reporting.LogLevelDebug
-	}
-	logFormat := reporting.LogFormat(cfg.Framework.LogFormat)
-
 	logger := reporting.NewLogger(reporting.LoggerConfig{
-		Level:  logLevel,
-		Format: logFormat,
-		Output: os.Stdout,
+		Level:  cliLogLevel(),
+		Format: reporting.LogFormat(cfg.Framework.LogFormat),
+		Output: logOutput(),
 	})
 
 	logger.Info("Chaos Runner starting", "version", version)
 
-	// Parse scenario
-	logger.Info("Parsing scenario", "file", scenarioPath)
-	p := parser.New(nil)
-	scenario, err := p.ParseFile(scenarioPath)
+	// Parse scenario. A file may hold several ---separated documents; they
+	// run one after another as an implicit suite.
+	valuesFiles, _ := cmd.Flags().GetStringArray("values")
+	values, err := parser.LoadValues(valuesFiles...)
+	if err != nil {
+		return err
+	}
+	p := parser.New(values)
+	p.Profile = cfg.ActiveProfile()
+	var scenarios []*scenario.Scenario
+	var paths []string
+	var suite *scenario.Suite
+	if suitePath != "" {
+		logger.Info("Parsing suite", "file", suitePath)
+		if suite, scenarios, paths, err = loadSuite(suitePath, values, cfg.ActiveProfile()); err != nil {
+			return err
+		}
+	} else if builtinName != "" {
+		logger.Info("Parsing scenario", "builtin", builtinName)
+		data, err := builtin.Get(builtinName)
+		if err != nil {
+			return err
+		}
+		scenarioPath = "builtin:" + builtinName
+		scenarios, err = p.ParseAll(data)
+	} else {
+		logger.Info("Parsing scenario", "file", scenarioPath)
+		scenarios, err = p.ParseFileAll(scenarioPath)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to parse scenario: %w", err)
 	}
+	for len(paths) < len(scenarios) {
+		paths = append(paths, scenarioPath)
+	}
 
-	// Apply overrides
-	if len(setFlags) > 0 {
-		overrides := parseSetFlags(setFlags)
-		if err := parser.ApplyOverrides(scenario, overrides); err != nil {
-			return fmt.Errorf("failed to apply overrides: %w", err)
+	// Dry-run criterion queries and collected metrics against the live
+	// Prometheus so a typo surfaces now rather than in DETECT after the
+	// whole fault window has elapsed.
+	promClient, promErr := prometheus.New(prometheus.Config{
+		URL:     cfg.Prometheus.URL,
+		Timeout: cfg.Prometheus.Timeout,
+	})
+	if promErr == nil {
+		aliaser := prometheus.NewMetricAliaser(prometheus.DefaultAliasGroups)
+		if aliaser.Resolve(context.Background(), promClient) == nil {
+			promClient.SetAliaser(aliaser)
 		}
-		logger.Debug("Applied overrides", "count", len(overrides))
 	}
 
-	// Validate scenario
-	logger.Info("Validating scenario")
-	v := validator.New()
-	if err := v.Validate(scenario); err != nil {
-		return fmt.Errorf("scenario validation failed: %w", err)
-	}
+	for _, scenario := range scenarios {
+		// Apply overrides
+		if len(setFlags) > 0 {
+			overrides := parseSetFlags(setFlags)
+			if err := parser.ApplyOverrides(scenario, overrides); err != nil {
+				return fmt.Errorf("failed to apply overrides: %w", err)
+			}
+			logger.Debug("Applied overrides", "count", len(overrides))
+		}
 
-	if len(v.Warnings) > 0 {
-		logger.Warn("Scenario has warnings")
-		for _, warning := range v.Warnings {
-			logger.Warn("  " + warning)
+		// Validate scenario
+		logger.Info("Validating scenario", "name", scenario.Metadata.Name)
+		v := validator.New()
+		if err := v.Validate(scenario); err != nil {
+			return fmt.Errorf("scenario %s validation failed: %w", scenario.Metadata.Name, err)
+		}
+		if promErr == nil {
+			v.ValidateQueries(context.Background(), scenario, promClient)
 		}
-	}
 
-	logger.Info("Scenario validated successfully", "name", scenario.Metadata.Name)
+		if len(v.Warnings) > 0 {
+			logger.Warn("Scenario has warnings")
+			for _, warning := range v.Warnings {
+				logger.Warn("  " + warning)
+			}
+		}
+
+		logger.Info("Scenario validated successfully", "name", scenario.Metadata.Name)
+	}
 
 	// Dry run - exit after validation
 	if dryRun {
-		fmt.Println("✅ Scenario is valid (dry-run mode)")
+		status.Scenario = scenarios[len(scenarios)-1].Metadata.Name
+		showSummary()
+		fmt.Printf("✅ %d scenario(s) valid (dry-run mode)\n", len(scenarios))
 		return nil
 	}
 
-	// Create orchestrator
-	logger.Info("Creating orchestrator")
-	orch, err := orchestrator.New(cfg)
-	if err != nil {
-		return NewInfraError("failed to create orchestrator: %w", err)
-	}
-
 	// Auto-discover Heimdall API endpoint from Kurtosis
 	fmt.Println("Attempting Heimdall API auto-discovery from Kurtosis...")
-	if heimdallURL, discoverErr := config.DiscoverHeimdallEndpoint(cfg.Kurtosis.EnclaveName); discoverErr == nil {
+	heimdallURL, discoverErr := config.DiscoverHeimdallEndpoint(cfg.Kurtosis.EnclaveName, cfg.ActiveProfile())
+	if discoverErr == nil {
 		fmt.Printf("Discovered Heimdall API endpoint: %s\n", heimdallURL)
-		orch.SetHeimdallAPI(heimdallURL)
 	} else {
-		fmt.Printf("Heimdall API auto-discovery failed (exclude_producer won't work): %v\n", discoverErr)
+		fmt.Printf("Heimdall API auto-discovery failed (exclude_producer and heimdall_api criteria won't work): %v\n", discoverErr)
+	}
+
+	rpcURL, _ := cmd.Flags().GetString("rpc-url")
+	resolveRPCURL(cfg, rpcURL)
+
+	if verifyEnclave, _ := cmd.Flags().GetBool("verify-enclave"); verifyEnclave {
+		cfg.Execution.VerifyEnclaveCleanup = true
+	}
+
+	var gatekeeper *gameday.Gatekeeper
+	gameDay, _ := cmd.Flags().GetBool("gameday")
+	if gameDay || cfg.GameDay.Enabled {
+		if gatekeeper, err = newGatekeeper(cfg.GameDay, labels); err != nil {
+			return NewInfraError("%w", err)
+		}
+	}
+
+	notifier, err := newNotifier(cfg.Notify, labels)
+	if err != nil {
+		return NewInfraError("%w", err)
+	}
+	// Let the last events (the final report, an emergency stop) go out
+	// before the process exits.
+	defer notifier.Close(notifyFlushTimeout)
+
+	auditSink, err := newAuditSink(cfg.Audit)
+	if err != nil {
+		return NewInfraError("%w", err)
+	}
+	if auditSink != nil {
+		defer auditSink.Close()
 	}
 
 	// Create progress reporter
@@ -158,63 +293,321 @@ func runChaosTest(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create storage: %w", err)
 	}
 
-	// Execute test
-	ctx := context.Background()
-	logger.Info("Starting chaos test execution", "scenario", scenario.Metadata.Name)
-
-	// Pass the in-memory, override-applied, validated scenario struct — NOT
-	// just the path. Orchestrator.Execute historically re-parsed the file
-	// and silently discarded --set overrides (F-04). scenarioPath is still
-	// passed for reporting/log context only.
-	result, err := orch.Execute(ctx, scenario, scenarioPath)
-
-	// Generate report regardless of success/failure
-	report := &reporting.TestReport{
-		TestID:          result.TestID,
-		ScenarioName:    scenario.Metadata.Name,
-		StartTime:       result.StartTime,
-		EndTime:         result.EndTime,
-		Duration:        result.Duration.String(),
-		Status:          convertStatus(result.State),
-		Success:         result.Success,
-		Message:         result.Message,
-		Targets:         convertTargets(result.Targets),
-		Faults:          convertFaults(scenario, result),
-		FaultInstalls:   result.FaultCount,
-		SuccessCriteria: convertCriteria(result.CriteriaResults),
-		CleanupSummary:  orch.GetCleanupSummary(),
-		Errors:          convertErrors(result.Errors),
-	}
-
-	// Save report
-	if _, saveErr := storage.SaveReport(report); saveErr != nil {
-		logger.Warn("Failed to save report", "error", saveErr)
-	}
-
-	// Display final summary
-	progressReporter.ReportTestCompleted(report)
-
-	// Return error if test failed.
-	// A CriteriaFailureError is a legitimate test finding (criteria missed after
-	// a clean orchestration run) and must exit 1 so CI treats it as a test
-	// failure rather than an infra breakage. Everything else (sidecar creation,
-	// container errors, Prometheus unreachability, etc.) is infra → exit 2.
+	// One orchestrator serves the whole suite: ExecuteNext resets the
+	// per-test state and keeps clients and sidecars warm between runs.
+	logger.Info("Creating orchestrator")
+	orch, err := orchestrator.New(cfg)
 	if err != nil {
-		var criteriaErr *orchestrator.CriteriaFailureError
-		if errors.As(err, &criteriaErr) {
-			return fmt.Errorf("chaos test failed: %w", err)
+		return NewInfraError("failed to create orchestrator: %w", err)
+	}
+	defer func() {
+		if err := orch.Close(context.Background()); err != nil {
+			logger.Warn("Failed to clean up sidecars", "error", err)
+		}
+	}()
+	orch.SetExecTracer(execTracer(logger))
+	if discoverErr == nil {
+		orch.SetHeimdallAPI(heimdallURL)
+	}
+	if gatekeeper != nil {
+		orch.SetGatekeeper(gatekeeper)
+	}
+	if auditSink != nil {
+		orch.SetAuditSink(auditSink)
+	}
+	if notifier != nil {
+		orch.SetNotifier(notifier)
+	}
+
+	// A suite's combined report indexes the per-scenario reports. It is
+	// written however the suite ends, including when an infrastructure
+	// error stops it early.
+	var suiteReport *reporting.SuiteReport
+	if suite != nil {
+		suiteReport = &reporting.SuiteReport{Suite: suite.Metadata.Name, StartTime: time.Now(), Labels: labels}
+		defer func() {
+			suiteReport.EndTime = time.Now()
+			suiteReport.Duration = suiteReport.EndTime.Sub(suiteReport.StartTime).Round(time.Second).String()
+			suiteReport.Success = err == nil
+			if err != nil && len(suiteReport.Scenarios) < len(scenarios) {
+				suiteReport.Message = err.Error()
+			}
+			printSuiteSummary(suiteReport)
+			if path, saveErr := storage.SaveSuiteReport(suiteReport); saveErr != nil {
+				logger.Warn("Failed to save suite report", "error", saveErr)
+			} else {
+				status.Reports = append(status.Reports, path)
+			}
+		}()
+	}
+
+	failed, unknown := 0, 0
+	for i, scenario := range scenarios {
+		if len(scenarios) > 1 {
+			fmt.Printf("\n=== Scenario %d/%d: %s ===\n", i+1, len(scenarios), scenario.Metadata.Name)
+		}
+
+		// Execute test
+		ctx := context.Background()
+		logger.Info("Starting chaos test execution", "scenario", scenario.Metadata.Name)
+
+		// Pass the in-memory, override-applied, validated scenario struct — NOT
+		// just the path. Orchestrator.Execute historically re-parsed the file
+		// and silently discarded --set overrides (F-04). scenarioPath is still
+		// passed for reporting/log context only.
+		stopProgress := followProgress(orch, progressReporter)
+		var result *orchestrator.TestResult
+		var err error
+		if resumeID != "" {
+			result, err = orch.Resume(ctx, scenario, paths[i], resumeID)
+		} else {
+			result, err = orch.ExecuteNext(ctx, scenario, paths[i])
+		}
+		stopProgress()
+		if result == nil {
+			return NewInfraError("chaos test failed: %w", err)
+		}
+
+		// Generate report regardless of success/failure
+		report := buildReport(scenario, result, orch)
+		report.Labels = labels
+
+		// Save report
+		reportPath, saveErr := storage.SaveReport(report)
+		if saveErr != nil {
+			logger.Warn("Failed to save report", "error", saveErr)
+		}
+		status.fromReport(report, reportPath)
+		notifyCompleted(notifier, report, reportPath, err)
+		if suiteReport != nil {
+			suiteReport.AddRun(paths[i], report, reportPath)
+		}
+
+		// Return error if test failed.
+		criteriaErr, infraFailure := classifyRunError(err)
+
+		// Display final summary. In quiet mode only the run that ends the
+		// command gets one.
+		if infraFailure || i == len(scenarios)-1 {
+			showSummary()
+		}
+		progressReporter.ReportTestCompleted(report)
+
+		if infraFailure || (err != nil && len(scenarios) == 1) {
+			return runError(err)
+		}
+		if err != nil {
+			if criteriaErr.Unknown {
+				unknown++
+			} else {
+				failed++
+			}
+			continue
+		}
+
+		if !result.Success {
+			failed++
 		}
-		return NewInfraError("chaos test failed: %w", err)
 	}
 
-	if !result.Success {
-		return fmt.Errorf("chaos test did not meet success criteria")
+	if failed > 0 {
+		if len(scenarios) == 1 {
+			return fmt.Errorf("chaos test did not meet success criteria")
+		}
+		return fmt.Errorf("%d of %d scenarios did not meet success criteria", failed, len(scenarios))
+	}
+	if unknown > 0 {
+		return &UnknownOutcomeError{Msg: fmt.Sprintf("%d of %d scenarios ended with an unknown outcome", unknown, len(scenarios))}
 	}
 
 	logger.Info("Chaos test completed successfully")
 	return nil
 }
 
+// classifyRunError sorts the error from one orchestrator run. A
+// CriteriaFailureError is a legitimate test finding (criteria missed after
+// a clean orchestration run) and must exit 1 so CI treats it as a test
+// failure rather than an infra breakage. Everything else (sidecar creation,
+// container errors, Prometheus unreachability, etc.) is infra → exit 2, and
+// stops the rest of a suite since the enclave may be unusable. An
+// interrupted run is never a finding, even when the stop landed in DETECT
+// and the InterruptedError wraps a criteria failure.
+func classifyRunError(err error) (criteriaErr *orchestrator.CriteriaFailureError, infraFailure bool) {
+	if err == nil {
+		return nil, false
+	}
+	var interruptedErr *orchestrator.InterruptedError
+	if errors.As(err, &interruptedErr) {
+		return nil, true
+	}
+	if errors.As(err, &criteriaErr) {
+		return criteriaErr, false
+	}
+	return nil, true
+}
+
+// runError is the command error for a run that ended with err, typed so
+// main exits with the matching code.
+func runError(err error) error {
+	criteriaErr, infraFailure := classifyRunError(err)
+	switch {
+	case infraFailure:
+		return NewInfraError("chaos test failed: %w", err)
+	case criteriaErr.Unknown:
+		return &UnknownOutcomeError{Msg: "chaos test outcome unknown: " + err.Error()}
+	default:
+		return fmt.Errorf("chaos test failed: %w", err)
+	}
+}
+
+// buildReport assembles the persisted report for one orchestrator run.
+func buildReport(s *scenario.Scenario, result *orchestrator.TestResult, orch *orchestrator.Orchestrator) *reporting.TestReport {
+	return &reporting.TestReport{
+		TestID:           result.TestID,
+		ScenarioName:     s.Metadata.Name,
+		StartTime:        result.StartTime,
+		EndTime:          result.EndTime,
+		Duration:         result.Duration.String(),
+		ExpectedImpact:   s.Metadata.ExpectedImpact,
+		RunbookURL:       s.Metadata.RunbookURL,
+		Owner:            s.Metadata.Owner,
+		Approval:         convertApproval(result.Approval),
+		Status:           convertStatus(result.State),
+		Success:          result.Success,
+		Unknown:          result.Unknown,
+		Message:          result.Message,
+		StuckPhase:       result.StuckPhase,
+		Environment:      convertEnvironment(result.Environment),
+		Targets:          convertTargets(result.Targets),
+		Faults:           convertFaults(s, result),
+		FaultInstalls:    result.FaultCount,
+		SuccessCriteria:  convertCriteria(result.CriteriaResults),
+		BlastRadius:      convertBlastRadius(result.BlastRadius),
+		CollateralEvents: convertCollateral(result.Collateral),
+		EnclaveResidue:   convertResidue(result.EnclaveResidue),
+		RunnerUsage:      convertRunnerUsage(result.RunnerUsage),
+		Detections:       convertDetections(result.Detections),
+		CleanupSummary:   orch.GetCleanupSummary(),
+		Errors:           convertErrors(result.Errors),
+	}
+}
+
+// notifyFlushTimeout bounds how long the command waits for webhook posts
+// still in flight when it exits.
+const notifyFlushTimeout = 15 * time.Second
+
+// notifyCompleted posts the test_completed event for one finished run;
+// err is the run's error, classified as for the exit code.
+func notifyCompleted(n *notify.Notifier, report *reporting.TestReport, reportPath string, err error) {
+	if n == nil {
+		return
+	}
+	var counts runStatus
+	counts.fromReport(report, "")
+	outcome := "passed"
+	var interruptedErr *orchestrator.InterruptedError
+	criteriaErr, infraFailure := classifyRunError(err)
+	switch {
+	case errors.As(err, &interruptedErr):
+		outcome = "interrupted"
+	case infraFailure:
+		outcome = "error"
+	case err != nil && criteriaErr.Unknown:
+		outcome = "unknown"
+	case err != nil || !report.Success:
+		outcome = "failed"
+	}
+	c := counts.Criteria
+	n.Notify(notify.Event{
+		Event:          notify.EventTestCompleted,
+		TestID:         report.TestID,
+		Scenario:       report.ScenarioName,
+		Owner:          report.Owner,
+		RunbookURL:     report.RunbookURL,
+		ExpectedImpact: report.ExpectedImpact,
+		Message:        report.Message,
+		Outcome:        outcome,
+		Duration:       report.Duration,
+		Criteria:       &notify.CriteriaCount{Total: c.Total, Passed: c.Passed, Failed: c.Failed, Unknown: c.Unknown, CriticalFailed: c.CriticalFailed},
+		ReportPath:     reportPath,
+	})
+}
+
+// runStatus is the single object printed by --format json-status.
+type runStatus struct {
+	TestID   string `json:"test_id,omitempty"`
+	Scenario string `json:"scenario,omitempty"`
+	// Outcome is passed, failed (criteria missed, exit 1), error
+	// (infrastructure failure, exit 2) or unknown (critical criteria not
+	// judged under treat_unknown_as: unknown, exit 3).
+	Outcome  string          `json:"outcome"`
+	ExitCode int             `json:"exit_code"`
+	Message  string          `json:"message,omitempty"`
+	Criteria criteriaSummary `json:"criteria"`
+	Reports  []string        `json:"reports,omitempty"`
+}
+
+// criteriaSummary counts success criteria results.
+type criteriaSummary struct {
+	Total          int `json:"total"`
+	Passed         int `json:"passed"`
+	Failed         int `json:"failed"`
+	Unknown        int `json:"unknown,omitempty"`
+	CriticalFailed int `json:"critical_failed"`
+}
+
+// fromReport fills in a run's result. For a multi-scenario file it is called
+// once per run: criteria and report paths accumulate, while test_id and
+// scenario name the last run. reportPath is empty when the report could not
+// be saved.
+func (s *runStatus) fromReport(report *reporting.TestReport, reportPath string) {
+	s.TestID = report.TestID
+	s.Scenario = report.ScenarioName
+	for _, c := range report.SuccessCriteria {
+		s.Criteria.Total++
+		if c.Passed {
+			s.Criteria.Passed++
+			continue
+		}
+		if c.Unknown {
+			s.Criteria.Unknown++
+		} else {
+			s.Criteria.Failed++
+		}
+		if c.Critical {
+			s.Criteria.CriticalFailed++
+		}
+	}
+	if reportPath != "" {
+		s.Reports = append(s.Reports, reportPath, reporting.HTMLPath(reportPath), reporting.TracePath(reportPath))
+	}
+}
+
+// print writes the status as one line of JSON, classifying err the same
+// way main maps it to an exit code.
+func (s *runStatus) print(err error) {
+	var infraErr *InfraError
+	var unknownErr *UnknownOutcomeError
+	var interruptedErr *orchestrator.InterruptedError
+	switch {
+	case err == nil:
+		s.Outcome = "passed"
+	case errors.As(err, &interruptedErr):
+		s.Outcome, s.ExitCode = "interrupted", 2
+	case errors.As(err, &infraErr):
+		s.Outcome, s.ExitCode = "error", 2
+	case errors.As(err, &unknownErr):
+		s.Outcome, s.ExitCode = "unknown", 3
+	default:
+		s.Outcome, s.ExitCode = "failed", 1
+	}
+	if err != nil {
+		s.Message = err.Error()
+	}
+	data, _ := json.Marshal(s)
+	fmt.Println(string(data))
+}
+
 // parseSetFlags parses --set flags into a map
 func parseSetFlags(setFlags []string) map[string]string {
 	overrides := make(map[string]string)
@@ -234,6 +627,8 @@ func convertStatus(state orchestrator.TestState) reporting.TestStatus {
 		return reporting.StatusCompleted
 	case orchestrator.StateFailed:
 		return reporting.StatusFailed
+	case orchestrator.StateInterrupted:
+		return reporting.StatusInterrupted
 	default:
 		return reporting.StatusRunning
 	}
@@ -257,15 +652,165 @@ func convertTargets(targets []orchestrator.TargetInfo) []reporting.TargetInfo {
 			ServiceName: t.Name,
 			ContainerID: t.ContainerID,
 			IP:          t.IP,
+			Agent:       t.Agent,
 		}
 	}
 	return result
 }
 
+// convertEnvironment converts orchestrator.EnvironmentInfo to reporting.EnvironmentInfo
+func convertEnvironment(env orchestrator.EnvironmentInfo) reporting.EnvironmentInfo {
+	images := make([]reporting.ImageInfo, len(env.Images))
+	for i, img := range env.Images {
+		images[i] = reporting.ImageInfo{
+			Alias:       img.Alias,
+			ServiceName: img.Name,
+			Image:       img.Image,
+			ImageID:     img.ImageID,
+			RepoDigest:  img.RepoDigest,
+		}
+	}
+	return reporting.EnvironmentInfo{
+		EnclaveName:        env.EnclaveName,
+		KurtosisCLIVersion: env.KurtosisCLIVersion,
+		RunnerVersion:      version,
+		DockerVersion:      env.DockerVersion,
+		HostKernel:         env.HostKernel,
+		HostOS:             env.HostOS,
+		Images:             images,
+		Topology:           convertTopology(env.Topology),
+	}
+}
+
+// convertTopology converts orchestrator.Topology to reporting.TopologyInfo
+func convertTopology(topo *orchestrator.Topology) *reporting.TopologyInfo {
+	if topo == nil {
+		return nil
+	}
+	services := make([]reporting.ServiceInfo, len(topo.Services))
+	for i, svc := range topo.Services {
+		services[i] = reporting.ServiceInfo{Name: svc.Name, Role: svc.Role, Image: svc.Image, State: svc.State}
+	}
+	return &reporting.TopologyInfo{
+		EnclaveID:      topo.EnclaveID,
+		ValidatorCount: topo.ValidatorCount,
+		RoleCounts:     topo.RoleCounts,
+		Services:       services,
+	}
+}
+
+// convertBlastRadius converts orchestrator.BlastRadius to reporting.BlastRadiusInfo
+func convertBlastRadius(br *orchestrator.BlastRadius) *reporting.BlastRadiusInfo {
+	if br == nil {
+		return nil
+	}
+	group := func(g orchestrator.BlastGroup) reporting.BlastGroupInfo {
+		return reporting.BlastGroupInfo{Nodes: g.Nodes, Worst: g.Worst, Mean: g.Mean}
+	}
+	metrics := make([]reporting.BlastMetricInfo, len(br.Metrics))
+	for i, m := range br.Metrics {
+		metrics[i] = reporting.BlastMetricInfo{
+			Name:        m.Name,
+			Description: m.Description,
+			Target:      group(m.Target),
+			Control:     group(m.Control),
+		}
+		for _, n := range m.Collateral {
+			metrics[i].Collateral = append(metrics[i].Collateral, reporting.BlastNodeInfo{Job: n.Job, Baseline: n.Baseline, Worst: n.Worst})
+		}
+	}
+	return &reporting.BlastRadiusInfo{Window: br.Window.String(), Metrics: metrics}
+}
+
+// convertCollateral converts orchestrator.CollateralEvent to reporting.CollateralEventInfo
+func convertCollateral(events []orchestrator.CollateralEvent) []reporting.CollateralEventInfo {
+	var infos []reporting.CollateralEventInfo
+	for _, ev := range events {
+		infos = append(infos, reporting.CollateralEventInfo{
+			Container: ev.Container,
+			Action:    ev.Action,
+			At:        ev.At,
+			ExitCode:  ev.ExitCode,
+		})
+	}
+	return infos
+}
+
+// convertResidue converts orchestrator.EnclaveResidue to reporting.ResidueInfo
+func convertResidue(residue []orchestrator.EnclaveResidue) []reporting.ResidueInfo {
+	var infos []reporting.ResidueInfo
+	for _, r := range residue {
+		infos = append(infos, reporting.ResidueInfo{
+			Container:   r.Container,
+			ContainerID: r.ContainerID,
+			Target:      r.Target,
+			Artifacts:   r.Artifacts.String(),
+		})
+	}
+	return infos
+}
+
+// convertRunnerUsage converts orchestrator.PhaseUsage to reporting.PhaseUsageInfo
+func convertRunnerUsage(usage []orchestrator.PhaseUsage) []reporting.PhaseUsageInfo {
+	infos := make([]reporting.PhaseUsageInfo, len(usage))
+	for i, u := range usage {
+		infos[i] = reporting.PhaseUsageInfo{
+			Phase:           u.Phase,
+			StartTime:       u.Start,
+			DurationSeconds: u.Duration.Seconds(),
+			CPUSeconds:      u.CPU.Seconds(),
+			CPUPercent:      u.CPUPercent(),
+			HeapBytes:       u.HeapBytes,
+			MaxRSSBytes:     u.MaxRSSBytes,
+			Goroutines:      u.Goroutines,
+			DockerAPICalls:  u.DockerAPICalls,
+		}
+	}
+	return infos
+}
+
+// convertApproval converts approval.Approval to reporting.ApprovalInfo
+func convertApproval(ap *approval.Approval) *reporting.ApprovalInfo {
+	if ap == nil {
+		return nil
+	}
+	return &reporting.ApprovalInfo{
+		Method:      ap.Method,
+		Approver:    ap.Approver,
+		Role:        ap.Role,
+		TokenSHA256: ap.TokenSHA256,
+		Time:        ap.Time,
+	}
+}
+
+// convertDetections converts orchestrator.Detection to reporting.DetectionInfo
+func convertDetections(detections []orchestrator.Detection) []reporting.DetectionInfo {
+	infos := make([]reporting.DetectionInfo, len(detections))
+	for i, d := range detections {
+		infos[i] = reporting.DetectionInfo{
+			Signal:         d.Signal,
+			Kind:           d.Kind,
+			Detected:       d.Detected,
+			DetectedAt:     d.At,
+			LatencySeconds: d.Latency.Seconds(),
+			PreExisting:    d.PreExisting,
+		}
+	}
+	return infos
+}
+
 // convertCriteria converts orchestrator criteria results to reporting format
 func convertCriteria(criteria []orchestrator.CriterionOutcome) []reporting.CriterionResult {
 	results := make([]reporting.CriterionResult, len(criteria))
 	for i, c := range criteria {
+		history := make([]reporting.EvaluationPoint, len(c.History))
+		for j, h := range c.History {
+			history[j] = reporting.EvaluationPoint{Time: h.Time, Value: h.Value, Passed: h.Passed}
+		}
+		var evalTime time.Time
+		if len(c.History) > 0 {
+			evalTime = c.History[len(c.History)-1].Time
+		}
 		results[i] = reporting.CriterionResult{
 			Name:        c.Name,
 			Description: c.Description,
@@ -273,9 +818,14 @@ func convertCriteria(criteria []orchestrator.CriterionOutcome) []reporting.Crite
 			Query:       c.Query,
 			Threshold:   c.Threshold,
 			Passed:      c.Passed,
+			Unknown:     c.Unknown,
 			Value:       c.Value,
 			Message:     c.Message,
 			Critical:    c.Critical,
+			EvalTime:    evalTime,
+			Evaluations: c.Evaluations,
+			Failures:    c.Failures,
+			History:     history,
 		}
 	}
 	return results
@@ -300,11 +850,42 @@ func convertFaults(s *scenario.Scenario, result *orchestrator.TestResult) []repo
 			Parameters:  make(map[string]interface{}),
 		}
 
-		// Convert fault parameters to map
-		if f.Params != nil {
-			for k, v := range f.Params {
-				faultInfo.Parameters[k] = v
+		// Convert fault parameters to map, without secrets
+		for k, v := range scenario.RedactParams(f.Params) {
+			faultInfo.Parameters[k] = v
+		}
+
+		for _, r := range result.Recoveries[f.Phase] {
+			faultInfo.Recoveries = append(faultInfo.Recoveries, reporting.RecoveryInfo{
+				Target:                  r.Target,
+				RestartToHealthy:        r.Latency.Round(time.Millisecond).String(),
+				RestartToHealthySeconds: r.Latency.Seconds(),
+				StoppedAt:               r.StoppedAt,
+				StartedAt:               r.StartedAt,
+				HealthyAt:               r.HealthyAt,
+			})
+		}
+		for _, m := range result.AppliedMethods[f.Phase] {
+			faultInfo.AppliedMethods = append(faultInfo.AppliedMethods, reporting.AppliedMethodInfo{
+				Target:   m.Target,
+				Method:   m.Method,
+				Fallback: m.Fallback,
+			})
+		}
+		for _, c := range result.Captures[f.Phase] {
+			faultInfo.Captures = append(faultInfo.Captures, reporting.CaptureInfo{
+				Target: c.Target,
+				Path:   c.Path,
+				Bytes:  c.Bytes,
+			})
+		}
+		faultInfo.FailurePolicy = f.FailurePolicy.String()
+		for _, in := range result.Injections[f.Phase] {
+			info := reporting.InjectionInfo{Target: in.Target, ContainerID: in.ContainerID, Installed: in.Err == nil, InstalledAt: in.InstalledAt, RemovedAt: in.RemovedAt}
+			if in.Err != nil {
+				info.Error = in.Err.Error()
 			}
+			faultInfo.Injections = append(faultInfo.Injections, info)
 		}
 
 		faults = append(faults, faultInfo)
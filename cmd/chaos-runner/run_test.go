@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+)
+
+func TestRunOutcome_ExitCodeMatchesStatus(t *testing.T) {
+	criteria := &orchestrator.CriteriaFailureError{Msg: "one or more critical success criteria failed"}
+	unknown := &orchestrator.CriteriaFailureError{Msg: "could not be judged", Unknown: true}
+
+	tests := []struct {
+		name        string
+		err         error
+		wantExit    int
+		wantOutcome string
+	}{
+		{"criteria failure", criteria, 1, "failed"},
+		{"unknown outcome", unknown, 3, "unknown"},
+		{"infra failure", errors.New("failed to create sidecar"), 2, "error"},
+		{"stopped during DETECT", &orchestrator.InterruptedError{Phase: orchestrator.StateDetect, Err: criteria}, 2, "interrupted"},
+		{"stopped during INJECT", &orchestrator.InterruptedError{Phase: orchestrator.StateInject, Err: errors.New("context canceled")}, 2, "interrupted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runError(tt.err)
+			if got := exitCode(err); got != tt.wantExit {
+				t.Errorf("exitCode() = %d, want %d", got, tt.wantExit)
+			}
+			status := &runStatus{}
+			status.print(err)
+			if status.ExitCode != tt.wantExit || status.Outcome != tt.wantOutcome {
+				t.Errorf("status = %s/%d, want %s/%d", status.Outcome, status.ExitCode, tt.wantOutcome, tt.wantExit)
+			}
+			if got := runExitCode(tt.err); got != tt.wantExit {
+				t.Errorf("runExitCode() = %d, want %d", got, tt.wantExit)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	chaosrunnerv1 "github.com/jihwankim/chaos-utils/api/gen/chaosrunner/v1"
+	"github.com/jihwankim/chaos-utils/pkg/audit"
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/control"
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+	"github.com/jihwankim/chaos-utils/pkg/reporting"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Args:  cobra.NoArgs,
+	Short: "Serve the gRPC and REST control APIs",
+	Long: `Listens for the gRPC control API (api/proto/chaosrunner/v1/chaos_runner.proto)
+and/or its REST form, so a larger test harness can submit scenarios, stream
+their progress, stop them and fetch their reports without shelling out to
+chaos-runner run. Both listeners share one server, so a test submitted over
+one can be followed over the other.
+
+REST endpoints (--http):
+  POST   /tests               body: scenario YAML/JSON; ?var=K=V, ?set=k=v, ?dry_run=true
+  GET    /tests/{id}          state, exit code and report once done
+  GET    /tests/{id}/events   newline-delimited JSON events until the test ends
+  DELETE /tests/{id}          stop the test (teardown still runs)
+
+One test runs at a time; a submission while one is running is rejected with
+FAILED_PRECONDITION. Reports are saved to reporting.output_dir as with run,
+and each test's events and report stay available until the server exits.
+The listener is plaintext and unauthenticated: bind it to loopback or a
+private network.`,
+	Example: `  chaos-runner serve --grpc 127.0.0.1:9090 --enclave my-enclave
+  chaos-runner serve --http 127.0.0.1:8080 --enclave my-enclave
+  curl --data-binary @scenario.yaml 'http://127.0.0.1:8080/tests?var=ENCLAVE_NAME=my-enclave'`,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().String("grpc", "", "address to serve the gRPC control API on (e.g. 127.0.0.1:9090)")
+	serveCmd.Flags().String("http", "", "address to serve the REST control API on (e.g. 127.0.0.1:8080)")
+	serveCmd.Flags().String("enclave", "", "Kurtosis enclave name (overrides config)")
+	serveCmd.Flags().String("profile", "", "deployment profile: auto, pos-heimdall-v2, pos-heimdall-v1, cdk-erigon (overrides config)")
+	serveCmd.Flags().String("rpc-url", "", "EVM JSON-RPC endpoint for rpc criteria (overrides config and auto-discovery)")
+	serveCmd.MarkFlagsOneRequired("grpc", "http")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	grpcAddr, _ := cmd.Flags().GetString("grpc")
+	httpAddr, _ := cmd.Flags().GetString("http")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return NewInfraError("failed to load configuration: %w", err)
+	}
+	if enclaveName, _ := cmd.Flags().GetString("enclave"); enclaveName != "" {
+		cfg.Kurtosis.EnclaveName = enclaveName
+	}
+	profileName, _ := cmd.Flags().GetString("profile")
+	if err := resolveProfile(cfg, profileName); err != nil {
+		return NewInfraError("%w", err)
+	}
+	if os.Getenv("PROMETHEUS_URL") == "" {
+		endpoint, err := config.DiscoverPrometheusEndpoint(cfg.Kurtosis.EnclaveName)
+		if err != nil {
+			return NewInfraError("Prometheus is required but not reachable: auto-discovery failed: %w", err)
+		}
+		cfg.Prometheus.URL = endpoint
+	}
+	rpcURL, _ := cmd.Flags().GetString("rpc-url")
+	resolveRPCURL(cfg, rpcURL)
+
+	logger := reporting.NewLogger(reporting.LoggerConfig{
+		Level:  cliLogLevel(),
+		Format: reporting.LogFormat(cfg.Framework.LogFormat),
+		Output: logOutput(),
+	})
+
+	storage, err := reporting.NewStorage(cfg.Reporting.OutputDir, cfg.Reporting.KeepLastN, logger)
+	if err != nil {
+		return NewInfraError("failed to create storage: %w", err)
+	}
+	auditSink, err := newAuditSink(cfg.Audit)
+	if err != nil {
+		return NewInfraError("%w", err)
+	}
+	if auditSink != nil {
+		defer auditSink.Close()
+	}
+
+	runner := &orchestratorRunner{cfg: cfg, logger: logger, storage: storage, audit: auditSink}
+	if heimdallURL, err := config.DiscoverHeimdallEndpoint(cfg.Kurtosis.EnclaveName, cfg.ActiveProfile()); err == nil {
+		runner.heimdallURL = heimdallURL
+	} else {
+		logger.Warn("Heimdall API auto-discovery failed (exclude_producer and heimdall_api criteria won't work)", "error", err)
+	}
+
+	ctrl := control.NewServer(runner)
+	ctrl.SetProfile(cfg.ActiveProfile())
+	var grpcSrv *grpc.Server
+	var grpcLis net.Listener
+	if grpcAddr != "" {
+		if grpcLis, err = net.Listen("tcp", grpcAddr); err != nil {
+			return NewInfraError("failed to listen on %s: %w", grpcAddr, err)
+		}
+		grpcSrv = grpc.NewServer()
+		chaosrunnerv1.RegisterChaosRunnerServer(grpcSrv, ctrl)
+	}
+	var httpSrv *http.Server
+	var httpLis net.Listener
+	if httpAddr != "" {
+		if httpLis, err = net.Listen("tcp", httpAddr); err != nil {
+			return NewInfraError("failed to listen on %s: %w", httpAddr, err)
+		}
+		httpSrv = &http.Server{Handler: ctrl.HTTPHandler(), ReadHeaderTimeout: 10 * time.Second}
+	}
+
+	// On SIGINT/SIGTERM stop the test in progress and let its teardown
+	// finish before the servers go away.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		<-sigCh
+		logger.Info("Shutting down control API")
+		runner.Stop()
+		runner.wait()
+		if grpcSrv != nil {
+			grpcSrv.GracefulStop()
+		}
+		if httpSrv != nil {
+			_ = httpSrv.Shutdown(context.Background())
+		}
+	}()
+
+	errs := make(chan error, 2)
+	if grpcSrv != nil {
+		logger.Info("Serving gRPC control API", "address", grpcLis.Addr().String(), "version", version)
+		go func() {
+			if err := grpcSrv.Serve(grpcLis); err != nil {
+				errs <- NewInfraError("gRPC server failed: %w", err)
+				return
+			}
+			errs <- nil
+		}()
+	}
+	if httpSrv != nil {
+		logger.Info("Serving REST control API", "address", httpLis.Addr().String(), "version", version)
+		go func() {
+			if err := httpSrv.Serve(httpLis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- NewInfraError("HTTP server failed: %w", err)
+				return
+			}
+			errs <- nil
+		}()
+	}
+
+	// The first listener to fail takes the other down with it.
+	err = <-errs
+	if err != nil {
+		if grpcSrv != nil {
+			grpcSrv.Stop()
+		}
+		if httpSrv != nil {
+			_ = httpSrv.Close()
+		}
+	}
+	if grpcSrv != nil && httpSrv != nil {
+		<-errs
+	}
+	return err
+}
+
+// orchestratorRunner is the control.Runner behind chaos-runner serve. Each
+// test gets a fresh orchestrator, since one that has been stopped refuses
+// further runs.
+type orchestratorRunner struct {
+	cfg         *config.Config
+	logger      *reporting.Logger
+	storage     *reporting.Storage
+	audit       audit.Sink
+	heimdallURL string
+
+	mu      sync.Mutex
+	orch    *orchestrator.Orchestrator
+	stopped bool
+	running sync.WaitGroup
+}
+
+func (r *orchestratorRunner) Run(ctx context.Context, s *scenario.Scenario, obs orchestrator.Observer) ([]byte, int, error) {
+	r.running.Add(1)
+	defer r.running.Done()
+
+	orch, err := orchestrator.New(r.cfg)
+	if err != nil {
+		return nil, 2, fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+	defer func() {
+		if err := orch.Close(context.Background()); err != nil {
+			r.logger.Warn("Failed to clean up sidecars", "error", err)
+		}
+	}()
+	orch.SetExecTracer(execTracer(r.logger))
+	orch.SetObserver(obs)
+	if r.heimdallURL != "" {
+		orch.SetHeimdallAPI(r.heimdallURL)
+	}
+	if r.audit != nil {
+		orch.SetAuditSink(r.audit)
+	}
+
+	// A stop that arrived before the orchestrator existed still applies.
+	r.mu.Lock()
+	r.orch = orch
+	if r.stopped {
+		r.stopped = false
+		orch.RequestStop()
+	}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.orch = nil
+		r.mu.Unlock()
+	}()
+
+	scenarioPath := "grpc:" + s.Metadata.Name
+	result, err := orch.ExecuteNext(ctx, s, scenarioPath)
+	if result == nil {
+		return nil, 2, err
+	}
+
+	report := buildReport(s, result, orch)
+	if _, saveErr := r.storage.SaveReport(report); saveErr != nil {
+		r.logger.Warn("Failed to save report", "error", saveErr)
+	}
+	data, jsonErr := json.MarshalIndent(report, "", "  ")
+	if jsonErr != nil {
+		return nil, 2, fmt.Errorf("failed to encode report: %w", jsonErr)
+	}
+	if err == nil && !result.Success {
+		return data, 1, fmt.Errorf("chaos test did not meet success criteria")
+	}
+	return data, runExitCode(err), err
+}
+
+// Stop interrupts the test in progress, or the next one if it has not
+// created its orchestrator yet.
+func (r *orchestratorRunner) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.orch != nil {
+		r.orch.RequestStop()
+		return
+	}
+	r.stopped = true
+}
+
+// wait blocks until the test in progress, if any, has torn down.
+func (r *orchestratorRunner) wait() { r.running.Wait() }
+
+// runExitCode maps a run's error to the exit code chaos-runner run would
+// have used for it.
+func runExitCode(err error) int {
+	criteriaErr, infraFailure := classifyRunError(err)
+	switch {
+	case err == nil:
+		return 0
+	case infraFailure:
+		return 2
+	case criteriaErr.Unknown:
+		return 3
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/detector"
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
+	"github.com/jihwankim/chaos-utils/pkg/reporting"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/parser"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/validator"
+	"github.com/spf13/cobra"
+)
+
+var soakCmd = &cobra.Command{
+	Use:   "soak",
+	Args:  cobra.NoArgs,
+	Short: "Run a long-haul soak test rotating low-severity scenarios",
+	Long: `Runs the given scenarios round-robin for a wall-clock period, with a
+recovery gap between runs. The universal Prometheus safety invariants are
+evaluated continuously for the whole soak, including during gaps, and a
+summary checkpoint is printed and written to the reports directory every
+--checkpoint-interval.
+
+Scenarios containing destructive or one-shot faults (kills, restarts, file
+deletion/corruption, p2p attacks) are rejected: soak is for faults the
+network should absorb indefinitely.`,
+	Example: `  # Rotate every network scenario for 12 hours
+  chaos-runner soak --scenario scenarios/polygon-chain/network --duration 12h
+
+  # Two specific scenarios, 5 minute recovery gap, 30 minute checkpoints
+  chaos-runner soak --scenario a.yaml --scenario b.yaml --duration 6h --gap 5m --checkpoint-interval 30m`,
+	RunE: runSoak,
+}
+
+func init() {
+	soakCmd.Flags().StringArray("scenario", []string{}, "scenario file or directory (repeatable)")
+	soakCmd.Flags().Duration("duration", 0, "wall-clock soak duration (e.g. 12h)")
+	soakCmd.Flags().Duration("gap", 2*time.Minute, "recovery gap between scenario runs")
+	soakCmd.Flags().Duration("checkpoint-interval", time.Hour, "interval between summary checkpoints")
+	soakCmd.Flags().Duration("invariant-interval", time.Minute, "interval between safety invariant evaluations")
+	soakCmd.Flags().String("enclave", "", "Kurtosis enclave name (overrides config)")
+	soakCmd.Flags().String("profile", "", "deployment profile (overrides config)")
+	soakCmd.Flags().String("rpc-url", "", "EVM JSON-RPC endpoint for rpc criteria (overrides config and auto-discovery)")
+	soakCmd.Flags().StringArray("label", []string{}, "attach run metadata to every report (e.g., --label release=v1.2.0)")
+}
+
+// soakExcludedFaultTypes are faults too severe to rotate unattended.
+var soakExcludedFaultTypes = map[string]bool{
+	"container_kill":    true,
+	"container_restart": true,
+	"process_kill":      true,
+	"file_delete":       true,
+	"file_corrupt":      true,
+	"p2p_attack":        true,
+}
+
+// soakRun is one scenario execution within a soak.
+type soakRun struct {
+	Scenario string    `json:"scenario"`
+	TestID   string    `json:"test_id"`
+	Start    time.Time `json:"start"`
+	Success  bool      `json:"success"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// soakCheckpoint summarises one checkpoint window.
+type soakCheckpoint struct {
+	Index                int            `json:"index"`
+	Start                time.Time      `json:"start"`
+	End                  time.Time      `json:"end"`
+	Runs                 []soakRun      `json:"runs"`
+	Passed               int            `json:"passed"`
+	Failed               int            `json:"failed"`
+	InvariantEvaluations int            `json:"invariant_evaluations"`
+	InvariantViolations  map[string]int `json:"invariant_violations"`
+}
+
+// soakWindow accumulates results between checkpoints. The invariant
+// monitor goroutine and the run loop both write to it.
+type soakWindow struct {
+	mu sync.Mutex
+	cp soakCheckpoint
+}
+
+func newSoakWindow(index int) *soakWindow {
+	return &soakWindow{cp: soakCheckpoint{Index: index, Start: time.Now(), InvariantViolations: map[string]int{}}}
+}
+
+func (w *soakWindow) recordInvariants(results map[string]*detector.CriterionResult) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cp.InvariantEvaluations++
+	for name, r := range results {
+		if !r.Passed {
+			w.cp.InvariantViolations[name]++
+		}
+	}
+}
+
+func (w *soakWindow) recordRun(run soakRun) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cp.Runs = append(w.cp.Runs, run)
+	if run.Success {
+		w.cp.Passed++
+	} else {
+		w.cp.Failed++
+	}
+}
+
+// close ends the window and returns its checkpoint.
+func (w *soakWindow) close() soakCheckpoint {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cp.End = time.Now()
+	return w.cp
+}
+
+func runSoak(cmd *cobra.Command, args []string) error {
+	paths, _ := cmd.Flags().GetStringArray("scenario")
+	duration, _ := cmd.Flags().GetDuration("duration")
+	gap, _ := cmd.Flags().GetDuration("gap")
+	checkpointInterval, _ := cmd.Flags().GetDuration("checkpoint-interval")
+	invariantInterval, _ := cmd.Flags().GetDuration("invariant-interval")
+	enclaveName, _ := cmd.Flags().GetString("enclave")
+	profileName, _ := cmd.Flags().GetString("profile")
+	labelFlags, _ := cmd.Flags().GetStringArray("label")
+	labels, err := parseLabels(labelFlags)
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		return fmt.Errorf("--scenario flag is required")
+	}
+	if duration <= 0 {
+		return fmt.Errorf("--duration must be positive")
+	}
+	if checkpointInterval <= 0 || invariantInterval <= 0 {
+		return fmt.Errorf("--checkpoint-interval and --invariant-interval must be positive")
+	}
+
+	restoreStdout := func() {}
+	if quiet {
+		restoreStdout = silenceStdout()
+	}
+	defer restoreStdout()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return NewInfraError("failed to load configuration: %w", err)
+	}
+	if enclaveName != "" {
+		cfg.Kurtosis.EnclaveName = enclaveName
+	}
+	if err := resolveProfile(cfg, profileName); err != nil {
+		return NewInfraError("%w", err)
+	}
+	if os.Getenv("PROMETHEUS_URL") == "" {
+		if endpoint, err := config.DiscoverPrometheusEndpoint(cfg.Kurtosis.EnclaveName); err == nil {
+			cfg.Prometheus.URL = endpoint
+		} else {
+			return NewInfraError("Prometheus auto-discovery failed: %w", err)
+		}
+	}
+	rpcURL, _ := cmd.Flags().GetString("rpc-url")
+	resolveRPCURL(cfg, rpcURL)
+
+	rotation, err := loadSoakScenarios(paths, cfg.ActiveProfile())
+	if err != nil {
+		return err
+	}
+
+	promClient, err := prometheus.New(prometheus.Config{URL: cfg.Prometheus.URL, Timeout: cfg.Prometheus.Timeout})
+	if err != nil {
+		return NewInfraError("failed to create Prometheus client: %w", err)
+	}
+
+	logger := reporting.NewLogger(reporting.LoggerConfig{
+		Level:  cliLogLevel(),
+		Format: reporting.LogFormat(cfg.Framework.LogFormat),
+		Output: logOutput(),
+	})
+	storage, err := reporting.NewStorage(cfg.Reporting.OutputDir, cfg.Reporting.KeepLastN, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+	progress := reporting.NewProgressReporter(reporting.FormatText, logger)
+
+	auditSink, err := newAuditSink(cfg.Audit)
+	if err != nil {
+		return NewInfraError("%w", err)
+	}
+	if auditSink != nil {
+		defer auditSink.Close()
+	}
+
+	start := time.Now()
+	soakDir := filepath.Join(cfg.Reporting.OutputDir, "soak-"+start.Format("20060102-150405"))
+	if err := os.MkdirAll(soakDir, 0755); err != nil {
+		return NewInfraError("failed to create soak directory: %w", err)
+	}
+
+	// The deadline only stops new runs from starting; a run in progress is
+	// always allowed to tear down.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithDeadline(ctx, start.Add(duration))
+	defer cancel()
+
+	fmt.Printf("Soak: %d scenario(s) for %s, gap %s, checkpoints every %s → %s\n",
+		len(rotation), duration, gap, checkpointInterval, soakDir)
+
+	var windowMu sync.Mutex
+	window := newSoakWindow(1)
+	current := func() *soakWindow {
+		windowMu.Lock()
+		defer windowMu.Unlock()
+		return window
+	}
+
+	go detector.New(promClient).MonitorContinuous(ctx, orchestrator.SafetyInvariants(cfg.ActiveProfile()), invariantInterval,
+		func(results map[string]*detector.CriterionResult) { current().recordInvariants(results) })
+
+	var checkpoints []soakCheckpoint
+	emit := func() {
+		windowMu.Lock()
+		cp := window.close()
+		window = newSoakWindow(cp.Index + 1)
+		windowMu.Unlock()
+
+		checkpoints = append(checkpoints, cp)
+		printSoakCheckpoint(cp)
+		if data, err := json.MarshalIndent(cp, "", "  "); err == nil {
+			path := filepath.Join(soakDir, fmt.Sprintf("checkpoint-%03d.json", cp.Index))
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				fmt.Printf("⚠ Failed to write checkpoint: %v\n", err)
+			}
+		}
+	}
+
+	// The orchestrator is created on first use and reused across runs
+	// (ExecuteNext); creation is retried on the next run if it fails.
+	var orch *orchestrator.Orchestrator
+	defer func() {
+		if orch != nil {
+			if err := orch.Close(context.Background()); err != nil {
+				fmt.Printf("⚠ Failed to clean up sidecars: %v\n", err)
+			}
+		}
+	}()
+
+	lastCheckpoint := time.Now()
+	for i := 0; ctx.Err() == nil; i++ {
+		entry := rotation[i%len(rotation)]
+		fmt.Printf("\n=== Soak run %d: %s (%s elapsed) ===\n", i+1, entry.scenario.Metadata.Name, time.Since(start).Round(time.Second))
+
+		run := soakRun{Scenario: entry.scenario.Metadata.Name, Start: time.Now()}
+		if orch == nil {
+			if orch, err = orchestrator.New(cfg); err == nil {
+				orch.SetExecTracer(execTracer(logger))
+				if auditSink != nil {
+					orch.SetAuditSink(auditSink)
+				}
+			}
+		}
+		if orch == nil {
+			run.Message = err.Error()
+		} else {
+			if heimdallURL, err := config.DiscoverHeimdallEndpoint(cfg.Kurtosis.EnclaveName, cfg.ActiveProfile()); err == nil {
+				orch.SetHeimdallAPI(heimdallURL)
+			}
+			// Each run gets its own copy: DETECT appends universal criteria
+			// to the scenario it is given.
+			scen := *entry.scenario
+			scen.Spec.SuccessCriteria = append([]scenario.SuccessCriterion(nil), entry.scenario.Spec.SuccessCriteria...)
+
+			stopProgress := followProgress(orch, progress)
+			result, execErr := orch.ExecuteNext(context.Background(), &scen, entry.path)
+			stopProgress()
+			if result == nil {
+				// Emergency stop: the orchestrator refuses further runs.
+				fmt.Printf("⚠ %v\n", execErr)
+				break
+			}
+			run.TestID = result.TestID
+			run.Success = execErr == nil && result.Success
+			run.Message = result.Message
+			if execErr != nil {
+				run.Message = execErr.Error()
+			}
+			report := buildReport(&scen, result, orch)
+			report.Labels = labels
+			if _, err := storage.SaveReport(report); err != nil {
+				fmt.Printf("⚠ Failed to save report: %v\n", err)
+			}
+		}
+		current().recordRun(run)
+
+		if time.Since(lastCheckpoint) >= checkpointInterval {
+			emit()
+			lastCheckpoint = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(gap):
+		}
+	}
+	emit()
+
+	totalRuns, totalFailed, totalViolations := 0, 0, 0
+	for _, cp := range checkpoints {
+		totalRuns += cp.Passed + cp.Failed
+		totalFailed += cp.Failed
+		for _, n := range cp.InvariantViolations {
+			totalViolations += n
+		}
+	}
+	restoreStdout()
+	fmt.Printf("\nSoak finished after %s: %d run(s), %d failed, %d invariant violation(s)\n",
+		time.Since(start).Round(time.Second), totalRuns, totalFailed, totalViolations)
+
+	if totalFailed > 0 || totalViolations > 0 {
+		return fmt.Errorf("soak test failed: %d failed run(s), %d invariant violation(s)", totalFailed, totalViolations)
+	}
+	return nil
+}
+
+// soakEntry is one scenario in the rotation.
+type soakEntry struct {
+	path     string
+	scenario *scenario.Scenario
+}
+
+// loadSoakScenarios expands directories to their YAML files, then parses
+// and validates each scenario (every document of a multi-document file)
+// and rejects destructive fault types. Presets are rendered for profile.
+func loadSoakScenarios(paths []string, profile *config.Profile) ([]soakEntry, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("scenario path %s: %w", p, err)
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(p, "*.yaml"))
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no scenario files found in %s", strings.Join(paths, ", "))
+	}
+
+	var rotation []soakEntry
+	for _, f := range files {
+		p := parser.New(nil)
+		p.Profile = profile
+		docs, err := p.ParseFileAll(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to parse scenario: %w", f, err)
+		}
+		for _, s := range docs {
+			if err := validator.New().Validate(s); err != nil {
+				return nil, fmt.Errorf("%s: scenario %s validation failed: %w", f, s.Metadata.Name, err)
+			}
+			for _, fault := range s.Spec.Faults {
+				if soakExcludedFaultTypes[scenario.CanonicalFaultType(fault.Type)] {
+					return nil, fmt.Errorf("%s: fault %q has type %s, which is too destructive for soak", f, fault.Phase, fault.Type)
+				}
+			}
+			rotation = append(rotation, soakEntry{path: f, scenario: s})
+		}
+	}
+	return rotation, nil
+}
+
+// printSoakCheckpoint prints a one-block summary of a checkpoint window.
+func printSoakCheckpoint(cp soakCheckpoint) {
+	fmt.Printf("\n--- Soak checkpoint %d (%s – %s) ---\n", cp.Index, cp.Start.Format("15:04:05"), cp.End.Format("15:04:05"))
+	fmt.Printf("  Runs: %d passed, %d failed\n", cp.Passed, cp.Failed)
+	for _, r := range cp.Runs {
+		if !r.Success {
+			fmt.Printf("  ✗ %s (%s): %s\n", r.Scenario, r.TestID, r.Message)
+		}
+	}
+	if len(cp.InvariantViolations) == 0 {
+		fmt.Printf("  ✓ Invariants held across %d evaluation(s)\n", cp.InvariantEvaluations)
+		return
+	}
+	names := make([]string, 0, len(cp.InvariantViolations))
+	for name := range cp.InvariantViolations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  ✗ %s violated in %d of %d evaluation(s)\n", name, cp.InvariantViolations[name], cp.InvariantEvaluations)
+	}
+}
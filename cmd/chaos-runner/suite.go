@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/reporting"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/parser"
+)
+
+// loadSuite parses a ChaosSuite manifest and every scenario it lists, in
+// order, with the suite's and each entry's overrides applied. values
+// (--values) override the suite's variables; presets are rendered for
+// profile. paths holds the file each scenario came from.
+func loadSuite(suitePath string, values map[string]string, profile *config.Profile) (suite *scenario.Suite, scenarios []*scenario.Scenario, paths []string, err error) {
+	suite, err = parser.New(maps.Clone(values)).ParseSuiteFile(suitePath)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse suite: %w", err)
+	}
+
+	vars := maps.Clone(suite.Spec.Variables)
+	if vars == nil {
+		vars = make(map[string]string)
+	}
+	maps.Copy(vars, values)
+
+	for i, entry := range suite.Spec.Scenarios {
+		p := parser.New(maps.Clone(vars))
+		p.Profile = profile
+		docs, err := p.ParseFileAll(entry.Path)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("suite scenario %d (%s): %w", i+1, entry.Path, err)
+		}
+		for _, s := range docs {
+			for _, overrides := range []map[string]string{suite.Spec.Set, entry.Set} {
+				if err := parser.ApplyOverrides(s, overrides); err != nil {
+					return nil, nil, nil, fmt.Errorf("suite scenario %d (%s): %w", i+1, entry.Path, err)
+				}
+			}
+			scenarios = append(scenarios, s)
+			paths = append(paths, entry.Path)
+		}
+	}
+	return suite, scenarios, paths, nil
+}
+
+// printSuiteSummary prints one line per suite run and the totals.
+func printSuiteSummary(r *reporting.SuiteReport) {
+	fmt.Printf("\n=== Suite %s: %d passed, %d failed", r.Suite, r.Passed, r.Failed)
+	if r.Unknown > 0 {
+		fmt.Printf(", %d unknown", r.Unknown)
+	}
+	fmt.Printf(" (%s) ===\n", r.Duration)
+	for _, run := range r.Scenarios {
+		mark := "✗"
+		switch {
+		case run.Success:
+			mark = "✓"
+		case run.Unknown:
+			mark = "?"
+		}
+		fmt.Printf("  %s %-40s %-10s criteria %d/%d  %s\n", mark, run.Scenario, run.Status, run.CriteriaPassed, run.CriteriaPassed+run.CriteriaFailed, run.Duration)
+	}
+	if r.Message != "" {
+		fmt.Printf("  Suite stopped: %s\n", r.Message)
+	}
+}
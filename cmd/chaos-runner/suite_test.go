@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const suiteTestScenario = `
+apiVersion: chaos.polygon.io/v1
+kind: ChaosScenario
+metadata:
+  name: ${NAME}
+spec:
+  duration: 5m
+  targets:
+    - selector:
+        type: kurtosis_service
+        pattern: rpc
+      alias: rpc
+  faults:
+    - phase: lag
+      target: rpc
+      type: network
+      params:
+        latency: 100
+`
+
+func TestLoadSuite_OrderAndOverrides(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.yaml", suiteTestScenario)
+	write("b.yaml", suiteTestScenario)
+	write("suite.yaml", `
+apiVersion: chaos.polygon.io/v1
+kind: ChaosSuite
+metadata:
+  name: nightly
+spec:
+  variables:
+    NAME: from-suite
+  set:
+    duration: 2m
+  scenarios:
+    - path: b.yaml
+    - path: a.yaml
+      set:
+        duration: 3m
+`)
+
+	suite, scenarios, paths, err := loadSuite(filepath.Join(dir, "suite.yaml"), map[string]string{"NAME": "from-values"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if suite.Metadata.Name != "nightly" || len(scenarios) != 2 {
+		t.Fatalf("suite %q with %d scenarios, want nightly with 2", suite.Metadata.Name, len(scenarios))
+	}
+	if paths[0] != filepath.Join(dir, "b.yaml") || paths[1] != filepath.Join(dir, "a.yaml") {
+		t.Errorf("paths = %v, want b.yaml then a.yaml", paths)
+	}
+	if got := scenarios[0].Metadata.Name; got != "from-values" {
+		t.Errorf("name = %q, want --values to override suite variables", got)
+	}
+	if got := scenarios[0].Spec.Duration; got != 2*time.Minute {
+		t.Errorf("duration = %s, want suite set 2m", got)
+	}
+	if got := scenarios[1].Spec.Duration; got != 3*time.Minute {
+		t.Errorf("duration = %s, want entry set 3m", got)
+	}
+}
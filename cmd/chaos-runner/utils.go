@@ -2,18 +2,24 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/jihwankim/chaos-utils/pkg/audit"
 	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
+	"github.com/jihwankim/chaos-utils/pkg/gameday"
+	"github.com/jihwankim/chaos-utils/pkg/notify"
+	"github.com/jihwankim/chaos-utils/pkg/reporting"
 )
 
 // loadConfig loads the configuration from file, auto-generating if needed
 func loadConfig() (*config.Config, error) {
-	// Determine config file path
-	configPath := cfgFile
-	if configPath == "" {
-		configPath = "config.yaml"
-	}
+	configPath := configPath()
 
 	// Check if config exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -22,12 +28,11 @@ func loadConfig() (*config.Config, error) {
 		fmt.Println("   You can edit this file to customize settings (enclave name, Prometheus URL, etc.)")
 		fmt.Println()
 
-		cfg := config.DefaultConfig()
-		if err := cfg.Save(configPath); err != nil {
+		if err := os.WriteFile(configPath, config.DefaultYAML(), 0644); err != nil {
 			return nil, fmt.Errorf("failed to create default config: %w", err)
 		}
 
-		return cfg, nil
+		return config.DefaultConfig(), nil
 	}
 
 	// Load existing configuration
@@ -44,3 +49,230 @@ func loadConfig() (*config.Config, error) {
 	return cfg, nil
 }
 
+// configPath returns the config file path: --config, or ./config.yaml.
+func configPath() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	return "config.yaml"
+}
+
+// resolveProfile settles cfg.Kurtosis.Profile on a concrete built-in
+// profile. override (from --profile) wins over the config file; "auto" or
+// an empty value probes the enclave and falls back to the default profile
+// when detection fails.
+func resolveProfile(cfg *config.Config, override string) error {
+	name := cfg.Kurtosis.Profile
+	if override != "" {
+		name = override
+	}
+
+	if name != "" && name != config.ProfileAuto {
+		if _, ok := config.LookupProfile(name); !ok {
+			return fmt.Errorf("unknown deployment profile %q (available: auto, %s)", name, strings.Join(config.ProfileNames(), ", "))
+		}
+		cfg.Kurtosis.Profile = name
+		return nil
+	}
+
+	profile, err := config.DetectProfile(cfg.Kurtosis.EnclaveName)
+	if err != nil {
+		profile = config.DefaultProfile()
+		fmt.Printf("⚠ Deployment profile detection failed, using %s: %v\n", profile.Name, err)
+	} else {
+		fmt.Printf("Detected deployment profile: %s\n", profile.Name)
+	}
+	cfg.Kurtosis.Profile = profile.Name
+	return nil
+}
+
+// resolveRPCURL settles cfg.RPC.URL for rpc criteria. override (from
+// --rpc-url) wins over the config file; when neither sets it, the endpoint
+// is discovered from the enclave, and the profile's other RPC services
+// become fallbacks unless the config lists its own. A failed discovery is
+// not fatal: rpc criteria then report unknown.
+func resolveRPCURL(cfg *config.Config, override string) {
+	if override != "" {
+		cfg.RPC.URL = override
+		return
+	}
+	if cfg.RPC.URL != "" {
+		return
+	}
+	endpoints, err := config.DiscoverEVMRPCEndpoints(cfg.Kurtosis.EnclaveName, cfg.ActiveProfile())
+	if err != nil {
+		fmt.Printf("EVM RPC auto-discovery failed (rpc criteria will be unknown): %v\n", err)
+		return
+	}
+	cfg.RPC.URL = endpoints[0]
+	if len(cfg.RPC.Fallbacks) == 0 {
+		cfg.RPC.Fallbacks = endpoints[1:]
+	}
+	fmt.Printf("Discovered EVM RPC endpoint: %s (%d fallback(s))\n", cfg.RPC.URL, len(cfg.RPC.Fallbacks))
+}
+
+// parseLabels parses --label key=value flags. Unlike --set, a malformed
+// label is an error: a silently dropped label makes the run unfindable.
+func parseLabels(flags []string) (map[string]string, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	labels := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		key, value, ok := strings.Cut(flag, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --label %q: expected key=value", flag)
+		}
+		labels[key] = strings.TrimSpace(value)
+	}
+	return labels, nil
+}
+
+// newGatekeeper builds a GameDay gatekeeper from config. labels are
+// attached to every gate announcement.
+func newGatekeeper(cfg config.GameDayConfig, labels map[string]string) (*gameday.Gatekeeper, error) {
+	gates := make([]gameday.Gate, len(cfg.Gates))
+	for i, g := range cfg.Gates {
+		gates[i] = gameday.Gate(g)
+	}
+	return gameday.New(gameday.Config{
+		Gates:       gates,
+		Approval:    cfg.Approval,
+		ApprovalURL: cfg.ApprovalURL,
+		AnnounceURL: cfg.AnnounceURL,
+		Timeout:     cfg.Timeout,
+		Labels:      labels,
+	})
+}
+
+// newNotifier builds the webhook notifier configured under notify:, or
+// returns nil when no webhook is listed. labels are attached to every
+// event.
+func newNotifier(cfg config.NotifyConfig, labels map[string]string) (*notify.Notifier, error) {
+	if len(cfg.Webhooks) == 0 {
+		return nil, nil
+	}
+	hooks := make([]notify.Webhook, len(cfg.Webhooks))
+	for i, w := range cfg.Webhooks {
+		hooks[i] = notify.Webhook{Name: w.Name, URL: w.URL, Format: w.Format, Events: w.Events, Template: w.Template}
+	}
+	return notify.New(hooks, labels)
+}
+
+// newAuditSink opens the fault audit trail configured under audit:, or
+// returns nil when audit.sink is unset.
+func newAuditSink(cfg config.AuditConfig) (audit.Sink, error) {
+	if cfg.Sink == "" {
+		return nil, nil
+	}
+	return audit.New(audit.Config{Sink: cfg.Sink, SyslogAddr: cfg.SyslogAddr, Tag: cfg.Tag})
+}
+
+// cliLogLevel maps -q / -v / -vv to a logger level.
+func cliLogLevel() reporting.LogLevel {
+	switch {
+	case quiet:
+		return reporting.LogLevelError
+	case verbose >= 2:
+		return reporting.LogLevelTrace
+	case verbose == 1:
+		return reporting.LogLevelDebug
+	default:
+		return reporting.LogLevelInfo
+	}
+}
+
+// logOutput is where the structured logger writes: stderr in quiet mode, so
+// errors survive silenceStdout, stdout otherwise.
+func logOutput() io.Writer {
+	if quiet {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// silenceStdout points os.Stdout at /dev/null so the orchestrator's progress
+// output is dropped, and returns an idempotent func that restores it.
+func silenceStdout() func() {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return func() {}
+	}
+	orig := os.Stdout
+	os.Stdout = devNull
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			os.Stdout = orig
+			devNull.Close()
+		})
+	}
+}
+
+// plainOutput routes os.Stdout and os.Stderr through reporting.Plain
+// (--no-emoji), so every print — progress, summary, orchestrator phases —
+// is rendered as ASCII without each call site knowing. The returned func
+// restores both and waits for buffered output to drain; call it before
+// exiting.
+func plainOutput() func() {
+	var restores []func()
+	for _, f := range []**os.File{&os.Stdout, &os.Stderr} {
+		r, w, err := os.Pipe()
+		if err != nil {
+			continue
+		}
+		orig := *f
+		*f = w
+		done := make(chan struct{})
+		go func() {
+			io.Copy(reporting.NewPlainWriter(orig), r)
+			close(done)
+		}()
+		f := f
+		restores = append(restores, func() {
+			*f = orig
+			w.Close()
+			<-done
+			r.Close()
+		})
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			for _, restore := range restores {
+				restore()
+			}
+		})
+	}
+}
+
+// secretPattern matches what looks like a hex private key. Injectors hand
+// secrets to commands over stdin, so this only guards against one slipping
+// into a command line.
+var secretPattern = regexp.MustCompile(`\b(0x)?[0-9a-fA-F]{64}\b`)
+
+// redactCommand joins cmd for logging with anything key-shaped replaced.
+func redactCommand(cmd []string) string {
+	return secretPattern.ReplaceAllString(strings.Join(cmd, " "), "<redacted>")
+}
+
+// execTracer logs every docker exec at trace level, so -vv shows the exact
+// commands injectors ran inside targets and sidecars, less any secrets.
+func execTracer(logger *reporting.Logger) docker.ExecTracer {
+	return func(containerID string, cmd []string, exitCode int, elapsed time.Duration, err error) {
+		if len(containerID) > 12 {
+			containerID = containerID[:12]
+		}
+		fields := []interface{}{
+			"container", containerID,
+			"cmd", redactCommand(cmd),
+			"exit_code", exitCode,
+			"elapsed", elapsed.Round(time.Millisecond).String(),
+		}
+		if err != nil {
+			fields = append(fields, "error", err)
+		}
+		logger.Trace("docker exec", fields...)
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestRedactCommand(t *testing.T) {
+	key := "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	tests := []struct {
+		name string
+		cmd  []string
+		want string
+	}{
+		{"plain", []string{"tc", "qdisc", "show"}, "tc qdisc show"},
+		{"bare key", []string{"sh", "-c", "echo " + key + " > /tmp/k"}, "sh -c echo <redacted> > /tmp/k"},
+		{"0x key", []string{"chaos-txflood", "--key", "0x" + key}, "chaos-txflood --key <redacted>"},
+		{"short hex kept", []string{"pgrep", "-f", "abcdef1234567890"}, "pgrep -f abcdef1234567890"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactCommand(tt.cmd); got != tt.want {
+				t.Errorf("redactCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
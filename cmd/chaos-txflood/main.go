@@ -0,0 +1,117 @@
+// chaos-txflood floods a Bor node's transaction pool with valid,
+// low-priority transactions signed by a funded key. It runs in the target's
+// sidecar for the txpool_saturation fault and sends until --count
+// transactions are out or it receives SIGTERM.
+//
+// Usage:
+//
+//	chaos-txflood --rpc http://127.0.0.1:8545 --key-file /tmp/key --rate 200 --count 50000
+//
+// The key file holds the hex private key and is deleted once read.
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/injection/txpool"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagRPC             string
+	flagKeyFile         string
+	flagRate            float64
+	flagCount           int
+	flagGasPriceGwei    float64
+	flagKeepKey         bool
+	flagVerbose         bool
+	flagAllowProduction bool
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:   "chaos-txflood",
+		Short: "Bor txpool flood for the txpool_saturation fault",
+		Long: `chaos-txflood sends zero-value self-transfers from a funded account to a
+Bor node's JSON-RPC at a fixed rate. They are valid and priced at the node's
+suggested gas price (or --gas-price-gwei), so they fill the pool and
+compete with real traffic for block space without being rejected outright.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          run,
+	}
+
+	root.Flags().StringVar(&flagRPC, "rpc", "http://127.0.0.1:8545", "Bor JSON-RPC URL")
+	root.Flags().StringVar(&flagKeyFile, "key-file", "", "file holding the funded account's hex private key (deleted once read)")
+	root.Flags().Float64Var(&flagRate, "rate", 100, "transactions per second")
+	root.Flags().IntVar(&flagCount, "count", 0, "stop after this many transactions (0 = until terminated)")
+	root.Flags().Float64Var(&flagGasPriceGwei, "gas-price-gwei", 0, "gas price in gwei (0 = the node's eth_gasPrice)")
+	root.Flags().BoolVar(&flagKeepKey, "keep-key", false, "do not delete the key file after reading it")
+	root.Flags().BoolVarP(&flagVerbose, "verbose", "v", false, "enable debug logging")
+	root.Flags().BoolVar(&flagAllowProduction, "allow-production", false, "allow flooding known production networks (use with extreme caution)")
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(cmd *cobra.Command, _ []string) error {
+	level := zerolog.InfoLevel
+	if flagVerbose {
+		level = zerolog.DebugLevel
+	}
+	log := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}).
+		Level(level).
+		With().
+		Timestamp().
+		Logger()
+
+	if flagKeyFile == "" {
+		return fmt.Errorf("--key-file is required")
+	}
+	data, err := os.ReadFile(flagKeyFile)
+	if err != nil {
+		return fmt.Errorf("read key: %w", err)
+	}
+	if !flagKeepKey {
+		os.Remove(flagKeyFile)
+	}
+	key, err := txpool.ParseKey(string(data))
+	if err != nil {
+		return err
+	}
+
+	cfg := txpool.FloodConfig{
+		RPCURL:          flagRPC,
+		Key:             key,
+		Rate:            flagRate,
+		Count:           flagCount,
+		AllowProduction: flagAllowProduction,
+	}
+	if flagGasPriceGwei > 0 {
+		wei, _ := new(big.Float).Mul(big.NewFloat(flagGasPriceGwei), big.NewFloat(1e9)).Int(nil)
+		cfg.GasPrice = wei
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	stats, err := txpool.Flood(ctx, cfg, log)
+	if err != nil {
+		return err
+	}
+	log.Info().
+		Int("sent", stats.Sent).
+		Int("accepted", stats.Accepted).
+		Int("rejected", stats.Rejected).
+		Str("last_error", stats.LastError).
+		Msg("chaos-txflood finished")
+	return nil
+}
@@ -0,0 +1,130 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	chaosagentv1 "github.com/jihwankim/chaos-utils/api/gen/chaosagent/v1"
+	"github.com/jihwankim/chaos-utils/pkg/injection"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"gopkg.in/yaml.v3"
+)
+
+// Client calls one chaos-agent.
+type Client struct {
+	// Name identifies the agent in output and in TargetInfo.Agent.
+	Name string
+	// Address is the agent's host:port.
+	Address string
+
+	conn *grpc.ClientConn
+	api  chaosagentv1.ChaosAgentClient
+}
+
+// Dial returns a client for the agent at address, authenticating with
+// token. The connection is made lazily, on the first call.
+func Dial(name, address, token string) (*Client, error) {
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(tokenCredentials(token)))
+	if err != nil {
+		return nil, fmt.Errorf("agent %s (%s): %w", name, address, err)
+	}
+	return &Client{Name: name, Address: address, conn: conn, api: chaosagentv1.NewChaosAgentClient(conn)}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error { return c.conn.Close() }
+
+// Ping returns the agent's hostname, Docker version and agent version.
+func (c *Client) Ping(ctx context.Context) (*chaosagentv1.PingResponse, error) {
+	resp, err := c.api.Ping(ctx, &chaosagentv1.PingRequest{})
+	if err != nil {
+		return nil, c.wrap(err)
+	}
+	return resp, nil
+}
+
+// ListContainers lists the running containers on the agent's host.
+func (c *Client) ListContainers(ctx context.Context) ([]*chaosagentv1.Container, error) {
+	resp, err := c.api.ListContainers(ctx, &chaosagentv1.ListContainersRequest{})
+	if err != nil {
+		return nil, c.wrap(err)
+	}
+	return resp.GetContainers(), nil
+}
+
+// Prepare creates a sidecar for each of targets; cgroupAccess lists the
+// container IDs whose sidecar needs the host cgroups.
+func (c *Client) Prepare(ctx context.Context, targets []injection.Target, cgroupAccess map[string]bool) error {
+	req := &chaosagentv1.PrepareRequest{}
+	for _, t := range targets {
+		req.Targets = append(req.Targets, &chaosagentv1.PrepareTarget{
+			Target:       &chaosagentv1.Target{Name: t.Name, ContainerId: t.ContainerID},
+			CgroupAccess: cgroupAccess[t.ContainerID],
+		})
+	}
+	_, err := c.api.Prepare(ctx, req)
+	return c.wrap(err)
+}
+
+// InjectFault installs fault on targets.
+func (c *Client) InjectFault(ctx context.Context, fault *scenario.Fault, targets []injection.Target) error {
+	data, err := yaml.Marshal(fault)
+	if err != nil {
+		return fmt.Errorf("failed to encode fault: %w", err)
+	}
+	req := &chaosagentv1.InjectFaultRequest{FaultYaml: data}
+	for _, t := range targets {
+		req.Targets = append(req.Targets, &chaosagentv1.Target{Name: t.Name, ContainerId: t.ContainerID})
+	}
+	_, err = c.api.InjectFault(ctx, req)
+	return c.wrap(err)
+}
+
+// RemoveFault removes a fault of faultType from target. params are the
+// fault's, for an agent that no longer remembers installing it.
+func (c *Client) RemoveFault(ctx context.Context, faultType string, target injection.Target, params map[string]interface{}) error {
+	data, err := yaml.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode params: %w", err)
+	}
+	_, err = c.api.RemoveFault(ctx, &chaosagentv1.RemoveFaultRequest{
+		FaultType:  faultType,
+		Target:     &chaosagentv1.Target{Name: target.Name, ContainerId: target.ContainerID},
+		ParamsYaml: data,
+	})
+	return c.wrap(err)
+}
+
+// Cleanup removes every fault the agent still has installed and destroys
+// its sidecars, returning how many faults it removed and what it could
+// not clean up.
+func (c *Client) Cleanup(ctx context.Context) (int, []string, error) {
+	resp, err := c.api.Cleanup(ctx, &chaosagentv1.CleanupRequest{})
+	if err != nil {
+		return 0, nil, c.wrap(err)
+	}
+	return int(resp.GetFaultsRemoved()), resp.GetErrors(), nil
+}
+
+// wrap names the agent in err.
+func (c *Client) wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("agent %s: %w", c.Name, err)
+}
+
+// tokenCredentials sends the shared token with every call. The agent API
+// is plaintext, like the control API, so it does not require transport
+// security.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{tokenHeader: "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool { return false }
@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	chaosagentv1 "github.com/jihwankim/chaos-utils/api/gen/chaosagent/v1"
+	"github.com/jihwankim/chaos-utils/pkg/core/cleanup"
+	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
+	"github.com/jihwankim/chaos-utils/pkg/injection"
+	"github.com/jihwankim/chaos-utils/pkg/injection/sidecar"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// dockerHost injects through the local Docker daemon with the same
+// injector, sidecar manager and cleanup coordinator chaos-runner uses.
+type dockerHost struct {
+	docker       *docker.Client
+	sidecarMgr   *sidecar.Manager
+	injector     *injection.Injector
+	cleanupCoord *cleanup.Coordinator
+}
+
+func newDockerHost(sidecarImage string) (*dockerHost, error) {
+	dockerClient, err := docker.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	sidecarMgr := sidecar.New(dockerClient, sidecarImage)
+	return &dockerHost{
+		docker:       dockerClient,
+		sidecarMgr:   sidecarMgr,
+		injector:     injection.New(sidecarMgr, dockerClient),
+		cleanupCoord: cleanup.New(sidecarMgr),
+	}, nil
+}
+
+func (h *dockerHost) close() error { return h.docker.Close() }
+
+func (h *dockerHost) dockerVersion(ctx context.Context) (string, error) {
+	info, err := h.docker.GetClient().Info(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.ServerVersion, nil
+}
+
+func (h *dockerHost) containers(ctx context.Context) ([]*chaosagentv1.Container, error) {
+	list, err := h.docker.ContainerList(ctx, types.ContainerListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	containers := make([]*chaosagentv1.Container, 0, len(list))
+	for _, c := range list {
+		ctr := &chaosagentv1.Container{Id: c.ID, Names: c.Names}
+		if c.NetworkSettings != nil {
+			for _, net := range c.NetworkSettings.Networks {
+				if net != nil && net.IPAddress != "" {
+					ctr.Ip = net.IPAddress
+					break
+				}
+			}
+		}
+		containers = append(containers, ctr)
+	}
+	return containers, nil
+}
+
+// prepare creates a sidecar for target unless it has one.
+func (h *dockerHost) prepare(ctx context.Context, target injection.Target, cgroupAccess bool) error {
+	if _, err := h.docker.ContainerInspect(ctx, target.ContainerID); err != nil {
+		if errdefs.IsNotFound(err) {
+			return fmt.Errorf("%s (%s): %w", target.Name, target.ContainerID, errUnknownTarget)
+		}
+		return err
+	}
+	if _, ok := h.sidecarMgr.GetSidecarID(target.ContainerID); ok {
+		return nil
+	}
+	create := h.sidecarMgr.CreateSidecar
+	if cgroupAccess {
+		create = h.sidecarMgr.CreateCgroupSidecar
+	}
+	if _, err := create(ctx, target.ContainerID); err != nil {
+		return fmt.Errorf("failed to create sidecar for %s: %w", target.Name, err)
+	}
+	return nil
+}
+
+func (h *dockerHost) inject(ctx context.Context, fault *scenario.Fault, targets []injection.Target) error {
+	return h.injector.InjectFault(ctx, fault, targets)
+}
+
+func (h *dockerHost) remove(ctx context.Context, faultType string, target injection.Target) error {
+	return h.injector.RemoveFault(ctx, faultType, target.ContainerID)
+}
+
+func (h *dockerHost) recover(ctx context.Context, faultType string, target injection.Target, params map[string]interface{}) error {
+	return h.injector.RecoverFault(ctx, faultType, target.ContainerID, target.Name, params)
+}
+
+func (h *dockerHost) destroySidecars(ctx context.Context) error {
+	return h.cleanupCoord.CleanupAll(ctx)
+}
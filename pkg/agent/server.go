@@ -0,0 +1,220 @@
+// Package agent serves and consumes the chaos-agent injection API
+// (api/proto/chaosagent/v1/chaos_agent.proto). chaos-agent runs a Server on
+// each Docker host of a multi-host deployment; chaos-runner reaches the
+// containers on those hosts through a Client per agent, so no one host
+// needs access to every daemon.
+package agent
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	chaosagentv1 "github.com/jihwankim/chaos-utils/api/gen/chaosagent/v1"
+	"github.com/jihwankim/chaos-utils/pkg/injection"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// errUnknownTarget marks a container the daemon does not know.
+var errUnknownTarget = errors.New("no such container")
+
+// host is what the server needs from the Docker host it runs on. NewServer
+// backs it with the local daemon; tests use a fake.
+type host interface {
+	dockerVersion(ctx context.Context) (string, error)
+	containers(ctx context.Context) ([]*chaosagentv1.Container, error)
+	prepare(ctx context.Context, target injection.Target, cgroupAccess bool) error
+	inject(ctx context.Context, fault *scenario.Fault, targets []injection.Target) error
+	remove(ctx context.Context, faultType string, target injection.Target) error
+	// recover removes a fault this process did not install, from its params.
+	recover(ctx context.Context, faultType string, target injection.Target, params map[string]interface{}) error
+	destroySidecars(ctx context.Context) error
+}
+
+// installedFault is one fault the agent installed on one container.
+type installedFault struct {
+	faultType string
+	target    injection.Target
+}
+
+// Server implements chaosagentv1.ChaosAgentServer. It remembers the faults
+// it installed, so Cleanup can remove whatever the runner did not.
+type Server struct {
+	chaosagentv1.UnimplementedChaosAgentServer
+
+	host    host
+	version string
+
+	mu        sync.Mutex
+	installed []installedFault
+}
+
+// NewServer returns a server that injects through the local Docker daemon,
+// creating sidecars from sidecarImage. version is reported by Ping.
+func NewServer(sidecarImage, version string) (*Server, error) {
+	h, err := newDockerHost(sidecarImage)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{host: h, version: version}, nil
+}
+
+// Close releases the Docker client. Call Cleanup first.
+func (s *Server) Close() error {
+	if h, ok := s.host.(*dockerHost); ok {
+		return h.close()
+	}
+	return nil
+}
+
+// Ping reports the agent's hostname and daemon version.
+func (s *Server) Ping(ctx context.Context, _ *chaosagentv1.PingRequest) (*chaosagentv1.PingResponse, error) {
+	hostname, _ := os.Hostname()
+	version, err := s.host.dockerVersion(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "docker daemon: %v", err)
+	}
+	return &chaosagentv1.PingResponse{Hostname: hostname, DockerVersion: version, AgentVersion: s.version}, nil
+}
+
+// ListContainers lists the running containers on the host.
+func (s *Server) ListContainers(ctx context.Context, _ *chaosagentv1.ListContainersRequest) (*chaosagentv1.ListContainersResponse, error) {
+	containers, err := s.host.containers(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to list containers: %v", err)
+	}
+	return &chaosagentv1.ListContainersResponse{Containers: containers}, nil
+}
+
+// Prepare creates the sidecars of the request's targets.
+func (s *Server) Prepare(ctx context.Context, req *chaosagentv1.PrepareRequest) (*chaosagentv1.PrepareResponse, error) {
+	for _, p := range req.GetTargets() {
+		target := injection.Target{Name: p.GetTarget().GetName(), ContainerID: p.GetTarget().GetContainerId()}
+		if err := s.host.prepare(ctx, target, p.GetCgroupAccess()); err != nil {
+			if errors.Is(err, errUnknownTarget) {
+				return nil, status.Error(codes.NotFound, err.Error())
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return &chaosagentv1.PrepareResponse{}, nil
+}
+
+// InjectFault installs the fault on the request's targets.
+func (s *Server) InjectFault(ctx context.Context, req *chaosagentv1.InjectFaultRequest) (*chaosagentv1.InjectFaultResponse, error) {
+	var fault scenario.Fault
+	if err := yaml.Unmarshal(req.GetFaultYaml(), &fault); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse fault: %v", err)
+	}
+	if fault.Type == "" {
+		return nil, status.Error(codes.InvalidArgument, "fault has no type")
+	}
+	if len(req.GetTargets()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no targets")
+	}
+	targets := make([]injection.Target, len(req.GetTargets()))
+	for i, t := range req.GetTargets() {
+		targets[i] = injection.Target{Name: t.GetName(), ContainerID: t.GetContainerId()}
+	}
+
+	// As with a local inject, what a failed inject left behind is cleared
+	// from the namespace when its sidecar is destroyed.
+	if err := s.host.inject(ctx, &fault, targets); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s %s: %v", fault.Phase, fault.Type, err)
+	}
+	s.mu.Lock()
+	for _, t := range targets {
+		s.installed = append(s.installed, installedFault{faultType: fault.Type, target: t})
+	}
+	s.mu.Unlock()
+	return &chaosagentv1.InjectFaultResponse{}, nil
+}
+
+// RemoveFault removes one fault from one container. A fault the agent has
+// no record of is recovered from its params instead.
+func (s *Server) RemoveFault(ctx context.Context, req *chaosagentv1.RemoveFaultRequest) (*chaosagentv1.RemoveFaultResponse, error) {
+	target := injection.Target{Name: req.GetTarget().GetName(), ContainerID: req.GetTarget().GetContainerId()}
+	if req.GetFaultType() == "" || target.ContainerID == "" {
+		return nil, status.Error(codes.InvalidArgument, "fault_type and target.container_id are required")
+	}
+
+	var err error
+	if s.forget(req.GetFaultType(), target.ContainerID) {
+		err = s.host.remove(ctx, req.GetFaultType(), target)
+	} else {
+		var params map[string]interface{}
+		if perr := yaml.Unmarshal(req.GetParamsYaml(), &params); perr != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse params: %v", perr)
+		}
+		err = s.host.recover(ctx, req.GetFaultType(), target, params)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "remove %s from %s: %v", req.GetFaultType(), target.Name, err)
+	}
+	return &chaosagentv1.RemoveFaultResponse{}, nil
+}
+
+// forget drops the latest record of faultType on containerID, reporting
+// whether there was one.
+func (s *Server) forget(faultType, containerID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.installed) - 1; i >= 0; i-- {
+		if f := s.installed[i]; f.faultType == faultType && f.target.ContainerID == containerID {
+			s.installed = append(s.installed[:i], s.installed[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Cleanup removes every fault still installed, newest first so stacked
+// tc qdiscs and iptables rules come off in order, then destroys the
+// sidecars.
+func (s *Server) Cleanup(ctx context.Context, _ *chaosagentv1.CleanupRequest) (*chaosagentv1.CleanupResponse, error) {
+	s.mu.Lock()
+	installed := s.installed
+	s.installed = nil
+	s.mu.Unlock()
+
+	resp := &chaosagentv1.CleanupResponse{}
+	for i := len(installed) - 1; i >= 0; i-- {
+		f := installed[i]
+		if err := s.host.remove(ctx, f.faultType, f.target); err != nil {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("%s on %s: %v", f.faultType, f.target.Name, err))
+			continue
+		}
+		resp.FaultsRemoved++
+	}
+	if err := s.host.destroySidecars(ctx); err != nil {
+		resp.Errors = append(resp.Errors, fmt.Sprintf("sidecars: %v", err))
+	}
+	return resp, nil
+}
+
+// tokenHeader is the metadata key carrying the shared token.
+const tokenHeader = "authorization"
+
+// TokenAuth returns a unary interceptor that rejects calls without
+// "Bearer <token>" in their authorization metadata with UNAUTHENTICATED.
+func TokenAuth(token string) grpc.UnaryServerInterceptor {
+	want := []byte("Bearer " + token)
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, got := range md.Get(tokenHeader) {
+			if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(got)), want) == 1 {
+				return handler(ctx, req)
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing or wrong agent token")
+	}
+}
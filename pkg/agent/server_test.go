@@ -0,0 +1,158 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	chaosagentv1 "github.com/jihwankim/chaos-utils/api/gen/chaosagent/v1"
+	"github.com/jihwankim/chaos-utils/pkg/injection"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeHost records what the server asks of the Docker host.
+type fakeHost struct {
+	injected  []*scenario.Fault
+	calls     []string
+	destroyed bool
+}
+
+func (h *fakeHost) dockerVersion(context.Context) (string, error) { return "27.0.0", nil }
+
+func (h *fakeHost) containers(context.Context) ([]*chaosagentv1.Container, error) {
+	return []*chaosagentv1.Container{{Id: "c1", Names: []string{"/l2-el-1--abc"}, Ip: "10.0.0.1"}}, nil
+}
+
+func (h *fakeHost) prepare(_ context.Context, t injection.Target, cgroup bool) error {
+	if t.ContainerID == "gone" {
+		return errUnknownTarget
+	}
+	return nil
+}
+
+func (h *fakeHost) inject(_ context.Context, f *scenario.Fault, _ []injection.Target) error {
+	h.injected = append(h.injected, f)
+	return nil
+}
+
+func (h *fakeHost) remove(_ context.Context, faultType string, t injection.Target) error {
+	h.calls = append(h.calls, "remove "+faultType+" "+t.ContainerID)
+	return nil
+}
+
+func (h *fakeHost) recover(_ context.Context, faultType string, t injection.Target, params map[string]interface{}) error {
+	h.calls = append(h.calls, "recover "+faultType+" "+t.ContainerID)
+	if params["method"] != "stress" {
+		return errors.New("params not passed")
+	}
+	return nil
+}
+
+func (h *fakeHost) destroySidecars(context.Context) error {
+	h.destroyed = true
+	return nil
+}
+
+// startAgent serves a Server backed by h on a loopback port, requiring
+// token, and returns the listen address.
+func startAgent(t *testing.T, h host, token string) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := grpc.NewServer(grpc.UnaryInterceptor(TokenAuth(token)))
+	chaosagentv1.RegisterChaosAgentServer(gs, &Server{host: h, version: "test"})
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+	return lis.Addr().String()
+}
+
+func dial(t *testing.T, addr, token string) *Client {
+	t.Helper()
+	c, err := Dial("host-b", addr, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestServer_TokenAuth(t *testing.T) {
+	addr := startAgent(t, &fakeHost{}, "s3cret")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := dial(t, addr, "wrong").Ping(ctx)
+	if status.Code(errors.Unwrap(err)) != codes.Unauthenticated {
+		t.Errorf("Ping() with wrong token error = %v, want Unauthenticated", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "agent host-b") {
+		t.Errorf("error %v does not name the agent", err)
+	}
+	resp, err := dial(t, addr, "s3cret").Ping(ctx)
+	if err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if resp.GetDockerVersion() != "27.0.0" || resp.GetAgentVersion() != "test" {
+		t.Errorf("Ping() = %v", resp)
+	}
+}
+
+func TestServer_InjectRemoveCleanup(t *testing.T) {
+	h := &fakeHost{}
+	c := dial(t, startAgent(t, h, "tok"), "tok")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c1 := injection.Target{Name: "l2-el-1", ContainerID: "c1"}
+	if err := c.Prepare(ctx, []injection.Target{{Name: "old", ContainerID: "gone"}}, nil); status.Code(errors.Unwrap(err)) != codes.NotFound {
+		t.Errorf("Prepare() of unknown container error = %v, want NotFound", err)
+	}
+
+	policy, _ := scenario.ParseFailurePolicy("min_targets(1)")
+	fault := &scenario.Fault{
+		Phase:         "latency",
+		Target:        "el",
+		Type:          "network",
+		Params:        map[string]interface{}{"latency": 200},
+		Duration:      90 * time.Second,
+		FailurePolicy: policy,
+	}
+	for _, f := range []*scenario.Fault{fault, {Phase: "drop", Target: "el", Type: "connection_drop"}} {
+		if err := c.InjectFault(ctx, f, []injection.Target{c1}); err != nil {
+			t.Fatalf("InjectFault(%s) error = %v", f.Phase, err)
+		}
+	}
+	if got := h.injected[0]; !reflect.DeepEqual(got, fault) {
+		t.Errorf("fault did not survive the trip: got %+v, want %+v", got, fault)
+	}
+
+	// A fault the agent installed is removed; one it has no record of is
+	// recovered from its params.
+	if err := c.RemoveFault(ctx, "network", c1, fault.Params); err != nil {
+		t.Fatalf("RemoveFault() error = %v", err)
+	}
+	if err := c.RemoveFault(ctx, "cpu_stress", c1, map[string]interface{}{"method": "stress"}); err != nil {
+		t.Fatalf("RemoveFault() of unknown fault error = %v", err)
+	}
+
+	removed, errs, err := c.Cleanup(ctx)
+	if err != nil || removed != 1 || len(errs) != 0 {
+		t.Fatalf("Cleanup() = %d, %v, %v; want 1 removed", removed, errs, err)
+	}
+	want := []string{"remove network c1", "recover cpu_stress c1", "remove connection_drop c1"}
+	if !reflect.DeepEqual(h.calls, want) {
+		t.Errorf("host calls = %v, want %v", h.calls, want)
+	}
+	if !h.destroyed {
+		t.Error("Cleanup() left the sidecars")
+	}
+}
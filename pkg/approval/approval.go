@@ -0,0 +1,272 @@
+// Package approval confirms scenarios marked metadata.destructive before
+// any fault is installed. Confirmation is a second, deliberate step on
+// top of GameDay gates: the operator types the scenario's name, or an
+// approval service hands out a token naming who approved and in which
+// role. The resulting Approval is what the runner records in the audit
+// trail and the report.
+package approval
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+)
+
+// Methods.
+const (
+	MethodPhrase = "phrase"
+	MethodToken  = "token"
+)
+
+// Config configures an Approver.
+type Config struct {
+	// Method is MethodPhrase (default) or MethodToken.
+	Method string
+	// TokenURL is polled with GET ?test_id=…&scenario=… in token mode. A
+	// 2xx response with {"token": …, "approver": …, "role": …} approves;
+	// anything else (404, no approval yet) keeps waiting.
+	TokenURL string
+	// Roles the approver must hold. In token mode the role is the one the
+	// token service returns; in phrase mode it is one of the operator's
+	// Unix groups. Empty accepts anyone.
+	Roles []string
+	// Timeout bounds the wait; an unanswered confirmation is rejected.
+	// Zero means wait indefinitely.
+	Timeout time.Duration
+	// PollInterval is the token polling interval (default 5s).
+	PollInterval time.Duration
+}
+
+// Approval records who confirmed a destructive scenario, and how.
+type Approval struct {
+	Method   string `json:"method"`
+	Approver string `json:"approver"`
+	Role     string `json:"role,omitempty"`
+	// TokenSHA256 fingerprints the approval token so it can be matched
+	// against the token service's records; the token itself is never
+	// stored.
+	TokenSHA256 string    `json:"token_sha256,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// ErrNotApproved is returned by Confirm when the scenario is rejected or
+// the wait times out.
+type ErrNotApproved struct {
+	Scenario string
+	Reason   string
+}
+
+func (e *ErrNotApproved) Error() string {
+	return fmt.Sprintf("destructive scenario %s not approved: %s", e.Scenario, e.Reason)
+}
+
+// Approver confirms destructive scenarios.
+type Approver struct {
+	cfg    Config
+	client *http.Client
+	in     io.Reader
+	out    io.Writer
+	// groups returns the operator's user name and Unix group names.
+	groups func() (string, []string, error)
+}
+
+// New validates cfg and creates an Approver.
+func New(cfg Config) (*Approver, error) {
+	if cfg.Method == "" {
+		cfg.Method = MethodPhrase
+	}
+	if cfg.Method != MethodPhrase && cfg.Method != MethodToken {
+		return nil, fmt.Errorf("approval: unknown method %q (use %s or %s)", cfg.Method, MethodPhrase, MethodToken)
+	}
+	if cfg.Method == MethodToken && cfg.TokenURL == "" {
+		return nil, fmt.Errorf("approval: token_url is required for token approval")
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	return &Approver{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		in:     os.Stdin,
+		out:    os.Stdout,
+		groups: currentUser,
+	}, nil
+}
+
+// Confirm blocks until the destructive scenario is approved and returns
+// the approval. It returns *ErrNotApproved when it is rejected or times
+// out, and ctx.Err() when ctx is cancelled. summary describes what is
+// about to be injected.
+func (a *Approver) Confirm(ctx context.Context, testID, scenario, summary string) (*Approval, error) {
+	if a.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.cfg.Timeout)
+		defer cancel()
+	}
+
+	var (
+		ap  *Approval
+		err error
+	)
+	if a.cfg.Method == MethodToken {
+		fmt.Fprintf(a.out, "⏸  Destructive scenario %s: waiting for an approval token from %s\n", scenario, a.cfg.TokenURL)
+		ap, err = a.waitToken(ctx, testID, scenario)
+	} else {
+		ap, err = a.confirmPhrase(ctx, scenario, summary)
+	}
+
+	if err == context.DeadlineExceeded && a.cfg.Timeout > 0 {
+		err = &ErrNotApproved{Scenario: scenario, Reason: fmt.Sprintf("no approval within %s", a.cfg.Timeout)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	ap.Method = a.cfg.Method
+	ap.Time = time.Now()
+	who := ap.Approver
+	if ap.Role != "" {
+		who += " (" + ap.Role + ")"
+	}
+	fmt.Fprintf(a.out, "▶  Destructive scenario %s approved by %s\n", scenario, who)
+	return ap, nil
+}
+
+// confirmPhrase has the operator type the scenario name. The approver is
+// the OS user running the runner; with Roles set, they must belong to
+// one of them as a Unix group. The read runs in a goroutine so a timeout
+// or cancellation is not stuck behind a blocking read.
+func (a *Approver) confirmPhrase(ctx context.Context, scenario, summary string) (*Approval, error) {
+	name, groups, err := a.groups()
+	if err != nil {
+		return nil, fmt.Errorf("approval: cannot identify the operator: %w", err)
+	}
+	role, ok := a.matchRole(groups...)
+	if !ok {
+		return nil, &ErrNotApproved{Scenario: scenario, Reason: fmt.Sprintf("%s is in none of the groups %v", name, a.cfg.Roles)}
+	}
+
+	fmt.Fprintf(a.out, "\n⚠  Scenario %s is marked destructive", scenario)
+	if summary != "" {
+		fmt.Fprintf(a.out, " — %s", summary)
+	}
+	fmt.Fprintf(a.out, "\n   Type the scenario name to inject, anything else to abort: ")
+
+	answers := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(a.in).ReadString('\n')
+		answers <- strings.TrimSpace(line)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case answer := <-answers:
+		if answer != scenario {
+			return nil, &ErrNotApproved{Scenario: scenario, Reason: fmt.Sprintf("operator typed %q", answer)}
+		}
+		return &Approval{Approver: name, Role: role}, nil
+	}
+}
+
+// waitToken polls TokenURL until it hands out a token.
+func (a *Approver) waitToken(ctx context.Context, testID, scenario string) (*Approval, error) {
+	u, err := url.Parse(a.cfg.TokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("approval: invalid token_url: %w", err)
+	}
+	q := u.Query()
+	q.Set("test_id", testID)
+	q.Set("scenario", scenario)
+	u.RawQuery = q.Encode()
+
+	ticker := time.NewTicker(a.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if tok, ok := a.fetchToken(ctx, u.String()); ok {
+			if _, allowed := a.matchRole(tok.Role); !allowed {
+				return nil, &ErrNotApproved{Scenario: scenario, Reason: fmt.Sprintf("approver %s has role %q, want one of %v", tok.Approver, tok.Role, a.cfg.Roles)}
+			}
+			sum := sha256.Sum256([]byte(tok.Token))
+			return &Approval{Approver: tok.Approver, Role: tok.Role, TokenSHA256: hex.EncodeToString(sum[:])}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// token is the token service's response body.
+type token struct {
+	Token    string `json:"token"`
+	Approver string `json:"approver"`
+	Role     string `json:"role"`
+}
+
+// fetchToken makes one token request. ok is false when no one has
+// approved yet or the service could not be reached.
+func (a *Approver) fetchToken(ctx context.Context, u string) (tok token, ok bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return tok, false
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return tok, false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return tok, false
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil || tok.Token == "" || tok.Approver == "" {
+		return tok, false
+	}
+	return tok, true
+}
+
+// matchRole returns the first of held that is an allowed role. With no
+// roles configured anyone is allowed, with no role.
+func (a *Approver) matchRole(held ...string) (string, bool) {
+	if len(a.cfg.Roles) == 0 {
+		return "", true
+	}
+	for _, h := range held {
+		for _, r := range a.cfg.Roles {
+			if h != "" && h == r {
+				return h, true
+			}
+		}
+	}
+	return "", false
+}
+
+// currentUser returns the OS user's name and the names of their groups.
+func currentUser() (string, []string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", nil, err
+	}
+	ids, err := u.GroupIds()
+	if err != nil {
+		return u.Username, nil, nil
+	}
+	groups := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if g, err := user.LookupGroupId(id); err == nil {
+			groups = append(groups, g.Name)
+		}
+	}
+	return u.Username, groups, nil
+}
@@ -0,0 +1,138 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"defaults to phrase", Config{}, false},
+		{"token with url", Config{Method: MethodToken, TokenURL: "http://localhost:1"}, false},
+		{"token without url", Config{Method: MethodToken}, true},
+		{"unknown method", Config{Method: "sms"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfirmPhrase(t *testing.T) {
+	tests := []struct {
+		name     string
+		roles    []string
+		input    string
+		wantErr  bool
+		wantRole string
+	}{
+		{"scenario name approves", nil, "wipe-bor-datadir\n", false, ""},
+		{"yes is not enough", nil, "yes\n", true, ""},
+		{"name must match exactly", nil, "Wipe-Bor-Datadir\n", true, ""},
+		{"operator in allowed group", []string{"sre"}, "wipe-bor-datadir\n", false, "sre"},
+		{"operator in no allowed group", []string{"admin"}, "wipe-bor-datadir\n", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := New(Config{Roles: tt.roles})
+			a.in = strings.NewReader(tt.input)
+			a.out = io.Discard
+			a.groups = func() (string, []string, error) { return "alice", []string{"users", "sre"}, nil }
+
+			ap, err := a.Confirm(context.Background(), "test-1", "wipe-bor-datadir", "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Confirm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				var rejected *ErrNotApproved
+				if !errors.As(err, &rejected) {
+					t.Errorf("Confirm() error = %T, want *ErrNotApproved", err)
+				}
+				return
+			}
+			if ap.Method != MethodPhrase || ap.Approver != "alice" || ap.Role != tt.wantRole || ap.Time.IsZero() {
+				t.Errorf("Confirm() = %+v", ap)
+			}
+		})
+	}
+}
+
+func TestConfirmTimeout(t *testing.T) {
+	a, _ := New(Config{Timeout: 20 * time.Millisecond})
+	reader, _ := io.Pipe() // never written: the operator does not answer
+	a.in = reader
+	a.out = io.Discard
+	a.groups = func() (string, []string, error) { return "alice", nil, nil }
+
+	var rejected *ErrNotApproved
+	if _, err := a.Confirm(context.Background(), "test-1", "scenario", ""); !errors.As(err, &rejected) {
+		t.Fatalf("Confirm() error = %v, want *ErrNotApproved", err)
+	}
+}
+
+func TestConfirmToken(t *testing.T) {
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("test_id") != "test-1" || r.URL.Query().Get("scenario") != "wipe-bor-datadir" {
+			http.Error(w, "bad query", http.StatusBadRequest)
+			return
+		}
+		// No approval on the first poll.
+		if polls.Add(1) == 1 {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": "tok-123", "approver": "bob", "role": "sre-oncall"})
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		roles   []string
+		wantErr bool
+	}{
+		{"any role", nil, false},
+		{"allowed role", []string{"sre-oncall"}, false},
+		{"role not allowed", []string{"admin"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls.Store(0)
+			a, _ := New(Config{Method: MethodToken, TokenURL: srv.URL, Roles: tt.roles, PollInterval: 5 * time.Millisecond})
+			a.out = io.Discard
+
+			ap, err := a.Confirm(context.Background(), "test-1", "wipe-bor-datadir", "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Confirm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if ap.Method != MethodToken || ap.Approver != "bob" || ap.Role != "sre-oncall" {
+				t.Errorf("Confirm() = %+v", ap)
+			}
+			if ap.TokenSHA256 == "" || strings.Contains(ap.TokenSHA256, "tok-123") {
+				t.Errorf("TokenSHA256 = %q, want a fingerprint of the token", ap.TokenSHA256)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Package audit records every fault the runner injects or removes in the
+// host's system log — journald or syslog — so chaos on shared devnet hosts
+// leaves a trail outside the runner's own reports.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Sinks.
+const (
+	SinkJournald = "journald"
+	SinkSyslog   = "syslog"
+)
+
+// Actions recorded.
+const (
+	ActionInject       = "inject"
+	ActionInjectFailed = "inject_failed"
+	ActionRemove       = "remove"
+	ActionRemoveFailed = "remove_failed"
+	// ActionApprove and ActionApproveRejected record the confirmation of
+	// a destructive scenario (see pkg/approval).
+	ActionApprove         = "approve"
+	ActionApproveRejected = "approve_rejected"
+)
+
+// Event is one fault install or removal on one container, or the
+// approval of a destructive scenario.
+type Event struct {
+	Action      string
+	TestID      string
+	Scenario    string
+	Phase       string
+	Fault       string
+	Target      string
+	ContainerID string
+	Params      map[string]interface{}
+	Err         string
+	// Approver, Role, Method and TokenSHA256 say who approved a
+	// destructive scenario, and how.
+	Approver    string
+	Role        string
+	Method      string
+	TokenSHA256 string
+}
+
+// Failed reports whether the action did not take effect.
+func (e Event) Failed() bool {
+	return e.Action == ActionInjectFailed || e.Action == ActionRemoveFailed || e.Action == ActionApproveRejected
+}
+
+// Message is the human-readable line, e.g. "chaos inject network on
+// l2-el-1-bor (test-1712345)" or "chaos approve wipe-datadir by alice
+// (test-1712345)".
+func (e Event) Message() string {
+	subject := fmt.Sprintf("%s on %s", e.Fault, e.Target)
+	if e.Action == ActionApprove || e.Action == ActionApproveRejected {
+		subject = e.Scenario
+		if e.Approver != "" {
+			subject += " by " + e.Approver
+		}
+	}
+	msg := fmt.Sprintf("chaos %s %s (%s)", e.Action, subject, e.TestID)
+	if e.Err != "" {
+		msg += ": " + e.Err
+	}
+	return msg
+}
+
+// Fields returns the structured fields, keyed the way journald expects
+// (upper case, CHAOS_ prefix). Empty values are omitted.
+func (e Event) Fields() map[string]string {
+	fields := map[string]string{
+		"CHAOS_ACTION":       e.Action,
+		"CHAOS_TEST_ID":      e.TestID,
+		"CHAOS_SCENARIO":     e.Scenario,
+		"CHAOS_PHASE":        e.Phase,
+		"CHAOS_FAULT":        e.Fault,
+		"CHAOS_TARGET":       e.Target,
+		"CHAOS_CONTAINER_ID": e.ContainerID,
+		"CHAOS_ERROR":        e.Err,
+		"CHAOS_APPROVER":     e.Approver,
+		"CHAOS_ROLE":         e.Role,
+		"CHAOS_METHOD":       e.Method,
+		"CHAOS_TOKEN_SHA256": e.TokenSHA256,
+	}
+	if len(e.Params) > 0 {
+		if data, err := json.Marshal(e.Params); err == nil {
+			fields["CHAOS_PARAMS"] = string(data)
+		}
+	}
+	for k, v := range fields {
+		if v == "" {
+			delete(fields, k)
+		}
+	}
+	return fields
+}
+
+// Sink receives audit events.
+type Sink interface {
+	Record(Event) error
+	Close() error
+}
+
+// Config selects and configures a sink.
+type Config struct {
+	// Sink is SinkJournald or SinkSyslog.
+	Sink string
+	// SyslogAddr is "network://host:port" for a remote syslog daemon, e.g.
+	// udp://10.0.0.5:514. Empty logs to the local daemon.
+	SyslogAddr string
+	// Tag is the syslog identifier (default "chaos-runner").
+	Tag string
+}
+
+// New opens the configured sink.
+func New(cfg Config) (Sink, error) {
+	if cfg.Tag == "" {
+		cfg.Tag = "chaos-runner"
+	}
+	switch cfg.Sink {
+	case SinkJournald:
+		return newJournald(cfg.Tag)
+	case SinkSyslog:
+		return newSyslog(cfg.Tag, cfg.SyslogAddr)
+	default:
+		return nil, fmt.Errorf("audit: unknown sink %q (use %s or %s)", cfg.Sink, SinkJournald, SinkSyslog)
+	}
+}
+
+// journaldSocket is where systemd-journald accepts native-protocol
+// datagrams.
+const journaldSocket = "/run/systemd/journal/socket"
+
+type journald struct {
+	tag  string
+	conn *net.UnixConn
+}
+
+func newJournald(tag string) (*journald, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journaldSocket, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("audit: cannot reach journald at %s: %w", journaldSocket, err)
+	}
+	return &journald{tag: tag, conn: conn}, nil
+}
+
+func (j *journald) Record(e Event) error {
+	priority := "6" // info
+	if e.Failed() {
+		priority = "4" // warning
+	}
+	fields := e.Fields()
+	fields["MESSAGE"] = e.Message()
+	fields["PRIORITY"] = priority
+	fields["SYSLOG_IDENTIFIER"] = j.tag
+
+	if _, err := j.conn.Write(encodeJournal(fields)); err != nil {
+		return fmt.Errorf("audit: journald write failed: %w", err)
+	}
+	return nil
+}
+
+func (j *journald) Close() error { return j.conn.Close() }
+
+// encodeJournal serialises fields in journald's native protocol: KEY=value
+// lines, or for values containing a newline, KEY, a newline, the value's
+// length as a little-endian uint64, the value and a newline. Keys are
+// sorted so the output is stable.
+func encodeJournal(fields map[string]string) []byte {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b []byte
+	for _, k := range keys {
+		v := fields[k]
+		if !strings.Contains(v, "\n") {
+			b = append(b, k+"="+v+"\n"...)
+			continue
+		}
+		b = append(b, k+"\n"...)
+		n := uint64(len(v))
+		for i := 0; i < 8; i++ {
+			b = append(b, byte(n>>(8*i)))
+		}
+		b = append(b, v+"\n"...)
+	}
+	return b
+}
+
+type syslogSink struct {
+	w *syslog.Writer
+}
+
+func newSyslog(tag, addr string) (*syslogSink, error) {
+	network, raddr := "", ""
+	if addr != "" {
+		parts := strings.SplitN(addr, "://", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("audit: syslog_addr %q must be network://host:port", addr)
+		}
+		network, raddr = parts[0], parts[1]
+	}
+	w, err := syslog.Dial(network, raddr, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, fmt.Errorf("audit: cannot reach syslog: %w", err)
+	}
+	return &syslogSink{w: w}, nil
+}
+
+// Record writes the message followed by the fields as key=value pairs,
+// since syslog has no structured fields of its own.
+func (s *syslogSink) Record(e Event) error {
+	line := e.Message() + " " + formatFields(e.Fields())
+	if e.Failed() {
+		return s.w.Warning(line)
+	}
+	return s.w.Info(line)
+}
+
+func (s *syslogSink) Close() error { return s.w.Close() }
+
+// formatFields renders fields as sorted, lower-case key=value pairs with
+// values quoted when they contain spaces or quotes.
+func formatFields(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		v := fields[k]
+		if strings.ContainsAny(v, " \"=") {
+			v = fmt.Sprintf("%q", v)
+		}
+		parts[i] = strings.ToLower(strings.TrimPrefix(k, "CHAOS_")) + "=" + v
+	}
+	return strings.Join(parts, " ")
+}
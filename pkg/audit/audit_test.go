@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncodeJournal(t *testing.T) {
+	got := encodeJournal(map[string]string{
+		"MESSAGE":     "chaos inject network on bor-1 (test-1)",
+		"CHAOS_ERROR": "a\nb",
+	})
+
+	want := []byte("CHAOS_ERROR\n")
+	want = append(want, 3, 0, 0, 0, 0, 0, 0, 0)
+	want = append(want, "a\nb\n"...)
+	want = append(want, "MESSAGE=chaos inject network on bor-1 (test-1)\n"...)
+	if !bytes.Equal(got, want) {
+		t.Errorf("encodeJournal() = %q, want %q", got, want)
+	}
+}
+
+func TestEventFields(t *testing.T) {
+	e := Event{
+		Action:      ActionRemoveFailed,
+		TestID:      "test-1",
+		Fault:       "network",
+		Target:      "l2-el-1-bor",
+		ContainerID: "abc123",
+		Params:      map[string]interface{}{"latency": 200},
+		Err:         "exit 1",
+	}
+
+	if !e.Failed() {
+		t.Error("remove_failed should be a failure")
+	}
+	want := `action=remove_failed container_id=abc123 error="exit 1" fault=network params="{\"latency\":200}" target=l2-el-1-bor test_id=test-1`
+	if got := formatFields(e.Fields()); got != want {
+		t.Errorf("formatFields() =\n  %s\nwant\n  %s", got, want)
+	}
+}
+
+func TestApprovalEvent(t *testing.T) {
+	e := Event{
+		Action:      ActionApprove,
+		TestID:      "test-1",
+		Scenario:    "wipe-datadir",
+		Approver:    "alice",
+		Role:        "sre-oncall",
+		Method:      "token",
+		TokenSHA256: "9f86d0",
+	}
+
+	if e.Failed() {
+		t.Error("approve should not be a failure")
+	}
+	if got, want := e.Message(), "chaos approve wipe-datadir by alice (test-1)"; got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+	want := `action=approve approver=alice method=token role=sre-oncall scenario=wipe-datadir test_id=test-1 token_sha256=9f86d0`
+	if got := formatFields(e.Fields()); got != want {
+		t.Errorf("formatFields() =\n  %s\nwant\n  %s", got, want)
+	}
+}
@@ -1,9 +1,10 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"strings"
 	"time"
 
@@ -12,13 +13,22 @@ import (
 
 // Config represents the chaos framework configuration
 type Config struct {
-	Framework  FrameworkConfig  `yaml:"framework"`
-	Kurtosis   KurtosisConfig   `yaml:"kurtosis"`
-	Docker     DockerConfig     `yaml:"docker"`
-	Prometheus PrometheusConfig `yaml:"prometheus"`
-	Reporting  ReportingConfig  `yaml:"reporting"`
-	Emergency  EmergencyConfig  `yaml:"emergency"`
-	Execution  ExecutionConfig  `yaml:"execution"`
+	Framework   FrameworkConfig   `yaml:"framework"`
+	Kurtosis    KurtosisConfig    `yaml:"kurtosis"`
+	Docker      DockerConfig      `yaml:"docker"`
+	Kubernetes  KubernetesConfig  `yaml:"kubernetes"`
+	Prometheus  PrometheusConfig  `yaml:"prometheus"`
+	RPC         EVMRPCConfig      `yaml:"rpc"`
+	Reporting   ReportingConfig   `yaml:"reporting"`
+	Emergency   EmergencyConfig   `yaml:"emergency"`
+	Execution   ExecutionConfig   `yaml:"execution"`
+	GameDay     GameDayConfig     `yaml:"gameday"`
+	Audit       AuditConfig       `yaml:"audit"`
+	Destructive DestructiveConfig `yaml:"destructive"`
+	Notify      NotifyConfig      `yaml:"notify"`
+	// Agents are the chaos-agents that reach targets on other Docker
+	// hosts; empty when every target is on the local daemon.
+	Agents []AgentConfig `yaml:"agents,omitempty"`
 }
 
 // FrameworkConfig contains general framework settings
@@ -31,6 +41,9 @@ type FrameworkConfig struct {
 // KurtosisConfig contains Kurtosis connection settings
 type KurtosisConfig struct {
 	EnclaveName string `yaml:"enclave_name"`
+	// Profile selects the deployment naming convention (see profile.go):
+	// "auto" detects it from the enclave, or name a built-in profile.
+	Profile string `yaml:"profile"`
 }
 
 // DockerConfig contains Docker settings for sidecar management
@@ -38,6 +51,14 @@ type DockerConfig struct {
 	SidecarImage string `yaml:"sidecar_image"`
 }
 
+// KubernetesConfig selects the cluster kubernetes_pod targets are found
+// in. Empty values leave kubectl's defaults ($KUBECONFIG, the current
+// context). Pod debug containers run docker.sidecar_image.
+type KubernetesConfig struct {
+	Kubeconfig string `yaml:"kubeconfig"`
+	Context    string `yaml:"context"`
+}
+
 // PrometheusConfig contains Prometheus connection settings
 type PrometheusConfig struct {
 	URL             string        `yaml:"url"`
@@ -45,21 +66,142 @@ type PrometheusConfig struct {
 	RefreshInterval time.Duration `yaml:"refresh_interval"`
 }
 
+// EVMRPCConfig contains the EVM JSON-RPC endpoints rpc criteria query
+type EVMRPCConfig struct {
+	// URL is auto-discovered from the profile's RPCServices when empty;
+	// run --rpc-url overrides it.
+	URL string `yaml:"url,omitempty"`
+	// Fallbacks are tried in order when URL does not answer, since the RPC
+	// node may itself be a chaos target. Discovery fills them with the
+	// profile's remaining RPC services when URL is discovered too.
+	Fallbacks []string `yaml:"fallbacks,omitempty"`
+	// Timeout bounds each call to one endpoint (default 10s).
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// Retries re-sends a failed call to the same endpoint this many times
+	// before failing over to the next.
+	Retries int `yaml:"retries,omitempty"`
+	// Username and Password set HTTP basic auth on every call. Use
+	// ${VAR} to keep the password out of the file.
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// Endpoints returns URL followed by Fallbacks, skipping empty entries.
+func (c EVMRPCConfig) Endpoints() []string {
+	var endpoints []string
+	for _, u := range append([]string{c.URL}, c.Fallbacks...) {
+		if u != "" {
+			endpoints = append(endpoints, u)
+		}
+	}
+	return endpoints
+}
+
 // ReportingConfig contains reporting and output settings
 type ReportingConfig struct {
 	OutputDir string `yaml:"output_dir"`
 	KeepLastN int    `yaml:"keep_last_n"`
+	// InterimInterval, when set, rewrites interim/<test-id>.json under
+	// OutputDir at this interval while a run is in progress.
+	InterimInterval time.Duration `yaml:"interim_interval"`
 }
 
 // EmergencyConfig contains emergency stop settings
 type EmergencyConfig struct {
 	StopFile string `yaml:"stop_file"`
+	// GracePeriod bounds how long teardown may take after a stop signal
+	// or the stop file before the process force-cleans sidecars and
+	// exits. 0 waits indefinitely.
+	GracePeriod time.Duration `yaml:"grace_period"`
 }
 
 // ExecutionConfig contains test execution settings
 type ExecutionConfig struct {
 	DefaultWarmup   time.Duration `yaml:"default_warmup"`
 	DefaultCooldown time.Duration `yaml:"default_cooldown"`
+	// PhaseTimeouts force-fail a phase that runs longer than its entry,
+	// keyed by phase name (discover, prepare, warmup, inject, monitor,
+	// cooldown, teardown, detect). Phases without an entry never time out.
+	PhaseTimeouts map[string]time.Duration `yaml:"phase_timeouts,omitempty"`
+	// VerifyEnclaveCleanup scans every container in the targets' enclave,
+	// not only the targets, for chaos artifacts after teardown.
+	VerifyEnclaveCleanup bool `yaml:"verify_enclave_cleanup,omitempty"`
+}
+
+// timeoutPhases are the valid execution.phase_timeouts keys.
+var timeoutPhases = []string{"discover", "prepare", "warmup", "inject", "monitor", "cooldown", "teardown", "detect"}
+
+// GameDayConfig configures human-in-the-loop gates (see pkg/gameday).
+// Disabled by default; `run --gameday` enables it for one run.
+type GameDayConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Gates: before_inject, before_teardown. Empty means both.
+	Gates []string `yaml:"gates,omitempty"`
+	// Approval: stdin (default) or webhook.
+	Approval    string        `yaml:"approval,omitempty"`
+	ApprovalURL string        `yaml:"approval_url,omitempty"`
+	AnnounceURL string        `yaml:"announce_url,omitempty"`
+	Timeout     time.Duration `yaml:"timeout,omitempty"`
+}
+
+// AuditConfig sends every fault injected or removed to the host's system
+// log (see pkg/audit), for audit trails on shared devnet hosts. Off by
+// default.
+type AuditConfig struct {
+	// Sink: journald or syslog; empty disables the audit trail.
+	Sink string `yaml:"sink,omitempty"`
+	// SyslogAddr is network://host:port for a remote syslog daemon; empty
+	// uses the local one. Ignored for journald.
+	SyslogAddr string `yaml:"syslog_addr,omitempty"`
+	// Tag is the syslog identifier (default chaos-runner).
+	Tag string `yaml:"tag,omitempty"`
+}
+
+// DestructiveConfig configures how scenarios marked
+// metadata.destructive are confirmed before INJECT (see pkg/approval).
+type DestructiveConfig struct {
+	// Approval: phrase (default; type the scenario name) or token.
+	Approval string `yaml:"approval,omitempty"`
+	// TokenURL hands out approval tokens in token mode.
+	TokenURL string `yaml:"token_url,omitempty"`
+	// Roles the approver must hold: token service roles, or Unix groups
+	// in phrase mode. Empty accepts anyone.
+	Roles   []string      `yaml:"roles,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// NotifyConfig posts run events to chat and generic webhooks (see
+// pkg/notify). Off when no webhook is listed.
+type NotifyConfig struct {
+	Webhooks []WebhookConfig `yaml:"webhooks,omitempty"`
+}
+
+// WebhookConfig is one notification endpoint.
+type WebhookConfig struct {
+	Name string `yaml:"name,omitempty"`
+	// URL receives a JSON POST per event. Use ${VAR} to keep it out of
+	// the file.
+	URL string `yaml:"url"`
+	// Format: generic (default), slack or discord.
+	Format string `yaml:"format,omitempty"`
+	// Events: test_started, emergency_stop, critical_failure,
+	// test_completed. Empty means all.
+	Events []string `yaml:"events,omitempty"`
+	// Template renders a custom JSON body from the event.
+	Template string `yaml:"template,omitempty"`
+}
+
+// AgentConfig is one chaos-agent (cmd/chaos-agent) serving a Docker host
+// the runner cannot reach directly. Discovery matches target selectors
+// against its containers too, and faults on them are installed through it.
+type AgentConfig struct {
+	// Name identifies the agent in output and reports.
+	Name string `yaml:"name"`
+	// Address is the agent's host:port.
+	Address string `yaml:"address"`
+	// Token is the agent's shared token. Use ${VAR} to keep it out of the
+	// file.
+	Token string `yaml:"token"`
 }
 
 // DefaultConfig returns a default configuration
@@ -72,6 +214,7 @@ func DefaultConfig() *Config {
 		},
 		Kurtosis: KurtosisConfig{
 			EnclaveName: "pos",
+			Profile:     ProfileAuto,
 		},
 		Docker: DockerConfig{
 			SidecarImage: "jhkimqd/chaos-utils:latest",
@@ -81,16 +224,26 @@ func DefaultConfig() *Config {
 			Timeout:         30 * time.Second,
 			RefreshInterval: 15 * time.Second,
 		},
+		RPC: EVMRPCConfig{
+			Timeout: 10 * time.Second,
+			Retries: 1,
+		},
 		Reporting: ReportingConfig{
 			OutputDir: "./reports",
 			KeepLastN: 50,
 		},
 		Emergency: EmergencyConfig{
-			StopFile: "/tmp/chaos-emergency-stop",
+			StopFile:    "/tmp/chaos-emergency-stop",
+			GracePeriod: 2 * time.Minute,
 		},
 		Execution: ExecutionConfig{
 			DefaultWarmup:   30 * time.Second,
 			DefaultCooldown: 30 * time.Second,
+			PhaseTimeouts: map[string]time.Duration{
+				"discover": 2 * time.Minute,
+				"prepare":  5 * time.Minute,
+				"teardown": 5 * time.Minute,
+			},
 		},
 	}
 }
@@ -110,9 +263,7 @@ func DiscoverPrometheusEndpoint(enclaveName string) (string, error) {
 	var lastErr error
 	for _, serviceName := range serviceNames {
 		// Run: kurtosis port print <enclave> <service> http
-		// Use Output() instead of CombinedOutput() to ignore stderr (Kurtosis warnings)
-		cmd := exec.Command("kurtosis", "port", "print", enclaveName, serviceName, "http")
-		output, err := cmd.Output()
+		output, err := kurtosisOutput(enclaveName, "port", "print", enclaveName, serviceName, "http")
 		if err != nil {
 			lastErr = err
 			continue // Try next service name
@@ -139,22 +290,21 @@ func DiscoverPrometheusEndpoint(enclaveName string) (string, error) {
 	return "", fmt.Errorf("failed to discover Prometheus endpoint (tried: %v)", serviceNames)
 }
 
-// DiscoverHeimdallEndpoint attempts to discover a Heimdall API endpoint from Kurtosis enclave
-func DiscoverHeimdallEndpoint(enclaveName string) (string, error) {
+// DiscoverHeimdallEndpoint attempts to discover a Heimdall API endpoint from Kurtosis enclave,
+// trying the profile's Heimdall service names
+func DiscoverHeimdallEndpoint(enclaveName string, profile *Profile) (string, error) {
 	if enclaveName == "" {
 		return "", fmt.Errorf("enclave name is empty")
 	}
 
-	// Try multiple Heimdall service name patterns
-	serviceNames := []string{
-		"l2-cl-1-heimdall-v2-bor-validator",
-		"l2-cl-2-heimdall-v2-bor-validator",
+	serviceNames := profile.HeimdallServices
+	if len(serviceNames) == 0 {
+		return "", fmt.Errorf("deployment profile %s has no Heimdall services", profile.Name)
 	}
 
 	var lastErr error
 	for _, serviceName := range serviceNames {
-		cmd := exec.Command("kurtosis", "port", "print", enclaveName, serviceName, "http")
-		output, err := cmd.Output()
+		output, err := kurtosisOutput(enclaveName, "port", "print", enclaveName, serviceName, "http")
 		if err != nil {
 			lastErr = err
 			continue
@@ -178,8 +328,56 @@ func DiscoverHeimdallEndpoint(enclaveName string) (string, error) {
 	return "", fmt.Errorf("failed to discover Heimdall endpoint (tried: %v)", serviceNames)
 }
 
+// DiscoverEVMRPCEndpoints discovers EVM JSON-RPC endpoints from Kurtosis enclave,
+// one per profile RPC service that exists, in the profile's order
+func DiscoverEVMRPCEndpoints(enclaveName string, profile *Profile) ([]string, error) {
+	if enclaveName == "" {
+		return nil, fmt.Errorf("enclave name is empty")
+	}
+
+	serviceNames := profile.RPCServices
+	if len(serviceNames) == 0 {
+		return nil, fmt.Errorf("deployment profile %s has no RPC services", profile.Name)
+	}
+
+	var endpoints []string
+	var lastErr error
+	for _, serviceName := range serviceNames {
+		output, err := kurtosisOutput(enclaveName, "port", "print", enclaveName, serviceName, "rpc")
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		endpoint := strings.TrimSpace(string(output))
+		if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+			continue
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	if len(endpoints) > 0 {
+		return endpoints, nil
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("failed to discover EVM RPC endpoint (tried: %v): %w", serviceNames, lastErr)
+	}
+	return nil, fmt.Errorf("failed to discover EVM RPC endpoint (tried: %v)", serviceNames)
+}
+
 // Load loads configuration from a YAML file
 func Load(path string) (*Config, error) {
+	return load(path, false)
+}
+
+// LoadStrict is Load, but rejects keys the schema does not know, which Load
+// silently ignores (e.g. a misspelled phase_timeouts).
+func LoadStrict(path string) (*Config, error) {
+	return load(path, true)
+}
+
+func load(path string, strict bool) (*Config, error) {
 	cfg := DefaultConfig()
 
 	if path == "" {
@@ -198,7 +396,9 @@ func Load(path string) (*Config, error) {
 	prometheusURLEnv := os.Getenv("PROMETHEUS_URL")
 	expandedData := []byte(os.ExpandEnv(string(data)))
 
-	if err := yaml.Unmarshal(expandedData, cfg); err != nil {
+	dec := yaml.NewDecoder(bytes.NewReader(expandedData))
+	dec.KnownFields(strict)
+	if err := dec.Decode(cfg); err != nil && err != io.EOF {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
@@ -237,6 +437,79 @@ func (c *Config) Validate() error {
 	if c.Reporting.OutputDir == "" {
 		return fmt.Errorf("reporting.output_dir is required")
 	}
+	if c.Reporting.InterimInterval < 0 {
+		return fmt.Errorf("reporting.interim_interval cannot be negative")
+	}
+
+	if c.RPC.Timeout < 0 {
+		return fmt.Errorf("rpc.timeout cannot be negative")
+	}
+	if c.RPC.Retries < 0 {
+		return fmt.Errorf("rpc.retries cannot be negative")
+	}
+	if c.RPC.Password != "" && c.RPC.Username == "" {
+		return fmt.Errorf("rpc.password is set without rpc.username")
+	}
+
+	if c.Emergency.GracePeriod < 0 {
+		return fmt.Errorf("emergency.grace_period cannot be negative")
+	}
+
+	for phase, d := range c.Execution.PhaseTimeouts {
+		known := false
+		for _, p := range timeoutPhases {
+			known = known || strings.EqualFold(phase, p)
+		}
+		if !known {
+			return fmt.Errorf("execution.phase_timeouts: unknown phase %q (valid: %s)", phase, strings.Join(timeoutPhases, ", "))
+		}
+		if d < 0 {
+			return fmt.Errorf("execution.phase_timeouts.%s cannot be negative", phase)
+		}
+	}
+
+	switch c.Audit.Sink {
+	case "", "journald", "syslog":
+	default:
+		return fmt.Errorf("audit.sink %q is invalid (must be journald or syslog)", c.Audit.Sink)
+	}
+	if c.Audit.SyslogAddr != "" && !strings.Contains(c.Audit.SyslogAddr, "://") {
+		return fmt.Errorf("audit.syslog_addr %q must be network://host:port", c.Audit.SyslogAddr)
+	}
+
+	switch c.Destructive.Approval {
+	case "", "phrase":
+	case "token":
+		if c.Destructive.TokenURL == "" {
+			return fmt.Errorf("destructive.token_url is required for token approval")
+		}
+	default:
+		return fmt.Errorf("destructive.approval %q is invalid (must be phrase or token)", c.Destructive.Approval)
+	}
+	if c.Destructive.Timeout < 0 {
+		return fmt.Errorf("destructive.timeout cannot be negative")
+	}
+
+	agentNames := make(map[string]bool)
+	for i, a := range c.Agents {
+		switch {
+		case a.Name == "":
+			return fmt.Errorf("agents[%d].name is required", i)
+		case agentNames[a.Name]:
+			return fmt.Errorf("agents: name %q is used twice", a.Name)
+		case a.Address == "":
+			return fmt.Errorf("agents.%s.address is required", a.Name)
+		case a.Token == "":
+			return fmt.Errorf("agents.%s.token is required", a.Name)
+		}
+		agentNames[a.Name] = true
+	}
+
+	if c.Kurtosis.Profile != "" && c.Kurtosis.Profile != ProfileAuto {
+		if _, ok := LookupProfile(c.Kurtosis.Profile); !ok {
+			return fmt.Errorf("kurtosis.profile %q is unknown (available: auto, %s)", c.Kurtosis.Profile, strings.Join(ProfileNames(), ", "))
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// KurtosisCacheTTL is how long cached Kurtosis lookups for an enclave stay
+// valid, counted from the cache file's mtime. An enclave recreated under
+// the same name gets new ports, so this is kept to about one session.
+const KurtosisCacheTTL = 10 * time.Minute
+
+// kurtosisCacheEntry is the outcome of one kurtosis CLI call. Failures are
+// cached too: probing for a service that does not exist costs as much as
+// finding one that does.
+type kurtosisCacheEntry struct {
+	Output string `json:"output"`
+	Err    string `json:"err,omitempty"`
+}
+
+// kurtosisCachePath is the cache file for an enclave, or "" when caching is
+// disabled (CHAOS_NO_KURTOSIS_CACHE) or there is no user cache directory.
+func kurtosisCachePath(enclaveName string) string {
+	if os.Getenv("CHAOS_NO_KURTOSIS_CACHE") != "" {
+		return ""
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "chaos-utils", "kurtosis", filepath.Base(enclaveName)+".json")
+}
+
+// readKurtosisCache returns the cached entries for an enclave and the cache
+// file's mtime, or nil once the file is older than KurtosisCacheTTL.
+func readKurtosisCache(path string) (map[string]kurtosisCacheEntry, time.Time) {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > KurtosisCacheTTL {
+		return nil, time.Time{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	var entries map[string]kurtosisCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, time.Time{}
+	}
+	return entries, info.ModTime()
+}
+
+// kurtosisOutput runs `kurtosis <args>` for an enclave and returns its
+// stdout, answering from the enclave's cache file when it is fresh. New
+// results are added without touching the file's mtime, so the TTL runs from
+// the first lookup rather than the latest.
+func kurtosisOutput(enclaveName string, args ...string) ([]byte, error) {
+	path := kurtosisCachePath(enclaveName)
+	key := strings.Join(args, " ")
+	entries, mtime := map[string]kurtosisCacheEntry(nil), time.Time{}
+	if path != "" {
+		entries, mtime = readKurtosisCache(path)
+		if e, ok := entries[key]; ok {
+			if e.Err != "" {
+				return []byte(e.Output), errors.New(e.Err)
+			}
+			return []byte(e.Output), nil
+		}
+	}
+
+	// Use Output() instead of CombinedOutput() to ignore stderr (Kurtosis warnings)
+	output, err := exec.Command("kurtosis", args...).Output()
+	if path == "" {
+		return output, err
+	}
+	var execErr *exec.ExitError
+	if err != nil && !errors.As(err, &execErr) {
+		// The CLI itself is missing or could not start; nothing about the
+		// enclave was learned.
+		return output, err
+	}
+
+	if entries == nil {
+		entries = make(map[string]kurtosisCacheEntry)
+	}
+	e := kurtosisCacheEntry{Output: string(output)}
+	if err != nil {
+		e.Err = err.Error()
+	}
+	entries[key] = e
+	if data, jsonErr := json.Marshal(entries); jsonErr == nil {
+		if os.MkdirAll(filepath.Dir(path), 0o755) == nil && os.WriteFile(path, data, 0o644) == nil && !mtime.IsZero() {
+			_ = os.Chtimes(path, time.Now(), mtime)
+		}
+	}
+	return output, err
+}
+
+// ClearKurtosisCache drops the cached Kurtosis lookups for an enclave.
+func ClearKurtosisCache(enclaveName string) error {
+	path := kurtosisCachePath(enclaveName)
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
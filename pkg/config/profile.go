@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ProfileAuto asks the runner to detect the deployment profile from the
+// enclave's service names.
+const ProfileAuto = "auto"
+
+// Profile captures the naming that differs between Kurtosis packages:
+// service and container names, Prometheus job labels and metric prefixes.
+// Code that needs one of these names should read it from the active
+// profile rather than hardcoding the heimdall-v2 convention.
+type Profile struct {
+	// Name identifies the profile in config.yaml and --profile.
+	Name string
+	// Description is shown when listing profiles.
+	Description string
+	// Stack is the chain stack the package deploys: StackPoS or StackCDK.
+	Stack string
+
+	// ProbeService is a Kurtosis service that only this profile's package
+	// creates; DetectProfile looks for it.
+	ProbeService string
+	// HeimdallServices are Kurtosis service names tried, in order, when
+	// discovering the Heimdall REST API. Empty for stacks without Heimdall.
+	HeimdallServices []string
+	// RPCServices are Kurtosis service names tried, in order, when
+	// discovering the EVM JSON-RPC endpoint for rpc criteria: the RPC node
+	// first, then validators in case the enclave runs none.
+	RPCServices []string
+
+	// ValidatorPattern matches consensus-layer validator container names;
+	// the default for preconditions.validator_pattern. Empty for stacks
+	// without a validator set.
+	ValidatorPattern string
+	// CLJobPattern and ELJobPattern select every validator's consensus and
+	// execution client in Prometheus job labels.
+	CLJobPattern string
+	ELJobPattern string
+	// CLContainerPattern and ELContainerPattern are substrings matching
+	// validator container names, used by log and state-root criteria.
+	CLContainerPattern string
+	ELContainerPattern string
+
+	// ConsensusMetricPrefix is the CometBFT/Tendermint metric namespace
+	// the consensus client exports ("cometbft" or "tendermint").
+	ConsensusMetricPrefix string
+	// HeimdallMetricPrefix is the namespace of Heimdall's own application
+	// metrics ("heimdallv2" or "heimdall").
+	HeimdallMetricPrefix string
+	// HasSideTxMetrics is true when the consensus client exports the
+	// heimdallv2_sidetx_* counters.
+	HasSideTxMetrics bool
+
+	// L1ELPattern, L1CLPattern and L1VCPattern match the rootchain's
+	// execution client, beacon node and validator client service names
+	// (ethereum-package naming, e.g. el-1-geth-lighthouse). Heimdall
+	// checkpoints to and the CDK settles on this L1, so it is a fault
+	// target tier of its own.
+	L1ELPattern string
+	L1CLPattern string
+	L1VCPattern string
+
+	// CDK component container patterns (substrings), set for StackCDK.
+	SequencerPattern  string
+	RPCPattern        string
+	AggregatorPattern string
+	ProverPattern     string
+}
+
+// Chain stacks a profile can describe.
+const (
+	StackPoS = "pos"
+	StackCDK = "cdk"
+)
+
+// DefaultProfileName is used when detection fails and no profile is set.
+const DefaultProfileName = "pos-heimdall-v2"
+
+var profiles = map[string]*Profile{
+	"pos-heimdall-v2": {
+		Name:                  "pos-heimdall-v2",
+		Description:           "Polygon PoS kurtosis-pos package with heimdall-v2 (CometBFT) and Bor",
+		Stack:                 StackPoS,
+		ProbeService:          "l2-cl-1-heimdall-v2-bor-validator",
+		HeimdallServices:      []string{"l2-cl-1-heimdall-v2-bor-validator", "l2-cl-2-heimdall-v2-bor-validator"},
+		RPCServices:           []string{"l2-el-9-bor-heimdall-v2-rpc", "l2-el-1-bor-heimdall-v2-validator", "l2-el-2-bor-heimdall-v2-validator"},
+		ValidatorPattern:      `l2-cl-[0-9]+-heimdall-v2-bor-validator`,
+		CLJobPattern:          `l2-cl-.*-heimdall-v2-bor-validator`,
+		ELJobPattern:          `l2-el-.*-bor-heimdall-v2-validator`,
+		CLContainerPattern:    "heimdall-v2-bor-validator",
+		ELContainerPattern:    "bor-heimdall-v2-validator",
+		ConsensusMetricPrefix: "cometbft",
+		HeimdallMetricPrefix:  "heimdallv2",
+		HasSideTxMetrics:      true,
+		L1ELPattern:           `^el-[0-9]+-`,
+		L1CLPattern:           `^cl-[0-9]+-`,
+		L1VCPattern:           `^vc-[0-9]+-`,
+	},
+	"pos-heimdall-v1": {
+		Name:                  "pos-heimdall-v1",
+		Description:           "Polygon PoS kurtosis-pos package with legacy heimdall (Tendermint) and Bor",
+		Stack:                 StackPoS,
+		ProbeService:          "l2-cl-1-heimdall-bor-validator",
+		HeimdallServices:      []string{"l2-cl-1-heimdall-bor-validator", "l2-cl-2-heimdall-bor-validator"},
+		RPCServices:           []string{"l2-el-9-bor-heimdall-rpc", "l2-el-1-bor-heimdall-validator", "l2-el-2-bor-heimdall-validator"},
+		ValidatorPattern:      `l2-cl-[0-9]+-heimdall-bor-validator`,
+		CLJobPattern:          `l2-cl-.*-heimdall-bor-validator`,
+		ELJobPattern:          `l2-el-.*-bor-heimdall-validator`,
+		CLContainerPattern:    "heimdall-bor-validator",
+		ELContainerPattern:    "bor-heimdall-validator",
+		ConsensusMetricPrefix: "tendermint",
+		HeimdallMetricPrefix:  "heimdall",
+		L1ELPattern:           `^el-[0-9]+-`,
+		L1CLPattern:           `^cl-[0-9]+-`,
+		L1VCPattern:           `^vc-[0-9]+-`,
+	},
+	"cdk-erigon": {
+		Name:              "cdk-erigon",
+		Description:       "Polygon CDK kurtosis-cdk package with cdk-erigon sequencer/RPC, cdk-node aggregator and zkEVM prover",
+		Stack:             StackCDK,
+		ProbeService:      "cdk-erigon-sequencer-001",
+		RPCServices:       []string{"cdk-erigon-rpc-001", "cdk-erigon-sequencer-001"},
+		SequencerPattern:  "cdk-erigon-sequencer",
+		RPCPattern:        "cdk-erigon-rpc",
+		AggregatorPattern: "cdk-node",
+		ProverPattern:     "zkevm-prover",
+		L1ELPattern:       `^el-[0-9]+-`,
+		L1CLPattern:       `^cl-[0-9]+-`,
+		L1VCPattern:       `^vc-[0-9]+-`,
+	},
+}
+
+// detectOrder is the order DetectProfile probes profiles in.
+var detectOrder = []string{"pos-heimdall-v2", "pos-heimdall-v1", "cdk-erigon"}
+
+// LookupProfile returns the built-in profile with the given name.
+func LookupProfile(name string) (*Profile, bool) {
+	p, ok := profiles[name]
+	return p, ok
+}
+
+// DefaultProfile returns the heimdall-v2 profile.
+func DefaultProfile() *Profile {
+	return profiles[DefaultProfileName]
+}
+
+// ProfileNames returns the names of all built-in profiles, sorted.
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DetectProfile probes the enclave for each profile's ProbeService and
+// returns the first profile whose service exists.
+func DetectProfile(enclaveName string) (*Profile, error) {
+	if enclaveName == "" {
+		return nil, fmt.Errorf("enclave name is empty")
+	}
+
+	for _, name := range detectOrder {
+		p := profiles[name]
+		// service inspect succeeds for any existing service regardless of
+		// which ports it exposes.
+		if _, err := kurtosisOutput(enclaveName, "service", "inspect", enclaveName, p.ProbeService); err == nil {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no known deployment profile matches enclave %q (tried: %v)", enclaveName, detectOrder)
+}
+
+// ActiveProfile returns the profile named by kurtosis.profile, or the
+// default profile when it is unset, "auto" (not yet resolved), or unknown.
+func (c *Config) ActiveProfile() *Profile {
+	if p, ok := LookupProfile(c.Kurtosis.Profile); ok {
+		return p
+	}
+	return DefaultProfile()
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultYAML is DefaultConfig written out with a comment per setting.
+//
+//go:embed default.yaml
+var defaultYAML []byte
+
+// DefaultYAML returns the commented default configuration written by
+// `chaos-runner config init`.
+func DefaultYAML() []byte {
+	return defaultYAML
+}
+
+// SourceDefault marks a setting left at its DefaultConfig value.
+const SourceDefault = "default"
+
+// FileSettings returns the dotted path (e.g. "execution.phase_timeouts.discover")
+// of every setting the config file at path sets. A missing file sets none.
+func FileSettings(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	var paths []string
+	walkSettings(&doc, "", func(path string, _ *yaml.Node) {
+		paths = append(paths, path)
+	})
+	return paths, nil
+}
+
+// Annotated returns c as YAML with each setting's source as a line comment.
+// sources maps dotted setting paths to where the value came from; settings
+// without an entry are marked SourceDefault.
+func (c *Config) Annotated(sources map[string]string) ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(c); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	walkSettings(&doc, "", func(path string, value *yaml.Node) {
+		if src, ok := sources[path]; ok {
+			value.LineComment = src
+		} else {
+			value.LineComment = SourceDefault
+		}
+	})
+	return yaml.Marshal(&doc)
+}
+
+// walkSettings calls fn for every leaf value under node, i.e. everything
+// but a mapping, with its dotted path.
+func walkSettings(node *yaml.Node, prefix string, fn func(path string, value *yaml.Node)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			walkSettings(n, prefix, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			path := node.Content[i].Value
+			if prefix != "" {
+				path = prefix + "." + path
+			}
+			walkSettings(node.Content[i+1], path, fn)
+		}
+	default:
+		if prefix != "" {
+			fn(prefix, node)
+		}
+	}
+}
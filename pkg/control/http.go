@@ -0,0 +1,188 @@
+package control
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	chaosrunnerv1 "github.com/jihwankim/chaos-utils/api/gen/chaosrunner/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxScenarioBytes caps a submitted scenario document.
+const maxScenarioBytes = 10 << 20
+
+// TestStatus is the body of GET /tests/{id}.
+type TestStatus struct {
+	TestID string `json:"test_id"`
+	// State is the orchestrator state the test is in, or ended in.
+	State string `json:"state"`
+	Done  bool   `json:"done"`
+	// ExitCode, Success and Message are set once the test is done.
+	ExitCode *int32 `json:"exit_code,omitempty"`
+	Success  bool   `json:"success,omitempty"`
+	Message  string `json:"message,omitempty"`
+	// Report is the JSON report, once the test is done and produced one.
+	Report json.RawMessage `json:"report,omitempty"`
+}
+
+// HTTPHandler serves the same API as REST, for CI jobs and dashboards
+// without a gRPC client:
+//
+//	POST   /tests              submit; the body is the scenario document,
+//	                           ?var=KEY=VALUE and ?set=key=value repeat,
+//	                           ?dry_run=true validates only
+//	GET    /tests/{id}         state, and the report once done
+//	GET    /tests/{id}/events  progress as newline-delimited JSON events,
+//	                           replayed from the start, until the test ends
+//	DELETE /tests/{id}         stop the test; teardown still runs
+//
+// Errors are {"error": "..."} with the status the gRPC code maps to.
+func (s *Server) HTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /tests", s.httpSubmit)
+	mux.HandleFunc("GET /tests/{id}", s.httpStatus)
+	mux.HandleFunc("GET /tests/{id}/events", s.httpEvents)
+	mux.HandleFunc("DELETE /tests/{id}", s.httpStop)
+	return mux
+}
+
+func (s *Server) httpSubmit(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxScenarioBytes))
+	if err != nil {
+		writeHTTPError(w, status.Errorf(codes.InvalidArgument, "failed to read scenario: %v", err))
+		return
+	}
+	query := r.URL.Query()
+	req := &chaosrunnerv1.SubmitScenarioRequest{ScenarioYaml: body}
+	if req.Variables, err = keyValues(query["var"]); err != nil {
+		writeHTTPError(w, status.Errorf(codes.InvalidArgument, "var: %v", err))
+		return
+	}
+	if req.Overrides, err = keyValues(query["set"]); err != nil {
+		writeHTTPError(w, status.Errorf(codes.InvalidArgument, "set: %v", err))
+		return
+	}
+	if raw := query.Get("dry_run"); raw != "" {
+		if req.DryRun, err = strconv.ParseBool(raw); err != nil {
+			writeHTTPError(w, status.Errorf(codes.InvalidArgument, "dry_run: %v", err))
+			return
+		}
+	}
+
+	resp, err := s.SubmitScenario(r.Context(), req)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/tests/"+resp.TestId)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"test_id": resp.TestId, "warnings": resp.Warnings})
+}
+
+func (s *Server) httpStatus(w http.ResponseWriter, r *http.Request) {
+	t, err := s.lookup(r.PathValue("id"))
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	st := TestStatus{TestID: t.id, State: t.currentState()}
+	report, done := t.result()
+	if done {
+		st.Done = true
+		st.Report = report
+		if f := t.finished(); f != nil {
+			code := f.ExitCode
+			st.ExitCode, st.Success, st.Message = &code, f.Success, f.Message
+		}
+	}
+	writeJSON(w, http.StatusOK, st)
+}
+
+func (s *Server) httpEvents(w http.ResponseWriter, r *http.Request) {
+	t, err := s.lookup(r.PathValue("id"))
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	marshal := protojson.MarshalOptions{UseProtoNames: true}
+	sent := 0
+	for {
+		events, done, wake := t.since(sent)
+		for _, ev := range events {
+			line, err := marshal.Marshal(ev)
+			if err != nil {
+				return
+			}
+			if _, err := w.Write(append(line, '\n')); err != nil {
+				return
+			}
+		}
+		sent += len(events)
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if done {
+			return
+		}
+		select {
+		case <-wake:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (s *Server) httpStop(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.StopTest(r.Context(), &chaosrunnerv1.StopTestRequest{TestId: r.PathValue("id")})
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]string{"state": resp.State})
+}
+
+// keyValues parses repeated KEY=VALUE query values.
+func keyValues(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("%q is not KEY=VALUE", p)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+// writeHTTPError writes err, a gRPC status error, with the matching HTTP
+// status.
+func writeHTTPError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.FailedPrecondition:
+		code = http.StatusConflict
+	}
+	writeJSON(w, code, map[string]string{"error": status.Convert(err).Message()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
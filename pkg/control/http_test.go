@@ -0,0 +1,154 @@
+package control
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario/builtin"
+)
+
+// startHTTPServer serves a Server's REST handler on a loopback port.
+func startHTTPServer(t *testing.T, runner Runner) string {
+	t.Helper()
+	srv := httptest.NewServer(NewServer(runner).HTTPHandler())
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func submitHTTP(t *testing.T, base string, query url.Values) *http.Response {
+	t.Helper()
+	data, err := builtin.Get("validator-isolation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(base+"/tests?"+query.Encode(), "application/yaml", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestHTTP_RunToCompletion(t *testing.T) {
+	runner := newFakeRunner()
+	base := startHTTPServer(t, runner)
+
+	resp := submitHTTP(t, base, url.Values{"var": {"ENCLAVE_NAME=test"}, "set": {"duration=2m"}})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /tests status = %d", resp.StatusCode)
+	}
+	var submitted struct {
+		TestID string `json:"test_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&submitted); err != nil {
+		t.Fatal(err)
+	}
+	<-runner.started
+
+	if again := submitHTTP(t, base, url.Values{"var": {"ENCLAVE_NAME=test"}}); again.StatusCode != http.StatusConflict {
+		t.Errorf("second POST /tests status = %d, want %d", again.StatusCode, http.StatusConflict)
+	}
+
+	status := getStatus(t, base+"/tests/"+submitted.TestID)
+	if status.Done || status.State != "INJECT" {
+		t.Errorf("status while running = %+v", status)
+	}
+
+	events, err := http.Get(base + "/tests/" + submitted.TestID + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer events.Body.Close()
+	close(runner.release)
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(events.Body)
+	for scanner.Scan() {
+		var ev map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			t.Fatalf("event %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, ev)
+	}
+	if len(lines) != 5 {
+		t.Fatalf("got %d events, want 5", len(lines))
+	}
+	if _, ok := lines[4]["finished"]; !ok || lines[4]["test_id"] != submitted.TestID {
+		t.Errorf("last event = %v, want finished for %s", lines[4], submitted.TestID)
+	}
+
+	status = getStatus(t, base+"/tests/"+submitted.TestID)
+	if !status.Done || !status.Success || status.ExitCode == nil || *status.ExitCode != 0 {
+		t.Errorf("final status = %+v", status)
+	}
+	if string(status.Report) != `{"test_id":"test-1"}` {
+		t.Errorf("report = %s", status.Report)
+	}
+}
+
+func TestHTTP_Stop(t *testing.T) {
+	runner := newFakeRunner()
+	base := startHTTPServer(t, runner)
+
+	resp := submitHTTP(t, base, url.Values{"var": {"ENCLAVE_NAME=test"}})
+	var submitted struct {
+		TestID string `json:"test_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&submitted); err != nil {
+		t.Fatal(err)
+	}
+	<-runner.started
+
+	req, _ := http.NewRequest(http.MethodDelete, base+"/tests/"+submitted.TestID, nil)
+	stop, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop.Body.Close()
+	if stop.StatusCode != http.StatusAccepted {
+		t.Errorf("DELETE status = %d, want %d", stop.StatusCode, http.StatusAccepted)
+	}
+}
+
+func TestHTTP_Errors(t *testing.T) {
+	base := startHTTPServer(t, newFakeRunner())
+
+	if resp := submitHTTP(t, base, url.Values{"set": {"duration"}}); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("malformed set status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	resp, err := http.Post(base+"/tests", "application/yaml", bytes.NewBufferString("{{{"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid scenario status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	resp, err = http.Get(base + "/tests/nope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown test status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func getStatus(t *testing.T, u string) TestStatus {
+	t.Helper()
+	resp, err := http.Get(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var st TestStatus
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
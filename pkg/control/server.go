@@ -0,0 +1,302 @@
+// Package control serves the gRPC control API
+// (api/proto/chaosrunner/v1/chaos_runner.proto) that lets a larger test
+// harness submit scenarios, stream their progress, stop them and fetch
+// their reports. The same operations are also served as REST; see
+// Server.HTTPHandler.
+package control
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	chaosrunnerv1 "github.com/jihwankim/chaos-utils/api/gen/chaosrunner/v1"
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/parser"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Runner executes the scenarios the server accepts. chaos-runner serve
+// backs it with an orchestrator; tests use a fake.
+type Runner interface {
+	// Run executes one validated scenario, reporting progress to obs, and
+	// returns the JSON report and the exit code `chaos-runner run` would
+	// have used. report is nil when the run never got far enough to
+	// produce one.
+	Run(ctx context.Context, s *scenario.Scenario, obs orchestrator.Observer) (report []byte, exitCode int, err error)
+	// Stop interrupts the run in progress, if any. Teardown still runs.
+	Stop()
+}
+
+// Server implements chaosrunnerv1.ChaosRunnerServer. It runs one test at a
+// time and keeps every test's events and report for the life of the
+// process.
+type Server struct {
+	chaosrunnerv1.UnimplementedChaosRunnerServer
+
+	runner Runner
+	// profile renders criterion presets in submitted scenarios; nil uses
+	// the default profile.
+	profile *config.Profile
+
+	mu     sync.Mutex
+	tests  map[string]*test
+	active *test
+	seq    int
+}
+
+// NewServer returns a server that executes scenarios with runner.
+func NewServer(runner Runner) *Server {
+	return &Server{
+		runner: runner,
+		tests:  make(map[string]*test),
+	}
+}
+
+// SetProfile sets the deployment profile criterion presets in submitted
+// scenarios are rendered for. Set it before serving.
+func (s *Server) SetProfile(p *config.Profile) {
+	s.profile = p
+}
+
+// SubmitScenario parses, validates and starts a scenario.
+func (s *Server) SubmitScenario(ctx context.Context, req *chaosrunnerv1.SubmitScenarioRequest) (*chaosrunnerv1.SubmitScenarioResponse, error) {
+	p := parser.New(req.GetVariables())
+	p.Profile = s.profile
+	scenarios, err := p.ParseAll(req.GetScenarioYaml())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse scenario: %v", err)
+	}
+	if len(scenarios) != 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "expected one scenario document, got %d", len(scenarios))
+	}
+	scen := scenarios[0]
+	if len(req.GetOverrides()) > 0 {
+		if err := parser.ApplyOverrides(scen, req.GetOverrides()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to apply overrides: %v", err)
+		}
+	}
+	v := validator.New()
+	if err := v.Validate(scen); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "scenario %s: %v: %s", scen.Metadata.Name, err, strings.Join(v.Errors, "; "))
+	}
+
+	s.mu.Lock()
+	if s.active != nil {
+		s.mu.Unlock()
+		return nil, status.Errorf(codes.FailedPrecondition, "test %s is still running", s.active.id)
+	}
+	s.seq++
+	t := newTest(fmt.Sprintf("grpc-%d-%d", time.Now().Unix(), s.seq))
+	s.tests[t.id] = t
+	if !req.GetDryRun() {
+		s.active = t
+	}
+	s.mu.Unlock()
+
+	if req.GetDryRun() {
+		t.finish(nil, 0, fmt.Sprintf("scenario %s valid (dry-run mode)", scen.Metadata.Name))
+	} else {
+		go s.run(t, scen)
+	}
+	return &chaosrunnerv1.SubmitScenarioResponse{TestId: t.id, Warnings: v.Warnings}, nil
+}
+
+// run executes t on the runner and clears the active slot when it ends.
+func (s *Server) run(t *test, scen *scenario.Scenario) {
+	report, exitCode, err := s.runner.Run(context.Background(), scen, t)
+	msg := "Test completed successfully"
+	if err != nil {
+		msg = err.Error()
+	}
+	t.finish(report, exitCode, msg)
+
+	s.mu.Lock()
+	if s.active == t {
+		s.active = nil
+	}
+	s.mu.Unlock()
+}
+
+// StreamEvents replays t's events so far, then follows it to the end.
+func (s *Server) StreamEvents(req *chaosrunnerv1.StreamEventsRequest, stream chaosrunnerv1.ChaosRunner_StreamEventsServer) error {
+	t, err := s.lookup(req.GetTestId())
+	if err != nil {
+		return err
+	}
+	sent := 0
+	for {
+		events, done, wake := t.since(sent)
+		for _, ev := range events {
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+		sent += len(events)
+		if done {
+			return nil
+		}
+		select {
+		case <-wake:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// StopTest interrupts a running test.
+func (s *Server) StopTest(ctx context.Context, req *chaosrunnerv1.StopTestRequest) (*chaosrunnerv1.StopTestResponse, error) {
+	t, err := s.lookup(req.GetTestId())
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	running := s.active == t
+	s.mu.Unlock()
+	if running {
+		s.runner.Stop()
+	}
+	return &chaosrunnerv1.StopTestResponse{State: t.currentState()}, nil
+}
+
+// GetReport returns a finished test's JSON report.
+func (s *Server) GetReport(ctx context.Context, req *chaosrunnerv1.GetReportRequest) (*chaosrunnerv1.GetReportResponse, error) {
+	t, err := s.lookup(req.GetTestId())
+	if err != nil {
+		return nil, err
+	}
+	report, done := t.result()
+	if !done {
+		return nil, status.Errorf(codes.FailedPrecondition, "test %s is still running", t.id)
+	}
+	if report == nil {
+		return nil, status.Errorf(codes.NotFound, "test %s produced no report", t.id)
+	}
+	return &chaosrunnerv1.GetReportResponse{ReportJson: report}, nil
+}
+
+func (s *Server) lookup(id string) (*test, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tests[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown test %q", id)
+	}
+	return t, nil
+}
+
+// test is one submission: its event log, state and report. It is the
+// orchestrator.Observer for its own run.
+type test struct {
+	id string
+
+	mu     sync.Mutex
+	events []*chaosrunnerv1.Event
+	state  string
+	done   bool
+	report []byte
+	// wake is closed and replaced on every new event, waking streams.
+	wake chan struct{}
+}
+
+func newTest(id string) *test {
+	return &test{id: id, state: orchestrator.StateInit.String(), wake: make(chan struct{})}
+}
+
+// emit appends an event and wakes every stream.
+func (t *test) emit(ev *chaosrunnerv1.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.appendLocked(ev)
+}
+
+func (t *test) appendLocked(ev *chaosrunnerv1.Event) {
+	ev.TestId = t.id
+	ev.Time = timestamppb.Now()
+	t.events = append(t.events, ev)
+	close(t.wake)
+	t.wake = make(chan struct{})
+}
+
+// since returns the events after the first n, whether the test has
+// finished, and a channel closed on the next event.
+func (t *test) since(n int) ([]*chaosrunnerv1.Event, bool, <-chan struct{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.events[n:], t.done, t.wake
+}
+
+func (t *test) currentState() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state
+}
+
+func (t *test) result() ([]byte, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.report, t.done
+}
+
+// finished returns the final event's outcome, or nil while running.
+func (t *test) finished() *chaosrunnerv1.TestFinished {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.done || len(t.events) == 0 {
+		return nil
+	}
+	return t.events[len(t.events)-1].GetFinished()
+}
+
+// finish records the outcome and emits the final event.
+func (t *test) finish(report []byte, exitCode int, msg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.report = report
+	t.done = true
+	t.appendLocked(&chaosrunnerv1.Event{Event: &chaosrunnerv1.Event_Finished{Finished: &chaosrunnerv1.TestFinished{
+		Success:  exitCode == 0,
+		Message:  msg,
+		ExitCode: int32(exitCode),
+	}}})
+}
+
+// StateChanged implements orchestrator.Observer.
+func (t *test) StateChanged(from, to orchestrator.TestState) {
+	t.mu.Lock()
+	t.state = to.String()
+	t.mu.Unlock()
+	t.emit(&chaosrunnerv1.Event{Event: &chaosrunnerv1.Event_State{State: &chaosrunnerv1.StateTransition{
+		From: from.String(),
+		To:   to.String(),
+	}}})
+}
+
+// FaultInjected implements orchestrator.Observer.
+func (t *test) FaultInjected(phase, faultType string, target orchestrator.TargetInfo) {
+	t.emit(&chaosrunnerv1.Event{Event: &chaosrunnerv1.Event_Fault{Fault: &chaosrunnerv1.FaultInjected{
+		Phase:       phase,
+		Type:        faultType,
+		Target:      target.Name,
+		ContainerId: target.ContainerID,
+	}}})
+}
+
+// CriterionEvaluated implements orchestrator.Observer.
+func (t *test) CriterionEvaluated(c orchestrator.CriterionOutcome, duringFault bool) {
+	t.emit(&chaosrunnerv1.Event{Event: &chaosrunnerv1.Event_Criterion{Criterion: &chaosrunnerv1.CriterionEvaluated{
+		Name:        c.Name,
+		Passed:      c.Passed,
+		Value:       c.Value,
+		Message:     c.Message,
+		Critical:    c.Critical,
+		DuringFault: duringFault,
+	}}})
+}
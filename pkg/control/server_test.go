@@ -0,0 +1,249 @@
+package control
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	chaosrunnerv1 "github.com/jihwankim/chaos-utils/api/gen/chaosrunner/v1"
+	"github.com/jihwankim/chaos-utils/pkg/core/orchestrator"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/builtin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRunner walks a run through a few observer calls, then blocks until
+// released or stopped.
+type fakeRunner struct {
+	started chan *scenario.Scenario
+	release chan struct{}
+	stopped chan struct{}
+}
+
+func newFakeRunner() *fakeRunner {
+	return &fakeRunner{
+		started: make(chan *scenario.Scenario, 1),
+		release: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (f *fakeRunner) Run(ctx context.Context, s *scenario.Scenario, obs orchestrator.Observer) ([]byte, int, error) {
+	obs.StateChanged(orchestrator.StateInit, orchestrator.StateParse)
+	obs.StateChanged(orchestrator.StateParse, orchestrator.StateInject)
+	obs.FaultInjected("isolate", "network", orchestrator.TargetInfo{Name: "l2-cl-1", ContainerID: "abc"})
+	obs.CriterionEvaluated(orchestrator.CriterionOutcome{Name: "liveness", Passed: true, Value: 1}, false)
+	f.started <- s
+	select {
+	case <-f.release:
+		return []byte(`{"test_id":"test-1"}`), 0, nil
+	case <-f.stopped:
+		return []byte(`{"test_id":"test-1"}`), 2, errors.New("interrupted during INJECT: context canceled")
+	}
+}
+
+func (f *fakeRunner) Stop() { close(f.stopped) }
+
+// startServer serves a Server on a loopback port and returns a client.
+func startServer(t *testing.T, runner Runner) chaosrunnerv1.ChaosRunnerClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := grpc.NewServer()
+	chaosrunnerv1.RegisterChaosRunnerServer(gs, NewServer(runner))
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return chaosrunnerv1.NewChaosRunnerClient(conn)
+}
+
+func validScenario(t *testing.T) *chaosrunnerv1.SubmitScenarioRequest {
+	t.Helper()
+	data, err := builtin.Get("validator-isolation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &chaosrunnerv1.SubmitScenarioRequest{
+		ScenarioYaml: data,
+		Variables:    map[string]string{"ENCLAVE_NAME": "test"},
+		Overrides:    map[string]string{"duration": "2m"},
+	}
+}
+
+// collect reads a stream to its end.
+func collect(t *testing.T, stream chaosrunnerv1.ChaosRunner_StreamEventsClient) []*chaosrunnerv1.Event {
+	t.Helper()
+	var events []*chaosrunnerv1.Event
+	for {
+		ev, err := stream.Recv()
+		if err == io.EOF {
+			return events
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		events = append(events, ev)
+	}
+}
+
+func TestServer_RunToCompletion(t *testing.T) {
+	runner := newFakeRunner()
+	client := startServer(t, runner)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.SubmitScenario(ctx, validScenario(t))
+	if err != nil {
+		t.Fatalf("SubmitScenario() error = %v", err)
+	}
+	scen := <-runner.started
+	if scen.Spec.Duration != 2*time.Minute {
+		t.Errorf("override not applied: duration = %s", scen.Spec.Duration)
+	}
+
+	if _, err := client.SubmitScenario(ctx, validScenario(t)); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("second SubmitScenario() error = %v, want FailedPrecondition", err)
+	}
+	if _, err := client.GetReport(ctx, &chaosrunnerv1.GetReportRequest{TestId: resp.TestId}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetReport() while running error = %v, want FailedPrecondition", err)
+	}
+
+	// Subscribe late: the events emitted so far are replayed.
+	stream, err := client.StreamEvents(ctx, &chaosrunnerv1.StreamEventsRequest{TestId: resp.TestId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(runner.release)
+	events := collect(t, stream)
+
+	if len(events) != 5 {
+		t.Fatalf("got %d events, want 5: %v", len(events), events)
+	}
+	if got := events[1].GetState().GetTo(); got != "INJECT" {
+		t.Errorf("events[1] state to = %q, want INJECT", got)
+	}
+	if got := events[2].GetFault().GetTarget(); got != "l2-cl-1" {
+		t.Errorf("events[2] fault target = %q, want l2-cl-1", got)
+	}
+	if got := events[3].GetCriterion().GetName(); got != "liveness" {
+		t.Errorf("events[3] criterion = %q, want liveness", got)
+	}
+	finished := events[4].GetFinished()
+	if finished == nil || !finished.Success || finished.ExitCode != 0 {
+		t.Errorf("last event = %v, want successful finish", events[4])
+	}
+	for _, ev := range events {
+		if ev.TestId != resp.TestId {
+			t.Errorf("event test_id = %q, want %q", ev.TestId, resp.TestId)
+		}
+	}
+
+	report, err := client.GetReport(ctx, &chaosrunnerv1.GetReportRequest{TestId: resp.TestId})
+	if err != nil {
+		t.Fatalf("GetReport() error = %v", err)
+	}
+	if string(report.ReportJson) != `{"test_id":"test-1"}` {
+		t.Errorf("GetReport() = %s", report.ReportJson)
+	}
+}
+
+func TestServer_StopTest(t *testing.T) {
+	runner := newFakeRunner()
+	client := startServer(t, runner)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.SubmitScenario(ctx, validScenario(t))
+	if err != nil {
+		t.Fatalf("SubmitScenario() error = %v", err)
+	}
+	<-runner.started
+
+	stop, err := client.StopTest(ctx, &chaosrunnerv1.StopTestRequest{TestId: resp.TestId})
+	if err != nil {
+		t.Fatalf("StopTest() error = %v", err)
+	}
+	if stop.State != "INJECT" {
+		t.Errorf("StopTest() state = %q, want INJECT", stop.State)
+	}
+
+	stream, err := client.StreamEvents(ctx, &chaosrunnerv1.StreamEventsRequest{TestId: resp.TestId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := collect(t, stream)
+	finished := events[len(events)-1].GetFinished()
+	if finished == nil || finished.Success || finished.ExitCode != 2 {
+		t.Errorf("last event = %v, want interrupted finish with exit code 2", events[len(events)-1])
+	}
+
+	// The slot is free again once the stopped run has finished.
+	if _, err := client.SubmitScenario(ctx, validScenario(t)); err != nil {
+		t.Errorf("SubmitScenario() after stop error = %v", err)
+	}
+}
+
+func TestServer_SubmitInvalid(t *testing.T) {
+	client := startServer(t, newFakeRunner())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name string
+		req  *chaosrunnerv1.SubmitScenarioRequest
+	}{
+		{"not yaml", &chaosrunnerv1.SubmitScenarioRequest{ScenarioYaml: []byte("{{{")}},
+		{"fails validation", &chaosrunnerv1.SubmitScenarioRequest{ScenarioYaml: []byte(
+			"apiVersion: chaos.polygon.io/v1\nkind: ChaosScenario\nmetadata:\n  name: empty\nspec:\n  duration: 1m\n")}},
+		{"bad override", func() *chaosrunnerv1.SubmitScenarioRequest {
+			req := validScenario(t)
+			req.Overrides = map[string]string{"duration": "soon"}
+			return req
+		}()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.SubmitScenario(ctx, tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("SubmitScenario() error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+
+	if _, err := client.GetReport(ctx, &chaosrunnerv1.GetReportRequest{TestId: "nope"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetReport(unknown) error = %v, want NotFound", err)
+	}
+}
+
+func TestServer_DryRun(t *testing.T) {
+	client := startServer(t, newFakeRunner())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req := validScenario(t)
+	req.DryRun = true
+	resp, err := client.SubmitScenario(ctx, req)
+	if err != nil {
+		t.Fatalf("SubmitScenario() error = %v", err)
+	}
+	stream, err := client.StreamEvents(ctx, &chaosrunnerv1.StreamEventsRequest{TestId: resp.TestId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := collect(t, stream)
+	if len(events) != 1 || !events[0].GetFinished().GetSuccess() {
+		t.Errorf("dry-run events = %v, want a single successful finish", events)
+	}
+}
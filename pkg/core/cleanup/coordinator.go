@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/jihwankim/chaos-utils/pkg/injection/sidecar"
+	"github.com/jihwankim/chaos-utils/pkg/injection/verification"
 )
 
 // Coordinator orchestrates cleanup of all chaos artifacts.
@@ -142,15 +143,17 @@ func (c *Coordinator) cleanupSidecar(ctx context.Context, targetID string) error
 func (c *Coordinator) verifySidecarNamespace(ctx context.Context, targetID string) bool {
 	clean := true
 
-	// Check tc rules
-	output, err := c.sidecarMgr.ExecInSidecar(ctx, targetID, []string{"tc", "qdisc", "show", "dev", "eth0"})
+	// Check tc rules on every device
+	output, err := c.sidecarMgr.ExecInSidecar(ctx, targetID, []string{"tc", "qdisc", "show"})
 	if err != nil {
 		// Sidecar may already be gone — treat as clean (best-effort)
 		return true
 	}
-	if strings.Contains(output, "netem") || strings.Contains(output, "tbf") {
-		c.logAudit("verify_namespace", targetID, fmt.Sprintf("TC rules still present: %s", strings.TrimSpace(output)), nil)
-		clean = false
+	for _, iface := range verification.ParseQdiscs(output) {
+		if iface.ChaosQdisc {
+			c.logAudit("verify_namespace", targetID, fmt.Sprintf("TC rules still present on %s: %s", iface.Device, strings.Join(iface.Qdiscs, "; ")), nil)
+			clean = false
+		}
 	}
 
 	// Check iptables rules (filter + nat tables).
@@ -170,8 +173,14 @@ func (c *Coordinator) verifySidecarNamespace(ctx context.Context, targetID strin
 
 // cleanViaSidecar removes tc and iptables rules using the sidecar.
 func (c *Coordinator) cleanViaSidecar(ctx context.Context, targetID string) {
-	// Remove tc qdisc (covers all tc-based faults)
-	_, _ = c.sidecarMgr.ExecInSidecar(ctx, targetID, []string{"tc", "qdisc", "del", "dev", "eth0", "root"})
+	// Remove chaos root qdiscs on every device that has one (covers all
+	// tc-based faults, including discovered / all_interfaces devices).
+	// Devices with only kernel-default qdiscs are left alone.
+	if output, err := c.sidecarMgr.ExecInSidecar(ctx, targetID, []string{"tc", "qdisc", "show"}); err == nil {
+		for _, cmd := range verification.CleanupCommands(verification.ParseQdiscs(output)) {
+			_, _ = c.sidecarMgr.ExecInSidecar(ctx, targetID, cmd)
+		}
+	}
 
 	// Remove firewall CHAOS_DROP chain and INPUT jump (connection_drop fault).
 	_, _ = c.sidecarMgr.ExecInSidecar(ctx, targetID, []string{"iptables", "-D", "INPUT", "-j", "CHAOS_DROP", "-m", "comment", "--comment", "chaos-engineering"})
@@ -211,6 +220,14 @@ func (c *Coordinator) logAudit(action, target, details string, err error) {
 	c.auditLog = append(c.auditLog, entry)
 }
 
+// ResetAuditLog clears the audit log, so a coordinator reused across
+// scenarios reports each one's cleanup separately.
+func (c *Coordinator) ResetAuditLog() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.auditLog = make([]AuditEntry, 0)
+}
+
 // GetAuditLog returns a copy of the audit log. Copy, not slice reference,
 // because the caller may iterate while a concurrent CleanupAll appends.
 func (c *Coordinator) GetAuditLog() []AuditEntry {
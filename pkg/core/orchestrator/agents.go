@@ -0,0 +1,164 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/docker/docker/api/types"
+	"github.com/jihwankim/chaos-utils/pkg/agent"
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/discovery/k8s"
+	"github.com/jihwankim/chaos-utils/pkg/injection"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// dialAgents returns a client per configured chaos-agent, keyed by name.
+func dialAgents(agents []config.AgentConfig) (map[string]*agent.Client, error) {
+	clients := make(map[string]*agent.Client, len(agents))
+	for _, a := range agents {
+		c, err := agent.Dial(a.Name, a.Address, a.Token)
+		if err != nil {
+			for _, opened := range clients {
+				opened.Close()
+			}
+			return nil, err
+		}
+		clients[a.Name] = c
+	}
+	return clients, nil
+}
+
+// candidate is a running container discovery can match: on the local
+// daemon, or on the host of the agent named by agent. A Kubernetes pod
+// candidate has pod set instead.
+type candidate struct {
+	id    string
+	names []string
+	ip    string
+	agent string
+	pod   *k8s.Pod
+}
+
+// listCandidates lists the running containers of the local daemon and of
+// every agent. An agent that cannot be reached fails discovery, since its
+// targets would otherwise silently drop out of the run.
+func (o *Orchestrator) listCandidates(ctx context.Context) ([]candidate, error) {
+	containers, err := o.dockerClient.ContainerList(ctx, types.ContainerListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	candidates := make([]candidate, 0, len(containers))
+	for _, c := range containers {
+		candidates = append(candidates, candidate{id: c.ID, names: c.Names, ip: getContainerIP(c)})
+	}
+
+	names := make([]string, 0, len(o.agents))
+	for name := range o.agents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		remote, err := o.agents[name].ListContainers(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list containers: %w", err)
+		}
+		for _, c := range remote {
+			candidates = append(candidates, candidate{id: c.GetId(), names: c.GetNames(), ip: c.GetIp(), agent: name})
+		}
+	}
+	return candidates, nil
+}
+
+// agentFor returns the agent serving containerID, or nil when the
+// container is on the local daemon.
+func (o *Orchestrator) agentFor(containerID string) *agent.Client {
+	for _, t := range o.targets {
+		if t.ContainerID == containerID && t.Agent != "" {
+			return o.agents[t.Agent]
+		}
+	}
+	return nil
+}
+
+// byAgent splits targets into the local ones and those of each agent.
+func byAgent(targets []TargetInfo) (local []TargetInfo, remote map[string][]TargetInfo) {
+	remote = make(map[string][]TargetInfo)
+	for _, t := range targets {
+		if t.Agent == "" {
+			local = append(local, t)
+		} else {
+			remote[t.Agent] = append(remote[t.Agent], t)
+		}
+	}
+	return local, remote
+}
+
+// injectionTargets converts targets for the injector and agent API.
+func injectionTargets(targets []TargetInfo) []injection.Target {
+	out := make([]injection.Target, len(targets))
+	for i, t := range targets {
+		out[i] = injection.Target{Name: t.Name, ContainerID: t.ContainerID}
+	}
+	return out
+}
+
+// injectTargets installs fault on targets, locally, through their agents
+// or in Kubernetes pods. The error joins every group that failed.
+func (o *Orchestrator) injectTargets(ctx context.Context, fault *scenario.Fault, targets []TargetInfo) error {
+	containers, pods := byBackend(targets)
+	local, remote := byAgent(containers)
+	var errs []error
+	if len(local) > 0 {
+		errs = append(errs, o.injector.InjectFault(ctx, fault, injectionTargets(local)))
+	}
+	if len(pods) > 0 {
+		errs = append(errs, o.podInjector.InjectFault(ctx, fault, injectionTargets(pods)))
+	}
+	for name, ts := range remote {
+		errs = append(errs, o.agents[name].InjectFault(ctx, fault, injectionTargets(ts)))
+	}
+	return errors.Join(errs...)
+}
+
+// prepareAgentTargets has each agent create the sidecars of its targets;
+// stressAliases are the aliases whose sidecars need the host cgroups.
+func (o *Orchestrator) prepareAgentTargets(ctx context.Context, remote map[string][]TargetInfo, stressAliases map[string]bool) error {
+	for name, targets := range remote {
+		cgroup := make(map[string]bool)
+		for _, t := range targets {
+			cgroup[t.ContainerID] = stressAliases[t.Alias]
+		}
+		fmt.Printf("  Creating %d sidecar(s) through agent %s...\n", len(targets), name)
+		if err := o.agents[name].Prepare(ctx, injectionTargets(targets), cgroup); err != nil {
+			return fmt.Errorf("failed to create sidecars: %w", err)
+		}
+	}
+	return nil
+}
+
+// cleanupAgents has every agent holding targets of this run remove what
+// it still has installed and destroy its sidecars. Agents keep no
+// sidecars between runs.
+func (o *Orchestrator) cleanupAgents(ctx context.Context) {
+	_, remote := byAgent(o.targets)
+	for name := range remote {
+		removed, errs, err := o.agents[name].Cleanup(ctx)
+		if err != nil {
+			fmt.Printf("⚠ Cleanup on %v\n", err)
+			continue
+		}
+		fmt.Printf("Cleaned up agent %s (%d leftover fault(s) removed)\n", name, removed)
+		for _, e := range errs {
+			fmt.Printf("  ⚠ %s\n", e)
+		}
+	}
+}
+
+// closeAgents closes the agent connections.
+func (o *Orchestrator) closeAgents() {
+	for _, c := range o.agents {
+		c.Close()
+	}
+}
@@ -0,0 +1,206 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// BlastRadius compares how targeted validators and the untouched control
+// group fared over the fault window, so a report shows whether a fault on
+// one node spilled over onto the rest of the network.
+type BlastRadius struct {
+	// Window is the span measured: from INJECT start to DETECT.
+	Window  time.Duration
+	Metrics []BlastMetric
+}
+
+// BlastMetric is one health metric split into target and control groups.
+type BlastMetric struct {
+	Name        string
+	Description string
+	Target      BlastGroup
+	Control     BlastGroup
+	// Collateral lists control nodes that degraded beyond the metric's
+	// tolerance relative to their own pre-injection reading.
+	Collateral []BlastNode
+}
+
+// BlastGroup summarises one group's worst reading per node.
+type BlastGroup struct {
+	Nodes int
+	// Worst is the worst per-node reading in the group; Mean averages
+	// the per-node worst readings.
+	Worst float64
+	Mean  float64
+}
+
+// BlastNode is one node's reading before injection and its worst since.
+type BlastNode struct {
+	Job      string
+	Baseline float64
+	Worst    float64
+}
+
+// blastMetricDef describes how to measure one metric across a fleet. expr
+// yields one series per job; worst wraps it into the worst value over a
+// window; degraded decides whether a control node was collaterally hit.
+type blastMetricDef struct {
+	name        string
+	description string
+	expr        string
+	// higherIsWorse is true for lag, false for peer counts.
+	higherIsWorse bool
+	degraded      func(baseline, worst float64) bool
+}
+
+// blastLagTolerance is how many blocks a control node may fall further
+// behind than it was before injection without counting as collateral.
+const blastLagTolerance = 5
+
+func (d blastMetricDef) worstQuery(window time.Duration) string {
+	fn := "min_over_time"
+	if d.higherIsWorse {
+		fn = "max_over_time"
+	}
+	return fmt.Sprintf("%s((%s)[%s:15s])", fn, d.expr, scenario.PromDuration(window))
+}
+
+// blastMetrics returns the metrics compared for the profile's validator
+// fleet. Stacks without a validator set (CDK) have no control group to
+// compare against and return nil.
+func blastMetrics(p *config.Profile) []blastMetricDef {
+	if p.Stack != config.StackPoS {
+		return nil
+	}
+
+	lag := func(b, w float64) bool { return w-b > blastLagTolerance }
+	peers := func(b, w float64) bool { return w == 0 || w < b/2 }
+
+	el := fmt.Sprintf(`{job=~"%s"}`, p.ELJobPattern)
+	cl := fmt.Sprintf(`{job=~"%s"}`, p.CLJobPattern)
+	height := p.ConsensusMetricPrefix + "_consensus_height"
+	return []blastMetricDef{
+		{"bor_block_lag", "blocks behind the highest Bor node",
+			fmt.Sprintf("scalar(max(chain_head_block%s)) - chain_head_block%s", el, el), true, lag},
+		{"bor_peers", "Bor p2p peers",
+			"p2p_peers" + el, false, peers},
+		{"heimdall_height_lag", "heights behind the highest Heimdall node",
+			fmt.Sprintf("scalar(max(%s%s)) - %s%s", height, cl, height, cl), true, lag},
+		{"heimdall_peers", "Heimdall p2p peers",
+			p.ConsensusMetricPrefix + "_p2p_peers" + cl, false, peers},
+	}
+}
+
+// measureBlastRadius queries each blast metric at injection time and as
+// its worst since, and splits nodes into targets and control. Best-effort:
+// a metric whose queries fail or return nothing is left out.
+func (o *Orchestrator) measureBlastRadius(ctx context.Context) *BlastRadius {
+	defs := blastMetrics(o.cfg.ActiveProfile())
+	if o.promClient == nil || o.injectTime.IsZero() || len(defs) == 0 {
+		return nil
+	}
+
+	targeted := make(map[string]bool)
+	for _, t := range o.targets {
+		targeted[serviceName(t.Name)] = true
+	}
+
+	now := time.Now()
+	br := &BlastRadius{Window: now.Sub(o.injectTime).Round(time.Second)}
+	for _, def := range defs {
+		baseline, err := o.promClient.QueryInstant(ctx, def.expr, o.injectTime)
+		if err != nil {
+			fmt.Printf("  ⚠ blast radius: %s baseline query failed: %v\n", def.name, err)
+			continue
+		}
+		worst, err := o.promClient.QueryInstant(ctx, def.worstQuery(br.Window), now)
+		if err != nil {
+			fmt.Printf("  ⚠ blast radius: %s query failed: %v\n", def.name, err)
+			continue
+		}
+
+		before := make(map[string]float64)
+		for _, r := range baseline {
+			before[r.Labels["job"]] = r.Value
+		}
+		after := make(map[string]float64)
+		for _, r := range worst {
+			after[r.Labels["job"]] = r.Value
+		}
+		if m, ok := compareBlastMetric(def, before, after, targeted); ok {
+			br.Metrics = append(br.Metrics, m)
+		}
+	}
+
+	if len(br.Metrics) == 0 {
+		return nil
+	}
+	return br
+}
+
+// compareBlastMetric groups per-job worst readings into targets and
+// control and flags degraded control nodes. ok is false when neither group
+// has data.
+func compareBlastMetric(def blastMetricDef, baseline, worst map[string]float64, targeted map[string]bool) (BlastMetric, bool) {
+	m := BlastMetric{Name: def.name, Description: def.description}
+	var targetVals, controlVals []float64
+
+	jobs := make([]string, 0, len(worst))
+	for job := range worst {
+		jobs = append(jobs, job)
+	}
+	sort.Strings(jobs)
+
+	for _, job := range jobs {
+		w := worst[job]
+		if targeted[job] {
+			targetVals = append(targetVals, w)
+			continue
+		}
+		controlVals = append(controlVals, w)
+		if b, ok := baseline[job]; ok && def.degraded(b, w) {
+			m.Collateral = append(m.Collateral, BlastNode{Job: job, Baseline: b, Worst: w})
+		}
+	}
+
+	m.Target = summarizeBlastGroup(targetVals, def.higherIsWorse)
+	m.Control = summarizeBlastGroup(controlVals, def.higherIsWorse)
+	return m, m.Target.Nodes+m.Control.Nodes > 0
+}
+
+func summarizeBlastGroup(vals []float64, higherIsWorse bool) BlastGroup {
+	g := BlastGroup{Nodes: len(vals)}
+	if len(vals) == 0 {
+		return g
+	}
+	g.Worst = vals[0]
+	sum := 0.0
+	for _, v := range vals {
+		sum += v
+		if (higherIsWorse && v > g.Worst) || (!higherIsWorse && v < g.Worst) {
+			g.Worst = v
+		}
+	}
+	g.Mean = sum / float64(len(vals))
+	return g
+}
+
+// printBlastRadius writes the comparison to the console.
+func printBlastRadius(br *BlastRadius) {
+	fmt.Printf("Blast radius over %s (targets vs control):\n", br.Window)
+	for _, m := range br.Metrics {
+		fmt.Printf("  %-20s targets worst %.0f (%d)  control worst %.0f (%d)", m.Name, m.Target.Worst, m.Target.Nodes, m.Control.Worst, m.Control.Nodes)
+		if len(m.Collateral) > 0 {
+			fmt.Printf("  ⚠ %d collateral", len(m.Collateral))
+		}
+		fmt.Println()
+		for _, n := range m.Collateral {
+			fmt.Printf("    ⚠ %s: %.0f → %.0f\n", n.Job, n.Baseline, n.Worst)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// captureStopTimeout bounds stopping and copying out captures on the
+// abort path, where the run's own context may already be cancelled.
+const captureStopTimeout = 30 * time.Second
+
+// CaptureFile is one target's pcap from a fault with capture: true.
+type CaptureFile struct {
+	Target string
+	Path   string
+	Bytes  int64
+}
+
+// runningCapture is tcpdump running in one target's sidecar, on behalf of
+// every captured fault that hit the target.
+type runningCapture struct {
+	target TargetInfo
+	phases []string
+}
+
+// startCaptures starts tcpdump in the sidecar of every target of the given
+// faults that set capture: true. A target shared by several such faults
+// gets one capture, attached to each of them. A capture that fails to
+// start is reported and skipped: it is a diagnostic, not part of the fault.
+func (o *Orchestrator) startCaptures(ctx context.Context, faults []injectedFault) {
+	for _, f := range faults {
+		c, err := scenario.ParseCapture(f.Params)
+		if err != nil || !c.Enabled {
+			continue
+		}
+		if running, ok := o.captures[f.ContainerID]; ok {
+			running.phases = append(running.phases, f.Phase)
+			continue
+		}
+		target := TargetInfo{Name: f.ContainerID[:12], ContainerID: f.ContainerID}
+		for _, t := range o.targets {
+			if t.ContainerID == f.ContainerID {
+				target = t
+				break
+			}
+		}
+		if target.Agent != "" {
+			fmt.Printf("  ⚠ %s: packet capture on %s not started: not supported through agent %s\n", f.Phase, target.Name, target.Agent)
+			continue
+		}
+		if target.Pod != nil {
+			fmt.Printf("  ⚠ %s: packet capture on %s not started: not supported on Kubernetes pods\n", f.Phase, target.Name)
+			continue
+		}
+		if err := o.capturer.Start(ctx, f.ContainerID, c.MaxBytes, c.MaxDuration); err != nil {
+			fmt.Printf("  ⚠ %s: packet capture on %s not started: %v\n", f.Phase, target.Name, err)
+			continue
+		}
+		if o.captures == nil {
+			o.captures = make(map[string]*runningCapture)
+		}
+		o.captures[f.ContainerID] = &runningCapture{target: target, phases: []string{f.Phase}}
+		fmt.Printf("  ⏺ %s: capturing packets on %s\n", f.Phase, target.Name)
+	}
+}
+
+// stopCaptures stops every running capture and saves its pcap under
+// <output_dir>/captures/<test-id>/, recording the file for each fault it
+// belongs to. Calling it again is a no-op.
+func (o *Orchestrator) stopCaptures(ctx context.Context) {
+	if len(o.captures) == 0 {
+		return
+	}
+	dir := filepath.Join(o.cfg.Reporting.OutputDir, "captures", o.testID)
+	for containerID, running := range o.captures {
+		data, err := o.capturer.Stop(ctx, containerID)
+		if err != nil {
+			fmt.Printf("  ⚠ Packet capture on %s lost: %v\n", running.target.Name, err)
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			fmt.Printf("  ⚠ Packet capture on %s not saved: %v\n", running.target.Name, err)
+			continue
+		}
+		path := filepath.Join(dir, running.target.Name+".pcap")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			fmt.Printf("  ⚠ Packet capture on %s not saved: %v\n", running.target.Name, err)
+			continue
+		}
+		fmt.Printf("  ✓ Saved packet capture for %s to %s (%d bytes)\n", running.target.Name, path, len(data))
+		if o.captureFiles == nil {
+			o.captureFiles = make(map[string][]CaptureFile)
+		}
+		for _, phase := range running.phases {
+			o.captureFiles[phase] = append(o.captureFiles[phase], CaptureFile{Target: running.target.Name, Path: path, Bytes: int64(len(data))})
+		}
+	}
+	o.captures = nil
+}
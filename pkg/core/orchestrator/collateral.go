@@ -0,0 +1,123 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// CollateralEvent is a crash, OOM kill or restart of an enclave container
+// that no fault targeted, seen while faults were active. A memory fault on
+// one validator can take down services sharing its host.
+type CollateralEvent struct {
+	Container string
+	// Action is the Docker event: "die", "oom" or "restart".
+	Action string
+	At     time.Time
+	// ExitCode is set for "die" events.
+	ExitCode *int
+}
+
+// collateralWatcher follows Docker events for the enclave's non-target
+// containers from INJECT until teardown.
+type collateralWatcher struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	events []CollateralEvent
+}
+
+// startCollateralWatch subscribes to die/oom/restart events for every
+// container in the targets' Kurtosis enclave. Targets are excluded: their
+// restarts are the fault. Without an enclave (non-Kurtosis targets) there
+// is nothing to scope the watch to, so it is skipped.
+func (o *Orchestrator) startCollateralWatch(ctx context.Context) {
+	if o.dockerClient == nil || o.environment.Topology == nil || o.environment.Topology.EnclaveID == "" {
+		return
+	}
+	targets := make(map[string]bool, len(o.targets))
+	for _, t := range o.targets {
+		targets[t.ContainerID] = true
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &collateralWatcher{cancel: cancel, done: make(chan struct{})}
+	msgs, errs := o.dockerClient.Events(ctx, types.EventsOptions{
+		Since: strconv.FormatInt(time.Now().Unix(), 10),
+		Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("label", kurtosisEnclaveLabel+"="+o.environment.Topology.EnclaveID),
+			filters.Arg("event", string(events.ActionDie)),
+			filters.Arg("event", string(events.ActionOOM)),
+			filters.Arg("event", string(events.ActionRestart)),
+		),
+	})
+	go func() {
+		defer close(w.done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				if err != nil && ctx.Err() == nil {
+					fmt.Printf("  ⚠ Collateral watch stopped: %v\n", err)
+				}
+				return
+			case msg := <-msgs:
+				if targets[msg.Actor.ID] {
+					continue
+				}
+				w.mu.Lock()
+				w.events = append(w.events, collateralEvent(msg))
+				w.mu.Unlock()
+			}
+		}
+	}()
+	o.collateralWatch = w
+}
+
+// collateralEvent converts a Docker event message.
+func collateralEvent(msg events.Message) CollateralEvent {
+	ev := CollateralEvent{
+		Container: msg.Actor.Attributes["name"],
+		Action:    string(msg.Action),
+		At:        time.Unix(0, msg.TimeNano),
+	}
+	if ev.Container == "" && len(msg.Actor.ID) >= 12 {
+		ev.Container = msg.Actor.ID[:12]
+	}
+	if code, err := strconv.Atoi(msg.Actor.Attributes["exitCode"]); err == nil {
+		ev.ExitCode = &code
+	}
+	return ev
+}
+
+// stopCollateralWatch ends the watch and keeps what it saw for the report.
+// Calling it again is a no-op.
+func (o *Orchestrator) stopCollateralWatch() {
+	w := o.collateralWatch
+	if w == nil {
+		return
+	}
+	o.collateralWatch = nil
+	w.cancel()
+	<-w.done
+
+	w.mu.Lock()
+	o.collateral = append(o.collateral, w.events...)
+	w.mu.Unlock()
+	for _, ev := range o.collateral {
+		detail := ""
+		if ev.ExitCode != nil {
+			detail = fmt.Sprintf(" (exit %d)", *ev.ExitCode)
+		}
+		fmt.Printf("  ⚠ Collateral: %s %s%s at %s\n", ev.Container, ev.Action, detail, ev.At.Format(time.RFC3339))
+	}
+}
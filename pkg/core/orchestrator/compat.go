@@ -0,0 +1,356 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// CompatStatus is the outcome of one compatibility check.
+type CompatStatus string
+
+const (
+	CompatPass CompatStatus = "pass"
+	CompatWarn CompatStatus = "warn"
+	CompatFail CompatStatus = "fail"
+)
+
+// CompatibilityCheck is one row of the compatibility matrix.
+type CompatibilityCheck struct {
+	// Category groups rows: platform, selector, metric, endpoint, fault.
+	Category string `json:"category"`
+	// Subject is what was checked, e.g. a target alias or a criterion name.
+	Subject string       `json:"subject"`
+	Status  CompatStatus `json:"status"`
+	Detail  string       `json:"detail"`
+}
+
+// CompatibilityReport is the result of CheckCompatibility.
+type CompatibilityReport struct {
+	Checks []CompatibilityCheck `json:"checks"`
+}
+
+// Worst returns the most severe status in the report.
+func (r *CompatibilityReport) Worst() CompatStatus {
+	worst := CompatPass
+	for _, c := range r.Checks {
+		switch {
+		case c.Status == CompatFail:
+			return CompatFail
+		case c.Status == CompatWarn:
+			worst = CompatWarn
+		}
+	}
+	return worst
+}
+
+func (r *CompatibilityReport) add(category, subject string, status CompatStatus, format string, a ...interface{}) {
+	r.Checks = append(r.Checks, CompatibilityCheck{
+		Category: category,
+		Subject:  subject,
+		Status:   status,
+		Detail:   fmt.Sprintf(format, a...),
+	})
+}
+
+// faultTargetTools lists binaries a fault executes inside the target
+// container itself (not its sidecar). Minimal images often lack them.
+var faultTargetTools = map[string][]string{
+	"disk_fill":    {"sh", "df", "dd"},
+	"disk_io":      {"sh"},
+	"file_delete":  {"sh"},
+	"file_corrupt": {"sh", "dd"},
+	"clock_skew":   {"sh", "date"},
+	"process_kill": {"sh", "kill"},
+}
+
+// CheckCompatibility checks a validated scenario against the live enclave
+// without injecting anything: selectors resolve, criterion queries and
+// metrics exist in Prometheus, endpoints the faults call respond, and the
+// target containers and daemon support each fault. It never returns an
+// error; every problem is a row in the report.
+func (o *Orchestrator) CheckCompatibility(ctx context.Context, scen *scenario.Scenario) *CompatibilityReport {
+	report := &CompatibilityReport{}
+
+	platform, err := o.dockerClient.DaemonPlatform(ctx)
+	if err != nil {
+		report.add("platform", "docker", CompatFail, "%v", err)
+	} else {
+		report.add("platform", "docker", CompatPass, "%s, cgroup v%s", platform, platform.CgroupVersion)
+	}
+	o.checkAgents(ctx, report)
+
+	targets := o.checkSelectors(ctx, scen, report)
+	o.checkMetrics(ctx, scen, targets, report)
+	o.checkEndpoints(ctx, scen, report)
+
+	for _, fault := range scen.Spec.Faults {
+		subject := fmt.Sprintf("%s (%s → %s)", fault.Phase, fault.Type, fault.Target)
+		o.checkFaultSupport(ctx, fault, subject, targets[fault.Target], platform, report)
+	}
+
+	return report
+}
+
+// checkAgents pings every configured chaos-agent.
+func (o *Orchestrator) checkAgents(ctx context.Context, report *CompatibilityReport) {
+	names := make([]string, 0, len(o.agents))
+	for name := range o.agents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resp, err := o.agents[name].Ping(ctx)
+		if err != nil {
+			report.add("platform", "agent "+name, CompatFail, "%v", err)
+			continue
+		}
+		report.add("platform", "agent "+name, CompatPass, "%s (Docker %s, chaos-agent %s)", resp.GetHostname(), resp.GetDockerVersion(), resp.GetAgentVersion())
+	}
+}
+
+// checkSelectors resolves every target selector the way DISCOVER does and
+// returns the matched containers per alias.
+func (o *Orchestrator) checkSelectors(ctx context.Context, scen *scenario.Scenario, report *CompatibilityReport) map[string][]TargetInfo {
+	resolved := make(map[string][]TargetInfo)
+
+	containers, err := o.listCandidates(ctx)
+	if err != nil {
+		report.add("selector", "all targets", CompatFail, "%v", err)
+		return resolved
+	}
+
+	for _, t := range scen.Spec.Targets {
+		for _, c := range containers {
+			if matchPattern(c.names, t.Selector.Pattern) {
+				resolved[t.Alias] = append(resolved[t.Alias], TargetInfo{
+					Alias:       t.Alias,
+					ContainerID: c.id,
+					Name:        getContainerName(c.names),
+					IP:          c.ip,
+					Agent:       c.agent,
+				})
+			}
+		}
+
+		matched := resolved[t.Alias]
+		k, countErr := t.Count.Resolve(len(matched))
+		fixed, isFixed := t.Count.Fixed()
+		switch {
+		case len(matched) == 0:
+			report.add("selector", t.Alias, CompatFail, "pattern %q matches no running container", t.Selector.Pattern)
+		case countErr != nil:
+			report.add("selector", t.Alias, CompatFail, "pattern %q: %v", t.Selector.Pattern, countErr)
+		case isFixed && len(matched) < fixed:
+			report.add("selector", t.Alias, CompatWarn, "pattern %q matches %d container(s), fewer than count %d", t.Selector.Pattern, len(matched), fixed)
+		case !t.Count.IsZero() && k < len(matched):
+			report.add("selector", t.Alias, CompatPass, "count %s → %d of %d matched container(s)", t.Count, k, len(matched))
+		default:
+			names := make([]string, len(matched))
+			for i, m := range matched {
+				names[i] = m.Name
+			}
+			report.add("selector", t.Alias, CompatPass, "%d container(s): %s", len(matched), strings.Join(names, ", "))
+		}
+
+		if countErr == nil && k < len(matched) {
+			sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+			resolved[t.Alias] = matched[:k]
+		}
+	}
+
+	return resolved
+}
+
+// checkMetrics runs every prometheus criterion query and spec.metrics entry
+// once. A rejected query fails; an empty result only warns, since some
+// series (reorgs, failures) legitimately do not exist on a healthy chain.
+// $__target resolves against every selector match, before count narrows it.
+func (o *Orchestrator) checkMetrics(ctx context.Context, scen *scenario.Scenario, targets map[string][]TargetInfo, report *CompatibilityReport) {
+	if o.promClient == nil {
+		report.add("metric", "prometheus", CompatFail, "Prometheus client is not configured")
+		return
+	}
+
+	check := func(subject, query string) {
+		results, err := o.promClient.QueryLatest(ctx, query)
+		switch {
+		case err != nil:
+			report.add("metric", subject, CompatFail, "query rejected: %v", err)
+		case len(results) == 0:
+			report.add("metric", subject, CompatWarn, "query returns no series: %s", query)
+		default:
+			report.add("metric", subject, CompatPass, "%d series", len(results))
+		}
+	}
+
+	var matched []TargetInfo
+	for _, infos := range targets {
+		matched = append(matched, infos...)
+	}
+	jobs := targetJobs(matched)
+
+	for _, criterion := range scen.Spec.SuccessCriteria {
+		criterion, err := scenario.ResolveCriterionTarget(criterion, jobs)
+		if err != nil {
+			report.add("metric", criterion.Name, CompatFail, "%v", err)
+			continue
+		}
+		for _, leaf := range criterion.Leaves() {
+			if leaf.Type == "prometheus" && leaf.Query != "" {
+				check(criterion.Name, leaf.Query)
+			}
+		}
+	}
+	for _, metric := range scen.Spec.Metrics {
+		check(metric, metric)
+	}
+}
+
+// checkEndpoints probes HTTP endpoints the scenario depends on: rpc_url
+// params, the Heimdall API when a fault uses exclude_producer, and the EVM
+// RPC endpoint when a criterion has type rpc. Any HTTP response counts as
+// reachable.
+func (o *Orchestrator) checkEndpoints(ctx context.Context, scen *scenario.Scenario, report *CompatibilityReport) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	probe := func(subject, url string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			report.add("endpoint", subject, CompatFail, "invalid URL %q: %v", url, err)
+			return
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			report.add("endpoint", subject, CompatFail, "%s unreachable: %v", url, err)
+			return
+		}
+		resp.Body.Close()
+		report.add("endpoint", subject, CompatPass, "%s responded %d", url, resp.StatusCode)
+	}
+
+	needHeimdall := false
+	for _, fault := range scen.Spec.Faults {
+		if fault.ExcludeProducer {
+			needHeimdall = true
+		}
+		if url, ok := fault.Params["rpc_url"].(string); ok && url != "" {
+			probe(fmt.Sprintf("%s rpc_url", fault.Phase), url)
+		}
+	}
+
+	if scen.HasCriterionType("rpc") {
+		endpoints := o.cfg.RPC.Endpoints()
+		if len(endpoints) == 0 {
+			report.add("endpoint", "EVM RPC", CompatFail, "rpc criteria are set but no EVM RPC endpoint was discovered (set rpc.url or --rpc-url)")
+		}
+		for i, url := range endpoints {
+			subject := "EVM RPC"
+			if i > 0 {
+				subject = fmt.Sprintf("EVM RPC fallback %d", i)
+			}
+			probe(subject, url)
+		}
+	}
+
+	if scen.HasCriterionType("heimdall_api") {
+		needHeimdall = true
+	}
+	if needHeimdall {
+		if o.heimdallAPI == "" {
+			report.add("endpoint", "heimdall API", CompatFail, "exclude_producer or heimdall_api criteria are set but no Heimdall API endpoint was discovered")
+		} else {
+			probe("heimdall API", o.heimdallAPI)
+		}
+	}
+}
+
+// checkFaultSupport reports whether one fault can run on its targets.
+func (o *Orchestrator) checkFaultSupport(ctx context.Context, fault scenario.Fault, subject string, targets []TargetInfo, platform *docker.DaemonPlatform, report *CompatibilityReport) {
+	info, ok := scenario.LookupFaultType(fault.Type)
+	if !ok {
+		report.add("fault", subject, CompatFail, "unknown fault type %q", fault.Type)
+		return
+	}
+	if info.Umbrella {
+		report.add("fault", subject, CompatFail, "%q is a category, not an injectable fault type", fault.Type)
+		return
+	}
+	if len(targets) == 0 {
+		report.add("fault", subject, CompatFail, "target %q resolves to no containers", fault.Target)
+		return
+	}
+
+	var problems, notes []string
+
+	if platform != nil && info.RequiresLinux && platform.OSType != "linux" {
+		problems = append(problems, fmt.Sprintf("requires a Linux Docker daemon (daemon OSType is %s)", platform.OSType))
+	}
+
+	if info.UsesSidecar {
+		if _, _, err := o.dockerClient.GetClient().ImageInspectWithRaw(ctx, o.cfg.Docker.SidecarImage); err != nil {
+			notes = append(notes, fmt.Sprintf("sidecar image %s not present locally; PREPARE will pull it", o.cfg.Docker.SidecarImage))
+		}
+	}
+
+	// Sidecar prerequisites are checked at inject time, once PREPARE has
+	// created the sidecars.
+	for _, t := range targets {
+		if t.Agent != "" {
+			notes = append(notes, fmt.Sprintf("%s is on agent %s; its prerequisites are not checked", t.Name, t.Agent))
+			continue
+		}
+		if missing := o.targetPrerequisites(ctx, info.Name, t.ContainerID); len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s lacks %s", t.Name, strings.Join(missing, ", ")))
+		}
+	}
+
+	switch {
+	case len(problems) > 0:
+		report.add("fault", subject, CompatFail, "%s", strings.Join(append(problems, notes...), "; "))
+	case len(notes) > 0:
+		report.add("fault", subject, CompatWarn, "%s", strings.Join(notes, "; "))
+	default:
+		report.add("fault", subject, CompatPass, "supported on %d target(s)", len(targets))
+	}
+}
+
+// missingTools returns the tools not found on PATH in the container. When
+// the container cannot exec at all, every tool is reported missing.
+func (o *Orchestrator) missingTools(ctx context.Context, containerID string, tools []string) []string {
+	if len(tools) == 0 {
+		return nil
+	}
+	script := ""
+	for _, t := range tools {
+		script += fmt.Sprintf("command -v %s >/dev/null 2>&1 || echo %s; ", t, t)
+	}
+	out, err := o.dockerClient.ExecCommand(ctx, containerID, []string{"sh", "-c", script})
+	if err != nil {
+		return tools
+	}
+	return strings.Fields(out)
+}
+
+// hasCapability reports whether the container is privileged or was started
+// with cap in CapAdd.
+func (o *Orchestrator) hasCapability(ctx context.Context, containerID, cap string) bool {
+	info, err := o.dockerClient.ContainerInspect(ctx, containerID)
+	if err != nil || info.HostConfig == nil {
+		return false
+	}
+	if info.HostConfig.Privileged {
+		return true
+	}
+	for _, c := range info.HostConfig.CapAdd {
+		if strings.TrimPrefix(strings.ToUpper(c), "CAP_") == cap || strings.EqualFold(c, "ALL") {
+			return true
+		}
+	}
+	return false
+}
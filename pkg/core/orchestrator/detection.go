@@ -0,0 +1,246 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/detector"
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// Detection is when one spec.detection signal first reflected the fault.
+type Detection struct {
+	Signal string
+	// Kind is "alert" or "criterion".
+	Kind     string
+	Detected bool
+	// At is the first poll that saw the signal; Latency is At minus the
+	// injection start, accurate to the poll interval.
+	At      time.Time
+	Latency time.Duration
+	// PreExisting marks an alert that was already firing before injection
+	// and never cleared, so its firing says nothing about the fault.
+	PreExisting bool
+}
+
+// detectionWatcher polls the spec.detection signals from just before
+// INJECT until TEARDOWN and keeps the first time each one was seen.
+type detectionWatcher struct {
+	prom     *prometheus.Client
+	detector *detector.FailureDetector
+	spec     *scenario.DetectionSpec
+	criteria []scenario.SuccessCriterion
+
+	mu    sync.Mutex
+	first map[string]time.Time
+	// firingBefore holds alerts firing at the baseline poll; an alert
+	// leaves it once seen not firing.
+	firingBefore map[string]bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newDetectionWatcher returns nil when the scenario has no detection spec.
+func newDetectionWatcher(prom *prometheus.Client, det *detector.FailureDetector, scen *scenario.Scenario) *detectionWatcher {
+	spec := scen.Spec.Detection
+	if spec == nil {
+		return nil
+	}
+	w := &detectionWatcher{
+		prom:         prom,
+		detector:     det,
+		spec:         spec,
+		first:        make(map[string]time.Time),
+		firingBefore: make(map[string]bool),
+		done:         make(chan struct{}),
+	}
+	for _, name := range spec.Criteria {
+		for _, c := range scen.Spec.SuccessCriteria {
+			if c.Name == name {
+				w.criteria = append(w.criteria, c)
+				break
+			}
+		}
+	}
+	return w
+}
+
+// Start takes the alert baseline and launches the polling goroutine.
+func (w *detectionWatcher) Start(parentCtx context.Context) {
+	if w == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(parentCtx)
+	w.cancel = cancel
+
+	if firing, err := w.firingAlerts(ctx); err == nil {
+		for name := range firing {
+			w.firingBefore[name] = true
+			fmt.Printf("  ⚠ Alert %s is already firing before injection; it counts only after it clears\n", name)
+		}
+	}
+
+	go func() {
+		defer close(w.done)
+		ticker := time.NewTicker(w.spec.PollInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.poll(ctx)
+			}
+		}
+	}()
+}
+
+// poll checks every signal not yet detected.
+func (w *detectionWatcher) poll(ctx context.Context) {
+	now := time.Now()
+
+	if len(w.spec.Alerts) > 0 {
+		if firing, err := w.firingAlerts(ctx); err == nil {
+			w.mu.Lock()
+			for _, name := range w.spec.Alerts {
+				switch {
+				case !firing[name]:
+					delete(w.firingBefore, name)
+				case !w.firingBefore[name] && w.first[name].IsZero():
+					w.first[name] = now
+				}
+			}
+			w.mu.Unlock()
+		}
+	}
+
+	for _, c := range w.criteria {
+		w.mu.Lock()
+		seen := !w.first[c.Name].IsZero()
+		w.mu.Unlock()
+		if seen || w.detector == nil {
+			continue
+		}
+		r, err := w.detector.EvaluateOnce(ctx, c)
+		if err != nil || r.Unknown {
+			continue
+		}
+		if r.Passed == c.DuringFault {
+			w.mu.Lock()
+			w.first[c.Name] = now
+			w.mu.Unlock()
+		}
+	}
+}
+
+// firingAlerts returns the watched alerts that are firing now.
+func (w *detectionWatcher) firingAlerts(ctx context.Context) (map[string]bool, error) {
+	if w.prom == nil || len(w.spec.Alerts) == 0 {
+		return nil, nil
+	}
+	quoted := make([]string, len(w.spec.Alerts))
+	for i, a := range w.spec.Alerts {
+		quoted[i] = regexp.QuoteMeta(a)
+	}
+	query := fmt.Sprintf(`ALERTS{alertstate="firing",alertname=~"%s"}`, strings.Join(quoted, "|"))
+	results, err := w.prom.QueryLatest(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	firing := make(map[string]bool)
+	for _, r := range results {
+		firing[r.Labels["alertname"]] = true
+	}
+	return firing, nil
+}
+
+// StopAndCollect stops polling and returns one Detection per signal, with
+// latencies measured from injectedAt.
+func (w *detectionWatcher) StopAndCollect(injectedAt time.Time) []Detection {
+	if w == nil {
+		return nil
+	}
+	if w.cancel != nil {
+		w.cancel()
+		<-w.done
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var out []Detection
+	add := func(signal, kind string) {
+		d := Detection{Signal: signal, Kind: kind, PreExisting: w.firingBefore[signal]}
+		if at := w.first[signal]; !at.IsZero() {
+			d.Detected, d.At = true, at
+			if d.Latency = at.Sub(injectedAt); d.Latency < 0 {
+				d.Latency = 0
+			}
+		}
+		out = append(out, d)
+	}
+	for _, a := range w.spec.Alerts {
+		add(a, "alert")
+	}
+	for _, c := range w.criteria {
+		add(c.Name, "criterion")
+	}
+	return out
+}
+
+// printDetections prints the time-to-detect table.
+func printDetections(detections []Detection, maxLatency time.Duration) {
+	if len(detections) == 0 {
+		return
+	}
+	fmt.Println("Time to detect:")
+	for _, d := range detections {
+		switch {
+		case !d.Detected && d.PreExisting:
+			fmt.Printf("  ? %s %s: already firing before injection, never cleared\n", d.Kind, d.Signal)
+		case !d.Detected:
+			fmt.Printf("  ✗ %s %s: not detected\n", d.Kind, d.Signal)
+		case maxLatency > 0 && d.Latency > maxLatency:
+			fmt.Printf("  ✗ %s %s: %s (max %s)\n", d.Kind, d.Signal, d.Latency.Round(time.Second), maxLatency)
+		default:
+			fmt.Printf("  ✓ %s %s: %s\n", d.Kind, d.Signal, d.Latency.Round(time.Second))
+		}
+	}
+}
+
+// detectionOutcomes turns detections into critical criterion outcomes when
+// spec.detection.max_latency is set, so a slow or missing detection fails
+// the run like any other critical criterion.
+func detectionOutcomes(detections []Detection, maxLatency time.Duration) []CriterionOutcome {
+	if maxLatency <= 0 {
+		return nil
+	}
+	out := make([]CriterionOutcome, 0, len(detections))
+	for _, d := range detections {
+		o := CriterionOutcome{
+			Name:        "time_to_detect:" + d.Signal,
+			Description: fmt.Sprintf("%s %s reflects the fault within %s of injection", d.Kind, d.Signal, maxLatency),
+			Type:        "detection",
+			Threshold:   "<= " + maxLatency.String(),
+			Critical:    true,
+			Evaluations: 1,
+		}
+		if d.Detected {
+			o.Value = d.Latency.Seconds()
+			o.Passed = d.Latency <= maxLatency
+			o.Message = fmt.Sprintf("detected after %s", d.Latency.Round(time.Second))
+		} else {
+			o.Message = "not detected before teardown"
+		}
+		if !o.Passed {
+			o.Failures = 1
+		}
+		out = append(out, o)
+	}
+	return out
+}
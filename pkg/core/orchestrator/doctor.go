@@ -0,0 +1,329 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/jihwankim/chaos-utils/pkg/injection"
+	"github.com/jihwankim/chaos-utils/pkg/injection/disk"
+	"github.com/jihwankim/chaos-utils/pkg/injection/verification"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// doctorProbeTimeout bounds one fault type's inject, verify and remove.
+const doctorProbeTimeout = 90 * time.Second
+
+// doctorVictim is the process the throwaway container keeps restarting, so
+// process_kill has something to kill that is not PID 1 or the sidecar's
+// sleep. The command comes from the environment so PID 1's own cmdline
+// does not match it.
+const doctorVictim = "sleep 3599"
+
+// doctorProbe is one fault type Doctor exercises against the throwaway
+// container, with parameters small enough to be harmless.
+type doctorProbe struct {
+	faultType string
+	params    map[string]interface{}
+	// namespace faults leave qdiscs or iptables rules the verifier sees.
+	namespace bool
+	// lifecycle faults stop or restart the container, which takes its
+	// sidecar down with them; they run after every sidecar fault.
+	lifecycle bool
+	// injected and removed, when set, confirm the fault took effect and
+	// was undone beyond the injector's own error return.
+	injected func(ctx context.Context, o *Orchestrator, id string) error
+	removed  func(ctx context.Context, o *Orchestrator, id string) error
+}
+
+var doctorProbes = []doctorProbe{
+	{faultType: "network", params: map[string]interface{}{"latency": 50}, namespace: true},
+	{faultType: "dns", params: map[string]interface{}{"delay_ms": 100}, namespace: true},
+	{faultType: "connection_drop", params: map[string]interface{}{"probability": 0.5, "target_ports": "65000"}, namespace: true},
+	{faultType: "scrape_block", params: map[string]interface{}{"ports": "65001"}, namespace: true},
+	{faultType: "network_partition", params: map[string]interface{}{"peer_ips": "192.0.2.1"}, namespace: true},
+	{faultType: "cpu_stress", params: map[string]interface{}{"cores": 1, "cpu_percent": 10}},
+	{
+		faultType: "memory_stress",
+		params:    map[string]interface{}{"method": "limit", "memory_mb": 256},
+		injected: func(ctx context.Context, o *Orchestrator, id string) error {
+			info, err := o.dockerClient.ContainerInspect(ctx, id)
+			if err != nil {
+				return err
+			}
+			if info.HostConfig == nil || info.HostConfig.Memory != 256*1024*1024 {
+				return fmt.Errorf("memory limit not applied")
+			}
+			return nil
+		},
+	},
+	{
+		faultType: "disk_io",
+		params:    map[string]interface{}{"io_latency_ms": 50, "target_path": "/tmp"},
+		injected: func(ctx context.Context, o *Orchestrator, id string) error {
+			_, err := o.dockerClient.ExecCommand(ctx, id, []string{"test", "-s", "/tmp/.chaos_io_stress.pids"})
+			return err
+		},
+	},
+	{faultType: "disk_fill", params: map[string]interface{}{"size_mb": 8, "target_path": "/tmp"}},
+	{faultType: "process_kill", params: map[string]interface{}{"process_pattern": doctorVictim}},
+	{
+		faultType: "container_pause",
+		params:    map[string]interface{}{"unpause": false},
+		lifecycle: true,
+		injected:  doctorExpectPaused(true),
+		removed:   doctorExpectPaused(false),
+	},
+	{faultType: "container_restart", params: map[string]interface{}{}, lifecycle: true, injected: doctorExpectRunning},
+	{faultType: "container_kill", params: map[string]interface{}{"restart": true}, lifecycle: true, injected: doctorExpectRunning},
+}
+
+// doctorSkipped are fault types Doctor never exercises, with why.
+var doctorSkipped = map[string]string{
+	"clock_skew": "not exercised: date -s shifts the host clock for every container",
+}
+
+// DoctorFaultTypes lists the fault types Doctor can exercise, in run order.
+func DoctorFaultTypes() []string {
+	types := make([]string, 0, len(doctorProbes))
+	for _, p := range doctorProbes {
+		types = append(types, p.faultType)
+	}
+	return types
+}
+
+// Doctor starts a throwaway container from image, attaches a sidecar, and
+// for each requested fault type (all of DoctorFaultTypes when empty)
+// injects the fault, verifies it took effect, removes it and verifies it
+// is gone. It reports which fault types work against this Docker daemon
+// and kernel before a real experiment depends on them. Only a failure to
+// start the throwaway container is returned as an error; every fault
+// problem is a row in the report.
+func (o *Orchestrator) Doctor(ctx context.Context, image string, faultTypes []string) (*CompatibilityReport, error) {
+	probes, err := selectDoctorProbes(faultTypes)
+	if err != nil {
+		return nil, err
+	}
+	report := &CompatibilityReport{}
+
+	platform, err := o.dockerClient.DaemonPlatform(ctx)
+	if err != nil {
+		report.add("platform", "docker", CompatWarn, "could not detect daemon platform: %v", err)
+	} else {
+		report.add("platform", "docker", CompatPass, "%s", platform)
+	}
+
+	id, err := o.startDoctorContainer(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	defer o.removeDoctorContainer(id)
+
+	verifier := verification.New(o.dockerClient)
+	verifier.UseSidecarExec(o.sidecarMgr)
+	// The probes include stress-ng, which needs the cgroup-enabled sidecar.
+	sidecarID, err := o.sidecarMgr.CreateCgroupSidecar(ctx, id)
+	if err != nil {
+		report.add("platform", "sidecar", CompatFail, "%v", err)
+	} else {
+		report.add("platform", "sidecar", CompatPass, "sidecar image %s attached", o.cfg.Docker.SidecarImage)
+		// The same check disk_io runs before choosing method dm-delay.
+		if _, err := o.dockerClient.ExecCommand(ctx, sidecarID, []string{"test", "-d", disk.DmDelayModulePath}); err != nil {
+			report.add("kernel", "dm_delay", CompatWarn, "dm_delay module not loaded on the host (modprobe dm-delay); disk_io method dm-delay will fall back to ionice")
+		} else {
+			report.add("kernel", "dm_delay", CompatPass, "dm_delay module loaded; disk_io method dm-delay available")
+		}
+	}
+
+	sidecarDown := false
+	for _, p := range probes {
+		if p.lifecycle && !sidecarDown {
+			// Lifecycle faults kill the namespace the sidecar shares.
+			if err := o.sidecarMgr.DestroySidecar(ctx, id); err != nil {
+				fmt.Printf("⚠ Failed to destroy doctor sidecar: %v\n", err)
+			}
+			sidecarDown = true
+		}
+		if !p.lifecycle && sidecarID == "" {
+			report.add("fault", p.faultType, CompatFail, "skipped: no sidecar")
+			continue
+		}
+		start := time.Now()
+		status, detail := o.runDoctorProbe(ctx, p, id, verifier)
+		report.add("fault", p.faultType, status, "%s (%s)", detail, time.Since(start).Round(100*time.Millisecond))
+	}
+	if len(faultTypes) == 0 {
+		for _, ft := range sortedKeys(doctorSkipped) {
+			report.add("fault", ft, CompatWarn, "%s", doctorSkipped[ft])
+		}
+	}
+	return report, nil
+}
+
+// runDoctorProbe injects, verifies, removes and re-verifies one fault. A
+// failed step is reported with the prerequisites the target or sidecar
+// lacks, when that explains it.
+func (o *Orchestrator) runDoctorProbe(parent context.Context, p doctorProbe, id string, verifier *verification.Verifier) (CompatStatus, string) {
+	ctx, cancel := context.WithTimeout(parent, doctorProbeTimeout)
+	defer cancel()
+
+	fault := &scenario.Fault{Phase: "doctor", Target: "doctor", Type: p.faultType, Params: p.params, Duration: 30 * time.Second}
+	fail := func(stage string, err error) (CompatStatus, string) {
+		detail := fmt.Sprintf("%s: %v", stage, err)
+		missing := o.targetPrerequisites(ctx, p.faultType, id)
+		if sidecarID, ok := o.sidecarMgr.GetSidecarID(id); ok {
+			missing = append(missing, o.sidecarPrerequisites(ctx, *fault, p.faultType, sidecarID, nil)...)
+		}
+		if len(missing) > 0 {
+			detail += "; missing " + strings.Join(missing, ", ")
+		} else if p.faultType == "network" || p.faultType == "dns" {
+			detail += "; is the sch_netem kernel module available on the host?"
+		}
+		return CompatFail, detail
+	}
+
+	target := []injection.Target{{Name: "chaos-doctor", ContainerID: id}}
+	if err := o.injector.InjectFault(ctx, fault, target); err != nil {
+		// Best effort: a half-applied fault must not leak into the next.
+		_ = o.injector.RemoveFault(ctx, p.faultType, id)
+		return fail("inject", err)
+	}
+	if err := doctorVerify(ctx, o, p, id, verifier, true); err != nil {
+		_ = o.injector.RemoveFault(ctx, p.faultType, id)
+		return fail("verify", err)
+	}
+	if err := o.injector.RemoveFault(ctx, p.faultType, id); err != nil {
+		return fail("remove", err)
+	}
+	if err := doctorVerify(ctx, o, p, id, verifier, false); err != nil {
+		return fail("verify removal", err)
+	}
+	return CompatPass, "inject, verify and remove succeeded"
+}
+
+// doctorVerify checks that the fault is present (injected) or gone.
+func doctorVerify(ctx context.Context, o *Orchestrator, p doctorProbe, id string, verifier *verification.Verifier, injected bool) error {
+	if p.namespace {
+		result, err := verifier.VerifyNamespaceClean(ctx, id)
+		if err != nil {
+			return err
+		}
+		switch {
+		case injected && result.Clean:
+			return fmt.Errorf("no tc or iptables artifacts found after injection")
+		case !injected && !result.Clean:
+			return fmt.Errorf("artifacts left after removal: %s", strings.Join(result.Details, "; "))
+		}
+	}
+	check := p.removed
+	if injected {
+		check = p.injected
+	}
+	if check != nil {
+		return check(ctx, o, id)
+	}
+	return nil
+}
+
+func doctorExpectPaused(want bool) func(ctx context.Context, o *Orchestrator, id string) error {
+	return func(ctx context.Context, o *Orchestrator, id string) error {
+		info, err := o.dockerClient.ContainerInspect(ctx, id)
+		if err != nil {
+			return err
+		}
+		if info.State == nil || info.State.Paused != want {
+			return fmt.Errorf("container paused = %v, want %v", info.State != nil && info.State.Paused, want)
+		}
+		return nil
+	}
+}
+
+func doctorExpectRunning(ctx context.Context, o *Orchestrator, id string) error {
+	info, err := o.dockerClient.ContainerInspect(ctx, id)
+	if err != nil {
+		return err
+	}
+	if info.State == nil || !info.State.Running {
+		return fmt.Errorf("container not running after the fault")
+	}
+	return nil
+}
+
+// selectDoctorProbes returns the probes for faultTypes in run order, or
+// every probe when faultTypes is empty.
+func selectDoctorProbes(faultTypes []string) ([]doctorProbe, error) {
+	if len(faultTypes) == 0 {
+		return doctorProbes, nil
+	}
+	want := make(map[string]bool)
+	for _, ft := range faultTypes {
+		ft = scenario.CanonicalFaultType(ft)
+		if why, skipped := doctorSkipped[ft]; skipped {
+			return nil, fmt.Errorf("fault type %s is %s", ft, why)
+		}
+		want[ft] = true
+	}
+	var probes []doctorProbe
+	for _, p := range doctorProbes {
+		if want[p.faultType] {
+			probes = append(probes, p)
+			delete(want, p.faultType)
+		}
+	}
+	if len(want) > 0 {
+		return nil, fmt.Errorf("doctor cannot exercise fault type(s) %s; supported: %s",
+			strings.Join(sortedKeys(want), ", "), strings.Join(DoctorFaultTypes(), ", "))
+	}
+	return probes, nil
+}
+
+// startDoctorContainer runs the throwaway target. PID 1 loops restarting
+// the victim so process_kill never takes the container down.
+func (o *Orchestrator) startDoctorContainer(ctx context.Context, image string) (string, error) {
+	if err := o.dockerClient.EnsureImage(ctx, image); err != nil {
+		return "", fmt.Errorf("doctor image unavailable: %w", err)
+	}
+	config := &container.Config{
+		Image: image,
+		Env:   []string{"CHAOS_DOCTOR_VICTIM=" + doctorVictim},
+		Cmd:   []string{"sh", "-c", "while true; do $CHAOS_DOCTOR_VICTIM; done"},
+	}
+	name := fmt.Sprintf("chaos-doctor-%d", time.Now().Unix())
+	resp, err := o.dockerClient.ContainerCreate(ctx, config, &container.HostConfig{}, &network.NetworkingConfig{}, nil, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to create doctor container: %w", err)
+	}
+	if err := o.dockerClient.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
+		o.removeDoctorContainer(resp.ID)
+		return "", fmt.Errorf("failed to start doctor container: %w", err)
+	}
+	fmt.Printf("Started doctor container %s (%s)\n", name, resp.ID[:12])
+	return resp.ID, nil
+}
+
+// removeDoctorContainer tears down the throwaway and its sidecar, even
+// after the caller's context is cancelled.
+func (o *Orchestrator) removeDoctorContainer(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := o.sidecarMgr.DestroySidecar(ctx, id); err != nil {
+		fmt.Printf("⚠ Failed to destroy doctor sidecar: %v\n", err)
+	}
+	if err := o.dockerClient.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true}); err != nil {
+		fmt.Printf("⚠ Failed to remove doctor container %s: %v\n", id[:12], err)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -23,6 +23,11 @@ import (
 // wins over any later passing reading. This is conservative — during_fault
 // criteria are about proving the fault was effective, and a single
 // non-effective reading means the fault wasn't observable.
+//
+// Criteria with expect set are the exception until their expected outcome
+// is first observed: a scrape_block takes a scrape interval or two to blind
+// Prometheus, and the readings before that are not evidence against the
+// detector. Until then the latest reading replaces the previous one.
 type duringFaultSampler struct {
 	detector   *detector.FailureDetector
 	criteria   []scenario.SuccessCriterion
@@ -33,6 +38,8 @@ type duringFaultSampler struct {
 	results map[string]*detector.CriterionResult // criterion name → worst reading
 	samples int                                  // total sample rounds completed (for reporting)
 	skipped map[string]int                       // counts of samples skipped due to eval errors (log criteria pre log-context wire-up, etc.)
+	history map[string][]detector.EvaluationSample // every reading, not just the worst, for the report trend
+	settled map[string]bool                        // expect criteria whose expected outcome has been observed
 
 	cancel context.CancelFunc
 	done   chan struct{}
@@ -48,6 +55,8 @@ func newDuringFaultSampler(det *detector.FailureDetector, criteria []scenario.Su
 		interval: interval,
 		results:  make(map[string]*detector.CriterionResult),
 		skipped:  make(map[string]int),
+		history:  make(map[string][]detector.EvaluationSample),
+		settled:  make(map[string]bool),
 		done:     make(chan struct{}),
 	}
 	for i, c := range criteria {
@@ -106,14 +115,20 @@ func (s *duringFaultSampler) sampleOnce(ctx context.Context) {
 		}
 
 		s.mu.Lock()
+		s.history[c.Name] = append(s.history[c.Name], r.History...)
 		prev, ok := s.results[c.Name]
 		// Replace if: no prior sample OR the new reading is worse (failed
 		// where prior passed). Passed readings do NOT overwrite a failed
 		// prior reading — that would silently discard the evidence of the
-		// fault being observable at some point in the window.
-		if !ok || (prev.Passed && !r.Passed) {
+		// fault being observable at some point in the window. A definite
+		// failure likewise replaces an unknown reading.
+		pending := c.Expect != "" && !s.settled[c.Name]
+		if !ok || pending || (prev.Passed && !r.Passed) || (prev.Unknown && !r.Passed && !r.Unknown) {
 			s.results[c.Name] = r
 		}
+		if pending && r.Passed {
+			s.settled[c.Name] = true
+		}
 		s.mu.Unlock()
 	}
 
@@ -138,7 +153,18 @@ func (s *duringFaultSampler) StopAndCollect() map[string]*detector.CriterionResu
 	defer s.mu.Unlock()
 	out := make(map[string]*detector.CriterionResult, len(s.results))
 	for k, v := range s.results {
-		out[k] = v
+		// Return a copy carrying the full sample history so the report
+		// shows the whole window, while Passed/LastValue stay the worst.
+		worst := *v
+		worst.History = s.history[k]
+		worst.Evaluations = len(worst.History)
+		worst.Failures = 0
+		for _, h := range worst.History {
+			if !h.Passed {
+				worst.Failures++
+			}
+		}
+		out[k] = &worst
 	}
 	if len(s.skipped) > 0 {
 		// Surface skipped-sample counts so operators can see when a
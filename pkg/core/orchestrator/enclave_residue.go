@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/jihwankim/chaos-utils/pkg/injection/verification"
+)
+
+// EnclaveResidue is chaos state left on an enclave container after
+// teardown. Residue on a container no fault targeted usually means a
+// selector matched more than intended, or an IP-scoped rule was installed
+// on the wrong side.
+type EnclaveResidue struct {
+	Container   string
+	ContainerID string
+	// Target is set when a fault targeted the container.
+	Target    bool
+	Artifacts *verification.ChaosArtifacts
+}
+
+// scanEnclaveResidue lists the chaos artifacts on every running container
+// in the targets' Kurtosis enclave, not only the targets, once teardown has
+// removed the faults (execution.verify_enclave_cleanup). It only reports;
+// nothing found is removed. Without an enclave there is nothing to scope
+// the scan to, and in sidecar-only mode non-targets have no way in, so the
+// scan is skipped.
+func (o *Orchestrator) scanEnclaveResidue(ctx context.Context) {
+	fmt.Println("Scanning enclave containers for chaos artifacts...")
+	topo := o.environment.Topology
+	if topo == nil || topo.EnclaveID == "" {
+		fmt.Println("  Skipped: targets are not in a Kurtosis enclave")
+		return
+	}
+	if o.verifier.SidecarOnly() {
+		fmt.Println("  Skipped: remote/non-Linux Docker daemon (non-target namespaces are unreachable)")
+		return
+	}
+
+	containers, err := o.dockerClient.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", kurtosisEnclaveLabel+"="+topo.EnclaveID)),
+	})
+	if err != nil {
+		fmt.Printf("  ⚠ Failed to list enclave containers: %v\n", err)
+		return
+	}
+	targets := make(map[string]bool, len(o.targets))
+	for _, t := range o.targets {
+		targets[t.ContainerID] = true
+	}
+
+	scanned := 0
+	for _, c := range containers {
+		name := getContainerName(c.Names)
+		artifacts, err := o.verifier.ListChaosArtifacts(ctx, c.ID)
+		if err != nil {
+			fmt.Printf("  ⚠ Failed to scan %s: %v\n", name, err)
+			continue
+		}
+		scanned++
+		if artifacts.Empty() {
+			continue
+		}
+		residue := EnclaveResidue{Container: name, ContainerID: c.ID, Target: targets[c.ID], Artifacts: artifacts}
+		o.enclaveResidue = append(o.enclaveResidue, residue)
+		kind := "non-target"
+		if residue.Target {
+			kind = "target"
+		}
+		fmt.Printf("  ⚠ Residue on %s %s: %s\n", kind, name, artifacts)
+	}
+
+	if len(o.enclaveResidue) > 0 {
+		fmt.Printf("⚠ Chaos artifacts remain on %d of %d enclave container(s)\n", len(o.enclaveResidue), scanned)
+		return
+	}
+	fmt.Printf("✓ No chaos artifacts on %d enclave container(s)\n", scanned)
+}
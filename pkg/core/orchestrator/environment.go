@@ -0,0 +1,202 @@
+package orchestrator
+
+import (
+	"context"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/jihwankim/chaos-utils/pkg/config"
+)
+
+// EnvironmentInfo fingerprints the system under test so that a failing report
+// can be attributed to a specific Bor/Heimdall build when comparing runs
+// across days. Every field is best-effort: a missing value is left empty
+// rather than failing the test.
+type EnvironmentInfo struct {
+	EnclaveName string
+	// KurtosisCLIVersion is what `kurtosis version` prints: the CLI and
+	// engine versions, not the version of the deployed package.
+	KurtosisCLIVersion string
+	DockerVersion      string
+	HostKernel         string
+	HostOS             string
+	Images             []TargetImage
+	// Topology is nil when targets are not Kurtosis services.
+	Topology *Topology
+}
+
+// Topology snapshots every service in the enclave at DISCOVER time so
+// results can be normalised by network size, and a run on a smaller
+// devnet than usual stands out.
+type Topology struct {
+	EnclaveID      string
+	Services       []TopologyService
+	ValidatorCount int // consensus-layer validators (PoS) — 0 for CDK
+	RoleCounts     map[string]int
+}
+
+// TopologyService is one enclave service and the role inferred from its
+// name under the active deployment profile.
+type TopologyService struct {
+	Name  string
+	Role  string
+	Image string
+	State string
+}
+
+// TargetImage records the image a discovered target was running.
+type TargetImage struct {
+	Alias      string
+	Name       string
+	Image      string // image reference from the container config (e.g. 0xpolygon/bor:2.0.1)
+	ImageID    string // local image ID (sha256:...)
+	RepoDigest string // registry digest when the image was pulled, empty for local builds
+}
+
+// collectEnvironment gathers the environment fingerprint. Called after
+// DISCOVER so target images are known. Kernel/OS come from the Docker daemon
+// rather than the runner host — the daemon's kernel is the one tc/netem and
+// stress faults actually exercise.
+func (o *Orchestrator) collectEnvironment(ctx context.Context) EnvironmentInfo {
+	env := EnvironmentInfo{
+		EnclaveName:        o.cfg.Kurtosis.EnclaveName,
+		KurtosisCLIVersion: kurtosisCLIVersion(),
+	}
+
+	cli := o.dockerClient.GetClient()
+	if info, err := cli.Info(ctx); err == nil {
+		env.DockerVersion = info.ServerVersion
+		env.HostKernel = info.KernelVersion
+		env.HostOS = info.OperatingSystem
+	}
+
+	for _, t := range o.targets {
+		img := TargetImage{Alias: t.Alias, Name: t.Name}
+		if ctr, err := o.dockerClient.ContainerInspect(ctx, t.ContainerID); err == nil {
+			img.ImageID = ctr.Image
+			if ctr.Config != nil {
+				img.Image = ctr.Config.Image
+			}
+			if inspect, _, err := cli.ImageInspectWithRaw(ctx, ctr.Image); err == nil && len(inspect.RepoDigests) > 0 {
+				img.RepoDigest = inspect.RepoDigests[0]
+			}
+		}
+		env.Images = append(env.Images, img)
+	}
+
+	env.Topology = o.collectTopology(ctx)
+
+	return env
+}
+
+// kurtosisEnclaveLabel is set by Kurtosis on every container it creates;
+// its value is the enclave UUID.
+const kurtosisEnclaveLabel = "com.kurtosistech.enclave-id"
+
+// collectTopology lists every container in the targets' Kurtosis enclave.
+// The enclave is identified by the label on the first target rather than by
+// name, because Kurtosis container names carry no enclave prefix.
+func (o *Orchestrator) collectTopology(ctx context.Context) *Topology {
+	var enclaveID string
+	for _, t := range o.targets {
+		if ctr, err := o.dockerClient.ContainerInspect(ctx, t.ContainerID); err == nil && ctr.Config != nil {
+			if id := ctr.Config.Labels[kurtosisEnclaveLabel]; id != "" {
+				enclaveID = id
+				break
+			}
+		}
+	}
+	if enclaveID == "" {
+		return nil
+	}
+
+	containers, err := o.dockerClient.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", kurtosisEnclaveLabel+"="+enclaveID)),
+	})
+	if err != nil {
+		return nil
+	}
+
+	profile := o.cfg.ActiveProfile()
+	topo := &Topology{EnclaveID: enclaveID, RoleCounts: map[string]int{}}
+	for _, c := range containers {
+		name := serviceName(getContainerName(c.Names))
+		role := inferRole(profile, name)
+		topo.Services = append(topo.Services, TopologyService{Name: name, Role: role, Image: c.Image, State: c.State})
+		topo.RoleCounts[role]++
+	}
+	sort.Slice(topo.Services, func(i, j int) bool { return topo.Services[i].Name < topo.Services[j].Name })
+	topo.ValidatorCount = topo.RoleCounts["validator-cl"]
+
+	return topo
+}
+
+// inferRole classifies a Kurtosis service name using the deployment
+// profile's naming patterns.
+func inferRole(p *config.Profile, name string) string {
+	for _, blocked := range observabilityBlocklist {
+		if strings.Contains(name, blocked) {
+			return "observability"
+		}
+	}
+
+	switch p.Stack {
+	case config.StackCDK:
+		for _, r := range []struct{ role, pattern string }{
+			{"sequencer", p.SequencerPattern},
+			{"rpc", p.RPCPattern},
+			{"aggregator", p.AggregatorPattern},
+			{"prover", p.ProverPattern},
+		} {
+			if strings.Contains(name, r.pattern) {
+				return r.role
+			}
+		}
+	default:
+		if re, err := regexp.Compile(p.ValidatorPattern); err == nil && re.MatchString(name) {
+			return "validator-cl"
+		}
+		if strings.Contains(name, p.ELContainerPattern) {
+			return "validator-el"
+		}
+		if strings.HasPrefix(name, "l2-") && strings.HasSuffix(name, "-rpc") {
+			return "rpc"
+		}
+	}
+
+	for _, r := range []struct{ role, pattern string }{
+		{"l1-el", p.L1ELPattern},
+		{"l1-cl", p.L1CLPattern},
+		{"l1-vc", p.L1VCPattern},
+	} {
+		if r.pattern == "" {
+			continue
+		}
+		if re, err := regexp.Compile(r.pattern); err == nil && re.MatchString(name) {
+			return r.role
+		}
+	}
+	return "other"
+}
+
+// kurtosisCLIVersion returns the Kurtosis CLI/engine version string, or ""
+// when the CLI is unavailable (docker_container-only scenarios).
+func kurtosisCLIVersion() string {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Output() ignores stderr, which carries Kurtosis upgrade nags.
+	out, err := exec.CommandContext(ctx, "kurtosis", "version").Output()
+	if err != nil {
+		return ""
+	}
+	// Output is "CLI Version:   1.4.3\n\nRunning Engine Version:   1.4.3"
+	// — collapse to a single line for the report.
+	return strings.Join(strings.Fields(string(out)), " ")
+}
@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventKind names what an Event reports.
+type EventKind string
+
+const (
+	EventStateChanged       EventKind = "state_changed"
+	EventFaultInjected      EventKind = "fault_injected"
+	EventCriterionEvaluated EventKind = "criterion_evaluated"
+)
+
+// Event is one step of a run's progress, as published on an EventBus.
+// Which fields are set depends on Kind.
+type Event struct {
+	Kind EventKind
+	Time time.Time
+
+	// From and To are set for EventStateChanged.
+	From, To TestState
+
+	// Phase, FaultType and Target are set for EventFaultInjected.
+	Phase     string
+	FaultType string
+	Target    TargetInfo
+
+	// Criterion and DuringFault are set for EventCriterionEvaluated.
+	Criterion   CriterionOutcome
+	DuringFault bool
+}
+
+// EventBus is an Observer that fans a run's progress out to channel
+// subscribers, so callers render it in their own output format instead of
+// the orchestrator printing it. Publishing never blocks the run: an event
+// for a subscriber whose buffer is full is dropped and counted.
+type EventBus struct {
+	mu      sync.Mutex
+	subs    []chan Event
+	closed  bool
+	dropped atomic.Int64
+}
+
+// NewEventBus returns a bus with no subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe returns a channel receiving every event published from now on,
+// buffered to hold buffer events. It is closed by Close.
+func (b *EventBus) Subscribe(buffer int) <-chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan Event, buffer)
+	if b.closed {
+		close(ch)
+		return ch
+	}
+	b.subs = append(b.subs, ch)
+	return ch
+}
+
+// Close closes every subscription. Events published after Close are
+// discarded.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, ch := range b.subs {
+		close(ch)
+	}
+	b.subs = nil
+}
+
+// Dropped returns how many events were dropped on full subscriptions.
+func (b *EventBus) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Publish sends ev to every subscriber, stamping its time if unset.
+func (b *EventBus) Publish(ev Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, ch := range b.subs {
+		select {
+		case ch <- ev:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// StateChanged implements Observer.
+func (b *EventBus) StateChanged(from, to TestState) {
+	b.Publish(Event{Kind: EventStateChanged, From: from, To: to})
+}
+
+// FaultInjected implements Observer.
+func (b *EventBus) FaultInjected(phase, faultType string, target TargetInfo) {
+	b.Publish(Event{Kind: EventFaultInjected, Phase: phase, FaultType: faultType, Target: target})
+}
+
+// CriterionEvaluated implements Observer.
+func (b *EventBus) CriterionEvaluated(outcome CriterionOutcome, duringFault bool) {
+	b.Publish(Event{Kind: EventCriterionEvaluated, Criterion: outcome, DuringFault: duringFault})
+}
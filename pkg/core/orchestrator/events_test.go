@@ -0,0 +1,40 @@
+package orchestrator
+
+import "testing"
+
+func TestEventBus_FansOutAndDrops(t *testing.T) {
+	bus := NewEventBus()
+	a := bus.Subscribe(2)
+	b := bus.Subscribe(1)
+
+	bus.StateChanged(StateInit, StateParse)
+	bus.FaultInjected("lag", "network", TargetInfo{Name: "rpc"})
+	bus.Close()
+	bus.StateChanged(StateParse, StateDiscover) // after Close: discarded
+
+	var gotA []Event
+	for ev := range a {
+		gotA = append(gotA, ev)
+	}
+	if len(gotA) != 2 || gotA[0].Kind != EventStateChanged || gotA[1].Kind != EventFaultInjected {
+		t.Fatalf("subscriber a got %+v, want state_changed then fault_injected", gotA)
+	}
+	if gotA[0].Time.IsZero() || gotA[1].Target.Name != "rpc" {
+		t.Errorf("events not filled in: %+v", gotA)
+	}
+
+	var gotB []Event
+	for ev := range b {
+		gotB = append(gotB, ev)
+	}
+	if len(gotB) != 1 {
+		t.Errorf("subscriber b got %d events, want 1 (buffer full)", len(gotB))
+	}
+	if bus.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", bus.Dropped())
+	}
+
+	if _, ok := <-bus.Subscribe(1); ok {
+		t.Error("subscription after Close is open")
+	}
+}
@@ -0,0 +1,129 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// InterimReport is a snapshot of a run in progress. With
+// reporting.interim_interval set it is rewritten on every phase change and
+// periodically during warmup, monitor and cooldown, so partial data
+// survives a crashed runner and dashboards can poll progress without the
+// gRPC API. The file is removed once the run ends.
+type InterimReport struct {
+	TestID       string             `json:"test_id"`
+	ScenarioName string             `json:"scenario_name"`
+	State        string             `json:"state"`
+	StartTime    time.Time          `json:"start_time"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	ActiveFaults []InterimFault     `json:"active_faults"`
+	Criteria     []InterimCriterion `json:"criteria,omitempty"`
+	// Metrics are the latest collected value of each scenario metric.
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+}
+
+// InterimFault is one fault currently installed on one target.
+type InterimFault struct {
+	Phase  string `json:"phase"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+}
+
+// InterimCriterion is one criterion outcome recorded so far.
+type InterimCriterion struct {
+	Name     string  `json:"name"`
+	Passed   bool    `json:"passed"`
+	Unknown  bool    `json:"unknown,omitempty"`
+	Critical bool    `json:"critical"`
+	Value    float64 `json:"value"`
+	Message  string  `json:"message,omitempty"`
+}
+
+// InterimPath is where a run's interim report is written.
+func InterimPath(outputDir, testID string) string {
+	return filepath.Join(outputDir, "interim", testID+".json")
+}
+
+// interimReport builds the snapshot from the run's state so far.
+func (o *Orchestrator) interimReport() *InterimReport {
+	r := &InterimReport{
+		TestID:       o.testID,
+		State:        o.currentState.String(),
+		StartTime:    o.startTime,
+		UpdatedAt:    time.Now(),
+		ActiveFaults: []InterimFault{},
+	}
+	if o.scenario != nil {
+		r.ScenarioName = o.scenario.Metadata.Name
+	}
+	for _, f := range o.injectedFaults {
+		name := f.ContainerID[:12]
+		for _, t := range o.targets {
+			if t.ContainerID == f.ContainerID {
+				name = t.Name
+				break
+			}
+		}
+		r.ActiveFaults = append(r.ActiveFaults, InterimFault{Phase: f.Phase, Type: f.FaultType, Target: name})
+	}
+	for _, c := range o.criteriaResults {
+		r.Criteria = append(r.Criteria, InterimCriterion{
+			Name:     c.Name,
+			Passed:   c.Passed,
+			Unknown:  c.Unknown,
+			Critical: c.Critical,
+			Value:    c.Value,
+			Message:  c.Message,
+		})
+	}
+	if o.collector != nil {
+		for _, name := range o.collector.GetMetricNames() {
+			if v, ok := o.collector.GetLatestValue(name); ok {
+				if r.Metrics == nil {
+					r.Metrics = make(map[string]float64)
+				}
+				r.Metrics[name] = v
+			}
+		}
+	}
+	return r
+}
+
+// writeInterim rewrites the interim report when interim reports are on.
+// Unless force is set it does nothing until interim_interval has passed
+// since the last write. The file is replaced atomically so a poller never
+// reads half of it. Failures are ignored: the snapshot is a convenience.
+func (o *Orchestrator) writeInterim(force bool) {
+	interval := o.cfg.Reporting.InterimInterval
+	if interval <= 0 || o.testID == "" {
+		return
+	}
+	if !force && time.Since(o.lastInterim) < interval {
+		return
+	}
+	o.lastInterim = time.Now()
+
+	data, err := json.MarshalIndent(o.interimReport(), "", "  ")
+	if err != nil {
+		return
+	}
+	path := InterimPath(o.cfg.Reporting.OutputDir, o.testID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, path)
+}
+
+// removeInterim deletes the run's interim report once the run is over.
+func (o *Orchestrator) removeInterim() {
+	if o.cfg.Reporting.InterimInterval <= 0 || o.testID == "" {
+		return
+	}
+	_ = os.Remove(InterimPath(o.cfg.Reporting.OutputDir, o.testID))
+}
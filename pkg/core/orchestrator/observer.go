@@ -0,0 +1,40 @@
+package orchestrator
+
+import "github.com/jihwankim/chaos-utils/pkg/notify"
+
+// Observer receives a run's progress as it happens, for callers that
+// forward it elsewhere (the gRPC control API). Methods are called from the
+// orchestrator's goroutine and must not block.
+type Observer interface {
+	// StateChanged is called on every state transition.
+	StateChanged(from, to TestState)
+	// FaultInjected is called once per target a fault was installed on.
+	FaultInjected(phase, faultType string, target TargetInfo)
+	// CriterionEvaluated is called for every success criterion outcome
+	// recorded for the report. duringFault marks criteria judged from the
+	// during-fault sampler.
+	CriterionEvaluated(outcome CriterionOutcome, duringFault bool)
+}
+
+// SetObserver reports every subsequent run's progress to obs. A nil
+// observer (the default) reports nothing.
+func (o *Orchestrator) SetObserver(obs Observer) {
+	o.observer = obs
+}
+
+// recordCriterion stores outcome for the report and tells the observer.
+// A critical criterion that failed is also posted to the webhooks.
+func (o *Orchestrator) recordCriterion(outcome CriterionOutcome, duringFault bool) {
+	o.criteriaResults = append(o.criteriaResults, outcome)
+	if o.observer != nil {
+		o.observer.CriterionEvaluated(outcome, duringFault)
+	}
+	if outcome.Critical && !outcome.Passed && !outcome.Unknown {
+		o.sendNotification(notify.Event{
+			Event:     notify.EventCriticalFailure,
+			Criterion: outcome.Name,
+			Value:     outcome.Value,
+			Message:   outcome.Message,
+		}, o.scenario)
+	}
+}
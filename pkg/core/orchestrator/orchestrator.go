@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -17,17 +18,26 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/jihwankim/chaos-utils/pkg/agent"
+	"github.com/jihwankim/chaos-utils/pkg/approval"
+	"github.com/jihwankim/chaos-utils/pkg/audit"
 	"github.com/jihwankim/chaos-utils/pkg/config"
 	"github.com/jihwankim/chaos-utils/pkg/core/cleanup"
 	"github.com/jihwankim/chaos-utils/pkg/core/logcollector"
+	"github.com/jihwankim/chaos-utils/pkg/core/state"
 	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
+	"github.com/jihwankim/chaos-utils/pkg/discovery/k8s"
 	"github.com/jihwankim/chaos-utils/pkg/emergency"
+	"github.com/jihwankim/chaos-utils/pkg/gameday"
 	"github.com/jihwankim/chaos-utils/pkg/injection"
+	"github.com/jihwankim/chaos-utils/pkg/injection/capture"
+	"github.com/jihwankim/chaos-utils/pkg/injection/disk"
 	"github.com/jihwankim/chaos-utils/pkg/injection/sidecar"
 	"github.com/jihwankim/chaos-utils/pkg/injection/verification"
 	"github.com/jihwankim/chaos-utils/pkg/monitoring/collector"
 	"github.com/jihwankim/chaos-utils/pkg/monitoring/detector"
 	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
+	"github.com/jihwankim/chaos-utils/pkg/notify"
 	"github.com/jihwankim/chaos-utils/pkg/scenario"
 	"github.com/jihwankim/chaos-utils/pkg/scenario/parser"
 	"github.com/jihwankim/chaos-utils/pkg/scenario/validator"
@@ -39,10 +49,27 @@ import (
 // errors (exit 2) so CI doesn't halt the suite on a plain criteria miss.
 type CriteriaFailureError struct {
 	Msg string
+	// Unknown is set when no critical criterion failed outright but at
+	// least one could not be judged under treat_unknown_as: unknown.
+	Unknown bool
 }
 
 func (e *CriteriaFailureError) Error() string { return e.Msg }
 
+// InterruptedError marks a run stopped by SIGINT/SIGTERM or the emergency
+// stop file. Faults and sidecars were still torn down; the result carries
+// whatever was gathered before the stop.
+type InterruptedError struct {
+	Phase TestState
+	Err   error
+}
+
+func (e *InterruptedError) Error() string {
+	return fmt.Sprintf("interrupted during %s: %v", e.Phase, e.Err)
+}
+
+func (e *InterruptedError) Unwrap() error { return e.Err }
+
 // TestState represents the current state of a chaos test execution
 type TestState int
 
@@ -60,6 +87,9 @@ const (
 	StateReport
 	StateCompleted
 	StateFailed
+	// StateInterrupted is a run cut short by an emergency stop (signal or
+	// stop file). Teardown still ran; results are partial.
+	StateInterrupted
 )
 
 func (s TestState) String() string {
@@ -90,6 +120,8 @@ func (s TestState) String() string {
 		return "COMPLETED"
 	case StateFailed:
 		return "FAILED"
+	case StateInterrupted:
+		return "INTERRUPTED"
 	default:
 		return "UNKNOWN"
 	}
@@ -101,6 +133,12 @@ type TargetInfo struct {
 	ContainerID string
 	Name        string
 	IP          string
+	// Agent is the chaos-agent the container is reached through; empty
+	// for a container on the local daemon.
+	Agent string
+	// Pod is set when the target is a Kubernetes pod rather than a
+	// container; ContainerID is then the pod UID.
+	Pod *k8s.Pod
 }
 
 // Orchestrator coordinates the chaos test lifecycle
@@ -115,6 +153,16 @@ type Orchestrator struct {
 	emergencyCtrl    *emergency.Controller
 	emergencyStopCtx context.Context
 	emergencyCancel  context.CancelFunc
+	emergencyOnce    sync.Once
+
+	// runCancel cancels the running test's context so an emergency stop
+	// interrupts the phase in progress instead of waiting for it.
+	runMu     sync.Mutex
+	runCancel context.CancelFunc
+
+	// stateFile mirrors sidecars and faults to disk for `chaos-runner
+	// recover`; nil when it could not be created.
+	stateFile atomic.Pointer[state.File]
 
 	// Components for test execution
 	parser       *parser.Parser
@@ -122,10 +170,23 @@ type Orchestrator struct {
 	dockerClient *docker.Client
 	promClient   *prometheus.Client
 	heimdallAPI  string
+	gatekeeper   *gameday.Gatekeeper
+	approver     *approval.Approver
+	auditSink    audit.Sink
+	notifier     *notify.Notifier
+	observer     Observer
 	detector     *detector.FailureDetector
 	collector    *collector.Collector
 	logCollector *logcollector.Collector
 	injector     *injection.Injector
+	// agents are the configured chaos-agents, keyed by name.
+	agents map[string]*agent.Client
+	// kube reaches the cluster of kubernetes_pod targets, whose sidecars
+	// are podSidecars and whose faults go through podInjector.
+	kube        *k8s.Client
+	podSidecars *sidecar.PodManager
+	podInjector *injection.Injector
+	podVerifier *verification.Verifier
 
 	// Test data
 	scenario      *scenario.Scenario
@@ -133,6 +194,8 @@ type Orchestrator struct {
 	scenarioPath  string
 	testID        string
 	injectTime    time.Time         // set at INJECT start; used to scope log capture to fault window
+	teardownTime  time.Time         // set when TEARDOWN completes; grace_period is measured from here
+	blastRadius   *BlastRadius      // measured at DETECT start
 	// injectedFaults tracks every fault currently installed on a container
 	// as an ordered slice so that:
 	//   - multiple faults on the same container are not conflated (a single
@@ -151,11 +214,63 @@ type Orchestrator struct {
 	// state and report a misleading pass/fail.
 	dfSampler *duringFaultSampler
 
+	// detWatcher polls spec.detection signals from INJECT to TEARDOWN;
+	// detections is what it saw.
+	detWatcher *detectionWatcher
+	detections []Detection
+
+	// approval is who confirmed the scenario, when it is destructive.
+	approval *approval.Approval
+
 	// faultVerificationWarnings counts faults that passed InjectFault's own
 	// error check but failed the orchestrator's post-injection verification.
 	// Non-zero means the test ran with at least one fault whose observable
 	// side effect could not be confirmed.
 	faultVerificationWarnings int
+
+	// environment is the fingerprint captured right after DISCOVER.
+	environment EnvironmentInfo
+
+	// captures are the packet captures running per target container for
+	// faults with capture: true; captureFiles are the saved pcaps, keyed
+	// by fault phase.
+	capturer     *capture.Capturer
+	captures     map[string]*runningCapture
+	captureFiles map[string][]CaptureFile
+
+	// lastInterim is when the interim report was last written.
+	lastInterim time.Time
+
+	// collateralWatch follows non-target enclave containers from INJECT
+	// to teardown; collateral is what it saw.
+	collateralWatch *collateralWatcher
+	collateral      []CollateralEvent
+
+	// enclaveResidue is what the post-teardown enclave scan found.
+	enclaveResidue []EnclaveResidue
+
+	// timeline holds fault starts and removals still due during MONITOR,
+	// in time order.
+	timeline []timelineEntry
+	// resumeMonitor is what is left of MONITOR for a resumed run; 0
+	// otherwise.
+	resumeMonitor time.Duration
+
+	// injections are the per-target install outcomes, keyed by fault phase.
+	injections map[string][]TargetInjection
+
+	// faultInstallCount is how many faults were installed when TEARDOWN
+	// began.
+	faultInstallCount int
+
+	// stuckPhase is the phase that exceeded its execution.phase_timeouts
+	// entry, or StateInit when none did.
+	stuckPhase TestState
+
+	// phaseUsage is the runner's own resource usage per finished phase;
+	// usageMark holds the counters at the start of the current one.
+	phaseUsage []PhaseUsage
+	usageMark  *usageMark
 }
 
 // injectedFault records one fault installed on one container during INJECT.
@@ -165,6 +280,26 @@ type Orchestrator struct {
 type injectedFault struct {
 	ContainerID string
 	FaultType   string
+	// Phase and Params identify the fault in the audit trail.
+	Phase  string
+	Params map[string]interface{}
+	// resumed marks a fault a crashed runner installed: this injector
+	// never tracked it, so it is removed from Params (see RecoverFault).
+	resumed bool
+}
+
+// TargetInjection is whether one fault installed on one target. Every
+// target is recorded, so a report shows which targets a partially applied
+// fault (failure_policy continue or min_targets) reached.
+type TargetInjection struct {
+	Target      string
+	ContainerID string
+	// Err is nil when the fault installed.
+	Err error
+	// InstalledAt and RemovedAt bound the fault's window on this target;
+	// RemovedAt is zero when removal failed or never ran.
+	InstalledAt time.Time
+	RemovedAt   time.Time
 }
 
 // CriterionOutcome captures the result of a single success criterion evaluation.
@@ -175,9 +310,15 @@ type CriterionOutcome struct {
 	Query       string
 	Threshold   string
 	Passed      bool
+	// Unknown is set when the detector could not judge the criterion
+	// (see detector.CriterionResult.Unknown).
+	Unknown     bool
 	Value       float64
 	Message     string
 	Critical    bool
+	Evaluations int
+	Failures    int
+	History     []detector.EvaluationSample
 }
 
 // TestResult represents the result of a chaos test execution
@@ -195,6 +336,41 @@ type TestResult struct {
 	FaultCount                int
 	CriteriaResults           []CriterionOutcome
 	FaultVerificationWarnings int
+	Environment               EnvironmentInfo
+	// StuckPhase names the phase that hit its timeout ("" when none).
+	StuckPhase string
+	// Recoveries are restart-to-healthy latencies from verify_health,
+	// keyed by fault phase.
+	Recoveries map[string][]injection.Recovery
+	// AppliedMethods are the methods disk_io faults actually used per
+	// target, keyed by fault phase.
+	AppliedMethods map[string][]injection.AppliedMethod
+	// Captures are the pcaps saved for faults with capture: true, keyed
+	// by fault phase.
+	Captures map[string][]CaptureFile
+	// Injections are the per-target install outcomes, keyed by fault
+	// phase.
+	Injections map[string][]TargetInjection
+	// Collateral lists crashes, OOM kills and restarts of non-target
+	// enclave containers while faults were active.
+	Collateral []CollateralEvent
+	// EnclaveResidue lists enclave containers that still carried chaos
+	// artifacts after teardown (execution.verify_enclave_cleanup).
+	EnclaveResidue []EnclaveResidue
+	// Unknown is set when the run ended undecided: see
+	// CriteriaFailureError.Unknown.
+	Unknown bool
+	// BlastRadius compares targets with the untouched validators; nil
+	// when the run never reached DETECT or metrics were unavailable.
+	BlastRadius *BlastRadius
+	// RunnerUsage is the runner's own CPU, memory and Docker API usage
+	// per phase.
+	RunnerUsage []PhaseUsage
+	// Detections are the spec.detection time-to-detect results.
+	Detections []Detection
+	// Approval records who confirmed a destructive scenario; nil when the
+	// scenario is not destructive or was never approved.
+	Approval *approval.Approval
 }
 
 // New creates a new Orchestrator instance
@@ -219,6 +395,7 @@ func New(cfg *config.Config) (*Orchestrator, error) {
 		StopFile:             cfg.Emergency.StopFile,
 		PollInterval:         1 * time.Second,
 		EnableSignalHandlers: true,
+		GracePeriod:          cfg.Emergency.GracePeriod,
 	})
 
 	// Create context for emergency controller
@@ -226,6 +403,7 @@ func New(cfg *config.Config) (*Orchestrator, error) {
 
 	// Create scenario parser and validator
 	p := parser.New(nil)
+	p.Profile = cfg.ActiveProfile()
 	v := validator.New()
 
 	// Create Prometheus client — required for metrics collection and success criteria evaluation.
@@ -239,8 +417,19 @@ func New(cfg *config.Config) (*Orchestrator, error) {
 		return nil, fmt.Errorf("failed to create Prometheus client (url=%s): %w", cfg.Prometheus.URL, err)
 	}
 
-	// Create failure detector
+	// Create failure detector. rpc criteria need the EVM RPC endpoint;
+	// without one they report unknown.
 	det := detector.New(promClient)
+	if endpoints := cfg.RPC.Endpoints(); len(endpoints) > 0 {
+		det = detector.NewWithRPC(promClient, detector.RPCConfig{
+			Endpoints: endpoints,
+			Timeout:   cfg.RPC.Timeout,
+			Retries:   cfg.RPC.Retries,
+			Username:  cfg.RPC.Username,
+			Password:  cfg.RPC.Password,
+		})
+	}
+	det.SetProfile(cfg.ActiveProfile())
 
 	// Create metrics collector (will be reconfigured per-scenario)
 	col := collector.New(collector.Config{
@@ -251,10 +440,34 @@ func New(cfg *config.Config) (*Orchestrator, error) {
 	// Create unified fault injector
 	injector := injection.New(sidecarMgr, dockerClient)
 
+	// Kubernetes pod targets are reached through kubectl, which is only
+	// run when a scenario selects pods.
+	kube := k8s.New(cfg.Kubernetes.Kubeconfig, cfg.Kubernetes.Context)
+	podSidecars := sidecar.NewPodManager(kube, cfg.Docker.SidecarImage)
+	podVerifier := verification.New(nil)
+	podVerifier.UseSidecarExec(podSidecars)
+
 	// Create log collector for post-failure diagnosis
 	logCol := logcollector.New(dockerClient)
 
-	return &Orchestrator{
+	approver, err := approval.New(approval.Config{
+		Method:   cfg.Destructive.Approval,
+		TokenURL: cfg.Destructive.TokenURL,
+		Roles:    cfg.Destructive.Roles,
+		Timeout:  cfg.Destructive.Timeout,
+	})
+	if err != nil {
+		emergencyCancel()
+		return nil, err
+	}
+
+	agents, err := dialAgents(cfg.Agents)
+	if err != nil {
+		emergencyCancel()
+		return nil, err
+	}
+
+	o := &Orchestrator{
 		cfg:        cfg,
 		sidecarMgr: sidecarMgr,
 		verifier:         verifier,
@@ -270,8 +483,17 @@ func New(cfg *config.Config) (*Orchestrator, error) {
 		collector:        col,
 		logCollector:     logCol,
 		injector:         injector,
+		agents:           agents,
+		kube:             kube,
+		podSidecars:      podSidecars,
+		podInjector:      injection.NewPodInjector(podSidecars),
+		podVerifier:      podVerifier,
+		approver:         approver,
+		capturer:         capture.New(sidecarMgr),
 		injectedFaults:   nil, // lazily appended during INJECT
-	}, nil
+	}
+	sidecarMgr.SetTracker(o.trackSidecar)
+	return o, nil
 }
 
 // Execute runs the complete chaos test lifecycle against an already-parsed
@@ -280,12 +502,129 @@ func New(cfg *config.Config) (*Orchestrator, error) {
 // scenarioPath is purely for reporting/logging — the orchestrator does NOT
 // re-read the file, because doing so would silently discard any overrides
 // the caller applied to the in-memory struct (F-04).
+//
+// Execute is single-shot: it destroys every sidecar and stops the
+// emergency controller on return. Use ExecuteNext to run several
+// scenarios on one orchestrator.
 func (o *Orchestrator) Execute(ctx context.Context, scen *scenario.Scenario, scenarioPath string) (*TestResult, error) {
+	defer o.emergencyCancel() // Stop emergency controller when test completes
+	return o.execute(ctx, scen, scenarioPath, false, nil)
+}
+
+// ExecuteNext runs scen on a warm orchestrator, for suites, soaks and
+// other callers that run scenarios back to back. The Docker and
+// Prometheus clients, the emergency controller and the sidecars of a
+// cleanly completed run are kept for the next call instead of being
+// rebuilt per scenario; sidecars whose target has restarted since are
+// pruned first. Faults are always removed at the end of each run, and a
+// run that fails falls back to full cleanup. Call Close when done.
+//
+// Once an emergency stop or RequestStop has been seen, ExecuteNext
+// refuses to start further runs.
+func (o *Orchestrator) ExecuteNext(ctx context.Context, scen *scenario.Scenario, scenarioPath string) (*TestResult, error) {
+	if o.stopRequested.Load() {
+		return nil, fmt.Errorf("orchestrator stopped: not starting %s", scenarioPath)
+	}
+	return o.execute(ctx, scen, scenarioPath, true, nil)
+}
+
+// Close destroys the sidecars kept by ExecuteNext, stops the emergency
+// controller and closes the Docker client.
+func (o *Orchestrator) Close(ctx context.Context) error {
+	defer o.emergencyCancel()
+	err := o.cleanupCoord.CleanupAll(ctx)
+	o.closeStateFile()
+	o.closeAgents()
+	if cerr := o.dockerClient.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// resetRun clears the per-test state left by a previous run.
+func (o *Orchestrator) resetRun() {
+	o.currentState = StateInit
+	o.scenario = nil
+	o.targets = nil
+	o.injectTime, o.teardownTime = time.Time{}, time.Time{}
+	o.blastRadius = nil
+	o.injectedFaults = nil
+	o.criteriaResults = nil
+	o.dfSampler = nil
+	o.detWatcher, o.detections = nil, nil
+	o.approval = nil
+	o.faultVerificationWarnings = 0
+	o.faultInstallCount = 0
+	o.injections = map[string][]TargetInjection{}
+	o.environment = EnvironmentInfo{}
+	o.captures, o.captureFiles = nil, nil
+	o.lastInterim = time.Time{}
+	o.collateralWatch, o.collateral = nil, nil
+	o.enclaveResidue = nil
+	o.timeline = nil
+	o.resumeMonitor = 0
+	o.stuckPhase = StateInit
+	o.phaseUsage, o.usageMark = nil, nil
+	o.cleanupCoord.ResetAuditLog()
+	o.injector.ResetRecoveries()
+	o.injector.ResetAppliedMethods()
+}
+
+// startEmergency starts the emergency controller and registers its
+// callbacks, once per orchestrator.
+//
+// A stop cancels the running phase and lets the normal abort path tear
+// down faults and sidecars, so the run still ends with a report. Only if
+// that outlasts emergency.grace_period does the forced exit destroy the
+// sidecars directly.
+func (o *Orchestrator) startEmergency() {
+	o.emergencyOnce.Do(func() {
+		o.emergencyCtrl.Start(o.emergencyStopCtx)
+
+		o.emergencyCtrl.OnStop(func() {
+			fmt.Println("🛑 Emergency stop triggered, interrupting the run for teardown...")
+			o.stopRequested.Store(true)
+			o.sendNotification(notify.Event{Event: notify.EventEmergencyStop}, o.scenario)
+			o.runMu.Lock()
+			if o.runCancel != nil {
+				o.runCancel()
+			}
+			o.runMu.Unlock()
+		})
+		o.emergencyCtrl.OnForce(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), forceCleanupTimeout)
+			defer cancel()
+			o.cleanupAgents(ctx)
+			o.cleanupPods(ctx)
+			if err := o.cleanupCoord.CleanupAll(ctx); err != nil {
+				fmt.Printf("Emergency cleanup errors: %v\n", err)
+			}
+			o.cleanupCoord.PrintAuditLog()
+		})
+	})
+}
+
+// setRunCancel installs (or with nil, clears) the running test's cancel.
+func (o *Orchestrator) setRunCancel(cancel context.CancelFunc) {
+	o.runMu.Lock()
+	defer o.runMu.Unlock()
+	o.runCancel = cancel
+}
+
+// execute runs one test. warm keeps the sidecars of a cleanly completed
+// run for the next one (see ExecuteNext). With checkpoint set, the run is
+// the crashed one it describes, picked up where it stopped (see Resume).
+func (o *Orchestrator) execute(ctx context.Context, scen *scenario.Scenario, scenarioPath string, warm bool, checkpoint *checkpoint) (*TestResult, error) {
 	if scen == nil {
 		return nil, fmt.Errorf("orchestrator.Execute: scenario is nil")
 	}
+	o.resetRun()
 	o.startTime = time.Now()
 	o.testID = generateTestID()
+	if checkpoint != nil {
+		o.startTime = checkpoint.state.StartedAt
+		o.testID = checkpoint.state.TestID
+	}
 	o.scenarioPath = scenarioPath
 
 	result := &TestResult{
@@ -294,26 +633,37 @@ func (o *Orchestrator) Execute(ctx context.Context, scen *scenario.Scenario, sce
 		State:     o.currentState,
 	}
 
-	// Start emergency controller
-	o.emergencyCtrl.Start(o.emergencyStopCtx)
-	defer o.emergencyCancel() // Stop emergency controller when test completes
+	o.startEmergency()
+	if checkpoint != nil {
+		o.reopenStateFile(ctx, checkpoint)
+		o.sendNotification(notify.Event{Event: notify.EventTestStarted, Message: "resumed after a crash"}, scen)
+	} else {
+		o.openStateFile(scen.Metadata.Name)
+		o.sendNotification(notify.Event{Event: notify.EventTestStarted}, scen)
+	}
 
-	// Register cleanup callback with emergency controller
-	o.emergencyCtrl.OnStop(func() {
-		fmt.Println("🛑 Emergency stop triggered, running cleanup...")
-		o.stopRequested.Store(true)
-		if err := o.cleanupCoord.CleanupAll(ctx); err != nil {
-			fmt.Printf("Emergency cleanup errors: %v\n", err)
-		}
-		o.cleanupCoord.PrintAuditLog()
-	})
+	// Phases run under a context the emergency stop can cancel; teardown
+	// and cleanup use the caller's, so they still work after a stop.
+	parent := ctx
+	ctx, cancelRun := context.WithCancel(ctx)
+	o.setRunCancel(cancelRun)
+	defer func() {
+		o.setRunCancel(nil)
+		cancelRun()
+		o.removeInterim()
+	}()
+	if o.stopRequested.Load() {
+		cancelRun()
+	}
 
 	// Ensure cleanup runs on panic or normal exit
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Printf("PANIC during execution: %v\n", r)
 			fmt.Println("Running emergency cleanup...")
-			if err := o.cleanupCoord.CleanupAll(ctx); err != nil {
+			o.cleanupAgents(parent)
+			o.cleanupPods(parent)
+			if err := o.cleanupCoord.CleanupAll(parent); err != nil {
 				fmt.Printf("Panic cleanup errors: %v\n", err)
 			}
 			o.cleanupCoord.PrintAuditLog()
@@ -331,154 +681,56 @@ func (o *Orchestrator) Execute(ctx context.Context, scen *scenario.Scenario, sce
 	// stress state installed on the target kernel namespace until the next
 	// run's pre-flight tries to sweep it — and pre-flight only handles tc.
 	defer func() {
+		// After a stuck phase the daemon is probably wedged; bound the
+		// cleanup so the runner exits and reports instead of hanging too.
+		cleanupCtx := parent
+		if o.stuckPhase != StateInit {
+			var cancel context.CancelFunc
+			cleanupCtx, cancel = context.WithTimeout(context.Background(), stuckCleanupTimeout)
+			defer cancel()
+		}
 		if len(o.injectedFaults) > 0 && o.currentState != StateCompleted {
 			fmt.Println("Cleaning up faults recorded before abort...")
-			o.removeTrackedFaults(ctx)
+			o.removeTrackedFaults(cleanupCtx)
+		}
+		o.cleanupAgents(cleanupCtx)
+		o.cleanupPods(cleanupCtx)
+		if warm && o.currentState == StateCompleted {
+			fmt.Printf("Keeping %d sidecar(s) for the next scenario\n", len(o.sidecarMgr.ListSidecars()))
+			return
 		}
 		fmt.Println("Running cleanup...")
-		if err := o.cleanupCoord.CleanupAll(ctx); err != nil {
+		if err := o.cleanupCoord.CleanupAll(cleanupCtx); err != nil {
 			fmt.Printf("Cleanup errors: %v\n", err)
 		}
 		o.cleanupCoord.PrintAuditLog()
+		o.closeStateFile()
 	}()
 
+	// Sidecars kept from the previous warm run are reused, unless their
+	// target restarted in the meantime.
+	if n := o.sidecarMgr.PruneStale(ctx); n > 0 {
+		fmt.Printf("Pruned %d stale sidecar(s) from the previous run\n", n)
+	}
+
 	// PRE-FLIGHT CLEANUP: Remove remnants from previous failed/interrupted tests
 	if err := o.preFlightCleanup(ctx); err != nil {
 		fmt.Printf("⚠ Pre-flight cleanup warning: %v\n", err)
 		// Don't fail the test, just warn
 	}
 
-	// State machine execution
+	// State machine execution: PARSE through DETECT, as laid out in the
+	// transition table.
 	var err error
-
-	// PARSE state — validate the pre-parsed scenario. We do NOT re-read
-	// scenarioPath here: the caller has already parsed it, applied --set
-	// overrides, and validated. Re-parsing would discard the overrides
-	// (historical bug: --set duration=1m silently became the in-file value).
-	o.transitionState(StateParse)
-	if err = o.executeParse(ctx, scen); err != nil {
-		return o.failTest(result, err)
-	}
-	result.ScenarioName = o.scenario.Metadata.Name
-
-	// Check for stop
-	if o.stopRequested.Load() {
-		return o.failTest(result, fmt.Errorf("stopped before discovery"))
-	}
-
-	// DISCOVER state
-	o.transitionState(StateDiscover)
-	if err = o.executeDiscover(ctx); err != nil {
-		return o.failTest(result, err)
-	}
-
-	// Topology preconditions: a scenario may require a minimum number of
-	// validators to exercise its fault path meaningfully. Fail fast here,
-	// before we start creating sidecars, so the operator gets a clear error.
-	if err = o.executePreconditions(ctx); err != nil {
-		return o.failTest(result, err)
-	}
-
-	// Check for stop
-	if o.stopRequested.Load() {
-		return o.failTest(result, fmt.Errorf("stopped before prepare"))
-	}
-
-	// PREPARE state
-	o.transitionState(StatePrepare)
-	if err = o.executePrepare(ctx); err != nil {
-		return o.failTest(result, err)
-	}
-
-	// Check for stop
-	if o.stopRequested.Load() {
-		return o.failTest(result, fmt.Errorf("stopped before warmup"))
-	}
-
-	// WARMUP state
-	o.transitionState(StateWarmup)
-	if err = o.executeWarmup(ctx); err != nil {
-		return o.failTest(result, err)
-	}
-
-	// Check for stop
-	if o.stopRequested.Load() {
-		return o.failTest(result, fmt.Errorf("stopped before inject"))
-	}
-
-	// Pre-fault health check: verify steady state before injection.
-	// Aborts if any critical criterion fails — system must be healthy before we break it.
-	if err = o.executePreCheck(ctx); err != nil {
-		return o.failTest(result, err)
-	}
-
-	// Start the during-fault sampler BEFORE inject. Some fault types
-	// (notably container_pause with Duration set) block their InjectFault
-	// call for the full fault window and self-terminate inside INJECT.
-	// If we only evaluate during_fault criteria after MONITOR, those
-	// faults are already gone and the criteria observe post-fault state.
-	// The sampler polls every 15s throughout INJECT + MONITOR and keeps
-	// the worst reading per criterion, so a single violation is recorded
-	// no matter when it occurs.
-	o.dfSampler = newDuringFaultSampler(o.detector, o.scenario.Spec.SuccessCriteria, 15*time.Second)
-	o.dfSampler.Start(ctx)
-
-	// INJECT state
-	o.transitionState(StateInject)
-	if err = o.executeInject(ctx); err != nil {
-		o.dfSampler.Stop()
-		return o.failTest(result, err)
-	}
-
-	// Check for stop
-	if o.stopRequested.Load() {
-		return o.failTest(result, fmt.Errorf("stopped before monitor"))
-	}
-
-	// MONITOR state
-	o.transitionState(StateMonitor)
-	if err = o.executeMonitor(ctx); err != nil {
-		return o.failTest(result, err)
-	}
-
-	// Check for stop
-	if o.stopRequested.Load() {
-		return o.failTest(result, fmt.Errorf("stopped before cooldown"))
-	}
-
-	// COOLDOWN state — wait for the system to stabilise before removing faults
-	o.transitionState(StateCooldown)
-	if err = o.executeCooldown(ctx); err != nil {
-		return o.failTest(result, err)
-	}
-
-	// Check for stop
-	if o.stopRequested.Load() {
-		return o.failTest(result, fmt.Errorf("stopped before teardown"))
-	}
-
-	// TEARDOWN state — remove faults and sidecars before evaluating criteria.
-	// This ensures Prometheus can scrape cleanly and criteria are not affected
-	// by network faults blocking the scrape path.
-	//
-	// Capture the install count BEFORE teardown runs: removeTrackedFaults
-	// nils injectedFaults on exit (for idempotency w.r.t. the deferred
-	// abort-path cleanup), so reading len(o.injectedFaults) at success
-	// time would always see 0 (F-11).
-	faultInstallCount := len(o.injectedFaults)
-	o.transitionState(StateTeardown)
-	if err = o.executeTeardown(ctx); err != nil {
-		return o.failTest(result, err)
+	if checkpoint != nil {
+		err = o.resumeTransitions(ctx, o.transitions(scen), checkpoint)
+	} else {
+		err = o.runTransitions(ctx, o.transitions(scen), StateParse)
 	}
-
-	// Check for stop
-	if o.stopRequested.Load() {
-		return o.failTest(result, fmt.Errorf("stopped before detect"))
+	if o.scenario != nil {
+		result.ScenarioName = o.scenario.Metadata.Name
 	}
-
-	// DETECT state — evaluate success criteria now that faults are removed
-	o.transitionState(StateDetect)
-	if err = o.executeDetect(ctx); err != nil {
+	if err != nil {
 		return o.failTest(result, err)
 	}
 
@@ -495,17 +747,85 @@ func (o *Orchestrator) Execute(ctx context.Context, scen *scenario.Scenario, sce
 	result.Success = true
 	result.Message = "Test completed successfully"
 	result.Targets = o.targets
-	result.FaultCount = faultInstallCount
+	result.FaultCount = o.faultInstallCount
 	result.CriteriaResults = o.criteriaResults
 	result.FaultVerificationWarnings = o.faultVerificationWarnings
+	result.Environment = o.environment
+	result.Recoveries = o.injector.Recoveries()
+	result.AppliedMethods = o.injector.AppliedMethods()
+	result.Captures = o.captureFiles
+	result.Injections = o.injections
+	result.Collateral = o.collateral
+	result.EnclaveResidue = o.enclaveResidue
+	result.BlastRadius = o.blastRadius
+	result.RunnerUsage = o.phaseUsage
+	result.Detections = o.detections
+	result.Approval = o.approval
+	printPhaseUsage(o.phaseUsage)
 
 	return result, nil
 }
 
+// collectDetections stops the detection watcher, if running, and keeps
+// what it saw. Called before teardown, and on failure.
+func (o *Orchestrator) collectDetections() {
+	if o.detWatcher == nil {
+		return
+	}
+	injectedAt := o.injectTime
+	if injectedAt.IsZero() {
+		injectedAt = time.Now()
+	}
+	o.detections = o.detWatcher.StopAndCollect(injectedAt)
+	o.detWatcher = nil
+	printDetections(o.detections, o.detectionMaxLatency())
+}
+
+// detectionMaxLatency returns spec.detection.max_latency, or 0.
+func (o *Orchestrator) detectionMaxLatency() time.Duration {
+	if o.scenario == nil || o.scenario.Spec.Detection == nil {
+		return 0
+	}
+	return o.scenario.Spec.Detection.MaxLatency
+}
+
+// gameDayScenario is the scenario as GameDay announcements name it.
+func (o *Orchestrator) gameDayScenario() gameday.Scenario {
+	md := o.scenario.Metadata
+	return gameday.Scenario{
+		Name:           md.Name,
+		Owner:          md.Owner,
+		RunbookURL:     md.RunbookURL,
+		ExpectedImpact: md.ExpectedImpact,
+	}
+}
+
+// resolveMetricAliases probes Prometheus for which metric naming scheme the
+// enclave exposes (e.g. Heimdall v2 cometbft_* vs v1 tendermint_*) and
+// installs a query rewriter on the shared client, so scenarios written for
+// one scheme evaluate against the other. Best-effort: a probe failure leaves
+// queries untouched.
+func (o *Orchestrator) resolveMetricAliases(ctx context.Context) {
+	aliaser := prometheus.NewMetricAliaser(prometheus.DefaultAliasGroups)
+	if err := aliaser.Resolve(ctx, o.promClient); err != nil {
+		fmt.Printf("⚠ Metric alias probe failed, queries used as written: %v\n", err)
+		return
+	}
+	o.promClient.SetAliaser(aliaser)
+	if rewrites := aliaser.Rewrites(); len(rewrites) > 0 {
+		fmt.Printf("✓ Metric aliases active: %s\n", strings.Join(rewrites, ", "))
+	}
+}
+
 // State transition method
 func (o *Orchestrator) transitionState(newState TestState) {
-	fmt.Printf("[%s] → [%s]\n", o.currentState, newState)
+	o.markPhaseUsage(newState)
+	o.persist(func(f *state.File) error { return f.SetRunState(newState.String()) })
+	if o.observer != nil {
+		o.observer.StateChanged(o.currentState, newState)
+	}
 	o.currentState = newState
+	o.writeInterim(true)
 }
 
 // executeParse attaches the pre-parsed scenario to the orchestrator and
@@ -526,15 +846,36 @@ func (o *Orchestrator) executeParse(ctx context.Context, scen *scenario.Scenario
 	}
 
 	o.scenario = scen
+	// A scenario that blinds Prometheus must get unknown, not a verdict on
+	// stale data, from criteria that read the blinded targets.
+	if o.detector != nil {
+		o.detector.DetectBlindSpots(scen.HasFaultType("scrape_block"))
+	}
 	fmt.Printf("✓ Loaded scenario: %s\n", scen.Metadata.Name)
 	fmt.Printf("  Duration: %s, Warmup: %s, Cooldown: %s\n",
 		scen.Spec.Duration, scen.Spec.Warmup, scen.Spec.Cooldown)
 	fmt.Printf("  Targets: %d, Faults: %d, Success Criteria: %d\n",
 		len(scen.Spec.Targets), len(scen.Spec.Faults), len(scen.Spec.SuccessCriteria))
+	if md := scen.Metadata; md.ExpectedImpact != "" {
+		fmt.Printf("  Expected impact: %s\n", md.ExpectedImpact)
+	}
+	if md := scen.Metadata; md.RunbookURL != "" || md.Owner != "" {
+		fmt.Printf("  Runbook: %s, Owner: %s\n", orNone(md.RunbookURL), orNone(md.Owner))
+	}
+	if scen.Metadata.Destructive {
+		fmt.Println("  Destructive: injection waits for a second confirmation")
+	}
 
 	return nil
 }
 
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
 // observabilityBlocklist contains container name substrings that must never be
 // fault targets. Prometheus and Grafana are observability infrastructure — they
 // must remain reachable throughout every experiment.
@@ -578,18 +919,29 @@ func (o *Orchestrator) executeDiscover(ctx context.Context) error {
 	for _, targetSpec := range o.scenario.Spec.Targets {
 		fmt.Printf("  Looking for targets matching pattern: %s\n", targetSpec.Selector.Pattern)
 
-		// List all containers using the underlying Docker API
-		containers, err := o.dockerClient.ContainerList(ctx, types.ContainerListOptions{})
+		// List the containers of the local daemon and of every agent, or
+		// the pods of the cluster. Pods may be selected by labels alone.
+		pattern := targetSpec.Selector.Pattern
+		var containers []candidate
+		var err error
+		if targetSpec.Selector.Type == "kubernetes_pod" {
+			containers, err = o.listPodCandidates(ctx, targetSpec.Selector)
+			if pattern == "" {
+				pattern = "*"
+			}
+		} else {
+			containers, err = o.listCandidates(ctx)
+		}
 		if err != nil {
-			return fmt.Errorf("failed to list containers: %w", err)
+			return err
 		}
 
 		// Filter by pattern
-		matched := false
+		var found []TargetInfo
 		for _, container := range containers {
 			// Match against container name
-			if matchPattern(container.Names, targetSpec.Selector.Pattern) {
-				name := getContainerName(container.Names)
+			if matchPattern(container.names, pattern) {
+				name := getContainerName(container.names)
 				// Observability infrastructure must never be a fault target.
 				for _, blocked := range observabilityBlocklist {
 					if strings.Contains(name, blocked) {
@@ -599,20 +951,43 @@ func (o *Orchestrator) executeDiscover(ctx context.Context) error {
 						)
 					}
 				}
-				target := TargetInfo{
+				found = append(found, TargetInfo{
 					Alias:       targetSpec.Alias,
-					ContainerID: container.ID,
+					ContainerID: container.id,
 					Name:        name,
-					IP:          getContainerIP(container),
-				}
-				o.targets = append(o.targets, target)
-				fmt.Printf("    ✓ Found: %s (%s)\n", target.Name, target.ContainerID[:12])
-				matched = true
+					IP:          container.ip,
+					Agent:       container.agent,
+					Pod:         container.pod,
+				})
 			}
 		}
 
-		if !matched {
+		if len(found) == 0 {
 			fmt.Printf("    ⚠ No containers found matching pattern: %s\n", targetSpec.Selector.Pattern)
+			continue
+		}
+
+		// Narrow to count, resolved against how many matched. Sorting by
+		// name keeps the pick stable across runs on the same enclave.
+		if !targetSpec.Count.IsZero() {
+			k, err := targetSpec.Count.Resolve(len(found))
+			if err != nil {
+				return fmt.Errorf("target %s: %w", targetSpec.Alias, err)
+			}
+			fmt.Printf("    count %s → %d of %d matched\n", targetSpec.Count, k, len(found))
+			sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+			found = found[:k]
+		}
+
+		for _, target := range found {
+			o.targets = append(o.targets, target)
+			if target.Agent != "" {
+				fmt.Printf("    ✓ Found: %s (%s) via agent %s\n", target.Name, target.ContainerID[:12], target.Agent)
+			} else if target.Pod != nil {
+				fmt.Printf("    ✓ Found: pod %s/%s (%s)\n", target.Pod.Namespace, target.Name, target.ContainerID[:12])
+			} else {
+				fmt.Printf("    ✓ Found: %s (%s)\n", target.Name, target.ContainerID[:12])
+			}
 		}
 	}
 
@@ -621,13 +996,52 @@ func (o *Orchestrator) executeDiscover(ctx context.Context) error {
 	}
 
 	fmt.Printf("✓ Discovered %d target(s)\n", len(o.targets))
+	return o.resolveCriterionTargets()
+}
+
+// resolveCriterionTargets expands $__target in criterion queries to job
+// matchers for the containers just discovered. The scenario is copied
+// first so a caller re-running it (soak, suites) rediscovers from the
+// original queries.
+func (o *Orchestrator) resolveCriterionTargets() error {
+	jobs := targetJobs(o.targets)
+	resolved := make([]scenario.SuccessCriterion, len(o.scenario.Spec.SuccessCriteria))
+	for i, criterion := range o.scenario.Spec.SuccessCriteria {
+		c, err := scenario.ResolveCriterionTarget(criterion, jobs)
+		if err != nil {
+			return err
+		}
+		if c.Query != criterion.Query {
+			fmt.Printf("  %s → %s\n", c.Name, c.Query)
+		}
+		resolved[i] = c
+	}
+
+	scen := *o.scenario
+	scen.Spec.SuccessCriteria = resolved
+	o.scenario = &scen
 	return nil
 }
 
-// defaultValidatorPattern is the Polygon PoS Kurtosis naming convention for
-// Heimdall consensus-layer validators. Used as the fallback when a scenario
-// declares Preconditions without an explicit ValidatorPattern.
-const defaultValidatorPattern = `l2-cl-[0-9]+-heimdall-v2-bor-validator`
+// targetJobs maps each target alias to the Prometheus job names of its
+// containers. Kurtosis scrape jobs are named after the service, which is
+// the container name without its "--<uuid>" suffix.
+func targetJobs(targets []TargetInfo) map[string][]string {
+	jobs := make(map[string][]string)
+	for _, t := range targets {
+		jobs[t.Alias] = append(jobs[t.Alias], serviceName(t.Name))
+	}
+	return jobs
+}
+
+// serviceName strips the "--<uuid>" suffix Kurtosis appends to container
+// names.
+func serviceName(containerName string) string {
+	if i := strings.Index(containerName, "--"); i > 0 {
+		return containerName[:i]
+	}
+	return containerName
+}
 
 // executePreconditions enforces topology requirements declared in the
 // scenario. Runs after target discovery and before sidecar preparation.
@@ -638,9 +1052,14 @@ func (o *Orchestrator) executePreconditions(ctx context.Context) error {
 		return nil
 	}
 
+	// Fall back to the deployment profile's consensus-layer validator
+	// naming when the scenario does not give a pattern.
 	pattern := pre.ValidatorPattern
 	if pattern == "" {
-		pattern = defaultValidatorPattern
+		pattern = o.cfg.ActiveProfile().ValidatorPattern
+	}
+	if pattern == "" {
+		return fmt.Errorf("preconditions: validator_pattern is required with deployment profile %s", o.cfg.ActiveProfile().Name)
 	}
 	re, err := regexp.Compile(pattern)
 	if err != nil {
@@ -680,60 +1099,128 @@ func (o *Orchestrator) executePreconditions(ctx context.Context) error {
 	return nil
 }
 
+// checkDaemonPlatform detects where the Docker daemon runs. When container
+// PIDs are not meaningful to this host (remote daemon, Docker Desktop VM,
+// non-Linux runner) all namespace operations are routed through sidecar
+// exec. A Windows-container daemon fails fast listing the scenario's fault
+// types that cannot run there.
+func (o *Orchestrator) checkDaemonPlatform(ctx context.Context) error {
+	platform, err := o.dockerClient.DaemonPlatform(ctx)
+	if err != nil {
+		fmt.Printf("⚠ Could not detect Docker daemon platform: %v\n", err)
+		return nil
+	}
+
+	if platform.OSType != "linux" {
+		var unsupported []string
+		seen := make(map[string]bool)
+		for _, f := range o.scenario.Spec.Faults {
+			info, known := scenario.LookupFaultType(f.Type)
+			if known && info.RequiresLinux && !seen[f.Type] {
+				seen[f.Type] = true
+				unsupported = append(unsupported, f.Type)
+			}
+		}
+		if len(unsupported) > 0 {
+			return fmt.Errorf("%s: fault type(s) %s require Linux containers; only Docker lifecycle faults (container_restart, container_kill, container_pause) and external are supported on this daemon",
+				platform, strings.Join(unsupported, ", "))
+		}
+	}
+
+	if !platform.HostNamespaceAccess() {
+		o.verifier.UseSidecarExec(o.sidecarMgr)
+		fmt.Printf("⚠ %s: namespace operations will run through sidecars only\n", platform)
+	}
+	return nil
+}
+
 // executePrepare creates sidecars for all targets
 func (o *Orchestrator) executePrepare(ctx context.Context) error {
-	// Check and clean target namespaces before creating sidecars
+	// Check and clean target namespaces before creating sidecars. In
+	// sidecar-only mode there is no way into the namespace until the
+	// sidecar exists, so the remnant check is left to post-teardown
+	// verification.
+	containers, pods := byBackend(o.targets)
+	local, remote := byAgent(containers)
 	fmt.Println("Checking target namespaces for remnant artifacts...")
-	for _, target := range o.targets {
-		// First check if there are tc rules
-		result, err := o.verifier.VerifyNamespaceClean(ctx, target.ContainerID)
+	for _, target := range local {
+		if o.verifier.SidecarOnly() {
+			fmt.Println("  Skipped: remote/non-Linux Docker daemon (namespace checks run via sidecars)")
+			break
+		}
+		artifacts, err := o.verifier.ListChaosArtifacts(ctx, target.ContainerID)
 		if err != nil {
 			fmt.Printf("  ⚠ Failed to verify %s: %v\n", target.Name, err)
 			continue
 		}
+		cleanup := artifacts.CleanupCommands()
+		if len(cleanup) == 0 {
+			continue
+		}
+		fmt.Printf("  Found remnant chaos artifacts on %s (%s), clearing...\n", target.Name, artifacts)
 
-		if !result.Clean && result.TCRulesFound {
-			fmt.Printf("  Found remnant tc rules on %s, clearing...\n", target.Name)
+		// Create temporary sidecar to run the cleanup in the namespace
+		tempSidecarID, err := o.sidecarMgr.CreateSidecar(ctx, target.ContainerID)
+		if err != nil {
+			fmt.Printf("  ⚠ Failed to create temp sidecar for %s: %v\n", target.Name, err)
+			continue
+		}
 
-			// Create temporary sidecar to clear tc rules
-			tempSidecarID, err := o.sidecarMgr.CreateSidecar(ctx, target.ContainerID)
-			if err != nil {
-				fmt.Printf("  ⚠ Failed to create temp sidecar for %s: %v\n", target.Name, err)
-				continue
+		// Remove exactly what was found, on every affected device and table
+		var execErrs []error
+		for _, clearCmd := range cleanup {
+			if _, err := o.dockerClient.ExecCommand(ctx, tempSidecarID, clearCmd); err != nil {
+				execErrs = append(execErrs, fmt.Errorf("%s: %w", strings.Join(clearCmd, " "), err))
 			}
+		}
 
-			// Remove tc rules directly
-			clearCmd := []string{"tc", "qdisc", "del", "dev", "eth0", "root"}
-			_, execErr := o.dockerClient.ExecCommand(ctx, tempSidecarID, clearCmd)
-
-			// Destroy temp sidecar
-			removeOptions := types.ContainerRemoveOptions{
-				Force:         true,
-				RemoveVolumes: true,
-			}
-			o.dockerClient.ContainerRemove(ctx, tempSidecarID, removeOptions)
+		// Destroy temp sidecar
+		removeOptions := types.ContainerRemoveOptions{
+			Force:         true,
+			RemoveVolumes: true,
+		}
+		o.dockerClient.ContainerRemove(ctx, tempSidecarID, removeOptions)
 
-			if execErr != nil {
-				fmt.Printf("  ⚠ Failed to clear tc rules: %v\n", execErr)
-			} else {
-				fmt.Printf("  ✓ Cleaned tc rules on %s\n", target.Name)
-			}
+		if len(execErrs) > 0 {
+			fmt.Printf("  ⚠ Failed to clear chaos artifacts: %v\n", errors.Join(execErrs...))
+		} else {
+			fmt.Printf("  ✓ Cleaned chaos artifacts on %s\n", target.Name)
 		}
 	}
 	fmt.Println("✓ Target namespace check complete")
 
 	fmt.Println("Preparing sidecars...")
 
-	for _, target := range o.targets {
+	// Only targets of stress faults get a sidecar with access to their PID
+	// namespace and the host cgroups, which stress-ng needs.
+	stressAliases := make(map[string]bool)
+	for _, f := range o.scenario.Spec.Faults {
+		switch scenario.CanonicalFaultType(f.Type) {
+		case "cpu_stress", "memory_stress":
+			stressAliases[f.Target] = true
+		}
+	}
+
+	for _, target := range local {
 		fmt.Printf("  Creating sidecar for %s (%s)...\n", target.Name, target.ContainerID[:12])
 
-		sidecarID, err := o.sidecarMgr.CreateSidecar(ctx, target.ContainerID)
+		create := o.sidecarMgr.CreateSidecar
+		if stressAliases[target.Alias] {
+			create = o.sidecarMgr.CreateCgroupSidecar
+		}
+		sidecarID, err := create(ctx, target.ContainerID)
 		if err != nil {
 			return fmt.Errorf("failed to create sidecar for %s: %w", target.Name, err)
 		}
 
 		fmt.Printf("    ✓ Sidecar created: %s\n", sidecarID[:12])
 	}
+	if err := o.prepareAgentTargets(ctx, remote, stressAliases); err != nil {
+		return err
+	}
+	if err := o.preparePodTargets(ctx, pods); err != nil {
+		return err
+	}
 
 	fmt.Printf("✓ Created %d sidecar(s)\n", len(o.targets))
 	return nil
@@ -784,10 +1271,11 @@ func (o *Orchestrator) executePreCheck(ctx context.Context) error {
 
 	// Collect only critical criteria that verify steady-state health.
 	// Skip criteria marked post_fault_only — they verify fault effectiveness
-	// and are expected to fail before injection.
+	// and are expected to fail before injection — and those expecting an
+	// unknown outcome, which only a live fault can produce.
 	var critical []int
 	for i, c := range o.scenario.Spec.SuccessCriteria {
-		if !c.Critical || c.PostFaultOnly || c.DuringFault {
+		if !c.Critical || c.PostFaultOnly || c.DuringFault || c.Expect != "" {
 			continue
 		}
 		critical = append(critical, i)
@@ -808,11 +1296,12 @@ func (o *Orchestrator) executePreCheck(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("pre-check query failed for %q: %w", criterion.Name, err)
 		}
+		o.applyUnknownPolicy(result)
 
 		if result.Passed {
 			fmt.Printf("    ✓ %s\n", result.Message)
 		} else {
-			fmt.Printf("    ✗ FAILED (CRITICAL): %s\n", result.Message)
+			fmt.Printf("    ✗ %s (CRITICAL): %s\n", verdict(result), result.Message)
 			if criterion.Description != "" {
 				fmt.Printf("      → %s\n", criterion.Description)
 			}
@@ -828,21 +1317,108 @@ func (o *Orchestrator) executePreCheck(ctx context.Context) error {
 // Each fault targets a different set of containers so concurrent injection is safe.
 func (o *Orchestrator) executeInject(ctx context.Context) error {
 	o.injectTime = time.Now() // record fault window start for log scoping
+	o.persist(func(f *state.File) error { return f.SetInjectedAt(o.injectTime) })
 	fmt.Println("Injecting faults...")
+	o.startCollateralWatch(ctx)
 
 	if len(o.scenario.Spec.Faults) == 0 {
 		fmt.Println("  ⚠ No faults defined in scenario")
 		return nil
 	}
 
-	// faultJob pairs a fault with its resolved targets.
-	type faultJob struct {
-		index   int
-		fault   scenario.Fault
-		targets []TargetInfo
+	jobs, err := o.faultJobs()
+	if err != nil {
+		return err
 	}
 
-	// Resolve targets for every fault (sequential, cheap).
+	// Refuse any scenario that co-injects dns + network on the same container.
+	// Both install a root tc qdisc; the second one silently wipes or clobbers
+	// the first. Detect at plan time rather than debugging a missing fault.
+	{
+		perContainer := map[string]map[string]bool{}
+		for _, job := range jobs {
+			for _, t := range job.targets {
+				if perContainer[t.ContainerID] == nil {
+					perContainer[t.ContainerID] = map[string]bool{}
+				}
+				perContainer[t.ContainerID][job.fault.Type] = true
+			}
+		}
+		for cid, faultTypes := range perContainer {
+			if faultTypes["dns"] && faultTypes["network"] {
+				name := cid[:12]
+				for _, t := range o.targets {
+					if t.ContainerID == cid {
+						name = t.Name
+						break
+					}
+				}
+				return fmt.Errorf("dns and network faults cannot share a container (both install a root tc qdisc on eth0) — target %q has both", name)
+			}
+		}
+	}
+
+	// Verify tools and capabilities up front, so a missing prerequisite is
+	// reported as such instead of as an exec failure halfway through INJECT.
+	{
+		faults := make([]scenario.Fault, len(jobs))
+		targets := make([][]TargetInfo, len(jobs))
+		for i, job := range jobs {
+			faults[i], targets[i] = job.fault, job.targets
+		}
+		if err := o.checkInjectPrerequisites(ctx, faults, targets); err != nil {
+			return err
+		}
+	}
+
+	// Faults with start_after are left to the MONITOR timeline, as is the
+	// removal of every fault with a duration.
+	var now []faultJob
+	for _, job := range jobs {
+		if job.fault.StartAfter > 0 {
+			o.schedule(timelineEntry{at: job.fault.StartAfter, job: job})
+		} else {
+			now = append(now, job)
+		}
+		if job.fault.Duration > 0 {
+			o.schedule(timelineEntry{at: job.fault.StartAfter + job.fault.Duration, job: job, remove: true})
+		}
+	}
+	if len(o.timeline) > 0 {
+		fmt.Printf("  %d fault start(s)/removal(s) scheduled during monitoring\n", len(o.timeline))
+	}
+
+	installed, err := o.injectJobs(ctx, o.excludeCurrentProducer(ctx, now))
+	if err != nil {
+		return err
+	}
+
+	distinctContainers := map[string]struct{}{}
+	for _, f := range installed {
+		distinctContainers[f.ContainerID] = struct{}{}
+	}
+	fmt.Printf("✓ %d fault(s) injected on %d distinct container(s)\n",
+		len(installed), len(distinctContainers))
+
+	o.startCaptures(ctx, installed)
+
+	// Post-injection verification: confirm tc rules are actually in place.
+	if err := o.verifyFaultsActive(ctx, installed); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// faultJob pairs a fault with its resolved targets.
+type faultJob struct {
+	index   int
+	fault   scenario.Fault
+	targets []TargetInfo
+}
+
+// faultJobs resolves the targets of every fault (sequential, cheap).
+func (o *Orchestrator) faultJobs() ([]faultJob, error) {
 	var jobs []faultJob
 	for i, fault := range o.scenario.Spec.Faults {
 		var targets []TargetInfo
@@ -855,75 +1431,117 @@ func (o *Orchestrator) executeInject(ctx context.Context) error {
 			fmt.Printf("  ⚠ No targets found for fault %q (alias: %s)\n", fault.Phase, fault.Target)
 			continue
 		}
+		if scenario.CanonicalFaultType(fault.Type) == "network_partition" {
+			resolved, err := o.resolvePartitionPeers(fault, targets)
+			if err != nil {
+				return nil, err
+			}
+			fault = resolved
+		}
 		jobs = append(jobs, faultJob{index: i, fault: fault, targets: targets})
 	}
+	return jobs, nil
+}
 
-	// Exclude current block producer from jobs that request it.
-	for i, job := range jobs {
-		if !job.fault.ExcludeProducer {
-			continue
-		}
-		producerName, err := o.resolveCurrentProducer(ctx)
-		if err != nil {
-			fmt.Printf("  ⚠ Could not resolve current producer for fault %q: %v\n", job.fault.Phase, err)
-			continue
-		}
-		var filtered []TargetInfo
-		for _, t := range job.targets {
-			if t.Name == producerName {
-				fmt.Printf("  ⊘ Excluding current block producer %s from fault %q\n", producerName, job.fault.Phase)
-			} else {
-				filtered = append(filtered, t)
+// resolvePartitionPeers returns a copy of a network_partition fault whose
+// peer_ips also lists the address of every target of its peers aliases.
+// A peer that is also one of the fault's own targets would cut that target
+// off from itself, so it is refused.
+func (o *Orchestrator) resolvePartitionPeers(fault scenario.Fault, targets []TargetInfo) (scenario.Fault, error) {
+	aliases, err := scenario.ParseListParam(fault.Params["peers"])
+	if err != nil {
+		return fault, fmt.Errorf("fault %q: invalid peers: %w", fault.Phase, err)
+	}
+	peerIPs, err := scenario.ParseListParam(fault.Params["peer_ips"])
+	if err != nil {
+		return fault, fmt.Errorf("fault %q: invalid peer_ips: %w", fault.Phase, err)
+	}
+
+	own := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		own[t.ContainerID] = true
+	}
+	for _, alias := range aliases {
+		found := 0
+		for _, t := range o.targets {
+			if t.Alias != alias {
+				continue
+			}
+			found++
+			if own[t.ContainerID] {
+				return fault, fmt.Errorf("fault %q: %s is both a target and a peer (alias %s)", fault.Phase, t.Name, alias)
 			}
+			if t.IP == "" {
+				return fault, fmt.Errorf("fault %q: could not determine the IP of peer %s (alias %s)", fault.Phase, t.Name, alias)
+			}
+			peerIPs = append(peerIPs, t.IP)
 		}
-		if len(filtered) == 0 {
-			fmt.Printf("  ⚠ No targets remain after excluding producer for fault %q — skipping\n", job.fault.Phase)
+		if found == 0 {
+			return fault, fmt.Errorf("fault %q: no targets found for peer alias %s", fault.Phase, alias)
 		}
-		jobs[i].targets = filtered
 	}
 
-	// Remove jobs with no targets after producer exclusion.
-	{
-		var remaining []faultJob
-		for _, job := range jobs {
-			if len(job.targets) > 0 {
-				remaining = append(remaining, job)
-			}
-		}
-		jobs = remaining
+	params := make(map[string]interface{}, len(fault.Params))
+	for k, v := range fault.Params {
+		params[k] = v
 	}
+	params["peer_ips"] = peerIPs
+	fault.Params = params
+	fmt.Printf("  Fault %q partitions %s from %s\n", fault.Phase, fault.Target, strings.Join(peerIPs, ", "))
+	return fault, nil
+}
 
-	// Refuse any scenario that co-injects dns + network on the same container.
-	// Both install a root tc qdisc; the second one silently wipes or clobbers
-	// the first. Detect at plan time rather than debugging a missing fault.
-	{
-		perContainer := map[string]map[string]bool{}
-		for _, job := range jobs {
-			for _, t := range job.targets {
-				if perContainer[t.ContainerID] == nil {
-					perContainer[t.ContainerID] = map[string]bool{}
-				}
-				perContainer[t.ContainerID][job.fault.Type] = true
-			}
-		}
-		for cid, faultTypes := range perContainer {
-			if faultTypes["dns"] && faultTypes["network"] {
-				name := cid[:12]
-				for _, t := range o.targets {
-					if t.ContainerID == cid {
-						name = t.Name
-						break
+// excludeCurrentProducer drops the current block producer from the jobs
+// that request it, and then any job left without targets. Scheduled jobs
+// call it when they fire, since the producer rotates.
+func (o *Orchestrator) excludeCurrentProducer(ctx context.Context, jobs []faultJob) []faultJob {
+	var remaining []faultJob
+	for _, job := range jobs {
+		if job.fault.ExcludeProducer {
+			producerName, err := o.resolveCurrentProducer(ctx)
+			if err != nil {
+				fmt.Printf("  ⚠ Could not resolve current producer for fault %q: %v\n", job.fault.Phase, err)
+			} else {
+				var filtered []TargetInfo
+				for _, t := range job.targets {
+					if t.Name == producerName {
+						fmt.Printf("  ⊘ Excluding current block producer %s from fault %q\n", producerName, job.fault.Phase)
+					} else {
+						filtered = append(filtered, t)
 					}
 				}
-				return fmt.Errorf("dns and network faults cannot share a container (both install a root tc qdisc on eth0) — target %q has both", name)
+				if len(filtered) == 0 {
+					fmt.Printf("  ⚠ No targets remain after excluding producer for fault %q — skipping\n", job.fault.Phase)
+				}
+				job.targets = filtered
 			}
 		}
+		if len(job.targets) > 0 {
+			remaining = append(remaining, job)
+		}
 	}
+	return remaining
+}
 
-	// injectResult carries the outcome of one goroutine.
+// injectJobs installs jobs concurrently and records every fault that went
+// in, returning those. The error joins every job that failed.
+func (o *Orchestrator) injectJobs(ctx context.Context, jobs []faultJob) ([]injectedFault, error) {
+	// injectResult carries the outcome of one goroutine: one error per
+	// target, in job.targets order.
 	type injectResult struct {
-		job faultJob
-		err error
+		job  faultJob
+		errs []error
+		at   time.Time
+	}
+
+	// Persist every fault before it is installed, so a runner crash
+	// mid-inject still leaves `chaos-runner recover` something to remove.
+	for _, job := range jobs {
+		for _, t := range job.targets {
+			o.persist(func(f *state.File) error {
+				return f.AddFault(state.Fault{ContainerID: t.ContainerID, Target: t.Name, Type: job.fault.Type, Phase: job.fault.Phase, Params: scenario.RedactParams(job.fault.Params)})
+			})
+		}
 	}
 
 	// Fire all injections concurrently so every fault starts at the same instant.
@@ -941,20 +1559,26 @@ func (o *Orchestrator) executeInject(ctx context.Context) error {
 				select {
 				case <-time.After(job.fault.Delay):
 				case <-ctx.Done():
-					results[i] = injectResult{job: job, err: ctx.Err()}
+					results[i] = injectResult{job: job, errs: repeatErr(ctx.Err(), len(job.targets))}
 					return
 				}
 			}
 
-			injTargets := make([]injection.Target, len(job.targets))
-			for j, t := range job.targets {
-				injTargets[j] = injection.Target{Name: t.Name, ContainerID: t.ContainerID}
+			fmt.Printf("  → injecting %s on %d container(s)...\n", job.fault.Phase, len(job.targets))
+
+			// A partial-failure policy needs each target's own outcome, so
+			// targets are injected one at a time; otherwise the handler's
+			// single error stands for all of them.
+			if !job.fault.FailurePolicy.Partial() || len(job.targets) == 1 {
+				errs := repeatErr(o.injectTargets(ctx, &job.fault, job.targets), len(job.targets))
+				results[i] = injectResult{job: job, errs: errs, at: time.Now()}
+				return
 			}
-			fmt.Printf("  → injecting %s on %d container(s)...\n", job.fault.Phase, len(injTargets))
-			results[i] = injectResult{
-				job: job,
-				err: o.injector.InjectFault(ctx, &job.fault, injTargets),
+			errs := make([]error, len(job.targets))
+			for j, t := range job.targets {
+				errs[j] = o.injectTargets(ctx, &job.fault, []TargetInfo{t})
 			}
+			results[i] = injectResult{job: job, errs: errs, at: time.Now()}
 		}()
 	}
 	wg.Wait()
@@ -972,20 +1596,50 @@ func (o *Orchestrator) executeInject(ctx context.Context) error {
 	// Each (container, faultType) pair is recorded independently. Multiple
 	// faults can share a container (e.g. compound disk_io + network), and
 	// teardown will remove them in reverse order of injection.
-	distinctContainers := map[string]struct{}{}
+	//
+	// failure_policy decides whether targets that failed fail the test.
+	var installed []injectedFault
 	var injectErrs []error
 	for _, r := range results {
-		if r.err != nil {
-			injectErrs = append(injectErrs, fmt.Errorf("inject %q: %w", r.job.fault.Phase, r.err))
-			continue
-		}
-		for _, t := range r.job.targets {
-			o.injectedFaults = append(o.injectedFaults, injectedFault{
+		var failed []error
+		for j, t := range r.job.targets {
+			err := r.errs[j]
+			in := TargetInjection{Target: t.Name, ContainerID: t.ContainerID, Err: err}
+			if err == nil {
+				in.InstalledAt = r.at
+			}
+			o.injections[r.job.fault.Phase] = append(o.injections[r.job.fault.Phase], in)
+			if err != nil {
+				failed = append(failed, err)
+				o.recordAudit(audit.ActionInjectFailed, r.job.fault.Phase, r.job.fault.Type, t, r.job.fault.Params, err)
+				o.persist(func(f *state.File) error { return f.RemoveFault(t.ContainerID, r.job.fault.Type, r.job.fault.Phase) })
+				if r.job.fault.FailurePolicy.Partial() {
+					fmt.Printf("  ✗ %s on %s: %v\n", r.job.fault.Phase, t.Name, err)
+				}
+				continue
+			}
+			f := injectedFault{
 				ContainerID: t.ContainerID,
 				FaultType:   r.job.fault.Type,
-			})
-			distinctContainers[t.ContainerID] = struct{}{}
-			fmt.Printf("  ✓ %s on %s (%s)\n", r.job.fault.Phase, t.Name, t.ContainerID[:12])
+				Phase:       r.job.fault.Phase,
+				Params:      r.job.fault.Params,
+			}
+			o.injectedFaults = append(o.injectedFaults, f)
+			installed = append(installed, f)
+			o.recordAudit(audit.ActionInject, r.job.fault.Phase, r.job.fault.Type, t, r.job.fault.Params, nil)
+			if o.observer != nil {
+				o.observer.FaultInjected(r.job.fault.Phase, r.job.fault.Type, t)
+			}
+		}
+
+		total := len(r.job.targets)
+		switch policyErr := r.job.fault.FailurePolicy.Check(total-len(failed), total); {
+		case policyErr != nil && !r.job.fault.FailurePolicy.Partial():
+			injectErrs = append(injectErrs, fmt.Errorf("inject %q: %w", r.job.fault.Phase, failed[0]))
+		case policyErr != nil:
+			injectErrs = append(injectErrs, fmt.Errorf("inject %q: %v: %w", r.job.fault.Phase, policyErr, errors.Join(failed...)))
+		case len(failed) > 0:
+			fmt.Printf("  ⚠ %s installed on %d of %d target(s); continuing under failure_policy %s\n", r.job.fault.Phase, total-len(failed), total, r.job.fault.FailurePolicy)
 		}
 	}
 
@@ -994,21 +1648,22 @@ func (o *Orchestrator) executeInject(ctx context.Context) error {
 		// been appended to injectedFaults. The caller (Execute) will route
 		// to failTest, which defers to the cleanup coordinator + teardown
 		// path to remove whatever did install cleanly.
-		return errors.Join(injectErrs...)
+		return installed, errors.Join(injectErrs...)
 	}
+	return installed, nil
+}
 
-	fmt.Printf("✓ %d fault(s) injected on %d distinct container(s)\n",
-		len(o.injectedFaults), len(distinctContainers))
-
-	// Post-injection verification: confirm tc rules are actually in place.
-	if err := o.verifyFaultsActive(ctx); err != nil {
-		return err
+// repeatErr returns n copies of err, for one error that stands for every
+// target of a fault.
+func repeatErr(err error, n int) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = err
 	}
-
-	return nil
+	return errs
 }
 
-// verifyFaultsActive confirms each injected fault left observable state in
+// verifyFaultsActive confirms each of faults left observable state in
 // the target's namespace/sidecar. Without this step a silently-failed exec
 // (sidecar returned success but the rule was not applied) would be
 // indistinguishable from a real injection.
@@ -1016,23 +1671,23 @@ func (o *Orchestrator) executeInject(ctx context.Context) error {
 // Verification failures are logged and counted but do NOT abort the run,
 // because we may be inspecting a fault that has already produced the desired
 // side effect and self-terminated (e.g. a short-lived p2p attack). The
-// returned count of warnings is stored on the orchestrator so the final
-// report can flag experiments where verification did not pass cleanly.
+// count of warnings is added to the orchestrator's so the final report can
+// flag experiments where verification did not pass cleanly.
 //
 // Fault types whose implementations return synchronous errors on failure and
 // have no separate post-install side effect to inspect (container lifecycle,
 // process_kill, p2p_attack, file_delete, file_corrupt) are skipped here.
-func (o *Orchestrator) verifyFaultsActive(ctx context.Context) error {
+func (o *Orchestrator) verifyFaultsActive(ctx context.Context, faults []injectedFault) error {
 	fmt.Println("Verifying faults are active...")
 
-	o.faultVerificationWarnings = 0
+	warnings := 0
 	verified := 0
 	// Deduplicate: if two faults share a container and the same verify
 	// family (e.g. network + dns), we only need to inspect once. But
 	// semantically different faults (network + disk_io) must both be
 	// verified, so we deduplicate on (containerID, faultType) pair.
 	seen := map[string]struct{}{}
-	for _, f := range o.injectedFaults {
+	for _, f := range faults {
 		key := f.ContainerID + "\x00" + f.FaultType
 		if _, ok := seen[key]; ok {
 			continue
@@ -1047,6 +1702,16 @@ func (o *Orchestrator) verifyFaultsActive(ctx context.Context) error {
 				break
 			}
 		}
+		// Verification inspects the namespace through a local sidecar; on
+		// an agent's host the inject's own error check is all there is.
+		if a := o.agentFor(containerID); a != nil {
+			fmt.Printf("  - %s: %s not verified (on agent %s)\n", targetName, faultType, a.Name)
+			continue
+		}
+		if o.podFor(containerID) {
+			fmt.Printf("  - %s: %s not verified (Kubernetes pod)\n", targetName, faultType)
+			continue
+		}
 
 		var verifyErr error
 		switch faultType {
@@ -1056,25 +1721,32 @@ func (o *Orchestrator) verifyFaultsActive(ctx context.Context) error {
 			verifyErr = o.verifyDNSFault(ctx, containerID, targetName)
 		case "connection_drop":
 			verifyErr = o.verifyConnectionDropFault(ctx, containerID, targetName)
+		case "network_partition":
+			verifyErr = o.verifyPartitionFault(ctx, containerID, targetName)
 		case "http_fault", "corruption_proxy":
 			verifyErr = o.verifyHTTPRedirect(ctx, containerID, targetName, faultType)
+		case "txpool_saturation":
+			verifyErr = o.verifyTxPoolFlood(ctx, containerID, targetName)
 		case "disk_fill":
 			verifyErr = o.verifyDiskFillFault(ctx, containerID, targetName)
 		case "disk_io":
 			verifyErr = o.verifyDiskIOFault(ctx, containerID, targetName)
 		case "cpu_stress", "cpu", "memory_stress", "memory_pressure", "memory":
 			verifyErr = o.verifyStressFault(ctx, containerID, targetName, faultType)
+		case "external":
+			verifyErr = o.injector.VerifyExternal(ctx, containerID)
 		}
 
 		if verifyErr != nil {
-			o.faultVerificationWarnings++
+			warnings++
 			fmt.Printf("  ⚠ %s: %v\n", targetName, verifyErr)
 		}
 		verified++
 	}
 
-	if o.faultVerificationWarnings > 0 {
-		fmt.Printf("⚠ Verified %d fault(s), %d with verification warnings — see above\n", verified, o.faultVerificationWarnings)
+	o.faultVerificationWarnings += warnings
+	if warnings > 0 {
+		fmt.Printf("⚠ Verified %d fault(s), %d with verification warnings — see above\n", verified, warnings)
 	} else {
 		fmt.Printf("✓ Verified %d fault(s) active\n", verified)
 	}
@@ -1082,23 +1754,34 @@ func (o *Orchestrator) verifyFaultsActive(ctx context.Context) error {
 }
 
 // verifyNetworkFault inspects tc qdisc/filters in the target's sidecar.
+// All devices are listed since the fault may be on a discovered interface
+// other than eth0, or on several (all_interfaces).
 func (o *Orchestrator) verifyNetworkFault(ctx context.Context, containerID, targetName string) error {
-	output, err := o.sidecarMgr.ExecInSidecar(ctx, containerID, []string{"tc", "qdisc", "show", "dev", "eth0"})
+	output, err := o.sidecarMgr.ExecInSidecar(ctx, containerID, []string{"tc", "qdisc", "show"})
 	if err != nil {
 		return fmt.Errorf("could not inspect tc rules: %w", err)
 	}
 	if !strings.Contains(output, "netem") && !strings.Contains(output, "tbf") {
 		return fmt.Errorf("no netem/tbf rules found after injection (tc output: %s)", strings.TrimSpace(output))
 	}
+	devices := map[string]bool{}
 	for _, line := range strings.Split(output, "\n") {
 		line = strings.TrimSpace(line)
 		if strings.Contains(line, "netem") || strings.Contains(line, "tbf") {
 			fmt.Printf("  ✓ %s: %s\n", targetName, line)
+			fields := strings.Fields(line)
+			for i := 0; i < len(fields)-1; i++ {
+				if fields[i] == "dev" {
+					devices[fields[i+1]] = true
+				}
+			}
 		}
 	}
-	filterOutput, _ := o.sidecarMgr.ExecInSidecar(ctx, containerID, []string{"tc", "filter", "show", "dev", "eth0"})
-	if filterOutput != "" && strings.Contains(filterOutput, "u32") {
-		fmt.Printf("  ✓ %s: %d u32 port filter(s) active\n", targetName, strings.Count(filterOutput, "match"))
+	for device := range devices {
+		filterOutput, _ := o.sidecarMgr.ExecInSidecar(ctx, containerID, []string{"tc", "filter", "show", "dev", device})
+		if filterOutput != "" && strings.Contains(filterOutput, "u32") {
+			fmt.Printf("  ✓ %s: %d u32 port filter(s) active on %s\n", targetName, strings.Count(filterOutput, "match"), device)
+		}
 	}
 	return nil
 }
@@ -1130,6 +1813,19 @@ func (o *Orchestrator) verifyConnectionDropFault(ctx context.Context, containerI
 	return nil
 }
 
+// verifyPartitionFault confirms the CHAOS_PARTITION chain is populated.
+func (o *Orchestrator) verifyPartitionFault(ctx context.Context, containerID, targetName string) error {
+	output, err := o.sidecarMgr.ExecInSidecar(ctx, containerID, []string{"iptables", "-L", "CHAOS_PARTITION", "-n"})
+	if err != nil {
+		return fmt.Errorf("CHAOS_PARTITION chain not found: %w", err)
+	}
+	if !strings.Contains(output, "DROP") {
+		return fmt.Errorf("CHAOS_PARTITION chain has no rules (%s)", strings.TrimSpace(output))
+	}
+	fmt.Printf("  ✓ %s: CHAOS_PARTITION chain active\n", targetName)
+	return nil
+}
+
 // verifyHTTPRedirect confirms PREROUTING contains a redirect rule with the
 // expected chaos comment.
 func (o *Orchestrator) verifyHTTPRedirect(ctx context.Context, containerID, targetName, faultType string) error {
@@ -1150,6 +1846,26 @@ func (o *Orchestrator) verifyHTTPRedirect(ctx context.Context, containerID, targ
 	return nil
 }
 
+// verifyTxPoolFlood confirms chaos-txflood is running in the sidecar, or
+// has already sent its count and exited.
+func (o *Orchestrator) verifyTxPoolFlood(ctx context.Context, containerID, targetName string) error {
+	output, err := o.sidecarMgr.ExecInSidecar(ctx, containerID, []string{"sh", "-c",
+		"pgrep -x chaos-txflood > /dev/null && echo running || grep -c 'chaos-txflood finished' /tmp/chaos-txflood.log 2>/dev/null || true",
+	})
+	if err != nil {
+		return fmt.Errorf("could not inspect chaos-txflood: %w", err)
+	}
+	switch out := strings.TrimSpace(output); {
+	case out == "running":
+		fmt.Printf("  ✓ %s: txpool flood running\n", targetName)
+	case out != "" && out != "0":
+		fmt.Printf("  ✓ %s: txpool flood already sent its count\n", targetName)
+	default:
+		return fmt.Errorf("chaos-txflood is not running")
+	}
+	return nil
+}
+
 // verifyDiskFillFault confirms a fill file exists somewhere under the target.
 func (o *Orchestrator) verifyDiskFillFault(ctx context.Context, containerID, targetName string) error {
 	output, err := o.dockerClient.ExecCommand(ctx, containerID, []string{"sh", "-c",
@@ -1165,8 +1881,13 @@ func (o *Orchestrator) verifyDiskFillFault(ctx context.Context, containerID, tar
 	return nil
 }
 
-// verifyDiskIOFault confirms chaos dd stress workers are running in the target.
+// verifyDiskIOFault confirms a dm-delay table is recorded in the target, or
+// chaos dd stress workers are running there.
 func (o *Orchestrator) verifyDiskIOFault(ctx context.Context, containerID, targetName string) error {
+	if _, err := o.dockerClient.ExecCommand(ctx, containerID, []string{"test", "-f", disk.DmTableFile}); err == nil {
+		fmt.Printf("  ✓ %s: dm-delay table active\n", targetName)
+		return nil
+	}
 	output, err := o.dockerClient.ExecCommand(ctx, containerID, []string{"sh", "-c",
 		"COUNT=0; for p in /proc/[0-9]*/cmdline; do " +
 			"if tr '\\0' ' ' < $p 2>/dev/null | grep -q 'chaos_io_stress'; then COUNT=$((COUNT+1)); fi; " +
@@ -1184,13 +1905,14 @@ func (o *Orchestrator) verifyDiskIOFault(ctx context.Context, containerID, targe
 }
 
 // verifyStressFault confirms a stress mechanism is active. The stress
-// injector supports two methods: active `yes` processes (method="stress")
-// and cgroup limits (method="limit"). Either "yes" processes OR an updated
-// cgroup (non-zero CPUQuota/Memory) counts as success.
+// injector supports active workers (`yes` loops, or stress-ng started from
+// the sidecar, which shares the target's PID namespace) and cgroup limits
+// (method="limit"). Either active workers OR an updated cgroup (non-zero
+// CPUQuota/Memory) counts as success.
 func (o *Orchestrator) verifyStressFault(ctx context.Context, containerID, targetName, faultType string) error {
 	output, err := o.dockerClient.ExecCommand(ctx, containerID, []string{"sh", "-c",
 		"COUNT=0; for p in /proc/[0-9]*/cmdline; do " +
-			"if tr '\\0' ' ' < $p 2>/dev/null | grep -q '^yes'; then COUNT=$((COUNT+1)); fi; " +
+			"if tr '\\0' ' ' < $p 2>/dev/null | grep -qE '^(yes|stress-ng)'; then COUNT=$((COUNT+1)); fi; " +
 			"done; echo $COUNT",
 	})
 	if err != nil {
@@ -1221,14 +1943,17 @@ func (o *Orchestrator) verifyStressFault(ctx context.Context, containerID, targe
 // executeMonitor monitors system metrics during the test
 func (o *Orchestrator) executeMonitor(ctx context.Context) error {
 	duration := o.scenario.Spec.Duration
+	if o.resumeMonitor > 0 {
+		duration = o.resumeMonitor
+	}
 	fmt.Printf("Monitoring for: %s\n", duration)
 
 	// Start real-time log watcher — streams container logs and prints
 	// ERROR/CRIT/PANIC/FATAL lines to stdout as they happen.
 	var logWatcher *logcollector.Watcher
-	if o.logCollector != nil && len(o.targets) > 0 {
-		watchTargets := make([]logcollector.WatchTarget, len(o.targets))
-		for i, t := range o.targets {
+	if containers, _ := byBackend(o.targets); o.logCollector != nil && len(containers) > 0 {
+		watchTargets := make([]logcollector.WatchTarget, len(containers))
+		for i, t := range containers {
 			watchTargets[i] = logcollector.WatchTarget{
 				ContainerID: t.ContainerID,
 				Name:        t.Name,
@@ -1255,8 +1980,9 @@ func (o *Orchestrator) executeMonitor(ctx context.Context) error {
 		fmt.Println("  Starting metrics collection...")
 		o.collector.Start(ctx)
 
-		// Monitor for the duration (interruptible)
-		if err := o.interruptibleSleep(ctx, duration); err != nil {
+		// Monitor for the duration (interruptible), running scheduled
+		// fault starts and removals as they come due
+		if err := o.runTimeline(ctx, duration); err != nil {
 			o.collector.Stop()
 			if logWatcher != nil {
 				logWatcher.Stop()
@@ -1269,7 +1995,7 @@ func (o *Orchestrator) executeMonitor(ctx context.Context) error {
 		fmt.Println("  Metrics collection stopped")
 	} else {
 		fmt.Println("  Prometheus not available, monitoring duration only")
-		if err := o.interruptibleSleep(ctx, duration); err != nil {
+		if err := o.runTimeline(ctx, duration); err != nil {
 			if logWatcher != nil {
 				logWatcher.Stop()
 			}
@@ -1345,7 +2071,7 @@ func (o *Orchestrator) evaluateDuringFaultCriteria(ctx context.Context) error {
 	fmt.Printf("\nEvaluating during-fault criteria (%d) — using worst-observed reading from %d samples across fault window...\n",
 		len(duringFault), o.dfSampler.SampleCount())
 
-	criticalFailed := false
+	criticalFailed, criticalUnknown := false, false
 	for j, idx := range duringFault {
 		criterion := o.scenario.Spec.SuccessCriteria[idx]
 		fmt.Printf("  [%d/%d] Evaluating: %s\n", j+1, len(duringFault), criterion.Name)
@@ -1363,29 +2089,38 @@ func (o *Orchestrator) evaluateDuringFaultCriteria(ctx context.Context) error {
 			}
 			result = r
 		}
+		o.applyUnknownPolicy(result)
 
-		o.criteriaResults = append(o.criteriaResults, CriterionOutcome{
+		o.recordCriterion(CriterionOutcome{
 			Name:        criterion.Name,
 			Description: criterion.Description,
 			Type:        criterion.Type,
 			Query:       criterion.Query,
 			Threshold:   criterion.Threshold,
 			Passed:      result.Passed,
+			Unknown:     result.Unknown,
 			Value:       result.LastValue,
 			Message:     result.Message,
 			Critical:    criterion.Critical,
-		})
+			Evaluations: result.Evaluations,
+			Failures:    result.Failures,
+			History:     result.History,
+		}, true)
 
 		if result.Passed {
 			fmt.Printf("    ✓ PASSED: %s\n", result.Message)
 		} else if criterion.Critical {
-			fmt.Printf("    ✗ FAILED (CRITICAL): %s\n", result.Message)
+			fmt.Printf("    ✗ %s (CRITICAL): %s\n", verdict(result), result.Message)
 			if criterion.Description != "" {
 				fmt.Printf("      → %s\n", criterion.Description)
 			}
-			criticalFailed = true
+			if o.undecided(result) {
+				criticalUnknown = true
+			} else {
+				criticalFailed = true
+			}
 		} else {
-			fmt.Printf("    ⚠ FAILED (non-critical): %s\n", result.Message)
+			fmt.Printf("    ⚠ %s (non-critical): %s\n", verdict(result), result.Message)
 			if criterion.Description != "" {
 				fmt.Printf("      → %s\n", criterion.Description)
 			}
@@ -1395,10 +2130,39 @@ func (o *Orchestrator) evaluateDuringFaultCriteria(ctx context.Context) error {
 	if criticalFailed {
 		return &CriteriaFailureError{Msg: "one or more critical during-fault criteria failed"}
 	}
+	if criticalUnknown {
+		return &CriteriaFailureError{Msg: "one or more critical during-fault criteria could not be judged", Unknown: true}
+	}
 
 	return nil
 }
 
+// applyUnknownPolicy counts a criterion the detector could not judge as
+// passed when the scenario sets treat_unknown_as: pass. Under "fail" and
+// "unknown" the result is left as not passed; undecided tells them apart.
+func (o *Orchestrator) applyUnknownPolicy(result *detector.CriterionResult) {
+	if !result.Unknown || result.Passed || o.scenario.Spec.UnknownPolicy() != scenario.UnknownAsPass {
+		return
+	}
+	result.Passed = true
+	result.Message = "unknown, treated as passed: " + result.Message
+}
+
+// undecided reports whether a result that did not pass should leave the run
+// UNKNOWN rather than FAILED, per treat_unknown_as.
+func (o *Orchestrator) undecided(result *detector.CriterionResult) bool {
+	return result.Unknown && o.scenario.Spec.UnknownPolicy() == scenario.UnknownAsUnknown
+}
+
+// verdict names a criterion that did not pass: FAILED, or UNKNOWN when the
+// detector could not judge it.
+func verdict(result *detector.CriterionResult) string {
+	if result.Unknown {
+		return "UNKNOWN"
+	}
+	return "FAILED"
+}
+
 // universalSafetyCriteria returns criteria that every chaos scenario should
 // evaluate regardless of what the YAML defines. These catch safety violations
 // (double-signing, deep reorgs, state divergence) that individual scenario
@@ -1408,13 +2172,19 @@ func (o *Orchestrator) evaluateDuringFaultCriteria(ctx context.Context) error {
 // and non-critical (informational — they surface problems without overriding
 // the scenario's own pass/fail logic). The one exception is byzantine
 // detection which is always critical: double-signing is never acceptable.
-func universalSafetyCriteria() []scenario.SuccessCriterion {
-	return []scenario.SuccessCriterion{
+// Job labels, container patterns and metric names come from the
+// deployment profile p.
+func universalSafetyCriteria(p *config.Profile) []scenario.SuccessCriterion {
+	if p.Stack == config.StackCDK {
+		return cdkSafetyCriteria(p)
+	}
+
+	criteria := []scenario.SuccessCriterion{
 		{
 			Name:          "[universal] no_byzantine_validators",
 			Description:   "No double-signing detected — any non-zero value is a critical safety violation",
 			Type:          "prometheus",
-			Query:         `max(cometbft_consensus_byzantine_validators{job=~"l2-cl-.*-heimdall-v2-bor-validator"}) or vector(0)`,
+			Query:         fmt.Sprintf(`max(%s_consensus_byzantine_validators{job=~"%s"}) or vector(0)`, p.ConsensusMetricPrefix, p.CLJobPattern),
 			Threshold:     "== 0",
 			Critical:      true,
 			PostFaultOnly: true,
@@ -1423,7 +2193,7 @@ func universalSafetyCriteria() []scenario.SuccessCriterion {
 			Name:          "[universal] reorg_depth_bounded",
 			Description:   "No deep chain reorganizations (> 20 blocks dropped) during or after chaos",
 			Type:          "prometheus",
-			Query:         `sum(increase(chain_reorg_drop{job=~"l2-el-.*-bor-heimdall-v2-validator"}[5m])) or vector(0)`,
+			Query:         fmt.Sprintf(`sum(increase(chain_reorg_drop{job=~"%s"}[5m])) or vector(0)`, p.ELJobPattern),
 			Threshold:     "< 20",
 			Critical:      false,
 			PostFaultOnly: true,
@@ -1436,7 +2206,7 @@ func universalSafetyCriteria() []scenario.SuccessCriterion {
 			Absence:       true,
 			Critical:      true,
 			PostFaultOnly: true,
-			ContainerPattern: "bor-heimdall-v2-validator",
+			ContainerPattern: p.ELContainerPattern,
 		},
 		{
 			Name:          "[universal] no_panic_or_consensus_failure_heimdall",
@@ -1446,7 +2216,7 @@ func universalSafetyCriteria() []scenario.SuccessCriterion {
 			Absence:       true,
 			Critical:      true,
 			PostFaultOnly: true,
-			ContainerPattern: "heimdall-v2-bor-validator",
+			ContainerPattern: p.CLContainerPattern,
 		},
 		{
 			Name:             "[universal] no_db_corruption_bor",
@@ -1456,7 +2226,7 @@ func universalSafetyCriteria() []scenario.SuccessCriterion {
 			Absence:          true,
 			Critical:         true,
 			PostFaultOnly:    true,
-			ContainerPattern: "bor-heimdall-v2-validator",
+			ContainerPattern: p.ELContainerPattern,
 		},
 		{
 			Name:             "[universal] no_db_corruption_heimdall",
@@ -1466,7 +2236,7 @@ func universalSafetyCriteria() []scenario.SuccessCriterion {
 			Absence:          true,
 			Critical:         true,
 			PostFaultOnly:    true,
-			ContainerPattern: "heimdall-v2-bor-validator",
+			ContainerPattern: p.CLContainerPattern,
 		},
 		{
 			Name:          "[universal] state_root_consensus",
@@ -1474,9 +2244,13 @@ func universalSafetyCriteria() []scenario.SuccessCriterion {
 			Type:          "state_root_consensus",
 			Critical:      true,
 			PostFaultOnly: true,
-			ContainerPattern: "bor-heimdall-v2-validator",
+			ContainerPattern: p.ELContainerPattern,
 		},
-		{
+	}
+
+	// heimdall v1 has no side-tx counters; the query would silently pass.
+	if p.HasSideTxMetrics {
+		criteria = append(criteria, scenario.SuccessCriterion{
 			Name:          "[universal] sidetx_consensus_healthy",
 			Description:   "Heimdall side-tx consensus failures remain low (< 20% of approvals)",
 			Type:          "prometheus",
@@ -1484,12 +2258,81 @@ func universalSafetyCriteria() []scenario.SuccessCriterion {
 			Threshold:     "< 0.2",
 			Critical:      false,
 			PostFaultOnly: true,
+		})
+	}
+
+	return criteria
+}
+
+// SafetyInvariants returns the Prometheus-based universal safety criteria
+// for profile p. Unlike log criteria they are meaningful at any instant, so
+// long-running modes (soak) can evaluate them continuously between runs.
+func SafetyInvariants(p *config.Profile) []scenario.SuccessCriterion {
+	var invariants []scenario.SuccessCriterion
+	for _, c := range universalSafetyCriteria(p) {
+		if c.Type == "prometheus" {
+			invariants = append(invariants, c)
+		}
+	}
+	return invariants
+}
+
+// cdkSafetyCriteria is the CDK counterpart of the PoS universal criteria:
+// no crash or database corruption in the sequencer, RPC or aggregator, and
+// the sequencer keeps producing L2 blocks once faults are removed.
+func cdkSafetyCriteria(p *config.Profile) []scenario.SuccessCriterion {
+	criteria := []scenario.SuccessCriterion{
+		{
+			Name:          "[universal] l2_blocks_advancing",
+			Description:   "The sequencer is producing L2 blocks again after chaos",
+			Type:          "prometheus",
+			Query:         fmt.Sprintf(`sum(increase(panoptichain_rpc_height{job=~".*%s.*"}[2m])) or vector(0)`, p.SequencerPattern),
+			Threshold:     "> 0",
+			Critical:      false,
+			PostFaultOnly: true,
 		},
 	}
+
+	components := []struct{ label, pattern string }{
+		{"sequencer", p.SequencerPattern},
+		{"rpc", p.RPCPattern},
+		{"aggregator", p.AggregatorPattern},
+	}
+	for _, c := range components {
+		criteria = append(criteria,
+			scenario.SuccessCriterion{
+				Name:             fmt.Sprintf("[universal] no_panic_%s", c.label),
+				Description:      fmt.Sprintf("No panic or fatal error in the CDK %s", c.label),
+				Type:             "log",
+				Pattern:          `(panic:|fatal:|FATAL)`,
+				Absence:          true,
+				Critical:         true,
+				PostFaultOnly:    true,
+				ContainerPattern: c.pattern,
+			},
+			scenario.SuccessCriterion{
+				Name:             fmt.Sprintf("[universal] no_db_corruption_%s", c.label),
+				Description:      fmt.Sprintf("No database corruption detected in the CDK %s", c.label),
+				Type:             "log",
+				Pattern:          `(corruption detected|MANIFEST.*error|mdbx.*(corrupt|panic))`,
+				Absence:          true,
+				Critical:         true,
+				PostFaultOnly:    true,
+				ContainerPattern: c.pattern,
+			},
+		)
+	}
+
+	return criteria
 }
 
 // executeDetect evaluates success criteria
 func (o *Orchestrator) executeDetect(ctx context.Context) error {
+	// Measured first so a criteria failure below still reports it.
+	if o.blastRadius = o.measureBlastRadius(ctx); o.blastRadius != nil {
+		printBlastRadius(o.blastRadius)
+	}
+
 	fmt.Println("Evaluating success criteria...")
 
 	// Inject universal safety criteria that apply to every scenario.
@@ -1499,7 +2342,7 @@ func (o *Orchestrator) executeDetect(ctx context.Context) error {
 	for _, c := range o.scenario.Spec.SuccessCriteria {
 		existing[c.Name] = true
 	}
-	for _, uc := range universalSafetyCriteria() {
+	for _, uc := range universalSafetyCriteria(o.cfg.ActiveProfile()) {
 		if !existing[uc.Name] {
 			o.scenario.Spec.SuccessCriteria = append(o.scenario.Spec.SuccessCriteria, uc)
 		}
@@ -1513,9 +2356,10 @@ func (o *Orchestrator) executeDetect(ctx context.Context) error {
 	// Check if any criteria need prometheus
 	hasPromCriteria := false
 	for _, c := range o.scenario.Spec.SuccessCriteria {
-		if c.Type == "prometheus" {
-			hasPromCriteria = true
-			break
+		for _, leaf := range c.Leaves() {
+			if leaf.Type == "prometheus" {
+				hasPromCriteria = true
+			}
 		}
 	}
 	if hasPromCriteria && (o.detector == nil || o.promClient == nil) {
@@ -1541,7 +2385,7 @@ func (o *Orchestrator) executeDetect(ctx context.Context) error {
 
 	// Evaluate each criterion
 	allPassed := true
-	criticalFailed := false
+	criticalFailed, criticalUnknown := false, false
 	var failedCritical []string
 
 	for i, criterion := range o.scenario.Spec.SuccessCriteria {
@@ -1552,36 +2396,49 @@ func (o *Orchestrator) executeDetect(ctx context.Context) error {
 
 		fmt.Printf("  [%d/%d] Evaluating: %s\n", i+1, len(o.scenario.Spec.SuccessCriteria), criterion.Name)
 
+		if err := o.waitGracePeriod(ctx, criterion); err != nil {
+			return err
+		}
+
 		result, err := o.detector.Evaluate(ctx, criterion)
 		if err != nil {
 			return fmt.Errorf("criteria query failed for %q: %w", criterion.Name, err)
 		}
+		o.applyUnknownPolicy(result)
 
 		// Store for the final report
-		o.criteriaResults = append(o.criteriaResults, CriterionOutcome{
+		o.recordCriterion(CriterionOutcome{
 			Name:        criterion.Name,
 			Description: criterion.Description,
 			Type:        criterion.Type,
 			Query:       criterion.Query,
 			Threshold:   criterion.Threshold,
 			Passed:      result.Passed,
+			Unknown:     result.Unknown,
 			Value:       result.LastValue,
 			Message:     result.Message,
 			Critical:    criterion.Critical,
-		})
+			Evaluations: result.Evaluations,
+			Failures:    result.Failures,
+			History:     result.History,
+		}, false)
 
 		if result.Passed {
 			fmt.Printf("    ✓ PASSED: %s\n", result.Message)
 		} else {
 			if criterion.Critical {
-				fmt.Printf("    ✗ FAILED (CRITICAL): %s\n", result.Message)
+				fmt.Printf("    ✗ %s (CRITICAL): %s\n", verdict(result), result.Message)
 				if criterion.Description != "" {
 					fmt.Printf("      → %s\n", criterion.Description)
 				}
-				criticalFailed = true
+				if o.undecided(result) {
+					criticalUnknown = true
+				} else {
+					criticalFailed = true
+				}
 				failedCritical = append(failedCritical, criterion.Name)
 			} else {
-				fmt.Printf("    ⚠ FAILED (non-critical): %s\n", result.Message)
+				fmt.Printf("    ⚠ %s (non-critical): %s\n", verdict(result), result.Message)
 				if criterion.Description != "" {
 					fmt.Printf("      → %s\n", criterion.Description)
 				}
@@ -1590,8 +2447,18 @@ func (o *Orchestrator) executeDetect(ctx context.Context) error {
 		}
 	}
 
+	// A detection slower than spec.detection.max_latency is a critical miss.
+	for _, outcome := range detectionOutcomes(o.detections, o.detectionMaxLatency()) {
+		o.recordCriterion(outcome, false)
+		if !outcome.Passed {
+			fmt.Printf("    ✗ FAILED (CRITICAL): %s: %s\n", outcome.Name, outcome.Message)
+			criticalFailed, allPassed = true, false
+			failedCritical = append(failedCritical, outcome.Name)
+		}
+	}
+
 	// Print a clear failure banner so the cause is visible above the log digest.
-	if criticalFailed {
+	if len(failedCritical) > 0 {
 		fmt.Printf("\n╔══ CRITICAL FAILURE ══════════════════════════════════════════════════╗\n")
 		for _, name := range failedCritical {
 			fmt.Printf("║  ✗ %s\n", name)
@@ -1608,6 +2475,9 @@ func (o *Orchestrator) executeDetect(ctx context.Context) error {
 	if criticalFailed {
 		return &CriteriaFailureError{Msg: "one or more critical success criteria failed"}
 	}
+	if criticalUnknown {
+		return &CriteriaFailureError{Msg: "one or more critical success criteria could not be judged", Unknown: true}
+	}
 
 	if allPassed {
 		fmt.Println("✓ All success criteria passed")
@@ -1637,6 +2507,9 @@ func (o *Orchestrator) collectAndPrintServiceLogs(ctx context.Context) {
 
 	var snapshots []*logcollector.ServiceLogSnapshot
 	for _, target := range o.targets {
+		if target.Agent != "" || target.Pod != nil {
+			continue // logs are read from the local daemon only
+		}
 		snap := o.logCollector.Snapshot(ctx, target.ContainerID, target.Name, since, 300)
 		if snap != nil {
 			snapshots = append(snapshots, snap)
@@ -1680,6 +2553,7 @@ func (o *Orchestrator) interruptibleSleep(ctx context.Context, duration time.Dur
 			if o.stopRequested.Load() {
 				return fmt.Errorf("interrupted by emergency stop")
 			}
+			o.writeInterim(false)
 			// Check if duration elapsed
 			if time.Now().After(deadline) {
 				return nil
@@ -1688,6 +2562,52 @@ func (o *Orchestrator) interruptibleSleep(ctx context.Context, duration time.Dur
 	}
 }
 
+// waitGracePeriod blocks until criterion.GracePeriod has elapsed since
+// teardown. Criteria are evaluated in order, so the deadline is absolute —
+// a later criterion with the same grace period does not wait again.
+//
+// For Prometheus criteria it then waits, for at most one more GracePeriod,
+// until every scrape target has a sample newer than teardown; a target that
+// is still stale after that is left for the criterion itself to judge.
+func (o *Orchestrator) waitGracePeriod(ctx context.Context, criterion scenario.SuccessCriterion) error {
+	if criterion.GracePeriod <= 0 || o.teardownTime.IsZero() {
+		return nil
+	}
+
+	deadline := o.teardownTime.Add(criterion.GracePeriod)
+	if wait := time.Until(deadline); wait > 0 {
+		fmt.Printf("      Waiting %s grace period after teardown\n", wait.Round(time.Second))
+		if err := o.interruptibleSleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+
+	hasProm := false
+	for _, leaf := range criterion.Leaves() {
+		if leaf.Type == "prometheus" {
+			hasProm = true
+		}
+	}
+	if !hasProm || o.promClient == nil {
+		return nil
+	}
+
+	freshDeadline := deadline.Add(criterion.GracePeriod)
+	for {
+		results, err := o.promClient.QueryLatest(ctx, "min(timestamp(up))")
+		if err == nil && len(results) > 0 && results[0].Value >= float64(o.teardownTime.Unix()) {
+			return nil
+		}
+		if time.Now().After(freshDeadline) {
+			fmt.Println("      ⚠ Some targets not scraped since teardown; evaluating anyway")
+			return nil
+		}
+		if err := o.interruptibleSleep(ctx, 2*time.Second); err != nil {
+			return err
+		}
+	}
+}
+
 // removeTrackedFaults iterates o.injectedFaults in reverse insertion order
 // and calls injector.RemoveFault for each entry. Returns the count of
 // successful removals. Errors are logged but not aggregated — a single
@@ -1707,36 +2627,67 @@ func (o *Orchestrator) interruptibleSleep(ctx context.Context, duration time.Dur
 // trigger a redundant outer-defer retry over the same entries.
 func (o *Orchestrator) removeTrackedFaults(ctx context.Context) int {
 	defer func() { o.injectedFaults = nil }()
+	o.stopCaptures(ctx)
 	removed := 0
 	for i := len(o.injectedFaults) - 1; i >= 0; i-- {
-		f := o.injectedFaults[i]
-		containerID := f.ContainerID
-		faultType := f.FaultType
-		// Find target name
-		targetName := containerID[:12]
-		for _, target := range o.targets {
-			if target.ContainerID == containerID {
-				targetName = target.Name
-				break
-			}
+		// Continue on failure — one removal failure must not leak the rest.
+		if o.removeInjectedFault(ctx, o.injectedFaults[i]) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// removeInjectedFault removes one installed fault, recording the outcome in
+// the audit log and the state file. It reports whether removal succeeded.
+func (o *Orchestrator) removeInjectedFault(ctx context.Context, f injectedFault) bool {
+	containerID := f.ContainerID
+	faultType := f.FaultType
+	// Find target name
+	targetName := containerID[:12]
+	for _, target := range o.targets {
+		if target.ContainerID == containerID {
+			targetName = target.Name
+			break
 		}
+	}
+	auditTarget := TargetInfo{Name: targetName, ContainerID: containerID}
 
-		fmt.Printf("  Removing %s fault from %s...\n", faultType, targetName)
+	fmt.Printf("  Removing %s fault from %s...\n", faultType, targetName)
 
-		if err := o.injector.RemoveFault(ctx, faultType, containerID); err != nil {
-			fmt.Printf("    ⚠ Error removing fault: %v\n", err)
-			// Continue — one removal failure must not leak the rest.
-		} else {
-			fmt.Printf("    ✓ Fault removed\n")
-			removed++
+	remove := func() error { return o.injector.RemoveFault(ctx, faultType, containerID) }
+	if f.resumed {
+		remove = func() error { return o.injector.RecoverFault(ctx, faultType, containerID, targetName, f.Params) }
+	}
+	if o.podFor(containerID) {
+		remove = func() error { return o.podInjector.RemoveFault(ctx, faultType, containerID) }
+	}
+	if a := o.agentFor(containerID); a != nil {
+		remove = func() error {
+			return a.RemoveFault(ctx, faultType, injection.Target{Name: targetName, ContainerID: containerID}, f.Params)
 		}
 	}
-	return removed
+	if err := remove(); err != nil {
+		fmt.Printf("    ⚠ Error removing fault: %v\n", err)
+		o.recordAudit(audit.ActionRemoveFailed, f.Phase, faultType, auditTarget, f.Params, err)
+		return false
+	}
+	fmt.Printf("    ✓ Fault removed\n")
+	for i, in := range o.injections[f.Phase] {
+		if in.ContainerID == containerID && in.Err == nil && in.RemovedAt.IsZero() {
+			o.injections[f.Phase][i].RemovedAt = time.Now()
+			break
+		}
+	}
+	o.recordAudit(audit.ActionRemove, f.Phase, faultType, auditTarget, f.Params, nil)
+	o.persist(func(sf *state.File) error { return sf.RemoveFault(containerID, faultType, f.Phase) })
+	return true
 }
 
 // executeTeardown removes all faults
 func (o *Orchestrator) executeTeardown(ctx context.Context) error {
 	fmt.Println("Tearing down faults...")
+	o.stopCollateralWatch()
 
 	if len(o.injectedFaults) == 0 {
 		fmt.Println("  No faults to remove")
@@ -1745,6 +2696,10 @@ func (o *Orchestrator) executeTeardown(ctx context.Context) error {
 		fmt.Printf("✓ Removed %d fault(s)\n", removed)
 	}
 
+	if o.cfg.Execution.VerifyEnclaveCleanup {
+		o.scanEnclaveResidue(ctx)
+	}
+
 	// Sidecar cleanup (cleanupCoord.CleanupAll) is intentionally NOT
 	// called here — Execute's outer deferred cleanup runs CleanupAll on
 	// every exit path (success, failure, panic, emergency stop), so
@@ -1757,10 +2712,17 @@ func (o *Orchestrator) executeTeardown(ctx context.Context) error {
 }
 
 
-// RequestStop requests the orchestrator to stop execution
+// RequestStop stops the orchestrator the way an emergency stop does: the
+// phase in progress is cancelled, teardown still runs, and ExecuteNext
+// refuses further runs.
 func (o *Orchestrator) RequestStop() {
 	fmt.Println("Stop requested!")
 	o.stopRequested.Store(true)
+	o.runMu.Lock()
+	if o.runCancel != nil {
+		o.runCancel()
+	}
+	o.runMu.Unlock()
 }
 
 // preFlightCleanup removes remnants from previous failed/interrupted tests
@@ -1777,9 +2739,17 @@ func (o *Orchestrator) preFlightCleanup(ctx context.Context) error {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
 
-	// Filter for chaos-sidecar containers
+	// Filter for chaos-sidecar containers, sparing the ones this
+	// orchestrator still tracks from a previous ExecuteNext run.
+	tracked := make(map[string]bool)
+	for _, id := range o.sidecarMgr.ListSidecars() {
+		tracked[id] = true
+	}
 	var sidecars []types.Container
 	for _, container := range allContainers {
+		if tracked[container.ID] {
+			continue
+		}
 		for _, name := range container.Names {
 			// Docker names start with "/" prefix
 			if len(name) > 0 && len(name) > 14 && name[1:14] == "chaos-sidecar" {
@@ -1820,9 +2790,159 @@ func (o *Orchestrator) preFlightCleanup(ctx context.Context) error {
 	return nil
 }
 
-// SetHeimdallAPI sets the Heimdall API endpoint URL for producer discovery.
+// SetHeimdallAPI sets the Heimdall API endpoint URL for producer discovery
+// and heimdall_api criteria.
 func (o *Orchestrator) SetHeimdallAPI(url string) {
 	o.heimdallAPI = url
+	if o.detector != nil {
+		o.detector.SetHeimdallAPI(url)
+	}
+}
+
+// SetExecTracer installs fn on the Docker and Kubernetes clients so every
+// container, pod and sidecar exec issued by injectors, verifiers and
+// cleanup is observed.
+func (o *Orchestrator) SetExecTracer(fn docker.ExecTracer) {
+	o.dockerClient.SetExecTracer(fn)
+	o.kube.SetExecTracer(k8s.ExecTracer(fn))
+}
+
+// openStateFile starts the crash-recovery state file for this run. A
+// previous warm run's file is replaced and the sidecars it kept move over,
+// unless it still lists faults that failed to come off.
+func (o *Orchestrator) openStateFile(scenarioName string) {
+	if prev := o.stateFile.Swap(nil); prev != nil && len(prev.Snapshot().Faults) == 0 {
+		if err := prev.Discard(); err != nil {
+			fmt.Printf("⚠ Failed to remove state file %s: %v\n", prev.Path(), err)
+		}
+	}
+	f, err := state.Create(o.cfg.Reporting.OutputDir, o.testID, scenarioName)
+	if err != nil {
+		fmt.Printf("⚠ Crash-recovery state disabled for this run: %v\n", err)
+		return
+	}
+	for targetID, sidecarID := range o.sidecarMgr.ListSidecars() {
+		if err := f.AddSidecar(targetID, sidecarID); err != nil {
+			fmt.Printf("⚠ Failed to update state file: %v\n", err)
+		}
+	}
+	o.stateFile.Store(f)
+}
+
+// closeStateFile removes the state file once nothing is left to clean up;
+// otherwise it stays for `chaos-runner recover`.
+func (o *Orchestrator) closeStateFile() {
+	f := o.stateFile.Load()
+	if f == nil {
+		return
+	}
+	if snap := f.Snapshot(); !snap.Empty() {
+		fmt.Printf("⚠ %d fault(s) and %d sidecar(s) may remain; run `chaos-runner recover` (state: %s)\n",
+			len(snap.Faults), len(snap.Sidecars), f.Path())
+		return
+	}
+	if err := f.Discard(); err != nil {
+		fmt.Printf("⚠ Failed to remove state file %s: %v\n", f.Path(), err)
+	}
+	o.stateFile.CompareAndSwap(f, nil)
+}
+
+// persist applies update to the state file, if any. Failures only warn:
+// the state file is a safety net, not a reason to abort the run.
+func (o *Orchestrator) persist(update func(*state.File) error) {
+	if f := o.stateFile.Load(); f != nil {
+		if err := update(f); err != nil {
+			fmt.Printf("⚠ Failed to update state file: %v\n", err)
+		}
+	}
+}
+
+// trackSidecar is the sidecar manager's tracker: it mirrors sidecar
+// creation and destruction into the state file.
+func (o *Orchestrator) trackSidecar(targetID, sidecarID string) {
+	o.persist(func(f *state.File) error {
+		if sidecarID == "" {
+			return f.RemoveSidecar(targetID)
+		}
+		return f.AddSidecar(targetID, sidecarID)
+	})
+}
+
+// SetAuditSink records every fault injected and removed to sink (see
+// pkg/audit). A nil sink (the default) records nothing.
+func (o *Orchestrator) SetAuditSink(sink audit.Sink) {
+	o.auditSink = sink
+}
+
+// recordAudit sends one fault event to the audit sink. A failed write is
+// printed, never fatal: losing the audit trail must not strand faults.
+func (o *Orchestrator) recordAudit(action, phase, faultType string, t TargetInfo, params map[string]interface{}, err error) {
+	if o.auditSink == nil {
+		return
+	}
+	ev := audit.Event{
+		Action:      action,
+		TestID:      o.testID,
+		Phase:       phase,
+		Fault:       faultType,
+		Target:      t.Name,
+		ContainerID: t.ContainerID,
+		Params:      scenario.RedactParams(params),
+	}
+	if o.scenario != nil {
+		ev.Scenario = o.scenario.Metadata.Name
+	}
+	if err != nil {
+		ev.Err = err.Error()
+	}
+	if werr := o.auditSink.Record(ev); werr != nil {
+		fmt.Printf("  ⚠ audit: %v\n", werr)
+	}
+}
+
+// SetNotifier posts run events to webhooks (see pkg/notify). A nil
+// notifier (the default) posts nothing.
+func (o *Orchestrator) SetNotifier(n *notify.Notifier) {
+	o.notifier = n
+}
+
+// sendNotification fills ev in with the running test and scen's
+// annotations and posts it.
+func (o *Orchestrator) sendNotification(ev notify.Event, scen *scenario.Scenario) {
+	if o.notifier == nil {
+		return
+	}
+	ev.TestID = o.testID
+	if scen != nil {
+		md := scen.Metadata
+		ev.Scenario, ev.Owner, ev.RunbookURL, ev.ExpectedImpact = md.Name, md.Owner, md.RunbookURL, md.ExpectedImpact
+	}
+	o.notifier.Notify(ev)
+}
+
+// recordApproval sends the confirmation of a destructive scenario, or its
+// rejection (err), to the audit sink.
+func (o *Orchestrator) recordApproval(ap *approval.Approval, err error) {
+	if o.auditSink == nil {
+		return
+	}
+	ev := audit.Event{Action: audit.ActionApprove, TestID: o.testID, Scenario: o.scenario.Metadata.Name}
+	if ap != nil {
+		ev.Approver, ev.Role, ev.Method, ev.TokenSHA256 = ap.Approver, ap.Role, ap.Method, ap.TokenSHA256
+	}
+	if err != nil {
+		ev.Action = audit.ActionApproveRejected
+		ev.Err = err.Error()
+	}
+	if werr := o.auditSink.Record(ev); werr != nil {
+		fmt.Printf("  ⚠ audit: %v\n", werr)
+	}
+}
+
+// SetGatekeeper enables GameDay gates. A nil gatekeeper (the default)
+// never pauses.
+func (o *Orchestrator) SetGatekeeper(g *gameday.Gatekeeper) {
+	o.gatekeeper = g
 }
 
 // resolveCurrentProducer queries the Heimdall API for the current block producer
@@ -1898,6 +3018,13 @@ func (o *Orchestrator) failTest(result *TestResult, err error) (*TestResult, err
 	result.EndTime = time.Now()
 	result.Duration = result.EndTime.Sub(result.StartTime)
 	result.State = StateFailed
+	if o.stopRequested.Load() {
+		result.State = StateInterrupted
+		var ie *InterruptedError
+		if !errors.As(err, &ie) {
+			err = &InterruptedError{Phase: o.currentState, Err: err}
+		}
+	}
 	result.Success = false
 	result.Message = err.Error()
 	result.Errors = append(result.Errors, err)
@@ -1905,6 +3032,31 @@ func (o *Orchestrator) failTest(result *TestResult, err error) (*TestResult, err
 	result.FaultCount = len(o.injectedFaults)
 	result.CriteriaResults = o.criteriaResults
 	result.FaultVerificationWarnings = o.faultVerificationWarnings
+	result.Environment = o.environment
+	result.Recoveries = o.injector.Recoveries()
+	result.AppliedMethods = o.injector.AppliedMethods()
+	// Faults are still installed here; stop their captures now so the
+	// pcaps make it into this result.
+	captureCtx, cancel := context.WithTimeout(context.Background(), captureStopTimeout)
+	o.stopCaptures(captureCtx)
+	cancel()
+	result.Captures = o.captureFiles
+	result.Injections = o.injections
+	o.stopCollateralWatch()
+	result.Collateral = o.collateral
+	result.EnclaveResidue = o.enclaveResidue
+	result.BlastRadius = o.blastRadius
+	o.markPhaseUsage(StateFailed)
+	result.RunnerUsage = o.phaseUsage
+	o.collectDetections()
+	result.Detections = o.detections
+	result.Approval = o.approval
+	printPhaseUsage(o.phaseUsage)
+	var cfe *CriteriaFailureError
+	result.Unknown = errors.As(err, &cfe) && cfe.Unknown
+	if o.stuckPhase != StateInit {
+		result.StuckPhase = o.stuckPhase.String()
+	}
 	return result, err
 }
 
@@ -1995,6 +3147,8 @@ func validateKurtosisEnclave(enclaveName string) error {
 		if msg == "" {
 			msg = err.Error()
 		}
+		// Lookups cached for the enclave are about one that is gone.
+		_ = config.ClearKurtosisCache(enclaveName)
 		return fmt.Errorf("Kurtosis enclave %q not found or not running: %s", enclaveName, msg)
 	}
 	return nil
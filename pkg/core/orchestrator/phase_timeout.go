@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// phaseAbandonGrace is how long past its timeout a phase may take to
+// notice its cancelled context before the orchestrator stops waiting for
+// it. Docker SDK calls normally honour ctx; a wedged daemon socket may not.
+const phaseAbandonGrace = 10 * time.Second
+
+// forceCleanupTimeout bounds the sidecar sweep run on a forced exit,
+// after emergency.grace_period has already expired.
+const forceCleanupTimeout = 20 * time.Second
+
+// stuckCleanupTimeout bounds the abort-path cleanup after a stuck phase,
+// since the same wedged daemon is likely to hang cleanup calls too.
+const stuckCleanupTimeout = 2 * time.Minute
+
+// PhaseTimeoutError reports a phase that exceeded its configured timeout.
+type PhaseTimeoutError struct {
+	Phase   TestState
+	Timeout time.Duration
+	// Abandoned is true when the phase did not return even after its
+	// context was cancelled and is still running in the background.
+	Abandoned bool
+}
+
+func (e *PhaseTimeoutError) Error() string {
+	if e.Abandoned {
+		return fmt.Sprintf("phase %s stuck: no progress within %s and did not respond to cancellation", e.Phase, e.Timeout)
+	}
+	return fmt.Sprintf("phase %s timed out after %s", e.Phase, e.Timeout)
+}
+
+// phaseTimeout returns the configured timeout for state, or 0 for none.
+// Keys in execution.phase_timeouts are case-insensitive state names.
+func (o *Orchestrator) phaseTimeout(state TestState) time.Duration {
+	for name, d := range o.cfg.Execution.PhaseTimeouts {
+		if strings.EqualFold(name, state.String()) {
+			return d
+		}
+	}
+	return 0
+}
+
+// runPhase runs fn under the phase's timeout. If fn does not return within
+// the timeout plus phaseAbandonGrace, runPhase returns without it and
+// records the phase as stuck; the deferred abort-path cleanup in Execute
+// then runs with its own bounded context.
+func (o *Orchestrator) runPhase(ctx context.Context, state TestState, fn func(context.Context) error) error {
+	timeout := o.phaseTimeout(state)
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	phaseCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- fn(phaseCtx) }()
+
+	select {
+	case err := <-done:
+		if err != nil && errors.Is(phaseCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			o.stuckPhase = state
+			fmt.Printf("  ✗ Phase %s exceeded its %s timeout\n", state, timeout)
+			return fmt.Errorf("%w: %v", &PhaseTimeoutError{Phase: state, Timeout: timeout}, err)
+		}
+		return err
+	case <-time.After(timeout + phaseAbandonGrace):
+		o.stuckPhase = state
+		fmt.Printf("  ✗ Phase %s stuck for %s — abandoning it and forcing cleanup\n", state, timeout+phaseAbandonGrace)
+		return &PhaseTimeoutError{Phase: state, Timeout: timeout, Abandoned: true}
+	}
+}
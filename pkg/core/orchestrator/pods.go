@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// listPodCandidates lists the running pods a kubernetes_pod selector can
+// match: those of its namespace carrying its labels. Pods are named by
+// pod name and identified by UID.
+func (o *Orchestrator) listPodCandidates(ctx context.Context, sel scenario.TargetSelector) ([]candidate, error) {
+	pods, err := o.kube.ListPods(ctx, sel.Namespace, sel.Labels)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]candidate, 0, len(pods))
+	for i := range pods {
+		candidates = append(candidates, candidate{id: pods[i].UID, names: []string{pods[i].Name}, ip: pods[i].IP, pod: &pods[i]})
+	}
+	return candidates, nil
+}
+
+// podFor reports whether containerID is a Kubernetes pod target.
+func (o *Orchestrator) podFor(containerID string) bool {
+	for _, t := range o.targets {
+		if t.ContainerID == containerID && t.Pod != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// byBackend splits off the Kubernetes pod targets from the containers,
+// which byAgent splits further.
+func byBackend(targets []TargetInfo) (containers, pods []TargetInfo) {
+	for _, t := range targets {
+		if t.Pod != nil {
+			pods = append(pods, t)
+		} else {
+			containers = append(containers, t)
+		}
+	}
+	return containers, pods
+}
+
+// preparePodTargets adds a debug container to every pod target; it is the
+// pod's sidecar for the rest of the run. Chaos state a previous run left
+// in the pod is cleared through it.
+func (o *Orchestrator) preparePodTargets(ctx context.Context, pods []TargetInfo) error {
+	for _, t := range pods {
+		o.podSidecars.AddPod(*t.Pod)
+		fmt.Printf("  Adding debug container to pod %s/%s...\n", t.Pod.Namespace, t.Name)
+		name, err := o.podSidecars.CreateSidecar(ctx, t.ContainerID)
+		if err != nil {
+			return fmt.Errorf("failed to create sidecar for %s: %w", t.Name, err)
+		}
+		fmt.Printf("    ✓ Debug container running: %s\n", name)
+		if err := o.clearPodArtifacts(ctx, t); err != nil {
+			fmt.Printf("    ⚠ Failed to clear chaos artifacts on %s: %v\n", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// clearPodArtifacts removes whatever chaos state is in the pod's network
+// namespace, through its debug container.
+func (o *Orchestrator) clearPodArtifacts(ctx context.Context, t TargetInfo) error {
+	artifacts, err := o.podVerifier.ListChaosArtifacts(ctx, t.ContainerID)
+	if err != nil {
+		return err
+	}
+	cleanup := artifacts.CleanupCommands()
+	if len(cleanup) == 0 {
+		return nil
+	}
+	fmt.Printf("    Clearing chaos artifacts on %s (%s)\n", t.Name, artifacts)
+	var errs []error
+	for _, cmd := range cleanup {
+		if _, err := o.podSidecars.ExecInSidecar(ctx, t.ContainerID, cmd); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", strings.Join(cmd, " "), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cleanupPods clears the chaos state still in this run's pod targets and
+// stops their debug containers; once a debug container is gone nothing
+// can reach the pod's namespace any more. Like agents, pods keep no
+// sidecars between runs.
+func (o *Orchestrator) cleanupPods(ctx context.Context) {
+	for _, t := range o.targets {
+		if t.Pod == nil {
+			continue
+		}
+		if _, ok := o.podSidecars.GetSidecarID(t.ContainerID); !ok {
+			continue
+		}
+		if err := o.clearPodArtifacts(ctx, t); err != nil {
+			fmt.Printf("⚠ Pod cleanup on %s: %v\n", t.Name, err)
+		}
+	}
+	if err := o.podSidecars.DestroyAll(ctx); err != nil {
+		fmt.Printf("⚠ Pod cleanup: %v\n", err)
+	}
+}
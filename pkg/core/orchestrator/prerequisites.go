@@ -0,0 +1,145 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// UnsupportedFaultError reports a fault that cannot run on a target because
+// the target container or its sidecar lacks a prerequisite. It is raised
+// before anything is injected, in place of an exec failure mid-test.
+type UnsupportedFaultError struct {
+	Phase   string
+	Type    string
+	Target  string
+	Missing []string
+}
+
+func (e *UnsupportedFaultError) Error() string {
+	return fmt.Sprintf("unsupported fault %q (%s) on target %s: missing %s",
+		e.Phase, e.Type, e.Target, strings.Join(e.Missing, "; "))
+}
+
+// faultSidecarTools lists binaries a fault executes in the target's
+// sidecar. The default sidecar image ships all of them; a custom
+// docker.sidecar_image may not.
+var faultSidecarTools = map[string][]string{
+	"network":           {"tc"},
+	"dns":               {"tc"},
+	"connection_drop":   {"iptables"},
+	"network_partition": {"iptables"},
+	"scrape_block":      {"iptables"},
+	"drain":             {"iptables"},
+	"http_fault":        {"iptables", "envoy"},
+	"corruption_proxy":  {"iptables", "corruption-proxy"},
+	"txpool_saturation": {"chaos-txflood"},
+}
+
+// hostCgroupMount is where the sidecar sees the host cgroup tree.
+const hostCgroupMount = "/host/sys/fs/cgroup"
+
+// targetPrerequisites returns what the target container itself lacks to
+// run a fault of the given type: binaries on PATH and, for clock_skew,
+// CAP_SYS_TIME.
+func (o *Orchestrator) targetPrerequisites(ctx context.Context, faultType, containerID string) []string {
+	var missing []string
+	for _, tool := range o.missingTools(ctx, containerID, faultTargetTools[faultType]) {
+		missing = append(missing, tool+" in the target container")
+	}
+	if faultType == "clock_skew" && !o.hasCapability(ctx, containerID, "SYS_TIME") {
+		missing = append(missing, "CAP_SYS_TIME on the target container (or privileged), needed by date -s")
+	}
+	return missing
+}
+
+// sidecarPrerequisites returns what the target's sidecar lacks to run the
+// fault: binaries, NET_ADMIN for namespace faults, and for memory_stress
+// method "stress", stress-ng plus a view of the host cgroup hierarchy
+// matching the daemon's cgroup version. cpu_stress is not checked because
+// it falls back to busy loops in the target.
+func (o *Orchestrator) sidecarPrerequisites(ctx context.Context, fault scenario.Fault, faultType, sidecarID string, platform *docker.DaemonPlatform) []string {
+	tools := faultSidecarTools[faultType]
+	stressNG := faultType == "memory_stress" && fault.Params["method"] == "stress"
+	if stressNG {
+		tools = append(tools, "stress-ng")
+	}
+
+	var missing []string
+	for _, tool := range o.missingTools(ctx, sidecarID, tools) {
+		missing = append(missing, fmt.Sprintf("%s in sidecar image %s", tool, o.cfg.Docker.SidecarImage))
+	}
+	if len(faultSidecarTools[faultType]) > 0 && !o.hasCapability(ctx, sidecarID, "NET_ADMIN") {
+		missing = append(missing, "CAP_NET_ADMIN on the sidecar")
+	}
+
+	if stressNG && platform != nil {
+		// stressNGRunnerScript joins the target's cgroup through this
+		// mount; which file proves it usable depends on the version.
+		probe, want := hostCgroupMount+"/memory", "cgroup v1 memory controller"
+		if platform.CgroupVersion == "2" {
+			probe, want = hostCgroupMount+"/cgroup.controllers", "cgroup v2 unified hierarchy"
+		}
+		if _, err := o.dockerClient.ExecCommand(ctx, sidecarID, []string{"test", "-e", probe}); err != nil {
+			missing = append(missing, fmt.Sprintf("%s at %s in the sidecar", want, hostCgroupMount))
+		}
+	}
+	return missing
+}
+
+// checkInjectPrerequisites verifies, after PREPARE has created sidecars,
+// that every fault's targets and sidecars have what the fault needs. All
+// unsupported fault/target pairs are reported together.
+func (o *Orchestrator) checkInjectPrerequisites(ctx context.Context, faults []scenario.Fault, targets [][]TargetInfo) error {
+	platform, err := o.dockerClient.DaemonPlatform(ctx)
+	if err != nil {
+		platform = nil // cgroup checks are skipped; the rest still run
+	}
+
+	var errs []error
+	for i, fault := range faults {
+		info, ok := scenario.LookupFaultType(fault.Type)
+		if !ok {
+			continue
+		}
+		for _, t := range targets[i] {
+			// Not checked on an agent's host; a missing tool there fails
+			// the inject instead.
+			if t.Agent != "" {
+				continue
+			}
+			// Pods take only faults confined to their network namespace;
+			// the validator rejects the rest before a run starts.
+			if t.Pod != nil {
+				if !info.Pods {
+					errs = append(errs, &UnsupportedFaultError{
+						Phase:   fault.Phase,
+						Type:    fault.Type,
+						Target:  t.Name,
+						Missing: []string{"support for Kubernetes pods"},
+					})
+				}
+				continue
+			}
+			missing := o.targetPrerequisites(ctx, info.Name, t.ContainerID)
+			if sidecarID, ok := o.sidecarMgr.GetSidecarID(t.ContainerID); ok {
+				missing = append(missing, o.sidecarPrerequisites(ctx, fault, info.Name, sidecarID, platform)...)
+			} else if info.UsesSidecar {
+				missing = append(missing, "a sidecar (none was created in PREPARE)")
+			}
+			if len(missing) > 0 {
+				errs = append(errs, &UnsupportedFaultError{
+					Phase:   fault.Phase,
+					Type:    fault.Type,
+					Target:  t.Name,
+					Missing: missing,
+				})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
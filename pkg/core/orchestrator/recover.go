@@ -0,0 +1,210 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/core/cleanup"
+	"github.com/jihwankim/chaos-utils/pkg/core/state"
+	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
+	"github.com/jihwankim/chaos-utils/pkg/injection"
+	"github.com/jihwankim/chaos-utils/pkg/injection/sidecar"
+)
+
+// RecoverResult is the outcome of recovering one orphaned state file.
+type RecoverResult struct {
+	Path     string
+	TestID   string
+	Scenario string
+	// Skipped explains why the file was left alone (its runner is still
+	// alive, or this was a dry run).
+	Skipped string
+	// FaultsRemoved counts faults removed or found moot because their
+	// container no longer exists.
+	FaultsRemoved   int
+	SidecarsRemoved int
+	// Remaining is what could not be cleaned; it is written back to the
+	// state file for another attempt.
+	Remaining state.State
+	Errors    []error
+}
+
+// Recover completes cleanup for runs that left state files under dir: it
+// adopts their surviving sidecars, removes their recorded faults in
+// reverse order, then destroys the sidecars. A state file whose runner
+// process is still alive is skipped unless force is set. With dryRun,
+// files are only listed.
+func Recover(ctx context.Context, cfg *config.Config, dir string, force, dryRun bool) ([]RecoverResult, error) {
+	paths, err := state.Find(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s for state files: %w", dir, err)
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	dockerClient, err := docker.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer dockerClient.Close()
+
+	results := make([]RecoverResult, 0, len(paths))
+	for _, path := range paths {
+		s, err := state.Load(path)
+		if err != nil {
+			results = append(results, RecoverResult{Path: path, Errors: []error{err}})
+			continue
+		}
+		res := RecoverResult{Path: path, TestID: s.TestID, Scenario: s.Scenario, Remaining: *s}
+		switch {
+		case s.Live() && !force:
+			res.Skipped = fmt.Sprintf("runner pid %d is still running", s.PID)
+		case dryRun:
+			res.Skipped = "dry run"
+		default:
+			recoverState(ctx, cfg, dockerClient, s, &res)
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// recoverState cleans up one state with a fresh sidecar manager and
+// injector, so nothing from another run's tracking leaks in. Faults are
+// removed with RecoverFault, which works from their persisted params; one
+// that is not recoverable stays in the state file. Faults on targets
+// reached through a chaos-agent are removed through that agent.
+func recoverState(ctx context.Context, cfg *config.Config, dockerClient *docker.Client, s *state.State, res *RecoverResult) {
+	sidecarMgr := sidecar.New(dockerClient, cfg.Docker.SidecarImage)
+	injector := injection.New(sidecarMgr, dockerClient)
+	coord := cleanup.New(sidecarMgr)
+
+	for _, sc := range s.Sidecars {
+		if ctr, err := dockerClient.ContainerInspect(ctx, sc.SidecarID); err == nil && ctr.State != nil && ctr.State.Running {
+			sidecarMgr.Adopt(sc.TargetID, sc.SidecarID)
+		}
+	}
+
+	agents, err := dialAgents(cfg.Agents)
+	if err != nil {
+		res.Errors = append(res.Errors, err)
+	}
+	defer func() {
+		for _, c := range agents {
+			c.Close()
+		}
+	}()
+	onAgent := make(map[string]string)
+	for _, t := range s.Targets {
+		if t.Agent != "" {
+			onAgent[t.ContainerID] = t.Agent
+		}
+	}
+	usedAgents := make(map[string]bool)
+
+	var remaining []state.Fault
+	for i := len(s.Faults) - 1; i >= 0; i-- {
+		f := s.Faults[i]
+		if name, ok := onAgent[f.ContainerID]; ok {
+			// The agent recovers the fault from its params if it no
+			// longer remembers installing it.
+			a, ok := agents[name]
+			if !ok {
+				err := fmt.Errorf("remove %s from %s: agent %s is not configured", f.Type, faultTarget(f), name)
+				fmt.Printf("  ✗ %v\n", err)
+				res.Errors = append(res.Errors, err)
+				remaining = append([]state.Fault{f}, remaining...)
+				continue
+			}
+			usedAgents[name] = true
+			if err := a.RemoveFault(ctx, f.Type, injection.Target{Name: f.Target, ContainerID: f.ContainerID}, f.Params); err != nil {
+				fmt.Printf("  ✗ %s on %s: %v\n", f.Type, faultTarget(f), err)
+				res.Errors = append(res.Errors, fmt.Errorf("remove %s from %s: %w", f.Type, faultTarget(f), err))
+				remaining = append([]state.Fault{f}, remaining...)
+				continue
+			}
+			fmt.Printf("  ✓ removed %s from %s via agent %s\n", f.Type, faultTarget(f), name)
+			res.FaultsRemoved++
+			continue
+		}
+		ctr, err := dockerClient.ContainerInspect(ctx, f.ContainerID)
+		if errdefs.IsNotFound(err) {
+			// The container is gone, and the fault with it.
+			fmt.Printf("  %s on %s: container gone, nothing to remove\n", f.Type, faultTarget(f))
+			res.FaultsRemoved++
+			continue
+		}
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Errorf("inspect %s: %w", faultTarget(f), err))
+			remaining = append([]state.Fault{f}, remaining...)
+			continue
+		}
+		// Removal of namespace faults runs through a sidecar; the old
+		// one may have died with the runner.
+		if _, ok := sidecarMgr.GetSidecarID(f.ContainerID); !ok && ctr.State != nil && ctr.State.Running {
+			if _, err := sidecarMgr.CreateSidecar(ctx, f.ContainerID); err != nil {
+				fmt.Printf("  ⚠ no sidecar for %s: %v\n", faultTarget(f), err)
+			}
+		}
+		if err := injector.RecoverFault(ctx, f.Type, f.ContainerID, f.Target, f.Params); err != nil {
+			if errors.Is(err, injection.ErrNotRecoverable) {
+				fmt.Printf("  ⚠ %s on %s: %v\n", f.Type, faultTarget(f), err)
+			} else {
+				fmt.Printf("  ✗ %s on %s: %v\n", f.Type, faultTarget(f), err)
+			}
+			res.Errors = append(res.Errors, fmt.Errorf("remove %s from %s: %w", f.Type, faultTarget(f), err))
+			remaining = append([]state.Fault{f}, remaining...)
+			continue
+		}
+		fmt.Printf("  ✓ removed %s from %s\n", f.Type, faultTarget(f))
+		res.FaultsRemoved++
+	}
+
+	// The run's sidecars on agent hosts go with the agents' own cleanup.
+	for name := range usedAgents {
+		if _, errs, err := agents[name].Cleanup(ctx); err != nil {
+			res.Errors = append(res.Errors, err)
+		} else {
+			for _, e := range errs {
+				res.Errors = append(res.Errors, fmt.Errorf("agent %s: %s", name, e))
+			}
+		}
+	}
+
+	adopted := len(sidecarMgr.ListSidecars())
+	if err := coord.CleanupAll(ctx); err != nil {
+		res.Errors = append(res.Errors, err)
+	}
+	left := sidecarMgr.ListSidecars()
+	res.SidecarsRemoved = adopted - len(left)
+
+	res.Remaining.Faults = remaining
+	res.Remaining.Sidecars = nil
+	for targetID, sidecarID := range left {
+		res.Remaining.Sidecars = append(res.Remaining.Sidecars, state.Sidecar{TargetID: targetID, SidecarID: sidecarID})
+	}
+
+	if res.Remaining.Empty() {
+		if err := state.Remove(res.Path); err != nil {
+			res.Errors = append(res.Errors, err)
+		}
+		return
+	}
+	if err := state.Save(res.Path, &res.Remaining); err != nil {
+		res.Errors = append(res.Errors, err)
+	}
+}
+
+func faultTarget(f state.Fault) string {
+	if f.Target != "" {
+		return f.Target
+	}
+	if len(f.ContainerID) > 12 {
+		return f.ContainerID[:12]
+	}
+	return f.ContainerID
+}
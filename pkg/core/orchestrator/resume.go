@@ -0,0 +1,200 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/core/state"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// checkpoint is a crashed run's state file, for Resume.
+type checkpoint struct {
+	path  string
+	state *state.State
+}
+
+// Resume continues run testID, which a crashed or killed runner left
+// behind, from the checkpoint in its state file. scen must be the scenario
+// that run executed, with the same overrides. A run that stopped during
+// INJECT or MONITOR with monitoring time left goes on monitoring for the
+// rest of it; any other goes straight to TEARDOWN. Either way it ends with
+// DETECT and a report under the original test ID. A run that stopped
+// before INJECT has nothing to resume: its sidecars are cleaned up and an
+// error is returned.
+func (o *Orchestrator) Resume(ctx context.Context, scen *scenario.Scenario, scenarioPath, testID string) (*TestResult, error) {
+	defer o.emergencyCancel()
+	cp, err := o.loadCheckpoint(testID, scen)
+	if err != nil {
+		return nil, err
+	}
+	return o.execute(ctx, scen, scenarioPath, false, cp)
+}
+
+// loadCheckpoint reads testID's state file and checks the run can be
+// resumed: its runner is gone, it had not ended, and it ran scen.
+func (o *Orchestrator) loadCheckpoint(testID string, scen *scenario.Scenario) (*checkpoint, error) {
+	path := filepath.Join(o.cfg.Reporting.OutputDir, testID, state.FileName)
+	s, err := state.Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no state for run %s in %s: it ended cleanly or was recovered", testID, o.cfg.Reporting.OutputDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.Live() {
+		return nil, fmt.Errorf("run %s is still running (pid %d)", testID, s.PID)
+	}
+	if s.Scenario != scen.Metadata.Name {
+		return nil, fmt.Errorf("run %s ran scenario %q, not %q", testID, s.Scenario, scen.Metadata.Name)
+	}
+	switch s.RunState {
+	case StateReport.String(), StateCompleted.String(), StateFailed.String(), StateInterrupted.String():
+		return nil, fmt.Errorf("run %s already ended (%s); use `chaos-runner recover` to clean up what it left", testID, s.RunState)
+	}
+	return &checkpoint{path: path, state: s}, nil
+}
+
+// reopenStateFile takes over the crashed run's state file and adopts the
+// sidecars it lists that are still running, so faults can be removed
+// through them and pre-flight cleanup spares them.
+func (o *Orchestrator) reopenStateFile(ctx context.Context, cp *checkpoint) {
+	for _, sc := range cp.state.Sidecars {
+		if ctr, err := o.dockerClient.ContainerInspect(ctx, sc.SidecarID); err == nil && ctr.State != nil && ctr.State.Running {
+			o.sidecarMgr.Adopt(sc.TargetID, sc.SidecarID)
+		}
+	}
+	f, err := state.Open(cp.path)
+	if err != nil {
+		fmt.Printf("⚠ Crash-recovery state disabled for this run: %v\n", err)
+		return
+	}
+	// Sidecars that died with the runner are gone; forget them.
+	adopted := o.sidecarMgr.ListSidecars()
+	for _, sc := range cp.state.Sidecars {
+		if _, ok := adopted[sc.TargetID]; !ok {
+			if err := f.RemoveSidecar(sc.TargetID); err != nil {
+				fmt.Printf("⚠ Failed to update state file: %v\n", err)
+			}
+		}
+	}
+	o.stateFile.Store(f)
+}
+
+// resumeTransitions validates the scenario as PARSE does, restores the
+// run from the checkpoint and walks the state table from where it
+// resumes.
+func (o *Orchestrator) resumeTransitions(ctx context.Context, table map[TestState]transition, cp *checkpoint) error {
+	o.transitionState(StateParse)
+	if err := table[StateParse].run(ctx); err != nil {
+		return err
+	}
+	from, err := o.restoreCheckpoint(ctx, cp.state)
+	if err != nil {
+		return err
+	}
+	return o.runTransitions(ctx, table, from)
+}
+
+// restoreCheckpoint rebuilds the run's targets, fault window and installed
+// faults from s, and returns the state to resume from.
+func (o *Orchestrator) restoreCheckpoint(ctx context.Context, s *state.State) (TestState, error) {
+	fmt.Printf("Resuming run %s, stopped in %s\n", s.TestID, s.RunState)
+	if s.InjectedAt.IsZero() {
+		return StateInit, fmt.Errorf("run %s stopped in %s before any fault was injected; nothing to resume, run the scenario again", s.TestID, s.RunState)
+	}
+
+	for _, t := range s.Targets {
+		o.targets = append(o.targets, TargetInfo{Alias: t.Alias, Name: t.Name, ContainerID: t.ContainerID, IP: t.IP, Agent: t.Agent})
+	}
+	o.environment = o.collectEnvironment(ctx)
+	o.resolveMetricAliases(ctx)
+
+	o.injectTime = s.InjectedAt
+	startAfter := map[string]time.Duration{}
+	for _, f := range o.scenario.Spec.Faults {
+		startAfter[f.Phase] = f.StartAfter
+	}
+	for _, f := range s.Faults {
+		o.injectedFaults = append(o.injectedFaults, injectedFault{ContainerID: f.ContainerID, FaultType: f.Type, Phase: f.Phase, Params: f.Params, resumed: true})
+		o.injections[f.Phase] = append(o.injections[f.Phase], TargetInjection{Target: f.Target, ContainerID: f.ContainerID, InstalledAt: s.InjectedAt.Add(startAfter[f.Phase])})
+	}
+	fmt.Printf("  %d target(s), %d fault(s) still installed, injected %s ago\n",
+		len(o.targets), len(o.injectedFaults), time.Since(s.InjectedAt).Round(time.Second))
+
+	elapsed := time.Since(s.InjectedAt)
+	remaining := o.scenario.Spec.Duration - elapsed
+	if (s.RunState == StateInject.String() || s.RunState == StateMonitor.String()) && remaining > 0 {
+		if err := o.resumeTimeline(elapsed); err != nil {
+			return StateInit, err
+		}
+		o.resumeMonitor = remaining
+		o.startCollateralWatch(ctx)
+		o.startFaultWindowWatch(ctx)
+		fmt.Printf("  Continuing MONITOR for the remaining %s\n", remaining.Round(time.Second))
+		return StateMonitor, nil
+	}
+
+	// The fault window passed while no runner watched it: during_fault
+	// criteria have no samples to judge.
+	for _, c := range o.scenario.Spec.SuccessCriteria {
+		if c.DuringFault {
+			o.recordCriterion(CriterionOutcome{
+				Name:        c.Name,
+				Description: c.Description,
+				Type:        c.Type,
+				Query:       c.Query,
+				Threshold:   c.Threshold,
+				Unknown:     true,
+				Message:     "not evaluated: the run was resumed after the fault window",
+				Critical:    c.Critical,
+			}, true)
+		}
+	}
+	fmt.Println("  Monitoring time is over; going straight to TEARDOWN")
+	return StateTeardown, nil
+}
+
+// resumeTimeline reschedules what was still due on the MONITOR timeline
+// elapsed after injection: the removal of each fault with a duration that
+// is still installed, and the start of each start_after fault not yet
+// injected. A start that fell due while no runner was running is skipped.
+func (o *Orchestrator) resumeTimeline(elapsed time.Duration) error {
+	jobs, err := o.faultJobs()
+	if err != nil {
+		return err
+	}
+	installed := map[string]bool{}
+	for _, f := range o.injectedFaults {
+		installed[f.Phase] = true
+	}
+	for _, job := range jobs {
+		switch {
+		case installed[job.fault.Phase]:
+			if job.fault.Duration > 0 {
+				o.schedule(timelineEntry{at: job.fault.StartAfter + job.fault.Duration, job: job, remove: true})
+			}
+		case job.fault.StartAfter > elapsed:
+			o.schedule(timelineEntry{at: job.fault.StartAfter, job: job})
+			if job.fault.Duration > 0 {
+				o.schedule(timelineEntry{at: job.fault.StartAfter + job.fault.Duration, job: job, remove: true})
+			}
+		default:
+			fmt.Printf("  ⚠ %s was due while the runner was down — not injected\n", job.fault.Phase)
+		}
+	}
+	return nil
+}
+
+// persistTargets checkpoints the discovered targets.
+func (o *Orchestrator) persistTargets() {
+	targets := make([]state.Target, len(o.targets))
+	for i, t := range o.targets {
+		targets[i] = state.Target{Alias: t.Alias, Name: t.Name, ContainerID: t.ContainerID, IP: t.IP, Agent: t.Agent}
+	}
+	o.persist(func(f *state.File) error { return f.SetTargets(targets) })
+}
@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/core/state"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+func TestLoadCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	o := &Orchestrator{cfg: &config.Config{}}
+	o.cfg.Reporting.OutputDir = dir
+	scen := &scenario.Scenario{Metadata: scenario.Metadata{Name: "demo"}}
+
+	write := func(testID string, pid int, runState string) {
+		t.Helper()
+		f, err := state.Create(dir, testID, "demo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := f.Snapshot()
+		s.PID, s.RunState = pid, runState
+		if err := state.Save(f.Path(), &s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("crashed", -1, "MONITOR")
+	write("live", os.Getpid(), "MONITOR")
+	write("ended", -1, "COMPLETED")
+
+	if cp, err := o.loadCheckpoint("crashed", scen); err != nil || cp.state.RunState != "MONITOR" {
+		t.Errorf("crashed run: %v, %v", cp, err)
+	}
+	for _, tt := range []struct {
+		testID string
+		scen   string
+		want   string
+	}{
+		{"missing", "demo", "no state"},
+		{"live", "demo", "still running"},
+		{"ended", "demo", "already ended"},
+		{"crashed", "other", "ran scenario"},
+	} {
+		_, err := o.loadCheckpoint(tt.testID, &scenario.Scenario{Metadata: scenario.Metadata{Name: tt.scen}})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("loadCheckpoint(%s, %s) = %v, want %q", tt.testID, tt.scen, err, tt.want)
+		}
+	}
+}
+
+func TestResumeTimeline(t *testing.T) {
+	o := &Orchestrator{
+		scenario: &scenario.Scenario{Spec: scenario.ScenarioSpec{Faults: []scenario.Fault{
+			{Phase: "installed", Target: "a", Type: "network", Duration: 5 * time.Minute},
+			{Phase: "pending", Target: "a", Type: "network", StartAfter: 4 * time.Minute, Duration: time.Minute},
+			{Phase: "missed", Target: "a", Type: "network", StartAfter: time.Minute},
+		}}},
+		targets:        []TargetInfo{{Alias: "a", Name: "a-1", ContainerID: "c1"}},
+		injectedFaults: []injectedFault{{ContainerID: "c1", FaultType: "network", Phase: "installed", resumed: true}},
+	}
+
+	if err := o.resumeTimeline(2 * time.Minute); err != nil {
+		t.Fatalf("resumeTimeline: %v", err)
+	}
+	var got []string
+	for _, e := range o.timeline {
+		action := "start"
+		if e.remove {
+			action = "remove"
+		}
+		got = append(got, e.job.fault.Phase+" "+action+" "+e.at.String())
+	}
+	want := []string{"pending start 4m0s", "installed remove 5m0s", "pending remove 5m0s"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("timeline = %v, want %v", got, want)
+	}
+}
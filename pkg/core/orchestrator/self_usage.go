@@ -0,0 +1,99 @@
+package orchestrator
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// PhaseUsage is the runner's own resource consumption during one phase.
+// It exists to spot the observer effect: a runner that burns CPU or
+// hammers the Docker daemon on the same host perturbs the enclave it is
+// measuring.
+type PhaseUsage struct {
+	Phase    string
+	Start    time.Time
+	Duration time.Duration
+	// CPU is user plus system CPU time the runner process consumed.
+	CPU time.Duration
+	// HeapBytes and Goroutines are read at the end of the phase.
+	HeapBytes  uint64
+	Goroutines int
+	// MaxRSSBytes is the process's peak resident set size so far; it only
+	// ever grows, so the phase where it jumps is the one that allocated.
+	MaxRSSBytes uint64
+	// DockerAPICalls is the number of Docker API requests made.
+	DockerAPICalls int64
+}
+
+// CPUPercent is CPU time as a percentage of one core over the phase.
+func (u PhaseUsage) CPUPercent() float64 {
+	if u.Duration <= 0 {
+		return 0
+	}
+	return 100 * u.CPU.Seconds() / u.Duration.Seconds()
+}
+
+// usageMark is the counter values at the start of a phase.
+type usageMark struct {
+	phase    TestState
+	at       time.Time
+	cpu      time.Duration
+	apiCalls int64
+}
+
+// processCPU returns the process's cumulative user plus system CPU time
+// and its peak resident set size in bytes.
+func processCPU() (time.Duration, uint64) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, 0
+	}
+	cpu := time.Duration(ru.Utime.Nano() + ru.Stime.Nano())
+	return cpu, uint64(ru.Maxrss) * 1024 // Linux reports kilobytes
+}
+
+// markPhaseUsage closes the running phase's usage record, if any, and
+// starts one for next. Terminal states are not measured.
+func (o *Orchestrator) markPhaseUsage(next TestState) {
+	now := time.Now()
+	cpu, maxRSS := processCPU()
+	calls := o.dockerClient.APICalls()
+
+	if m := o.usageMark; m != nil {
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		o.phaseUsage = append(o.phaseUsage, PhaseUsage{
+			Phase:          m.phase.String(),
+			Start:          m.at,
+			Duration:       now.Sub(m.at),
+			CPU:            cpu - m.cpu,
+			HeapBytes:      mem.HeapAlloc,
+			Goroutines:     runtime.NumGoroutine(),
+			MaxRSSBytes:    maxRSS,
+			DockerAPICalls: calls - m.apiCalls,
+		})
+		o.usageMark = nil
+	}
+
+	switch next {
+	case StateInit, StateCompleted, StateFailed, StateInterrupted:
+		return
+	}
+	o.usageMark = &usageMark{phase: next, at: now, cpu: cpu, apiCalls: calls}
+}
+
+// printPhaseUsage prints the per-phase usage table.
+func printPhaseUsage(usage []PhaseUsage) {
+	if len(usage) == 0 {
+		return
+	}
+	fmt.Println("\nRunner resource usage:")
+	fmt.Printf("  %-12s %10s %8s %6s %10s %10s %10s\n", "PHASE", "DURATION", "CPU", "CPU%", "HEAP", "MAX RSS", "DOCKER API")
+	for _, u := range usage {
+		fmt.Printf("  %-12s %10s %8s %5.1f%% %9.1fM %9.1fM %10d\n",
+			u.Phase, u.Duration.Round(time.Millisecond), u.CPU.Round(time.Millisecond), u.CPUPercent(),
+			float64(u.HeapBytes)/(1<<20), float64(u.MaxRSSBytes)/(1<<20), u.DockerAPICalls)
+	}
+}
@@ -0,0 +1,268 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/gameday"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// phaseAttempts is how many times a retryable phase runs before its
+// error ends the test.
+const phaseAttempts = 2
+
+// phaseRetryDelay is the pause between attempts.
+var phaseRetryDelay = 5 * time.Second
+
+// transition is one row of the run's state table: the state, the work done
+// in it, and the state the run moves to when that work succeeds.
+type transition struct {
+	state TestState
+	// enter runs before the run moves into state, after the stop check;
+	// an error fails the test without entering state.
+	enter func(ctx context.Context) error
+	run   func(ctx context.Context) error
+	// exit runs after run succeeds, still in state.
+	exit func(ctx context.Context) error
+	// abort runs when run fails, before the test is failed.
+	abort func()
+	next  TestState
+	// timed runs run under execution.phase_timeouts (see runPhase).
+	timed bool
+	// retryable phases are idempotent and run again after a failure that
+	// was neither a timeout nor a stop.
+	retryable bool
+}
+
+// transitions is the state table for one run, keyed by state. A run starts
+// at StateParse and follows next until it reaches StateReport.
+func (o *Orchestrator) transitions(scen *scenario.Scenario) map[TestState]transition {
+	table := []transition{
+		{
+			// PARSE validates the pre-parsed scenario. We do NOT re-read
+			// scenarioPath here: the caller has already parsed it, applied
+			// --set overrides, and validated. Re-parsing would discard the
+			// overrides (historical bug: --set duration=1m silently became
+			// the in-file value).
+			state: StateParse,
+			run:   func(ctx context.Context) error { return o.executeParse(ctx, scen) },
+			next:  StateDiscover,
+		},
+		{
+			state:     StateDiscover,
+			run:       o.executeDiscover,
+			exit:      o.exitDiscover,
+			next:      StatePrepare,
+			timed:     true,
+			retryable: true,
+		},
+		{state: StatePrepare, run: o.executePrepare, next: StateWarmup, timed: true},
+		{state: StateWarmup, run: o.executeWarmup, next: StateInject, timed: true},
+		{
+			state: StateInject,
+			enter: o.enterInject,
+			run:   o.executeInject,
+			abort: func() { o.dfSampler.Stop() },
+			next:  StateMonitor,
+			timed: true,
+		},
+		{state: StateMonitor, run: o.executeMonitor, next: StateCooldown, timed: true},
+		// COOLDOWN waits for the system to stabilise before removing faults.
+		{state: StateCooldown, run: o.executeCooldown, next: StateTeardown, timed: true},
+		{
+			// TEARDOWN removes faults and sidecars before criteria are
+			// evaluated, so Prometheus can scrape cleanly and criteria are
+			// not affected by network faults blocking the scrape path.
+			state: StateTeardown,
+			enter: o.enterTeardown,
+			run:   o.executeTeardown,
+			exit: func(context.Context) error {
+				o.teardownTime = time.Now()
+				return nil
+			},
+			next:  StateDetect,
+			timed: true,
+		},
+		// DETECT evaluates success criteria now that faults are removed.
+		// REPORT has no orchestrator-side work: the caller emits the report
+		// after Execute returns.
+		{state: StateDetect, run: o.executeDetect, next: StateReport, timed: true},
+	}
+
+	m := make(map[TestState]transition, len(table))
+	for _, t := range table {
+		m[t.state] = t
+	}
+	return m
+}
+
+// runTransitions walks the state table from from (PARSE, unless a run is
+// resumed) to REPORT. It returns the first error, with the run left in the
+// state that produced it.
+func (o *Orchestrator) runTransitions(ctx context.Context, table map[TestState]transition, from TestState) error {
+	for state := from; state != StateReport; {
+		t, ok := table[state]
+		if !ok {
+			return fmt.Errorf("no transition from state %s", state)
+		}
+		if state != StateParse && o.stopRequested.Load() {
+			return fmt.Errorf("stopped before %s", strings.ToLower(state.String()))
+		}
+		if t.enter != nil {
+			if err := t.enter(ctx); err != nil {
+				return err
+			}
+		}
+		o.transitionState(state)
+		if err := o.runTransition(ctx, t); err != nil {
+			if t.abort != nil {
+				t.abort()
+			}
+			return err
+		}
+		if t.exit != nil {
+			if err := t.exit(ctx); err != nil {
+				return err
+			}
+		}
+		state = t.next
+	}
+	return nil
+}
+
+// runTransition runs t's work, under its phase timeout when timed, and
+// again after a pause when it is retryable and failed.
+func (o *Orchestrator) runTransition(ctx context.Context, t transition) error {
+	attempts := 1
+	if t.retryable {
+		attempts = phaseAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		var err error
+		if t.timed {
+			err = o.runPhase(ctx, t.state, t.run)
+		} else {
+			err = t.run(ctx)
+		}
+		var timeoutErr *PhaseTimeoutError
+		if err == nil || attempt >= attempts || ctx.Err() != nil || o.stopRequested.Load() || errors.As(err, &timeoutErr) {
+			return err
+		}
+		fmt.Printf("  ⚠ %s failed (attempt %d/%d): %v — retrying in %s\n", t.state, attempt, attempts, err, phaseRetryDelay)
+		if err := o.interruptibleSleep(ctx, phaseRetryDelay); err != nil {
+			return err
+		}
+	}
+}
+
+// exitDiscover fingerprints the environment DISCOVER found and checks the
+// scenario can run on it before any sidecar is created.
+func (o *Orchestrator) exitDiscover(ctx context.Context) error {
+	o.persistTargets()
+	o.environment = o.collectEnvironment(ctx)
+	if topo := o.environment.Topology; topo != nil {
+		fmt.Printf("  Enclave topology: %d service(s), %d validator(s)\n", len(topo.Services), topo.ValidatorCount)
+	}
+	o.resolveMetricAliases(ctx)
+
+	// Topology preconditions: a scenario may require a minimum number of
+	// validators to exercise its fault path meaningfully. Fail fast here,
+	// before we start creating sidecars, so the operator gets a clear error.
+	if err := o.executePreconditions(ctx); err != nil {
+		return err
+	}
+
+	// Daemon platform: remote / non-Linux daemons cannot use host nsenter,
+	// and Windows daemons cannot run most fault types at all.
+	return o.checkDaemonPlatform(ctx)
+}
+
+// enterInject checks steady state, holds at the GameDay gate and for the
+// confirmation of a destructive scenario, and starts the samplers that
+// watch the fault window.
+func (o *Orchestrator) enterInject(ctx context.Context) error {
+	// Pre-fault health check: verify steady state before injection.
+	// Aborts if any critical criterion fails — system must be healthy before we break it.
+	if err := o.executePreCheck(ctx); err != nil {
+		return err
+	}
+
+	// GameDay: hold here until an operator approves injection. Nothing is
+	// installed yet, so a rejection simply ends the test.
+	summary := fmt.Sprintf("%d fault(s) on %d target(s)", len(o.scenario.Spec.Faults), len(o.targets))
+	if err := o.gatekeeper.Wait(ctx, gameday.GateBeforeInject, o.testID, o.gameDayScenario(), summary); err != nil {
+		return err
+	}
+
+	// Destructive scenarios need a second, recorded confirmation on top
+	// of any GameDay gate. Nothing is installed yet either.
+	if o.scenario.Metadata.Destructive {
+		if err := o.confirmDestructive(ctx, summary); err != nil {
+			return err
+		}
+	}
+
+	o.startFaultWindowWatch(ctx)
+	return nil
+}
+
+// confirmDestructive waits for the approval of a destructive scenario and
+// records the answer in the audit trail.
+func (o *Orchestrator) confirmDestructive(ctx context.Context, summary string) error {
+	if o.approver == nil {
+		return fmt.Errorf("scenario %s is destructive but no approver is configured", o.scenario.Metadata.Name)
+	}
+	ap, err := o.approver.Confirm(ctx, o.testID, o.scenario.Metadata.Name, summary)
+	if err != nil && ctx.Err() != nil {
+		return err
+	}
+	o.recordApproval(ap, err)
+	if err != nil {
+		return err
+	}
+	o.approval = ap
+	return nil
+}
+
+// startFaultWindowWatch starts the samplers that watch the fault window:
+// the during-fault criteria sampler and the time-to-detect watcher.
+func (o *Orchestrator) startFaultWindowWatch(ctx context.Context) {
+	// Start the during-fault sampler BEFORE inject. Some fault types
+	// (notably container_pause with Duration set) block their InjectFault
+	// call for the full fault window and self-terminate inside INJECT.
+	// If we only evaluate during_fault criteria after MONITOR, those
+	// faults are already gone and the criteria observe post-fault state.
+	// The sampler polls every 15s throughout INJECT + MONITOR and keeps
+	// the worst reading per criterion, so a single violation is recorded
+	// no matter when it occurs.
+	o.dfSampler = newDuringFaultSampler(o.detector, o.scenario.Spec.SuccessCriteria, 15*time.Second)
+	o.dfSampler.Start(ctx)
+
+	// Time-to-detect signals are polled from the same point, so latency
+	// covers the whole fault window.
+	o.detWatcher = newDetectionWatcher(o.promClient, o.detector, o.scenario)
+	o.detWatcher.Start(ctx)
+}
+
+// enterTeardown lets GameDay operators observe the active faults, then
+// records how many were installed before teardown removes them.
+func (o *Orchestrator) enterTeardown(ctx context.Context) error {
+	// GameDay: faults are still active; let operators observe before they
+	// come off. Teardown runs whatever the answer — leaving faults
+	// installed is never the safe choice.
+	if gateErr := o.gatekeeper.Wait(ctx, gameday.GateBeforeTeardown, o.testID, o.gameDayScenario(), "faults are active"); gateErr != nil {
+		fmt.Printf("  ⚠ %v — tearing down anyway\n", gateErr)
+	}
+
+	// Capture the install count BEFORE teardown runs: removeTrackedFaults
+	// nils injectedFaults on exit (for idempotency w.r.t. the deferred
+	// abort-path cleanup), so reading len(o.injectedFaults) at success
+	// time would always see 0 (F-11).
+	o.faultInstallCount = len(o.injectedFaults)
+	o.collectDetections()
+	return nil
+}
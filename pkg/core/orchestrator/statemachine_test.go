@@ -0,0 +1,77 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+)
+
+func TestTransitions_ReachReport(t *testing.T) {
+	table := (&Orchestrator{}).transitions(nil)
+
+	want := []TestState{StateParse, StateDiscover, StatePrepare, StateWarmup, StateInject, StateMonitor, StateCooldown, StateTeardown, StateDetect}
+	var got []TestState
+	seen := map[TestState]bool{}
+	for state := StateParse; state != StateReport; state = table[state].next {
+		tr, ok := table[state]
+		if !ok {
+			t.Fatalf("no transition from %s", state)
+		}
+		if seen[state] {
+			t.Fatalf("state %s visited twice", state)
+		}
+		seen[state] = true
+		if tr.run == nil {
+			t.Errorf("%s has no handler", state)
+		}
+		got = append(got, state)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("path = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("path = %v, want %v", got, want)
+		}
+	}
+	if len(table) != len(want) {
+		t.Errorf("table has %d states, %d reachable", len(table), len(want))
+	}
+}
+
+func TestRunTransition_RetriesOnlyRetryable(t *testing.T) {
+	defer func(d time.Duration) { phaseRetryDelay = d }(phaseRetryDelay)
+	phaseRetryDelay = 0
+
+	fail := errors.New("container not found")
+	for _, tt := range []struct {
+		name      string
+		retryable bool
+		wantCalls int
+	}{
+		{"retryable", true, phaseAttempts},
+		{"not retryable", false, 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			tr := transition{
+				state:     StateDiscover,
+				retryable: tt.retryable,
+				run: func(context.Context) error {
+					calls++
+					return fail
+				},
+			}
+			o := &Orchestrator{cfg: &config.Config{}}
+			if err := o.runTransition(context.Background(), tr); !errors.Is(err, fail) {
+				t.Errorf("err = %v, want %v", err, fail)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
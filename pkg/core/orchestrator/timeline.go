@@ -0,0 +1,91 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// timelineEntry is a fault start (start_after) or removal (duration) due
+// at an offset from the start of INJECT.
+type timelineEntry struct {
+	at     time.Duration
+	job    faultJob
+	remove bool
+}
+
+// schedule adds e to the timeline, keeping it in time order. A start and
+// a removal due together keep their scheduling order.
+func (o *Orchestrator) schedule(e timelineEntry) {
+	o.timeline = append(o.timeline, e)
+	sort.SliceStable(o.timeline, func(i, j int) bool { return o.timeline[i].at < o.timeline[j].at })
+}
+
+// runTimeline is MONITOR's wait: for duration it sleeps until the next
+// scheduled fault start or removal and runs it. Starts still pending when
+// monitoring ends are skipped; removals are left to teardown, which
+// removes every fault still installed.
+func (o *Orchestrator) runTimeline(ctx context.Context, duration time.Duration) error {
+	deadline := time.Now().Add(duration)
+	for len(o.timeline) > 0 {
+		e := o.timeline[0]
+		due := o.injectTime.Add(e.at)
+		if due.After(deadline) {
+			break
+		}
+		if wait := time.Until(due); wait > 0 {
+			if err := o.interruptibleSleep(ctx, wait); err != nil {
+				return err
+			}
+		}
+		o.timeline = o.timeline[1:]
+		if err := o.runTimelineEntry(ctx, e); err != nil {
+			return err
+		}
+	}
+	for _, e := range o.timeline {
+		if !e.remove {
+			fmt.Printf("  ⚠ %s: start_after %s falls after monitoring ended — not injected\n", e.job.fault.Phase, e.at)
+		}
+	}
+	o.timeline = nil
+
+	if wait := time.Until(deadline); wait > 0 {
+		return o.interruptibleSleep(ctx, wait)
+	}
+	return nil
+}
+
+// runTimelineEntry injects or removes one scheduled fault. An injection
+// failure ends the run like one in INJECT; a failed removal leaves the
+// fault for teardown.
+func (o *Orchestrator) runTimelineEntry(ctx context.Context, e timelineEntry) error {
+	offset := time.Since(o.injectTime).Round(time.Second)
+	if e.remove {
+		fmt.Printf("  ⏱ +%s: %s has run for %s, removing\n", offset, e.job.fault.Phase, e.job.fault.Duration)
+		remaining := o.injectedFaults[:0]
+		for _, f := range o.injectedFaults {
+			if f.Phase == e.job.fault.Phase && o.removeInjectedFault(ctx, f) {
+				continue
+			}
+			remaining = append(remaining, f)
+		}
+		o.injectedFaults = remaining
+		o.writeInterim(true)
+		return nil
+	}
+
+	fmt.Printf("  ⏱ +%s: starting %s\n", offset, e.job.fault.Phase)
+	jobs := o.excludeCurrentProducer(ctx, []faultJob{e.job})
+	if len(jobs) == 0 {
+		return nil
+	}
+	installed, err := o.injectJobs(ctx, jobs)
+	o.writeInterim(true)
+	if err != nil {
+		return fmt.Errorf("scheduled fault: %w", err)
+	}
+	o.startCaptures(ctx, installed)
+	return o.verifyFaultsActive(ctx, installed)
+}
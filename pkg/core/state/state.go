@@ -0,0 +1,282 @@
+// Package state persists what a run has installed — sidecars and faults —
+// to <output_dir>/<test-id>/state.json as it happens, so that
+// `chaos-runner recover` can finish cleanup after the runner crashed and
+// its in-memory tracking was lost. The file also checkpoints where the run
+// was (its state, targets and injection time), so `chaos-runner run
+// --resume` can pick the run up instead.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FileName is the state file inside a run's directory.
+const FileName = "state.json"
+
+// State is the persisted record of one run's chaos artifacts.
+type State struct {
+	TestID    string    `json:"test_id"`
+	Scenario  string    `json:"scenario,omitempty"`
+	Host      string    `json:"host"`
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Sidecars  []Sidecar `json:"sidecars,omitempty"`
+	// Faults are in injection order; recovery removes them in reverse.
+	Faults []Fault `json:"faults,omitempty"`
+
+	// RunState is the state the run last entered, e.g. MONITOR.
+	RunState string `json:"run_state,omitempty"`
+	// Targets are the targets DISCOVER resolved.
+	Targets []Target `json:"targets,omitempty"`
+	// InjectedAt is when INJECT started; zero before it.
+	InjectedAt time.Time `json:"injected_at,omitempty"`
+}
+
+// Target is one discovered target.
+type Target struct {
+	Alias       string `json:"alias"`
+	Name        string `json:"name"`
+	ContainerID string `json:"container_id"`
+	IP          string `json:"ip,omitempty"`
+	// Agent is the chaos-agent the container is reached through.
+	Agent string `json:"agent,omitempty"`
+}
+
+// Sidecar is one sidecar container attached to a target.
+type Sidecar struct {
+	TargetID  string `json:"target_id"`
+	SidecarID string `json:"sidecar_id"`
+}
+
+// Fault is one fault installed (or being installed) on one container.
+type Fault struct {
+	ContainerID string                 `json:"container_id"`
+	Target      string                 `json:"target,omitempty"`
+	Type        string                 `json:"type"`
+	Phase       string                 `json:"phase,omitempty"`
+	Params      map[string]interface{} `json:"params,omitempty"`
+}
+
+// Empty reports whether nothing is left to clean up.
+func (s *State) Empty() bool {
+	return len(s.Sidecars) == 0 && len(s.Faults) == 0
+}
+
+// Live reports whether the process that wrote the state is still running
+// on this host, in which case its artifacts are not orphaned.
+func (s *State) Live() bool {
+	host, _ := os.Hostname()
+	if s.Host != host || s.PID <= 0 {
+		return false
+	}
+	if s.PID == os.Getpid() {
+		return true
+	}
+	proc, err := os.FindProcess(s.PID)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// File is a state file kept in sync with every change. It is safe for
+// concurrent use.
+type File struct {
+	path  string
+	mu    sync.Mutex
+	state State
+}
+
+// Create starts a state file for testID under dir.
+func Create(dir, testID, scenario string) (*File, error) {
+	runDir := filepath.Join(dir, testID)
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	host, _ := os.Hostname()
+	f := &File{
+		path: filepath.Join(runDir, FileName),
+		state: State{
+			TestID:    testID,
+			Scenario:  scenario,
+			Host:      host,
+			PID:       os.Getpid(),
+			StartedAt: time.Now(),
+		},
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f, f.save()
+}
+
+// Path returns the state file's location.
+func (f *File) Path() string { return f.path }
+
+// Snapshot returns a copy of the current state.
+func (f *File) Snapshot() State {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.state
+	s.Sidecars = append([]Sidecar(nil), s.Sidecars...)
+	s.Faults = append([]Fault(nil), s.Faults...)
+	s.Targets = append([]Target(nil), s.Targets...)
+	return s
+}
+
+// SetRunState records the state the run entered.
+func (f *File) SetRunState(runState string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state.RunState = runState
+	return f.save()
+}
+
+// SetTargets records the run's discovered targets.
+func (f *File) SetTargets(targets []Target) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state.Targets = append([]Target(nil), targets...)
+	return f.save()
+}
+
+// SetInjectedAt records when INJECT started.
+func (f *File) SetInjectedAt(at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state.InjectedAt = at
+	return f.save()
+}
+
+// AddSidecar records a sidecar, replacing any earlier one for the target.
+func (f *File) AddSidecar(targetID, sidecarID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state.Sidecars = removeSidecar(f.state.Sidecars, targetID)
+	f.state.Sidecars = append(f.state.Sidecars, Sidecar{TargetID: targetID, SidecarID: sidecarID})
+	return f.save()
+}
+
+// RemoveSidecar forgets the sidecar of targetID.
+func (f *File) RemoveSidecar(targetID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state.Sidecars = removeSidecar(f.state.Sidecars, targetID)
+	return f.save()
+}
+
+// AddFault records a fault. Record it before injecting, so a crash
+// mid-inject still leaves a trace.
+func (f *File) AddFault(fault Fault) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state.Faults = append(f.state.Faults, fault)
+	return f.save()
+}
+
+// RemoveFault forgets the most recent matching fault.
+func (f *File) RemoveFault(containerID, faultType, phase string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.state.Faults) - 1; i >= 0; i-- {
+		ft := f.state.Faults[i]
+		if ft.ContainerID == containerID && ft.Type == faultType && ft.Phase == phase {
+			f.state.Faults = append(f.state.Faults[:i], f.state.Faults[i+1:]...)
+			break
+		}
+	}
+	return f.save()
+}
+
+// Discard deletes the state file and its directory, if empty.
+func (f *File) Discard() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return Remove(f.path)
+}
+
+// Remove deletes the state file at path and its run directory, if empty.
+func Remove(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	os.Remove(filepath.Dir(path)) // only succeeds when empty
+	return nil
+}
+
+// save writes the state atomically: a crash mid-write must not leave a
+// truncated file behind. Callers hold f.mu.
+func (f *File) save() error {
+	f.state.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(f.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
+
+// Save overwrites the state file at path with s, for recovery to record
+// what it could not clean.
+func Save(path string, s *State) error {
+	f := &File{path: path, state: *s}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.save()
+}
+
+// Open takes over the state file at path, for a run resumed by another
+// process: the file is rewritten under this process's host and PID, so
+// recover treats it as live again.
+func Open(path string) (*File, error) {
+	s, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	s.Host, s.PID = host, os.Getpid()
+	f := &File{path: path, state: *s}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f, f.save()
+}
+
+// Load reads a state file.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Find returns the state files under dir, one per run directory.
+func Find(dir string) ([]string, error) {
+	return filepath.Glob(filepath.Join(dir, "*", FileName))
+}
+
+func removeSidecar(sidecars []Sidecar, targetID string) []Sidecar {
+	out := sidecars[:0]
+	for _, s := range sidecars {
+		if s.TargetID != targetID {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	f, err := Create(dir, "test-1", "demo")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := f.AddSidecar("target-a", "sidecar-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.AddSidecar("target-a", "sidecar-2"); err != nil {
+		t.Fatal(err)
+	}
+	for _, ft := range []Fault{
+		{ContainerID: "target-a", Type: "network", Phase: "latency"},
+		{ContainerID: "target-a", Type: "cpu_stress", Phase: "cpu"},
+	} {
+		if err := f.AddFault(ft); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.RemoveFault("target-a", "network", "latency"); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := Find(dir)
+	if err != nil || len(paths) != 1 || paths[0] != filepath.Join(dir, "test-1", FileName) {
+		t.Fatalf("Find() = %v, %v", paths, err)
+	}
+	s, err := Load(paths[0])
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(s.Sidecars) != 1 || s.Sidecars[0].SidecarID != "sidecar-2" {
+		t.Errorf("sidecars = %+v, want only sidecar-2", s.Sidecars)
+	}
+	if len(s.Faults) != 1 || s.Faults[0].Type != "cpu_stress" {
+		t.Errorf("faults = %+v, want only cpu_stress", s.Faults)
+	}
+	if !s.Live() {
+		t.Error("state written by this process should be live")
+	}
+
+	if err := f.Discard(); err != nil {
+		t.Fatalf("Discard: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test-1")); !os.IsNotExist(err) {
+		t.Errorf("run directory should be removed, stat err = %v", err)
+	}
+}
+
+func TestOpenCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	f, err := Create(dir, "test-1", "demo")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	injectedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := f.SetTargets([]Target{{Alias: "validator", Name: "l2-el-1", ContainerID: "target-a"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetInjectedAt(injectedAt); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetRunState("MONITOR"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A resumed run takes the file over from the crashed process.
+	s, _ := Load(f.Path())
+	s.PID = -1
+	if err := Save(f.Path(), s); err != nil {
+		t.Fatal(err)
+	}
+	resumed, err := Open(f.Path())
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	snap := resumed.Snapshot()
+	if snap.RunState != "MONITOR" || !snap.InjectedAt.Equal(injectedAt) || len(snap.Targets) != 1 || snap.Targets[0].ContainerID != "target-a" {
+		t.Errorf("checkpoint = %+v, want MONITOR, %s and target-a", snap, injectedAt)
+	}
+	if !snap.Live() {
+		t.Error("an opened state file should belong to this process")
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
@@ -18,9 +21,18 @@ import (
 	"github.com/jihwankim/chaos-utils/pkg/discovery"
 )
 
+// ExecTracer observes every command run through ExecCommand. exitCode is
+// -1 when the exec never produced one (create/attach/inspect failed).
+type ExecTracer func(containerID string, cmd []string, exitCode int, elapsed time.Duration, err error)
+
 // Client wraps Docker API client for service discovery and container management
 type Client struct {
-	cli *client.Client
+	cli    *client.Client
+	tracer ExecTracer
+	// apiCalls counts HTTP requests sent to the daemon, including those
+	// made by packages that use GetClient directly. Hijacked exec attach
+	// streams bypass the transport and are not counted.
+	apiCalls atomic.Int64
 }
 
 // New creates a new Docker client
@@ -30,7 +42,32 @@ func New() (*Client, error) {
 		return nil, fmt.Errorf("failed to create Docker client: %w", err)
 	}
 
-	return &Client{cli: cli}, nil
+	c := &Client{cli: cli}
+	// Wrap the finished transport rather than passing an option: the SDK
+	// keeps its own handle on the base transport for dialing hijacked
+	// (exec attach) connections, which a custom transport would lose.
+	hc := cli.HTTPClient()
+	hc.Transport = &countingTransport{base: hc.Transport, n: &c.apiCalls}
+	if err := client.WithHTTPClient(hc)(cli); err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	return c, nil
+}
+
+// APICalls returns the number of Docker API requests made so far.
+func (c *Client) APICalls() int64 {
+	return c.apiCalls.Load()
+}
+
+// countingTransport counts every request it forwards.
+type countingTransport struct {
+	base http.RoundTripper
+	n    *atomic.Int64
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.n.Add(1)
+	return t.base.RoundTrip(req)
 }
 
 // Close closes the Docker client connection
@@ -41,6 +78,12 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// SetExecTracer installs fn to be called after every ExecCommand. Set it
+// before the client is shared between goroutines.
+func (c *Client) SetExecTracer(fn ExecTracer) {
+	c.tracer = fn
+}
+
 // GetClient returns the underlying Docker API client
 func (c *Client) GetClient() *client.Client {
 	return c.cli
@@ -68,44 +111,73 @@ func (c *Client) GetContainerPID(ctx context.Context, containerID string) (int,
 
 // ExecCommand executes a command in a container and returns output
 func (c *Client) ExecCommand(ctx context.Context, containerID string, cmd []string) (string, error) {
+	return c.ExecCommandStdin(ctx, containerID, cmd, nil)
+}
+
+// ExecCommandStdin is ExecCommand with stdin streamed to the command, so
+// data such as secrets reaches it without appearing in its command line.
+// A nil stdin attaches none.
+func (c *Client) ExecCommandStdin(ctx context.Context, containerID string, cmd []string, stdin io.Reader) (string, error) {
+	start := time.Now()
+	output, exitCode, err := c.execCommand(ctx, containerID, cmd, stdin)
+	if c.tracer != nil {
+		c.tracer(containerID, cmd, exitCode, time.Since(start), err)
+	}
+	return output, err
+}
+
+// execCommand does the work of ExecCommandStdin and also returns the exit
+// code.
+func (c *Client) execCommand(ctx context.Context, containerID string, cmd []string, stdin io.Reader) (string, int, error) {
 	// Create exec instance
 	execConfig := types.ExecConfig{
 		Cmd:          cmd,
+		AttachStdin:  stdin != nil,
 		AttachStdout: true,
 		AttachStderr: true,
 	}
 
 	execID, err := c.cli.ContainerExecCreate(ctx, containerID, execConfig)
 	if err != nil {
-		return "", fmt.Errorf("failed to create exec: %w", err)
+		return "", -1, fmt.Errorf("failed to create exec: %w", err)
 	}
 
 	// Attach to exec instance
 	resp, err := c.cli.ContainerExecAttach(ctx, execID.ID, types.ExecStartCheck{})
 	if err != nil {
-		return "", fmt.Errorf("failed to attach to exec: %w", err)
+		return "", -1, fmt.Errorf("failed to attach to exec: %w", err)
 	}
 	defer resp.Close()
 
+	if stdin != nil {
+		// Close our side once stdin is sent so the command sees EOF.
+		if _, err := io.Copy(resp.Conn, stdin); err != nil {
+			return "", -1, fmt.Errorf("failed to write stdin: %w", err)
+		}
+		if err := resp.CloseWrite(); err != nil {
+			return "", -1, fmt.Errorf("failed to close stdin: %w", err)
+		}
+	}
+
 	// Docker exec streams are multiplexed (8-byte header per chunk) when
 	// no TTY is allocated. Use stdcopy.StdCopy to demultiplex into clean output.
 	var stdout, stderr bytes.Buffer
 	if _, err := stdcopy.StdCopy(&stdout, &stderr, resp.Reader); err != nil {
-		return stdout.String(), fmt.Errorf("failed to read output: %w", err)
+		return stdout.String(), -1, fmt.Errorf("failed to read output: %w", err)
 	}
 
 	// Check exit code
 	inspectResp, err := c.cli.ContainerExecInspect(ctx, execID.ID)
 	if err != nil {
-		return stdout.String(), fmt.Errorf("failed to inspect exec: %w", err)
+		return stdout.String(), -1, fmt.Errorf("failed to inspect exec: %w", err)
 	}
 
 	if inspectResp.ExitCode != 0 {
 		combined := stdout.String() + stderr.String()
-		return combined, fmt.Errorf("command exited with code %d: %s", inspectResp.ExitCode, combined)
+		return combined, inspectResp.ExitCode, fmt.Errorf("command exited with code %d: %s", inspectResp.ExitCode, combined)
 	}
 
-	return stdout.String(), nil
+	return stdout.String(), 0, nil
 }
 
 // Helper function to convert inspect data to Service
@@ -257,3 +329,7 @@ func (c *Client) ContainerUpdate(ctx context.Context, containerID string, update
 	return c.cli.ContainerUpdate(ctx, containerID, updateConfig)
 }
 
+// Events subscribes to the daemon's event stream until ctx is done.
+func (c *Client) Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+	return c.cli.Events(ctx, options)
+}
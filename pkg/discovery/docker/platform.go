@@ -0,0 +1,69 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// DaemonPlatform describes where the Docker daemon runs relative to
+// chaos-runner.
+type DaemonPlatform struct {
+	// Host is the daemon endpoint, e.g. unix:///var/run/docker.sock.
+	Host string
+	// OSType is the container OS the daemon runs ("linux" or "windows").
+	OSType string
+	// OperatingSystem is the daemon host's OS description, e.g.
+	// "Ubuntu 22.04.4 LTS" or "Docker Desktop".
+	OperatingSystem string
+	// Remote is true when the daemon is reached over tcp:// or ssh://
+	// rather than a local socket.
+	Remote bool
+	// CgroupVersion is "1" or "2" as reported by the daemon.
+	CgroupVersion string
+}
+
+// DaemonPlatform inspects the daemon this client talks to.
+func (c *Client) DaemonPlatform(ctx context.Context) (*DaemonPlatform, error) {
+	info, err := c.cli.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Docker daemon info: %w", err)
+	}
+	host := c.cli.DaemonHost()
+	return &DaemonPlatform{
+		Host:            host,
+		OSType:          info.OSType,
+		OperatingSystem: info.OperatingSystem,
+		Remote:          isRemoteHost(host),
+		CgroupVersion:   info.CgroupVersion,
+	}, nil
+}
+
+// HostNamespaceAccess reports whether container PIDs from the daemon are
+// valid on this machine, i.e. whether nsenter-style access to a container's
+// namespaces from the runner host can work at all. It cannot when the
+// daemon is remote, inside the Docker Desktop VM, or when the runner itself
+// is not on Linux. In those cases every namespace operation has to go
+// through a sidecar that shares the target's network namespace.
+func (p *DaemonPlatform) HostNamespaceAccess() bool {
+	return !p.Remote &&
+		runtime.GOOS == "linux" &&
+		p.OSType == "linux" &&
+		!strings.Contains(p.OperatingSystem, "Docker Desktop")
+}
+
+// String summarises the platform for operator-facing messages.
+func (p *DaemonPlatform) String() string {
+	where := "local"
+	if p.Remote {
+		where = "remote"
+	}
+	return fmt.Sprintf("%s %s daemon (%s) at %s", where, p.OSType, p.OperatingSystem, p.Host)
+}
+
+// isRemoteHost reports whether a DOCKER_HOST-style endpoint is reached over
+// the network. Unix sockets and Windows named pipes are local.
+func isRemoteHost(host string) bool {
+	return !strings.HasPrefix(host, "unix://") && !strings.HasPrefix(host, "npipe://")
+}
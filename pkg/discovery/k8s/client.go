@@ -0,0 +1,287 @@
+// Package k8s discovers Kubernetes pods and runs commands in them through
+// kubectl, so scenarios can target pods without a client-go dependency.
+// Whatever kubectl is configured to reach (kubeconfig, context, auth
+// plugins) is what chaos-runner reaches.
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExecTracer observes every command run in a pod. target is
+// "<namespace>/<pod>/<container>"; exitCode is -1 when kubectl never
+// produced one.
+type ExecTracer func(target string, cmd []string, exitCode int, elapsed time.Duration, err error)
+
+// Runner runs kubectl with args, streaming stdin to it when non-nil.
+// exitCode is -1 and err is set when kubectl could not be run at all; a
+// kubectl that ran and failed reports its exit code with a nil err.
+type Runner func(ctx context.Context, args []string, stdin io.Reader) (stdout, stderr []byte, exitCode int, err error)
+
+// Pod is a running pod discovery can target.
+type Pod struct {
+	Namespace string
+	Name      string
+	// UID identifies the pod across the run; it stands in for the
+	// container ID of Docker targets.
+	UID        string
+	IP         string
+	Node       string
+	Labels     map[string]string
+	Containers []string
+}
+
+// Client runs kubectl against one cluster.
+type Client struct {
+	kubeconfig string
+	context    string
+	run        Runner
+	tracer     ExecTracer
+}
+
+// New creates a client for the cluster of kubeconfig and context; empty
+// values leave kubectl's own defaults ($KUBECONFIG, the current context).
+func New(kubeconfig, kubeContext string) *Client {
+	return &Client{kubeconfig: kubeconfig, context: kubeContext, run: runKubectl}
+}
+
+// SetExecTracer installs fn to observe every command run in a pod.
+func (c *Client) SetExecTracer(fn ExecTracer) {
+	c.tracer = fn
+}
+
+// runKubectl is the Runner that executes the kubectl binary.
+func runKubectl(ctx context.Context, args []string, stdin io.Reader) ([]byte, []byte, int, error) {
+	cmd := exec.CommandContext(ctx, "kubectl", args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return stdout.Bytes(), stderr.Bytes(), exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return nil, nil, -1, fmt.Errorf("failed to run kubectl: %w", err)
+	}
+	return stdout.Bytes(), stderr.Bytes(), 0, nil
+}
+
+// kubectl runs kubectl with the client's cluster flags and returns its
+// stdout, failing on a non-zero exit.
+func (c *Client) kubectl(ctx context.Context, args ...string) ([]byte, error) {
+	stdout, stderr, code, err := c.run(ctx, c.args(args...), nil)
+	if err != nil {
+		return nil, err
+	}
+	if code != 0 {
+		return nil, fmt.Errorf("kubectl %s exited with code %d: %s", args[0], code, strings.TrimSpace(string(stderr)))
+	}
+	return stdout, nil
+}
+
+// args prepends the client's cluster flags to args.
+func (c *Client) args(args ...string) []string {
+	var out []string
+	if c.kubeconfig != "" {
+		out = append(out, "--kubeconfig", c.kubeconfig)
+	}
+	if c.context != "" {
+		out = append(out, "--context", c.context)
+	}
+	return append(out, args...)
+}
+
+// podList is the part of `kubectl get pods -o json` discovery reads.
+type podList struct {
+	Items []podJSON `json:"items"`
+}
+
+type podJSON struct {
+	Metadata struct {
+		Namespace         string            `json:"namespace"`
+		Name              string            `json:"name"`
+		UID               string            `json:"uid"`
+		Labels            map[string]string `json:"labels"`
+		DeletionTimestamp *string           `json:"deletionTimestamp"`
+	} `json:"metadata"`
+	Spec struct {
+		NodeName   string `json:"nodeName"`
+		Containers []struct {
+			Name string `json:"name"`
+		} `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase                      string            `json:"phase"`
+		PodIP                      string            `json:"podIP"`
+		EphemeralContainerStatuses []containerStatus `json:"ephemeralContainerStatuses"`
+	} `json:"status"`
+}
+
+type containerStatus struct {
+	Name  string `json:"name"`
+	State struct {
+		Running    *struct{} `json:"running"`
+		Terminated *struct {
+			ExitCode int    `json:"exitCode"`
+			Reason   string `json:"reason"`
+		} `json:"terminated"`
+		Waiting *struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"waiting"`
+	} `json:"state"`
+}
+
+// ListPods lists the running pods of namespace that carry every label in
+// labels. An empty namespace lists the current context's namespace. Pods
+// being deleted are left out.
+func (c *Client) ListPods(ctx context.Context, namespace string, labels map[string]string) ([]Pod, error) {
+	args := []string{"get", "pods", "-o", "json"}
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+	if selector := labelSelector(labels); selector != "" {
+		args = append(args, "--selector", selector)
+	}
+	out, err := c.kubectl(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var list podList
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse pod list: %w", err)
+	}
+	var pods []Pod
+	for _, item := range list.Items {
+		if item.Status.Phase != "Running" || item.Metadata.DeletionTimestamp != nil {
+			continue
+		}
+		pod := Pod{
+			Namespace: item.Metadata.Namespace,
+			Name:      item.Metadata.Name,
+			UID:       item.Metadata.UID,
+			IP:        item.Status.PodIP,
+			Node:      item.Spec.NodeName,
+			Labels:    item.Metadata.Labels,
+		}
+		for _, ctr := range item.Spec.Containers {
+			pod.Containers = append(pod.Containers, ctr.Name)
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// labelSelector renders labels as a kubectl --selector, sorted so the
+// command line is stable.
+func labelSelector(labels map[string]string) string {
+	terms := make([]string, 0, len(labels))
+	for k, v := range labels {
+		terms = append(terms, k+"="+v)
+	}
+	sort.Strings(terms)
+	return strings.Join(terms, ",")
+}
+
+// Exec runs cmd in container of pod and returns its output. A non-zero
+// exit fails with the command's combined output, as Docker execs do. A
+// non-nil stdin is streamed to the command, so data such as secrets stays
+// out of its command line.
+func (c *Client) Exec(ctx context.Context, pod Pod, container string, cmd []string, stdin io.Reader) (string, error) {
+	args := []string{"exec", "--namespace", pod.Namespace, pod.Name, "--container", container}
+	if stdin != nil {
+		args = append(args, "--stdin")
+	}
+	args = append(append(args, "--"), cmd...)
+
+	start := time.Now()
+	stdout, stderr, code, err := c.run(ctx, c.args(args...), stdin)
+	if err == nil && code != 0 {
+		combined := string(stdout) + string(stderr)
+		err = fmt.Errorf("command exited with code %d: %s", code, combined)
+		stdout = []byte(combined)
+	}
+	if c.tracer != nil {
+		c.tracer(pod.Namespace+"/"+pod.Name+"/"+container, cmd, code, time.Since(start), err)
+	}
+	return string(stdout), err
+}
+
+// debugPollInterval is how often AddDebugContainer checks whether the
+// debug container has started, and debugStartTimeout how long it waits
+// (the image may have to be pulled on the node first).
+var (
+	debugPollInterval = time.Second
+	debugStartTimeout = 2 * time.Minute
+)
+
+// AddDebugContainer adds an ephemeral container named name to pod, running
+// cmd in image with the netadmin debug profile (NET_ADMIN and NET_RAW),
+// and waits until it runs. Ephemeral containers share the pod's network
+// namespace; they cannot be removed, only left to exit.
+func (c *Client) AddDebugContainer(ctx context.Context, pod Pod, name, image string, cmd []string) error {
+	args := []string{"debug", "--namespace", pod.Namespace, pod.Name,
+		"--container", name, "--image", image, "--profile", "netadmin", "--quiet", "--"}
+	if _, err := c.kubectl(ctx, append(args, cmd...)...); err != nil {
+		return fmt.Errorf("failed to add debug container to pod %s: %w", pod.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, debugStartTimeout)
+	defer cancel()
+	for {
+		status, err := c.ephemeralStatus(ctx, pod, name)
+		if err != nil {
+			return err
+		}
+		switch {
+		case status == nil:
+		case status.State.Running != nil:
+			return nil
+		case status.State.Terminated != nil:
+			return fmt.Errorf("debug container %s in pod %s exited (%s, code %d)", name, pod.Name,
+				status.State.Terminated.Reason, status.State.Terminated.ExitCode)
+		case status.State.Waiting != nil && status.State.Waiting.Reason == "ErrImagePull",
+			status.State.Waiting != nil && status.State.Waiting.Reason == "ImagePullBackOff":
+			return fmt.Errorf("debug container %s in pod %s cannot start: %s", name, pod.Name, status.State.Waiting.Message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("debug container %s in pod %s did not start: %w", name, pod.Name, ctx.Err())
+		case <-time.After(debugPollInterval):
+		}
+	}
+}
+
+// ephemeralStatus returns the status of pod's ephemeral container name,
+// or nil while the kubelet has not reported one.
+func (c *Client) ephemeralStatus(ctx context.Context, pod Pod, name string) (*containerStatus, error) {
+	out, err := c.kubectl(ctx, "get", "pod", "--namespace", pod.Namespace, pod.Name, "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s: %w", pod.Name, err)
+	}
+	var item podJSON
+	if err := json.Unmarshal(out, &item); err != nil {
+		return nil, fmt.Errorf("failed to parse pod %s: %w", pod.Name, err)
+	}
+	for i := range item.Status.EphemeralContainerStatuses {
+		if item.Status.EphemeralContainerStatuses[i].Name == name {
+			return &item.Status.EphemeralContainerStatuses[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,186 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeKubectl records the kubectl invocations of a Client and answers
+// them from respond.
+type fakeKubectl struct {
+	calls   [][]string
+	stdin   []string
+	respond func(args []string) (stdout, stderr string, exitCode int)
+}
+
+func (f *fakeKubectl) run(ctx context.Context, args []string, stdin io.Reader) ([]byte, []byte, int, error) {
+	f.calls = append(f.calls, args)
+	in := ""
+	if stdin != nil {
+		b, _ := io.ReadAll(stdin)
+		in = string(b)
+	}
+	f.stdin = append(f.stdin, in)
+	stdout, stderr, code := f.respond(args)
+	return []byte(stdout), []byte(stderr), code, nil
+}
+
+const podListJSON = `{"items": [
+  {"metadata": {"namespace": "pos", "name": "bor-0", "uid": "uid-bor-0", "labels": {"app": "bor"}},
+   "spec": {"nodeName": "node-1", "containers": [{"name": "bor"}, {"name": "exporter"}]},
+   "status": {"phase": "Running", "podIP": "10.0.0.5"}},
+  {"metadata": {"namespace": "pos", "name": "bor-1", "uid": "uid-bor-1", "labels": {"app": "bor"}},
+   "status": {"phase": "Pending"}},
+  {"metadata": {"namespace": "pos", "name": "bor-2", "uid": "uid-bor-2", "deletionTimestamp": "2026-01-01T00:00:00Z"},
+   "status": {"phase": "Running", "podIP": "10.0.0.7"}}
+]}`
+
+func TestListPods(t *testing.T) {
+	kubectl := &fakeKubectl{respond: func(args []string) (string, string, int) { return podListJSON, "", 0 }}
+	c := &Client{kubeconfig: "/tmp/kubeconfig", context: "devnet", run: kubectl.run}
+
+	pods, err := c.ListPods(context.Background(), "pos", map[string]string{"app": "bor", "tier": "el"})
+	if err != nil {
+		t.Fatalf("ListPods() error = %v", err)
+	}
+
+	wantArgs := []string{"--kubeconfig", "/tmp/kubeconfig", "--context", "devnet",
+		"get", "pods", "-o", "json", "--namespace", "pos", "--selector", "app=bor,tier=el"}
+	if !reflect.DeepEqual(kubectl.calls[0], wantArgs) {
+		t.Errorf("kubectl args = %v, want %v", kubectl.calls[0], wantArgs)
+	}
+
+	// Pending and terminating pods are left out.
+	want := []Pod{{
+		Namespace:  "pos",
+		Name:       "bor-0",
+		UID:        "uid-bor-0",
+		IP:         "10.0.0.5",
+		Node:       "node-1",
+		Labels:     map[string]string{"app": "bor"},
+		Containers: []string{"bor", "exporter"},
+	}}
+	if !reflect.DeepEqual(pods, want) {
+		t.Errorf("ListPods() = %+v, want %+v", pods, want)
+	}
+}
+
+func TestListPods_KubectlFails(t *testing.T) {
+	kubectl := &fakeKubectl{respond: func(args []string) (string, string, int) {
+		return "", "error: You must be logged in to the server (Unauthorized)\n", 1
+	}}
+	c := &Client{run: kubectl.run}
+
+	_, err := c.ListPods(context.Background(), "", nil)
+	if err == nil || !strings.Contains(err.Error(), "Unauthorized") {
+		t.Fatalf("ListPods() error = %v, want kubectl's stderr", err)
+	}
+	// No namespace or selector: kubectl's own defaults apply.
+	if got := strings.Join(kubectl.calls[0], " "); got != "get pods -o json" {
+		t.Errorf("kubectl args = %q", got)
+	}
+}
+
+func TestExec(t *testing.T) {
+	pod := Pod{Namespace: "pos", Name: "bor-0"}
+	kubectl := &fakeKubectl{respond: func(args []string) (string, string, int) {
+		if args[len(args)-1] == "fail" {
+			return "partial\n", "boom\n", 3
+		}
+		return "ok\n", "", 0
+	}}
+	var traced []string
+	c := &Client{run: kubectl.run}
+	c.SetExecTracer(func(target string, cmd []string, exitCode int, elapsed time.Duration, err error) {
+		traced = append(traced, fmt.Sprintf("%s %s %d", target, strings.Join(cmd, " "), exitCode))
+	})
+
+	out, err := c.Exec(context.Background(), pod, "chaos-debug-1", []string{"sh", "-c", "cat > /tmp/key"}, strings.NewReader("secret"))
+	if err != nil || out != "ok\n" {
+		t.Fatalf("Exec() = %q, %v", out, err)
+	}
+	wantArgs := []string{"exec", "--namespace", "pos", "bor-0", "--container", "chaos-debug-1", "--stdin", "--", "sh", "-c", "cat > /tmp/key"}
+	if !reflect.DeepEqual(kubectl.calls[0], wantArgs) {
+		t.Errorf("kubectl args = %v, want %v", kubectl.calls[0], wantArgs)
+	}
+	if kubectl.stdin[0] != "secret" {
+		t.Errorf("stdin = %q, want it streamed to kubectl", kubectl.stdin[0])
+	}
+
+	out, err = c.Exec(context.Background(), pod, "chaos-debug-1", []string{"fail"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "command exited with code 3") {
+		t.Fatalf("Exec() error = %v, want the exit code", err)
+	}
+	if out != "partial\nboom\n" {
+		t.Errorf("Exec() output = %q, want stdout and stderr", out)
+	}
+	if strings.Contains(strings.Join(kubectl.calls[1], " "), "--stdin") {
+		t.Errorf("kubectl args = %v, want no --stdin without stdin", kubectl.calls[1])
+	}
+
+	want := []string{"pos/bor-0/chaos-debug-1 sh -c cat > /tmp/key 0", "pos/bor-0/chaos-debug-1 fail 3"}
+	if !reflect.DeepEqual(traced, want) {
+		t.Errorf("traced = %q, want %q", traced, want)
+	}
+}
+
+// ephemeralPod renders `kubectl get pod -o json` with one ephemeral
+// container status.
+func ephemeralPod(name, state string) string {
+	return fmt.Sprintf(`{"status": {"phase": "Running", "ephemeralContainerStatuses": [{"name": %q, "state": %s}]}}`, name, state)
+}
+
+func TestAddDebugContainer(t *testing.T) {
+	defer func(d time.Duration) { debugPollInterval = d }(debugPollInterval)
+	debugPollInterval = time.Millisecond
+
+	pod := Pod{Namespace: "pos", Name: "bor-0"}
+	tests := []struct {
+		name    string
+		states  []string // successive statuses reported by get pod
+		wantErr string
+	}{
+		{"starts", []string{"", ephemeralPod("dbg", `{"waiting": {"reason": "ContainerCreating"}}`), ephemeralPod("dbg", `{"running": {}}`)}, ""},
+		{"exits", []string{ephemeralPod("dbg", `{"terminated": {"exitCode": 127, "reason": "Error"}}`)}, "exited (Error, code 127)"},
+		{"image pull fails", []string{ephemeralPod("dbg", `{"waiting": {"reason": "ErrImagePull", "message": "not found"}}`)}, "cannot start: not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			kubectl := &fakeKubectl{respond: func(args []string) (string, string, int) {
+				if args[0] == "debug" {
+					return "", "", 0
+				}
+				state := tt.states[polls]
+				if polls < len(tt.states)-1 {
+					polls++
+				}
+				if state == "" {
+					return `{"status": {"phase": "Running"}}`, "", 0
+				}
+				return state, "", 0
+			}}
+			c := &Client{run: kubectl.run}
+
+			err := c.AddDebugContainer(context.Background(), pod, "dbg", "jhkimqd/chaos-utils:latest", []string{"sleep", "infinity"})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("AddDebugContainer() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("AddDebugContainer() error = %v, want %q", err, tt.wantErr)
+			}
+
+			wantArgs := []string{"debug", "--namespace", "pos", "bor-0", "--container", "dbg",
+				"--image", "jhkimqd/chaos-utils:latest", "--profile", "netadmin", "--quiet", "--", "sleep", "infinity"}
+			if !reflect.DeepEqual(kubectl.calls[0], wantArgs) {
+				t.Errorf("kubectl args = %v, want %v", kubectl.calls[0], wantArgs)
+			}
+		})
+	}
+}
@@ -11,7 +11,7 @@ type Service struct {
 	// Type indicates the service type (validator, rpc, rabbitmq, etc.)
 	Type string
 
-	// Role indicates the role (l1, l2-cl, l2-el, messaging, etc.)
+	// Role indicates the role (l1-el, l1-cl, l2-cl, l2-el, messaging, etc.)
 	Role string
 
 	// IP is the service IP address
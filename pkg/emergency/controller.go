@@ -16,8 +16,11 @@ type Controller struct {
 	stopped        bool
 	mutex          sync.RWMutex
 	callbacks      []func()
+	forceCallbacks []func()
 	pollInterval   time.Duration
 	signalHandlers bool
+	gracePeriod    time.Duration
+	forceOnce      sync.Once
 }
 
 // Config contains emergency controller configuration
@@ -30,6 +33,12 @@ type Config struct {
 
 	// EnableSignalHandlers enables SIGINT/SIGTERM handling
 	EnableSignalHandlers bool
+
+	// GracePeriod is how long the process may keep running after a stop
+	// is triggered, so the normal teardown path can finish. When it
+	// expires, or on a second signal, the OnForce callbacks run and the
+	// process exits. 0 disables the forced exit.
+	GracePeriod time.Duration
 }
 
 // New creates a new emergency controller
@@ -47,6 +56,7 @@ func New(config Config) *Controller {
 		callbacks:      make([]func(), 0),
 		pollInterval:   config.PollInterval,
 		signalHandlers: config.EnableSignalHandlers,
+		gracePeriod:    config.GracePeriod,
 	}
 }
 
@@ -73,27 +83,36 @@ func (c *Controller) watchStopFile(ctx context.Context) {
 		case <-ticker.C:
 			if c.checkStopFile() {
 				fmt.Printf("🛑 Emergency stop file detected: %s\n", c.stopFile)
-				c.triggerStop("stop file detected")
+				c.triggerStop("stop file detected", 2)
 				return
 			}
 		}
 	}
 }
 
-// watchSignals listens for OS signals
+// watchSignals listens for OS signals. The first signal triggers the
+// stop; a second one forces exit without waiting out the grace period.
 func (c *Controller) watchSignals(ctx context.Context) {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
 
-	select {
-	case <-ctx.Done():
-		signal.Stop(sigCh)
-		return
-	case sig := <-sigCh:
-		fmt.Printf("🛑 Emergency stop signal received: %v\n", sig)
-		c.triggerStop(fmt.Sprintf("signal: %v", sig))
-		signal.Stop(sigCh)
-		return
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-sigCh:
+			code := 1
+			if s, ok := sig.(syscall.Signal); ok {
+				code = 128 + int(s)
+			}
+			if c.IsStopped() {
+				c.force(fmt.Sprintf("second signal: %v", sig), code)
+				return
+			}
+			fmt.Printf("🛑 Emergency stop signal received: %v\n", sig)
+			c.triggerStop(fmt.Sprintf("signal: %v", sig), code)
+		}
 	}
 }
 
@@ -103,8 +122,9 @@ func (c *Controller) checkStopFile() bool {
 	return err == nil
 }
 
-// triggerStop triggers the emergency stop
-func (c *Controller) triggerStop(reason string) {
+// triggerStop triggers the emergency stop. exitCode is used if the grace
+// period expires.
+func (c *Controller) triggerStop(reason string, exitCode int) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -121,6 +141,34 @@ func (c *Controller) triggerStop(reason string) {
 		fmt.Printf("   Executing emergency callback %d/%d...\n", i+1, len(c.callbacks))
 		callback()
 	}
+
+	if c.gracePeriod > 0 {
+		fmt.Printf("   Teardown has %s before forced exit\n", c.gracePeriod)
+		time.AfterFunc(c.gracePeriod, func() {
+			c.force(fmt.Sprintf("grace period of %s expired", c.gracePeriod), exitCode)
+		})
+	}
+}
+
+// force runs the OnForce callbacks and exits the process.
+func (c *Controller) force(reason string, exitCode int) {
+	c.forceOnce.Do(func() {
+		fmt.Printf("🚨 FORCED EXIT: %s\n", reason)
+		c.mutex.RLock()
+		callbacks := c.forceCallbacks
+		c.mutex.RUnlock()
+		for _, callback := range callbacks {
+			callback()
+		}
+		os.Exit(exitCode)
+	})
+}
+
+// IsStopped reports whether a stop has been triggered.
+func (c *Controller) IsStopped() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.stopped
 }
 
 // OnStop registers a callback to execute when stop is triggered
@@ -129,3 +177,11 @@ func (c *Controller) OnStop(callback func()) {
 	defer c.mutex.Unlock()
 	c.callbacks = append(c.callbacks, callback)
 }
+
+// OnForce registers a last-resort callback run just before a forced exit.
+// It should be quick and bounded: the process exits when it returns.
+func (c *Controller) OnForce(callback func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.forceCallbacks = append(c.forceCallbacks, callback)
+}
@@ -0,0 +1,306 @@
+// Package gameday implements human-in-the-loop gates for GameDay
+// exercises: the orchestrator pauses at configured points in the lifecycle
+// and waits for an operator (terminal) or an external approver (webhook)
+// before continuing, announcing each gate as it goes.
+package gameday
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Gate names a pause point in the test lifecycle.
+type Gate string
+
+const (
+	// GateBeforeInject pauses after the pre-fault health check, before any
+	// fault is installed. Rejecting it aborts the test with nothing to
+	// undo.
+	GateBeforeInject Gate = "before_inject"
+	// GateBeforeTeardown pauses after cooldown while faults are still
+	// active, so operators can observe the degraded system. Teardown always
+	// runs afterwards; the answer is only recorded.
+	GateBeforeTeardown Gate = "before_teardown"
+)
+
+// ValidGates lists every gate the orchestrator knows.
+var ValidGates = []Gate{GateBeforeInject, GateBeforeTeardown}
+
+// Approval modes.
+const (
+	ApprovalStdin   = "stdin"
+	ApprovalWebhook = "webhook"
+)
+
+// Config configures a Gatekeeper.
+type Config struct {
+	// Gates to pause at. Empty means every gate in ValidGates.
+	Gates []Gate
+	// Approval is ApprovalStdin (default) or ApprovalWebhook.
+	Approval string
+	// ApprovalURL is polled with GET ?test_id=…&gate=… in webhook mode. A
+	// 2xx response with {"approved": true} proceeds, {"approved": false}
+	// rejects; anything else (404, no decision yet) keeps waiting.
+	ApprovalURL string
+	// AnnounceURL, when set, receives a JSON POST for every gate event.
+	AnnounceURL string
+	// Timeout bounds each wait; an unanswered gate is rejected. Zero means
+	// wait indefinitely.
+	Timeout time.Duration
+	// PollInterval is the webhook polling interval (default 5s).
+	PollInterval time.Duration
+	// Labels are the run's --label metadata, copied into every Event.
+	Labels map[string]string
+}
+
+// Scenario identifies the scenario a gate belongs to, with the
+// annotations that tell whoever sees an announcement what to expect and
+// who to ask.
+type Scenario struct {
+	Name           string
+	Owner          string
+	RunbookURL     string
+	ExpectedImpact string
+}
+
+// Event is the announcement body POSTed to AnnounceURL.
+type Event struct {
+	Event          string            `json:"event"` // waiting, approved, rejected
+	Gate           Gate              `json:"gate"`
+	TestID         string            `json:"test_id"`
+	Scenario       string            `json:"scenario"`
+	Owner          string            `json:"owner,omitempty"`
+	RunbookURL     string            `json:"runbook_url,omitempty"`
+	ExpectedImpact string            `json:"expected_impact,omitempty"`
+	Time           time.Time         `json:"time"`
+	Message        string            `json:"message,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+func newEvent(event string, gate Gate, testID string, scen Scenario, message string) Event {
+	return Event{
+		Event:          event,
+		Gate:           gate,
+		TestID:         testID,
+		Scenario:       scen.Name,
+		Owner:          scen.Owner,
+		RunbookURL:     scen.RunbookURL,
+		ExpectedImpact: scen.ExpectedImpact,
+		Message:        message,
+	}
+}
+
+// ErrRejected is returned by Wait when a gate is rejected or times out.
+type ErrRejected struct {
+	Gate   Gate
+	Reason string
+}
+
+func (e *ErrRejected) Error() string {
+	return fmt.Sprintf("gameday gate %s rejected: %s", e.Gate, e.Reason)
+}
+
+// Gatekeeper blocks at configured gates until approval.
+type Gatekeeper struct {
+	cfg    Config
+	gates  map[Gate]bool
+	client *http.Client
+	in     io.Reader
+	out    io.Writer
+}
+
+// New validates cfg and creates a Gatekeeper.
+func New(cfg Config) (*Gatekeeper, error) {
+	if cfg.Approval == "" {
+		cfg.Approval = ApprovalStdin
+	}
+	if cfg.Approval != ApprovalStdin && cfg.Approval != ApprovalWebhook {
+		return nil, fmt.Errorf("gameday: unknown approval mode %q (use %s or %s)", cfg.Approval, ApprovalStdin, ApprovalWebhook)
+	}
+	if cfg.Approval == ApprovalWebhook && cfg.ApprovalURL == "" {
+		return nil, fmt.Errorf("gameday: approval_url is required for webhook approval")
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if len(cfg.Gates) == 0 {
+		cfg.Gates = ValidGates
+	}
+
+	gates := make(map[Gate]bool, len(cfg.Gates))
+	for _, g := range cfg.Gates {
+		known := false
+		for _, v := range ValidGates {
+			known = known || g == v
+		}
+		if !known {
+			return nil, fmt.Errorf("gameday: unknown gate %q", g)
+		}
+		gates[g] = true
+	}
+
+	return &Gatekeeper{
+		cfg:    cfg,
+		gates:  gates,
+		client: &http.Client{Timeout: 10 * time.Second},
+		in:     os.Stdin,
+		out:    os.Stdout,
+	}, nil
+}
+
+// Enabled reports whether the gatekeeper pauses at gate.
+func (g *Gatekeeper) Enabled(gate Gate) bool {
+	return g != nil && g.gates[gate]
+}
+
+// Wait announces gate and blocks until it is approved. It returns
+// *ErrRejected when the gate is rejected or times out, and ctx.Err() when
+// ctx is cancelled. It returns nil immediately for gates not configured.
+func (g *Gatekeeper) Wait(ctx context.Context, gate Gate, testID string, scen Scenario, summary string) error {
+	if !g.Enabled(gate) {
+		return nil
+	}
+
+	if g.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.cfg.Timeout)
+		defer cancel()
+	}
+
+	g.announce(newEvent("waiting", gate, testID, scen, summary))
+
+	var err error
+	if g.cfg.Approval == ApprovalWebhook {
+		fmt.Fprintf(g.out, "⏸  GameDay gate %s: waiting for approval from %s\n", gate, g.cfg.ApprovalURL)
+		err = g.waitWebhook(ctx, gate, testID)
+	} else {
+		err = g.waitStdin(ctx, gate, scen, summary)
+	}
+
+	if err == context.DeadlineExceeded && g.cfg.Timeout > 0 {
+		err = &ErrRejected{Gate: gate, Reason: fmt.Sprintf("no approval within %s", g.cfg.Timeout)}
+	}
+
+	if err != nil {
+		g.announce(newEvent("rejected", gate, testID, scen, err.Error()))
+		return err
+	}
+	g.announce(newEvent("approved", gate, testID, scen, ""))
+	fmt.Fprintf(g.out, "▶  GameDay gate %s approved\n", gate)
+	return nil
+}
+
+// waitStdin prompts on the terminal. The read runs in a goroutine so a
+// timeout or cancellation is not stuck behind a blocking read.
+func (g *Gatekeeper) waitStdin(ctx context.Context, gate Gate, scen Scenario, summary string) error {
+	fmt.Fprintf(g.out, "\n⏸  GameDay gate %s", gate)
+	if summary != "" {
+		fmt.Fprintf(g.out, " — %s", summary)
+	}
+	if scen.ExpectedImpact != "" {
+		fmt.Fprintf(g.out, "\n   Expected impact: %s", scen.ExpectedImpact)
+	}
+	if scen.RunbookURL != "" {
+		fmt.Fprintf(g.out, "\n   Runbook: %s", scen.RunbookURL)
+	}
+	if scen.Owner != "" {
+		fmt.Fprintf(g.out, "\n   Owner: %s", scen.Owner)
+	}
+	fmt.Fprintf(g.out, "\n   Type 'yes' to proceed or 'no' to abort: ")
+
+	answers := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(g.in).ReadString('\n')
+		answers <- strings.ToLower(strings.TrimSpace(line))
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case answer := <-answers:
+		if answer == "yes" || answer == "y" {
+			return nil
+		}
+		return &ErrRejected{Gate: gate, Reason: fmt.Sprintf("operator answered %q", answer)}
+	}
+}
+
+// waitWebhook polls ApprovalURL until it returns a decision.
+func (g *Gatekeeper) waitWebhook(ctx context.Context, gate Gate, testID string) error {
+	u, err := url.Parse(g.cfg.ApprovalURL)
+	if err != nil {
+		return fmt.Errorf("gameday: invalid approval_url: %w", err)
+	}
+	q := u.Query()
+	q.Set("test_id", testID)
+	q.Set("gate", string(gate))
+	u.RawQuery = q.Encode()
+
+	ticker := time.NewTicker(g.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if approved, decided := g.pollApproval(ctx, u.String()); decided {
+			if approved {
+				return nil
+			}
+			return &ErrRejected{Gate: gate, Reason: "rejected by approval webhook"}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollApproval makes one approval request. decided is false when the
+// approver has not answered yet or could not be reached.
+func (g *Gatekeeper) pollApproval(ctx context.Context, u string) (approved, decided bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return false, false
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return false, false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return false, false
+	}
+
+	var body struct {
+		Approved *bool `json:"approved"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Approved == nil {
+		return false, false
+	}
+	return *body.Approved, true
+}
+
+// announce POSTs ev to AnnounceURL. Failures are printed, never fatal: a
+// broken chat hook must not strand a GameDay mid-exercise.
+func (g *Gatekeeper) announce(ev Event) {
+	if g.cfg.AnnounceURL == "" {
+		return
+	}
+	ev.Time = time.Now()
+	ev.Labels = g.cfg.Labels
+	data, _ := json.Marshal(ev)
+	resp, err := g.client.Post(g.cfg.AnnounceURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		fmt.Fprintf(g.out, "⚠ GameDay announcement failed: %v\n", err)
+		return
+	}
+	resp.Body.Close()
+}
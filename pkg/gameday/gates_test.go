@@ -0,0 +1,141 @@
+package gameday
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"defaults to stdin", Config{}, false},
+		{"webhook with url", Config{Approval: ApprovalWebhook, ApprovalURL: "http://localhost:1"}, false},
+		{"webhook without url", Config{Approval: ApprovalWebhook}, true},
+		{"unknown mode", Config{Approval: "email"}, true},
+		{"unknown gate", Config{Gates: []Gate{"before_warmup"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaitStdin(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"yes proceeds", "yes\n", false},
+		{"y proceeds", "Y\n", false},
+		{"no rejects", "no\n", true},
+		{"empty rejects", "\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := New(Config{Gates: []Gate{GateBeforeInject}})
+			g.in = strings.NewReader(tt.input)
+			g.out = io.Discard
+
+			err := g.Wait(context.Background(), GateBeforeInject, "test-1", Scenario{Name: "scenario"}, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Wait() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaitSkipsUnconfiguredGate(t *testing.T) {
+	g, _ := New(Config{Gates: []Gate{GateBeforeInject}})
+	g.in = strings.NewReader("")
+	g.out = io.Discard
+	if err := g.Wait(context.Background(), GateBeforeTeardown, "test-1", Scenario{Name: "scenario"}, ""); err != nil {
+		t.Errorf("Wait() on unconfigured gate = %v, want nil", err)
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	g, _ := New(Config{Timeout: 20 * time.Millisecond})
+	reader, _ := io.Pipe() // never written: the operator does not answer
+	g.in = reader
+	g.out = io.Discard
+
+	var rejected *ErrRejected
+	if err := g.Wait(context.Background(), GateBeforeInject, "test-1", Scenario{Name: "scenario"}, ""); !errors.As(err, &rejected) {
+		t.Errorf("Wait() error = %v, want *ErrRejected", err)
+	}
+}
+
+func TestWaitWebhook(t *testing.T) {
+	var mu sync.Mutex
+	var events, runbooks []string
+	polls := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/announce":
+			var ev Event
+			_ = json.NewDecoder(r.Body).Decode(&ev)
+			events = append(events, ev.Event)
+			runbooks = append(runbooks, ev.RunbookURL)
+		case "/approve":
+			if r.URL.Query().Get("gate") != string(GateBeforeTeardown) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			polls++
+			if polls < 2 {
+				w.WriteHeader(http.StatusNotFound) // no decision yet
+				return
+			}
+			w.Write([]byte(`{"approved":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	g, err := New(Config{
+		Approval:     ApprovalWebhook,
+		ApprovalURL:  srv.URL + "/approve",
+		AnnounceURL:  srv.URL + "/announce",
+		PollInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	g.out = io.Discard
+
+	scen := Scenario{Name: "scenario", RunbookURL: "https://runbooks.example/bor-stall"}
+	if err := g.Wait(context.Background(), GateBeforeTeardown, "test-1", scen, ""); err != nil {
+		t.Fatalf("Wait() unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(events, ",") != "waiting,approved" {
+		t.Errorf("announcements = %v, want [waiting approved]", events)
+	}
+	for _, r := range runbooks {
+		if r != scen.RunbookURL {
+			t.Errorf("announcement runbook_url = %q, want %q", r, scen.RunbookURL)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Package capture records a target's traffic with tcpdump in its sidecar,
+// which shares the target's network namespace.
+package capture
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	pcapFile = "/tmp/.chaos-capture.pcap"
+	pidFile  = "/tmp/.chaos-capture.pid"
+	logFile  = "/tmp/.chaos-capture.log"
+
+	// snapLen keeps headers and the start of each payload, which is what a
+	// chaos run needs to see (retransmits, resets, drops) at a fraction of
+	// the size of full packets.
+	snapLen = 256
+	// recordOverhead is the per-packet pcap record header.
+	recordOverhead = 16
+)
+
+// SidecarManager is the part of the sidecar manager a capture needs.
+type SidecarManager interface {
+	CreateSidecar(ctx context.Context, targetContainerID string) (string, error)
+	ExecInSidecar(ctx context.Context, targetContainerID string, cmd []string) (string, error)
+}
+
+// Capturer starts and stops tcpdump in target sidecars.
+type Capturer struct {
+	sidecar SidecarManager
+}
+
+// New creates a Capturer.
+func New(sidecar SidecarManager) *Capturer {
+	return &Capturer{sidecar: sidecar}
+}
+
+// packetLimit is the packet count that keeps a pcap under maxBytes. tcpdump
+// has no plain size cap (-C rotates files), so the size is bounded by
+// packets times snapLen instead.
+func packetLimit(maxBytes int64) int64 {
+	n := maxBytes / (snapLen + recordOverhead)
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// startScript launches tcpdump detached from the exec session. timeout
+// stops it if Stop is never called; -U flushes every packet so a capture
+// that is killed still leaves a readable file.
+func startScript(maxBytes int64, maxDuration time.Duration) string {
+	return fmt.Sprintf("command -v tcpdump >/dev/null 2>&1 || { echo 'sidecar image has no tcpdump'; exit 1; }; "+
+		"[ -f %[1]s ] && kill -0 $(cat %[1]s) 2>/dev/null && exit 0; "+
+		"rm -f %[2]s %[3]s; "+
+		"setsid timeout -s INT %[4]d tcpdump -i any -U -n -s %[5]d -c %[6]d -w %[2]s > %[3]s 2>&1 < /dev/null & echo $! > %[1]s; "+
+		"sleep 1; kill -0 $(cat %[1]s) 2>/dev/null || { cat %[3]s; exit 1; }; echo ok",
+		pidFile, pcapFile, logFile, int(maxDuration.Seconds()), snapLen, packetLimit(maxBytes))
+}
+
+// stopScript interrupts tcpdump so it closes the file, then prints the
+// pcap base64-encoded (exec output is read as text).
+const stopScript = "[ -f " + pidFile + " ] || exit 0; " +
+	"kill -INT $(cat " + pidFile + ") 2>/dev/null; " +
+	"for i in 1 2 3 4 5; do kill -0 $(cat " + pidFile + ") 2>/dev/null || break; sleep 1; done; " +
+	"kill -KILL $(cat " + pidFile + ") 2>/dev/null; " +
+	"[ -f " + pcapFile + " ] && base64 " + pcapFile + "; " +
+	"rm -f " + pidFile + " " + pcapFile + " " + logFile
+
+// Start begins capturing in the target's sidecar, creating the sidecar if
+// needed. It is a no-op when a capture is already running there.
+func (c *Capturer) Start(ctx context.Context, targetContainerID string, maxBytes int64, maxDuration time.Duration) error {
+	if _, err := c.sidecar.CreateSidecar(ctx, targetContainerID); err != nil {
+		return fmt.Errorf("failed to create sidecar: %w", err)
+	}
+	out, err := c.sidecar.ExecInSidecar(ctx, targetContainerID, []string{"sh", "-c", startScript(maxBytes, maxDuration)})
+	if err != nil {
+		return fmt.Errorf("failed to start tcpdump: %w (output: %s)", err, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// Stop ends the capture in the target's sidecar and returns the pcap. It
+// returns nil data when no capture was running.
+func (c *Capturer) Stop(ctx context.Context, targetContainerID string) ([]byte, error) {
+	out, err := c.sidecar.ExecInSidecar(ctx, targetContainerID, []string{"sh", "-c", stopScript})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop tcpdump: %w", err)
+	}
+	encoded := strings.Join(strings.Fields(out), "")
+	if encoded == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pcap: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,71 @@
+package capture
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSidecar answers the stop script with a canned pcap and records the
+// start script.
+type fakeSidecar struct {
+	pcap  []byte
+	execs []string
+}
+
+func (f *fakeSidecar) CreateSidecar(ctx context.Context, targetContainerID string) (string, error) {
+	return "sidecar-" + targetContainerID, nil
+}
+
+func (f *fakeSidecar) ExecInSidecar(ctx context.Context, targetContainerID string, cmd []string) (string, error) {
+	script := cmd[len(cmd)-1]
+	f.execs = append(f.execs, script)
+	if script == stopScript {
+		if f.pcap == nil {
+			return "", nil
+		}
+		// base64 wraps its output at 76 columns.
+		enc := base64.StdEncoding.EncodeToString(f.pcap)
+		var b strings.Builder
+		for len(enc) > 76 {
+			b.WriteString(enc[:76] + "\n")
+			enc = enc[76:]
+		}
+		b.WriteString(enc + "\n")
+		return b.String(), nil
+	}
+	return "ok\n", nil
+}
+
+func TestCapturer_StartStop(t *testing.T) {
+	pcap := []byte(strings.Repeat("\xd4\xc3\xb2\xa1pcap-bytes", 20))
+	sidecar := &fakeSidecar{pcap: pcap}
+	c := New(sidecar)
+
+	if err := c.Start(context.Background(), "abc", 1<<20, 90*time.Second); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	start := sidecar.execs[0]
+	for _, want := range []string{"timeout -s INT 90 tcpdump", "-s 256", "-c 3855", "-w " + pcapFile} {
+		if !strings.Contains(start, want) {
+			t.Errorf("start script missing %q: %s", want, start)
+		}
+	}
+
+	got, err := c.Stop(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if string(got) != string(pcap) {
+		t.Errorf("Stop() = %q, want %q", got, pcap)
+	}
+}
+
+func TestCapturer_StopWithoutCapture(t *testing.T) {
+	got, err := New(&fakeSidecar{}).Stop(context.Background(), "abc")
+	if err != nil || got != nil {
+		t.Errorf("Stop() = %q, %v; want nil, nil", got, err)
+	}
+}
@@ -0,0 +1,167 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+)
+
+// containerEvents follows Docker state changes (start, die, health_status)
+// of a set of containers, so waits block on the daemon's event stream
+// instead of polling ContainerInspect. Open it before acting on the
+// containers: the stream does not replay events from before it was opened.
+type containerEvents struct {
+	dockerClient *client.Client
+	cancel       context.CancelFunc
+	msgs         <-chan events.Message
+	errs         <-chan error
+
+	// seen holds events read while waiting on another container.
+	seen []events.Message
+	// err is set once the stream has failed; it delivers nothing after.
+	err error
+}
+
+// watchContainers opens an event stream for the given containers.
+func watchContainers(ctx context.Context, dockerClient *client.Client, containerIDs ...string) *containerEvents {
+	ctx, cancel := context.WithCancel(ctx)
+	args := filters.NewArgs(
+		filters.Arg("type", string(events.ContainerEventType)),
+		filters.Arg("event", string(events.ActionStart)),
+		filters.Arg("event", string(events.ActionDie)),
+		filters.Arg("event", string(events.ActionHealthStatus)),
+	)
+	for _, id := range containerIDs {
+		args.Add("container", id)
+	}
+	msgs, errs := dockerClient.Events(ctx, types.EventsOptions{Filters: args})
+	return &containerEvents{dockerClient: dockerClient, cancel: cancel, msgs: msgs, errs: errs}
+}
+
+// close ends the stream.
+func (w *containerEvents) close() {
+	w.cancel()
+}
+
+// next returns the next event for containerID, oldest buffered one first.
+func (w *containerEvents) next(ctx context.Context, containerID string) (events.Message, error) {
+	for i, msg := range w.seen {
+		if isContainer(msg, containerID) {
+			w.seen = append(w.seen[:i], w.seen[i+1:]...)
+			return msg, nil
+		}
+	}
+	if w.err != nil {
+		return events.Message{}, w.err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return events.Message{}, ctx.Err()
+		case err := <-w.errs:
+			if err == nil {
+				err = errors.New("stream closed")
+			}
+			w.err = fmt.Errorf("docker events: %w", err)
+			return events.Message{}, w.err
+		case msg := <-w.msgs:
+			if isContainer(msg, containerID) {
+				return msg, nil
+			}
+			w.seen = append(w.seen, msg)
+		}
+	}
+}
+
+// waitForStop waits for the container to stop and returns when it did.
+func (w *containerEvents) waitForStop(ctx context.Context, containerID string, timeout time.Duration) (time.Time, error) {
+	return w.waitFor(ctx, containerID, timeout, events.ActionDie)
+}
+
+// waitForRunning waits for the container to start and returns when it did.
+func (w *containerEvents) waitForRunning(ctx context.Context, containerID string, timeout time.Duration) (time.Time, error) {
+	return w.waitFor(ctx, containerID, timeout, events.ActionStart)
+}
+
+// waitFor blocks until containerID emits action (die or start) and returns
+// the event's time. The container is inspected once before blocking: a
+// stop or start the daemon treats as a no-op emits no event, and Docker's
+// own StartedAt/FinishedAt are as exact as the event's timestamp.
+func (w *containerEvents) waitFor(ctx context.Context, containerID string, timeout time.Duration, action events.Action) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for i, msg := range w.seen {
+		if isContainer(msg, containerID) && msg.Action == action {
+			w.seen = append(w.seen[:i], w.seen[i+1:]...)
+			return eventTime(msg), nil
+		}
+	}
+
+	inspect, err := w.dockerClient.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if at, ok := reachedAt(inspect.State, action); ok {
+		return at, nil
+	}
+
+	for {
+		msg, err := w.next(ctx, containerID)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return time.Time{}, fmt.Errorf("no %s event within %v", action, timeout)
+			}
+			return time.Time{}, err
+		}
+		if msg.Action == action {
+			return eventTime(msg), nil
+		}
+	}
+}
+
+// reachedAt reports whether an inspected container is already in the state
+// action leads to, and since when.
+func reachedAt(state *types.ContainerState, action events.Action) (time.Time, bool) {
+	if state == nil {
+		return time.Time{}, false
+	}
+	switch action {
+	case events.ActionDie:
+		return dockerTime(state.FinishedAt), !state.Running
+	case events.ActionStart:
+		return dockerTime(state.StartedAt), state.Running
+	}
+	return time.Time{}, false
+}
+
+// isContainer reports whether msg is about containerID, which may be a
+// short ID.
+func isContainer(msg events.Message, containerID string) bool {
+	return strings.HasPrefix(msg.Actor.ID, containerID)
+}
+
+// eventTime is when the daemon emitted msg.
+func eventTime(msg events.Message) time.Time {
+	if msg.TimeNano != 0 {
+		return time.Unix(0, msg.TimeNano)
+	}
+	return time.Unix(msg.Time, 0)
+}
+
+// dockerTime parses an inspect timestamp; the zero time when Docker has
+// none (it reports "0001-01-01T00:00:00Z").
+func dockerTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
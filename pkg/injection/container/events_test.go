@@ -0,0 +1,73 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+)
+
+func TestContainerHealthFollowsEvents(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := func(action events.Action, offset time.Duration) events.Message {
+		return events.Message{Action: action, Actor: events.Actor{ID: "abc123"}, TimeNano: base.Add(offset).UnixNano()}
+	}
+
+	h := newContainerHealth(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{
+		Running:    false,
+		FinishedAt: base.Format(time.RFC3339Nano),
+		StartedAt:  "0001-01-01T00:00:00Z",
+		Health:     &types.Health{Status: types.Unhealthy},
+	}}})
+	if got := h.reason(); got != "container not running" {
+		t.Fatalf("reason() = %q before start", got)
+	}
+
+	h.apply(event(events.ActionStart, 2*time.Second))
+	if got := h.reason(); got != "docker healthcheck is starting" {
+		t.Fatalf("reason() = %q after start", got)
+	}
+
+	h.apply(event(events.ActionHealthStatusHealthy, 7*time.Second))
+	if got := h.reason(); got != "" {
+		t.Fatalf("reason() = %q after healthy", got)
+	}
+	if !h.stoppedAt.Equal(base) || !h.startedAt.Equal(base.Add(2*time.Second)) || !h.readyAt.Equal(base.Add(7*time.Second)) {
+		t.Errorf("timestamps = stopped %v, started %v, ready %v", h.stoppedAt, h.startedAt, h.readyAt)
+	}
+
+	h.apply(event(events.ActionDie, 9*time.Second))
+	if got := h.reason(); got != "container not running" || !h.stoppedAt.Equal(base.Add(9*time.Second)) {
+		t.Errorf("after die: reason() = %q, stoppedAt = %v", got, h.stoppedAt)
+	}
+}
+
+func TestContainerHealthWithoutHealthcheck(t *testing.T) {
+	started := time.Date(2026, 1, 1, 0, 0, 5, 0, time.UTC)
+	h := newContainerHealth(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{
+		Running:   true,
+		StartedAt: started.Format(time.RFC3339Nano),
+	}}})
+	if got := h.reason(); got != "" {
+		t.Fatalf("reason() = %q for a running container without healthcheck", got)
+	}
+	if !h.readyAt.Equal(started) {
+		t.Errorf("readyAt = %v, want %v", h.readyAt, started)
+	}
+}
+
+func TestReachedAt(t *testing.T) {
+	finished := "2026-01-01T00:00:03.5Z"
+	stopped := &types.ContainerState{Running: false, FinishedAt: finished}
+
+	if at, ok := reachedAt(stopped, events.ActionDie); !ok || at.Format(time.RFC3339Nano) != finished {
+		t.Errorf("reachedAt(stopped, die) = %v, %v", at, ok)
+	}
+	if _, ok := reachedAt(stopped, events.ActionStart); ok {
+		t.Error("reachedAt(stopped, start) = true")
+	}
+	if _, ok := reachedAt(nil, events.ActionDie); ok {
+		t.Error("reachedAt(nil, die) = true")
+	}
+}
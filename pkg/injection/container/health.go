@@ -0,0 +1,232 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/rs/zerolog/log"
+)
+
+// HealthCheck describes what a revived container must pass before it counts
+// as recovered. The container must be running, and its Docker healthcheck
+// (if the image defines one) must report healthy; HTTPURL and RPCURL add
+// application-level probes on top.
+type HealthCheck struct {
+	// HTTPURL must answer GET with a 2xx status
+	HTTPURL string
+
+	// RPCURL must answer a JSON-RPC call of RPCMethod with a result
+	RPCURL    string
+	RPCMethod string
+
+	// Timeout bounds the wait
+	Timeout time.Duration
+}
+
+// healthPollInterval is how often WaitHealthy re-runs the HTTP/RPC probes
+// while Docker reports the container ready. Docker-level state is not
+// polled; it follows the container's events.
+const healthPollInterval = time.Second
+
+// Revival is when Docker saw a revived container stop and start again,
+// and when it first passed its health check.
+type Revival struct {
+	StoppedAt time.Time
+	StartedAt time.Time
+	HealthyAt time.Time
+}
+
+// Latency is the time from the container's start to its first passing
+// check.
+func (r Revival) Latency() time.Duration {
+	return r.HealthyAt.Sub(r.StartedAt)
+}
+
+// WaitHealthy blocks until the container passes hc and returns when it
+// stopped, started and became healthy.
+func (m *Manager) WaitHealthy(ctx context.Context, containerID string, hc HealthCheck) (Revival, error) {
+	ctx, cancel := context.WithTimeout(ctx, hc.Timeout)
+	defer cancel()
+
+	watch := watchContainers(ctx, m.dockerClient, containerID)
+	defer watch.close()
+
+	inspect, err := m.dockerClient.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return Revival{}, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	state := newContainerHealth(inspect)
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	probed := hc.HTTPURL != "" || hc.RPCURL != ""
+	for {
+		lastReason := state.reason()
+		if lastReason == "" {
+			lastReason = probeHealth(ctx, httpClient, hc)
+		}
+		if lastReason == "" {
+			healthyAt := state.readyAt
+			if probed {
+				healthyAt = time.Now()
+			}
+			return Revival{StoppedAt: state.stoppedAt, StartedAt: state.startedAt, HealthyAt: healthyAt}, nil
+		}
+
+		log.Debug().Str("container", containerID).Str("reason", lastReason).Msg("Waiting for container to become healthy")
+
+		// Until Docker reports the container ready only an event can
+		// change that; after, the probes are retried on a timer.
+		wait := hc.Timeout
+		if state.reason() == "" {
+			wait = healthPollInterval
+		}
+		waitCtx, waitCancel := context.WithTimeout(ctx, wait)
+		msg, err := watch.next(waitCtx, containerID)
+		waitCancel()
+		switch {
+		case ctx.Err() != nil:
+			return Revival{}, fmt.Errorf("not healthy within %v: %s", hc.Timeout, lastReason)
+		case err == nil:
+			state.apply(msg)
+		case !errors.Is(err, context.DeadlineExceeded):
+			return Revival{}, fmt.Errorf("%w (last state: %s)", err, lastReason)
+		}
+	}
+}
+
+// containerHealth is a container's Docker-level readiness, seeded from one
+// inspect and kept current from its events.
+type containerHealth struct {
+	running bool
+	// status is the Docker healthcheck status; "" when the image defines
+	// no healthcheck.
+	status    string
+	stoppedAt time.Time
+	startedAt time.Time
+	// readyAt is when the container last became running and, with a
+	// healthcheck, healthy.
+	readyAt time.Time
+}
+
+func newContainerHealth(inspect types.ContainerJSON) *containerHealth {
+	h := &containerHealth{}
+	if inspect.State == nil {
+		return h
+	}
+	h.running = inspect.State.Running
+	h.stoppedAt = dockerTime(inspect.State.FinishedAt)
+	h.startedAt = dockerTime(inspect.State.StartedAt)
+	h.readyAt = h.startedAt
+	if health := inspect.State.Health; health != nil {
+		h.status = health.Status
+		if n := len(health.Log); n > 0 && h.status == types.Healthy {
+			h.readyAt = health.Log[n-1].End
+		}
+	}
+	return h
+}
+
+// apply updates the state from one of the container's events.
+func (h *containerHealth) apply(msg events.Message) {
+	at := eventTime(msg)
+	switch {
+	case msg.Action == events.ActionStart:
+		h.running, h.startedAt, h.readyAt = true, at, at
+		if h.status != "" {
+			h.status = types.Starting
+		}
+	case msg.Action == events.ActionDie:
+		h.running, h.stoppedAt = false, at
+	case strings.HasPrefix(string(msg.Action), string(events.ActionHealthStatus)+":"):
+		h.status = strings.TrimSpace(strings.TrimPrefix(string(msg.Action), string(events.ActionHealthStatus)+":"))
+		if h.status == types.Healthy {
+			h.readyAt = at
+		}
+	}
+}
+
+// reason returns why Docker does not report the container ready, or "".
+func (h *containerHealth) reason() string {
+	if !h.running {
+		return "container not running"
+	}
+	if h.status != "" && h.status != types.Healthy {
+		return fmt.Sprintf("docker healthcheck is %s", h.status)
+	}
+	return ""
+}
+
+// probeHealth runs hc's application-level probes and returns why one
+// failed, or "".
+func probeHealth(ctx context.Context, httpClient *http.Client, hc HealthCheck) string {
+	if hc.HTTPURL != "" {
+		if err := probeHTTP(ctx, httpClient, hc.HTTPURL); err != nil {
+			return fmt.Sprintf("http probe: %v", err)
+		}
+	}
+	if hc.RPCURL != "" {
+		if err := probeRPC(ctx, httpClient, hc.RPCURL, hc.RPCMethod); err != nil {
+			return fmt.Sprintf("rpc probe: %v", err)
+		}
+	}
+	return ""
+}
+
+// probeHTTP requires a 2xx response to GET url.
+func probeHTTP(ctx context.Context, httpClient *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// probeRPC requires a JSON-RPC result (not an error) for method on url.
+func probeRPC(ctx context.Context, httpClient *http.Client, url, method string) error {
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":%q,"params":[]}`, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("status %d, invalid JSON-RPC response: %w", resp.StatusCode, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%s: %s", method, rpcResp.Error.Message)
+	}
+	if len(rpcResp.Result) == 0 || string(rpcResp.Result) == "null" {
+		return fmt.Errorf("%s returned no result", method)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package container
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbeRPC(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"result", `{"jsonrpc":"2.0","id":1,"result":"0x1b4"}`, false},
+		{"rpc error", `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"syncing"}}`, true},
+		{"null result", `{"jsonrpc":"2.0","id":1,"result":null}`, true},
+		{"not json", `starting up`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			err := probeRPC(context.Background(), srv.Client(), srv.URL, "eth_blockNumber")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("probeRPC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProbeHTTP(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusServiceUnavailable} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		err := probeHTTP(context.Background(), srv.Client(), srv.URL)
+		srv.Close()
+		if (err != nil) != (status != http.StatusOK) {
+			t.Errorf("probeHTTP() with status %d: error = %v", status, err)
+		}
+	}
+}
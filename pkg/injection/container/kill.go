@@ -14,14 +14,12 @@ import (
 // KillManager handles container kill operations
 type KillManager struct {
 	dockerClient *client.Client
-	restartMgr   *RestartManager
 }
 
 // NewKillManager creates a new KillManager
 func NewKillManager(dockerClient *client.Client) *KillManager {
 	return &KillManager{
 		dockerClient: dockerClient,
-		restartMgr:   NewRestartManager(dockerClient),
 	}
 }
 
@@ -38,6 +36,9 @@ func (km *KillManager) KillContainer(ctx context.Context, containerID string, pa
 		Bool("restart", params.Restart).
 		Msg("Killing container")
 
+	watch := watchContainers(ctx, km.dockerClient, containerID)
+	defer watch.close()
+
 	// 1. Kill container with specified signal
 	if err := km.dockerClient.ContainerKill(ctx, containerID, signal); err != nil {
 		// "container is not running" is expected when another goroutine already
@@ -53,7 +54,7 @@ func (km *KillManager) KillContainer(ctx context.Context, containerID string, pa
 	log.Debug().Str("container", containerID).Msg("Kill signal sent")
 
 	// 2. Wait for container to stop
-	if err := km.restartMgr.waitForStop(ctx, containerID, 30*time.Second); err != nil {
+	if _, err := watch.waitForStop(ctx, containerID, 30*time.Second); err != nil {
 		// Container might already be stopped, which is fine
 		log.Warn().Err(err).Str("container", containerID).Msg("Container state check after kill")
 	}
@@ -74,7 +75,7 @@ func (km *KillManager) KillContainer(ctx context.Context, containerID string, pa
 		}
 
 		// Wait for container to start
-		if err := km.restartMgr.waitForRunning(ctx, containerID, 30*time.Second); err != nil {
+		if _, err := watch.waitForRunning(ctx, containerID, 30*time.Second); err != nil {
 			return fmt.Errorf("container %s did not restart in time: %w", containerID, err)
 		}
 
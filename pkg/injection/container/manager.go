@@ -34,9 +34,10 @@ func (m *Manager) RestartContainersSimultaneous(ctx context.Context, containerID
 	return m.restartMgr.RestartContainersSimultaneous(ctx, containerIDs, params)
 }
 
-// RestartContainersStaggered restarts multiple containers with stagger
-func (m *Manager) RestartContainersStaggered(ctx context.Context, containerIDs []string, params RestartParams) error {
-	return m.restartMgr.RestartContainersStaggered(ctx, containerIDs, params)
+// RestartHeld restarts a container, running hook while it is held paused
+// right after start
+func (m *Manager) RestartHeld(ctx context.Context, containerID string, params RestartParams, hook func(context.Context) error) error {
+	return m.restartMgr.RestartHeld(ctx, containerID, params, hook)
 }
 
 // KillContainer kills a container
@@ -2,6 +2,7 @@ package container
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -34,6 +35,9 @@ func (rm *RestartManager) RestartContainer(ctx context.Context, containerID stri
 	// is applied upstream in the injector when the YAML field is absent.
 	gracePeriod := params.GracePeriod
 
+	watch := watchContainers(ctx, rm.dockerClient, containerID)
+	defer watch.close()
+
 	// 1. Stop container with grace period
 	stopOptions := container.StopOptions{
 		Timeout: func() *int { t := gracePeriod; return &t }(),
@@ -43,13 +47,14 @@ func (rm *RestartManager) RestartContainer(ctx context.Context, containerID stri
 		return fmt.Errorf("failed to stop container %s: %w", containerID, err)
 	}
 
-	log.Debug().Str("container", containerID).Msg("Container stopped")
-
 	// 2. Wait for container to fully stop
-	if err := rm.waitForStop(ctx, containerID, 30*time.Second); err != nil {
+	stoppedAt, err := watch.waitForStop(ctx, containerID, 30*time.Second)
+	if err != nil {
 		return fmt.Errorf("container %s did not stop in time: %w", containerID, err)
 	}
 
+	log.Debug().Str("container", containerID).Time("stopped_at", stoppedAt).Msg("Container stopped")
+
 	// 3. Optional delay before restart
 	if params.RestartDelay > 0 {
 		log.Debug().
@@ -64,16 +69,60 @@ func (rm *RestartManager) RestartContainer(ctx context.Context, containerID stri
 		return fmt.Errorf("failed to start container %s: %w", containerID, err)
 	}
 
-	log.Info().Str("container", containerID).Msg("Container restarted successfully")
-
 	// 5. Wait for container to be running (validators need time to initialize)
-	if err := rm.waitForRunning(ctx, containerID, 120*time.Second); err != nil {
+	startedAt, err := watch.waitForRunning(ctx, containerID, 120*time.Second)
+	if err != nil {
 		return fmt.Errorf("container %s did not start in time: %w", containerID, err)
 	}
 
+	log.Info().Str("container", containerID).Time("started_at", startedAt).Msg("Container restarted successfully")
+
 	return nil
 }
 
+// RestartHeld restarts a container but pauses it the moment Docker has
+// started it, runs hook, then unpauses it. A container's network namespace
+// only exists while it runs, so this is the earliest a namespace fault can
+// go in: the process has run only for the instant between start and pause.
+// The container is unpaused even when hook fails.
+func (rm *RestartManager) RestartHeld(ctx context.Context, containerID string, params RestartParams, hook func(context.Context) error) error {
+	log.Info().
+		Str("container", containerID).
+		Int("grace_period", params.GracePeriod).
+		Msg("Restarting container held paused")
+
+	gracePeriod := params.GracePeriod
+	stopOptions := container.StopOptions{
+		Timeout: func() *int { t := gracePeriod; return &t }(),
+	}
+
+	watch := watchContainers(ctx, rm.dockerClient, containerID)
+	defer watch.close()
+
+	if err := rm.dockerClient.ContainerStop(ctx, containerID, stopOptions); err != nil {
+		return fmt.Errorf("failed to stop container %s: %w", containerID, err)
+	}
+	if _, err := watch.waitForStop(ctx, containerID, 30*time.Second); err != nil {
+		return fmt.Errorf("container %s did not stop in time: %w", containerID, err)
+	}
+
+	if err := rm.dockerClient.ContainerStart(ctx, containerID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container %s: %w", containerID, err)
+	}
+	if err := rm.dockerClient.ContainerPause(ctx, containerID); err != nil {
+		return fmt.Errorf("failed to pause container %s after start: %w", containerID, err)
+	}
+	log.Debug().Str("container", containerID).Msg("Container started and held paused")
+
+	hookErr := hook(ctx)
+
+	if err := rm.dockerClient.ContainerUnpause(ctx, containerID); err != nil {
+		return errors.Join(hookErr, fmt.Errorf("failed to unpause container %s: %w", containerID, err))
+	}
+	log.Info().Str("container", containerID).Msg("Container released")
+	return hookErr
+}
+
 // RestartContainersSimultaneous restarts multiple containers simultaneously
 // All containers are stopped first, then all are started (truly simultaneous downtime)
 func (rm *RestartManager) RestartContainersSimultaneous(ctx context.Context, containerIDs []string, params RestartParams) error {
@@ -87,6 +136,9 @@ func (rm *RestartManager) RestartContainersSimultaneous(ctx context.Context, con
 		Timeout: func() *int { t := gracePeriod; return &t }(),
 	}
 
+	watch := watchContainers(ctx, rm.dockerClient, containerIDs...)
+	defer watch.close()
+
 	// Phase 1: Stop all containers
 	log.Debug().Msg("Phase 1: Stopping all containers")
 	for i, containerID := range containerIDs {
@@ -99,10 +151,11 @@ func (rm *RestartManager) RestartContainersSimultaneous(ctx context.Context, con
 	// Phase 2: Wait for all containers to stop
 	log.Debug().Msg("Phase 2: Waiting for all containers to stop")
 	for i, containerID := range containerIDs {
-		if err := rm.waitForStop(ctx, containerID, 30*time.Second); err != nil {
+		stoppedAt, err := watch.waitForStop(ctx, containerID, 30*time.Second)
+		if err != nil {
 			return fmt.Errorf("container %d/%d did not stop in time: %w", i+1, len(containerIDs), err)
 		}
-		log.Debug().Str("container", containerID).Int("index", i+1).Msg("Container stopped")
+		log.Debug().Str("container", containerID).Int("index", i+1).Time("stopped_at", stoppedAt).Msg("Container stopped")
 	}
 
 	// Phase 3: Optional delay before restart
@@ -123,80 +176,13 @@ func (rm *RestartManager) RestartContainersSimultaneous(ctx context.Context, con
 	// Phase 5: Wait for all containers to be running
 	log.Debug().Msg("Phase 5: Waiting for all containers to be running")
 	for i, containerID := range containerIDs {
-		if err := rm.waitForRunning(ctx, containerID, 120*time.Second); err != nil {
+		startedAt, err := watch.waitForRunning(ctx, containerID, 120*time.Second)
+		if err != nil {
 			return fmt.Errorf("container %d/%d did not start in time: %w", i+1, len(containerIDs), err)
 		}
-		log.Debug().Str("container", containerID).Int("index", i+1).Msg("Container running")
+		log.Debug().Str("container", containerID).Int("index", i+1).Time("started_at", startedAt).Msg("Container running")
 	}
 
 	log.Info().Int("count", len(containerIDs)).Msg("All containers restarted simultaneously")
 	return nil
 }
-
-// RestartContainersStaggered restarts multiple containers with a stagger delay between each
-func (rm *RestartManager) RestartContainersStaggered(ctx context.Context, containerIDs []string, params RestartParams) error {
-	log.Info().
-		Int("count", len(containerIDs)).
-		Int("stagger_seconds", params.Stagger).
-		Msg("Restarting containers with stagger")
-
-	for i, containerID := range containerIDs {
-		// Restart this container
-		if err := rm.RestartContainer(ctx, containerID, params); err != nil {
-			return fmt.Errorf("failed to restart container %d/%d: %w", i+1, len(containerIDs), err)
-		}
-
-		// Wait stagger duration between restarts (except after last)
-		if i < len(containerIDs)-1 && params.Stagger > 0 {
-			log.Debug().
-				Int("current", i+1).
-				Int("total", len(containerIDs)).
-				Int("stagger_seconds", params.Stagger).
-				Msg("Waiting before next restart")
-			time.Sleep(time.Duration(params.Stagger) * time.Second)
-		}
-	}
-
-	log.Info().Int("count", len(containerIDs)).Msg("All containers restarted successfully")
-	return nil
-}
-
-// waitForStop waits for a container to stop
-func (rm *RestartManager) waitForStop(ctx context.Context, containerID string, timeout time.Duration) error {
-	deadline := time.Now().Add(timeout)
-
-	for time.Now().Before(deadline) {
-		inspect, err := rm.dockerClient.ContainerInspect(ctx, containerID)
-		if err != nil {
-			return fmt.Errorf("failed to inspect container: %w", err)
-		}
-
-		if !inspect.State.Running {
-			return nil
-		}
-
-		time.Sleep(500 * time.Millisecond)
-	}
-
-	return fmt.Errorf("container did not stop within %v", timeout)
-}
-
-// waitForRunning waits for a container to start running
-func (rm *RestartManager) waitForRunning(ctx context.Context, containerID string, timeout time.Duration) error {
-	deadline := time.Now().Add(timeout)
-
-	for time.Now().Before(deadline) {
-		inspect, err := rm.dockerClient.ContainerInspect(ctx, containerID)
-		if err != nil {
-			return fmt.Errorf("failed to inspect container: %w", err)
-		}
-
-		if inspect.State.Running {
-			return nil
-		}
-
-		time.Sleep(500 * time.Millisecond)
-	}
-
-	return fmt.Errorf("container did not start within %v", timeout)
-}
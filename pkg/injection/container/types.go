@@ -9,9 +9,6 @@ type RestartParams struct {
 
 	// RestartDelay is the number of seconds to wait after stop before restart
 	RestartDelay int `yaml:"restart_delay,omitempty"`
-
-	// Stagger is the number of seconds between restarts when multiple targets
-	Stagger int `yaml:"stagger,omitempty"`
 }
 
 // KillParams defines parameters for container kill fault
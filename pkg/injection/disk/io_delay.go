@@ -10,12 +10,19 @@ import (
 )
 
 // IODelayParams defines parameters for disk I/O delay injection.
-// Only Method="dd" (default) is implemented. A previous "dm-delay" mode was
-// removed because the mount swap that would route real I/O through the mapper
-// device is not possible from inside the sidecar container; ValidateIODelayParams
-// rejects "dm-delay" so scenarios still referencing it fail loudly.
+//
+// Method "dd" (the default) runs dd contention workers next to the target.
+// "dm-delay" adds real per-I/O latency by reloading the device-mapper table
+// under TargetPath with a delay target — live, no remount — which only
+// works when TargetPath is on a single-segment linear dm volume (LVM), the
+// host has the dm_delay module and the target can run dmsetup. When it
+// cannot, injection falls back to "ionice": the target's processes move to
+// the idle I/O class and the dd workers starve them. "ionice" falls back to
+// plain "dd" when the target has no ionice. AppliedMethod reports what was
+// actually used.
 type IODelayParams struct {
-	// IOLatencyMs controls contention intensity via dd worker-count scaling
+	// IOLatencyMs is the per-I/O delay for dm-delay. For the dd-based
+	// methods it controls contention intensity via worker-count scaling
 	// (<100ms=1 worker, 100-199=2, 200+=3), not precise per-I/O latency.
 	IOLatencyMs int
 
@@ -26,8 +33,8 @@ type IODelayParams struct {
 	// Operation is the operation type: "read", "write", or "all".
 	Operation string
 
-	// Method selects the injection approach. Only "dd" (the default) and ""
-	// are accepted.
+	// Method selects the injection approach: "dd" (or ""), "dm-delay" or
+	// "ionice".
 	Method string
 }
 
@@ -45,6 +52,17 @@ type IODelayWrapper struct {
 	// passes an empty IODelayParams at teardown.
 	mu            sync.Mutex
 	injectedPaths map[string]string
+
+	// applied is the method actually used per target, lazily created.
+	applied map[string]AppliedMethod
+}
+
+// AppliedMethod is the disk_io method actually applied to a target.
+type AppliedMethod struct {
+	Method string
+	// Fallback explains why the requested method was not used; empty when
+	// it was.
+	Fallback string
 }
 
 // New creates a new I/O delay wrapper
@@ -67,12 +85,221 @@ func workerCount(ioLatencyMs int) int {
 	}
 }
 
-// InjectIODelay creates I/O contention by running background dd processes that
+// InjectIODelay applies params.Method to the target, falling back from
+// dm-delay to ionice to dd as each proves unavailable, and records the
+// method used for AppliedMethod.
+func (iw *IODelayWrapper) InjectIODelay(ctx context.Context, targetContainerID string, params IODelayParams) error {
+	method := params.Method
+	if method == "" {
+		method = "dd"
+	}
+	var fallbacks []string
+	if method == "dm-delay" {
+		err := iw.injectDmDelay(ctx, targetContainerID, params)
+		if err == nil {
+			iw.setApplied(targetContainerID, AppliedMethod{Method: "dm-delay"})
+			return nil
+		}
+		fallbacks = append(fallbacks, fmt.Sprintf("dm-delay unavailable: %v", err))
+		fmt.Printf("  ⚠ dm-delay unavailable on %s (%v); falling back to ionice\n", targetContainerID[:12], err)
+		method = "ionice"
+	}
+	if method == "ionice" {
+		if err := iw.demoteIO(ctx, targetContainerID); err != nil {
+			fallbacks = append(fallbacks, fmt.Sprintf("ionice unavailable: %v", err))
+			fmt.Printf("  ⚠ ionice unavailable on %s (%v); falling back to dd contention\n", targetContainerID[:12], err)
+			method = "dd"
+		}
+	}
+
+	if err := iw.injectContention(ctx, targetContainerID, params); err != nil {
+		if method == "ionice" {
+			iw.restoreIO(ctx, targetContainerID)
+		}
+		return err
+	}
+	iw.setApplied(targetContainerID, AppliedMethod{Method: method, Fallback: strings.Join(fallbacks, "; ")})
+	return nil
+}
+
+// AppliedMethod returns the method InjectIODelay used on the target, if
+// the fault is active there.
+func (iw *IODelayWrapper) AppliedMethod(targetContainerID string) (AppliedMethod, bool) {
+	iw.mu.Lock()
+	defer iw.mu.Unlock()
+	m, ok := iw.applied[targetContainerID]
+	return m, ok
+}
+
+func (iw *IODelayWrapper) setApplied(targetContainerID string, m AppliedMethod) {
+	iw.mu.Lock()
+	defer iw.mu.Unlock()
+	if iw.applied == nil {
+		iw.applied = make(map[string]AppliedMethod)
+	}
+	iw.applied[targetContainerID] = m
+}
+
+// DmDelayModulePath exists when the host kernel has the device-mapper delay
+// target loaded. Containers see the host's /sys/module, so injection and
+// `chaos-runner doctor` both check it.
+const DmDelayModulePath = "/sys/module/dm_delay"
+
+// DmTableFile records the device-mapper volume dm-delay reloaded, and the
+// table it had before, one per line. It lives in the target's /tmp, like
+// ionicePIDFile, so RemoveFault — including `chaos-runner recover` after a
+// crash — restores the table from the target rather than from the runner's
+// memory. Its presence also marks dm-delay as active.
+const DmTableFile = "/tmp/.chaos_dm_delay.table"
+
+// dmDelayProbeScript prints "OK <volume>" followed by the volume's table
+// when the path is on a device-mapper volume and the delay target is
+// loaded, or "ERR <reason>".
+const dmDelayProbeScript = `P=%q; M=%q; F=%q; ` +
+	`[ -f "$F" ] && { echo "ERR a dm-delay table is already applied ($F)"; exit 0; }; ` +
+	`[ -d "$M" ] || { echo "ERR dm_delay module not loaded on the host (modprobe dm-delay)"; exit 0; }; ` +
+	`command -v dmsetup >/dev/null 2>&1 || { echo "ERR dmsetup not found in the target"; exit 0; }; ` +
+	`DEV=$(df -P "$P" 2>/dev/null | awk 'NR==2 {print $1}'); ` +
+	`NAME=$(dmsetup info -c --noheadings -o name "$DEV" 2>/dev/null) || { echo "ERR $P is on $DEV, not a device-mapper volume"; exit 0; }; ` +
+	`echo "OK $NAME"; dmsetup table "$NAME"`
+
+// dmDelayApplyScript records the original table in DmTableFile before
+// loading the delay table, so a crash right after the load still leaves
+// what RemoveFault needs. `dmsetup load` stages the table and `resume`
+// switches to it atomically, so the mounted filesystem never sees the
+// change. A failed load or resume clears the staged table.
+const dmDelayApplyScript = `F=%q; N=%q; ` +
+	`printf '%%s\n%%s\n' "$N" %q > "$F" || exit 1; ` +
+	`dmsetup load "$N" --table %q && dmsetup resume "$N" && exit 0; ` +
+	`dmsetup clear "$N" >/dev/null 2>&1; rm -f "$F"; exit 1`
+
+// dmDelayRestoreScript loads back the table recorded in DmTableFile. On
+// failure it keeps the file, so a later RemoveFault can retry, and prints
+// "ERR <reason>".
+const dmDelayRestoreScript = `F=%q; ` +
+	`if [ -f "$F" ]; then N=$(sed -n 1p "$F"); T=$(sed -n 2p "$F"); ` +
+	`if dmsetup load "$N" --table "$T" >/dev/null 2>&1 && dmsetup resume "$N" >/dev/null 2>&1; then rm -f "$F"; ` +
+	`else echo "ERR could not restore the device-mapper table of $N (original kept in $F)"; fi; fi`
+
+// injectDmDelay swaps the volume under params.TargetPath onto a delay
+// table.
+func (iw *IODelayWrapper) injectDmDelay(ctx context.Context, targetContainerID string, params IODelayParams) error {
+	targetPath := params.TargetPath
+	if targetPath == "" {
+		targetPath = "/tmp"
+	}
+	probe := fmt.Sprintf(dmDelayProbeScript, targetPath, DmDelayModulePath, DmTableFile)
+	out, err := iw.dockerClient.ExecCommand(ctx, targetContainerID, []string{"sh", "-c", probe})
+	if err != nil {
+		return fmt.Errorf("probe failed: %w", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	head := strings.TrimSpace(lines[0])
+	if reason, failed := strings.CutPrefix(head, "ERR "); failed {
+		return fmt.Errorf("%s", reason)
+	}
+	name, ok := strings.CutPrefix(head, "OK ")
+	if !ok || len(lines) != 2 {
+		return fmt.Errorf("unexpected probe output %q", strings.TrimSpace(out))
+	}
+	original := strings.TrimSpace(lines[1])
+	delayed, err := dmDelayTable(original, params.Operation, params.IOLatencyMs)
+	if err != nil {
+		return fmt.Errorf("volume %s: %w", name, err)
+	}
+
+	fmt.Printf("Injecting dm-delay on target %s: volume %s, %dms %s\n", targetContainerID[:12], name, params.IOLatencyMs, params.Operation)
+	apply := fmt.Sprintf(dmDelayApplyScript, DmTableFile, name, original, delayed)
+	if out, err := iw.dockerClient.ExecCommand(ctx, targetContainerID, []string{"sh", "-c", apply}); err != nil {
+		return fmt.Errorf("dmsetup load failed: %w (output: %s)", err, strings.TrimSpace(out))
+	}
+
+	iw.mu.Lock()
+	iw.injectedPaths[targetContainerID] = targetPath
+	iw.mu.Unlock()
+	return nil
+}
+
+// dmDelayTable turns a single-segment linear table
+// ("<start> <len> linear <dev> <offset>") into a delay table over the same
+// device, delaying reads, writes or both by ms.
+func dmDelayTable(linear, operation string, ms int) (string, error) {
+	f := strings.Fields(linear)
+	if len(f) != 5 || f[2] != "linear" {
+		return "", fmt.Errorf("table %q is not a single-segment linear mapping", linear)
+	}
+	readMs, writeMs := ms, ms
+	switch operation {
+	case "read":
+		writeMs = 0
+	case "write":
+		readMs = 0
+	}
+	return fmt.Sprintf("%s %s delay %s %s %d %s %s %d", f[0], f[1], f[3], f[4], readMs, f[3], f[4], writeMs), nil
+}
+
+// ionicePIDFile lists the processes demoteIO moved to the idle I/O class,
+// one "PID CLASS PRIO" line each with the class they had before. It lives in
+// the target's /tmp, like the cpu_stress yes-loop pidfile, rather than in
+// target_path, which is the node's data directory.
+const ionicePIDFile = "/tmp/.chaos_ionice.pids"
+
+// ioniceDemoteScript records each process's current I/O class and priority
+// from `ionice -p` (util-linux prints "best-effort: prio 4", busybox
+// "Class: best-effort\nPriority: 4") and moves it to the idle class. A
+// process already recorded keeps its first entry, so a repeated inject
+// never records idle as the original.
+const ioniceDemoteScript = `F=%q; ` +
+	`command -v ionice >/dev/null 2>&1 || { echo "ERR ionice not found in the target"; exit 0; }; ` +
+	`touch "$F"; ` +
+	`for d in /proc/[0-9]*; do PID=${d#/proc/}; [ "$PID" = "$$" ] && continue; ` +
+	`grep -q "^$PID " "$F" && continue; ` +
+	`CUR=$(ionice -p "$PID" 2>/dev/null | tr '\n' ' ') || continue; ` +
+	`case "$CUR" in *realtime*) C=1 ;; *best-effort*) C=2 ;; *idle*) C=3 ;; *) C=0 ;; esac; ` +
+	`N=$(echo "$CUR" | grep -o '[0-9][0-9]*' | tail -n 1); ` +
+	`ionice -c 3 -p "$PID" 2>/dev/null && echo "$PID $C ${N:--}" >> "$F"; done; ` +
+	`if [ -s "$F" ]; then echo OK; else rm -f "$F"; echo "ERR no process accepted the idle I/O class"; fi`
+
+// ioniceRestoreScript puts every recorded process back in its original
+// class, with its original priority for the classes that have one.
+const ioniceRestoreScript = `F=%q; ` +
+	`if [ -f "$F" ]; then while read -r pid c n; do [ -z "$pid" ] && continue; ` +
+	`case "$c" in 1|2) [ "$n" != "-" ] && { ionice -c "$c" -n "$n" -p "$pid" 2>/dev/null; continue; } ;; esac; ` +
+	`ionice -c "$c" -p "$pid" 2>/dev/null; done < "$F"; fi; ` +
+	`rm -f "$F"; echo done`
+
+// demoteIO moves every process in the target to the idle I/O class,
+// recording the class each had in ionicePIDFile so restoreIO can put it
+// back.
+func (iw *IODelayWrapper) demoteIO(ctx context.Context, targetContainerID string) error {
+	script := fmt.Sprintf(ioniceDemoteScript, ionicePIDFile)
+	out, err := iw.dockerClient.ExecCommand(ctx, targetContainerID, []string{"sh", "-c", script})
+	if err != nil {
+		return err
+	}
+	out = strings.TrimSpace(out)
+	if reason, failed := strings.CutPrefix(out, "ERR "); failed {
+		return fmt.Errorf("%s", reason)
+	}
+	fmt.Printf("  Target %s processes moved to the idle I/O class\n", targetContainerID[:12])
+	return nil
+}
+
+// restoreIO returns the processes demoteIO recorded to their original I/O
+// class when injection fails after demoting. RemoveFault runs the same
+// script as part of its cleanup.
+func (iw *IODelayWrapper) restoreIO(ctx context.Context, targetContainerID string) {
+	script := fmt.Sprintf(ioniceRestoreScript, ionicePIDFile)
+	if _, err := iw.dockerClient.ExecCommand(ctx, targetContainerID, []string{"sh", "-c", script}); err != nil {
+		log.Warn().Err(err).Str("container", targetContainerID[:12]).Msg("failed to restore I/O classes")
+	}
+}
+
+// injectContention creates I/O contention by running background dd processes that
 // saturate the I/O queue. Each worker shell's PID is written to a pidfile; the
 // verification step reads that pidfile and checks `kill -0` on every PID, so
 // the result is deterministic rather than pattern-matched against /proc.
-// Method="dm-delay" is rejected upstream by ValidateIODelayParams.
-func (iw *IODelayWrapper) InjectIODelay(ctx context.Context, targetContainerID string, params IODelayParams) error {
+func (iw *IODelayWrapper) injectContention(ctx context.Context, targetContainerID string, params IODelayParams) error {
 	fmt.Printf("Injecting I/O contention on target %s\n", targetContainerID[:12])
 
 	targetPath := params.TargetPath
@@ -182,8 +409,10 @@ func (iw *IODelayWrapper) InjectIODelay(ctx context.Context, targetContainerID s
 	return nil
 }
 
-// RemoveFault kills the worker shells recorded at inject time, sweeps any
-// orphaned processes carrying the chaos marker, and deletes stress files.
+// RemoveFault restores a dm-delay volume's original table, kills the worker
+// shells recorded at inject time, sweeps any orphaned processes carrying
+// the chaos marker, deletes stress files, and returns ionice-demoted
+// processes to their original I/O class.
 func (iw *IODelayWrapper) RemoveFault(ctx context.Context, targetContainerID string, params IODelayParams) error {
 	fmt.Printf("Removing I/O contention from target %s\n", targetContainerID[:12])
 
@@ -203,10 +432,14 @@ func (iw *IODelayWrapper) RemoveFault(ctx context.Context, targetContainerID str
 	chaosFile := base + "/.chaos_io_stress"
 	pidFile := base + "/.chaos_io_stress.pids"
 
-	// Kill by recorded PID first (authoritative), then sweep any survivors
+	// Restore a dm-delay table first, from DmTableFile, so it also undoes a
+	// crashed run's delay and is a no-op for the other methods. Then kill
+	// by recorded PID (authoritative), and sweep any survivors
 	// carrying the chaos_io_stress marker. The sweep catches dd children
 	// that were mid-exec when their parent shell was killed and any process
-	// left from a prior crashed run where the pidfile went missing.
+	// left from a prior crashed run where the pidfile went missing. Last,
+	// restore whatever ionice demoted: that reads only ionicePIDFile, so it
+	// also undoes a crashed run's demotion, and is a no-op for method dd.
 	//
 	// Two subtleties that bit earlier revisions:
 	//  1. The remove script's own cmdline contains the chaos_io_stress
@@ -218,7 +451,8 @@ func (iw *IODelayWrapper) RemoveFault(ctx context.Context, targetContainerID str
 	//     sh (not tr) prints "can't open …". `2>/dev/null` on tr doesn't
 	//     suppress it; wrap the whole compound in a { …; } 2>/dev/null.
 	removeScript := fmt.Sprintf(
-		"MY_PID=$$; "+
+		"%s; "+
+			"MY_PID=$$; "+
 			"PIDFILE=%q; "+
 			"if [ -f \"$PIDFILE\" ]; then "+
 			"while IFS= read -r pid; do "+
@@ -237,14 +471,19 @@ func (iw *IODelayWrapper) RemoveFault(ctx context.Context, targetContainerID str
 			"done; "+
 			"rm -f \"$PIDFILE\" \"%s\"_* 2>/dev/null; "+
 			"find /tmp /root /var/lib -maxdepth 6 -name '.chaos_io_stress_*' -delete 2>/dev/null; "+
-			"echo done",
-		pidFile, chaosFile,
+			"%s",
+		fmt.Sprintf(dmDelayRestoreScript, DmTableFile), pidFile, chaosFile, fmt.Sprintf(ioniceRestoreScript, ionicePIDFile),
 	)
 
-	_, killErr := iw.dockerClient.ExecCommand(ctx, targetContainerID, []string{"sh", "-c", removeScript})
+	out, killErr := iw.dockerClient.ExecCommand(ctx, targetContainerID, []string{"sh", "-c", removeScript})
 	if killErr != nil {
 		log.Warn().Err(killErr).Str("container", targetContainerID[:12]).Msg("failed to run I/O contention removal script")
 	}
+	for _, line := range strings.Split(out, "\n") {
+		if reason, failed := strings.CutPrefix(strings.TrimSpace(line), "ERR "); failed {
+			return fmt.Errorf("failed to remove dm-delay: %s", reason)
+		}
+	}
 
 	// Always verify no chaos_io_stress-tagged processes remain — the remove
 	// script swallows individual kill/rm errors via 2>/dev/null, so killErr
@@ -270,6 +509,7 @@ func (iw *IODelayWrapper) RemoveFault(ctx context.Context, targetContainerID str
 
 	iw.mu.Lock()
 	delete(iw.injectedPaths, targetContainerID)
+	delete(iw.applied, targetContainerID)
 	iw.mu.Unlock()
 
 	fmt.Printf("  I/O contention removed from target %s\n", targetContainerID[:12])
@@ -298,14 +538,6 @@ func parseAliveTotal(out string) (alive, total int, err error) {
 	return alive, total, nil
 }
 
-// ErrDmDelayUnsupported is returned when a scenario requests the legacy
-// method="dm-delay" mode. The mapper device could be created, but nothing
-// swaps the filesystem mount onto it, so no observable latency would be
-// applied; the supported mode is method="dd" (or unset).
-var ErrDmDelayUnsupported = fmt.Errorf(
-	"disk_io method=\"dm-delay\" is not supported; use method=\"dd\" instead",
-)
-
 // ValidateIODelayParams validates I/O delay parameters
 func ValidateIODelayParams(params IODelayParams) error {
 	if params.IOLatencyMs < 0 {
@@ -317,12 +549,10 @@ func ValidateIODelayParams(params IODelayParams) error {
 	}
 
 	switch params.Method {
-	case "", "dd":
+	case "", "dd", "dm-delay", "ionice":
 		// ok
-	case "dm-delay":
-		return ErrDmDelayUnsupported
 	default:
-		return fmt.Errorf("unsupported method %q; valid values: 'dd' or '' (empty)", params.Method)
+		return fmt.Errorf("unsupported method %q; valid values: 'dd', 'dm-delay', 'ionice' or '' (empty)", params.Method)
 	}
 
 	return nil
@@ -3,6 +3,9 @@ package disk
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -298,6 +301,9 @@ func TestValidateIODelayParams(t *testing.T) {
 		{"valid write", IODelayParams{IOLatencyMs: 50, TargetPath: "/data", Operation: "write"}, false},
 		{"negative latency", IODelayParams{IOLatencyMs: -1, TargetPath: "/data", Operation: "all"}, true},
 		{"invalid operation", IODelayParams{IOLatencyMs: 100, TargetPath: "/data", Operation: "delete"}, true},
+		{"ionice", IODelayParams{IOLatencyMs: 100, TargetPath: "/data", Operation: "all", Method: "ionice"}, false},
+		{"dm-delay", IODelayParams{IOLatencyMs: 100, TargetPath: "/data", Operation: "all", Method: "dm-delay"}, false},
+		{"unknown method", IODelayParams{IOLatencyMs: 100, TargetPath: "/data", Operation: "all", Method: "blkio"}, true},
 	}
 
 	for _, tt := range tests {
@@ -506,3 +512,308 @@ func TestParseAliveTotal(t *testing.T) {
 		})
 	}
 }
+
+// fakeIonice puts an ionice on PATH that reports class for `-p PID` and
+// logs every other invocation to the returned file.
+func fakeIonice(t *testing.T, class string) string {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "calls")
+	script := fmt.Sprintf("#!/bin/sh\nif [ \"$1\" = -p ]; then printf '%%b\\n' %q; else echo \"$*\" >> %q; fi\n", class, logFile)
+	if err := os.WriteFile(filepath.Join(dir, "ionice"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logFile
+}
+
+func TestIoniceDemoteScript_RecordsOriginalClass(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string // `ionice -p` output
+		want   string // recorded "CLASS PRIO"
+	}{
+		{"util-linux best-effort", "best-effort: prio 4", "2 4"},
+		{"util-linux realtime", "realtime: prio 0", "1 0"},
+		{"util-linux none", "none: prio 0", "0 0"},
+		{"idle", "idle", "3 -"},
+		{"busybox", "Class: best-effort\\nPriority: 6", "2 6"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeIonice(t, tt.output)
+			pidFile := filepath.Join(t.TempDir(), "pids")
+			out, err := exec.Command("sh", "-c", fmt.Sprintf(ioniceDemoteScript, pidFile)).Output()
+			if err != nil {
+				t.Fatalf("demote script: %v", err)
+			}
+			if strings.TrimSpace(string(out)) != "OK" {
+				t.Fatalf("demote script output = %q, want OK", out)
+			}
+			data, err := os.ReadFile(pidFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+				pid, rest, _ := strings.Cut(line, " ")
+				if pid == "" || rest != tt.want {
+					t.Fatalf("pidfile line %q, want \"<pid> %s\"", line, tt.want)
+				}
+			}
+
+			// A second demote must not record the idle class as original.
+			if err := exec.Command("sh", "-c", fmt.Sprintf(ioniceDemoteScript, pidFile)).Run(); err != nil {
+				t.Fatal(err)
+			}
+			again, _ := os.ReadFile(pidFile)
+			for _, line := range strings.Split(strings.TrimSpace(string(again)), "\n") {
+				if !strings.Contains(string(data), line+"\n") {
+					t.Errorf("second demote recorded %q", line)
+				}
+			}
+		})
+	}
+}
+
+func TestIoniceRestoreScript_RestoresOriginalClass(t *testing.T) {
+	logFile := fakeIonice(t, "")
+	pidFile := filepath.Join(t.TempDir(), "pids")
+	if err := os.WriteFile(pidFile, []byte("101 2 4\n102 1 0\n103 3 -\n104 0 0\n105 2 -\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := exec.Command("sh", "-c", fmt.Sprintf(ioniceRestoreScript, pidFile)).Run(); err != nil {
+		t.Fatalf("restore script: %v", err)
+	}
+	calls, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "-c 2 -n 4 -p 101\n-c 1 -n 0 -p 102\n-c 3 -p 103\n-c 0 -p 104\n-c 2 -p 105\n"
+	if string(calls) != want {
+		t.Errorf("ionice calls:\n%s\nwant:\n%s", calls, want)
+	}
+	if _, err := os.Stat(pidFile); !os.IsNotExist(err) {
+		t.Errorf("pidfile not removed: %v", err)
+	}
+}
+
+func TestInjectIODelay_MethodFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		probe        string // dm-delay probe output
+		ionice       string // demote script output
+		wantMethod   string
+		wantFallback string
+	}{
+		{
+			name:       "dm-delay applied",
+			method:     "dm-delay",
+			probe:      "OK vg-data\n0 2097152 linear 253:0 2048\n",
+			wantMethod: "dm-delay",
+		},
+		{
+			name:         "dm-delay falls back to ionice",
+			method:       "dm-delay",
+			probe:        "ERR dm_delay module not loaded on the host (modprobe dm-delay)\n",
+			ionice:       "OK\n",
+			wantMethod:   "ionice",
+			wantFallback: "dm-delay unavailable: dm_delay module not loaded",
+		},
+		{
+			name:         "dm-delay falls back to dd",
+			method:       "dm-delay",
+			probe:        "ERR /data is on /dev/sda1, not a device-mapper volume\n",
+			ionice:       "ERR ionice not found in the target\n",
+			wantMethod:   "dd",
+			wantFallback: "not a device-mapper volume; ionice unavailable",
+		},
+		{
+			name:       "ionice applied",
+			method:     "ionice",
+			ionice:     "OK\n",
+			wantMethod: "ionice",
+		},
+		{
+			name:         "ionice falls back to dd",
+			method:       "ionice",
+			ionice:       "ERR ionice not found in the target\n",
+			wantMethod:   "dd",
+			wantFallback: "ionice unavailable: ionice not found",
+		},
+		{
+			name:       "dd by default",
+			wantMethod: "dd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockDockerClientDisk{
+				execFunc: func(ctx context.Context, containerID string, cmd []string) (string, error) {
+					cmdStr := strings.Join(cmd, " ")
+					switch {
+					case strings.Contains(cmdStr, DmDelayModulePath):
+						return tt.probe, nil
+					case strings.Contains(cmdStr, "dmsetup load"):
+						return "", nil
+					case strings.Contains(cmdStr, "ionice -c 3"):
+						return tt.ionice, nil
+					case isStartScript(cmdStr):
+						return "1 1\n", nil
+					}
+					return "", nil
+				},
+			}
+
+			iw := &IODelayWrapper{dockerClient: mock, injectedPaths: map[string]string{}}
+			err := iw.InjectIODelay(context.Background(), "abcdef123456789", IODelayParams{
+				TargetPath:  "/var/lib/data",
+				Operation:   "all",
+				IOLatencyMs: 100,
+				Method:      tt.method,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, ok := iw.AppliedMethod("abcdef123456789")
+			if !ok {
+				t.Fatal("AppliedMethod not recorded")
+			}
+			if got.Method != tt.wantMethod {
+				t.Errorf("Method = %q, want %q", got.Method, tt.wantMethod)
+			}
+			if !strings.Contains(got.Fallback, tt.wantFallback) || (tt.wantFallback == "" && got.Fallback != "") {
+				t.Errorf("Fallback = %q, want it to contain %q", got.Fallback, tt.wantFallback)
+			}
+		})
+	}
+}
+
+func TestDmDelayTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		table     string
+		operation string
+		want      string
+		wantErr   bool
+	}{
+		{"all", "0 2097152 linear 253:0 2048", "all", "0 2097152 delay 253:0 2048 50 253:0 2048 50", false},
+		{"read only", "0 2097152 linear 8:16 0", "read", "0 2097152 delay 8:16 0 50 8:16 0 0", false},
+		{"write only", "0 2097152 linear 8:16 0", "write", "0 2097152 delay 8:16 0 0 8:16 0 50", false},
+		{"striped", "0 2097152 striped 2 128 8:16 0 8:32 0", "all", "", true},
+		{"multi-segment", "0 100 linear 8:16 0\n100 100 linear 8:32 0", "all", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dmDelayTable(tt.table, tt.operation, 50)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("dmDelayTable err=%v, wantErr=%v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("dmDelayTable = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeDmsetup puts a dmsetup on PATH that logs its arguments to the
+// returned file and exits with code.
+func fakeDmsetup(t *testing.T, code int) string {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "calls")
+	script := fmt.Sprintf("#!/bin/sh\necho \"$*\" >> %q\nexit %d\n", logFile, code)
+	if err := os.WriteFile(filepath.Join(dir, "dmsetup"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logFile
+}
+
+func TestDmDelayScripts_RecordAndRestoreTable(t *testing.T) {
+	logFile := fakeDmsetup(t, 0)
+	tableFile := filepath.Join(t.TempDir(), "table")
+	original := "0 2097152 linear 253:0 2048"
+	delayed := "0 2097152 delay 253:0 2048 50 253:0 2048 50"
+
+	apply := fmt.Sprintf(dmDelayApplyScript, tableFile, "vg-data", original, delayed)
+	if err := exec.Command("sh", "-c", apply).Run(); err != nil {
+		t.Fatalf("apply script: %v", err)
+	}
+	recorded, err := os.ReadFile(tableFile)
+	if err != nil {
+		t.Fatalf("original table not recorded: %v", err)
+	}
+	if want := "vg-data\n" + original + "\n"; string(recorded) != want {
+		t.Errorf("recorded %q, want %q", recorded, want)
+	}
+
+	out, err := exec.Command("sh", "-c", fmt.Sprintf(dmDelayRestoreScript, tableFile)).Output()
+	if err != nil || strings.TrimSpace(string(out)) != "" {
+		t.Fatalf("restore script: %v (output %q)", err, out)
+	}
+	calls, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "load vg-data --table " + delayed + "\nresume vg-data\n" +
+		"load vg-data --table " + original + "\nresume vg-data\n"
+	if string(calls) != want {
+		t.Errorf("dmsetup calls:\n%s\nwant:\n%s", calls, want)
+	}
+	if _, err := os.Stat(tableFile); !os.IsNotExist(err) {
+		t.Errorf("table file not removed after restore: %v", err)
+	}
+}
+
+func TestDmDelayScripts_FailuresKeepState(t *testing.T) {
+	fakeDmsetup(t, 1)
+	tableFile := filepath.Join(t.TempDir(), "table")
+
+	apply := fmt.Sprintf(dmDelayApplyScript, tableFile, "vg-data", "0 8 linear 8:16 0", "0 8 delay 8:16 0 50 8:16 0 50")
+	if err := exec.Command("sh", "-c", apply).Run(); err == nil {
+		t.Fatal("apply script succeeded although dmsetup load failed")
+	}
+	if _, err := os.Stat(tableFile); !os.IsNotExist(err) {
+		t.Errorf("table file left behind by a failed load: %v", err)
+	}
+
+	// A failed restore keeps the record so removal can be retried.
+	if err := os.WriteFile(tableFile, []byte("vg-data\n0 8 linear 8:16 0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, _ := exec.Command("sh", "-c", fmt.Sprintf(dmDelayRestoreScript, tableFile)).Output()
+	if !strings.HasPrefix(string(out), "ERR could not restore") {
+		t.Errorf("restore output = %q, want an ERR line", out)
+	}
+	if _, err := os.Stat(tableFile); err != nil {
+		t.Errorf("table file removed after a failed restore: %v", err)
+	}
+}
+
+func TestRemoveFault_DmDelayRestoreFails(t *testing.T) {
+	mock := &mockDockerClientDisk{
+		execFunc: func(ctx context.Context, containerID string, cmd []string) (string, error) {
+			cmdStr := strings.Join(cmd, " ")
+			if isVerifyCountScript(cmdStr) {
+				return "0", nil
+			}
+			if strings.Contains(cmdStr, DmTableFile) {
+				return "ERR could not restore the device-mapper table of vg-data\ndone\n", nil
+			}
+			return "done", nil
+		},
+	}
+
+	iw := &IODelayWrapper{dockerClient: mock, injectedPaths: map[string]string{}}
+	err := iw.RemoveFault(context.Background(), "abcdef123456789", IODelayParams{TargetPath: "/var/lib/data", Operation: "all"})
+	if err == nil || !strings.Contains(err.Error(), "vg-data") {
+		t.Fatalf("RemoveFault() error = %v, want the failed dm-delay restore", err)
+	}
+}
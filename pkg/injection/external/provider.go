@@ -0,0 +1,207 @@
+// Package external delegates fault injection to a process or HTTP service
+// outside chaos-runner, so faults can be implemented in any language.
+//
+// Protocol: for every action chaos-runner sends one JSON Request and expects
+// one JSON Response. For exec providers the request is written to the
+// command's stdin and the response read from its stdout; for http providers
+// the request is POSTed to the URL and the response is the body. A non-zero
+// exit status or non-2xx status is treated as a failure even if the body
+// parses. See docs/external-fault-providers.md.
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ProtocolVersion is sent with every request so providers can reject
+// payloads they do not understand.
+const ProtocolVersion = 1
+
+// Actions sent to a provider.
+const (
+	ActionInject = "inject"
+	ActionRemove = "remove"
+	ActionVerify = "verify"
+)
+
+// DefaultTimeout bounds a single provider call.
+const DefaultTimeout = 60 * time.Second
+
+// Target identifies the container an action applies to.
+type Target struct {
+	Name        string `json:"name"`
+	ContainerID string `json:"container_id"`
+}
+
+// Request is the JSON payload sent to a provider.
+type Request struct {
+	Version int                    `json:"version"`
+	Action  string                 `json:"action"`
+	Target  Target                 `json:"target"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+}
+
+// Response is the JSON payload a provider returns. OK=false fails the
+// action with Message as the error. For verify, Active reports whether the
+// fault is observably in effect; omitting it is treated as active.
+type Response struct {
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+	Active  *bool  `json:"active,omitempty"`
+}
+
+// Config selects and configures a provider.
+type Config struct {
+	// Provider is "exec" or "http".
+	Provider string
+	// Command is the argv for exec providers.
+	Command []string
+	// URL is the endpoint for http providers.
+	URL string
+	// Timeout bounds each call (default DefaultTimeout).
+	Timeout time.Duration
+	// Params are forwarded verbatim in every request.
+	Params map[string]interface{}
+}
+
+// Provider sends protocol requests to one external implementation.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+}
+
+// New validates cfg and returns a Provider.
+func New(cfg Config) (*Provider, error) {
+	switch cfg.Provider {
+	case "exec":
+		if len(cfg.Command) == 0 {
+			return nil, fmt.Errorf("exec provider requires a command")
+		}
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("http provider requires a url")
+		}
+	default:
+		return nil, fmt.Errorf("unknown provider %q (want exec or http)", cfg.Provider)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	return &Provider{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}, nil
+}
+
+// Inject asks the provider to install the fault on target.
+func (p *Provider) Inject(ctx context.Context, target Target) error {
+	_, err := p.call(ctx, ActionInject, target)
+	return err
+}
+
+// Remove asks the provider to remove the fault from target. Providers must
+// treat removing an absent fault as success: teardown and the abort-path
+// cleanup may both call it.
+func (p *Provider) Remove(ctx context.Context, target Target) error {
+	_, err := p.call(ctx, ActionRemove, target)
+	return err
+}
+
+// Verify asks the provider whether the fault is in effect on target.
+func (p *Provider) Verify(ctx context.Context, target Target) error {
+	resp, err := p.call(ctx, ActionVerify, target)
+	if err != nil {
+		return err
+	}
+	if resp.Active != nil && !*resp.Active {
+		if resp.Message != "" {
+			return fmt.Errorf("fault not active: %s", resp.Message)
+		}
+		return fmt.Errorf("fault not active")
+	}
+	return nil
+}
+
+func (p *Provider) call(ctx context.Context, action string, target Target) (*Response, error) {
+	payload, err := json.Marshal(Request{
+		Version: ProtocolVersion,
+		Action:  action,
+		Target:  target,
+		Params:  p.cfg.Params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s request: %w", action, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+
+	var out []byte
+	switch p.cfg.Provider {
+	case "exec":
+		out, err = p.callExec(ctx, payload)
+	case "http":
+		out, err = p.callHTTP(ctx, payload)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s provider %s failed: %w", p.cfg.Provider, action, err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("%s provider %s returned invalid JSON: %w (output: %s)",
+			p.cfg.Provider, action, err, truncate(string(out)))
+	}
+	if !resp.OK {
+		return &resp, fmt.Errorf("%s provider %s failed: %s", p.cfg.Provider, action, resp.Message)
+	}
+	return &resp, nil
+}
+
+func (p *Provider) callExec(ctx context.Context, payload []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, p.cfg.Command[0], p.cfg.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w (stderr: %s)", err, truncate(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+func (p *Provider) callHTTP(ctx context.Context, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, truncate(string(body)))
+	}
+	return body, nil
+}
+
+func truncate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > 500 {
+		return s[:500] + "..."
+	}
+	return s
+}
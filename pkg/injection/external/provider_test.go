@@ -0,0 +1,82 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"exec with command", Config{Provider: "exec", Command: []string{"true"}}, false},
+		{"exec without command", Config{Provider: "exec"}, true},
+		{"http with url", Config{Provider: "http", URL: "http://localhost:1"}, false},
+		{"http without url", Config{Provider: "http"}, true},
+		{"unknown provider", Config{Provider: "grpc"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecProvider(t *testing.T) {
+	target := Target{Name: "l2-el-4", ContainerID: "abc123"}
+
+	ok, _ := New(Config{Provider: "exec", Command: []string{"sh", "-c", `cat >/dev/null; echo '{"ok":true}'`}})
+	if err := ok.Inject(context.Background(), target); err != nil {
+		t.Errorf("Inject() unexpected error: %v", err)
+	}
+
+	refused, _ := New(Config{Provider: "exec", Command: []string{"sh", "-c", `cat >/dev/null; echo '{"ok":false,"message":"nope"}'`}})
+	if err := refused.Inject(context.Background(), target); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("Inject() error = %v, want provider message", err)
+	}
+
+	crashed, _ := New(Config{Provider: "exec", Command: []string{"sh", "-c", `echo boom >&2; exit 3`}})
+	if err := crashed.Remove(context.Background(), target); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Remove() error = %v, want stderr in error", err)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	var got Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got.Action == ActionVerify {
+			w.Write([]byte(`{"ok":true,"active":false,"message":"rule missing"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	p, err := New(Config{Provider: "http", URL: srv.URL, Params: map[string]interface{}{"mode": "slow"}})
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	target := Target{Name: "heimdall-1", ContainerID: "def456"}
+	if err := p.Inject(context.Background(), target); err != nil {
+		t.Fatalf("Inject() unexpected error: %v", err)
+	}
+	if got.Version != ProtocolVersion || got.Action != ActionInject || got.Target != target || got.Params["mode"] != "slow" {
+		t.Errorf("request = %+v, want inject for %+v with params", got, target)
+	}
+
+	if err := p.Verify(context.Background(), target); err == nil || !strings.Contains(err.Error(), "rule missing") {
+		t.Errorf("Verify() error = %v, want not-active error", err)
+	}
+}
@@ -0,0 +1,83 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// drainChain holds the drain fault's rules, separate from CHAOS_DROP so a
+// drain and a connection_drop on the same target never flush each other.
+const drainChain = "CHAOS_DRAIN"
+
+// InjectDrain takes the target out of load: new inbound TCP connections to
+// ports (comma-separated) are refused with a RST, so clients and load
+// balancers fail over at once, while established connections keep being
+// served until the target restarts.
+func (iw *IptablesWrapper) InjectDrain(ctx context.Context, targetContainerID, ports string) error {
+	if _, exists := iw.sidecarMgr.GetSidecarID(targetContainerID); !exists {
+		fmt.Printf("Creating sidecar for target %s\n", targetContainerID[:12])
+		if _, err := iw.sidecarMgr.CreateSidecar(ctx, targetContainerID); err != nil {
+			return fmt.Errorf("failed to create sidecar: %w", err)
+		}
+	}
+
+	fmt.Printf("Draining ports %s on target %s\n", ports, targetContainerID[:12])
+
+	for _, cmd := range buildDrainCommands(ports) {
+		if output, err := iw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd); err != nil {
+			return fmt.Errorf("failed to drain: %w (output: %s)", err, output)
+		}
+	}
+	return nil
+}
+
+// RemoveDrain deletes the drain rules. It is a no-op when the target no
+// longer has a sidecar — a restarted target comes back in a fresh network
+// namespace without them.
+func (iw *IptablesWrapper) RemoveDrain(ctx context.Context, targetContainerID string) error {
+	iw.removeInputChain(ctx, targetContainerID, drainChain)
+	return nil
+}
+
+// removeInputChain unhooks chain from INPUT and deletes it. Failures are
+// logged, not returned, so one missing rule does not stop the rest of
+// teardown.
+func (iw *IptablesWrapper) removeInputChain(ctx context.Context, targetContainerID, chain string) {
+	if _, exists := iw.sidecarMgr.GetSidecarID(targetContainerID); !exists {
+		return
+	}
+
+	flushCmds := [][]string{
+		{"iptables", "-D", "INPUT", "-j", chain, "-m", "comment", "--comment", "chaos-engineering"},
+		{"iptables", "-F", chain},
+		{"iptables", "-X", chain},
+	}
+	for _, cmd := range flushCmds {
+		if _, err := iw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd); err != nil {
+			log.Warn().Err(err).Str("container", targetContainerID[:12]).Strs("cmd", cmd).Msgf("failed to flush %s rule during removal", chain)
+		}
+	}
+}
+
+// buildDrainCommands rejects SYNs to each port. Only connection attempts
+// match, so established flows are untouched.
+func buildDrainCommands(ports string) [][]string {
+	cmds := [][]string{{"iptables", "-N", drainChain}}
+	for _, port := range strings.Split(ports, ",") {
+		port = strings.TrimSpace(port)
+		if port == "" {
+			continue
+		}
+		cmds = append(cmds, []string{
+			"iptables", "-A", drainChain, "-p", "tcp", "--syn", "--dport", port,
+			"-j", "REJECT", "--reject-with", "tcp-reset",
+		})
+	}
+	return append(cmds, []string{
+		"iptables", "-I", "INPUT", "1", "-j", drainChain,
+		"-m", "comment", "--comment", "chaos-engineering",
+	})
+}
@@ -0,0 +1,98 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// partitionChain holds the network_partition fault's rules.
+const partitionChain = "CHAOS_PARTITION"
+
+// InjectPartition cuts the target off from peers (IPv4 addresses or CIDRs)
+// and only from them: every packet from a peer is dropped on INPUT and
+// every packet to one on OUTPUT, established connections included, while
+// traffic with everyone else flows as before. Rules in the target's
+// namespace alone block both directions, so the peers are left untouched.
+func (iw *IptablesWrapper) InjectPartition(ctx context.Context, targetContainerID string, peers []string) error {
+	if _, exists := iw.sidecarMgr.GetSidecarID(targetContainerID); !exists {
+		fmt.Printf("Creating sidecar for target %s\n", targetContainerID[:12])
+		if _, err := iw.sidecarMgr.CreateSidecar(ctx, targetContainerID); err != nil {
+			return fmt.Errorf("failed to create sidecar: %w", err)
+		}
+	}
+
+	fmt.Printf("Partitioning target %s from %s\n", targetContainerID[:12], strings.Join(peers, ", "))
+
+	for _, cmd := range buildPartitionCommands(peers) {
+		if output, err := iw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd); err != nil {
+			return fmt.Errorf("failed to partition: %w (output: %s)", err, output)
+		}
+	}
+	return nil
+}
+
+// RemovePartition unhooks the partition chain from INPUT and OUTPUT and
+// deletes it. Failures are logged, as in removeInputChain.
+func (iw *IptablesWrapper) RemovePartition(ctx context.Context, targetContainerID string) error {
+	if _, exists := iw.sidecarMgr.GetSidecarID(targetContainerID); !exists {
+		return nil
+	}
+
+	flushCmds := [][]string{
+		{"iptables", "-D", "INPUT", "-j", partitionChain, "-m", "comment", "--comment", "chaos-engineering"},
+		{"iptables", "-D", "OUTPUT", "-j", partitionChain, "-m", "comment", "--comment", "chaos-engineering"},
+		{"iptables", "-F", partitionChain},
+		{"iptables", "-X", partitionChain},
+	}
+	for _, cmd := range flushCmds {
+		if _, err := iw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd); err != nil {
+			log.Warn().Err(err).Str("container", targetContainerID[:12]).Strs("cmd", cmd).Msgf("failed to flush %s rule during removal", partitionChain)
+		}
+	}
+	return nil
+}
+
+// buildPartitionCommands drops traffic from and to each peer. One chain is
+// hooked into both INPUT and OUTPUT: inbound packets match the -s rules,
+// outbound ones the -d rules.
+func buildPartitionCommands(peers []string) [][]string {
+	cmds := [][]string{{"iptables", "-N", partitionChain}}
+	for _, peer := range peers {
+		cmds = append(cmds,
+			[]string{"iptables", "-A", partitionChain, "-s", peer, "-j", "DROP"},
+			[]string{"iptables", "-A", partitionChain, "-d", peer, "-j", "DROP"},
+		)
+	}
+	for _, hook := range []string{"INPUT", "OUTPUT"} {
+		cmds = append(cmds, []string{
+			"iptables", "-I", hook, "1", "-j", partitionChain,
+			"-m", "comment", "--comment", "chaos-engineering",
+		})
+	}
+	return cmds
+}
+
+// ValidatePartitionPeers requires at least one peer, each an IPv4 address
+// or CIDR, since the rules are iptables (not ip6tables) rules.
+func ValidatePartitionPeers(peers []string) error {
+	if len(peers) == 0 {
+		return fmt.Errorf("no peers to partition from")
+	}
+	for _, peer := range peers {
+		ip := net.ParseIP(peer)
+		if ip == nil {
+			var err error
+			if ip, _, err = net.ParseCIDR(peer); err != nil {
+				return fmt.Errorf("peer %q is not an IP address or CIDR", peer)
+			}
+		}
+		if ip.To4() == nil {
+			return fmt.Errorf("peer %q is not IPv4", peer)
+		}
+	}
+	return nil
+}
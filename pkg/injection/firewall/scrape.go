@@ -0,0 +1,57 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// scrapeChain holds the scrape_block fault's rules.
+const scrapeChain = "CHAOS_SCRAPE"
+
+// InjectScrapeBlock cuts the monitoring path to the target: every inbound
+// TCP packet to ports (comma-separated metrics ports) is dropped, so
+// Prometheus scrapes time out and the target's series go stale while the
+// service itself keeps running. Whole packets are dropped rather than
+// SYNs, because Prometheus reuses its scrape connections.
+func (iw *IptablesWrapper) InjectScrapeBlock(ctx context.Context, targetContainerID, ports string) error {
+	if _, exists := iw.sidecarMgr.GetSidecarID(targetContainerID); !exists {
+		fmt.Printf("Creating sidecar for target %s\n", targetContainerID[:12])
+		if _, err := iw.sidecarMgr.CreateSidecar(ctx, targetContainerID); err != nil {
+			return fmt.Errorf("failed to create sidecar: %w", err)
+		}
+	}
+
+	fmt.Printf("Blocking scrapes of ports %s on target %s\n", ports, targetContainerID[:12])
+
+	for _, cmd := range buildScrapeBlockCommands(ports) {
+		if output, err := iw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd); err != nil {
+			return fmt.Errorf("failed to block scrapes: %w (output: %s)", err, output)
+		}
+	}
+	return nil
+}
+
+// RemoveScrapeBlock deletes the scrape_block rules.
+func (iw *IptablesWrapper) RemoveScrapeBlock(ctx context.Context, targetContainerID string) error {
+	iw.removeInputChain(ctx, targetContainerID, scrapeChain)
+	return nil
+}
+
+// buildScrapeBlockCommands drops inbound TCP to each port.
+func buildScrapeBlockCommands(ports string) [][]string {
+	cmds := [][]string{{"iptables", "-N", scrapeChain}}
+	for _, port := range strings.Split(ports, ",") {
+		port = strings.TrimSpace(port)
+		if port == "" {
+			continue
+		}
+		cmds = append(cmds, []string{
+			"iptables", "-A", scrapeChain, "-p", "tcp", "--dport", port, "-j", "DROP",
+		})
+	}
+	return append(cmds, []string{
+		"iptables", "-I", "INPUT", "1", "-j", scrapeChain,
+		"-m", "comment", "--comment", "chaos-engineering",
+	})
+}
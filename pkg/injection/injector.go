@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
@@ -13,12 +15,14 @@ import (
 	"github.com/jihwankim/chaos-utils/pkg/injection/dns"
 	"github.com/jihwankim/chaos-utils/pkg/injection/firewall"
 	"github.com/jihwankim/chaos-utils/pkg/injection/l3l4"
+	"github.com/jihwankim/chaos-utils/pkg/injection/external"
 	chaosp2p "github.com/jihwankim/chaos-utils/pkg/injection/p2p/bor"
 	"github.com/jihwankim/chaos-utils/pkg/injection/process"
 	"github.com/jihwankim/chaos-utils/pkg/injection/sidecar"
 	"github.com/jihwankim/chaos-utils/pkg/injection/stress"
 	chaoshttp "github.com/jihwankim/chaos-utils/pkg/injection/http"
 	chaostime "github.com/jihwankim/chaos-utils/pkg/injection/time"
+	"github.com/jihwankim/chaos-utils/pkg/injection/txpool"
 	"github.com/jihwankim/chaos-utils/pkg/scenario"
 	"github.com/rs/zerolog/log"
 )
@@ -42,16 +46,66 @@ type Injector struct {
 	fileOpsInjector  *disk.FileOpsWrapper
 	clockInjector    *chaostime.ClockSkewWrapper
 	httpInjector     *chaoshttp.HTTPFaultWrapper
+	txpoolInjector   *txpool.Wrapper
 	sidecarMgr       *sidecar.Manager
 	dockerClient     *docker.Client
+	customHandlers   map[string]FaultHandler // from RegisterFaultType
+
+	// podsOnly marks an injector of Kubernetes pod targets (see
+	// NewPodInjector), which has no Docker client and injects only fault
+	// types that support pods.
+	podsOnly bool
+
+	// externalMu guards externalFaults, the providers installed per
+	// container. RemoveFault only receives a container ID, so the provider
+	// config has to be remembered from injection.
+	externalMu     sync.Mutex
+	externalFaults map[string][]externalFault
+
+	// recoveryMu guards recoveries, the restart-to-healthy latencies
+	// measured by verify_health, keyed by fault phase.
+	recoveryMu sync.Mutex
+	recoveries map[string][]Recovery
+
+	// appliedMu guards applied, the variant each target actually got when
+	// a fault falls back from the requested method, keyed by fault phase.
+	appliedMu sync.Mutex
+	applied   map[string][]AppliedMethod
+}
+
+// Recovery is how long a restarted or killed target took to pass its
+// verify_health check, measured from the container's start, with the
+// Docker timestamps of its stop and start.
+type Recovery struct {
+	Target    string
+	Latency   time.Duration
+	StoppedAt time.Time
+	StartedAt time.Time
+	HealthyAt time.Time
+}
+
+// AppliedMethod is the method a fault actually used on one target, and why
+// the requested one was not used when it fell back.
+type AppliedMethod struct {
+	Target   string
+	Method   string
+	Fallback string
+}
+
+type externalFault struct {
+	provider *external.Provider
+	target   external.Target
 }
 
 // New creates a new unified fault injector
 func New(sidecarMgr *sidecar.Manager, dockerClient *docker.Client) *Injector {
+	stressInjector := stress.New(dockerClient)
+	stressInjector.UseSidecar(sidecarMgr)
+
 	return &Injector{
 		tcInjector:       l3l4.NewTCWrapper(sidecarMgr),
 		containerManager: container.NewManager(dockerClient.GetClient()),
-		stressInjector:   stress.New(dockerClient),
+		stressInjector:   stressInjector,
 		firewallInjector: firewall.New(sidecarMgr),
 		dnsInjector:      dns.New(sidecarMgr),
 		processInjector:  process.New(dockerClient),
@@ -60,14 +114,45 @@ func New(sidecarMgr *sidecar.Manager, dockerClient *docker.Client) *Injector {
 		fileOpsInjector:  disk.NewFileOpsWrapper(dockerClient),
 		clockInjector:    chaostime.New(dockerClient),
 		httpInjector:     chaoshttp.New(sidecarMgr),
+		txpoolInjector:   txpool.New(sidecarMgr),
 		sidecarMgr:       sidecarMgr,
 		dockerClient:     dockerClient,
+		customHandlers:   newCustomHandlers(sidecarMgr, dockerClient),
+		externalFaults:   make(map[string][]externalFault),
+		recoveries:       make(map[string][]Recovery),
+		applied:          make(map[string][]AppliedMethod),
 	}
 }
 
-// InjectFault injects a fault based on its type
+// InjectFault injects a fault based on its type. Faults with scheduling
+// params (stagger, stagger_jitter, batch_percent) are rolled out across their
+// targets in batches; see injectScheduled.
 func (i *Injector) InjectFault(ctx context.Context, fault *scenario.Fault, targets []Target) error {
-	switch fault.Type {
+	schedule, err := scenario.ParseSchedule(fault.Params)
+	if err != nil {
+		return fmt.Errorf("invalid scheduling params: %w", err)
+	}
+	if schedule.Active() && len(targets) > 1 {
+		return i.injectScheduled(ctx, fault, targets, schedule)
+	}
+	return i.injectFault(ctx, fault, targets)
+}
+
+// injectFault dispatches a fault to its type's handler.
+func (i *Injector) injectFault(ctx context.Context, fault *scenario.Fault, targets []Target) error {
+	if i.podsOnly {
+		if err := checkPodFault(fault.Type); err != nil {
+			return err
+		}
+		if atBoot, _ := fault.Params["at_boot"].(bool); atBoot {
+			return fmt.Errorf("at_boot is not supported on Kubernetes pods")
+		}
+	}
+	if atBoot, _ := fault.Params["at_boot"].(bool); atBoot {
+		return i.injectAtBoot(ctx, fault, targets)
+	}
+
+	switch scenario.CanonicalFaultType(fault.Type) {
 	case "network":
 		return i.injectNetworkFault(ctx, fault, targets)
 	case "container_restart":
@@ -76,12 +161,18 @@ func (i *Injector) InjectFault(ctx context.Context, fault *scenario.Fault, targe
 		return i.injectContainerKill(ctx, fault, targets)
 	case "container_pause":
 		return i.injectContainerPause(ctx, fault, targets)
-	case "cpu_stress", "cpu":
+	case "drain":
+		return i.injectDrain(ctx, fault, targets)
+	case "scrape_block":
+		return i.injectScrapeBlock(ctx, fault, targets)
+	case "cpu_stress":
 		return i.injectCPUStress(ctx, fault, targets)
-	case "memory_stress", "memory_pressure", "memory":
+	case "memory_stress":
 		return i.injectMemoryStress(ctx, fault, targets)
 	case "connection_drop":
 		return i.injectConnectionDrop(ctx, fault, targets)
+	case "network_partition":
+		return i.injectNetworkPartition(ctx, fault, targets)
 	case "dns":
 		return i.injectDNSDelay(ctx, fault, targets)
 	case "disk_io":
@@ -102,29 +193,78 @@ func (i *Injector) InjectFault(ctx context.Context, fault *scenario.Fault, targe
 		return i.injectCorruptionProxy(ctx, fault, targets)
 	case "p2p_attack":
 		return i.injectP2PAttack(ctx, fault, targets)
+	case "txpool_saturation":
+		return i.injectTxPoolSaturation(ctx, fault, targets)
+	case "external":
+		return i.injectExternal(ctx, fault, targets)
 	default:
+		if h, ok := i.customHandlers[fault.Type]; ok {
+			return h.Inject(ctx, fault, targets)
+		}
 		return fmt.Errorf("unknown fault type: %s", fault.Type)
 	}
 }
 
+// injectAtBoot restarts each target and installs the fault while the fresh
+// container is held paused, so its process boots into the degraded network
+// and cold-start behaviour is what gets tested.
+func (i *Injector) injectAtBoot(ctx context.Context, fault *scenario.Fault, targets []Target) error {
+	boot := *fault
+	boot.Params = make(map[string]interface{}, len(fault.Params))
+	for k, v := range fault.Params {
+		if k != "at_boot" {
+			boot.Params[k] = v
+		}
+	}
+
+	for _, target := range targets {
+		fmt.Printf("  ⏻ %s: restarting to inject %s at boot...\n", target.Name, fault.Type)
+		err := i.containerManager.RestartHeld(ctx, target.ContainerID, container.RestartParams{GracePeriod: 10}, func(ctx context.Context) error {
+			// The old sidecar is attached to the namespace the stop tore
+			// down; the fault must go into the new one.
+			if err := i.sidecarMgr.DestroySidecar(ctx, target.ContainerID); err != nil {
+				log.Warn().Err(err).Str("container", target.ContainerID[:12]).Msg("failed to destroy stale sidecar before boot injection")
+			}
+			return i.injectFault(ctx, &boot, []Target{target})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to inject %s at boot of %s: %w", fault.Type, target.Name, err)
+		}
+	}
+	return nil
+}
+
 // injectNetworkFault handles network fault injection
 func (i *Injector) injectNetworkFault(ctx context.Context, fault *scenario.Fault, targets []Target) error {
 	// Parse network fault parameters
-	params := l3l4.FaultParams{
-		Device: "eth0",
-	}
+	// Device is left empty unless set so the tc wrapper discovers the
+	// interface (eth0 when present) instead of assuming it.
+	params := l3l4.FaultParams{}
 
 	if fault.Params != nil {
 		if device, ok := fault.Params["device"].(string); ok {
 			params.Device = device
 		}
-		if latency, ok := fault.Params["latency"].(int); ok {
+		if all, ok := fault.Params["all_interfaces"].(bool); ok {
+			params.AllInterfaces = all
+		}
+		// latency accepts a bare number of ms (int, or float64 from
+		// `latency: 5000.0` / JSON-decoded overrides — without that the value
+		// silently zeroed, observed with boundary/heimdall-lag-during-fork.yaml)
+		// or a duration string such as "250ms" / "2s".
+		if rawLatency, present := fault.Params["latency"]; present {
+			latency, err := scenario.ParseMillisParam(rawLatency)
+			if err != nil {
+				return fmt.Errorf("invalid latency: %w", err)
+			}
 			params.Latency = latency
-		} else if latency, ok := fault.Params["latency"].(float64); ok {
-			// `latency: 5000.0` or JSON-decoded overrides land here — without
-			// this branch the value silently zeroed (observed with
-			// boundary/heimdall-lag-during-fork.yaml under JSON pipelines).
-			params.Latency = int(latency)
+		}
+		if rawJitter, present := fault.Params["jitter"]; present {
+			jitter, err := scenario.ParseMillisParam(rawJitter)
+			if err != nil {
+				return fmt.Errorf("invalid jitter: %w", err)
+			}
+			params.Jitter = jitter
 		}
 		if packetLoss, ok := fault.Params["packet_loss"].(float64); ok {
 			params.PacketLoss = packetLoss
@@ -152,6 +292,11 @@ func (i *Injector) injectNetworkFault(ctx context.Context, fault *scenario.Fault
 		} else if reorderCorr, ok := fault.Params["reorder_correlation"].(float64); ok {
 			params.ReorderCorrelation = int(reorderCorr)
 		}
+		if reorderGap, ok := fault.Params["reorder_gap"].(int); ok {
+			params.ReorderGap = reorderGap
+		} else if reorderGap, ok := fault.Params["reorder_gap"].(float64); ok {
+			params.ReorderGap = int(reorderGap)
+		}
 		if corrupt, ok := fault.Params["corrupt"].(float64); ok {
 			params.Corrupt = corrupt
 		} else if corrupt, ok := fault.Params["corrupt"].(int); ok {
@@ -184,7 +329,6 @@ func (i *Injector) injectContainerRestart(ctx context.Context, fault *scenario.F
 	params := container.RestartParams{
 		GracePeriod:  10,
 		RestartDelay: 0,
-		Stagger:      0,
 	}
 
 	if fault.Params != nil {
@@ -203,11 +347,11 @@ func (i *Injector) injectContainerRestart(ctx context.Context, fault *scenario.F
 		} else if restartDelay, ok := fault.Params["restart_delay"].(float64); ok {
 			params.RestartDelay = int(restartDelay)
 		}
-		if stagger, ok := fault.Params["stagger"].(int); ok {
-			params.Stagger = stagger
-		} else if stagger, ok := fault.Params["stagger"].(float64); ok {
-			params.Stagger = int(stagger)
-		}
+	}
+
+	healthCheck, err := scenario.ParseHealthCheckParam(fault.Params["verify_health"])
+	if err != nil {
+		return fmt.Errorf("invalid container_restart verify_health: %w", err)
 	}
 
 	// Collect all container IDs
@@ -216,20 +360,22 @@ func (i *Injector) injectContainerRestart(ctx context.Context, fault *scenario.F
 		containerIDs[i] = target.ContainerID
 	}
 
-	// Choose restart strategy based on parameters
+	// Choose restart strategy. stagger is a scheduling param: InjectFault
+	// has already split a staggered restart into per-batch calls, so every
+	// batch restarts simultaneously.
 	if len(containerIDs) == 1 {
 		// Single container - use simple restart
-		return i.containerManager.RestartContainer(ctx, containerIDs[0], params)
+		err = i.containerManager.RestartContainer(ctx, containerIDs[0], params)
+	} else {
+		// Multiple containers - truly simultaneous restart
+		// Stop all first, then start all
+		err = i.containerManager.RestartContainersSimultaneous(ctx, containerIDs, params)
 	}
-
-	if params.Stagger > 0 {
-		// Multiple containers with stagger - restart one by one with delay
-		return i.containerManager.RestartContainersStaggered(ctx, containerIDs, params)
+	if err != nil {
+		return err
 	}
 
-	// Multiple containers with stagger=0 - truly simultaneous restart
-	// Stop all first, then start all
-	return i.containerManager.RestartContainersSimultaneous(ctx, containerIDs, params)
+	return i.verifyHealth(ctx, fault, targets, healthCheck)
 }
 
 // injectContainerKill handles container kill faults
@@ -255,6 +401,14 @@ func (i *Injector) injectContainerKill(ctx context.Context, fault *scenario.Faul
 		}
 	}
 
+	healthCheck, err := scenario.ParseHealthCheckParam(fault.Params["verify_health"])
+	if err != nil {
+		return fmt.Errorf("invalid container_kill verify_health: %w", err)
+	}
+	if healthCheck != nil && !params.Restart {
+		return fmt.Errorf("container_kill verify_health requires restart: true")
+	}
+
 	// Kill all targets
 	for _, target := range targets {
 		if err := i.containerManager.KillContainer(ctx, target.ContainerID, params); err != nil {
@@ -262,9 +416,182 @@ func (i *Injector) injectContainerKill(ctx context.Context, fault *scenario.Faul
 		}
 	}
 
+	return i.verifyHealth(ctx, fault, targets, healthCheck)
+}
+
+// injectDrain performs a graceful rolling restart: one target at a time, it
+// refuses new connections on the drained ports for grace_window while
+// in-flight requests finish, then restarts the container.
+func (i *Injector) injectDrain(ctx context.Context, fault *scenario.Fault, targets []Target) error {
+	ports := portsParam(fault.Params)
+	if ports == "" {
+		return fmt.Errorf("drain requires ports")
+	}
+
+	graceWindow := 30 * time.Second
+	if raw, present := fault.Params["grace_window"]; present {
+		d, err := scenario.ParseDurationParam(raw, time.Second)
+		if err != nil {
+			return fmt.Errorf("invalid drain grace_window: %w", err)
+		}
+		graceWindow = d
+	}
+
+	restartParams := container.RestartParams{GracePeriod: 10}
+	if gracePeriod, ok := fault.Params["grace_period"].(int); ok {
+		restartParams.GracePeriod = gracePeriod
+	} else if gracePeriod, ok := fault.Params["grace_period"].(float64); ok {
+		restartParams.GracePeriod = int(gracePeriod)
+	}
+
+	healthCheck, err := scenario.ParseHealthCheckParam(fault.Params["verify_health"])
+	if err != nil {
+		return fmt.Errorf("invalid drain verify_health: %w", err)
+	}
+
+	for _, target := range targets {
+		if err := i.firewallInjector.InjectDrain(ctx, target.ContainerID, ports); err != nil {
+			return fmt.Errorf("failed to drain %s: %w", target.Name, err)
+		}
+
+		fmt.Printf("  ⏳ %s: draining for %s before restart...\n", target.Name, graceWindow)
+		select {
+		case <-time.After(graceWindow):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if err := i.containerManager.RestartContainer(ctx, target.ContainerID, restartParams); err != nil {
+			return fmt.Errorf("failed to restart drained %s: %w", target.Name, err)
+		}
+
+		// The restart gave the target a new network namespace (without the
+		// drain rules); the old sidecar is attached to the previous one.
+		if err := i.sidecarMgr.DestroySidecar(ctx, target.ContainerID); err != nil {
+			log.Warn().Err(err).Str("container", target.ContainerID[:12]).Msg("failed to destroy stale sidecar after drain restart")
+		}
+
+		if err := i.verifyHealth(ctx, fault, []Target{target}, healthCheck); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// injectScrapeBlock blinds Prometheus to the targets by dropping traffic
+// to their metrics ports. The services keep running; only observation of
+// them is lost.
+func (i *Injector) injectScrapeBlock(ctx context.Context, fault *scenario.Fault, targets []Target) error {
+	ports := portsParam(fault.Params)
+	if ports == "" {
+		return fmt.Errorf("scrape_block requires ports")
+	}
+
+	for _, target := range targets {
+		if err := i.firewallInjector.InjectScrapeBlock(ctx, target.ContainerID, ports); err != nil {
+			return fmt.Errorf("failed to block scrapes of %s: %w", target.Name, err)
+		}
+	}
+	return nil
+}
+
+// portsParam reads a "ports" param given as a CSV string or a single port
+// number.
+func portsParam(params map[string]interface{}) string {
+	switch p := params["ports"].(type) {
+	case string:
+		return strings.TrimSpace(p)
+	case int:
+		return strconv.Itoa(p)
+	case float64:
+		return strconv.Itoa(int(p))
+	}
+	return ""
+}
+
+// verifyHealth waits for every revived target to pass hc and records its
+// restart-to-healthy latency under the fault's phase. A nil hc (no
+// verify_health param) skips the wait.
+func (i *Injector) verifyHealth(ctx context.Context, fault *scenario.Fault, targets []Target, hc *scenario.HealthCheckParam) error {
+	if hc == nil {
+		return nil
+	}
+
+	check := container.HealthCheck{
+		HTTPURL:   hc.HTTPURL,
+		RPCURL:    hc.RPCURL,
+		RPCMethod: hc.RPCMethod,
+		Timeout:   hc.Timeout,
+	}
+	for _, target := range targets {
+		revival, err := i.containerManager.WaitHealthy(ctx, target.ContainerID, check)
+		if err != nil {
+			return fmt.Errorf("%s did not recover: %w", target.Name, err)
+		}
+		fmt.Printf("  ✓ %s healthy %s after restart\n", target.Name, revival.Latency().Round(time.Millisecond))
+
+		i.recoveryMu.Lock()
+		i.recoveries[fault.Phase] = append(i.recoveries[fault.Phase], Recovery{
+			Target:    target.Name,
+			Latency:   revival.Latency(),
+			StoppedAt: revival.StoppedAt,
+			StartedAt: revival.StartedAt,
+			HealthyAt: revival.HealthyAt,
+		})
+		i.recoveryMu.Unlock()
+	}
+	return nil
+}
+
+// ResetRecoveries forgets the latencies measured so far, for an injector
+// reused across scenarios.
+func (i *Injector) ResetRecoveries() {
+	i.recoveryMu.Lock()
+	defer i.recoveryMu.Unlock()
+	i.recoveries = make(map[string][]Recovery)
+}
+
+// Recoveries returns the restart-to-healthy latencies measured so far,
+// keyed by fault phase.
+func (i *Injector) Recoveries() map[string][]Recovery {
+	i.recoveryMu.Lock()
+	defer i.recoveryMu.Unlock()
+
+	out := make(map[string][]Recovery, len(i.recoveries))
+	for phase, r := range i.recoveries {
+		out[phase] = append([]Recovery(nil), r...)
+	}
+	return out
+}
+
+func (i *Injector) recordApplied(phase string, m AppliedMethod) {
+	i.appliedMu.Lock()
+	defer i.appliedMu.Unlock()
+	i.applied[phase] = append(i.applied[phase], m)
+}
+
+// ResetAppliedMethods forgets the applied methods recorded so far, for an
+// injector reused across scenarios.
+func (i *Injector) ResetAppliedMethods() {
+	i.appliedMu.Lock()
+	defer i.appliedMu.Unlock()
+	i.applied = make(map[string][]AppliedMethod)
+}
+
+// AppliedMethods returns the method each target of a method-selecting
+// fault (disk_io) actually got, keyed by fault phase.
+func (i *Injector) AppliedMethods() map[string][]AppliedMethod {
+	i.appliedMu.Lock()
+	defer i.appliedMu.Unlock()
+
+	out := make(map[string][]AppliedMethod, len(i.applied))
+	for phase, m := range i.applied {
+		out[phase] = append([]AppliedMethod(nil), m...)
+	}
+	return out
+}
+
 // injectContainerPause handles container pause faults
 func (i *Injector) injectContainerPause(ctx context.Context, fault *scenario.Fault, targets []Target) error {
 	// Parse pause parameters
@@ -283,22 +610,11 @@ func (i *Injector) injectContainerPause(ctx context.Context, fault *scenario.Fau
 		// unpause (observed as a ~0-byte no-op on the target). Reject unknown
 		// types loudly so new YAML mistakes fail fast instead of vanishing.
 		if rawDuration, present := fault.Params["duration"]; present {
-			switch v := rawDuration.(type) {
-			case string:
-				duration, err := time.ParseDuration(v)
-				if err != nil {
-					return fmt.Errorf("invalid duration format %q: %w", v, err)
-				}
-				params.Duration = duration
-			case int:
-				params.Duration = time.Duration(v) * time.Second
-			case int64:
-				params.Duration = time.Duration(v) * time.Second
-			case float64:
-				params.Duration = time.Duration(float64(time.Second) * v)
-			default:
-				return fmt.Errorf("container_pause duration has unsupported type %T (expected string like \"45s\" or number of seconds)", v)
+			duration, err := scenario.ParseDurationParam(rawDuration, time.Second)
+			if err != nil {
+				return fmt.Errorf("invalid container_pause duration: %w", err)
 			}
+			params.Duration = duration
 		}
 		if unpause, ok := fault.Params["unpause"].(bool); ok {
 			params.Unpause = unpause
@@ -322,6 +638,7 @@ func (i *Injector) injectCPUStress(ctx context.Context, fault *scenario.Fault, t
 		Method:     "stress",
 		CPUPercent: 50,
 		Cores:      1,
+		Timeout:    fault.Duration,
 	}
 
 	if fault.Params != nil {
@@ -359,8 +676,9 @@ func (i *Injector) injectCPUStress(ctx context.Context, fault *scenario.Fault, t
 func (i *Injector) injectMemoryStress(ctx context.Context, fault *scenario.Fault, targets []Target) error {
 	// Parse memory stress parameters
 	params := stress.StressParams{
-		Method:   "stress",
+		Method:   "limit",
 		MemoryMB: 512,
+		Timeout:  fault.Duration,
 	}
 
 	if fault.Params != nil {
@@ -428,6 +746,26 @@ func (i *Injector) injectConnectionDrop(ctx context.Context, fault *scenario.Fau
 	return nil
 }
 
+// injectNetworkPartition cuts the targets off from the addresses in
+// peer_ips. The orchestrator adds the addresses of the peers aliases to
+// peer_ips before injecting.
+func (i *Injector) injectNetworkPartition(ctx context.Context, fault *scenario.Fault, targets []Target) error {
+	peers, err := scenario.ParseListParam(fault.Params["peer_ips"])
+	if err != nil {
+		return fmt.Errorf("invalid network_partition peer_ips: %w", err)
+	}
+	if err := firewall.ValidatePartitionPeers(peers); err != nil {
+		return fmt.Errorf("invalid network_partition parameters: %w", err)
+	}
+
+	for _, target := range targets {
+		if err := i.firewallInjector.InjectPartition(ctx, target.ContainerID, peers); err != nil {
+			return fmt.Errorf("failed to partition %s: %w", target.Name, err)
+		}
+	}
+	return nil
+}
+
 // injectDNSDelay handles DNS delay fault injection
 func (i *Injector) injectDNSDelay(ctx context.Context, fault *scenario.Fault, targets []Target) error {
 	params := dns.DNSParams{
@@ -436,10 +774,12 @@ func (i *Injector) injectDNSDelay(ctx context.Context, fault *scenario.Fault, ta
 	}
 
 	if fault.Params != nil {
-		if delayMs, ok := fault.Params["delay_ms"].(int); ok {
+		if rawDelay, present := fault.Params["delay_ms"]; present {
+			delayMs, err := scenario.ParseMillisParam(rawDelay)
+			if err != nil {
+				return fmt.Errorf("invalid delay_ms: %w", err)
+			}
 			params.DelayMs = delayMs
-		} else if delayMs, ok := fault.Params["delay_ms"].(float64); ok {
-			params.DelayMs = int(delayMs)
 		}
 		if failureRate, ok := fault.Params["failure_rate"].(float64); ok {
 			params.FailureRate = failureRate
@@ -469,10 +809,12 @@ func (i *Injector) injectDiskIODelay(ctx context.Context, fault *scenario.Fault,
 	}
 
 	if fault.Params != nil {
-		if ioLatencyMs, ok := fault.Params["io_latency_ms"].(int); ok {
+		if rawLatency, present := fault.Params["io_latency_ms"]; present {
+			ioLatencyMs, err := scenario.ParseMillisParam(rawLatency)
+			if err != nil {
+				return fmt.Errorf("invalid io_latency_ms: %w", err)
+			}
 			params.IOLatencyMs = ioLatencyMs
-		} else if ioLatencyMs, ok := fault.Params["io_latency_ms"].(float64); ok {
-			params.IOLatencyMs = int(ioLatencyMs)
 		}
 		if targetPath, ok := fault.Params["target_path"].(string); ok {
 			params.TargetPath = targetPath
@@ -493,6 +835,9 @@ func (i *Injector) injectDiskIODelay(ctx context.Context, fault *scenario.Fault,
 		if err := i.diskInjector.InjectIODelay(ctx, target.ContainerID, params); err != nil {
 			return fmt.Errorf("failed to inject I/O delay on %s: %w", target.Name, err)
 		}
+		if m, ok := i.diskInjector.AppliedMethod(target.ContainerID); ok {
+			i.recordApplied(fault.Phase, AppliedMethod{Target: target.Name, Method: m.Method, Fallback: m.Fallback})
+		}
 	}
 
 	return nil
@@ -500,20 +845,32 @@ func (i *Injector) injectDiskIODelay(ctx context.Context, fault *scenario.Fault,
 
 // RemoveFault removes a fault from a target
 func (i *Injector) RemoveFault(ctx context.Context, faultType string, containerID string) error {
-	switch faultType {
+	if i.podsOnly {
+		if err := checkPodFault(faultType); err != nil {
+			return err
+		}
+	}
+	switch scenario.CanonicalFaultType(faultType) {
 	case "network":
 		return i.tcInjector.RemoveFault(ctx, containerID)
 	case "container_restart", "container_kill":
 		// Restart and kill don't need removal - containers are already running
 		return nil
+	case "drain":
+		// Normally gone with the restart; left behind only if it failed.
+		return i.firewallInjector.RemoveDrain(ctx, containerID)
+	case "scrape_block":
+		return i.firewallInjector.RemoveScrapeBlock(ctx, containerID)
 	case "container_pause":
 		// Unpause if it was paused
 		return i.containerManager.UnpauseContainer(ctx, containerID)
-	case "cpu_stress", "cpu", "memory_stress", "memory_pressure", "memory":
+	case "cpu_stress", "memory_stress":
 		// Remove stress faults
 		return i.stressInjector.RemoveFault(ctx, containerID)
 	case "connection_drop":
 		return i.firewallInjector.RemoveFault(ctx, containerID)
+	case "network_partition":
+		return i.firewallInjector.RemovePartition(ctx, containerID)
 	case "dns":
 		return i.dnsInjector.RemoveFault(ctx, containerID)
 	case "disk_io":
@@ -537,7 +894,14 @@ func (i *Injector) RemoveFault(ctx context.Context, faultType string, containerI
 		// P2P attacks are short-lived connections; the peer disconnects when done.
 		// Nothing to clean up on the target side.
 		return nil
+	case "txpool_saturation":
+		return i.txpoolInjector.RemoveFault(ctx, containerID)
+	case "external":
+		return i.removeExternal(ctx, containerID)
 	default:
+		if h, ok := i.customHandlers[faultType]; ok {
+			return h.Remove(ctx, containerID)
+		}
 		return fmt.Errorf("unknown fault type for removal: %s", faultType)
 	}
 }
@@ -733,10 +1097,12 @@ func (i *Injector) injectHTTPFault(ctx context.Context, fault *scenario.Fault, t
 		} else if targetPort, ok := fault.Params["target_port"].(float64); ok {
 			params.TargetPort = int(targetPort)
 		}
-		if delayMs, ok := fault.Params["delay_ms"].(int); ok {
+		if rawDelay, present := fault.Params["delay_ms"]; present {
+			delayMs, err := scenario.ParseMillisParam(rawDelay)
+			if err != nil {
+				return fmt.Errorf("invalid delay_ms: %w", err)
+			}
 			params.DelayMs = delayMs
-		} else if delayMs, ok := fault.Params["delay_ms"].(float64); ok {
-			params.DelayMs = int(delayMs)
 		}
 		if delayPercent, ok := fault.Params["delay_percent"].(int); ok {
 			params.DelayPercent = delayPercent
@@ -924,6 +1290,54 @@ func runP2PAttack(ctx context.Context, peer *chaosp2p.ChaosPeer, attackType stri
 	}
 }
 
+// injectTxPoolSaturation starts chaos-txflood in each target's sidecar,
+// flooding the node's pool with self-transfers signed by private_key.
+// Every target floods from the same account, so give each fault its own
+// key when several targets share a pool through gossip.
+func (i *Injector) injectTxPoolSaturation(ctx context.Context, fault *scenario.Fault, targets []Target) error {
+	params := txpool.Params{
+		Rate:    100,
+		RPCPort: 8545,
+	}
+
+	if fault.Params != nil {
+		if key, ok := fault.Params["private_key"].(string); ok {
+			params.PrivateKey = key
+		}
+		if rate, ok := fault.Params["rate"].(float64); ok {
+			params.Rate = rate
+		} else if rate, ok := fault.Params["rate"].(int); ok {
+			params.Rate = float64(rate)
+		}
+		if count, ok := fault.Params["count"].(int); ok {
+			params.Count = count
+		} else if count, ok := fault.Params["count"].(float64); ok {
+			params.Count = int(count)
+		}
+		if gwei, ok := fault.Params["gas_price_gwei"].(float64); ok {
+			params.GasPriceGwei = gwei
+		} else if gwei, ok := fault.Params["gas_price_gwei"].(int); ok {
+			params.GasPriceGwei = float64(gwei)
+		}
+		if port, ok := fault.Params["rpc_port"].(int); ok {
+			params.RPCPort = port
+		} else if port, ok := fault.Params["rpc_port"].(float64); ok {
+			params.RPCPort = int(port)
+		}
+	}
+
+	if err := txpool.ValidateParams(params); err != nil {
+		return fmt.Errorf("invalid txpool_saturation parameters: %w", err)
+	}
+
+	for _, target := range targets {
+		if err := i.txpoolInjector.InjectFlood(ctx, target.ContainerID, params); err != nil {
+			return fmt.Errorf("failed to flood txpool of %s: %w", target.Name, err)
+		}
+	}
+	return nil
+}
+
 // getContainerIP returns the first Docker network IP of a container.
 func (i *Injector) getContainerIP(ctx context.Context, containerID string) string {
 	info, err := i.dockerClient.ContainerInspect(ctx, containerID)
@@ -1087,3 +1501,105 @@ func (i *Injector) removeCorruptionProxy(ctx context.Context, containerID string
 	fmt.Printf("Corruption proxy removed from target %s\n", containerID[:12])
 	return nil
 }
+
+// externalConfigKeys configure the provider itself; every other key under
+// params is forwarded to it untouched.
+var externalConfigKeys = map[string]bool{"provider": true, "command": true, "url": true, "timeout": true}
+
+// ParseExternalConfig builds the provider config for an `external` fault.
+// command may be a string (split on whitespace) or a list of arguments.
+func ParseExternalConfig(params map[string]interface{}) (external.Config, error) {
+	cfg := external.Config{Params: make(map[string]interface{})}
+
+	cfg.Provider, _ = params["provider"].(string)
+	cfg.URL, _ = params["url"].(string)
+
+	switch cmd := params["command"].(type) {
+	case nil:
+	case string:
+		cfg.Command = strings.Fields(cmd)
+	case []interface{}:
+		for _, arg := range cmd {
+			cfg.Command = append(cfg.Command, fmt.Sprint(arg))
+		}
+	default:
+		return cfg, fmt.Errorf("command must be a string or list, got %T", cmd)
+	}
+
+	if raw, ok := params["timeout"]; ok {
+		timeout, err := scenario.ParseDurationParam(raw, time.Second)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid timeout: %w", err)
+		}
+		cfg.Timeout = timeout
+	}
+
+	for k, v := range params {
+		if !externalConfigKeys[k] {
+			cfg.Params[k] = v
+		}
+	}
+	return cfg, nil
+}
+
+// injectExternal delegates injection to an exec/HTTP provider, once per target.
+func (i *Injector) injectExternal(ctx context.Context, fault *scenario.Fault, targets []Target) error {
+	cfg, err := ParseExternalConfig(fault.Params)
+	if err != nil {
+		return err
+	}
+	provider, err := external.New(cfg)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		t := external.Target{Name: target.Name, ContainerID: target.ContainerID}
+		if err := provider.Inject(ctx, t); err != nil {
+			return fmt.Errorf("failed to inject external fault on %s: %w", target.Name, err)
+		}
+
+		i.externalMu.Lock()
+		i.externalFaults[target.ContainerID] = append(i.externalFaults[target.ContainerID], externalFault{provider: provider, target: t})
+		i.externalMu.Unlock()
+
+		log.Info().Str("target", target.Name).Str("provider", cfg.Provider).Msg("External fault injected")
+	}
+	return nil
+}
+
+// removeExternal calls remove on every provider injected into the container,
+// newest first. Entries are dropped even on error so a broken provider does
+// not wedge later teardowns; the error is still returned.
+func (i *Injector) removeExternal(ctx context.Context, containerID string) error {
+	i.externalMu.Lock()
+	faults := i.externalFaults[containerID]
+	delete(i.externalFaults, containerID)
+	i.externalMu.Unlock()
+
+	var errs []string
+	for j := len(faults) - 1; j >= 0; j-- {
+		if err := faults[j].provider.Remove(ctx, faults[j].target); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to remove external fault(s): %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// VerifyExternal asks each provider injected into the container whether its
+// fault is in effect.
+func (i *Injector) VerifyExternal(ctx context.Context, containerID string) error {
+	i.externalMu.Lock()
+	faults := append([]externalFault(nil), i.externalFaults[containerID]...)
+	i.externalMu.Unlock()
+
+	for _, f := range faults {
+		if err := f.provider.Verify(ctx, f.target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
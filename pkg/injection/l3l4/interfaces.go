@@ -0,0 +1,81 @@
+package l3l4
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ipLink is the subset of `ip -json link show` output we need.
+type ipLink struct {
+	IfName   string   `json:"ifname"`
+	LinkType string   `json:"link_type"`
+	Flags    []string `json:"flags"`
+}
+
+// DiscoverInterfaces lists the non-loopback interfaces in the target's
+// network namespace by running `ip -json link show` in its sidecar.
+func (tw *TCWrapper) DiscoverInterfaces(ctx context.Context, targetContainerID string) ([]string, error) {
+	if err := tw.ensureSidecar(ctx, targetContainerID); err != nil {
+		return nil, err
+	}
+	output, err := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, []string{"ip", "-json", "link", "show"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interfaces: %w (output: %s)", err, output)
+	}
+	return parseInterfaces(output)
+}
+
+// parseInterfaces extracts non-loopback interface names from `ip -json
+// link show` output, sorted for stable ordering.
+func parseInterfaces(output string) ([]string, error) {
+	// ExecInSidecar output can carry a trailing newline or leading noise
+	// from the exec stream; the JSON array starts at the first '['.
+	start := strings.Index(output, "[")
+	if start < 0 {
+		return nil, fmt.Errorf("unexpected ip link output: %q", output)
+	}
+	var links []ipLink
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output[start:])), &links); err != nil {
+		return nil, fmt.Errorf("failed to parse ip link output: %w", err)
+	}
+
+	var names []string
+	for _, l := range links {
+		if l.LinkType == "loopback" || l.IfName == "lo" {
+			continue
+		}
+		// veth peers show up as "eth0@if123" in plain output but ifname is
+		// already bare in JSON; strip defensively for older iproute2.
+		name := l.IfName
+		if i := strings.Index(name, "@"); i >= 0 {
+			name = name[:i]
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// defaultInterface picks the device for a fault that did not name one:
+// eth0 when present (the historical default), otherwise the only
+// non-loopback interface. With several candidates and no eth0 the choice
+// would be a guess, so it is an error that lists them.
+func defaultInterface(ifaces []string) (string, error) {
+	for _, name := range ifaces {
+		if name == "eth0" {
+			return name, nil
+		}
+	}
+	switch len(ifaces) {
+	case 0:
+		return "", fmt.Errorf("no non-loopback interfaces found")
+	case 1:
+		return ifaces[0], nil
+	default:
+		return "", fmt.Errorf("no eth0 and multiple interfaces (%s): set params.device or all_interfaces: true",
+			strings.Join(ifaces, ", "))
+	}
+}
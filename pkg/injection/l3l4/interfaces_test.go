@@ -0,0 +1,50 @@
+package l3l4
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseInterfaces(t *testing.T) {
+	output := `[{"ifindex":1,"ifname":"lo","flags":["LOOPBACK","UP"],"link_type":"loopback"},` +
+		`{"ifindex":42,"ifname":"eth1","flags":["UP"],"link_type":"ether"},` +
+		`{"ifindex":40,"ifname":"eth0","flags":["UP"],"link_type":"ether","link_index":41}]` + "\n"
+
+	got, err := parseInterfaces(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"eth0", "eth1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseInterfaces() = %v, want %v", got, want)
+	}
+
+	if _, err := parseInterfaces("Object \"link\" is unknown"); err == nil {
+		t.Error("expected error for non-JSON output")
+	}
+}
+
+func TestDefaultInterface(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifaces  []string
+		want    string
+		wantErr bool
+	}{
+		{"eth0 preferred", []string{"eth0", "eth1"}, "eth0", false},
+		{"single non-eth0", []string{"ens5"}, "ens5", false},
+		{"ambiguous", []string{"ens5", "ens6"}, "", true},
+		{"none", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := defaultInterface(tt.ifaces)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("defaultInterface() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("defaultInterface() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
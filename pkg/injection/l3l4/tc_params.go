@@ -1,17 +1,32 @@
 package l3l4
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
+// ErrQdiscConflict means a tc add kept failing with "File exists" after the
+// automatic replace retry: something else owns the qdisc/filter slot,
+// usually another fault on the same device.
+var ErrQdiscConflict = errors.New("conflicting tc qdisc already installed")
+
 // FaultParams defines parameters for L3/L4 network fault injection via tc netem
 type FaultParams struct {
-	// Device is the network interface (default: eth0)
+	// Device is the network interface. Empty means discover: eth0 if
+	// present, else the container's only non-loopback interface.
 	Device string
 
+	// AllInterfaces applies the fault to every non-loopback interface,
+	// ignoring Device.
+	AllInterfaces bool
+
 	// Latency in milliseconds
 	Latency int
 
+	// Jitter in milliseconds, the random variation around Latency
+	Jitter int
+
 	// PacketLoss as percentage (0-100)
 	PacketLoss float64
 
@@ -24,6 +39,11 @@ type FaultParams struct {
 	// ReorderCorrelation percentage (0-100) - correlation between reordered packets
 	ReorderCorrelation int
 
+	// ReorderGap reorders every Nth packet deterministically instead of at
+	// random: netem sends packets gap, 2*gap, ... immediately (subject to
+	// Reorder) and delays the rest. 0 means random reordering.
+	ReorderGap int
+
 	// Corrupt percentage (0-100) - probability of packet corruption
 	Corrupt float64
 
@@ -51,6 +71,14 @@ func ValidateFaultParams(params FaultParams) error {
 		return fmt.Errorf("bandwidth cannot be negative")
 	}
 
+	if params.Jitter < 0 {
+		return fmt.Errorf("jitter cannot be negative")
+	}
+
+	if params.Jitter > 0 && params.Latency == 0 {
+		return fmt.Errorf("jitter requires latency to be set")
+	}
+
 	if params.Corrupt < 0 || params.Corrupt > 100 {
 		return fmt.Errorf("corrupt must be between 0 and 100")
 	}
@@ -77,5 +105,43 @@ func ValidateFaultParams(params FaultParams) error {
 		return fmt.Errorf("reorder_correlation must be between 0 and 100")
 	}
 
+	if params.ReorderGap < 0 {
+		return fmt.Errorf("reorder_gap cannot be negative")
+	}
+
+	if (params.ReorderCorrelation > 0 || params.ReorderGap > 0) && params.Reorder == 0 {
+		return fmt.Errorf("reorder_correlation and reorder_gap require reorder to be set")
+	}
+
 	return nil
 }
+
+// InjectionError is returned when a tc command fails during injection. It
+// carries everything needed to diagnose the failure without re-running it:
+// the exact command, its combined output, and the interface/qdisc state of
+// the target namespace captured right after the failure. Error() includes
+// all of it, so it also lands verbatim in the report's errors list.
+type InjectionError struct {
+	ContainerID string
+	Device      string
+	Command     []string
+	Output      string
+	// InterfaceState is `ip -brief link` + `tc qdisc show` from the sidecar,
+	// or a note explaining why it could not be captured.
+	InterfaceState string
+	Err            error
+}
+
+func (e *InjectionError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "tc command failed on %s: %v\n  command: %s", e.Device, e.Err, strings.Join(e.Command, " "))
+	if out := strings.TrimSpace(e.Output); out != "" {
+		fmt.Fprintf(&b, "\n  output: %s", out)
+	}
+	if state := strings.TrimSpace(e.InterfaceState); state != "" {
+		fmt.Fprintf(&b, "\n  interface state:\n    %s", strings.ReplaceAll(state, "\n", "\n    "))
+	}
+	return b.String()
+}
+
+func (e *InjectionError) Unwrap() error { return e.Err }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/rs/zerolog/log"
 )
@@ -13,6 +14,11 @@ import (
 // netem root qdisc.
 type TCWrapper struct {
 	sidecarMgr SidecarManager
+
+	// devices records which interfaces were shaped per container so
+	// RemoveFault clears exactly those.
+	mu      sync.Mutex
+	devices map[string][]string
 }
 
 // SidecarManager interface for sidecar operations
@@ -26,44 +32,109 @@ type SidecarManager interface {
 func NewTCWrapper(sidecarMgr SidecarManager) *TCWrapper {
 	return &TCWrapper{
 		sidecarMgr: sidecarMgr,
+		devices:    make(map[string][]string),
 	}
 }
 
 // InjectFault injects a network fault using tc commands.
 // When port filtering is specified, uses a prio qdisc with u32 filters.
-// Otherwise, uses a simple root netem qdisc.
+// Otherwise, uses a simple root netem qdisc. The fault is applied to
+// params.Device, to every interface when AllInterfaces is set, or to the
+// discovered default interface when Device is empty.
 func (tw *TCWrapper) InjectFault(ctx context.Context, targetContainerID string, params FaultParams) error {
 	if err := tw.ensureSidecar(ctx, targetContainerID); err != nil {
 		return err
 	}
 
-	tw.clearRules(ctx, targetContainerID, params.Device)
+	devices, err := tw.resolveDevices(ctx, targetContainerID, params)
+	if err != nil {
+		return err
+	}
+
+	for _, device := range devices {
+		p := params
+		p.Device = device
+
+		tw.clearRules(ctx, targetContainerID, device)
+		tw.trackDevice(targetContainerID, device)
+
+		if p.TargetPorts != "" {
+			err = tw.injectWithPortFilter(ctx, targetContainerID, p)
+		} else {
+			err = tw.injectWholeDevice(ctx, targetContainerID, p)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", device, err)
+		}
+	}
+	return nil
+}
 
-	if params.TargetPorts != "" {
-		return tw.injectWithPortFilter(ctx, targetContainerID, params)
+// resolveDevices returns the interfaces a fault applies to.
+func (tw *TCWrapper) resolveDevices(ctx context.Context, targetContainerID string, params FaultParams) ([]string, error) {
+	if params.Device != "" && !params.AllInterfaces {
+		return []string{params.Device}, nil
 	}
 
-	return tw.injectWholeDevice(ctx, targetContainerID, params)
+	ifaces, err := tw.DiscoverInterfaces(ctx, targetContainerID)
+	if err != nil {
+		return nil, fmt.Errorf("interface discovery failed: %w", err)
+	}
+	if params.AllInterfaces {
+		if len(ifaces) == 0 {
+			return nil, fmt.Errorf("all_interfaces: no non-loopback interfaces found")
+		}
+		return ifaces, nil
+	}
+	device, err := defaultInterface(ifaces)
+	if err != nil {
+		return nil, err
+	}
+	return []string{device}, nil
 }
 
-// RemoveFault removes all tc rules from the device
+// trackDevice remembers a shaped device for RemoveFault. Tracking happens
+// before the add so a partially-applied fault is still cleaned up.
+func (tw *TCWrapper) trackDevice(targetContainerID, device string) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	for _, d := range tw.devices[targetContainerID] {
+		if d == device {
+			return
+		}
+	}
+	tw.devices[targetContainerID] = append(tw.devices[targetContainerID], device)
+}
+
+// RemoveFault removes tc rules from every device the container was shaped
+// on (eth0 if nothing was recorded, e.g. a wrapper created after injection).
 func (tw *TCWrapper) RemoveFault(ctx context.Context, targetContainerID string) error {
 	if _, exists := tw.sidecarMgr.GetSidecarID(targetContainerID); !exists {
 		return fmt.Errorf("no sidecar found for target %s", targetContainerID)
 	}
 
-	fmt.Printf("Removing tc rules from target %s\n", targetContainerID[:12])
+	tw.mu.Lock()
+	devices := tw.devices[targetContainerID]
+	delete(tw.devices, targetContainerID)
+	tw.mu.Unlock()
+	if len(devices) == 0 {
+		devices = []string{"eth0"}
+	}
 
-	cmd := []string{"tc", "qdisc", "del", "dev", "eth0", "root"}
-	_, tcErr := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd)
-	if tcErr != nil {
-		// Same benign-absence path as clearRules: teardown after an inject
-		// that never got past the sidecar-create stage leaves no root qdisc
-		// to delete. Demote so success teardowns stay quiet.
-		if isBenignTCAbsentErr(tcErr) {
-			log.Debug().Err(tcErr).Str("container", targetContainerID[:12]).Msg("no tc qdisc present at teardown (nothing to remove)")
-		} else {
-			log.Warn().Err(tcErr).Str("container", targetContainerID[:12]).Msg("failed to remove tc qdisc during fault removal")
+	fmt.Printf("Removing tc rules from target %s (%s)\n", targetContainerID[:12], strings.Join(devices, ", "))
+
+	for _, device := range devices {
+		cmd := []string{"tc", "qdisc", "del", "dev", device, "root"}
+		_, tcErr := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd)
+		if tcErr != nil {
+			// Same benign-absence path as clearRules: teardown after an inject
+			// that never got past the sidecar-create stage leaves no root qdisc
+			// to delete. Demote so success teardowns stay quiet.
+			if isBenignTCAbsentErr(tcErr) {
+				log.Debug().Err(tcErr).Str("container", targetContainerID[:12]).Str("device", device).Msg("no tc qdisc present at teardown (nothing to remove)")
+			} else {
+				log.Warn().Err(tcErr).Str("container", targetContainerID[:12]).Str("device", device).Msg("failed to remove tc qdisc during fault removal")
+			}
 		}
 	}
 
@@ -115,6 +186,22 @@ func isBenignTCAbsentErr(err error) bool {
 		strings.Contains(msg, "RTNETLINK answers: No such file or directory")
 }
 
+// isFileExistsErr matches the kernel's EEXIST answer to a tc add.
+func isFileExistsErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "File exists")
+}
+
+// asQdiscReplace rewrites `tc qdisc add ...` to `tc qdisc replace ...`.
+// Other commands (filters) have no safe replace form.
+func asQdiscReplace(cmd []string) ([]string, bool) {
+	if len(cmd) < 3 || cmd[0] != "tc" || cmd[1] != "qdisc" || cmd[2] != "add" {
+		return nil, false
+	}
+	out := append([]string{}, cmd...)
+	out[2] = "replace"
+	return out, true
+}
+
 // injectWholeDevice applies netem directly as the root qdisc — affects all traffic on the device.
 func (tw *TCWrapper) injectWholeDevice(ctx context.Context, targetContainerID string, params FaultParams) error {
 	device := params.Device
@@ -127,9 +214,8 @@ func (tw *TCWrapper) injectWholeDevice(ctx context.Context, targetContainerID st
 
 	fmt.Printf("Injecting fault on target %s: %s\n", targetContainerID[:12], strings.Join(cmd, " "))
 
-	output, err := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd)
-	if err != nil {
-		return fmt.Errorf("failed to inject network fault: %w (output: %s)", err, output)
+	if err := tw.exec(ctx, targetContainerID, device, cmd); err != nil {
+		return fmt.Errorf("failed to inject network fault: %w", err)
 	}
 
 	fmt.Printf("Fault injected successfully on target %s\n", targetContainerID[:12])
@@ -149,15 +235,15 @@ func (tw *TCWrapper) injectWithPortFilter(ctx context.Context, targetContainerID
 	prioCmd := []string{"tc", "qdisc", "add", "dev", device, "root", "handle", "1:", "prio", "bands", "3", "priomap",
 		"0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0"}
 	fmt.Printf("Injecting fault on target %s: setting up tc prio qdisc with u32 port filter\n", targetContainerID[:12])
-	if output, err := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, prioCmd); err != nil {
-		return fmt.Errorf("failed to create prio qdisc: %w (output: %s)", err, output)
+	if err := tw.exec(ctx, targetContainerID, device, prioCmd); err != nil {
+		return fmt.Errorf("failed to create prio qdisc: %w", err)
 	}
 
 	// Step 2: Attach netem to band 2 with fault parameters
 	netemCmd := []string{"tc", "qdisc", "add", "dev", device, "parent", "1:2", "handle", "20:", "netem"}
 	netemCmd = appendNetemParams(netemCmd, params)
-	if output, err := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, netemCmd); err != nil {
-		return fmt.Errorf("failed to create netem qdisc: %w (output: %s)", err, output)
+	if err := tw.exec(ctx, targetContainerID, device, netemCmd); err != nil {
+		return fmt.Errorf("failed to create netem qdisc: %w", err)
 	}
 
 	// Step 3: Add u32 filters to match traffic by port and direct to band 2
@@ -176,16 +262,16 @@ func (tw *TCWrapper) injectWithPortFilter(ctx context.Context, targetContainerID
 			dportCmd := []string{"tc", "filter", "add", "dev", device, "parent", "1:0", "protocol", "ip",
 				"u32", "match", "ip", "protocol", protoNum, "0xff",
 				"match", "ip", "dport", port, "0xffff", "flowid", "1:2"}
-			if output, err := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, dportCmd); err != nil {
-				return fmt.Errorf("failed to add dport filter for %s/%s: %w (output: %s)", proto, port, err, output)
+			if err := tw.exec(ctx, targetContainerID, device, dportCmd); err != nil {
+				return fmt.Errorf("failed to add dport filter for %s/%s: %w", proto, port, err)
 			}
 
 			// Match source port
 			sportCmd := []string{"tc", "filter", "add", "dev", device, "parent", "1:0", "protocol", "ip",
 				"u32", "match", "ip", "protocol", protoNum, "0xff",
 				"match", "ip", "sport", port, "0xffff", "flowid", "1:2"}
-			if output, err := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, sportCmd); err != nil {
-				return fmt.Errorf("failed to add sport filter for %s/%s: %w (output: %s)", proto, port, err, output)
+			if err := tw.exec(ctx, targetContainerID, device, sportCmd); err != nil {
+				return fmt.Errorf("failed to add sport filter for %s/%s: %w", proto, port, err)
 			}
 
 			fmt.Printf("  → %s port %s filter added (sport + dport)\n", proto, port)
@@ -196,10 +282,66 @@ func (tw *TCWrapper) injectWithPortFilter(ctx context.Context, targetContainerID
 	return nil
 }
 
+// exec runs one tc command in the sidecar. On failure it returns an
+// *InjectionError with the command, its output, and a snapshot of the
+// namespace's interfaces and qdiscs, so a failed inject explains itself.
+//
+// A `tc qdisc add` that hits "File exists" (a leftover qdisc from an
+// interrupted run, or a concurrent inject on the same device) is retried
+// once as `tc qdisc replace`, which installs ours over whatever is there.
+// If that also conflicts — or a filter add conflicts, which replace cannot
+// resolve — the error wraps ErrQdiscConflict.
+func (tw *TCWrapper) exec(ctx context.Context, targetContainerID, device string, cmd []string) error {
+	output, err := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd)
+	if err == nil {
+		return nil
+	}
+
+	if isFileExistsErr(err) {
+		if replaceCmd, ok := asQdiscReplace(cmd); ok {
+			log.Warn().Str("container", targetContainerID[:12]).Str("device", device).
+				Msg("tc qdisc already exists, retrying with replace")
+			cmd = replaceCmd
+			output, err = tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, cmd)
+			if err == nil {
+				return nil
+			}
+		}
+		if isFileExistsErr(err) {
+			err = fmt.Errorf("%w: %v", ErrQdiscConflict, err)
+		}
+	}
+
+	return &InjectionError{
+		ContainerID:    targetContainerID,
+		Device:         device,
+		Command:        cmd,
+		Output:         output,
+		InterfaceState: tw.interfaceState(ctx, targetContainerID),
+		Err:            err,
+	}
+}
+
+// interfaceState is a best-effort snapshot for error reports.
+func (tw *TCWrapper) interfaceState(ctx context.Context, targetContainerID string) string {
+	links, err := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, []string{"ip", "-brief", "link", "show"})
+	if err != nil {
+		return fmt.Sprintf("(could not capture interface state: %v)", err)
+	}
+	qdiscs, err := tw.sidecarMgr.ExecInSidecar(ctx, targetContainerID, []string{"tc", "qdisc", "show"})
+	if err != nil {
+		qdiscs = fmt.Sprintf("(tc qdisc show failed: %v)", err)
+	}
+	return strings.TrimSpace(links) + "\n" + strings.TrimSpace(qdiscs)
+}
+
 // appendNetemParams appends netem parameters (delay, loss, reorder) to a tc command
 func appendNetemParams(cmd []string, params FaultParams) []string {
 	if params.Latency > 0 {
 		cmd = append(cmd, "delay", fmt.Sprintf("%dms", params.Latency))
+		if params.Jitter > 0 {
+			cmd = append(cmd, fmt.Sprintf("%dms", params.Jitter))
+		}
 	}
 	if params.PacketLoss > 0 {
 		cmd = append(cmd, "loss", fmt.Sprintf("%.2f%%", params.PacketLoss))
@@ -209,6 +351,9 @@ func appendNetemParams(cmd []string, params FaultParams) []string {
 		if params.ReorderCorrelation > 0 {
 			cmd = append(cmd, fmt.Sprintf("%d%%", params.ReorderCorrelation))
 		}
+		if params.ReorderGap > 0 {
+			cmd = append(cmd, "gap", fmt.Sprintf("%d", params.ReorderGap))
+		}
 	}
 	if params.Corrupt > 0 {
 		cmd = append(cmd, "corrupt", fmt.Sprintf("%.2f%%", params.Corrupt))
@@ -0,0 +1,128 @@
+package l3l4
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeSidecar answers ExecInSidecar from a table keyed by the joined
+// command prefix; unmatched commands succeed with empty output.
+type fakeSidecar struct {
+	fail map[string]string // command prefix -> output to return with an error
+	out  map[string]string // command prefix -> successful output
+}
+
+func (f *fakeSidecar) CreateSidecar(ctx context.Context, id string) (string, error) {
+	return "sidecar", nil
+}
+func (f *fakeSidecar) GetSidecarID(id string) (string, bool) { return "sidecar", true }
+
+func (f *fakeSidecar) ExecInSidecar(ctx context.Context, id string, cmd []string) (string, error) {
+	joined := strings.Join(cmd, " ")
+	for prefix, output := range f.fail {
+		if strings.HasPrefix(joined, prefix) {
+			return output, fmt.Errorf("command exited with code 2: %s", output)
+		}
+	}
+	for prefix, output := range f.out {
+		if strings.HasPrefix(joined, prefix) {
+			return output, nil
+		}
+	}
+	return "", nil
+}
+
+func TestInjectFault_ErrorCarriesContext(t *testing.T) {
+	sc := &fakeSidecar{
+		fail: map[string]string{"tc qdisc add dev eth0 root netem": "Error: Specified qdisc kind is unknown."},
+		out: map[string]string{
+			"ip -brief link show": "lo UNKNOWN\neth0@if12 UP",
+			"tc qdisc show":       "qdisc noqueue 0: dev eth0 root refcnt 2",
+		},
+	}
+	tw := NewTCWrapper(sc)
+
+	err := tw.InjectFault(context.Background(), "0123456789abcdef", FaultParams{Device: "eth0", Latency: 100})
+	var injErr *InjectionError
+	if !errors.As(err, &injErr) {
+		t.Fatalf("expected *InjectionError, got %T: %v", err, err)
+	}
+	if injErr.Device != "eth0" || !strings.Contains(injErr.Output, "qdisc kind is unknown") {
+		t.Errorf("unexpected error fields: %+v", injErr)
+	}
+	for _, want := range []string{"delay 100ms", "qdisc kind is unknown", "eth0@if12 UP", "noqueue"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error message missing %q:\n%s", want, err.Error())
+		}
+	}
+}
+
+func TestInjectFault_FileExistsRetriesWithReplace(t *testing.T) {
+	sc := &fakeSidecar{fail: map[string]string{
+		"tc qdisc add dev eth0 root netem": "RTNETLINK answers: File exists",
+	}}
+	tw := NewTCWrapper(sc)
+
+	if err := tw.InjectFault(context.Background(), "0123456789abcdef", FaultParams{Device: "eth0", Latency: 100}); err != nil {
+		t.Fatalf("expected replace retry to succeed, got %v", err)
+	}
+}
+
+func TestInjectFault_PersistentConflict(t *testing.T) {
+	sc := &fakeSidecar{fail: map[string]string{
+		"tc qdisc add dev eth0 root netem":     "RTNETLINK answers: File exists",
+		"tc qdisc replace dev eth0 root netem": "RTNETLINK answers: File exists",
+	}}
+	tw := NewTCWrapper(sc)
+
+	err := tw.InjectFault(context.Background(), "0123456789abcdef", FaultParams{Device: "eth0", Latency: 100})
+	if !errors.Is(err, ErrQdiscConflict) {
+		t.Fatalf("expected ErrQdiscConflict, got %v", err)
+	}
+}
+
+func TestAppendNetemParams_Reorder(t *testing.T) {
+	tests := []struct {
+		name   string
+		params FaultParams
+		want   string
+	}{
+		{"probability only", FaultParams{Latency: 100, Reorder: 25}, "delay 100ms reorder 25%"},
+		{"with correlation", FaultParams{Latency: 100, Reorder: 25, ReorderCorrelation: 50}, "delay 100ms reorder 25% 50%"},
+		{"with gap", FaultParams{Latency: 100, Reorder: 25, ReorderCorrelation: 50, ReorderGap: 5}, "delay 100ms reorder 25% 50% gap 5"},
+		{"gap without correlation", FaultParams{Latency: 100, Reorder: 100, ReorderGap: 5}, "delay 100ms reorder 100% gap 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateFaultParams(tt.params); err != nil {
+				t.Fatalf("ValidateFaultParams() error = %v", err)
+			}
+			if got := strings.Join(appendNetemParams(nil, tt.params), " "); got != tt.want {
+				t.Errorf("appendNetemParams() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFaultParams_Reorder(t *testing.T) {
+	tests := []struct {
+		name   string
+		params FaultParams
+	}{
+		{"reorder without latency", FaultParams{Reorder: 25}},
+		{"correlation out of range", FaultParams{Latency: 100, Reorder: 25, ReorderCorrelation: 101}},
+		{"negative gap", FaultParams{Latency: 100, Reorder: 25, ReorderGap: -1}},
+		{"gap without reorder", FaultParams{Latency: 100, ReorderGap: 5}},
+		{"correlation without reorder", FaultParams{Latency: 100, ReorderCorrelation: 50}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateFaultParams(tt.params); err == nil {
+				t.Errorf("ValidateFaultParams(%+v) = nil, want an error", tt.params)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package injection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jihwankim/chaos-utils/pkg/injection/dns"
+	"github.com/jihwankim/chaos-utils/pkg/injection/firewall"
+	"github.com/jihwankim/chaos-utils/pkg/injection/l3l4"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// PodSidecarManager runs commands in the network namespace of Kubernetes
+// pod targets, keyed by pod UID; see sidecar.PodManager.
+type PodSidecarManager interface {
+	CreateSidecar(ctx context.Context, targetContainerID string) (string, error)
+	ExecInSidecar(ctx context.Context, targetContainerID string, cmd []string) (string, error)
+	GetSidecarID(targetContainerID string) (string, bool)
+}
+
+// NewPodInjector creates an injector for Kubernetes pod targets, whose
+// Target.ContainerID is the pod UID. Only fault types marked Pods in the
+// registry are supported: the tc, iptables and DNS wrappers run through
+// pods' debug containers exactly as through Docker sidecars.
+func NewPodInjector(pods PodSidecarManager) *Injector {
+	return &Injector{
+		tcInjector:       l3l4.NewTCWrapper(pods),
+		firewallInjector: firewall.New(pods),
+		dnsInjector:      dns.New(pods),
+		podsOnly:         true,
+		externalFaults:   make(map[string][]externalFault),
+		recoveries:       make(map[string][]Recovery),
+		applied:          make(map[string][]AppliedMethod),
+	}
+}
+
+// checkPodFault fails for a fault type a pod injector cannot handle.
+func checkPodFault(faultType string) error {
+	if info, ok := scenario.LookupFaultType(faultType); !ok || !info.Pods {
+		return fmt.Errorf("fault type %s is not supported on Kubernetes pods", faultType)
+	}
+	return nil
+}
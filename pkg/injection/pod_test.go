@@ -0,0 +1,68 @@
+package injection
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// fakePodSidecars answers every command with empty output and records it.
+type fakePodSidecars struct {
+	execs []string
+}
+
+func (f *fakePodSidecars) CreateSidecar(ctx context.Context, targetID string) (string, error) {
+	return "chaos-debug-1", nil
+}
+
+func (f *fakePodSidecars) ExecInSidecar(ctx context.Context, targetID string, cmd []string) (string, error) {
+	f.execs = append(f.execs, strings.Join(cmd, " "))
+	return "", nil
+}
+
+func (f *fakePodSidecars) GetSidecarID(targetID string) (string, bool) {
+	return "chaos-debug-1", true
+}
+
+func TestPodInjector_FaultTypes(t *testing.T) {
+	targets := []Target{{Name: "bor-0", ContainerID: "4b0d7a52-91e3-4c1f-8f2e-6d3c0a9b1e77"}}
+
+	tests := []struct {
+		name    string
+		fault   scenario.Fault
+		wantErr string
+	}{
+		{"partition", scenario.Fault{Type: "network_partition", Params: map[string]interface{}{"peer_ips": "10.0.0.7"}}, ""},
+		{"restart", scenario.Fault{Type: "container_restart", Params: map[string]interface{}{}}, "not supported on Kubernetes pods"},
+		{"alias of unsupported type", scenario.Fault{Type: "cpu", Params: map[string]interface{}{}}, "not supported on Kubernetes pods"},
+		{"at_boot", scenario.Fault{Type: "network", Params: map[string]interface{}{"latency": 100, "at_boot": true}}, "at_boot is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods := &fakePodSidecars{}
+			err := NewPodInjector(pods).InjectFault(context.Background(), &tt.fault, targets)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("InjectFault() error = %v", err)
+				}
+				if len(pods.execs) == 0 {
+					t.Error("InjectFault() ran nothing in the debug container")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("InjectFault() error = %v, want %q", err, tt.wantErr)
+			}
+			if len(pods.execs) > 0 {
+				t.Errorf("rejected fault ran %q", pods.execs)
+			}
+		})
+	}
+
+	if err := NewPodInjector(&fakePodSidecars{}).RemoveFault(context.Background(), "disk_io", "4b0d7a52-91e3-4c1f-8f2e-6d3c0a9b1e77"); err == nil {
+		t.Error("RemoveFault() of disk_io on a pod succeeded")
+	}
+}
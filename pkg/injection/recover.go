@@ -0,0 +1,61 @@
+package injection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jihwankim/chaos-utils/pkg/injection/disk"
+	"github.com/jihwankim/chaos-utils/pkg/injection/external"
+	"github.com/jihwankim/chaos-utils/pkg/injection/stress"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// ErrNotRecoverable marks a fault whose removal needs state that only the
+// injecting process held. Recovery leaves such a fault recorded so it can
+// be cleaned up by hand.
+var ErrNotRecoverable = errors.New("not recoverable")
+
+// RecoverFault removes a fault injected by another Injector, such as one
+// that died with a crashed runner. RemoveFault relies on what this
+// injector tracked at injection; RecoverFault instead rebuilds what the
+// removal needs from the fault's persisted params, and returns an error
+// wrapping ErrNotRecoverable when that is not enough.
+func (i *Injector) RecoverFault(ctx context.Context, faultType, containerID, targetName string, params map[string]interface{}) error {
+	switch canonical := scenario.CanonicalFaultType(faultType); canonical {
+	case "cpu_stress", "memory_stress":
+		// Same defaults as injectCPUStress and injectMemoryStress.
+		method := "limit"
+		if canonical == "cpu_stress" {
+			method = "stress"
+		}
+		if m, ok := params["method"].(string); ok {
+			method = m
+		}
+		if method != "stress" {
+			return fmt.Errorf("%w: the original CPU/memory limits were held only by the runner that injected them; reset them with docker update", ErrNotRecoverable)
+		}
+		if err := i.stressInjector.RecoverStress(ctx, containerID); err != nil {
+			if errors.Is(err, stress.ErrStressNGUntracked) {
+				return fmt.Errorf("%w: %w; it stops at its timeout, or restart the container", ErrNotRecoverable, err)
+			}
+			return err
+		}
+		return nil
+	case "disk_io":
+		targetPath, _ := params["target_path"].(string)
+		return i.diskInjector.RemoveFault(ctx, containerID, disk.IODelayParams{TargetPath: targetPath, Operation: "all"})
+	case "external":
+		cfg, err := ParseExternalConfig(params)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrNotRecoverable, err)
+		}
+		provider, err := external.New(cfg)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrNotRecoverable, err)
+		}
+		return provider.Remove(ctx, external.Target{Name: targetName, ContainerID: containerID})
+	default:
+		return i.RemoveFault(ctx, faultType, containerID)
+	}
+}
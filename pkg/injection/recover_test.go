@@ -0,0 +1,107 @@
+package injection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/jihwankim/chaos-utils/pkg/injection/disk"
+	"github.com/jihwankim/chaos-utils/pkg/injection/external"
+	"github.com/jihwankim/chaos-utils/pkg/injection/stress"
+)
+
+// fakeTarget answers the execs the stress and disk removers run in a
+// target and records them.
+type fakeTarget struct {
+	stressNG string // stress-ng process count the target reports
+	execs    []string
+}
+
+func (f *fakeTarget) ExecCommand(ctx context.Context, containerID string, cmd []string) (string, error) {
+	s := strings.Join(cmd, " ")
+	f.execs = append(f.execs, s)
+	switch {
+	case strings.Contains(s, "stress-ng*"):
+		return f.stressNG + "\n", nil
+	case strings.Contains(s, "COUNT=0"):
+		return "0\n", nil
+	}
+	return "done\n", nil
+}
+
+func (f *fakeTarget) ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{}, nil
+}
+
+func (f *fakeTarget) ContainerUpdate(ctx context.Context, containerID string, updateConfig container.UpdateConfig) (container.ContainerUpdateOKBody, error) {
+	return container.ContainerUpdateOKBody{}, nil
+}
+
+// freshInjector is an Injector that has injected nothing, as in recover.
+func freshInjector(target *fakeTarget) *Injector {
+	return &Injector{
+		stressInjector: stress.New(target),
+		diskInjector:   disk.New(target),
+		externalFaults: make(map[string][]externalFault),
+	}
+}
+
+func TestRecoverFault_FreshInjector(t *testing.T) {
+	const containerID = "abcdef1234567890"
+
+	var removed []external.Request
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req external.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		removed = append(removed, req)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer provider.Close()
+
+	tests := []struct {
+		name               string
+		faultType          string
+		params             map[string]interface{}
+		stressNG           string
+		wantNotRecoverable bool
+		wantExec           string // an exec that must have run
+	}{
+		{"memory limit", "memory_stress", map[string]interface{}{"memory_mb": 256.0}, "0", true, ""},
+		{"cpu limit", "cpu_stress", map[string]interface{}{"method": "limit"}, "0", true, ""},
+		{"cpu stress with untracked stress-ng", "cpu_stress", nil, "2", true, ".chaos-cpu-stress.pids"},
+		{"cpu stress already gone", "cpu_stress", nil, "0", false, ".chaos-cpu-stress.pids"},
+		{"disk_io uses persisted target_path", "disk_io", map[string]interface{}{"target_path": "/var/lib/bor/"}, "0", false, "/var/lib/bor/.chaos_io_stress.pids"},
+		{"external rebuilt from params", "external", map[string]interface{}{"provider": "http", "url": provider.URL, "rule": "drop"}, "0", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &fakeTarget{stressNG: tt.stressNG}
+			err := freshInjector(target).RecoverFault(context.Background(), tt.faultType, containerID, "l2-el-1", tt.params)
+			if got := errors.Is(err, ErrNotRecoverable); got != tt.wantNotRecoverable {
+				t.Fatalf("RecoverFault() error = %v, want not-recoverable %v", err, tt.wantNotRecoverable)
+			}
+			if !tt.wantNotRecoverable && err != nil {
+				t.Fatalf("RecoverFault() error = %v", err)
+			}
+			if tt.wantExec != "" && !strings.Contains(strings.Join(target.execs, "\n"), tt.wantExec) {
+				t.Errorf("no exec mentioning %q; got:\n%s", tt.wantExec, strings.Join(target.execs, "\n"))
+			}
+		})
+	}
+
+	if len(removed) != 1 {
+		t.Fatalf("external provider got %d requests, want 1", len(removed))
+	}
+	got := removed[0]
+	if got.Action != external.ActionRemove || got.Target.Name != "l2-el-1" || got.Target.ContainerID != containerID || got.Params["rule"] != "drop" {
+		t.Errorf("external request = %+v, want remove of l2-el-1 with its params", got)
+	}
+}
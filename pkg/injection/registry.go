@@ -0,0 +1,77 @@
+package injection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
+	"github.com/jihwankim/chaos-utils/pkg/injection/sidecar"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// FaultHandler injects and removes one custom fault type. Remove is called
+// once per container the fault was injected into, during teardown and on
+// every abort path, so it must be idempotent.
+type FaultHandler interface {
+	Inject(ctx context.Context, fault *scenario.Fault, targets []Target) error
+	Remove(ctx context.Context, containerID string) error
+}
+
+// FaultFactory builds a FaultHandler for one Injector. It receives the same
+// sidecar manager and Docker client the built-in injectors use.
+type FaultFactory func(sidecarMgr *sidecar.Manager, dockerClient *docker.Client) FaultHandler
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]FaultFactory)
+)
+
+// RegisterFaultType makes a custom fault type available to every Injector
+// created afterwards. Call it from an init function in the package that
+// implements the fault, the way database/sql drivers register themselves:
+//
+//	func init() {
+//		injection.RegisterFaultType("heimdall_admin", newAdminFault)
+//	}
+//
+// The type is also added to the scenario fault-type registry (with open
+// params) so the validator accepts it. It panics if name is empty, collides
+// with a built-in type or alias, is registered twice, or factory is nil —
+// all programming errors that should fail at startup.
+func RegisterFaultType(name string, factory FaultFactory) {
+	if factory == nil {
+		panic(fmt.Sprintf("injection: RegisterFaultType(%q) called with nil factory", name))
+	}
+	if err := scenario.RegisterFaultType(scenario.FaultTypeInfo{Name: name, OpenParams: true, Reversible: true}); err != nil {
+		panic(fmt.Sprintf("injection: %v", err))
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// RegisteredFaultTypes returns the names of all custom fault types, sorted.
+func RegisteredFaultTypes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newCustomHandlers instantiates every registered factory for one Injector.
+func newCustomHandlers(sidecarMgr *sidecar.Manager, dockerClient *docker.Client) map[string]FaultHandler {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	handlers := make(map[string]FaultHandler, len(registry))
+	for name, factory := range registry {
+		handlers[name] = factory(sidecarMgr, dockerClient)
+	}
+	return handlers
+}
@@ -0,0 +1,63 @@
+package injection
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// injectScheduled rolls fault out across targets in batches of
+// schedule.BatchSize, pausing Stagger plus a random 0..Jitter between
+// batches. Each batch is injected like an ordinary fault with the
+// scheduling params stripped, so e.g. a container_restart batch restarts
+// its members simultaneously.
+func (i *Injector) injectScheduled(ctx context.Context, fault *scenario.Fault, targets []Target, schedule scenario.Schedule) error {
+	batchFault := *fault
+	batchFault.Params = make(map[string]interface{}, len(fault.Params))
+	for k, v := range fault.Params {
+		batchFault.Params[k] = v
+	}
+	for _, k := range scenario.SchedulingParams {
+		delete(batchFault.Params, k)
+	}
+
+	batches := splitBatches(targets, schedule.BatchSize(len(targets)))
+	for n, batch := range batches {
+		if n > 0 {
+			pause := schedule.Stagger
+			if schedule.Jitter > 0 {
+				pause += time.Duration(rand.Int63n(int64(schedule.Jitter) + 1))
+			}
+			if pause > 0 {
+				fmt.Printf("  ⏳ %s: waiting %s before batch %d/%d...\n", fault.Phase, pause.Round(time.Millisecond), n+1, len(batches))
+				select {
+				case <-time.After(pause):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
+		fmt.Printf("  → %s: batch %d/%d (%d target(s))\n", fault.Phase, n+1, len(batches), len(batch))
+		if err := i.injectFault(ctx, &batchFault, batch); err != nil {
+			return fmt.Errorf("batch %d/%d: %w", n+1, len(batches), err)
+		}
+	}
+	return nil
+}
+
+// splitBatches cuts targets into consecutive batches of at most size.
+func splitBatches(targets []Target, size int) [][]Target {
+	var batches [][]Target
+	for start := 0; start < len(targets); start += size {
+		end := start + size
+		if end > len(targets) {
+			end = len(targets)
+		}
+		batches = append(batches, targets[start:end])
+	}
+	return batches
+}
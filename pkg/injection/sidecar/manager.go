@@ -3,8 +3,9 @@ package sidecar
 import (
 	"context"
 	"fmt"
-	"strings"
+	"io"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -30,6 +31,11 @@ type Manager struct {
 	sidecarImage  string
 	mu              sync.RWMutex
 	createdSidecars map[string]string // target container ID -> sidecar container ID
+	// cgroupAccess holds the sidecar IDs created by CreateCgroupSidecar.
+	cgroupAccess map[string]bool
+	// tracker, if set, is told about every sidecar created or destroyed
+	// (sidecarID is "" on destroy), e.g. to persist them for crash recovery.
+	tracker func(targetID, sidecarID string)
 }
 
 // New creates a new sidecar manager
@@ -38,11 +44,37 @@ func New(dockerClient *docker.Client, sidecarImage string) *Manager {
 		dockerClient:    dockerClient,
 		sidecarImage:    sidecarImage,
 		createdSidecars: make(map[string]string),
+		cgroupAccess:    make(map[string]bool),
 	}
 }
 
 // CreateSidecar creates and attaches a sidecar to a target container's network namespace
 func (m *Manager) CreateSidecar(ctx context.Context, targetContainerID string) (string, error) {
+	return m.createSidecar(ctx, targetContainerID, false)
+}
+
+// CreateCgroupSidecar is CreateSidecar for a sidecar that also shares the
+// target's PID namespace and mounts the host cgroup tree read-write, so
+// stress-ng started from it can join the target's cgroup. Only stress
+// faults need that; every other sidecar stays out of the host cgroups.
+//
+// An existing sidecar for the target without that access is replaced, so
+// call it before any fault has been injected through that sidecar.
+func (m *Manager) CreateCgroupSidecar(ctx context.Context, targetContainerID string) (string, error) {
+	m.mu.RLock()
+	sidecarID, exists := m.createdSidecars[targetContainerID]
+	privileged := m.cgroupAccess[sidecarID]
+	m.mu.RUnlock()
+	if exists && !privileged {
+		fmt.Printf("Replacing sidecar %s for target %s to grant cgroup access\n", sidecarID[:12], targetContainerID[:12])
+		if err := m.DestroySidecar(ctx, targetContainerID); err != nil {
+			return "", err
+		}
+	}
+	return m.createSidecar(ctx, targetContainerID, true)
+}
+
+func (m *Manager) createSidecar(ctx context.Context, targetContainerID string, cgroupAccess bool) (string, error) {
 	// Reuse existing sidecar if one is already running for this target.
 	// RLock for the cheap lookup; we re-check under Lock later to catch the
 	// race where two goroutines both see "not exists" and both proceed to
@@ -70,14 +102,7 @@ func (m *Manager) CreateSidecar(ctx context.Context, targetContainerID string) (
 		Tty: true,
 	}
 
-	hostConfig := &container.HostConfig{
-		// Share network namespace with target
-		NetworkMode: container.NetworkMode(fmt.Sprintf("container:%s", targetContainerID)),
-		// Grant network admin capabilities
-		CapAdd: []string{"NET_ADMIN", "NET_RAW"},
-		// Auto-remove when stopped
-		AutoRemove: true,
-	}
+	hostConfig := sidecarHostConfig(targetContainerID, cgroupAccess)
 
 	networkingConfig := &network.NetworkingConfig{}
 
@@ -108,13 +133,41 @@ func (m *Manager) CreateSidecar(ctx context.Context, targetContainerID string) (
 		return existing, nil
 	}
 	m.createdSidecars[targetContainerID] = sidecarID
+	if cgroupAccess {
+		if m.cgroupAccess == nil {
+			m.cgroupAccess = make(map[string]bool)
+		}
+		m.cgroupAccess[sidecarID] = true
+	}
 	m.mu.Unlock()
+	m.track(targetContainerID, sidecarID)
 
 	fmt.Printf("Created sidecar %s for target %s\n", sidecarID[:12], targetContainerID[:12])
 
 	return sidecarID, nil
 }
 
+// sidecarHostConfig joins the sidecar to the target's network namespace
+// and, with cgroupAccess, to its PID namespace and the host cgroup tree.
+func sidecarHostConfig(targetContainerID string, cgroupAccess bool) *container.HostConfig {
+	hostConfig := &container.HostConfig{
+		// Share network namespace with target
+		NetworkMode: container.NetworkMode(fmt.Sprintf("container:%s", targetContainerID)),
+		// Grant network admin capabilities
+		CapAdd: []string{"NET_ADMIN", "NET_RAW"},
+		// Auto-remove when stopped
+		AutoRemove: true,
+	}
+	if cgroupAccess {
+		// Share the target's PID namespace and see the host cgroup tree so
+		// stress-ng started here can join the target's cgroup
+		hostConfig.PidMode = container.PidMode(fmt.Sprintf("container:%s", targetContainerID))
+		hostConfig.CgroupnsMode = container.CgroupnsModeHost
+		hostConfig.Binds = []string{"/sys/fs/cgroup:/host/sys/fs/cgroup"}
+	}
+	return hostConfig
+}
+
 // DestroySidecar removes a sidecar container
 func (m *Manager) DestroySidecar(ctx context.Context, targetContainerID string) error {
 	m.mu.RLock()
@@ -152,15 +205,45 @@ func (m *Manager) DestroySidecar(ctx context.Context, targetContainerID string)
 	// as long as both callers serialise through mu.
 	m.mu.Lock()
 	delete(m.createdSidecars, targetContainerID)
+	delete(m.cgroupAccess, sidecarID)
 	m.mu.Unlock()
+	m.track(targetContainerID, "")
 
 	fmt.Printf("Destroyed sidecar for target %s\n", targetContainerID[:12])
 
 	return nil
 }
 
+// SetTracker installs fn to observe sidecar creation and destruction. Set
+// it before the manager is shared between goroutines.
+func (m *Manager) SetTracker(fn func(targetID, sidecarID string)) {
+	m.tracker = fn
+}
+
+func (m *Manager) track(targetID, sidecarID string) {
+	if m.tracker != nil {
+		m.tracker(targetID, sidecarID)
+	}
+}
+
+// Adopt takes over an existing sidecar container for targetContainerID,
+// e.g. one left behind by a crashed run, so faults can be removed through
+// it and DestroySidecar can clean it up.
+func (m *Manager) Adopt(targetContainerID, sidecarID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.createdSidecars[targetContainerID] = sidecarID
+}
+
 // ExecInSidecar executes a command in a sidecar container
 func (m *Manager) ExecInSidecar(ctx context.Context, targetContainerID string, cmd []string) (string, error) {
+	return m.ExecInSidecarStdin(ctx, targetContainerID, cmd, nil)
+}
+
+// ExecInSidecarStdin executes a command in a sidecar container with stdin
+// streamed to it. Use it to hand the command secrets, which would
+// otherwise show in the exec trace.
+func (m *Manager) ExecInSidecarStdin(ctx context.Context, targetContainerID string, cmd []string, stdin io.Reader) (string, error) {
 	m.mu.RLock()
 	sidecarID, exists := m.createdSidecars[targetContainerID]
 	m.mu.RUnlock()
@@ -168,9 +251,7 @@ func (m *Manager) ExecInSidecar(ctx context.Context, targetContainerID string, c
 		return "", fmt.Errorf("no sidecar found for target %s", targetContainerID)
 	}
 
-	fmt.Printf("Executing in sidecar %s: %s\n", sidecarID[:12], strings.Join(cmd, " "))
-
-	output, err := m.dockerClient.ExecCommand(ctx, sidecarID, cmd)
+	output, err := m.dockerClient.ExecCommandStdin(ctx, sidecarID, cmd, stdin)
 	if err != nil {
 		return output, fmt.Errorf("failed to execute command in sidecar: %w", err)
 	}
@@ -201,6 +282,42 @@ func (m *Manager) ListSidecars() map[string]string {
 	return result
 }
 
+// PruneStale destroys tracked sidecars that can no longer reach their
+// target: the sidecar is not running, or the target has been (re)started
+// since the sidecar was, so the namespace it joined is gone. Used between
+// scenarios when sidecars are kept for reuse. Returns how many were pruned.
+func (m *Manager) PruneStale(ctx context.Context) int {
+	pruned := 0
+	for targetID, sidecarID := range m.ListSidecars() {
+		if m.sidecarUsable(ctx, targetID, sidecarID) {
+			continue
+		}
+		if err := m.DestroySidecar(ctx, targetID); err != nil {
+			fmt.Printf("Failed to prune stale sidecar %s: %v\n", sidecarID[:12], err)
+			continue
+		}
+		pruned++
+	}
+	return pruned
+}
+
+func (m *Manager) sidecarUsable(ctx context.Context, targetID, sidecarID string) bool {
+	sc, err := m.dockerClient.ContainerInspect(ctx, sidecarID)
+	if err != nil || sc.State == nil || !sc.State.Running {
+		return false
+	}
+	target, err := m.dockerClient.ContainerInspect(ctx, targetID)
+	if err != nil || target.State == nil || !target.State.Running {
+		return false
+	}
+	targetStart, err1 := time.Parse(time.RFC3339Nano, target.State.StartedAt)
+	sidecarStart, err2 := time.Parse(time.RFC3339Nano, sc.State.StartedAt)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	return !targetStart.After(sidecarStart)
+}
+
 // destroyOrphanSidecar is used to clean up a just-created sidecar that
 // lost a CreateSidecar race. It runs in a fresh background context so the
 // caller's ctx cancellation does not prevent the cleanup, and it
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+
+	"github.com/docker/docker/api/types/container"
 )
 
 func TestCreateSidecar_Idempotent(t *testing.T) {
@@ -29,6 +31,51 @@ func TestCreateSidecar_Idempotent(t *testing.T) {
 	}
 }
 
+func TestCreateCgroupSidecar_ReusesCgroupSidecar(t *testing.T) {
+	targetID := "target-container-id-123456"
+	sidecarID := "sidecar-container-id-789012"
+	m := &Manager{
+		createdSidecars: map[string]string{targetID: sidecarID},
+		cgroupAccess:    map[string]bool{sidecarID: true},
+	}
+
+	got, err := m.CreateCgroupSidecar(nil, targetID)
+	if err != nil {
+		t.Fatalf("CreateCgroupSidecar() error = %v", err)
+	}
+	if got != sidecarID {
+		t.Errorf("CreateCgroupSidecar() = %s, want reuse of %s", got, sidecarID)
+	}
+	// A plain request is happy with the cgroup-enabled sidecar too.
+	if got, _ := m.CreateSidecar(nil, targetID); got != sidecarID {
+		t.Errorf("CreateSidecar() = %s, want reuse of %s", got, sidecarID)
+	}
+}
+
+func TestSidecarHostConfig(t *testing.T) {
+	target := "target-container-id-123456"
+
+	plain := sidecarHostConfig(target, false)
+	if plain.NetworkMode != container.NetworkMode("container:"+target) {
+		t.Errorf("NetworkMode = %q", plain.NetworkMode)
+	}
+	if plain.PidMode != "" || plain.CgroupnsMode != "" || len(plain.Binds) != 0 {
+		t.Errorf("plain sidecar got PID/cgroup access: pid=%q cgroupns=%q binds=%v",
+			plain.PidMode, plain.CgroupnsMode, plain.Binds)
+	}
+
+	cg := sidecarHostConfig(target, true)
+	if cg.PidMode != container.PidMode("container:"+target) {
+		t.Errorf("PidMode = %q, want the target's", cg.PidMode)
+	}
+	if cg.CgroupnsMode != container.CgroupnsModeHost {
+		t.Errorf("CgroupnsMode = %q, want host", cg.CgroupnsMode)
+	}
+	if len(cg.Binds) != 1 || cg.Binds[0] != "/sys/fs/cgroup:/host/sys/fs/cgroup" {
+		t.Errorf("Binds = %v", cg.Binds)
+	}
+}
+
 func TestDestroySidecar_Idempotent(t *testing.T) {
 	// Test that destroying a non-existent sidecar returns nil
 	m := &Manager{
@@ -0,0 +1,174 @@
+package sidecar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/discovery/k8s"
+)
+
+// PodClient is the part of the Kubernetes client PodManager uses.
+type PodClient interface {
+	AddDebugContainer(ctx context.Context, pod k8s.Pod, name, image string, cmd []string) error
+	Exec(ctx context.Context, pod k8s.Pod, container string, cmd []string, stdin io.Reader) (string, error)
+}
+
+// podStopFile ends a debug container: its command waits for the file to
+// appear. Ephemeral containers cannot be removed from a pod, so this is
+// how one is "destroyed".
+const podStopFile = "/tmp/.chaos-debug-stop"
+
+// podDebugCommand keeps a debug container running until podStopFile is
+// created.
+var podDebugCommand = []string{"sh", "-c", "while [ ! -e " + podStopFile + " ]; do sleep 1; done"}
+
+// PodManager is the sidecar manager of Kubernetes pod targets. A pod's
+// sidecar is an ephemeral debug container running the sidecar image,
+// which shares the pod's network namespace the way a Docker sidecar
+// shares its target's. Targets are identified by pod UID and must be
+// added with AddPod before their sidecar is created.
+type PodManager struct {
+	client PodClient
+	image  string
+
+	mu   sync.RWMutex
+	pods map[string]k8s.Pod // pod UID -> pod
+	// debug maps pod UID -> name of its running debug container.
+	debug map[string]string
+}
+
+// NewPodManager creates a pod sidecar manager whose debug containers run
+// image.
+func NewPodManager(client PodClient, image string) *PodManager {
+	return &PodManager{
+		client: client,
+		image:  image,
+		pods:   make(map[string]k8s.Pod),
+		debug:  make(map[string]string),
+	}
+}
+
+// AddPod registers pod as a target, keyed by its UID.
+func (m *PodManager) AddPod(pod k8s.Pod) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pods[pod.UID] = pod
+}
+
+// pod returns the registered pod with UID targetID.
+func (m *PodManager) pod(targetID string) (k8s.Pod, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	pod, ok := m.pods[targetID]
+	if !ok {
+		return k8s.Pod{}, fmt.Errorf("unknown pod target %s", targetID)
+	}
+	return pod, nil
+}
+
+// CreateSidecar adds a debug container to the target pod, or returns the
+// one it already has. The returned ID is the debug container's name.
+func (m *PodManager) CreateSidecar(ctx context.Context, targetID string) (string, error) {
+	if name, ok := m.GetSidecarID(targetID); ok {
+		return name, nil
+	}
+	pod, err := m.pod(targetID)
+	if err != nil {
+		return "", err
+	}
+
+	// Names of ephemeral containers cannot be reused within a pod, even
+	// once they have exited.
+	name := "chaos-debug-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	fmt.Printf("Adding debug container %s to pod %s/%s\n", name, pod.Namespace, pod.Name)
+	if err := m.client.AddDebugContainer(ctx, pod, name, m.image, podDebugCommand); err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	m.debug[targetID] = name
+	m.mu.Unlock()
+	return name, nil
+}
+
+// ExecInSidecar executes a command in the target pod's debug container.
+func (m *PodManager) ExecInSidecar(ctx context.Context, targetID string, cmd []string) (string, error) {
+	return m.ExecInSidecarStdin(ctx, targetID, cmd, nil)
+}
+
+// ExecInSidecarStdin is ExecInSidecar with stdin streamed to the command.
+func (m *PodManager) ExecInSidecarStdin(ctx context.Context, targetID string, cmd []string, stdin io.Reader) (string, error) {
+	name, ok := m.GetSidecarID(targetID)
+	if !ok {
+		return "", fmt.Errorf("no sidecar found for target %s", targetID)
+	}
+	pod, err := m.pod(targetID)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := m.client.Exec(ctx, pod, name, cmd, stdin)
+	if err != nil {
+		return output, fmt.Errorf("failed to execute command in sidecar: %w", err)
+	}
+	return output, nil
+}
+
+// GetSidecarID returns the name of the target pod's debug container.
+func (m *PodManager) GetSidecarID(targetID string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	name, ok := m.debug[targetID]
+	return name, ok
+}
+
+// DestroySidecar stops the target pod's debug container. Like
+// Manager.DestroySidecar it is a no-op when there is none.
+func (m *PodManager) DestroySidecar(ctx context.Context, targetID string) error {
+	name, ok := m.GetSidecarID(targetID)
+	if !ok {
+		return nil
+	}
+	pod, err := m.pod(targetID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.client.Exec(ctx, pod, name, []string{"touch", podStopFile}, nil); err != nil {
+		return fmt.Errorf("failed to stop debug container %s in pod %s: %w", name, pod.Name, err)
+	}
+	m.mu.Lock()
+	delete(m.debug, targetID)
+	m.mu.Unlock()
+	fmt.Printf("Stopped debug container %s in pod %s/%s\n", name, pod.Namespace, pod.Name)
+	return nil
+}
+
+// DestroyAll stops every debug container and forgets the pods. The error
+// joins the containers that could not be stopped.
+func (m *PodManager) DestroyAll(ctx context.Context) error {
+	m.mu.RLock()
+	targets := make([]string, 0, len(m.debug))
+	for targetID := range m.debug {
+		targets = append(targets, targetID)
+	}
+	m.mu.RUnlock()
+
+	var errs []error
+	for _, targetID := range targets {
+		if err := m.DestroySidecar(ctx, targetID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		m.mu.Lock()
+		m.pods = make(map[string]k8s.Pod)
+		m.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
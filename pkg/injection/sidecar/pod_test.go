@@ -0,0 +1,76 @@
+package sidecar
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jihwankim/chaos-utils/pkg/discovery/k8s"
+)
+
+// fakePodClient records the debug containers added and the commands run
+// in them.
+type fakePodClient struct {
+	added []string
+	execs []string // "<pod>/<container>: <cmd> <- <stdin>"
+}
+
+func (f *fakePodClient) AddDebugContainer(ctx context.Context, pod k8s.Pod, name, image string, cmd []string) error {
+	f.added = append(f.added, pod.Name+"/"+name)
+	return nil
+}
+
+func (f *fakePodClient) Exec(ctx context.Context, pod k8s.Pod, container string, cmd []string, stdin io.Reader) (string, error) {
+	in := ""
+	if stdin != nil {
+		b, _ := io.ReadAll(stdin)
+		in = string(b)
+	}
+	f.execs = append(f.execs, pod.Name+"/"+container+": "+strings.Join(cmd, " ")+" <- "+in)
+	return "", nil
+}
+
+func TestPodManager(t *testing.T) {
+	ctx := context.Background()
+	client := &fakePodClient{}
+	m := NewPodManager(client, "jhkimqd/chaos-utils:latest")
+	pod := k8s.Pod{Namespace: "pos", Name: "bor-0", UID: "uid-bor-0"}
+
+	if _, err := m.CreateSidecar(ctx, pod.UID); err == nil {
+		t.Fatal("CreateSidecar() for an unregistered pod succeeded")
+	}
+
+	m.AddPod(pod)
+	name, err := m.CreateSidecar(ctx, pod.UID)
+	if err != nil {
+		t.Fatalf("CreateSidecar() error = %v", err)
+	}
+	if !strings.HasPrefix(name, "chaos-debug-") {
+		t.Errorf("debug container name = %q", name)
+	}
+	// A second create reuses the running debug container.
+	if again, _ := m.CreateSidecar(ctx, pod.UID); again != name || len(client.added) != 1 {
+		t.Errorf("CreateSidecar() = %s after %d adds, want reuse of %s", again, len(client.added), name)
+	}
+
+	if _, err := m.ExecInSidecarStdin(ctx, pod.UID, []string{"cat"}, strings.NewReader("key")); err != nil {
+		t.Fatalf("ExecInSidecarStdin() error = %v", err)
+	}
+	if want := "bor-0/" + name + ": cat <- key"; client.execs[0] != want {
+		t.Errorf("exec = %q, want %q", client.execs[0], want)
+	}
+
+	if err := m.DestroyAll(ctx); err != nil {
+		t.Fatalf("DestroyAll() error = %v", err)
+	}
+	if want := "bor-0/" + name + ": touch " + podStopFile + " <- "; client.execs[1] != want {
+		t.Errorf("exec = %q, want the stop file created", client.execs[1])
+	}
+	if _, ok := m.GetSidecarID(pod.UID); ok {
+		t.Error("debug container still tracked after DestroyAll")
+	}
+	if _, err := m.ExecInSidecar(ctx, pod.UID, []string{"true"}); err == nil {
+		t.Error("ExecInSidecar() after DestroyAll succeeded")
+	}
+}
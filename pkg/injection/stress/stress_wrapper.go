@@ -2,9 +2,11 @@ package stress
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -26,6 +28,11 @@ type StressParams struct {
 
 	// Cores is the number of CPU cores to stress (0 = default 4)
 	Cores int
+
+	// Timeout stops stress-ng on its own after this long, as a backstop if
+	// teardown never runs (0 = stress-ng's default). Teardown still stops
+	// it normally.
+	Timeout time.Duration
 }
 
 // StressWrapper wraps resource constraint injection via Docker API
@@ -34,6 +41,11 @@ type StressWrapper struct {
 	// Store original container resources for restoration
 	mu                sync.Mutex
 	originalResources map[string]container.Resources
+
+	// sidecar runs stress-ng for the active methods (see UseSidecar);
+	// sidecarStress records targets with stress-ng running.
+	sidecar       SidecarManager
+	sidecarStress map[string]bool
 }
 
 // DockerClient interface for Docker operations
@@ -48,9 +60,31 @@ func New(dockerClient DockerClient) *StressWrapper {
 	return &StressWrapper{
 		dockerClient:      dockerClient,
 		originalResources: make(map[string]container.Resources),
+		sidecarStress:     make(map[string]bool),
 	}
 }
 
+// yesPIDFile, inside the target, lists the loops injectYesCPUStress
+// started; yesKillScript kills each of them together with its descendants
+// (timeout, yes, sleep), collected before anything is killed so orphans
+// are not missed. BusyBox-compatible — reads /proc, no pkill dependency.
+const yesPIDFile = "/tmp/.chaos-cpu-stress.pids"
+
+const yesKillScript = `
+	[ -f ` + yesPIDFile + ` ] || { echo done; exit 0; }
+	desc() {
+		for s in /proc/[0-9]*/stat; do
+			read -r p c st pp rest < "$s" 2>/dev/null || continue
+			[ "$pp" = "$1" ] && echo "$p" && desc "$p"
+		done
+	}
+	ALL=""
+	while read -r pid; do ALL="$ALL $pid $(desc "$pid")"; done < ` + yesPIDFile + `
+	[ -n "$ALL" ] && kill -9 $ALL 2>/dev/null
+	rm -f ` + yesPIDFile + `
+	echo done
+`
+
 // InjectCPUStress injects CPU stress on a target container
 func (sw *StressWrapper) InjectCPUStress(ctx context.Context, targetContainerID string, params StressParams) error {
 	// Choose method based on params
@@ -61,7 +95,9 @@ func (sw *StressWrapper) InjectCPUStress(ctx context.Context, targetContainerID
 	return sw.injectCPULimit(ctx, targetContainerID, params)
 }
 
-// injectActiveCPUStress actively stresses CPU by running busy loops
+// injectActiveCPUStress actively stresses CPU. It runs stress-ng from the
+// sidecar when available, which holds an accurate per-core load, and falls
+// back to shell busy loops inside the target otherwise.
 func (sw *StressWrapper) injectActiveCPUStress(ctx context.Context, targetContainerID string, params StressParams) error {
 	cores := params.Cores
 	if cores == 0 {
@@ -76,13 +112,32 @@ func (sw *StressWrapper) injectActiveCPUStress(ctx context.Context, targetContai
 	fmt.Printf("Injecting active CPU stress on target %s: %d%% load on %d core(s)\n",
 		targetContainerID[:12], cpuPercent, cores)
 
+	reason := sw.stressNGUnavailable(ctx, targetContainerID)
+	if reason == "" {
+		args := []string{"--cpu", fmt.Sprint(cores), "--cpu-load", fmt.Sprint(cpuPercent)}
+		if err := sw.startStressNG(ctx, targetContainerID, "cpu", params.Timeout, args); err != nil {
+			return fmt.Errorf("failed to inject active CPU stress: %w", err)
+		}
+		fmt.Printf("Active CPU stress injected on target %s via stress-ng\n", targetContainerID[:12])
+		return nil
+	}
+	log.Warn().Str("container", targetContainerID[:12]).Str("reason", reason).
+		Msg("stress-ng unavailable, falling back to yes loops in the target")
+
+	return sw.injectYesCPUStress(ctx, targetContainerID, cores, cpuPercent)
+}
+
+// injectYesCPUStress approximates CPU load with yes loops run inside the
+// target. Loads below 70% are duty-cycled with timeout/sleep, so they are
+// coarse.
+func (sw *StressWrapper) injectYesCPUStress(ctx context.Context, targetContainerID string, cores, cpuPercent int) error {
 	// Build CPU stress command using shell built-ins
 	// Use 'yes > /dev/null' for simple continuous CPU burn on each core
 	var stressCmd string
 
 	if cpuPercent >= 70 {
 		// High load: Run continuous yes command for each core
-		stressCmd = fmt.Sprintf("for i in $(seq 1 %d); do yes > /dev/null & done", cores)
+		stressCmd = fmt.Sprintf("for i in $(seq 1 %d); do yes > /dev/null & echo $! >> %s; done", cores, yesPIDFile)
 	} else {
 		// Moderate load: Run yes with periodic pauses
 		// Use timeout to run yes for X seconds, then sleep
@@ -96,8 +151,8 @@ func (sw *StressWrapper) injectActiveCPUStress(ctx context.Context, targetContai
 		}
 
 		stressCmd = fmt.Sprintf(
-			"for i in $(seq 1 %d); do while true; do timeout %d yes > /dev/null 2>/dev/null; sleep %d; done & done",
-			cores, burnSec, sleepSec,
+			"for i in $(seq 1 %d); do while true; do timeout %d yes > /dev/null 2>/dev/null; sleep %d; done & echo $! >> %s; done",
+			cores, burnSec, sleepSec, yesPIDFile,
 		)
 	}
 
@@ -208,13 +263,16 @@ func (sw *StressWrapper) injectCPULimit(ctx context.Context, targetContainerID s
 	return nil
 }
 
-// InjectMemoryStress injects memory pressure on a target container
-// Note: Memory stress uses cgroup limits (method is ignored)
-// Active memory allocation is unreliable without installing tools in target
-// Use conservative limits (e.g., 512MB-2GB) to create pressure without OOM kills
+// InjectMemoryStress injects memory pressure on a target container.
+// Method "limit" (default) lowers the container's cgroup memory limit; use
+// conservative limits (e.g., 512MB-2GB) to create pressure without OOM
+// kills. Method "stress" allocates MemoryMB with stress-ng from the sidecar,
+// charged to the target's cgroup, so the target's own limit and the OOM
+// killer see it.
 func (sw *StressWrapper) InjectMemoryStress(ctx context.Context, targetContainerID string, params StressParams) error {
-	// Always use limit method for memory
-	// Active memory stress requires tools we can't rely on in target containers
+	if params.Method == "stress" {
+		return sw.injectActiveMemoryStress(ctx, targetContainerID, params)
+	}
 	return sw.injectMemoryLimit(ctx, targetContainerID, params)
 }
 
@@ -272,24 +330,26 @@ func (sw *StressWrapper) injectMemoryLimit(ctx context.Context, targetContainerI
 func (sw *StressWrapper) RemoveFault(ctx context.Context, targetContainerID string) error {
 	fmt.Printf("Removing stress/limits from target %s\n", targetContainerID[:12])
 
-	// Kill active stress processes (yes, dd, timeout loops) using /proc scanning.
-	// BusyBox-compatible — no pkill dependency.
-	killCmd := []string{"sh", "-c", `
-		for p in /proc/[0-9]*/cmdline; do
-			PID=$(echo $p | cut -d/ -f3)
-			CMD=$(tr '\0' ' ' < $p 2>/dev/null)
-			case "$CMD" in
-				yes*|"dd "*|"timeout "*|"head "*|"tr "*) kill -9 $PID 2>/dev/null ;;
-			esac
-		done
-		rm -f /dev/shm/mem-stress-fill /tmp/mem-stress-fill 2>/dev/null
-		echo done
-	`}
+	// Kill the yes loops this wrapper started, and their children, by the
+	// PIDs recorded at injection. Matching by name would also kill the
+	// application's own yes/dd/timeout processes.
+	killCmd := []string{"sh", "-c", yesKillScript}
 	_, killErr := sw.dockerClient.ExecCommand(ctx, targetContainerID, killCmd)
 	if killErr != nil {
 		log.Warn().Err(killErr).Str("container", targetContainerID[:12]).Msg("failed to kill stress processes during removal")
 	}
 
+	// stress-ng started from the sidecar lives in the target's cgroup, so
+	// destroying the sidecar would not stop it.
+	sw.mu.Lock()
+	sidecarStress := sw.sidecarStress[targetContainerID]
+	sw.mu.Unlock()
+	if sidecarStress {
+		if err := sw.stopStressNG(ctx, targetContainerID); err != nil {
+			return err
+		}
+	}
+
 	// Restore original resource limits (for "limit" method)
 	sw.mu.Lock()
 	originalRes, exists := sw.originalResources[targetContainerID]
@@ -356,6 +416,44 @@ func (sw *StressWrapper) RemoveFault(ctx context.Context, targetContainerID stri
 }
 
 
+// stressNGCountScript, run in the target, counts stress-ng processes. The
+// stress-ng a sidecar starts shares the target's PID namespace, so the
+// target sees it even after the sidecar is gone.
+const stressNGCountScript = `N=0; for c in /proc/[0-9]*/comm; do ` +
+	`{ read -r n < "$c"; } 2>/dev/null || continue; ` +
+	`case "$n" in stress-ng*) N=$((N+1)) ;; esac; done; echo $N`
+
+// ErrStressNGUntracked is returned by RecoverStress when stress-ng is still
+// running in the target but the sidecar that recorded its PIDs is gone.
+var ErrStressNGUntracked = errors.New("stress-ng is still running in the target, but the sidecar that recorded its PIDs is gone")
+
+// RecoverStress removes method "stress" load started by another wrapper,
+// e.g. one that died with a crashed runner. It relies only on what the
+// target and its sidecar still hold: the yes loops in yesPIDFile, and
+// stress-ng in the sidecar's PID file if that sidecar survived. When the
+// PID file is gone but stress-ng still runs, it returns
+// ErrStressNGUntracked rather than killing by name.
+func (sw *StressWrapper) RecoverStress(ctx context.Context, targetContainerID string) error {
+	if _, err := sw.dockerClient.ExecCommand(ctx, targetContainerID, []string{"sh", "-c", yesKillScript}); err != nil {
+		log.Warn().Err(err).Str("container", targetContainerID[:12]).Msg("failed to kill stress processes during recovery")
+	}
+
+	if sw.sidecar != nil {
+		probe := []string{"sh", "-c", "[ -f " + stressNGPIDFile + " ] && echo tracked; true"}
+		if out, err := sw.sidecar.ExecInSidecar(ctx, targetContainerID, probe); err == nil && strings.TrimSpace(out) == "tracked" {
+			return sw.stopStressNG(ctx, targetContainerID)
+		}
+	}
+
+	out, err := sw.dockerClient.ExecCommand(ctx, targetContainerID, []string{"sh", "-c", stressNGCountScript})
+	if err != nil {
+		return fmt.Errorf("failed to look for stress-ng in the target: %w", err)
+	}
+	if n := strings.TrimSpace(out); n != "0" {
+		return fmt.Errorf("%w (%s processes)", ErrStressNGUntracked, n)
+	}
+	return nil
+}
 
 // ValidateStressParams validates stress parameters
 func ValidateStressParams(params StressParams) error {
@@ -205,3 +205,39 @@ func TestValidateStressParams(t *testing.T) {
 		})
 	}
 }
+
+func TestYesStress_KillsOnlyRecordedPIDs(t *testing.T) {
+	var cmds []string
+	mock := &mockDockerClientStress{
+		execFunc: func(ctx context.Context, containerID string, cmd []string) (string, error) {
+			cmdStr := strings.Join(cmd, " ")
+			cmds = append(cmds, cmdStr)
+			if strings.Contains(cmdStr, "COUNT") {
+				return "2", nil
+			}
+			return "done", nil
+		},
+	}
+	sw := &StressWrapper{
+		dockerClient:      mock,
+		originalResources: make(map[string]container.Resources),
+	}
+
+	for _, pct := range []int{80, 40} {
+		cmds = nil
+		if err := sw.InjectCPUStress(context.Background(), "abcdef123456789", StressParams{Method: "stress", CPUPercent: pct, Cores: 2}); err != nil {
+			t.Fatalf("cpu %d%%: unexpected error: %v", pct, err)
+		}
+		if !strings.Contains(cmds[0], "echo $! >> "+yesPIDFile) {
+			t.Errorf("cpu %d%%: stress command does not record PIDs: %q", pct, cmds[0])
+		}
+	}
+
+	cmds = nil
+	if err := sw.RemoveFault(context.Background(), "abcdef123456789"); err != nil {
+		t.Fatalf("RemoveFault: %v", err)
+	}
+	if !strings.Contains(cmds[0], yesPIDFile) || strings.Contains(cmds[0], "yes*") {
+		t.Errorf("kill command should target recorded PIDs only, got %q", cmds[0])
+	}
+}
@@ -0,0 +1,153 @@
+package stress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SidecarManager is the subset of sidecar.Manager the stress-ng methods
+// need. A sidecar from CreateCgroupSidecar shares the target's PID
+// namespace and mounts the host cgroup hierarchy, so a process it starts
+// can join the target's cgroup and be accounted (and OOM-killed) as part
+// of the target.
+type SidecarManager interface {
+	CreateCgroupSidecar(ctx context.Context, targetContainerID string) (string, error)
+	ExecInSidecar(ctx context.Context, targetContainerID string, cmd []string) (string, error)
+}
+
+// Paths inside the sidecar. The PID file lists every stress-ng parent the
+// wrapper started for that target; teardown kills exactly those.
+const (
+	stressNGRunner  = "/tmp/.chaos-stress-ng.sh"
+	stressNGPIDFile = "/tmp/.chaos-stress-ng.pids"
+	stressNGLog     = "/tmp/.chaos-stress-ng.log"
+	hostCgroupRoot  = "/host/sys/fs/cgroup"
+)
+
+// stressNGRunnerScript moves itself into the cgroup of the target's PID 1
+// for every controller named in $1 (cgroup v1) or the unified hierarchy
+// (cgroup v2), then execs the remaining arguments.
+const stressNGRunnerScript = `#!/bin/sh
+CTRLS=$1; shift
+ROOT=` + hostCgroupRoot + `
+if [ -f "$ROOT/cgroup.controllers" ]; then
+	CG=$(awk -F: '$1 == "0" { print $3; exit }' /proc/1/cgroup)
+	echo $$ > "$ROOT$CG/cgroup.procs" || { echo "cannot join target cgroup $CG" >&2; exit 97; }
+else
+	for c in $CTRLS; do
+		LINE=$(awk -F: -v c="$c" '{ n = split($2, a, ","); for (i = 1; i <= n; i++) if (a[i] == c) { print $2 ":" $3; exit } }' /proc/1/cgroup)
+		echo $$ > "$ROOT/${LINE%%:*}${LINE#*:}/cgroup.procs" || { echo "cannot join target $c cgroup" >&2; exit 97; }
+	done
+fi
+exec "$@"
+`
+
+// UseSidecar enables the stress-ng methods, which run stress-ng from the
+// target's chaos sidecar. Without it method "stress" for memory fails and
+// CPU stress falls back to shell busy loops inside the target.
+func (sw *StressWrapper) UseSidecar(s SidecarManager) {
+	sw.sidecar = s
+}
+
+// stressNGUnavailable reports why stress-ng cannot be run for the target,
+// or "" when it can.
+func (sw *StressWrapper) stressNGUnavailable(ctx context.Context, targetContainerID string) string {
+	if sw.sidecar == nil {
+		return "no sidecar manager"
+	}
+	if _, err := sw.sidecar.CreateCgroupSidecar(ctx, targetContainerID); err != nil {
+		return fmt.Sprintf("sidecar: %v", err)
+	}
+	if _, err := sw.sidecar.ExecInSidecar(ctx, targetContainerID, []string{"sh", "-c", "command -v stress-ng"}); err != nil {
+		return "sidecar image has no stress-ng"
+	}
+	return ""
+}
+
+// startStressNG launches stress-ng with args from the target's sidecar,
+// charged to the target's cgroup for controllers, and verifies it stayed
+// up. timeout bounds its runtime as a safety net in case teardown never
+// runs; 0 leaves stress-ng's own default.
+func (sw *StressWrapper) startStressNG(ctx context.Context, targetContainerID, controllers string, timeout time.Duration, args []string) error {
+	if _, err := sw.sidecar.CreateCgroupSidecar(ctx, targetContainerID); err != nil {
+		return fmt.Errorf("failed to create sidecar: %w", err)
+	}
+
+	install := []string{"sh", "-c", `printf '%s' "$1" > ` + stressNGRunner + ` && chmod +x ` + stressNGRunner, "sh", stressNGRunnerScript}
+	if out, err := sw.sidecar.ExecInSidecar(ctx, targetContainerID, install); err != nil {
+		return fmt.Errorf("failed to install stress-ng runner: %w (output: %s)", err, out)
+	}
+
+	cmd := append([]string{stressNGRunner, controllers, "stress-ng"}, args...)
+	if timeout > 0 {
+		cmd = append(cmd, "--timeout", fmt.Sprintf("%ds", int(timeout.Seconds())))
+	}
+	launch := fmt.Sprintf("setsid %s > %s 2>&1 < /dev/null & echo $! >> %s", strings.Join(cmd, " "), stressNGLog, stressNGPIDFile)
+	if out, err := sw.sidecar.ExecInSidecar(ctx, targetContainerID, []string{"sh", "-c", launch}); err != nil {
+		return fmt.Errorf("failed to start stress-ng: %w (output: %s)", err, out)
+	}
+
+	sw.mu.Lock()
+	if sw.sidecarStress == nil {
+		sw.sidecarStress = make(map[string]bool)
+	}
+	sw.sidecarStress[targetContainerID] = true
+	sw.mu.Unlock()
+
+	// stress-ng exits immediately on a bad option or when it cannot join
+	// the cgroup; give it a moment, then require every recorded PID alive.
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Second):
+	}
+	check := fmt.Sprintf("DEAD=0; while read -r pid; do kill -0 \"$pid\" 2>/dev/null || DEAD=1; done < %s; "+
+		"if [ $DEAD = 1 ]; then cat %s; exit 1; fi; echo ok", stressNGPIDFile, stressNGLog)
+	if out, err := sw.sidecar.ExecInSidecar(ctx, targetContainerID, []string{"sh", "-c", check}); err != nil {
+		return fmt.Errorf("stress-ng exited right after start: %s", strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// stopStressNG terminates the stress-ng processes recorded for the target:
+// SIGTERM first so stress-ng reaps its workers, then SIGKILL for anything
+// left, including orphaned workers.
+func (sw *StressWrapper) stopStressNG(ctx context.Context, targetContainerID string) error {
+	stop := fmt.Sprintf("[ -f %[1]s ] || exit 0; "+
+		"while read -r pid; do kill -TERM \"$pid\" 2>/dev/null; done < %[1]s; sleep 2; "+
+		"while read -r pid; do pkill -KILL -P \"$pid\" 2>/dev/null; kill -KILL \"$pid\" 2>/dev/null; done < %[1]s; "+
+		"rm -f %[1]s %[2]s", stressNGPIDFile, stressNGLog)
+	if out, err := sw.sidecar.ExecInSidecar(ctx, targetContainerID, []string{"sh", "-c", stop}); err != nil {
+		return fmt.Errorf("failed to stop stress-ng: %w (output: %s)", err, out)
+	}
+
+	sw.mu.Lock()
+	delete(sw.sidecarStress, targetContainerID)
+	sw.mu.Unlock()
+	return nil
+}
+
+// injectActiveMemoryStress allocates and keeps MemoryMB resident with
+// stress-ng --vm, charged to the target's memory cgroup.
+func (sw *StressWrapper) injectActiveMemoryStress(ctx context.Context, targetContainerID string, params StressParams) error {
+	if sw.sidecar == nil {
+		return fmt.Errorf("memory stress method %q requires the chaos sidecar", params.Method)
+	}
+
+	memoryMB := params.MemoryMB
+	if memoryMB == 0 {
+		memoryMB = 512
+	}
+
+	fmt.Printf("Injecting active memory stress on target %s: %dMB via stress-ng\n", targetContainerID[:12], memoryMB)
+
+	args := []string{"--vm", "1", "--vm-bytes", fmt.Sprintf("%dM", memoryMB), "--vm-keep"}
+	if err := sw.startStressNG(ctx, targetContainerID, "memory", params.Timeout, args); err != nil {
+		return fmt.Errorf("failed to inject active memory stress: %w", err)
+	}
+
+	fmt.Printf("Active memory stress injected on target %s\n", targetContainerID[:12])
+	return nil
+}
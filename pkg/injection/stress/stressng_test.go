@@ -0,0 +1,182 @@
+package stress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+type mockSidecar struct {
+	execFunc func(cmd string) (string, error)
+	cmds     []string
+}
+
+func (m *mockSidecar) CreateCgroupSidecar(ctx context.Context, targetContainerID string) (string, error) {
+	return "sidecar123456789", nil
+}
+
+func (m *mockSidecar) ExecInSidecar(ctx context.Context, targetContainerID string, cmd []string) (string, error) {
+	joined := strings.Join(cmd, " ")
+	m.cmds = append(m.cmds, joined)
+	if m.execFunc != nil {
+		return m.execFunc(joined)
+	}
+	return "ok", nil
+}
+
+func newTestWrapper(sc SidecarManager) *StressWrapper {
+	sw := &StressWrapper{
+		dockerClient: &mockDockerClientStress{
+			execFunc: func(ctx context.Context, containerID string, cmd []string) (string, error) {
+				return "done", nil
+			},
+		},
+		originalResources: make(map[string]container.Resources),
+	}
+	if sc != nil {
+		sw.UseSidecar(sc)
+	}
+	return sw
+}
+
+func TestInjectActiveMemoryStress_RequiresSidecar(t *testing.T) {
+	sw := newTestWrapper(nil)
+	err := sw.InjectMemoryStress(context.Background(), "abcdef123456789", StressParams{Method: "stress", MemoryMB: 256})
+	if err == nil || !strings.Contains(err.Error(), "sidecar") {
+		t.Fatalf("expected sidecar error, got %v", err)
+	}
+}
+
+func TestInjectActiveMemoryStress_StartsAndStops(t *testing.T) {
+	sc := &mockSidecar{}
+	sw := newTestWrapper(sc)
+	target := "abcdef123456789"
+
+	if err := sw.InjectMemoryStress(context.Background(), target, StressParams{Method: "stress", MemoryMB: 256}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var launched bool
+	for _, c := range sc.cmds {
+		if strings.Contains(c, "stress-ng --vm 1 --vm-bytes 256M --vm-keep") {
+			launched = true
+		}
+	}
+	if !launched {
+		t.Fatalf("stress-ng was not launched; commands: %v", sc.cmds)
+	}
+
+	if err := sw.RemoveFault(context.Background(), target); err != nil {
+		t.Fatalf("RemoveFault: %v", err)
+	}
+	if last := sc.cmds[len(sc.cmds)-1]; !strings.Contains(last, "kill -TERM") {
+		t.Errorf("expected stress-ng teardown, last command %q", last)
+	}
+	if sw.sidecarStress[target] {
+		t.Error("target still recorded as running stress-ng after RemoveFault")
+	}
+}
+
+func TestInjectActiveMemoryStress_FailsWhenStressNGExits(t *testing.T) {
+	sc := &mockSidecar{execFunc: func(cmd string) (string, error) {
+		if strings.Contains(cmd, "kill -0") {
+			return "cannot join target memory cgroup", fmt.Errorf("exit status 1")
+		}
+		return "ok", nil
+	}}
+	sw := newTestWrapper(sc)
+
+	err := sw.InjectMemoryStress(context.Background(), "abcdef123456789", StressParams{Method: "stress", MemoryMB: 256})
+	if err == nil || !strings.Contains(err.Error(), "cannot join target memory cgroup") {
+		t.Fatalf("expected stress-ng start failure, got %v", err)
+	}
+}
+
+func TestInjectActiveCPUStress_UsesStressNG(t *testing.T) {
+	sc := &mockSidecar{}
+	sw := newTestWrapper(sc)
+
+	err := sw.InjectCPUStress(context.Background(), "abcdef123456789", StressParams{Method: "stress", CPUPercent: 40, Cores: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var launched bool
+	for _, c := range sc.cmds {
+		if strings.Contains(c, "stress-ng --cpu 2 --cpu-load 40") {
+			launched = true
+		}
+	}
+	if !launched {
+		t.Fatalf("stress-ng was not launched; commands: %v", sc.cmds)
+	}
+}
+
+func TestInjectActiveCPUStress_FallsBackWithoutStressNG(t *testing.T) {
+	sc := &mockSidecar{execFunc: func(cmd string) (string, error) {
+		if strings.Contains(cmd, "command -v stress-ng") {
+			return "", fmt.Errorf("exit status 1")
+		}
+		return "ok", nil
+	}}
+	sw := newTestWrapper(sc)
+	var ranYes bool
+	sw.dockerClient = &mockDockerClientStress{
+		execFunc: func(ctx context.Context, containerID string, cmd []string) (string, error) {
+			cmdStr := strings.Join(cmd, " ")
+			if strings.Contains(cmdStr, "COUNT") {
+				return "2", nil
+			}
+			ranYes = ranYes || strings.Contains(cmdStr, "yes > /dev/null")
+			return "", nil
+		},
+	}
+
+	if err := sw.InjectCPUStress(context.Background(), "abcdef123456789", StressParams{Method: "stress", CPUPercent: 80, Cores: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ranYes {
+		t.Error("expected fallback to yes loops in the target")
+	}
+	if len(sc.cmds) != 1 {
+		t.Errorf("expected only the stress-ng probe in the sidecar, got %v", sc.cmds)
+	}
+}
+
+func TestRecoverStress_StopsTrackedStressNG(t *testing.T) {
+	sc := &mockSidecar{execFunc: func(cmd string) (string, error) {
+		if strings.Contains(cmd, "echo tracked") {
+			return "tracked\n", nil
+		}
+		return "", nil
+	}}
+	// A fresh wrapper: nothing recorded in sidecarStress.
+	sw := newTestWrapper(sc)
+
+	if err := sw.RecoverStress(context.Background(), "abcdef123456789"); err != nil {
+		t.Fatalf("RecoverStress() error = %v", err)
+	}
+	last := sc.cmds[len(sc.cmds)-1]
+	if !strings.Contains(last, "kill -TERM") || !strings.Contains(last, stressNGPIDFile) {
+		t.Errorf("last sidecar command = %q, want stress-ng stopped by its PID file", last)
+	}
+}
+
+func TestRecoverStress_UntrackedStressNG(t *testing.T) {
+	sw := newTestWrapper(&mockSidecar{execFunc: func(cmd string) (string, error) { return "", nil }})
+	sw.dockerClient = &mockDockerClientStress{
+		execFunc: func(ctx context.Context, containerID string, cmd []string) (string, error) {
+			if strings.Contains(strings.Join(cmd, " "), "stress-ng*") {
+				return "3\n", nil
+			}
+			return "done", nil
+		},
+	}
+
+	err := sw.RecoverStress(context.Background(), "abcdef123456789")
+	if !errors.Is(err, ErrStressNGUntracked) {
+		t.Fatalf("RecoverStress() error = %v, want ErrStressNGUntracked", err)
+	}
+}
@@ -0,0 +1,284 @@
+// Package txpool floods a Bor node's transaction pool with valid,
+// low-priority transactions signed by a funded key. The flood runs as
+// chaos-txflood inside the target's sidecar, which shares the target's
+// network namespace, so it reaches the node's JSON-RPC on localhost and
+// loads exactly that node's pool. Combined with other faults it tests
+// block building and pool eviction under pressure.
+package txpool
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog"
+)
+
+// transferGas is the gas limit of a plain value transfer.
+const transferGas = 21000
+
+// progressInterval is how often Flood logs its counters.
+const progressInterval = 10 * time.Second
+
+// productionChainIDs maps chain IDs Flood refuses to target unless
+// FloodConfig.AllowProduction is set.
+var productionChainIDs = map[uint64]string{
+	1:     "Ethereum Mainnet",
+	137:   "Polygon Mainnet",
+	80002: "Polygon Amoy",
+}
+
+// FloodConfig configures Flood.
+type FloodConfig struct {
+	// RPCURL is the target node's JSON-RPC endpoint.
+	RPCURL string
+	// Key signs every transaction. Its account pays for them, so it must
+	// hold enough to cover Count transfers at GasPrice.
+	Key *ecdsa.PrivateKey
+	// Rate is the number of transactions sent per second.
+	Rate float64
+	// Count stops the flood after that many transactions; zero floods
+	// until ctx is cancelled.
+	Count int
+	// GasPrice of every transaction. Nil uses the node's eth_gasPrice, the
+	// lowest price it suggests, so real traffic paying more outranks the
+	// flood.
+	GasPrice *big.Int
+	// AllowProduction disables the refusal to flood productionChainIDs.
+	AllowProduction bool
+}
+
+// FloodStats counts what Flood sent. Accepted transactions entered the
+// pool; rejected ones were refused (pool full, underpriced, …), which is
+// the pressure the flood is after and not an error.
+type FloodStats struct {
+	Sent      int
+	Accepted  int
+	Rejected  int
+	LastError string
+}
+
+// Flood sends zero-value self-transfers from cfg.Key to cfg.RPCURL at
+// cfg.Rate until cfg.Count have been sent or ctx is cancelled. It logs
+// "flood started" once the preflight checks (chain ID, gas price, nonce,
+// balance) pass. It returns an error only when the flood cannot start;
+// rejections while flooding are counted in the stats.
+func Flood(ctx context.Context, cfg FloodConfig, log zerolog.Logger) (FloodStats, error) {
+	var stats FloodStats
+	if cfg.Key == nil {
+		return stats, fmt.Errorf("no key")
+	}
+	if cfg.Rate <= 0 {
+		return stats, fmt.Errorf("rate must be positive")
+	}
+
+	rpc := &rpcClient{url: cfg.RPCURL, client: &http.Client{Timeout: 5 * time.Second}}
+	from := crypto.PubkeyToAddress(cfg.Key.PublicKey)
+
+	var chainID hexutil.Big
+	if err := rpc.call(ctx, &chainID, "eth_chainId"); err != nil {
+		return stats, fmt.Errorf("eth_chainId: %w", err)
+	}
+	if name, ok := productionChainIDs[chainID.ToInt().Uint64()]; ok && !cfg.AllowProduction {
+		return stats, fmt.Errorf("refusing to flood %s (chain ID %d)", name, chainID.ToInt())
+	}
+
+	gasPrice := cfg.GasPrice
+	if gasPrice == nil {
+		var suggested hexutil.Big
+		if err := rpc.call(ctx, &suggested, "eth_gasPrice"); err != nil {
+			return stats, fmt.Errorf("eth_gasPrice: %w", err)
+		}
+		gasPrice = suggested.ToInt()
+	}
+
+	nonce, err := rpc.pendingNonce(ctx, from)
+	if err != nil {
+		return stats, err
+	}
+
+	var balance hexutil.Big
+	if err := rpc.call(ctx, &balance, "eth_getBalance", from, "latest"); err != nil {
+		return stats, fmt.Errorf("eth_getBalance: %w", err)
+	}
+	perTx := new(big.Int).Mul(gasPrice, big.NewInt(transferGas))
+	if balance.ToInt().Cmp(perTx) < 0 {
+		return stats, fmt.Errorf("account %s is not funded: balance %s wei, one transfer costs %s wei", from, balance.ToInt(), perTx)
+	}
+	if cfg.Count > 0 {
+		if need := new(big.Int).Mul(perTx, big.NewInt(int64(cfg.Count))); balance.ToInt().Cmp(need) < 0 {
+			log.Warn().Str("balance", balance.ToInt().String()).Str("needed", need.String()).
+				Msg("balance does not cover every transfer; the pool will start rejecting them once it runs out")
+		}
+	}
+
+	signer := types.LatestSignerForChainID(chainID.ToInt())
+	log.Info().
+		Str("from", from.Hex()).
+		Str("chain_id", chainID.ToInt().String()).
+		Str("gas_price", gasPrice.String()).
+		Uint64("nonce", nonce).
+		Float64("rate", cfg.Rate).
+		Int("count", cfg.Count).
+		Msg("flood started")
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.Rate))
+	defer ticker.Stop()
+	progress := time.NewTicker(progressInterval)
+	defer progress.Stop()
+
+	for cfg.Count == 0 || stats.Sent < cfg.Count {
+		select {
+		case <-ctx.Done():
+			return stats, nil
+		case <-progress.C:
+			log.Info().Int("sent", stats.Sent).Int("accepted", stats.Accepted).Int("rejected", stats.Rejected).Msg("flooding")
+			continue
+		case <-ticker.C:
+		}
+
+		tx, err := types.SignNewTx(cfg.Key, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: gasPrice,
+			Gas:      transferGas,
+			To:       &from,
+			Value:    new(big.Int),
+		})
+		if err != nil {
+			return stats, fmt.Errorf("sign transaction: %w", err)
+		}
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return stats, fmt.Errorf("encode transaction: %w", err)
+		}
+
+		stats.Sent++
+		var hash common.Hash
+		err = rpc.call(ctx, &hash, "eth_sendRawTransaction", hexutil.Bytes(raw))
+		switch {
+		case err == nil, isAlreadyKnown(err):
+			stats.Accepted++
+			nonce++
+		case ctx.Err() != nil:
+			stats.Sent--
+			return stats, nil
+		default:
+			stats.Rejected++
+			stats.LastError = err.Error()
+			log.Debug().Err(err).Uint64("nonce", nonce).Msg("transaction rejected")
+			switch {
+			case strings.Contains(err.Error(), "nonce too low"):
+				// Mined past our nonce; pick up where the node says the
+				// account is.
+				if n, err := rpc.pendingNonce(ctx, from); err == nil {
+					nonce = n
+				}
+			case strings.Contains(err.Error(), "replacement transaction underpriced"):
+				// Another sender of the same key holds this nonce.
+				nonce++
+			}
+		}
+	}
+	return stats, nil
+}
+
+// ParseKey decodes a hex private key, with or without 0x.
+func ParseKey(hexKey string) (*ecdsa.PrivateKey, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return key, nil
+}
+
+// isAlreadyKnown reports a transaction the pool already holds, which
+// happens when a retry races its own earlier send.
+func isAlreadyKnown(err error) bool {
+	return strings.Contains(err.Error(), "already known")
+}
+
+// rpcClient is a minimal JSON-RPC client; the flood needs a handful of
+// eth_ methods and no subscriptions.
+type rpcClient struct {
+	url    string
+	client *http.Client
+	id     atomic.Int64
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int64         `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call invokes method and decodes its result into result.
+func (c *rpcClient) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: c.id.Add(1), Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(data, &rpcResp); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%s", rpcResp.Error.Message)
+	}
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return fmt.Errorf("decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// pendingNonce returns the next nonce of addr, counting transactions
+// already in the pool.
+func (c *rpcClient) pendingNonce(ctx context.Context, addr common.Address) (uint64, error) {
+	var nonce hexutil.Uint64
+	if err := c.call(ctx, &nonce, "eth_getTransactionCount", addr, "pending"); err != nil {
+		return 0, fmt.Errorf("eth_getTransactionCount: %w", err)
+	}
+	return uint64(nonce), nil
+}
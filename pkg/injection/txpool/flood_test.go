@@ -0,0 +1,143 @@
+package txpool
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog"
+)
+
+// fakeNode answers the JSON-RPC methods Flood calls and records the
+// transactions it accepts. The send numbered rejectAt (1-based) is
+// refused as if the pool were full.
+type fakeNode struct {
+	chainID  int64
+	balance  *big.Int
+	rejectAt int
+
+	mu       sync.Mutex
+	sends    int
+	accepted []*types.Transaction
+}
+
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result interface{}
+	var rpcErr string
+	switch req.Method {
+	case "eth_chainId":
+		result = hexutil.EncodeBig(big.NewInt(n.chainID))
+	case "eth_gasPrice":
+		result = hexutil.EncodeBig(big.NewInt(30_000_000_000))
+	case "eth_getTransactionCount":
+		result = hexutil.EncodeUint64(5)
+	case "eth_getBalance":
+		result = hexutil.EncodeBig(n.balance)
+	case "eth_sendRawTransaction":
+		var raw hexutil.Bytes
+		json.Unmarshal(req.Params[0], &raw)
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			rpcErr = err.Error()
+			break
+		}
+		n.mu.Lock()
+		n.sends++
+		if n.sends == n.rejectAt {
+			rpcErr = "txpool is full"
+		} else {
+			n.accepted = append(n.accepted, tx)
+			result = tx.Hash()
+		}
+		n.mu.Unlock()
+	default:
+		rpcErr = "method not found"
+	}
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if rpcErr != "" {
+		resp["error"] = map[string]interface{}{"code": -32000, "message": rpcErr}
+	} else {
+		resp["result"] = result
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func TestFlood(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	node := &fakeNode{chainID: 4927, balance: big.NewInt(1e18), rejectAt: 3}
+	srv := httptest.NewServer(node)
+	defer srv.Close()
+
+	stats, err := Flood(context.Background(), FloodConfig{RPCURL: srv.URL, Key: key, Rate: 1000, Count: 5}, zerolog.New(io.Discard))
+	if err != nil {
+		t.Fatalf("Flood() error = %v", err)
+	}
+	if stats.Sent != 5 || stats.Accepted != 4 || stats.Rejected != 1 || stats.LastError != "txpool is full" {
+		t.Errorf("Flood() stats = %+v, want 5 sent, 4 accepted, 1 rejected", stats)
+	}
+
+	signer := types.LatestSignerForChainID(big.NewInt(node.chainID))
+	// The rejected send does not use up its nonce.
+	for i, tx := range node.accepted {
+		if want := uint64(5 + i); tx.Nonce() != want {
+			t.Errorf("tx %d nonce = %d, want %d", i, tx.Nonce(), want)
+		}
+		if sender, err := types.Sender(signer, tx); err != nil || sender != from {
+			t.Errorf("tx %d sender = %s (%v), want %s", i, sender, err, from)
+		}
+		if *tx.To() != from || tx.Value().Sign() != 0 || tx.Gas() != transferGas {
+			t.Errorf("tx %d is not a zero-value self-transfer: to %s, value %s, gas %d", i, tx.To(), tx.Value(), tx.Gas())
+		}
+		if tx.GasPrice().Cmp(big.NewInt(30_000_000_000)) != 0 {
+			t.Errorf("tx %d gas price = %s, want the node's eth_gasPrice", i, tx.GasPrice())
+		}
+	}
+}
+
+func TestFloodRefuses(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tests := []struct {
+		name    string
+		node    *fakeNode
+		wantErr string
+	}{
+		{"unfunded account", &fakeNode{chainID: 4927, balance: big.NewInt(0)}, "not funded"},
+		{"production chain", &fakeNode{chainID: 137, balance: big.NewInt(1e18)}, "refusing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.node)
+			defer srv.Close()
+
+			_, err := Flood(context.Background(), FloodConfig{RPCURL: srv.URL, Key: key, Rate: 1000, Count: 1}, zerolog.New(io.Discard))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Flood() error = %v, want %q", err, tt.wantErr)
+			}
+			if len(tt.node.accepted) != 0 {
+				t.Errorf("Flood() sent %d transactions, want none", len(tt.node.accepted))
+			}
+		})
+	}
+}
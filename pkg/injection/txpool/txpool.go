@@ -0,0 +1,193 @@
+package txpool
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Sidecar paths of the running flood. The key file is removed by
+// chaos-txflood as soon as it has read it.
+const (
+	keyPath = "/tmp/chaos-txflood.key"
+	logPath = "/tmp/chaos-txflood.log"
+)
+
+// Limits on the flood's params.
+const (
+	MaxRate  = 5000
+	MaxCount = 10_000_000
+)
+
+// Params defines a txpool_saturation fault.
+type Params struct {
+	// PrivateKey is the hex secp256k1 key of a funded account, usually
+	// passed as ${VAR} so it stays out of the scenario file.
+	PrivateKey string
+	// Rate is transactions per second.
+	Rate float64
+	// Count stops the flood after that many transactions; zero floods
+	// until the fault is removed.
+	Count int
+	// GasPriceGwei prices every transaction; zero uses the node's
+	// eth_gasPrice.
+	GasPriceGwei float64
+	// RPCPort is the target's JSON-RPC port, reached on localhost from
+	// the sidecar.
+	RPCPort int
+}
+
+// SidecarManager interface for sidecar operations
+type SidecarManager interface {
+	CreateSidecar(ctx context.Context, targetContainerID string) (string, error)
+	ExecInSidecar(ctx context.Context, targetContainerID string, cmd []string) (string, error)
+	ExecInSidecarStdin(ctx context.Context, targetContainerID string, cmd []string, stdin io.Reader) (string, error)
+	GetSidecarID(targetContainerID string) (string, bool)
+}
+
+// Wrapper runs chaos-txflood in target sidecars.
+type Wrapper struct {
+	sidecarMgr SidecarManager
+}
+
+// New creates a new txpool flood wrapper
+func New(sidecarMgr SidecarManager) *Wrapper {
+	return &Wrapper{sidecarMgr: sidecarMgr}
+}
+
+// secp256k1N is the order of the secp256k1 curve; a private key is a
+// scalar in [1, N).
+var secp256k1N, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+// validateKey checks that hexKey, with or without 0x, is a secp256k1
+// private key. The runner only checks it; chaos-txflood parses it.
+func validateKey(hexKey string) error {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+	if err != nil || len(raw) != 32 {
+		return fmt.Errorf("private_key must be 32 hex-encoded bytes")
+	}
+	if k := new(big.Int).SetBytes(raw); k.Sign() == 0 || k.Cmp(secp256k1N) >= 0 {
+		return fmt.Errorf("private_key is not a valid secp256k1 key")
+	}
+	return nil
+}
+
+// ValidateParams checks a txpool_saturation fault's params.
+func ValidateParams(params Params) error {
+	if params.PrivateKey == "" {
+		return fmt.Errorf("private_key is required")
+	}
+	if strings.Contains(params.PrivateKey, "${") {
+		return fmt.Errorf("private_key has an unresolved variable; set it in the environment or a --values file")
+	}
+	if err := validateKey(params.PrivateKey); err != nil {
+		return err
+	}
+	if params.Rate <= 0 || params.Rate > MaxRate {
+		return fmt.Errorf("rate must be greater than 0 and at most %d transactions per second", MaxRate)
+	}
+	if params.Count < 0 || params.Count > MaxCount {
+		return fmt.Errorf("count must be between 0 (until removed) and %d", MaxCount)
+	}
+	if params.GasPriceGwei < 0 {
+		return fmt.Errorf("gas_price_gwei cannot be negative")
+	}
+	if params.RPCPort <= 0 || params.RPCPort > 65535 {
+		return fmt.Errorf("rpc_port must be between 1 and 65535")
+	}
+	return nil
+}
+
+// InjectFlood starts chaos-txflood in the target's sidecar and waits for
+// its preflight checks to pass, so an unfunded key or an unreachable RPC
+// fails the injection instead of a silent no-op.
+func (w *Wrapper) InjectFlood(ctx context.Context, targetContainerID string, params Params) error {
+	if _, exists := w.sidecarMgr.GetSidecarID(targetContainerID); !exists {
+		fmt.Printf("Creating sidecar for target %s\n", targetContainerID[:12])
+		if _, err := w.sidecarMgr.CreateSidecar(ctx, targetContainerID); err != nil {
+			return fmt.Errorf("failed to create sidecar: %w", err)
+		}
+	}
+
+	if out, _ := w.sidecarMgr.ExecInSidecar(ctx, targetContainerID, []string{"sh", "-c", "pgrep -x chaos-txflood || true"}); strings.TrimSpace(out) != "" {
+		return fmt.Errorf("a txpool flood is already running on target %s", targetContainerID[:12])
+	}
+
+	// The key is streamed over stdin so it stays out of the exec's command
+	// line, which -vv traces; umask keeps the file private to the
+	// sidecar's root.
+	writeCmd := []string{"sh", "-c", fmt.Sprintf("umask 077 && cat > %s", keyPath)}
+	if out, err := w.sidecarMgr.ExecInSidecarStdin(ctx, targetContainerID, writeCmd, strings.NewReader(params.PrivateKey)); err != nil {
+		return fmt.Errorf("failed to write key to sidecar: %w (output: %s)", err, out)
+	}
+
+	args := floodArgs(params)
+	fmt.Printf("Starting txpool flood on target %s: %s\n", targetContainerID[:12], strings.Join(args, " "))
+	// Fully detached, as for corruption-proxy: stdin from /dev/null,
+	// output to the log file.
+	startCmd := []string{"sh", "-c", strings.Join(args, " ") + fmt.Sprintf(" </dev/null > %s 2>&1 &", logPath)}
+	if out, err := w.sidecarMgr.ExecInSidecar(ctx, targetContainerID, startCmd); err != nil {
+		return fmt.Errorf("failed to start chaos-txflood: %w (output: %s)", err, out)
+	}
+
+	// Ready once it logs "flood started"; failed if it exits first.
+	readyCmd := []string{"sh", "-c", fmt.Sprintf(
+		"for i in $(seq 1 30); do "+
+			"grep -q 'flood started' %[1]s 2>/dev/null && exit 0; "+
+			"pgrep -x chaos-txflood > /dev/null || exit 1; "+
+			"sleep 0.5; done; exit 1", logPath)}
+	if _, err := w.sidecarMgr.ExecInSidecar(ctx, targetContainerID, readyCmd); err != nil {
+		logs, _ := w.sidecarMgr.ExecInSidecar(ctx, targetContainerID, []string{"sh", "-c", fmt.Sprintf("tail -5 %s 2>/dev/null", logPath)})
+		w.RemoveFault(ctx, targetContainerID)
+		return fmt.Errorf("chaos-txflood did not start within 15s: %s", strings.TrimSpace(logs))
+	}
+
+	fmt.Printf("Txpool flood active on target %s\n", targetContainerID[:12])
+	return nil
+}
+
+// floodArgs is the chaos-txflood command line for params.
+func floodArgs(params Params) []string {
+	args := []string{
+		"chaos-txflood",
+		"--rpc", fmt.Sprintf("http://127.0.0.1:%d", params.RPCPort),
+		"--key-file", keyPath,
+		"--rate", strconv.FormatFloat(params.Rate, 'f', -1, 64),
+		"--count", strconv.Itoa(params.Count),
+	}
+	if params.GasPriceGwei > 0 {
+		args = append(args, "--gas-price-gwei", strconv.FormatFloat(params.GasPriceGwei, 'f', -1, 64))
+	}
+	return args
+}
+
+// RemoveFault stops the flood, giving chaos-txflood a few seconds to log
+// its final counts, and prints them. Transactions already in the pool are
+// left to be mined or evicted.
+func (w *Wrapper) RemoveFault(ctx context.Context, targetContainerID string) error {
+	if _, exists := w.sidecarMgr.GetSidecarID(targetContainerID); !exists {
+		return nil
+	}
+
+	stopCmd := []string{"sh", "-c", fmt.Sprintf(
+		"pkill -x chaos-txflood; "+
+			"for i in $(seq 1 10); do pgrep -x chaos-txflood > /dev/null || break; sleep 0.5; done; "+
+			"pkill -9 -x chaos-txflood; "+
+			"grep 'chaos-txflood finished' %[1]s 2>/dev/null | tail -1; "+
+			"rm -f %[1]s %[2]s; true", logPath, keyPath)}
+	out, err := w.sidecarMgr.ExecInSidecar(ctx, targetContainerID, stopCmd)
+	if err != nil {
+		log.Warn().Err(err).Str("container", targetContainerID[:12]).Msg("failed to stop chaos-txflood")
+		return nil
+	}
+	if out = strings.TrimSpace(out); out != "" {
+		fmt.Printf("Txpool flood stopped on target %s: %s\n", targetContainerID[:12], out)
+	}
+	return nil
+}
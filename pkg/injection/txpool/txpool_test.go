@@ -0,0 +1,145 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const testKey = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestValidateParams(t *testing.T) {
+	valid := Params{PrivateKey: testKey, Rate: 100, RPCPort: 8545}
+	tests := []struct {
+		name    string
+		modify  func(*Params)
+		wantErr bool
+	}{
+		{"valid", func(p *Params) {}, false},
+		{"key without 0x", func(p *Params) { p.PrivateKey = strings.TrimPrefix(testKey, "0x") }, false},
+		{"missing key", func(p *Params) { p.PrivateKey = "" }, true},
+		{"unresolved variable", func(p *Params) { p.PrivateKey = "${FUNDED_KEY}" }, true},
+		{"short key", func(p *Params) { p.PrivateKey = "0x1234" }, true},
+		{"zero key", func(p *Params) { p.PrivateKey = "0x" + strings.Repeat("0", 64) }, true},
+		{"key above curve order", func(p *Params) { p.PrivateKey = "0x" + strings.Repeat("f", 64) }, true},
+		{"zero rate", func(p *Params) { p.Rate = 0 }, true},
+		{"rate too high", func(p *Params) { p.Rate = MaxRate + 1 }, true},
+		{"fractional rate", func(p *Params) { p.Rate = 0.5 }, false},
+		{"negative count", func(p *Params) { p.Count = -1 }, true},
+		{"negative gas price", func(p *Params) { p.GasPriceGwei = -1 }, true},
+		{"bad rpc port", func(p *Params) { p.RPCPort = 0 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.modify(&p)
+			if err := ValidateParams(p); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type fakeSidecar struct {
+	captured [][]string
+	stdin    map[string]string // joined cmd -> stdin it was sent
+	outputs  map[string]string // cmd keyword -> output
+	execErr  map[string]error  // cmd keyword -> err to return
+}
+
+func (f *fakeSidecar) CreateSidecar(ctx context.Context, cid string) (string, error) {
+	return "sidecar-" + cid, nil
+}
+
+func (f *fakeSidecar) ExecInSidecar(ctx context.Context, cid string, cmd []string) (string, error) {
+	f.captured = append(f.captured, append([]string(nil), cmd...))
+	joined := strings.Join(cmd, " ")
+	for key, err := range f.execErr {
+		if strings.Contains(joined, key) {
+			return "", err
+		}
+	}
+	for key, out := range f.outputs {
+		if strings.Contains(joined, key) {
+			return out, nil
+		}
+	}
+	return "", nil
+}
+
+func (f *fakeSidecar) ExecInSidecarStdin(ctx context.Context, cid string, cmd []string, stdin io.Reader) (string, error) {
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", err
+	}
+	if f.stdin == nil {
+		f.stdin = make(map[string]string)
+	}
+	f.stdin[strings.Join(cmd, " ")] = string(data)
+	return f.ExecInSidecar(ctx, cid, cmd)
+}
+
+func (f *fakeSidecar) GetSidecarID(cid string) (string, bool) {
+	return "sidecar-" + cid, true
+}
+
+func (f *fakeSidecar) ran(keyword string) bool {
+	for _, c := range f.captured {
+		if strings.Contains(strings.Join(c, " "), keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestInjectFlood(t *testing.T) {
+	sc := &fakeSidecar{}
+	w := New(sc)
+	params := Params{PrivateKey: testKey, Rate: 250, Count: 1000, GasPriceGwei: 25, RPCPort: 8545}
+	if err := w.InjectFlood(context.Background(), "abcdef1234567890", params); err != nil {
+		t.Fatalf("InjectFlood() error = %v", err)
+	}
+
+	for _, c := range sc.captured {
+		if strings.Contains(strings.Join(c, " "), strings.TrimPrefix(testKey, "0x")) {
+			t.Errorf("the private key appears in a sidecar command: %v", c)
+		}
+	}
+	if got := sc.stdin["sh -c umask 077 && cat > "+keyPath]; got != testKey {
+		t.Errorf("key file was written with stdin %q, want the private key", got)
+	}
+	want := "chaos-txflood --rpc http://127.0.0.1:8545 --key-file " + keyPath + " --rate 250 --count 1000 --gas-price-gwei 25"
+	if !sc.ran(want) {
+		t.Errorf("chaos-txflood was not started with %q; ran %v", want, sc.captured)
+	}
+}
+
+func TestInjectFloodAlreadyRunning(t *testing.T) {
+	sc := &fakeSidecar{outputs: map[string]string{"pgrep -x chaos-txflood || true": "4242\n"}}
+	w := New(sc)
+	err := w.InjectFlood(context.Background(), "abcdef1234567890", Params{PrivateKey: testKey, Rate: 1, RPCPort: 8545})
+	if err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Fatalf("InjectFlood() error = %v, want already running", err)
+	}
+	if sc.ran("--key-file") {
+		t.Error("a second flood was started")
+	}
+}
+
+func TestInjectFloodNotReady(t *testing.T) {
+	sc := &fakeSidecar{
+		execErr: map[string]error{"flood started": errors.New("exit status 1")},
+		outputs: map[string]string{"tail -5": "error: account 0xabc is not funded"},
+	}
+	w := New(sc)
+	err := w.InjectFlood(context.Background(), "abcdef1234567890", Params{PrivateKey: testKey, Rate: 1, RPCPort: 8545})
+	if err == nil || !strings.Contains(err.Error(), "not funded") {
+		t.Fatalf("InjectFlood() error = %v, want the flood's log", err)
+	}
+	if !sc.ran("pkill -x chaos-txflood") {
+		t.Error("a flood that failed to start was not cleaned up")
+	}
+}
@@ -0,0 +1,354 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChaosArtifacts is the chaos state found in one target's network
+// namespace, parsed into typed objects.
+type ChaosArtifacts struct {
+	ContainerID string
+	// Qdiscs are every qdisc on the devices that carry a chaos qdisc, so
+	// the prio roots the netem/tbf leaves hang off are included.
+	Qdiscs []Qdisc
+	// Filters are the classifiers on those same devices.
+	Filters []Filter
+	// IPTablesChains are chaos-owned chains (CHAOS_*), and IPTablesRules
+	// the rules in them plus chaos-commented rules in other chains.
+	IPTablesChains []IPTablesChain
+	IPTablesRules  []IPTablesRule
+	NFTables       []NFTable
+	// EnvoyListeners are sockets an envoy process listens on. Only
+	// processes visible where the check runs (the sidecar, or the target
+	// under nsenter) are attributed.
+	EnvoyListeners []EnvoyListener
+}
+
+// Qdisc is one line of `tc qdisc show`.
+type Qdisc struct {
+	Device string
+	Kind   string
+	Handle string
+	// Parent is the parent class, or "root".
+	Parent  string
+	Options string
+}
+
+// Chaos reports whether the qdisc is a kind only chaos faults install.
+func (q Qdisc) Chaos() bool {
+	return q.Kind == "netem" || q.Kind == "tbf"
+}
+
+// Filter is one classifier from `tc filter show`, with its match lines.
+type Filter struct {
+	Device   string
+	Parent   string
+	Protocol string
+	Pref     int
+	Kind     string
+	FlowID   string
+	Matches  []string
+}
+
+// IPTablesChain is a chain declared in an iptables-save table.
+type IPTablesChain struct {
+	Table string
+	Name  string
+}
+
+// IPTablesRule is one -A line of iptables-save.
+type IPTablesRule struct {
+	Table string
+	Chain string
+	// Spec is the rule after "-A <chain>", as iptables -D takes it.
+	Spec    []string
+	Comment string
+}
+
+// NFTable is one `nft list tables` entry.
+type NFTable struct {
+	Family string
+	Name   string
+}
+
+// EnvoyListener is one listening socket owned by envoy.
+type EnvoyListener struct {
+	Address string
+	Port    int
+	PID     int
+}
+
+// Empty reports whether no chaos artifacts were found.
+func (a *ChaosArtifacts) Empty() bool {
+	return len(a.Qdiscs) == 0 && len(a.Filters) == 0 && len(a.IPTablesChains) == 0 &&
+		len(a.IPTablesRules) == 0 && len(a.NFTables) == 0 && len(a.EnvoyListeners) == 0
+}
+
+// Devices lists the devices carrying chaos qdiscs, in first-seen order.
+func (a *ChaosArtifacts) Devices() []string {
+	var devices []string
+	seen := map[string]bool{}
+	for _, q := range a.Qdiscs {
+		if !seen[q.Device] {
+			seen[q.Device] = true
+			devices = append(devices, q.Device)
+		}
+	}
+	return devices
+}
+
+// String summarises the artifacts in one line.
+func (a *ChaosArtifacts) String() string {
+	var parts []string
+	if devices := a.Devices(); len(devices) > 0 {
+		parts = append(parts, fmt.Sprintf("qdiscs on %s", strings.Join(devices, ", ")))
+	}
+	if n := len(a.Filters); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d tc filter(s)", n))
+	}
+	if n := len(a.IPTablesChains); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d iptables chain(s)", n))
+	}
+	if n := len(a.IPTablesRules); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d iptables rule(s)", n))
+	}
+	if n := len(a.NFTables); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d nftables table(s)", n))
+	}
+	if n := len(a.EnvoyListeners); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d envoy listener(s)", n))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// CleanupCommands returns the commands that remove exactly these
+// artifacts, run in the target's network namespace: the root qdisc of
+// each chaos device (taking its filters with it), chaos rules in shared
+// chains, then the chaos chains and nftables tables. Envoy is not
+// included; it lives and dies with its sidecar.
+func (a *ChaosArtifacts) CleanupCommands() [][]string {
+	var cmds [][]string
+	for _, device := range a.Devices() {
+		cmds = append(cmds, []string{"tc", "qdisc", "del", "dev", device, "root"})
+	}
+	owned := map[IPTablesChain]bool{}
+	for _, c := range a.IPTablesChains {
+		owned[c] = true
+	}
+	for _, r := range a.IPTablesRules {
+		if owned[IPTablesChain{Table: r.Table, Name: r.Chain}] {
+			continue
+		}
+		cmds = append(cmds, append([]string{"iptables", "-t", r.Table, "-D", r.Chain}, r.Spec...))
+	}
+	for _, c := range a.IPTablesChains {
+		cmds = append(cmds, []string{"iptables", "-t", c.Table, "-F", c.Name}, []string{"iptables", "-t", c.Table, "-X", c.Name})
+	}
+	for _, t := range a.NFTables {
+		cmds = append(cmds, []string{"nft", "delete", "table", t.Family, t.Name})
+	}
+	return cmds
+}
+
+// ListChaosArtifacts lists the chaos state in a container's network
+// namespace. Unlike VerifyNamespaceClean it reports what is there rather
+// than whether anything is; nftables and envoy listings are best-effort,
+// since nft and ss may be missing where the check runs.
+func (v *Verifier) ListChaosArtifacts(ctx context.Context, containerID string) (*ChaosArtifacts, error) {
+	pid := 0
+	if v.sidecar == nil {
+		var err error
+		pid, err = v.dockerClient.GetContainerPID(ctx, containerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get container PID: %w", err)
+		}
+	}
+
+	a := &ChaosArtifacts{ContainerID: containerID}
+
+	out, err := v.execInNamespace(ctx, containerID, pid, "tc", "qdisc", "show")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list qdiscs: %w", err)
+	}
+	for _, iface := range ParseQdiscs(out) {
+		if !iface.ChaosQdisc {
+			continue
+		}
+		for _, line := range iface.Qdiscs {
+			if q, ok := parseQdisc(line); ok {
+				a.Qdiscs = append(a.Qdiscs, q)
+			}
+		}
+		out, err := v.execInNamespace(ctx, containerID, pid, "tc", "filter", "show", "dev", iface.Device)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list filters on %s: %w", iface.Device, err)
+		}
+		a.Filters = append(a.Filters, parseFilters(iface.Device, out)...)
+	}
+
+	out, err = v.execInNamespace(ctx, containerID, pid, "iptables-save")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list iptables rules: %w", err)
+	}
+	a.IPTablesChains, a.IPTablesRules = parseIPTablesSave(out)
+
+	if out, err := v.execInNamespace(ctx, containerID, pid, "nft", "list", "tables"); err == nil {
+		a.NFTables = parseNFTables(out)
+	}
+	if out, err := v.execInNamespace(ctx, containerID, pid, "ss", "-ltnpH"); err == nil {
+		a.EnvoyListeners = parseEnvoyListeners(out)
+	}
+	return a, nil
+}
+
+// parseQdisc parses one `tc qdisc show` line, e.g.
+// "qdisc netem 10: dev eth0 parent 1:3 limit 1000 delay 100ms".
+func parseQdisc(line string) (Qdisc, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "qdisc" || fields[3] != "dev" {
+		return Qdisc{}, false
+	}
+	q := Qdisc{Kind: fields[1], Handle: fields[2], Device: fields[4]}
+	rest := fields[5:]
+	switch {
+	case len(rest) > 0 && rest[0] == "root":
+		q.Parent, rest = "root", rest[1:]
+	case len(rest) > 1 && rest[0] == "parent":
+		q.Parent, rest = rest[1], rest[2:]
+	}
+	q.Options = strings.Join(rest, " ")
+	return q, true
+}
+
+// parseFilters parses `tc filter show dev <device>`. Only entries with a
+// flowid are classifiers; the u32 hash-table header lines are skipped.
+func parseFilters(device, output string) []Filter {
+	var filters []Filter
+	last := -1
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] != "filter" {
+			if fields[0] == "match" && last >= 0 {
+				filters[last].Matches = append(filters[last].Matches, strings.Join(fields[1:], " "))
+			}
+			continue
+		}
+		f := Filter{Device: device}
+		for i := 1; i < len(fields)-1; i++ {
+			switch fields[i] {
+			case "parent":
+				f.Parent = fields[i+1]
+			case "protocol":
+				f.Protocol = fields[i+1]
+			case "pref":
+				f.Pref, _ = strconv.Atoi(fields[i+1])
+				if i+2 < len(fields) {
+					f.Kind = fields[i+2]
+				}
+			case "flowid", "classid":
+				f.FlowID = fields[i+1]
+			}
+		}
+		last = -1
+		if f.FlowID != "" {
+			filters = append(filters, f)
+			last = len(filters) - 1
+		}
+	}
+	return filters
+}
+
+// parseIPTablesSave returns the chaos chains and rules in iptables-save
+// output: chains named CHAOS_*, every rule in them, and any rule whose
+// comment mentions chaos.
+func parseIPTablesSave(output string) ([]IPTablesChain, []IPTablesRule) {
+	var chains []IPTablesChain
+	var rules []IPTablesRule
+	table := ""
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "*"):
+			table = line[1:]
+		case strings.HasPrefix(line, ":"):
+			name := strings.Fields(line[1:])
+			if len(name) > 0 && strings.HasPrefix(name[0], "CHAOS_") {
+				chains = append(chains, IPTablesChain{Table: table, Name: name[0]})
+			}
+		case strings.HasPrefix(line, "-A "):
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			r := IPTablesRule{Table: table, Chain: fields[1]}
+			for i, f := range fields[2:] {
+				f = strings.Trim(f, `"`)
+				r.Spec = append(r.Spec, f)
+				if i > 0 && fields[i+1] == "--comment" {
+					r.Comment = f
+				}
+			}
+			if strings.HasPrefix(r.Chain, "CHAOS_") || strings.Contains(r.Comment, "chaos") {
+				rules = append(rules, r)
+			}
+		}
+	}
+	return chains, rules
+}
+
+// parseNFTables returns the chaos tables in `nft list tables` output.
+// chaos-utils never installs nftables, so any such table is stale.
+func parseNFTables(output string) []NFTable {
+	var tables []NFTable
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "table" && strings.Contains(fields[2], "chaos") {
+			tables = append(tables, NFTable{Family: fields[1], Name: fields[2]})
+		}
+	}
+	return tables
+}
+
+// parseEnvoyListeners returns envoy's sockets from `ss -ltnpH`, whose
+// lines look like
+// `LISTEN 0 4096 0.0.0.0:15000 0.0.0.0:* users:(("envoy",pid=42,fd=20))`.
+func parseEnvoyListeners(output string) []EnvoyListener {
+	var listeners []EnvoyListener
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.Contains(line, `"envoy"`) {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		local := fields[3]
+		sep := strings.LastIndex(local, ":")
+		if sep < 0 {
+			continue
+		}
+		port, err := strconv.Atoi(local[sep+1:])
+		if err != nil {
+			continue
+		}
+		l := EnvoyListener{Address: local[:sep], Port: port}
+		if i := strings.Index(line, "pid="); i >= 0 {
+			digits := line[i+4:]
+			if end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+				digits = digits[:end]
+			}
+			l.PID, _ = strconv.Atoi(digits)
+		}
+		listeners = append(listeners, l)
+	}
+	return listeners
+}
@@ -0,0 +1,99 @@
+package verification
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseQdisc(t *testing.T) {
+	tests := []struct {
+		line string
+		want Qdisc
+	}{
+		{"qdisc prio 1: dev eth0 root refcnt 2 bands 3 priomap 1 2 2 2", Qdisc{Device: "eth0", Kind: "prio", Handle: "1:", Parent: "root", Options: "refcnt 2 bands 3 priomap 1 2 2 2"}},
+		{"qdisc netem 10: dev eth0 parent 1:3 limit 1000 delay 100ms", Qdisc{Device: "eth0", Kind: "netem", Handle: "10:", Parent: "1:3", Options: "limit 1000 delay 100ms"}},
+	}
+	for _, tt := range tests {
+		got, ok := parseQdisc(tt.line)
+		if !ok || got != tt.want {
+			t.Errorf("parseQdisc(%q) = %+v, %v; want %+v", tt.line, got, ok, tt.want)
+		}
+	}
+	if _, ok := parseQdisc("garbage"); ok {
+		t.Error("parseQdisc accepted a non-qdisc line")
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	out := `filter parent 1: protocol ip pref 1 u32 chain 0
+filter parent 1: protocol ip pref 1 u32 chain 0 fh 800: ht divisor 1
+filter parent 1: protocol ip pref 1 u32 chain 0 fh 800::800 order 2048 key ht 800 bkt 0 flowid 1:3 not_in_hw
+  match 0a000005/ffffffff at 16
+`
+	want := []Filter{{Device: "eth0", Parent: "1:", Protocol: "ip", Pref: 1, Kind: "u32", FlowID: "1:3", Matches: []string{"0a000005/ffffffff at 16"}}}
+	if got := parseFilters("eth0", out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseFilters() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseIPTablesSave(t *testing.T) {
+	out := `*nat
+:PREROUTING ACCEPT [0:0]
+-A PREROUTING -p tcp -m tcp --dport 8545 -m comment --comment chaos-http-fault -j REDIRECT --to-ports 18545
+COMMIT
+*filter
+:INPUT ACCEPT [0:0]
+:CHAOS_DROP - [0:0]
+-A INPUT -m comment --comment "chaos-engineering" -j CHAOS_DROP
+-A INPUT -p tcp --dport 22 -j ACCEPT
+-A CHAOS_DROP -s 10.0.0.5/32 -j DROP
+COMMIT
+`
+	chains, rules := parseIPTablesSave(out)
+	if want := []IPTablesChain{{Table: "filter", Name: "CHAOS_DROP"}}; !reflect.DeepEqual(chains, want) {
+		t.Errorf("chains = %+v, want %+v", chains, want)
+	}
+	if len(rules) != 3 {
+		t.Fatalf("got %d rules, want 3: %+v", len(rules), rules)
+	}
+	if rules[0].Table != "nat" || rules[0].Comment != "chaos-http-fault" {
+		t.Errorf("rules[0] = %+v, want the nat redirect", rules[0])
+	}
+	if rules[1].Comment != "chaos-engineering" {
+		t.Errorf("rules[1].Comment = %q, want unquoted chaos-engineering", rules[1].Comment)
+	}
+
+	a := &ChaosArtifacts{
+		Qdiscs:         []Qdisc{{Device: "eth0", Kind: "netem"}},
+		IPTablesChains: chains,
+		IPTablesRules:  rules,
+		NFTables:       parseNFTables("table inet filter\ntable inet chaos_block\n"),
+	}
+	var got []string
+	for _, cmd := range a.CleanupCommands() {
+		got = append(got, strings.Join(cmd, " "))
+	}
+	want := []string{
+		"tc qdisc del dev eth0 root",
+		"iptables -t nat -D PREROUTING -p tcp -m tcp --dport 8545 -m comment --comment chaos-http-fault -j REDIRECT --to-ports 18545",
+		"iptables -t filter -D INPUT -m comment --comment chaos-engineering -j CHAOS_DROP",
+		"iptables -t filter -F CHAOS_DROP",
+		"iptables -t filter -X CHAOS_DROP",
+		"nft delete table inet chaos_block",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CleanupCommands() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParseEnvoyListeners(t *testing.T) {
+	out := `LISTEN 0 4096 0.0.0.0:15000 0.0.0.0:* users:(("envoy",pid=42,fd=20))
+LISTEN 0 128 127.0.0.1:15001 0.0.0.0:* users:(("envoy",pid=42,fd=21))
+LISTEN 0 4096 *:8545 *:* users:(("bor",pid=7,fd=9))
+`
+	want := []EnvoyListener{{Address: "0.0.0.0", Port: 15000, PID: 42}, {Address: "127.0.0.1", Port: 15001, PID: 42}}
+	if got := parseEnvoyListeners(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseEnvoyListeners() = %+v, want %+v", got, want)
+	}
+}
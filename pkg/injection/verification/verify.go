@@ -8,9 +8,20 @@ import (
 	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
 )
 
+// SidecarExecer runs a command in the sidecar attached to a target. The
+// sidecar shares the target's network namespace, so no nsenter is needed.
+type SidecarExecer interface {
+	ExecInSidecar(ctx context.Context, targetContainerID string, cmd []string) (string, error)
+}
+
 // Verifier checks for remaining chaos artifacts in container network namespaces
 type Verifier struct {
 	dockerClient *docker.Client
+
+	// sidecar, when set, replaces host-PID nsenter for namespace checks.
+	// Set for remote / non-Linux daemons where container PIDs are not
+	// meaningful to the runner host.
+	sidecar SidecarExecer
 }
 
 // New creates a new verifier
@@ -20,6 +31,18 @@ func New(dockerClient *docker.Client) *Verifier {
 	}
 }
 
+// UseSidecarExec routes all namespace checks through target sidecars
+// instead of nsenter. Checks on a target without a sidecar then fail with a
+// warning rather than attempting host access.
+func (v *Verifier) UseSidecarExec(s SidecarExecer) {
+	v.sidecar = s
+}
+
+// SidecarOnly reports whether namespace checks are routed through sidecars.
+func (v *Verifier) SidecarOnly() bool {
+	return v.sidecar != nil
+}
+
 // VerificationResult contains the results of a namespace verification check
 type VerificationResult struct {
 	ContainerID    string
@@ -29,6 +52,76 @@ type VerificationResult struct {
 	NFTablesFound  bool
 	EnvoyFound     bool
 	Details        []string
+
+	// Interfaces is the per-device qdisc state from `tc qdisc show`.
+	Interfaces []InterfaceState
+}
+
+// InterfaceState is the qdisc state of one network device.
+type InterfaceState struct {
+	Device string
+	// Qdiscs are the raw `tc qdisc show` lines for the device.
+	Qdiscs []string
+	// ChaosQdisc is true when a netem or tbf qdisc (always chaos-added)
+	// is attached anywhere on the device.
+	ChaosQdisc bool
+}
+
+// ChaosDevices lists the devices that still carry chaos qdiscs.
+func (r *VerificationResult) ChaosDevices() []string {
+	var devices []string
+	for _, iface := range r.Interfaces {
+		if iface.ChaosQdisc {
+			devices = append(devices, iface.Device)
+		}
+	}
+	return devices
+}
+
+// ParseQdiscs groups `tc qdisc show` output by device. Devices appear in
+// first-seen order.
+func ParseQdiscs(output string) []InterfaceState {
+	var states []InterfaceState
+	index := map[string]int{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		fields := strings.Fields(line)
+		device := ""
+		for i := 0; i < len(fields)-1; i++ {
+			if fields[i] == "dev" {
+				device = fields[i+1]
+				break
+			}
+		}
+		if device == "" {
+			continue
+		}
+		i, ok := index[device]
+		if !ok {
+			i = len(states)
+			index[device] = i
+			states = append(states, InterfaceState{Device: device})
+		}
+		states[i].Qdiscs = append(states[i].Qdiscs, line)
+		if len(fields) > 1 && (fields[1] == "netem" || fields[1] == "tbf") {
+			states[i].ChaosQdisc = true
+		}
+	}
+	return states
+}
+
+// CleanupCommands returns the tc commands that remove chaos qdiscs from the
+// given devices. Deleting the root qdisc drops any prio/netem/tbf tree the
+// injectors built on it and restores the kernel default; devices without
+// chaos qdiscs are never touched.
+func CleanupCommands(states []InterfaceState) [][]string {
+	var cmds [][]string
+	for _, iface := range states {
+		if iface.ChaosQdisc {
+			cmds = append(cmds, []string{"tc", "qdisc", "del", "dev", iface.Device, "root"})
+		}
+	}
+	return cmds
 }
 
 // VerifyNamespaceClean checks if a container's network namespace is clean
@@ -39,14 +132,18 @@ func (v *Verifier) VerifyNamespaceClean(ctx context.Context, containerID string)
 		Details:     make([]string, 0),
 	}
 
-	// Get container PID
-	pid, err := v.dockerClient.GetContainerPID(ctx, containerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get container PID: %w", err)
+	// Get container PID (only meaningful when checks use host nsenter)
+	pid := 0
+	if v.sidecar == nil {
+		var err error
+		pid, err = v.dockerClient.GetContainerPID(ctx, containerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get container PID: %w", err)
+		}
 	}
 
 	// Check tc (traffic control) rules
-	hasTC, details, err := v.checkTCRules(ctx, containerID, pid)
+	hasTC, details, err := v.checkTCRules(ctx, containerID, pid, result)
 	if err != nil {
 		result.Clean = false
 		result.Details = append(result.Details, fmt.Sprintf("WARN: %v", err))
@@ -92,30 +189,40 @@ func (v *Verifier) VerifyNamespaceClean(ctx context.Context, containerID string)
 	return result, nil
 }
 
-// checkTCRules checks for traffic control rules using tc command
-func (v *Verifier) checkTCRules(ctx context.Context, containerID string, pid int) (bool, []string, error) {
-	// Use nsenter to check tc rules in the container's network namespace
-	cmd := []string{"nsenter", "-t", fmt.Sprintf("%d", pid), "-n", "tc", "qdisc", "show"}
+// execInNamespace runs cmd in the target's network namespace: via the
+// target's sidecar in sidecar-only mode, otherwise via nsenter on pid.
+func (v *Verifier) execInNamespace(ctx context.Context, containerID string, pid int, cmd ...string) (string, error) {
+	if v.sidecar != nil {
+		return v.sidecar.ExecInSidecar(ctx, containerID, cmd)
+	}
+	nsCmd := append([]string{"nsenter", "-t", fmt.Sprintf("%d", pid), "-n"}, cmd...)
+	return v.dockerClient.ExecCommand(ctx, containerID, nsCmd)
+}
 
-	output, err := v.dockerClient.ExecCommand(ctx, containerID, cmd)
+// checkTCRules checks for traffic control rules using tc command on every
+// device, recording per-interface state on the result.
+func (v *Verifier) checkTCRules(ctx context.Context, containerID string, pid int, result *VerificationResult) (bool, []string, error) {
+	// Check tc rules in the container's network namespace
+	output, err := v.execInNamespace(ctx, containerID, pid, "tc", "qdisc", "show")
 	if err != nil {
 		return false, nil, fmt.Errorf("tc check failed (cannot verify clean state): %w", err)
 	}
 
-	// Check if output contains netem or tbf (traffic shaping) qdiscs
-	if strings.Contains(output, "netem") || strings.Contains(output, "tbf") {
-		return true, []string{fmt.Sprintf("TC rules found: %s", output)}, nil
-	}
+	result.Interfaces = ParseQdiscs(output)
 
-	return false, nil, nil
+	var details []string
+	for _, iface := range result.Interfaces {
+		if iface.ChaosQdisc {
+			details = append(details, fmt.Sprintf("TC rules found on %s: %s", iface.Device, strings.Join(iface.Qdiscs, "; ")))
+		}
+	}
+	return len(details) > 0, details, nil
 }
 
 // checkIPTablesRules checks for iptables rules
 func (v *Verifier) checkIPTablesRules(ctx context.Context, containerID string, pid int) (bool, []string, error) {
-	// Use nsenter to check iptables rules
-	cmd := []string{"nsenter", "-t", fmt.Sprintf("%d", pid), "-n", "iptables", "-L", "-n"}
-
-	output, err := v.dockerClient.ExecCommand(ctx, containerID, cmd)
+	// Check iptables rules in the container's network namespace
+	output, err := v.execInNamespace(ctx, containerID, pid, "iptables", "-L", "-n")
 	if err != nil {
 		return false, nil, fmt.Errorf("iptables check failed (cannot verify clean state): %w", err)
 	}
@@ -131,10 +238,8 @@ func (v *Verifier) checkIPTablesRules(ctx context.Context, containerID string, p
 
 // checkNFTablesRules checks for nftables rules
 func (v *Verifier) checkNFTablesRules(ctx context.Context, containerID string, pid int) (bool, []string, error) {
-	// Use nsenter to check nftables rules
-	cmd := []string{"nsenter", "-t", fmt.Sprintf("%d", pid), "-n", "nft", "list", "tables"}
-
-	output, err := v.dockerClient.ExecCommand(ctx, containerID, cmd)
+	// Check nftables rules in the container's network namespace
+	output, err := v.execInNamespace(ctx, containerID, pid, "nft", "list", "tables")
 	if err != nil {
 		// nft may not be installed — this is expected in many containers,
 		// so treat as "no nftables rules" rather than a verification failure
@@ -61,7 +61,7 @@ func TestCheckTCRules_ReturnsError(t *testing.T) {
 	// 3-return signature is used correctly.
 
 	// Verify the function signatures return 3 values (compile check)
-	var _ func(context.Context, string, int) (bool, []string, error) = v.checkTCRules
+	var _ func(context.Context, string, int, *VerificationResult) (bool, []string, error) = v.checkTCRules
 	var _ func(context.Context, string, int) (bool, []string, error) = v.checkIPTablesRules
 	var _ func(context.Context, string, int) (bool, []string, error) = v.checkNFTablesRules
 	var _ func(context.Context, string) (bool, []string, error) = v.checkEnvoyProcesses
@@ -87,3 +87,56 @@ func TestVerificationResult_NotCleanOnCheckError(t *testing.T) {
 		t.Error("result should have details about the failure")
 	}
 }
+
+type fakeSidecar struct {
+	calls  [][]string
+	output string
+}
+
+func (f *fakeSidecar) ExecInSidecar(ctx context.Context, targetContainerID string, cmd []string) (string, error) {
+	f.calls = append(f.calls, cmd)
+	return f.output, nil
+}
+
+func TestCheckTCRules_SidecarOnly(t *testing.T) {
+	sc := &fakeSidecar{output: "qdisc netem 8001: dev eth0 root refcnt 2 delay 100ms"}
+	v := &Verifier{}
+	v.UseSidecarExec(sc)
+
+	found, _, err := v.checkTCRules(context.Background(), "abc123", 0, &VerificationResult{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !found {
+		t.Error("expected netem qdisc to be detected")
+	}
+	if len(sc.calls) != 1 || sc.calls[0][0] != "tc" {
+		t.Errorf("expected a bare tc command in the sidecar (no nsenter), got %v", sc.calls)
+	}
+}
+
+func TestParseQdiscs(t *testing.T) {
+	output := `qdisc noqueue 0: dev lo root refcnt 2
+qdisc prio 1: dev eth0 root refcnt 2 bands 3 priomap 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0
+qdisc netem 20: dev eth0 parent 1:2 limit 1000 delay 200ms
+qdisc fq_codel 0: dev eth1 root refcnt 2 limit 10240p
+qdisc netem 8001: dev eth2 root refcnt 2 loss 10%
+`
+	states := ParseQdiscs(output)
+	if len(states) != 4 {
+		t.Fatalf("expected 4 devices, got %d: %+v", len(states), states)
+	}
+
+	result := &VerificationResult{Interfaces: states}
+	if got := result.ChaosDevices(); len(got) != 2 || got[0] != "eth0" || got[1] != "eth2" {
+		t.Errorf("ChaosDevices() = %v, want [eth0 eth2]", got)
+	}
+	if len(states[1].Qdiscs) != 2 {
+		t.Errorf("eth0 should carry both prio and netem lines, got %v", states[1].Qdiscs)
+	}
+
+	cmds := CleanupCommands(states)
+	if len(cmds) != 2 || cmds[0][4] != "eth0" || cmds[1][4] != "eth2" {
+		t.Errorf("CleanupCommands() = %v, want root deletes on eth0 and eth2 only", cmds)
+	}
+}
@@ -0,0 +1,152 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// newBlindPrometheus serves a Prometheus where the "blind" job's scrape
+// target is down. Criterion queries return 1, or 0 when they read the
+// "bad" job.
+func newBlindPrometheus(t *testing.T) *prometheus.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		query := r.Form.Get("query")
+		now := time.Now().Unix()
+		switch query {
+		case `up{job="blind"} == 0`, `up == 0`:
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"__name__":"up","job":"blind","instance":"10.0.0.6:7071"},"value":[%d,"0"]}]}}`, now)
+		case `up{job="bad"} == 0`, `up{job="seen"} == 0`:
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		case `height{job="bad"}`:
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[%d,"0"]}]}}`, now)
+		default:
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[%d,"1"]}]}}`, now)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := prometheus.New(prometheus.Config{URL: srv.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("prometheus.New: %v", err)
+	}
+	return client
+}
+
+func TestBlindSpotDetection(t *testing.T) {
+	blind := promCriterion("blind", `height{job="blind"}`)
+	seen := promCriterion("seen", `height{job="seen"}`)
+	bad := promCriterion("bad", `height{job="bad"}`)
+	expectBlind := blind
+	expectBlind.Expect = scenario.ExpectUnknown
+	expectSeen := seen
+	expectSeen.Expect = scenario.ExpectUnknown
+	failBad, failSeen, failBlind := bad, seen, blind
+	failBad.Expect, failSeen.Expect, failBlind.Expect = scenario.ExpectFailure, scenario.ExpectFailure, scenario.ExpectFailure
+
+	tests := []struct {
+		name        string
+		criterion   scenario.SuccessCriterion
+		detect      bool
+		wantPassed  bool
+		wantUnknown bool
+	}{
+		{"detection off judges stale data", blind, false, true, false},
+		{"blinded job is unknown", blind, true, false, true},
+		{"healthy job is judged", seen, true, true, false},
+		{"unscoped query checks every target", promCriterion("any", "height"), true, false, true},
+		{"expect unknown passes when blinded", expectBlind, true, true, true},
+		{"expect unknown fails when judged", expectSeen, true, false, false},
+		{"expect failure passes when judged failing", failBad, true, true, false},
+		{"expect failure fails when judged passing", failSeen, true, false, false},
+		{"expect failure stays unknown when blinded", failBlind, true, false, true},
+		{"all_of with an unknown child is unknown", scenario.SuccessCriterion{Type: "composite", AllOf: []scenario.SuccessCriterion{seen, blind}}, true, false, true},
+		{"all_of with a failing child fails", scenario.SuccessCriterion{Type: "composite", AllOf: []scenario.SuccessCriterion{bad, blind}}, true, false, false},
+		{"any_of with a passing child passes", scenario.SuccessCriterion{Type: "composite", AnyOf: []scenario.SuccessCriterion{blind, seen}}, true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := New(newBlindPrometheus(t))
+			fd.DetectBlindSpots(tt.detect)
+
+			result, err := fd.EvaluateOnce(context.Background(), tt.criterion)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Passed != tt.wantPassed || result.Unknown != tt.wantUnknown {
+				t.Errorf("Passed = %v, Unknown = %v, want %v, %v (%s)", result.Passed, result.Unknown, tt.wantPassed, tt.wantUnknown, result.Message)
+			}
+		})
+	}
+}
+
+func TestEvaluatePrometheus_QueryErrors(t *testing.T) {
+	// apiError answers every query with a Prometheus API error.
+	apiError := func(code int, errorType string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(code)
+			fmt.Fprintf(w, `{"status":"error","errorType":%q,"error":"query rejected"}`, errorType)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc // nil: the server is closed before the query
+		wantErr     bool
+		wantUnknown bool
+	}{
+		{name: "unreachable", wantErr: true},
+		{name: "server unavailable", handler: apiError(http.StatusServiceUnavailable, "unavailable"), wantErr: true},
+		{name: "bad PromQL", handler: apiError(http.StatusBadRequest, "bad_data"), wantUnknown: true},
+		{name: "execution error", handler: apiError(http.StatusUnprocessableEntity, "execution"), wantUnknown: true},
+		{name: "no data", handler: func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		}, wantUnknown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := tt.handler
+			if handler == nil {
+				handler = func(w http.ResponseWriter, r *http.Request) {}
+			}
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+			client, err := prometheus.New(prometheus.Config{URL: srv.URL, Timeout: 5 * time.Second})
+			if err != nil {
+				t.Fatalf("prometheus.New: %v", err)
+			}
+			if tt.handler == nil {
+				srv.Close() // every query now fails to connect
+			}
+
+			result, err := New(client).EvaluateOnce(context.Background(), promCriterion("height", "height"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Passed {
+				t.Errorf("Passed = true (%s)", result.Message)
+			}
+			if result.Unknown != tt.wantUnknown {
+				t.Errorf("Unknown = %v, want %v (%s)", result.Unknown, tt.wantUnknown, result.Message)
+			}
+			wantFailures := 0
+			if tt.wantErr {
+				wantFailures = 1
+			}
+			if result.Failures != wantFailures {
+				t.Errorf("Failures = %d, want %d", result.Failures, wantFailures)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// newFakePrometheus serves /api/v1/query, answering each query with a single
+// sample whose value is looked up in values.
+func newFakePrometheus(t *testing.T, values map[string]float64) *prometheus.Client {
+	return newFakePrometheusFunc(t, func(query string) (float64, bool) {
+		v, ok := values[query]
+		return v, ok
+	})
+}
+
+// newFakePrometheusFunc is newFakePrometheus with a callback; returning
+// ok=false yields an empty vector (no series).
+func newFakePrometheusFunc(t *testing.T, lookup func(query string) (float64, bool)) *prometheus.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		v, ok := lookup(r.Form.Get("query"))
+		if !ok {
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[%d,"%g"]}]}}`,
+			time.Now().Unix(), v)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := prometheus.New(prometheus.Config{URL: srv.URL, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("prometheus.New: %v", err)
+	}
+	return client
+}
+
+func promCriterion(name, query string) scenario.SuccessCriterion {
+	return scenario.SuccessCriterion{Name: name, Type: "prometheus", Query: query, Threshold: "> 0"}
+}
+
+func TestEvaluateComposite(t *testing.T) {
+	fd := New(newFakePrometheus(t, map[string]float64{"good": 1, "bad": 0}))
+
+	good := promCriterion("good", "good")
+	bad := promCriterion("bad", "bad")
+
+	tests := []struct {
+		name      string
+		criterion scenario.SuccessCriterion
+		want      bool
+		wantValue float64
+	}{
+		{"all_of all pass", scenario.SuccessCriterion{AllOf: []scenario.SuccessCriterion{good, good}}, true, 2},
+		{"all_of one fails", scenario.SuccessCriterion{AllOf: []scenario.SuccessCriterion{good, bad}}, false, 1},
+		{"any_of one passes", scenario.SuccessCriterion{AnyOf: []scenario.SuccessCriterion{bad, good}}, true, 1},
+		{"any_of none pass", scenario.SuccessCriterion{AnyOf: []scenario.SuccessCriterion{bad, bad}}, false, 0},
+		{
+			"nested (A or B) and C",
+			scenario.SuccessCriterion{AllOf: []scenario.SuccessCriterion{
+				{Type: "composite", AnyOf: []scenario.SuccessCriterion{bad, good}},
+				good,
+			}},
+			true, 2,
+		},
+		{
+			"weighted meets min_score",
+			scenario.SuccessCriterion{MinScore: 3, Weighted: []scenario.SuccessCriterion{
+				withWeight(good, 3), withWeight(bad, 1),
+			}},
+			true, 3,
+		},
+		{
+			"weighted below min_score",
+			scenario.SuccessCriterion{MinScore: 3, Weighted: []scenario.SuccessCriterion{
+				withWeight(bad, 3), good,
+			}},
+			false, 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.criterion.Name = tt.name
+			tt.criterion.Type = "composite"
+			result, err := fd.EvaluateOnce(context.Background(), tt.criterion)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Passed != tt.want {
+				t.Errorf("Passed = %v, want %v (%s)", result.Passed, tt.want, result.Message)
+			}
+			if result.LastValue != tt.wantValue {
+				t.Errorf("LastValue = %v, want %v", result.LastValue, tt.wantValue)
+			}
+		})
+	}
+
+	if len(fd.GetResults()) != 0 {
+		t.Errorf("composite children must not be recorded in detector results, got %d", len(fd.GetResults()))
+	}
+}
+
+func withWeight(c scenario.SuccessCriterion, w float64) scenario.SuccessCriterion {
+	c.Weight = w
+	return c
+}
@@ -10,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 
 	dockertypes "github.com/docker/docker/api/types"
 
+	"github.com/jihwankim/chaos-utils/pkg/config"
 	"github.com/jihwankim/chaos-utils/pkg/discovery/docker"
 	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
 	"github.com/jihwankim/chaos-utils/pkg/scenario"
@@ -37,12 +39,30 @@ type FailureDetector struct {
 	logSince     time.Time
 	results      map[string]*CriterionResult
 	mu           sync.RWMutex
+	// blindSpots makes Prometheus criteria report Unknown when a scrape
+	// target their query reads from is down (see DetectBlindSpots).
+	blindSpots bool
+	// rpc evaluates rpc criteria; nil unless built with NewWithRPC.
+	rpc *rpcClient
+	// heimdallAPI is the Heimdall REST base URL heimdall_api criteria
+	// query; empty until SetHeimdallAPI.
+	heimdallAPI string
+	// stateRootPattern is the container pattern state_root_consensus
+	// criteria use when they set none: the deployment profile's Bor
+	// validators (see SetProfile).
+	stateRootPattern string
 }
 
 // CriterionResult represents the evaluation result of a success criterion
 type CriterionResult struct {
-	Criterion   scenario.SuccessCriterion
-	Passed      bool
+	Criterion scenario.SuccessCriterion
+	Passed    bool
+	// Unknown means the criterion could not be judged: its query was
+	// rejected, returned no data or read from a scrape target that was
+	// down. Passed is false unless the criterion expected this (expect:
+	// unknown); the orchestrator applies the scenario's treat_unknown_as
+	// policy on top.
+	Unknown     bool
 	LastValue   float64
 	LastChecked time.Time
 	Evaluations int
@@ -52,16 +72,58 @@ type CriterionResult struct {
 	// evaluation. >1 means the query did not aggregate and the detector
 	// reduced them deterministically (see evaluatePrometheus).
 	SeriesCount int
+	// History holds one sample per evaluation, oldest first, so reports
+	// can show how a criterion trended rather than only its final value.
+	History []EvaluationSample
+}
+
+// EvaluationSample is a single evaluation outcome.
+type EvaluationSample struct {
+	Time   time.Time
+	Value  float64
+	Passed bool
+}
+
+// maxHistory caps History so a long soak with a 15s sampler does not grow
+// the report without bound. The oldest samples are dropped first.
+const maxHistory = 500
+
+// record appends the current outcome to History.
+func (r *CriterionResult) record() {
+	r.History = append(r.History, EvaluationSample{
+		Time:   r.LastChecked,
+		Value:  r.LastValue,
+		Passed: r.Passed,
+	})
+	if len(r.History) > maxHistory {
+		r.History = r.History[len(r.History)-maxHistory:]
+	}
 }
 
 // New creates a new failure detector
 func New(promClient *prometheus.Client) *FailureDetector {
 	return &FailureDetector{
-		promClient: promClient,
-		results:    make(map[string]*CriterionResult),
+		promClient:       promClient,
+		results:          make(map[string]*CriterionResult),
+		stateRootPattern: config.DefaultProfile().ELContainerPattern,
 	}
 }
 
+// SetProfile takes the defaults that depend on the deployment's naming
+// from p.
+func (fd *FailureDetector) SetProfile(p *config.Profile) {
+	fd.stateRootPattern = p.ELContainerPattern
+}
+
+// DetectBlindSpots turns on scrape-health checking for Prometheus
+// criteria. Before each query the detector looks up `up` for the jobs the
+// query selects; if any is down, the criterion is reported Unknown instead
+// of being judged on whatever partial or stale series remain. The
+// orchestrator enables it when a scenario degrades the monitoring path.
+func (fd *FailureDetector) DetectBlindSpots(enabled bool) {
+	fd.blindSpots = enabled
+}
+
 // SetLogContext configures the detector for log-based criteria evaluation.
 func (fd *FailureDetector) SetLogContext(dockerClient *docker.Client, targets []LogTarget, since time.Time) {
 	fd.dockerClient = dockerClient
@@ -84,21 +146,15 @@ func (fd *FailureDetector) EvaluateOnce(ctx context.Context, criterion scenario.
 		Evaluations: 1,
 	}
 
-	switch criterion.Type {
-	case "prometheus":
-		return fd.evaluatePrometheus(ctx, criterion, result)
-	case "log":
-		return fd.evaluateLog(ctx, criterion, result)
-	case "state_root_consensus":
-		return fd.evaluateStateRootConsensus(ctx, criterion, result)
-	default:
-		result.Passed = false
-		result.Message = fmt.Sprintf("unsupported criterion type: %s", criterion.Type)
-		return result, fmt.Errorf("unsupported criterion type: %s", criterion.Type)
-	}
+	result, err := fd.evaluateByType(ctx, criterion, result)
+	result.record()
+	return result, err
 }
 
-// Evaluate evaluates a single success criterion
+// Evaluate evaluates a single success criterion. When the criterion sets
+// retries, the evaluation is repeated up to 1+retries times at
+// retry_interval and passes once required_passes attempts have passed —
+// see evaluateWithRetries.
 func (fd *FailureDetector) Evaluate(ctx context.Context, criterion scenario.SuccessCriterion) (*CriterionResult, error) {
 	result := &CriterionResult{
 		Criterion:   criterion,
@@ -115,9 +171,49 @@ func (fd *FailureDetector) Evaluate(ctx context.Context, criterion scenario.Succ
 	}
 	fd.mu.Unlock()
 
+	if criterion.Retries > 0 {
+		return fd.evaluateWithRetries(ctx, criterion, result)
+	}
+
 	result.Evaluations++
+	result, err := fd.evaluateByType(ctx, criterion, result)
+	result.record()
+	return result, err
+}
+
+// evaluateByType dispatches to the per-type evaluator, then applies the
+// criterion's expect inversion.
+func (fd *FailureDetector) evaluateByType(ctx context.Context, criterion scenario.SuccessCriterion, result *CriterionResult) (*CriterionResult, error) {
+	result.Unknown = false
+	result, err := fd.dispatch(ctx, criterion, result)
+	if err != nil {
+		return result, err
+	}
+	switch criterion.Expect {
+	case scenario.ExpectUnknown:
+		result.Passed = result.Unknown
+		if result.Unknown {
+			result.Message = "unknown as expected: " + result.Message
+		} else {
+			result.Message = "expected unknown, but the criterion was judged: " + result.Message
+		}
+	case scenario.ExpectFailure:
+		// An unknown criterion stays unknown: it shows nothing either way.
+		switch {
+		case result.Unknown:
+			result.Message = "expected failure, but the criterion could not be judged: " + result.Message
+		case result.Passed:
+			result.Passed = false
+			result.Message = "expected failure, but the criterion passed: " + result.Message
+		default:
+			result.Passed = true
+			result.Message = "failed as expected: " + result.Message
+		}
+	}
+	return result, err
+}
 
-	// Evaluate based on criterion type
+func (fd *FailureDetector) dispatch(ctx context.Context, criterion scenario.SuccessCriterion, result *CriterionResult) (*CriterionResult, error) {
 	switch criterion.Type {
 	case "prometheus":
 		return fd.evaluatePrometheus(ctx, criterion, result)
@@ -128,6 +224,15 @@ func (fd *FailureDetector) Evaluate(ctx context.Context, criterion scenario.Succ
 	case "state_root_consensus":
 		return fd.evaluateStateRootConsensus(ctx, criterion, result)
 
+	case "rpc":
+		return fd.evaluateRPC(ctx, criterion, result)
+
+	case "heimdall_api":
+		return fd.evaluateHeimdallAPI(ctx, criterion, result)
+
+	case "composite":
+		return fd.evaluateComposite(ctx, criterion, result)
+
 	default:
 		result.Passed = false
 		result.Message = fmt.Sprintf("unsupported criterion type: %s", criterion.Type)
@@ -135,6 +240,76 @@ func (fd *FailureDetector) Evaluate(ctx context.Context, criterion scenario.Succ
 	}
 }
 
+// defaultRetryInterval spaces retry attempts when retry_interval is unset.
+// One default Prometheus scrape interval, so consecutive attempts see a
+// fresh sample rather than the same gap.
+const defaultRetryInterval = 15 * time.Second
+
+// evaluateWithRetries runs up to 1+Retries attempts and requires
+// RequiredPasses (default 1) of them to pass. It stops early once the
+// quorum is reached or can no longer be reached. A single Prometheus scrape
+// gap ("query returned no results") or a transient query error therefore
+// costs one attempt instead of the whole run.
+//
+// An attempt that errors or comes out unknown counts as a failed attempt.
+// The result is Unknown only when the quorum was missed and no attempt was
+// judged; the error is only returned when every attempt errored — i.e. the
+// criterion could never be evaluated at all.
+func (fd *FailureDetector) evaluateWithRetries(ctx context.Context, criterion scenario.SuccessCriterion, result *CriterionResult) (*CriterionResult, error) {
+	attempts := criterion.Retries + 1
+	required := criterion.RequiredPasses
+	if required <= 0 {
+		required = 1
+	}
+	interval := criterion.RetryInterval
+	if interval <= 0 {
+		interval = defaultRetryInterval
+	}
+
+	passes, errored, unknown := 0, 0, 0
+	var lastErr error
+	var lastMessage string
+	tried := 0
+	for tried < attempts {
+		if tried > 0 {
+			select {
+			case <-ctx.Done():
+				result.Passed = false
+				result.Message = fmt.Sprintf("%d/%d passes (required %d) before cancellation: %s", passes, tried, required, lastMessage)
+				return result, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		tried++
+		result.Evaluations++
+		result.LastChecked = time.Now()
+		_, err := fd.evaluateByType(ctx, criterion, result)
+		result.record()
+		lastMessage = result.Message
+		if err != nil {
+			errored++
+			lastErr = err
+		} else if result.Passed {
+			passes++
+		} else if result.Unknown {
+			unknown++
+		}
+
+		if passes >= required || passes+(attempts-tried) < required {
+			break
+		}
+	}
+
+	result.Passed = passes >= required
+	result.Unknown = !result.Passed && unknown > 0 && unknown+errored == tried
+	result.Message = fmt.Sprintf("%d/%d attempts passed (required %d): %s", passes, tried, required, lastMessage)
+	if !result.Passed && errored == tried {
+		return result, lastErr
+	}
+	return result, nil
+}
+
 // evaluatePrometheus evaluates a Prometheus-based criterion
 func (fd *FailureDetector) evaluatePrometheus(ctx context.Context, criterion scenario.SuccessCriterion, result *CriterionResult) (*CriterionResult, error) {
 	if criterion.Query == "" {
@@ -143,22 +318,44 @@ func (fd *FailureDetector) evaluatePrometheus(ctx context.Context, criterion sce
 		return result, fmt.Errorf("query is empty")
 	}
 
-	// Execute query
+	if fd.blindSpots {
+		if down := fd.downScrapeTargets(ctx, criterion.Query); len(down) > 0 {
+			result.Passed = false
+			result.Unknown = true
+			result.Message = fmt.Sprintf("scrape target(s) down: %s — data for this query is missing or stale", strings.Join(down, ", "))
+			return result, nil
+		}
+	}
+
+	// Execute query. A query Prometheus rejects or fails to evaluate (bad
+	// PromQL, an execution error) says nothing about the system under test
+	// and is reported Unknown, as is one that returns no data below. Only a
+	// Prometheus that cannot be reached is an infrastructure error.
 	queryResults, err := fd.promClient.QueryLatest(ctx, criterion.Query)
 	if err != nil {
 		result.Passed = false
 		result.Message = fmt.Sprintf("query failed: %v", err)
+		if prometheus.IsQueryError(err) {
+			result.Unknown = true
+			return result, nil
+		}
 		result.Failures++
 		return result, err
 	}
 
+	// Explicit aggregation ("min > 0", "p95 < 2", "count >= 3") replaces
+	// the implicit worst-case reduction below.
+	if agg, cmp := splitAggregator(criterion.Threshold); agg != "" {
+		return fd.evaluateAggregated(criterion, result, queryResults, agg, cmp)
+	}
+
 	// Check if we got results
 	if len(queryResults) == 0 {
 		result.Passed = false
+		result.Unknown = true
 		result.LastValue = 0
 		result.SeriesCount = 0
 		result.Message = "query returned no results"
-		result.Failures++
 		return result, nil
 	}
 
@@ -251,6 +448,254 @@ func (fd *FailureDetector) evaluatePrometheus(ctx context.Context, criterion sce
 	return result, nil
 }
 
+// evaluateComposite evaluates an all_of / any_of / weighted group. Children
+// go through EvaluateOnce so they never appear in fd.results on their own —
+// only the composite is a reportable criterion. A child evaluation error
+// (e.g. Prometheus unreachable) aborts the composite just as it would a
+// standalone criterion; a child that merely fails its threshold does not.
+//
+// A child that is Unknown leaves the composite Unknown only when it could
+// have changed the outcome: all_of with no known failure, any_of with no
+// pass, or a weighted score that the unknown children could still lift
+// over min_score.
+//
+// LastValue is the number of passing children, or the weighted score for
+// weighted composites.
+func (fd *FailureDetector) evaluateComposite(ctx context.Context, criterion scenario.SuccessCriterion, result *CriterionResult) (*CriterionResult, error) {
+	children := criterion.Children()
+	if len(children) == 0 {
+		result.Passed = false
+		result.Message = "composite criterion has no children"
+		result.Failures++
+		return result, fmt.Errorf("composite criterion %q has no children", criterion.Name)
+	}
+
+	passed, unknown := 0, 0
+	score, total, unknownWeight := 0.0, 0.0, 0.0
+	parts := make([]string, 0, len(children))
+	for i, child := range children {
+		label := child.Name
+		if label == "" {
+			label = fmt.Sprintf("#%d", i+1)
+		}
+
+		childResult, err := fd.EvaluateOnce(ctx, child)
+		if err != nil {
+			result.Passed = false
+			result.Message = fmt.Sprintf("child %s evaluation failed: %v", label, err)
+			result.Failures++
+			return result, fmt.Errorf("composite %q child %s: %w", criterion.Name, label, err)
+		}
+
+		weight := child.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		total += weight
+
+		mark := "✗"
+		switch {
+		case childResult.Passed:
+			mark = "✓"
+			passed++
+			score += weight
+		case childResult.Unknown:
+			mark = "?"
+			unknown++
+			unknownWeight += weight
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", mark, label))
+	}
+
+	switch {
+	case len(criterion.AllOf) > 0:
+		result.Passed = passed == len(children)
+		result.Unknown = !result.Passed && passed+unknown == len(children)
+		result.LastValue = float64(passed)
+		result.Message = fmt.Sprintf("all_of: %d/%d passed [%s]", passed, len(children), strings.Join(parts, ", "))
+	case len(criterion.AnyOf) > 0:
+		result.Passed = passed > 0
+		result.Unknown = !result.Passed && unknown > 0
+		result.LastValue = float64(passed)
+		result.Message = fmt.Sprintf("any_of: %d/%d passed [%s]", passed, len(children), strings.Join(parts, ", "))
+	default:
+		result.Passed = score >= criterion.MinScore
+		result.Unknown = !result.Passed && score+unknownWeight >= criterion.MinScore
+		result.LastValue = score
+		result.Message = fmt.Sprintf("weighted: score %.2f/%.2f (min %.2f) [%s]", score, total, criterion.MinScore, strings.Join(parts, ", "))
+	}
+
+	if !result.Passed {
+		result.Failures++
+	}
+	return result, nil
+}
+
+// jobMatcher finds the job label matchers in a PromQL query.
+var jobMatcher = regexp.MustCompile(`\bjob\s*(=~|=)\s*"([^"]*)"`)
+
+// downScrapeTargets returns the jobs with a down scrape target among those
+// query selects by job label. A query that selects no job is checked
+// against every target, since any of them may feed it. Errors looking up
+// `up` are ignored — the criterion's own query surfaces them.
+func (fd *FailureDetector) downScrapeTargets(ctx context.Context, query string) []string {
+	selectors := []string{"up == 0"}
+	if matches := jobMatcher.FindAllStringSubmatch(query, -1); len(matches) > 0 {
+		selectors = selectors[:0]
+		seen := make(map[string]bool)
+		for _, m := range matches {
+			sel := fmt.Sprintf(`up{job%s"%s"} == 0`, m[1], m[2])
+			if !seen[sel] {
+				seen[sel] = true
+				selectors = append(selectors, sel)
+			}
+		}
+	}
+
+	var down []string
+	seen := make(map[string]bool)
+	for _, sel := range selectors {
+		results, err := fd.promClient.QueryLatest(ctx, sel)
+		if err != nil {
+			continue
+		}
+		for _, r := range results {
+			target := r.Labels["job"]
+			if instance := r.Labels["instance"]; instance != "" {
+				target += "/" + instance
+			}
+			if !seen[target] {
+				seen[target] = true
+				down = append(down, target)
+			}
+		}
+	}
+	sort.Strings(down)
+	return down
+}
+
+// thresholdAggregators are the reducers accepted as a threshold prefix, in
+// addition to percentiles written as pNN (p50, p95, p99.9).
+var thresholdAggregators = map[string]bool{
+	"min": true, "max": true, "avg": true, "sum": true, "count": true,
+}
+
+// splitAggregator splits "p95 < 2" into ("p95", "< 2"). A threshold with no
+// recognised aggregator prefix returns ("", threshold) unchanged.
+func splitAggregator(threshold string) (string, string) {
+	t := strings.TrimSpace(threshold)
+	i := strings.IndexAny(t, "<>=!")
+	if i <= 0 {
+		return "", threshold
+	}
+	agg := strings.ToLower(strings.TrimSpace(t[:i]))
+	if thresholdAggregators[agg] {
+		return agg, t[i:]
+	}
+	if _, ok := parsePercentile(agg); ok {
+		return agg, t[i:]
+	}
+	return "", threshold
+}
+
+// parsePercentile parses "p95" → 95. Valid range is (0, 100].
+func parsePercentile(agg string) (float64, bool) {
+	if !strings.HasPrefix(agg, "p") {
+		return 0, false
+	}
+	p, err := strconv.ParseFloat(agg[1:], 64)
+	if err != nil || p <= 0 || p > 100 {
+		return 0, false
+	}
+	return p, true
+}
+
+// aggregateValues reduces sample values with the named aggregator. count
+// is defined for an empty set (0); every other aggregator requires at least
+// one value.
+func aggregateValues(values []float64, agg string) (float64, error) {
+	if agg == "count" {
+		return float64(len(values)), nil
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("no series to aggregate")
+	}
+
+	switch agg {
+	case "min":
+		m := values[0]
+		for _, v := range values[1:] {
+			m = math.Min(m, v)
+		}
+		return m, nil
+	case "max":
+		m := values[0]
+		for _, v := range values[1:] {
+			m = math.Max(m, v)
+		}
+		return m, nil
+	case "sum", "avg":
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		if agg == "avg" {
+			return total / float64(len(values)), nil
+		}
+		return total, nil
+	}
+
+	p, ok := parsePercentile(agg)
+	if !ok {
+		return 0, fmt.Errorf("unknown aggregator %q", agg)
+	}
+	// Linear interpolation between closest ranks.
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo)), nil
+}
+
+// evaluateAggregated evaluates a threshold with an explicit aggregator
+// across every returned series.
+func (fd *FailureDetector) evaluateAggregated(criterion scenario.SuccessCriterion, result *CriterionResult, queryResults []prometheus.QueryResult, agg, cmp string) (*CriterionResult, error) {
+	result.SeriesCount = len(queryResults)
+
+	values := make([]float64, len(queryResults))
+	for i, qr := range queryResults {
+		values[i] = qr.Value
+	}
+
+	value, err := aggregateValues(values, agg)
+	if err != nil {
+		result.Passed = false
+		result.Unknown = true
+		result.LastValue = 0
+		result.Message = fmt.Sprintf("query returned no results (cannot compute %s)", agg)
+		return result, nil
+	}
+	result.LastValue = value
+
+	passed, err := fd.evaluateThreshold(value, cmp)
+	if err != nil {
+		result.Passed = false
+		result.Message = fmt.Sprintf("threshold evaluation failed: %v", err)
+		result.Failures++
+		return result, err
+	}
+
+	result.Passed = passed
+	if passed {
+		result.Message = fmt.Sprintf("%s across %d series = %.4f meets %s", agg, len(values), value, strings.TrimSpace(cmp))
+	} else {
+		result.Message = fmt.Sprintf("%s across %d series = %.4f does not meet %s", agg, len(values), value, strings.TrimSpace(cmp))
+		result.Failures++
+	}
+	return result, nil
+}
+
 // aggregateSeries reduces a multi-sample Prometheus result to a single value
 // using worst-case semantics for the threshold direction. For `<`/`<=` it
 // returns the max; for `>`/`>=` it returns the min; for `==`/`!=` (or any
@@ -327,13 +772,13 @@ func (fd *FailureDetector) evaluateLog(ctx context.Context, criterion scenario.S
 
 	if len(targets) == 0 {
 		result.Passed = false
+		result.Unknown = true
 		result.Message = "no targets available for log scanning"
-		result.Failures++
 		return result, nil
 	}
 
 	// Scan logs from each target
-	totalMatches := 0
+	totalMatches, scanned := 0, 0
 	var matchExamples []string
 
 	for _, target := range targets {
@@ -344,6 +789,7 @@ func (fd *FailureDetector) evaluateLog(ctx context.Context, criterion scenario.S
 			fmt.Printf("    [log] warning: failed to fetch logs from %s: %v\n", target.Name, err)
 			continue
 		}
+		scanned++
 
 		for _, line := range lines {
 			if re.MatchString(line) {
@@ -360,6 +806,13 @@ func (fd *FailureDetector) evaluateLog(ctx context.Context, criterion scenario.S
 		}
 	}
 
+	if scanned == 0 {
+		result.Passed = false
+		result.Unknown = true
+		result.Message = fmt.Sprintf("could not read logs from any of %d target(s)", len(targets))
+		return result, nil
+	}
+
 	result.LastValue = float64(totalMatches)
 
 	if criterion.Threshold != "" {
@@ -481,7 +934,13 @@ func (fd *FailureDetector) evaluateStateRootConsensus(ctx context.Context, crite
 
 	pattern := criterion.ContainerPattern
 	if pattern == "" {
-		pattern = "bor-heimdall-v2-validator"
+		pattern = fd.stateRootPattern
+	}
+	if pattern == "" {
+		result.Passed = false
+		result.Message = "container_pattern is required: the deployment profile has no Bor validators"
+		result.Failures++
+		return result, nil
 	}
 
 	// Discover target containers
@@ -805,7 +1264,9 @@ func jsonRPCCall(ctx context.Context, rpcURL, body string) (map[string]interface
 }
 
 // evaluateThreshold parses and evaluates a threshold expression
-// Supports: "> 0", "< 100", ">= 50", "<= 75", "== 0", "!= 0"
+// Supports: "> 0", "< 100", ">= 50", "<= 75", "== 0", "!= 0".
+// Aggregator prefixes ("min > 0", "p95 < 2") are handled by the caller —
+// see splitAggregator.
 func (fd *FailureDetector) evaluateThreshold(value float64, threshold string) (bool, error) {
 	threshold = strings.TrimSpace(threshold)
 
@@ -1,7 +1,12 @@
 package detector
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
 )
 
 func TestEvaluateThreshold(t *testing.T) {
@@ -70,3 +75,122 @@ func TestEvaluateThreshold(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluateWithRetries(t *testing.T) {
+	// "flaky" has no series on the first scrape (simulated gap), then 1.
+	// "down" is always 0. Any other query has no series at all.
+	var calls int
+	client := newFakePrometheusFunc(t, func(query string) (float64, bool) {
+		switch query {
+		case "flaky":
+			calls++
+			return 1, calls > 1
+		case "down":
+			return 0, true
+		}
+		return 0, false
+	})
+
+	tests := []struct {
+		name        string
+		criterion   scenario.SuccessCriterion
+		want        bool
+		wantUnknown bool
+		wantEvals   int
+	}{
+		{"no retries is unknown on scrape gap", scenario.SuccessCriterion{Query: "flaky"}, false, true, 1},
+		{"one retry recovers from gap", scenario.SuccessCriterion{Query: "flaky", Retries: 1}, true, false, 2},
+		{"stops early once quorum reached", scenario.SuccessCriterion{Query: "flaky", Retries: 4}, true, false, 2},
+		{"required passes not met", scenario.SuccessCriterion{Query: "down", Retries: 2, RequiredPasses: 2}, false, false, 2},
+		{"no data on every attempt is unknown", scenario.SuccessCriterion{Query: "missing", Retries: 2}, false, true, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			fd := New(client)
+			tt.criterion.Name = tt.name
+			tt.criterion.Type = "prometheus"
+			tt.criterion.Threshold = "> 0"
+			tt.criterion.RetryInterval = time.Millisecond
+
+			result, err := fd.Evaluate(context.Background(), tt.criterion)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Passed != tt.want || result.Unknown != tt.wantUnknown {
+				t.Errorf("Passed = %v, Unknown = %v, want %v, %v (%s)", result.Passed, result.Unknown, tt.want, tt.wantUnknown, result.Message)
+			}
+			if result.Evaluations != tt.wantEvals {
+				t.Errorf("Evaluations = %d, want %d", result.Evaluations, tt.wantEvals)
+			}
+		})
+	}
+}
+
+func TestSplitAggregator(t *testing.T) {
+	tests := []struct {
+		threshold string
+		agg       string
+		rest      string
+	}{
+		{"> 0", "", "> 0"},
+		{"min > 0", "min", "> 0"},
+		{"p95 < 2", "p95", "< 2"},
+		{"count >= 3", "count", ">= 3"},
+		{"AVG<=1.5", "avg", "<=1.5"},
+		{"p0 > 1", "", "p0 > 1"},
+		{"median > 1", "", "median > 1"},
+	}
+	for _, tt := range tests {
+		agg, rest := splitAggregator(tt.threshold)
+		if agg != tt.agg || rest != tt.rest {
+			t.Errorf("splitAggregator(%q) = (%q, %q), want (%q, %q)", tt.threshold, agg, rest, tt.agg, tt.rest)
+		}
+	}
+}
+
+func TestAggregateValues(t *testing.T) {
+	values := []float64{4, 1, 3, 2, 5}
+	tests := []struct {
+		agg  string
+		want float64
+	}{
+		{"min", 1},
+		{"max", 5},
+		{"sum", 15},
+		{"avg", 3},
+		{"count", 5},
+		{"p50", 3},
+		{"p100", 5},
+		{"p25", 2},
+	}
+	for _, tt := range tests {
+		got, err := aggregateValues(values, tt.agg)
+		if err != nil {
+			t.Fatalf("aggregateValues(%q) error: %v", tt.agg, err)
+		}
+		if got != tt.want {
+			t.Errorf("aggregateValues(%q) = %v, want %v", tt.agg, got, tt.want)
+		}
+	}
+
+	if got, err := aggregateValues(nil, "count"); err != nil || got != 0 {
+		t.Errorf("count of empty set = (%v, %v), want (0, nil)", got, err)
+	}
+	if _, err := aggregateValues(nil, "min"); err == nil {
+		t.Error("min of empty set should error")
+	}
+}
+
+func TestSetProfile_StateRootPattern(t *testing.T) {
+	fd := New(nil)
+	if fd.stateRootPattern != "bor-heimdall-v2-validator" {
+		t.Errorf("default state_root pattern = %q, want the heimdall-v2 Bor validators", fd.stateRootPattern)
+	}
+	v1, _ := config.LookupProfile("pos-heimdall-v1")
+	fd.SetProfile(v1)
+	if fd.stateRootPattern != "bor-heimdall-validator" {
+		t.Errorf("pos-heimdall-v1 state_root pattern = %q, want bor-heimdall-validator", fd.stateRootPattern)
+	}
+}
@@ -0,0 +1,108 @@
+package detector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// heimdallTimeout bounds each Heimdall REST request.
+const heimdallTimeout = 10 * time.Second
+
+// SetHeimdallAPI sets the Heimdall REST API base URL heimdall_api criteria
+// query. Without it they are reported unknown.
+func (fd *FailureDetector) SetHeimdallAPI(url string) {
+	fd.heimdallAPI = strings.TrimRight(url, "/")
+}
+
+// evaluateHeimdallAPI GETs criterion.Path from the Heimdall REST API and
+// compares the value at criterion.Field against the threshold. Heimdall
+// may itself be a chaos target, so a request that fails or a value that is
+// absent leaves the criterion unknown, as for rpc criteria.
+func (fd *FailureDetector) evaluateHeimdallAPI(ctx context.Context, criterion scenario.SuccessCriterion, result *CriterionResult) (*CriterionResult, error) {
+	if fd.heimdallAPI == "" {
+		result.Passed = false
+		result.Unknown = true
+		result.Message = "no Heimdall API endpoint discovered"
+		return result, nil
+	}
+
+	subject := criterion.Path
+	if criterion.Field != "" {
+		subject += " " + criterion.Field
+	}
+
+	raw, err := fd.heimdallGet(ctx, criterion.Path)
+	if err != nil {
+		result.Passed = false
+		result.Unknown = true
+		result.Message = fmt.Sprintf("%s failed: %v", criterion.Path, err)
+		return result, nil
+	}
+
+	value, ok, err := rpcValue(raw, criterion.Field)
+	if err != nil {
+		result.Passed = false
+		result.Message = fmt.Sprintf("%s: %v", criterion.Path, err)
+		result.Failures++
+		return result, err
+	}
+	if !ok {
+		result.Passed = false
+		result.Unknown = true
+		result.Message = fmt.Sprintf("%s is absent", subject)
+		return result, nil
+	}
+	result.LastValue = value
+
+	passed, err := fd.evaluateThreshold(value, criterion.Threshold)
+	if err != nil {
+		result.Passed = false
+		result.Message = fmt.Sprintf("threshold evaluation failed: %v", err)
+		result.Failures++
+		return result, err
+	}
+
+	result.Passed = passed
+	if passed {
+		result.Message = fmt.Sprintf("%s = %.2f meets threshold %s", subject, value, criterion.Threshold)
+	} else {
+		result.Message = fmt.Sprintf("%s = %.2f does not meet threshold %s", subject, value, criterion.Threshold)
+		result.Failures++
+	}
+	return result, nil
+}
+
+// heimdallGet fetches path from the Heimdall REST API.
+func (fd *FailureDetector) heimdallGet(ctx context.Context, path string) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, heimdallTimeout)
+	defer cancel()
+
+	url := fd.heimdallAPI + "/" + strings.TrimLeft(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body from %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned HTTP %d", url, resp.StatusCode)
+	}
+	return data, nil
+}
@@ -0,0 +1,65 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+func TestEvaluateHeimdallAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/checkpoints/latest":
+			fmt.Fprint(w, `{"checkpoint":{"id":"42","end_block":"12800"}}`)
+		case "/stake/validators-set":
+			fmt.Fprint(w, `{"validator_set":{"validators":[{"val_id":"1"},{"val_id":"2"},{"val_id":"3"},{"val_id":"4"}]}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	withAPI := func(url string) *FailureDetector {
+		fd := New(nil)
+		fd.SetHeimdallAPI(url)
+		return fd
+	}
+	heimdallCriterion := func(path, field, threshold string) scenario.SuccessCriterion {
+		return scenario.SuccessCriterion{Name: "heimdall", Type: "heimdall_api", Path: path, Field: field, Threshold: threshold}
+	}
+
+	tests := []struct {
+		name        string
+		fd          *FailureDetector
+		criterion   scenario.SuccessCriterion
+		wantPassed  bool
+		wantUnknown bool
+		wantValue   float64
+	}{
+		{"meets threshold", withAPI(srv.URL + "/"), heimdallCriterion("/checkpoints/latest", "checkpoint.end_block", "> 12000"), true, false, 12800},
+		{"misses threshold", withAPI(srv.URL), heimdallCriterion("/checkpoints/latest", "checkpoint.id", "> 50"), false, false, 42},
+		{"validator count", withAPI(srv.URL), heimdallCriterion("/stake/validators-set", "validator_set.validators.length()", ">= 4"), true, false, 4},
+		{"absent field is unknown", withAPI(srv.URL), heimdallCriterion("/checkpoints/latest", "checkpoint.proposer", "> 0"), false, true, 0},
+		{"HTTP error is unknown", withAPI(srv.URL), heimdallCriterion("/bor/spans/latest", "span.id", "> 0"), false, true, 0},
+		{"no endpoint is unknown", New(nil), heimdallCriterion("/checkpoints/latest", "checkpoint.id", "> 0"), false, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fd.EvaluateOnce(context.Background(), tt.criterion)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Passed != tt.wantPassed || result.Unknown != tt.wantUnknown {
+				t.Errorf("Passed = %v, Unknown = %v, want %v, %v (%s)", result.Passed, result.Unknown, tt.wantPassed, tt.wantUnknown, result.Message)
+			}
+			if result.LastValue != tt.wantValue {
+				t.Errorf("LastValue = %v, want %v", result.LastValue, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package detector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// RPCConfig configures the EVM JSON-RPC client rpc criteria use.
+type RPCConfig struct {
+	// Endpoints are tried in order. A call fails over to the next when an
+	// endpoint is unreachable, times out or answers with an HTTP error —
+	// the primary RPC node may itself be a chaos target.
+	Endpoints []string
+	// Timeout bounds each call to one endpoint (default 10s).
+	Timeout time.Duration
+	// Retries re-sends a failed call to the same endpoint this many times
+	// before failing over.
+	Retries int
+	// Username and Password set HTTP basic auth when Username is non-empty.
+	Username string
+	Password string
+}
+
+// rpcRetryDelay spaces retries against the same endpoint.
+const rpcRetryDelay = time.Second
+
+// rpcClient calls the EVM JSON-RPC endpoints rpc criteria are evaluated
+// against.
+type rpcClient struct {
+	cfg        RPCConfig
+	httpClient *http.Client
+}
+
+// errJSONRPC wraps an error the node itself returned. The endpoint
+// answered, so the call does not fail over.
+var errJSONRPC = errors.New("JSON-RPC error")
+
+// NewWithRPC creates a detector that can also evaluate rpc criteria against
+// the EVM JSON-RPC endpoints in cfg.
+func NewWithRPC(promClient *prometheus.Client, cfg RPCConfig) *FailureDetector {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	fd := New(promClient)
+	fd.rpc = &rpcClient{
+		cfg:        cfg,
+		httpClient: &http.Client{},
+	}
+	return fd
+}
+
+// call sends one JSON-RPC request, failing over across endpoints, and
+// returns its raw result and the endpoint that answered.
+func (c *rpcClient) call(ctx context.Context, method string, params []interface{}) (json.RawMessage, string, error) {
+	if len(c.cfg.Endpoints) == 0 {
+		return nil, "", fmt.Errorf("no RPC endpoints configured")
+	}
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode params: %w", err)
+	}
+
+	var failures []string
+	for _, endpoint := range c.cfg.Endpoints {
+		for attempt := 0; attempt <= c.cfg.Retries; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return nil, "", ctx.Err()
+				case <-time.After(rpcRetryDelay):
+				}
+			}
+			result, err := c.post(ctx, endpoint, body)
+			if err == nil || errors.Is(err, errJSONRPC) {
+				return result, endpoint, err
+			}
+			if ctx.Err() != nil {
+				return nil, "", ctx.Err()
+			}
+			if attempt == c.cfg.Retries {
+				failures = append(failures, err.Error())
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("all %d RPC endpoint(s) failed: %s", len(c.cfg.Endpoints), strings.Join(failures, "; "))
+}
+
+// post sends body to one endpoint under the per-call timeout.
+func (c *rpcClient) post(ctx context.Context, endpoint string, body []byte) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.cfg.Username != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC request to %s failed: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body from %s: %w", endpoint, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("RPC endpoint %s returned HTTP %d", endpoint, resp.StatusCode)
+	}
+
+	var decoded struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON-RPC response from %s: %w", endpoint, err)
+	}
+	if decoded.Error != nil {
+		return nil, fmt.Errorf("%w %d: %s", errJSONRPC, decoded.Error.Code, decoded.Error.Message)
+	}
+	return decoded.Result, nil
+}
+
+// evaluateRPC calls criterion.Method and compares its numeric result
+// against the threshold. A call that fails or returns null leaves the
+// criterion unknown, as a failed Prometheus query does.
+func (fd *FailureDetector) evaluateRPC(ctx context.Context, criterion scenario.SuccessCriterion, result *CriterionResult) (*CriterionResult, error) {
+	if fd.rpc == nil {
+		result.Passed = false
+		result.Unknown = true
+		result.Message = "no EVM RPC endpoint configured (set rpc.url or run --rpc-url)"
+		return result, nil
+	}
+
+	raw, endpoint, err := fd.rpc.call(ctx, criterion.Method, criterion.Params)
+	if err != nil {
+		result.Passed = false
+		result.Unknown = true
+		result.Message = fmt.Sprintf("%s failed: %v", criterion.Method, err)
+		return result, nil
+	}
+	// Name the endpoint when the primary did not answer, so a report shows
+	// the criterion was judged by a fallback.
+	via := ""
+	if endpoint != fd.rpc.cfg.Endpoints[0] {
+		via = fmt.Sprintf(" (via fallback %s)", endpoint)
+	}
+
+	value, ok, err := rpcValue(raw, criterion.Field)
+	if err != nil {
+		result.Passed = false
+		result.Message = fmt.Sprintf("%s: %v", criterion.Method, err)
+		result.Failures++
+		return result, err
+	}
+	if !ok {
+		result.Passed = false
+		result.Unknown = true
+		result.Message = fmt.Sprintf("%s returned null%s", criterion.Method, via)
+		return result, nil
+	}
+	result.LastValue = value
+
+	passed, err := fd.evaluateThreshold(value, criterion.Threshold)
+	if err != nil {
+		result.Passed = false
+		result.Message = fmt.Sprintf("threshold evaluation failed: %v", err)
+		result.Failures++
+		return result, err
+	}
+
+	result.Passed = passed
+	if passed {
+		result.Message = fmt.Sprintf("%s = %.2f meets threshold %s%s", criterion.Method, value, criterion.Threshold, via)
+	} else {
+		result.Message = fmt.Sprintf("%s = %.2f does not meet threshold %s%s", criterion.Method, value, criterion.Threshold, via)
+		result.Failures++
+	}
+	return result, nil
+}
+
+// rpcValue reads a JSON-RPC result, or a Heimdall REST response, as a
+// number: a hex quantity ("0x1a"), a decimal string, a JSON number, or a
+// boolean (1/0). field selects a value by JSON path (see lookupField). ok
+// is false for a null result.
+func rpcValue(raw json.RawMessage, field string) (value float64, ok bool, err error) {
+	var v interface{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return 0, false, fmt.Errorf("failed to decode result: %w", err)
+		}
+	}
+
+	v, err = lookupField(v, field)
+	if err != nil {
+		return 0, false, err
+	}
+
+	switch x := v.(type) {
+	case nil:
+		return 0, false, nil
+	case bool:
+		if x {
+			return 1, true, nil
+		}
+		return 0, true, nil
+	case float64:
+		return x, true, nil
+	case string:
+		if strings.HasPrefix(x, "0x") || strings.HasPrefix(x, "0X") {
+			n, err := strconv.ParseUint(x[2:], 16, 64)
+			if err != nil {
+				return 0, false, fmt.Errorf("result %q is not a hex quantity", x)
+			}
+			return float64(n), true, nil
+		}
+		f, err := strconv.ParseFloat(x, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("result %q is not numeric", x)
+		}
+		return f, true, nil
+	default:
+		return 0, false, fmt.Errorf("result is %T, not a number (set field to pick one out)", v)
+	}
+}
+
+// indexPattern matches one array index in a field path segment.
+var indexPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// lookupField walks v along a JSON path: dotted object keys, [n] array
+// indexes and a final length() for the size of an array, object or
+// string, with an optional leading "$.". For example
+// "span.selected_producers[0].val_id" or "validator_set.validators.length()".
+// A missing key or an index out of range yields nil.
+func lookupField(v interface{}, field string) (interface{}, error) {
+	path := strings.TrimPrefix(strings.TrimPrefix(field, "$"), ".")
+	if path == "" {
+		return v, nil
+	}
+	for _, segment := range strings.Split(path, ".") {
+		if v == nil {
+			return nil, nil
+		}
+		if segment == "length()" {
+			switch x := v.(type) {
+			case []interface{}:
+				v = float64(len(x))
+			case map[string]interface{}:
+				v = float64(len(x))
+			case string:
+				v = float64(len(x))
+			default:
+				return nil, fmt.Errorf("cannot take length() of %T in field %q", v, field)
+			}
+			continue
+		}
+
+		key, indexes := segment, ""
+		if i := strings.IndexByte(segment, '['); i >= 0 {
+			key, indexes = segment[:i], segment[i:]
+			if indexPattern.ReplaceAllString(indexes, "") != "" {
+				return nil, fmt.Errorf("field %q: malformed index in %q", field, segment)
+			}
+		}
+		if key != "" {
+			obj, isObj := v.(map[string]interface{})
+			if !isObj {
+				return nil, fmt.Errorf("result is not an object, cannot read field %q", field)
+			}
+			v = obj[key]
+		}
+		for _, m := range indexPattern.FindAllStringSubmatch(indexes, -1) {
+			if v == nil {
+				return nil, nil
+			}
+			arr, isArr := v.([]interface{})
+			if !isArr {
+				return nil, fmt.Errorf("result is not an array, cannot index field %q", field)
+			}
+			n, _ := strconv.Atoi(m[1])
+			if n >= len(arr) {
+				return nil, nil
+			}
+			v = arr[n]
+		}
+	}
+	return v, nil
+}
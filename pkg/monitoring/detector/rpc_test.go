@@ -0,0 +1,168 @@
+package detector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+func TestRPCValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		field   string
+		want    float64
+		wantOK  bool
+		wantErr bool
+	}{
+		{"hex quantity", `"0x1a"`, "", 26, true, false},
+		{"decimal string", `"42"`, "", 42, true, false},
+		{"number", `7`, "", 7, true, false},
+		{"true", `true`, "", 1, true, false},
+		{"false", `false`, "", 0, true, false},
+		{"null", `null`, "", 0, false, false},
+		{"field", `{"number":"0x10","hash":"0xab"}`, "number", 16, true, false},
+		{"nested field", `{"a":{"b":3}}`, "a.b", 3, true, false},
+		{"missing field", `{"number":"0x10"}`, "gasUsed", 0, false, false},
+		{"object without field", `{"number":"0x10"}`, "", 0, false, true},
+		{"field on scalar", `"0x10"`, "number", 0, false, true},
+		{"bad hex", `"0xzz"`, "", 0, false, true},
+		{"array index", `{"producers":[{"val_id":"4"},{"val_id":"7"}]}`, "producers[1].val_id", 7, true, false},
+		{"index out of range", `{"producers":[]}`, "producers[0].val_id", 0, false, false},
+		{"length", `{"validator_set":{"validators":[1,2,3]}}`, "$.validator_set.validators.length()", 3, true, false},
+		{"index on object", `{"producers":{"a":1}}`, "producers[0]", 0, false, true},
+		{"malformed index", `{"producers":[1]}`, "producers[x]", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := rpcValue(json.RawMessage(tt.raw), tt.field)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("rpcValue(%s, %q) = %v, %v, want %v, %v", tt.raw, tt.field, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEvaluateRPC(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch req.Method {
+		case "eth_blockNumber":
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x64"}`)
+		case "eth_getBlockByNumber":
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":null}`)
+		default:
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`)
+		}
+	}))
+	defer srv.Close()
+
+	rpcCriterion := func(method, threshold string) scenario.SuccessCriterion {
+		return scenario.SuccessCriterion{Name: method, Type: "rpc", Method: method, Threshold: threshold}
+	}
+
+	tests := []struct {
+		name        string
+		fd          *FailureDetector
+		criterion   scenario.SuccessCriterion
+		wantPassed  bool
+		wantUnknown bool
+	}{
+		{"meets threshold", NewWithRPC(nil, RPCConfig{Endpoints: []string{srv.URL}}), rpcCriterion("eth_blockNumber", "> 50"), true, false},
+		{"misses threshold", NewWithRPC(nil, RPCConfig{Endpoints: []string{srv.URL}}), rpcCriterion("eth_blockNumber", "> 500"), false, false},
+		{"null result is unknown", NewWithRPC(nil, RPCConfig{Endpoints: []string{srv.URL}}), rpcCriterion("eth_getBlockByNumber", "> 0"), false, true},
+		{"rpc error is unknown", NewWithRPC(nil, RPCConfig{Endpoints: []string{srv.URL}}), rpcCriterion("eth_nope", "> 0"), false, true},
+		{"no endpoint is unknown", New(nil), rpcCriterion("eth_blockNumber", "> 0"), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fd.EvaluateOnce(context.Background(), tt.criterion)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Passed != tt.wantPassed || result.Unknown != tt.wantUnknown {
+				t.Errorf("Passed = %v, Unknown = %v, want %v, %v (%s)", result.Passed, result.Unknown, tt.wantPassed, tt.wantUnknown, result.Message)
+			}
+		})
+	}
+}
+
+func TestRPCFailover(t *testing.T) {
+	var primaryCalls int
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls++
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	}))
+	defer slow.Close()
+	authed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "chaos" || pass != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x5"}`)
+	}))
+	defer authed.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name             string
+		cfg              RPCConfig
+		wantPassed       bool
+		wantUnknown      bool
+		wantPrimaryCalls int
+	}{
+		{"fails over past HTTP error and timeout",
+			RPCConfig{Endpoints: []string{primary.URL, slow.URL, authed.URL}, Timeout: 100 * time.Millisecond, Username: "chaos", Password: "secret"},
+			true, false, 1},
+		{"unreachable primary falls back",
+			RPCConfig{Endpoints: []string{closed.URL, authed.URL}, Username: "chaos", Password: "secret"},
+			true, false, 0},
+		{"retries the same endpoint first",
+			RPCConfig{Endpoints: []string{primary.URL, authed.URL}, Retries: 1, Username: "chaos", Password: "secret"},
+			true, false, 2},
+		{"wrong credentials on every endpoint is unknown",
+			RPCConfig{Endpoints: []string{closed.URL, authed.URL}, Username: "chaos", Password: "wrong"},
+			false, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primaryCalls = 0
+			fd := NewWithRPC(nil, tt.cfg)
+			criterion := scenario.SuccessCriterion{Name: "height", Type: "rpc", Method: "eth_blockNumber", Threshold: "> 0"}
+
+			result, err := fd.EvaluateOnce(context.Background(), criterion)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Passed != tt.wantPassed || result.Unknown != tt.wantUnknown {
+				t.Errorf("Passed = %v, Unknown = %v, want %v, %v (%s)", result.Passed, result.Unknown, tt.wantPassed, tt.wantUnknown, result.Message)
+			}
+			if primaryCalls != tt.wantPrimaryCalls {
+				t.Errorf("primary called %d times, want %d", primaryCalls, tt.wantPrimaryCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultAliasGroups lists metric-name prefixes that identify the same
+// family of series across client versions. Scenarios are written against
+// whichever name was current at the time; the aliaser rewrites them to the
+// one the live Prometheus actually exposes.
+//
+//   - Heimdall v2 runs CometBFT and exports cometbft_*; Heimdall v1 ran
+//     Tendermint and exported tendermint_* with identical suffixes
+//     (consensus_height, consensus_validators, p2p_peers, ...).
+//   - Heimdall's own application metrics are heimdallv2_* on v2 and
+//     heimdall_* on v1 (bor_api_calls_total, checkpoint_api_calls_total).
+//   - Bor inherits geth's registry, exported unprefixed (chain_head_block,
+//     txpool_pending, p2p_peers) by upstream geth and some Bor builds, and
+//     under a bor_ namespace by others.
+var DefaultAliasGroups = [][]string{
+	{"cometbft_", "tendermint_"},
+	{"heimdallv2_", "heimdall_"},
+	{"chain_", "bor_chain_"},
+	{"txpool_", "bor_txpool_"},
+	{"p2p_", "bor_p2p_"},
+}
+
+// MetricAliaser rewrites metric-name prefixes in PromQL queries based on
+// which naming scheme the connected Prometheus exposes. A zero-rewrite
+// aliaser (nothing resolved, or all schemes present) is a no-op.
+type MetricAliaser struct {
+	groups [][]string
+
+	mu       sync.RWMutex
+	rewrites map[string]string // absent prefix → present prefix
+}
+
+// NewMetricAliaser creates an aliaser for the given prefix groups. Pass
+// DefaultAliasGroups unless a downstream deployment needs extra families.
+func NewMetricAliaser(groups [][]string) *MetricAliaser {
+	return &MetricAliaser{
+		groups:   groups,
+		rewrites: make(map[string]string),
+	}
+}
+
+// Resolve probes the metric names Prometheus has seen in the last hour and
+// records, per group, which prefixes must be rewritten. A prefix is only
+// rewritten when it has no series at all and exactly one other prefix in
+// its group does — mixed deployments (v1 and v2 side by side) are left
+// untouched so neither half silently disappears.
+func (a *MetricAliaser) Resolve(ctx context.Context, c *Client) error {
+	names, err := c.GetMetricNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to probe metric names: %w", err)
+	}
+
+	rewrites := resolveRewrites(a.groups, names)
+
+	a.mu.Lock()
+	a.rewrites = rewrites
+	a.mu.Unlock()
+	return nil
+}
+
+// resolveRewrites is the pure half of Resolve, split out for testing.
+func resolveRewrites(groups [][]string, names []string) map[string]string {
+	rewrites := make(map[string]string)
+	for _, group := range groups {
+		var present, absent []string
+		for _, prefix := range group {
+			found := false
+			for _, name := range names {
+				if strings.HasPrefix(name, prefix) {
+					found = true
+					break
+				}
+			}
+			if found {
+				present = append(present, prefix)
+			} else {
+				absent = append(absent, prefix)
+			}
+		}
+		if len(present) != 1 {
+			continue
+		}
+		for _, prefix := range absent {
+			rewrites[prefix] = present[0]
+		}
+	}
+	return rewrites
+}
+
+// Rewrite returns query with every aliased metric prefix replaced. Only
+// identifiers are rewritten: text inside quoted label values (e.g.
+// job="tendermint_exporter") is left alone, and a prefix must start an
+// identifier rather than appear mid-name.
+func (a *MetricAliaser) Rewrite(query string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return rewriteQuery(query, a.rewrites)
+}
+
+// Rewrites returns the active prefix rewrites as "from → to" strings,
+// sorted, for display.
+func (a *MetricAliaser) Rewrites() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	out := make([]string, 0, len(a.rewrites))
+	for from, to := range a.rewrites {
+		out = append(out, fmt.Sprintf("%s* → %s*", from, to))
+	}
+	sort.Strings(out)
+	return out
+}
+
+func rewriteQuery(query string, rewrites map[string]string) string {
+	if len(rewrites) == 0 {
+		return query
+	}
+
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+
+		if quote != 0 {
+			b.WriteByte(ch)
+			if ch == '\\' && i+1 < len(query) {
+				i++
+				b.WriteByte(query[i])
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		if ch == '"' || ch == '\'' || ch == '`' {
+			quote = ch
+			b.WriteByte(ch)
+			continue
+		}
+
+		if i == 0 || !isIdentByte(query[i-1]) {
+			replaced := false
+			for from, to := range rewrites {
+				if strings.HasPrefix(query[i:], from) {
+					b.WriteString(to)
+					i += len(from) - 1
+					replaced = true
+					break
+				}
+			}
+			if replaced {
+				continue
+			}
+		}
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
+
+func isIdentByte(ch byte) bool {
+	return ch == '_' || ch == ':' ||
+		(ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
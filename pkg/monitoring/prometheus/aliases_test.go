@@ -0,0 +1,113 @@
+package prometheus
+
+import "testing"
+
+func TestResolveRewrites(t *testing.T) {
+	groups := [][]string{{"cometbft_", "tendermint_"}}
+
+	tests := []struct {
+		name  string
+		names []string
+		want  map[string]string
+	}{
+		{
+			name:  "v2 only rewrites tendermint to cometbft",
+			names: []string{"up", "cometbft_consensus_height"},
+			want:  map[string]string{"tendermint_": "cometbft_"},
+		},
+		{
+			name:  "v1 only rewrites cometbft to tendermint",
+			names: []string{"tendermint_consensus_height"},
+			want:  map[string]string{"cometbft_": "tendermint_"},
+		},
+		{
+			name:  "mixed deployment is left alone",
+			names: []string{"cometbft_consensus_height", "tendermint_consensus_height"},
+			want:  map[string]string{},
+		},
+		{
+			name:  "neither present is left alone",
+			names: []string{"chain_head_block"},
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveRewrites(groups, tt.names)
+			if len(got) != len(tt.want) {
+				t.Fatalf("resolveRewrites() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("resolveRewrites()[%q] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestRewriteQuery(t *testing.T) {
+	rewrites := map[string]string{"tendermint_": "cometbft_"}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: `tendermint_consensus_height`,
+			want:  `cometbft_consensus_height`,
+		},
+		{
+			query: `max(tendermint_consensus_height{job=~"l2-cl-.*"}) - min(tendermint_consensus_height)`,
+			want:  `max(cometbft_consensus_height{job=~"l2-cl-.*"}) - min(cometbft_consensus_height)`,
+		},
+		{
+			// label values are not identifiers
+			query: `up{job="tendermint_exporter"}`,
+			want:  `up{job="tendermint_exporter"}`,
+		},
+		{
+			// prefix must start an identifier
+			query: `my_tendermint_metric`,
+			want:  `my_tendermint_metric`,
+		},
+		{
+			query: `chain_head_block`,
+			want:  `chain_head_block`,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := rewriteQuery(tt.query, rewrites); got != tt.want {
+			t.Errorf("rewriteQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestResolveRewrites_DefaultGroups(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		from  string
+		to    string
+	}{
+		{"heimdall v2 only", []string{"heimdallv2_bor_api_calls_total"}, "heimdall_", "heimdallv2_"},
+		{"heimdall v1 only", []string{"heimdall_bor_api_calls_total"}, "heimdallv2_", "heimdall_"},
+		{"geth-style chain", []string{"chain_head_block"}, "bor_chain_", "chain_"},
+		{"bor-namespaced chain", []string{"bor_chain_head_block"}, "chain_", "bor_chain_"},
+		{"geth-style txpool", []string{"txpool_pending"}, "bor_txpool_", "txpool_"},
+		{"bor-namespaced txpool", []string{"bor_txpool_pending"}, "txpool_", "bor_txpool_"},
+		{"geth-style p2p", []string{"p2p_peers"}, "bor_p2p_", "p2p_"},
+		{"bor-namespaced p2p", []string{"bor_p2p_peers"}, "p2p_", "bor_p2p_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveRewrites(DefaultAliasGroups, tt.names)
+			if got[tt.from] != tt.to {
+				t.Errorf("resolveRewrites()[%q] = %q, want %q (all: %v)", tt.from, got[tt.from], tt.to, got)
+			}
+		})
+	}
+}
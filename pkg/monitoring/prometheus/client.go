@@ -2,6 +2,7 @@ package prometheus
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,8 +13,9 @@ import (
 
 // Client wraps the Prometheus API client
 type Client struct {
-	api    v1.API
-	config Config
+	api     v1.API
+	config  Config
+	aliaser *MetricAliaser
 }
 
 // Config contains Prometheus client configuration
@@ -50,8 +52,24 @@ func New(config Config) (*Client, error) {
 	}, nil
 }
 
+// SetAliaser installs a metric-name aliaser. Every subsequent instant and
+// range query is rewritten through it before being sent.
+func (c *Client) SetAliaser(a *MetricAliaser) {
+	c.aliaser = a
+}
+
+// rewrite applies the configured aliaser, if any.
+func (c *Client) rewrite(query string) string {
+	if c.aliaser == nil {
+		return query
+	}
+	return c.aliaser.Rewrite(query)
+}
+
 // QueryInstant executes an instant query at a specific time
 func (c *Client) QueryInstant(ctx context.Context, query string, ts time.Time) ([]QueryResult, error) {
+	query = c.rewrite(query)
+
 	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
 	defer cancel()
 
@@ -69,6 +87,8 @@ func (c *Client) QueryInstant(ctx context.Context, query string, ts time.Time) (
 
 // QueryRange executes a range query over a time window
 func (c *Client) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]QueryResult, error) {
+	query = c.rewrite(query)
+
 	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
 	defer cancel()
 
@@ -95,6 +115,21 @@ func (c *Client) QueryLatest(ctx context.Context, query string) ([]QueryResult,
 	return c.QueryInstant(ctx, query, time.Now())
 }
 
+// IsQueryError reports whether err is Prometheus rejecting or failing one
+// query (bad PromQL, an execution error or a query timeout) rather than
+// Prometheus itself being unreachable or unhealthy.
+func IsQueryError(err error) bool {
+	var apiErr *v1.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Type {
+	case v1.ErrBadData, v1.ErrExec, v1.ErrTimeout:
+		return true
+	}
+	return false
+}
+
 // TestConnection tests the connection to Prometheus
 func (c *Client) TestConnection(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
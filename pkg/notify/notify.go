@@ -0,0 +1,289 @@
+// Package notify posts run events — a test starting, an emergency stop, a
+// critical criterion failing, the final report — to chat and generic
+// webhooks, so a team hears about chaos on shared devnets without
+// watching the runner's output.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Event kinds.
+const (
+	EventTestStarted     = "test_started"
+	EventEmergencyStop   = "emergency_stop"
+	EventCriticalFailure = "critical_failure"
+	EventTestCompleted   = "test_completed"
+)
+
+// ValidEvents lists every event kind.
+var ValidEvents = []string{EventTestStarted, EventEmergencyStop, EventCriticalFailure, EventTestCompleted}
+
+// Payload formats.
+const (
+	FormatGeneric = "generic"
+	FormatSlack   = "slack"
+	FormatDiscord = "discord"
+)
+
+// Webhook is one endpoint events are POSTed to.
+type Webhook struct {
+	// Name identifies the webhook in warnings.
+	Name string
+	URL  string
+	// Format is FormatGeneric (default: the Event as JSON), FormatSlack
+	// ({"text": …}) or FormatDiscord ({"content": …}).
+	Format string
+	// Events the webhook receives. Empty means every event.
+	Events []string
+	// Template, when set, is a text/template over Event that renders the
+	// JSON body instead of Format's. {{json .X}} quotes a value as JSON.
+	Template string
+}
+
+// Event is one notification. Which fields are set depends on Event.
+type Event struct {
+	Event          string    `json:"event"`
+	TestID         string    `json:"test_id"`
+	Scenario       string    `json:"scenario"`
+	Time           time.Time `json:"time"`
+	Owner          string    `json:"owner,omitempty"`
+	RunbookURL     string    `json:"runbook_url,omitempty"`
+	ExpectedImpact string    `json:"expected_impact,omitempty"`
+	Message        string    `json:"message,omitempty"`
+
+	// Criterion and Value are set for critical_failure.
+	Criterion string  `json:"criterion,omitempty"`
+	Value     float64 `json:"value,omitempty"`
+
+	// Outcome (passed, failed, unknown or interrupted), Duration,
+	// Criteria and ReportPath are set for test_completed.
+	Outcome    string         `json:"outcome,omitempty"`
+	Duration   string         `json:"duration,omitempty"`
+	Criteria   *CriteriaCount `json:"criteria,omitempty"`
+	ReportPath string         `json:"report_path,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// CriteriaCount counts a finished run's success criteria.
+type CriteriaCount struct {
+	Total          int `json:"total"`
+	Passed         int `json:"passed"`
+	Failed         int `json:"failed"`
+	Unknown        int `json:"unknown,omitempty"`
+	CriticalFailed int `json:"critical_failed"`
+}
+
+// Summary is the one-line text chat formats post.
+func (e Event) Summary() string {
+	var b strings.Builder
+	switch e.Event {
+	case EventTestStarted:
+		fmt.Fprintf(&b, "🧪 Chaos test %s started: %s", e.TestID, e.Scenario)
+		if e.ExpectedImpact != "" {
+			fmt.Fprintf(&b, " — expected impact: %s", e.ExpectedImpact)
+		}
+	case EventEmergencyStop:
+		fmt.Fprintf(&b, "🛑 Emergency stop of chaos test %s (%s), tearing down", e.TestID, e.Scenario)
+	case EventCriticalFailure:
+		fmt.Fprintf(&b, "🔥 Chaos test %s (%s): critical criterion %s failed", e.TestID, e.Scenario, e.Criterion)
+		if e.Message != "" {
+			fmt.Fprintf(&b, ": %s", e.Message)
+		}
+	case EventTestCompleted:
+		fmt.Fprintf(&b, "%s Chaos test %s (%s) %s in %s", outcomeIcon(e.Outcome), e.TestID, e.Scenario, e.Outcome, e.Duration)
+		if c := e.Criteria; c != nil {
+			fmt.Fprintf(&b, " — %d/%d criteria passed", c.Passed, c.Total)
+		}
+		if e.ReportPath != "" {
+			fmt.Fprintf(&b, ", report %s", e.ReportPath)
+		}
+	default:
+		fmt.Fprintf(&b, "Chaos test %s (%s): %s", e.TestID, e.Scenario, e.Event)
+	}
+	if e.Owner != "" && e.Event != EventTestCompleted {
+		fmt.Fprintf(&b, " · owner %s", e.Owner)
+	}
+	if e.RunbookURL != "" && (e.Event == EventCriticalFailure || e.Event == EventEmergencyStop) {
+		fmt.Fprintf(&b, " · runbook %s", e.RunbookURL)
+	}
+	return b.String()
+}
+
+func outcomeIcon(outcome string) string {
+	switch outcome {
+	case "passed":
+		return "✅"
+	case "failed":
+		return "❌"
+	default:
+		return "⚠️"
+	}
+}
+
+// webhook is a validated Webhook.
+type webhook struct {
+	Webhook
+	events map[string]bool
+	tmpl   *template.Template
+}
+
+// Notifier posts events to webhooks. Posts run in the background so a
+// slow endpoint never holds up the run; Close waits for them.
+type Notifier struct {
+	hooks  []webhook
+	labels map[string]string
+	client *http.Client
+	wg     sync.WaitGroup
+	out    io.Writer
+}
+
+// New validates webhooks and creates a Notifier. labels are the run's
+// --label metadata, copied into every event.
+func New(webhooks []Webhook, labels map[string]string) (*Notifier, error) {
+	n := &Notifier{
+		labels: labels,
+		client: &http.Client{Timeout: 10 * time.Second},
+		out:    os.Stdout,
+	}
+	for i, w := range webhooks {
+		name := w.Name
+		if name == "" {
+			name = fmt.Sprintf("webhooks[%d]", i)
+			w.Name = name
+		}
+		if w.URL == "" {
+			return nil, fmt.Errorf("notify: %s: url is required", name)
+		}
+		switch w.Format {
+		case "":
+			w.Format = FormatGeneric
+		case FormatGeneric, FormatSlack, FormatDiscord:
+		default:
+			return nil, fmt.Errorf("notify: %s: unknown format %q (use %s, %s or %s)", name, w.Format, FormatGeneric, FormatSlack, FormatDiscord)
+		}
+
+		hook := webhook{Webhook: w}
+		if len(w.Events) > 0 {
+			hook.events = make(map[string]bool, len(w.Events))
+			for _, ev := range w.Events {
+				known := false
+				for _, v := range ValidEvents {
+					known = known || ev == v
+				}
+				if !known {
+					return nil, fmt.Errorf("notify: %s: unknown event %q (valid: %s)", name, ev, strings.Join(ValidEvents, ", "))
+				}
+				hook.events[ev] = true
+			}
+		}
+		if w.Template != "" {
+			tmpl, err := template.New(name).Funcs(templateFuncs).Parse(w.Template)
+			if err != nil {
+				return nil, fmt.Errorf("notify: %s: invalid template: %w", name, err)
+			}
+			hook.tmpl = tmpl
+		}
+		n.hooks = append(n.hooks, hook)
+	}
+	return n, nil
+}
+
+// Notify posts ev to every webhook subscribed to its kind, in the
+// background. Failures are printed, never fatal: a broken chat hook must
+// not fail a chaos run.
+func (n *Notifier) Notify(ev Event) {
+	if n == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	ev.Labels = n.labels
+	for _, h := range n.hooks {
+		if h.events != nil && !h.events[ev.Event] {
+			continue
+		}
+		n.wg.Add(1)
+		go func(h webhook) {
+			defer n.wg.Done()
+			if err := n.post(h, ev); err != nil {
+				fmt.Fprintf(n.out, "⚠ notify %s: %v\n", h.Name, err)
+			}
+		}(h)
+	}
+}
+
+// Close waits up to timeout for posts still in flight.
+func (n *Notifier) Close(timeout time.Duration) {
+	if n == nil {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		fmt.Fprintf(n.out, "⚠ notify: gave up waiting for webhooks after %s\n", timeout)
+	}
+}
+
+// post renders ev for h and POSTs it.
+func (n *Notifier) post(h webhook, ev Event) error {
+	body, err := render(h, ev)
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Post(h.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s event answered HTTP %d", ev.Event, resp.StatusCode)
+	}
+	return nil
+}
+
+// templateFuncs are the functions webhook templates may call.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// render builds the request body h expects for ev.
+func render(h webhook, ev Event) ([]byte, error) {
+	if h.tmpl != nil {
+		var buf bytes.Buffer
+		if err := h.tmpl.Execute(&buf, ev); err != nil {
+			return nil, fmt.Errorf("template: %w", err)
+		}
+		if !json.Valid(buf.Bytes()) {
+			return nil, fmt.Errorf("template rendered invalid JSON for %s event", ev.Event)
+		}
+		return buf.Bytes(), nil
+	}
+	switch h.Format {
+	case FormatSlack:
+		return json.Marshal(map[string]string{"text": ev.Summary()})
+	case FormatDiscord:
+		return json.Marshal(map[string]string{"content": ev.Summary()})
+	default:
+		return json.Marshal(ev)
+	}
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		hooks   []Webhook
+		wantErr bool
+	}{
+		{"defaults to generic", []Webhook{{URL: "http://localhost:1"}}, false},
+		{"slack with events", []Webhook{{URL: "http://localhost:1", Format: FormatSlack, Events: []string{EventCriticalFailure}}}, false},
+		{"missing url", []Webhook{{Name: "chat"}}, true},
+		{"unknown format", []Webhook{{URL: "http://localhost:1", Format: "teams"}}, true},
+		{"unknown event", []Webhook{{URL: "http://localhost:1", Events: []string{"test_paused"}}}, true},
+		{"bad template", []Webhook{{URL: "http://localhost:1", Template: "{{.Scenario"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.hooks, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies = map[string][]string{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies[r.URL.Path] = append(bodies[r.URL.Path], string(data))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	n, err := New([]Webhook{
+		{Name: "generic", URL: srv.URL + "/generic"},
+		{Name: "slack", URL: srv.URL + "/slack", Format: FormatSlack, Events: []string{EventCriticalFailure}},
+		{Name: "discord", URL: srv.URL + "/discord", Format: FormatDiscord, Events: []string{EventTestCompleted}},
+		{Name: "custom", URL: srv.URL + "/custom", Events: []string{EventTestStarted}, Template: `{"title": {{json .Scenario}}, "id": {{json .TestID}}}`},
+	}, map[string]string{"release": "v2.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.out = io.Discard
+
+	n.Notify(Event{Event: EventTestStarted, TestID: "test-1", Scenario: `bor "partition"`})
+	n.Notify(Event{Event: EventCriticalFailure, TestID: "test-1", Scenario: "partition", Criterion: "chain_head_advances", Message: "0 blocks"})
+	n.Notify(Event{Event: EventTestCompleted, TestID: "test-1", Scenario: "partition", Outcome: "failed", Duration: "5m0s",
+		Criteria: &CriteriaCount{Total: 2, Passed: 1, Failed: 1, CriticalFailed: 1}})
+	n.Close(5 * time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := len(bodies["/generic"]); got != 3 {
+		t.Errorf("generic webhook got %d events, want all 3", got)
+	}
+	var generic Event
+	if err := json.Unmarshal([]byte(bodies["/generic"][0]), &generic); err != nil || generic.Labels["release"] != "v2.1" || generic.Time.IsZero() {
+		t.Errorf("generic body = %s (%v), want the event with labels and time", bodies["/generic"][0], err)
+	}
+	if got := bodies["/slack"]; len(got) != 1 || !strings.Contains(got[0], `"text":"🔥 Chaos test test-1 (partition): critical criterion chain_head_advances failed: 0 blocks"`) {
+		t.Errorf("slack bodies = %v", got)
+	}
+	if got := bodies["/discord"]; len(got) != 1 || !strings.Contains(got[0], `"content":"❌ Chaos test test-1 (partition) failed in 5m0s — 1/2 criteria passed"`) {
+		t.Errorf("discord bodies = %v", got)
+	}
+	if got := bodies["/custom"]; len(got) != 1 || got[0] != `{"title": "bor \"partition\"", "id": "test-1"}` {
+		t.Errorf("custom bodies = %v", got)
+	}
+}
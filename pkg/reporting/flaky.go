@@ -0,0 +1,109 @@
+package reporting
+
+import (
+	"sort"
+	"strings"
+)
+
+// FlakeReport is how stable each success criterion was across repeated
+// runs of one scenario. A criterion that both passed and failed on the
+// same scenario is more likely a noisy check than a protocol regression,
+// which would fail from some run onwards.
+type FlakeReport struct {
+	Scenario string `json:"scenario"`
+	// Runs are the test IDs analysed, oldest first.
+	Runs     []string             `json:"runs"`
+	Criteria []CriterionStability `json:"criteria"`
+}
+
+// CriterionStability is one criterion's outcomes across the runs.
+type CriterionStability struct {
+	Name     string `json:"name"`
+	Critical bool   `json:"critical"`
+	Passed   int    `json:"passed"`
+	Failed   int    `json:"failed"`
+	Unknown  int    `json:"unknown,omitempty"`
+	// Flips counts changes between pass and fail from one judged run to
+	// the next; unknown and missing outcomes are skipped.
+	Flips int `json:"flips"`
+	// Outcomes has one character per run, oldest first: P passed,
+	// F failed, ? unknown, - not evaluated.
+	Outcomes string `json:"outcomes"`
+	// Flaky is set when the criterion both passed and failed.
+	Flaky bool `json:"flaky"`
+}
+
+// AnalyzeFlakes compares the criteria of reports, which should be runs of
+// the same scenario. Criteria are matched by name. Flaky criteria come
+// first, the most often flipping first.
+func AnalyzeFlakes(scenario string, reports []*TestReport) *FlakeReport {
+	runs := append([]*TestReport(nil), reports...)
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].StartTime.Before(runs[j].StartTime) })
+
+	fr := &FlakeReport{Scenario: scenario, Runs: make([]string, len(runs))}
+	index := map[string]int{}
+	var outcomes [][]byte
+	for i, r := range runs {
+		fr.Runs[i] = r.TestID
+		for _, c := range r.SuccessCriteria {
+			k, ok := index[c.Name]
+			if !ok {
+				k = len(fr.Criteria)
+				index[c.Name] = k
+				fr.Criteria = append(fr.Criteria, CriterionStability{Name: c.Name})
+				outcomes = append(outcomes, []byte(strings.Repeat("-", len(runs))))
+			}
+			cs := &fr.Criteria[k]
+			cs.Critical = cs.Critical || c.Critical
+			switch {
+			case c.Unknown:
+				cs.Unknown++
+				outcomes[k][i] = '?'
+			case c.Passed:
+				cs.Passed++
+				outcomes[k][i] = 'P'
+			default:
+				cs.Failed++
+				outcomes[k][i] = 'F'
+			}
+		}
+	}
+
+	for k := range fr.Criteria {
+		cs := &fr.Criteria[k]
+		cs.Outcomes = string(outcomes[k])
+		var last byte
+		for _, o := range outcomes[k] {
+			if o != 'P' && o != 'F' {
+				continue
+			}
+			if last != 0 && o != last {
+				cs.Flips++
+			}
+			last = o
+		}
+		cs.Flaky = cs.Passed > 0 && cs.Failed > 0
+	}
+	sort.SliceStable(fr.Criteria, func(i, j int) bool {
+		a, b := fr.Criteria[i], fr.Criteria[j]
+		if a.Flaky != b.Flaky {
+			return a.Flaky
+		}
+		if a.Flips != b.Flips {
+			return a.Flips > b.Flips
+		}
+		return a.Name < b.Name
+	})
+	return fr
+}
+
+// Flaky returns the criteria that both passed and failed.
+func (r *FlakeReport) Flaky() []CriterionStability {
+	var flaky []CriterionStability
+	for _, c := range r.Criteria {
+		if c.Flaky {
+			flaky = append(flaky, c)
+		}
+	}
+	return flaky
+}
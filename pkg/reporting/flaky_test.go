@@ -0,0 +1,53 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyzeFlakes(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	run := func(id string, n int, criteria ...CriterionResult) *TestReport {
+		return &TestReport{TestID: id, StartTime: start.Add(time.Duration(n) * time.Hour), SuccessCriteria: criteria}
+	}
+	pass := func(name string) CriterionResult { return CriterionResult{Name: name, Passed: true} }
+	fail := func(name string) CriterionResult { return CriterionResult{Name: name} }
+	unknown := func(name string) CriterionResult { return CriterionResult{Name: name, Unknown: true} }
+
+	// Newest first, as ListReports returns them.
+	fr := AnalyzeFlakes("demo", []*TestReport{
+		run("t4", 4, pass("blocks"), fail("peers"), pass("finality")),
+		run("t3", 3, pass("blocks"), unknown("peers"), fail("finality")),
+		run("t2", 2, pass("blocks"), pass("peers")),
+		run("t1", 1, pass("blocks"), fail("peers"), pass("finality")),
+	})
+
+	if got := fr.Runs; len(got) != 4 || got[0] != "t1" || got[3] != "t4" {
+		t.Fatalf("Runs = %v, want oldest first", got)
+	}
+	want := []struct {
+		name     string
+		outcomes string
+		flips    int
+		flaky    bool
+	}{
+		{"finality", "P-FP", 2, true},
+		{"peers", "FP?F", 2, true},
+		{"blocks", "PPPP", 0, false},
+	}
+	if len(fr.Criteria) != len(want) {
+		t.Fatalf("got %d criteria, want %d", len(fr.Criteria), len(want))
+	}
+	for i, w := range want {
+		c := fr.Criteria[i]
+		if c.Name != w.name || c.Outcomes != w.outcomes || c.Flips != w.flips || c.Flaky != w.flaky {
+			t.Errorf("criteria[%d] = %+v, want %s %s flips=%d flaky=%v", i, c, w.name, w.outcomes, w.flips, w.flaky)
+		}
+	}
+	if got := fr.Criteria[1]; got.Passed != 1 || got.Failed != 2 || got.Unknown != 1 {
+		t.Errorf("peers counts = %d/%d/%d, want 1 passed, 2 failed, 1 unknown", got.Passed, got.Failed, got.Unknown)
+	}
+	if n := len(fr.Flaky()); n != 2 {
+		t.Errorf("Flaky() returned %d criteria, want 2", n)
+	}
+}
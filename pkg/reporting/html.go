@@ -0,0 +1,183 @@
+package reporting
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+)
+
+// RenderHTML renders a self-contained, single-file HTML view of a report.
+// Each success criterion gets an inline SVG sparkline of its evaluation
+// history (green = passing sample, red = failing), which is the part the
+// JSON report cannot show at a glance.
+func RenderHTML(report *TestReport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlReportTemplate.Execute(&buf, report); err != nil {
+		return nil, fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// sparkline dimensions in px
+const (
+	sparkWidth  = 240
+	sparkHeight = 36
+	sparkPad    = 3
+)
+
+// mib formats a byte count in mebibytes.
+func mib(b uint64) string {
+	return fmt.Sprintf("%.1f MiB", float64(b)/(1<<20))
+}
+
+// sparklineSVG draws the history as a polyline plus one dot per sample.
+// A flat series is drawn through the vertical middle.
+func sparklineSVG(history []EvaluationPoint) template.HTML {
+	if len(history) == 0 {
+		return template.HTML(`<span class="muted">no samples</span>`)
+	}
+
+	lo, hi := history[0].Value, history[0].Value
+	for _, h := range history[1:] {
+		lo = math.Min(lo, h.Value)
+		hi = math.Max(hi, h.Value)
+	}
+
+	x := func(i int) float64 {
+		if len(history) == 1 {
+			return sparkWidth / 2
+		}
+		return sparkPad + float64(i)*(sparkWidth-2*sparkPad)/float64(len(history)-1)
+	}
+	y := func(v float64) float64 {
+		if hi == lo {
+			return sparkHeight / 2
+		}
+		return sparkHeight - sparkPad - (v-lo)*(sparkHeight-2*sparkPad)/(hi-lo)
+	}
+
+	var points, dots strings.Builder
+	for i, h := range history {
+		fmt.Fprintf(&points, "%.1f,%.1f ", x(i), y(h.Value))
+		color := "#2e7d32"
+		if !h.Passed {
+			color = "#c62828"
+		}
+		fmt.Fprintf(&dots, `<circle cx="%.1f" cy="%.1f" r="2" fill="%s"><title>%s = %g</title></circle>`,
+			x(i), y(h.Value), color, h.Time.Format("15:04:05"), h.Value)
+	}
+
+	return template.HTML(fmt.Sprintf(
+		`<svg width="%d" height="%d" viewBox="0 0 %d %d"><polyline fill="none" stroke="#90a4ae" stroke-width="1" points="%s"/>%s</svg>`,
+		sparkWidth, sparkHeight, sparkWidth, sparkHeight, strings.TrimSpace(points.String()), dots.String()))
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"sparkline": sparklineSVG,
+	"mib":       mib,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.ScenarioName}} — {{.TestID}}</title>
+<style>
+body { font-family: -apple-system, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 4px 8px; border-bottom: 1px solid #eee; vertical-align: middle; }
+.pass { color: #2e7d32; } .fail { color: #c62828; } .unknown { color: #ef6c00; } .muted { color: #999; }
+code { font-size: 0.85em; }
+.annotations { background: #fff8e1; border-left: 4px solid #ef6c00; padding: 0.2em 1em; margin-bottom: 1em; }
+.annotations p { margin: 0.4em 0; }
+</style>
+</head>
+<body>
+<h1>{{if .Success}}<span class="pass">✓ PASSED</span>{{else if eq .Status "interrupted"}}<span class="unknown">INTERRUPTED</span>{{else if .Unknown}}<span class="unknown">? UNKNOWN</span>{{else}}<span class="fail">✗ FAILED</span>{{end}} {{.ScenarioName}}</h1>
+<p>Test {{.TestID}} · {{.StartTime.Format "2006-01-02 15:04:05"}} · {{.Duration}}{{if .Message}} · {{.Message}}{{end}}{{if .StuckPhase}} · stuck in {{.StuckPhase}}{{end}}</p>
+{{if or .ExpectedImpact .RunbookURL .Owner .Approval}}<div class="annotations">
+{{if .ExpectedImpact}}<p><strong>Expected impact:</strong> {{.ExpectedImpact}}</p>{{end}}
+{{if .RunbookURL}}<p><strong>Runbook:</strong> <a href="{{.RunbookURL}}">{{.RunbookURL}}</a></p>{{end}}
+{{if .Owner}}<p><strong>Owner:</strong> {{.Owner}}</p>{{end}}
+{{with .Approval}}<p><strong>Destructive, approved by:</strong> {{.Approver}}{{if .Role}} ({{.Role}}){{end}} · {{.Method}} · {{.Time.Format "2006-01-02 15:04:05"}}</p>{{end}}
+</div>{{end}}
+{{with .Environment}}<p class="muted">enclave {{.EnclaveName}} · runner {{.RunnerVersion}}{{if .HostKernel}} · kernel {{.HostKernel}}{{end}}{{with .Topology}} · {{.ValidatorCount}} validators / {{len .Services}} services{{end}}</p>{{end}}
+{{if .Labels}}<p class="muted">{{range $k, $v := .Labels}}<code>{{$k}}={{$v}}</code> {{end}}</p>{{end}}
+
+<h2>Success criteria</h2>
+<table>
+<tr><th></th><th>Criterion</th><th>Value</th><th>Evaluations</th><th>Trend</th><th>Message</th></tr>
+{{range .SuccessCriteria}}
+<tr>
+<td>{{if .Passed}}<span class="pass">✓</span>{{else if .Unknown}}<span class="fail" title="unknown: could not be judged">?</span>{{else}}<span class="fail">✗</span>{{end}}</td>
+<td><strong>{{.Name}}</strong>{{if .Unknown}} <span class="muted">(unknown)</span>{{end}}{{if .Critical}} <span class="muted">(critical)</span>{{end}}{{if .Query}}<br><code>{{.Query}}</code> {{.Threshold}}{{end}}</td>
+<td>{{printf "%.4g" .Value}}</td>
+<td>{{.Evaluations}}{{if .Failures}} <span class="fail">({{.Failures}} failed)</span>{{end}}</td>
+<td>{{sparkline .History}}</td>
+<td>{{.Message}}</td>
+</tr>
+{{end}}
+</table>
+
+{{with .BlastRadius}}
+<h2>Blast radius</h2>
+<p class="muted">Worst reading per group over {{.Window}} since injection</p>
+<table>
+<tr><th>Metric</th><th>Targets worst / mean</th><th>Control worst / mean</th><th>Collateral</th></tr>
+{{range .Metrics}}<tr><td><strong>{{.Name}}</strong>{{if .Description}}<br><span class="muted">{{.Description}}</span>{{end}}</td>
+<td>{{if .Target.Nodes}}{{printf "%.4g" .Target.Worst}} / {{printf "%.4g" .Target.Mean}} <span class="muted">({{.Target.Nodes}})</span>{{end}}</td>
+<td>{{if .Control.Nodes}}{{printf "%.4g" .Control.Worst}} / {{printf "%.4g" .Control.Mean}} <span class="muted">({{.Control.Nodes}})</span>{{end}}</td>
+<td>{{if .Collateral}}{{range $i, $n := .Collateral}}{{if $i}}, {{end}}<span class="fail">{{$n.Job}}: {{printf "%.4g" $n.Baseline}} → {{printf "%.4g" $n.Worst}}</span>{{end}}{{else}}<span class="pass">none</span>{{end}}</td></tr>
+{{end}}
+</table>
+{{end}}
+
+{{if .CollateralEvents}}
+<h2>Collateral container events</h2>
+<p class="muted">Enclave containers no fault targeted that died, were OOM-killed or restarted while faults were active</p>
+<table>
+<tr><th>Container</th><th>Event</th><th>At</th></tr>
+{{range .CollateralEvents}}<tr><td><strong>{{.Container}}</strong></td><td><span class="fail">{{.Action}}</span>{{with .ExitCode}} <span class="muted">(exit {{.}})</span>{{end}}</td><td>{{.At.Format "15:04:05"}}</td></tr>
+{{end}}
+</table>
+{{end}}
+
+{{if .EnclaveResidue}}
+<h2>Enclave residue</h2>
+<p class="muted">Enclave containers that still carried chaos artifacts after teardown</p>
+<table>
+<tr><th>Container</th><th>Targeted</th><th>Artifacts</th></tr>
+{{range .EnclaveResidue}}<tr><td><strong>{{.Container}}</strong></td><td>{{if .Target}}yes{{else}}<span class="fail">no</span>{{end}}</td><td><span class="fail">{{.Artifacts}}</span></td></tr>
+{{end}}
+</table>
+{{end}}
+
+{{if .Detections}}
+<h2>Time to detect</h2>
+<table>
+<tr><th></th><th>Signal</th><th>Latency after injection</th></tr>
+{{range .Detections}}<tr><td>{{if .Detected}}<span class="pass">✓</span>{{else}}<span class="fail">✗</span>{{end}}</td><td><strong>{{.Signal}}</strong> <span class="muted">({{.Kind}})</span></td><td>{{if .Detected}}{{printf "%.0fs" .LatencySeconds}}{{else if .PreExisting}}<span class="muted">firing before injection</span>{{else}}<span class="fail">not detected</span>{{end}}</td></tr>
+{{end}}
+</table>
+{{end}}
+
+{{if .RunnerUsage}}
+<h2>Runner resource usage</h2>
+<table>
+<tr><th>Phase</th><th>Duration</th><th>CPU</th><th>Heap</th><th>Max RSS</th><th>Goroutines</th><th>Docker API calls</th></tr>
+{{range .RunnerUsage}}<tr><td>{{.Phase}}</td><td>{{printf "%.1fs" .DurationSeconds}}</td><td>{{printf "%.2fs" .CPUSeconds}} <span class="muted">({{printf "%.1f" .CPUPercent}}%)</span></td><td>{{mib .HeapBytes}}</td><td>{{mib .MaxRSSBytes}}</td><td>{{.Goroutines}}</td><td>{{.DockerAPICalls}}</td></tr>
+{{end}}
+</table>
+{{end}}
+
+<h2>Faults</h2>
+<table>
+<tr><th>Phase</th><th>Type</th><th>Target</th><th>Description</th><th>Restart to healthy</th></tr>
+{{range .Faults}}<tr><td>{{.Phase}}</td><td>{{.Type}}{{range .AppliedMethods}}<br><span class="muted">{{.Target}}: {{.Method}}{{if .Fallback}} ({{.Fallback}}){{end}}</span>{{end}}</td><td>{{.Target}}{{range .Injections}}{{if not .Installed}}<br><span class="fail">✗ {{.Target}}: {{.Error}}</span>{{end}}{{end}}</td><td>{{.Description}}</td><td>{{range $i, $r := .Recoveries}}{{if $i}}, {{end}}{{$r.Target}}: {{$r.RestartToHealthy}}{{end}}{{range .Captures}}<br><span class="muted">pcap {{.Target}}: {{.Path}}</span>{{end}}</td></tr>
+{{end}}
+</table>
+
+{{if .Errors}}<h2>Errors</h2><ul>{{range .Errors}}<li class="fail">{{.}}</li>{{end}}</ul>{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,84 @@
+package reporting
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderHTML(t *testing.T) {
+	now := time.Now()
+	exitCode := 137
+	report := &TestReport{
+		TestID:       "test-1",
+		ScenarioName: "demo <scenario>",
+		StartTime:    now,
+		Success:      false,
+		SuccessCriteria: []CriterionResult{
+			{
+				Name:        "block_production",
+				Passed:      false,
+				Evaluations: 3,
+				Failures:    1,
+				History: []EvaluationPoint{
+					{Time: now, Value: 1, Passed: true},
+					{Time: now.Add(15 * time.Second), Value: 0.5, Passed: true},
+					{Time: now.Add(30 * time.Second), Value: 0, Passed: false},
+				},
+			},
+			{Name: "no_history", Passed: true},
+		},
+		BlastRadius: &BlastRadiusInfo{
+			Window: "5m0s",
+			Metrics: []BlastMetricInfo{
+				{
+					Name:       "bor_block_lag",
+					Target:     BlastGroupInfo{Nodes: 1, Worst: 40, Mean: 40},
+					Control:    BlastGroupInfo{Nodes: 2, Worst: 12, Mean: 7},
+					Collateral: []BlastNodeInfo{{Job: "l2-el-2-bor", Baseline: 1, Worst: 12}},
+				},
+			},
+		},
+		CollateralEvents: []CollateralEventInfo{
+			{Container: "l2-cl-3-heimdall-v2", Action: "die", At: now, ExitCode: &exitCode},
+		},
+		RunnerUsage: []PhaseUsageInfo{
+			{Phase: "INJECT", DurationSeconds: 12, CPUSeconds: 0.6, CPUPercent: 5, HeapBytes: 8 << 20, MaxRSSBytes: 40 << 20, DockerAPICalls: 57},
+		},
+		Detections: []DetectionInfo{
+			{Signal: "BorBlockProductionStalled", Kind: "alert", Detected: true, LatencySeconds: 95},
+			{Signal: "HeimdallPeersLow", Kind: "alert"},
+		},
+	}
+
+	out, err := RenderHTML(report)
+	if err != nil {
+		t.Fatalf("RenderHTML: %v", err)
+	}
+	html := string(out)
+
+	if !strings.Contains(html, "demo &lt;scenario&gt;") {
+		t.Error("scenario name must be HTML-escaped")
+	}
+	if strings.Count(html, "<circle") != 3 {
+		t.Errorf("expected 3 sparkline points, got %d", strings.Count(html, "<circle"))
+	}
+	if !strings.Contains(html, "#c62828") {
+		t.Error("failing sample should be drawn in red")
+	}
+	if !strings.Contains(html, "no samples") {
+		t.Error("criterion without history should render a placeholder")
+	}
+	if !strings.Contains(html, "Blast radius") || !strings.Contains(html, "l2-el-2-bor: 1 → 12") {
+		t.Error("blast radius section should list collateral nodes")
+	}
+	if !strings.Contains(html, "Collateral container events") || !strings.Contains(html, "(exit 137)") {
+		t.Error("collateral section should list the event with its exit code")
+	}
+	if !strings.Contains(html, "Runner resource usage") || !strings.Contains(html, "40.0 MiB") {
+		t.Error("runner usage section should show memory in MiB")
+	}
+	if !strings.Contains(html, "Time to detect") || !strings.Contains(html, "95s") || !strings.Contains(html, "not detected") {
+		t.Error("time to detect section should show latency and missed signals")
+	}
+}
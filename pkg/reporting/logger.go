@@ -13,6 +13,7 @@ import (
 type LogLevel string
 
 const (
+	LogLevelTrace LogLevel = "trace"
 	LogLevelDebug LogLevel = "debug"
 	LogLevelInfo  LogLevel = "info"
 	LogLevelWarn  LogLevel = "warn"
@@ -57,6 +58,8 @@ func NewLogger(cfg LoggerConfig) *Logger {
 	zlog := zerolog.New(output).With().Timestamp().Logger()
 
 	switch cfg.Level {
+	case LogLevelTrace:
+		zlog = zlog.Level(zerolog.TraceLevel)
 	case LogLevelDebug:
 		zlog = zlog.Level(zerolog.DebugLevel)
 	case LogLevelInfo:
@@ -72,6 +75,13 @@ func NewLogger(cfg LoggerConfig) *Logger {
 	return &Logger{logger: zlog}
 }
 
+// Trace logs a trace message
+func (l *Logger) Trace(msg string, fields ...interface{}) {
+	event := l.logger.Trace()
+	l.addFields(event, fields...)
+	event.Msg(msg)
+}
+
 // Debug logs a debug message
 func (l *Logger) Debug(msg string, fields ...interface{}) {
 	event := l.logger.Debug()
@@ -0,0 +1,88 @@
+package reporting
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// plainReplacer maps the glyphs the runner prints to ASCII, for terminals
+// and CI log systems that garble them (--no-emoji).
+var plainReplacer = strings.NewReplacer(
+	"\ufe0f", "", // emoji variation selector
+	"✓", "[OK]",
+	"✅", "[OK]",
+	"✗", "[X]",
+	"❌", "[X]",
+	"⚠", "[WARN]",
+	"🛑", "[STOP]",
+	"🚨", "[ALERT]",
+	"⏳", "[WAIT]",
+	"⏸", "[PAUSE]",
+	"▶", "[GO]",
+	"🧹", "[CLEANUP]",
+	"🔍", "[SCAN]",
+	"📋", "[INFO]",
+	"⊘", "[SKIP]",
+	"→", "->",
+	"—", "-",
+	"–", "-",
+	"•", "*",
+	"·", "-",
+	"…", "...",
+	"≥", ">=",
+	"≤", "<=",
+	"─", "-",
+	"═", "=",
+	"║", "|",
+	"╔", "+", "╗", "+", "╚", "+", "╝", "+",
+)
+
+// Plain renders s as ASCII: known glyphs become tags like [OK] and [WARN],
+// box drawing becomes -, = and |, and any other non-ASCII rune becomes ?.
+func Plain(s string) string {
+	s = plainReplacer.Replace(s)
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return strings.Map(func(r rune) rune {
+				if r >= utf8.RuneSelf {
+					return '?'
+				}
+				return r
+			}, s)
+		}
+	}
+	return s
+}
+
+// plainWriter applies Plain to everything written through it.
+type plainWriter struct {
+	w       io.Writer
+	pending []byte // trailing bytes of a rune split across writes
+}
+
+// NewPlainWriter returns a writer that renders output through Plain. A
+// multi-byte rune split across writes is held back until it is complete.
+func NewPlainWriter(w io.Writer) io.Writer {
+	return &plainWriter{w: w}
+}
+
+func (p *plainWriter) Write(b []byte) (int, error) {
+	data := append(p.pending, b...)
+
+	cut := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				cut = i
+			}
+			break
+		}
+	}
+	p.pending = append([]byte(nil), data[cut:]...)
+
+	if _, err := io.WriteString(p.w, Plain(string(data[:cut]))); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
@@ -0,0 +1,44 @@
+package reporting
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPlain(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"  ✓ PASSED  demo", "  [OK] PASSED  demo"},
+		{"⚠️ No targets found", "[WARN] No targets found"},
+		{"[INIT] → [PARSE]", "[INIT] -> [PARSE]"},
+		{"═══", "==="},
+		{"héllo", "h?llo"},
+		{"plain ascii", "plain ascii"},
+	}
+	for _, tt := range tests {
+		if got := Plain(tt.in); got != tt.want {
+			t.Errorf("Plain(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPlainWriterSplitRune(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewPlainWriter(&buf)
+
+	msg := []byte("✓ done\n")
+	// Split inside the three-byte ✓.
+	if _, err := w.Write(msg[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("incomplete rune was flushed: %q", buf.String())
+	}
+	if _, err := w.Write(msg[2:]); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "[OK] done\n" {
+		t.Errorf("got %q, want %q", got, "[OK] done\n")
+	}
+}
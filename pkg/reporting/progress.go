@@ -14,6 +14,9 @@ const (
 	FormatText OutputFormat = "text"
 	FormatJSON OutputFormat = "json"
 	FormatTUI  OutputFormat = "tui"
+	// FormatJSONStatus prints nothing during the run; the caller emits a
+	// single status object at exit.
+	FormatJSONStatus OutputFormat = "json-status"
 )
 
 // ProgressReporter reports test execution progress
@@ -40,11 +43,59 @@ func (pr *ProgressReporter) ReportTestCompleted(report *TestReport) {
 	case FormatTUI:
 		pr.clearLine()
 		pr.printSummary(report)
+	case FormatJSONStatus:
 	default:
 		pr.printSummary(report)
 	}
 }
 
+// ProgressEvent is one step of a run in progress: a state transition, a
+// fault installed on a target, or a criterion evaluated. Which fields are
+// set depends on Event.
+type ProgressEvent struct {
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+
+	Phase       string `json:"phase,omitempty"`
+	FaultType   string `json:"fault_type,omitempty"`
+	Target      string `json:"target,omitempty"`
+	ContainerID string `json:"container_id,omitempty"`
+
+	Criterion   string  `json:"criterion,omitempty"`
+	Passed      bool    `json:"passed,omitempty"`
+	Unknown     bool    `json:"unknown,omitempty"`
+	Critical    bool    `json:"critical,omitempty"`
+	Value       float64 `json:"value,omitempty"`
+	Message     string  `json:"message,omitempty"`
+	DuringFault bool    `json:"during_fault,omitempty"`
+}
+
+// ReportEvent renders one progress event. JSON output gets every event as
+// a line; text and TUI show state transitions and installed faults, since
+// DETECT prints each criterion with its context already.
+func (pr *ProgressReporter) ReportEvent(ev ProgressEvent) {
+	switch pr.format {
+	case FormatJSON:
+		data, _ := json.Marshal(ev)
+		fmt.Println(string(data))
+	case FormatJSONStatus:
+	default:
+		switch ev.Event {
+		case "state_changed":
+			fmt.Printf("[%s] → [%s]\n", ev.From, ev.To)
+		case "fault_injected":
+			id := ev.ContainerID
+			if len(id) > 12 {
+				id = id[:12]
+			}
+			fmt.Printf("  ✓ %s on %s (%s)\n", ev.Phase, ev.Target, id)
+		}
+	}
+}
+
 // printSummary renders the end-of-test summary.
 func (pr *ProgressReporter) printSummary(report *TestReport) {
 	w := 72
@@ -55,8 +106,12 @@ func (pr *ProgressReporter) printSummary(report *TestReport) {
 	// Overall verdict banner
 	if report.Status == StatusStopped {
 		fmt.Printf("  STOPPED  %s\n", report.ScenarioName)
+	} else if report.Status == StatusInterrupted {
+		fmt.Printf("  INTERRUPTED  %s\n", report.ScenarioName)
 	} else if report.Success {
 		fmt.Printf("  ✓ PASSED  %s\n", report.ScenarioName)
+	} else if report.Unknown {
+		fmt.Printf("  ? UNKNOWN  %s\n", report.ScenarioName)
 	} else {
 		fmt.Printf("  ✗ FAILED  %s\n", report.ScenarioName)
 	}
@@ -92,12 +147,16 @@ func (pr *ProgressReporter) printSummary(report *TestReport) {
 		fmt.Println()
 
 		for _, c := range report.SuccessCriteria {
+			mark := "✗"
+			if c.Unknown && !c.Passed {
+				mark = "?"
+			}
 			if c.Passed {
 				fmt.Printf("    ✓  %s\n", c.Name)
 			} else if c.Critical {
-				fmt.Printf("    ✗  %s  (CRITICAL)\n", c.Name)
+				fmt.Printf("    %s  %s  (CRITICAL)\n", mark, c.Name)
 			} else {
-				fmt.Printf("    ✗  %s  (non-critical)\n", c.Name)
+				fmt.Printf("    %s  %s  (non-critical)\n", mark, c.Name)
 			}
 		}
 
@@ -112,11 +171,17 @@ func (pr *ProgressReporter) printSummary(report *TestReport) {
 				if c.Critical {
 					severity = "CRITICAL"
 				}
-				fmt.Printf("\n    ✗ %s [%s]\n", c.Name, severity)
+				if c.Unknown {
+					fmt.Printf("\n    ? %s [%s, UNKNOWN]\n", c.Name, severity)
+				} else {
+					fmt.Printf("\n    ✗ %s [%s]\n", c.Name, severity)
+				}
 				if c.Description != "" {
 					fmt.Printf("      %s\n", c.Description)
 				}
-				fmt.Printf("      got %.4g, expected %s\n", c.Value, c.Threshold)
+				if !c.Unknown {
+					fmt.Printf("      got %.4g, expected %s\n", c.Value, c.Threshold)
+				}
 				if c.Query != "" {
 					fmt.Printf("      query: %s\n", c.Query)
 				}
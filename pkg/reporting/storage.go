@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -50,6 +51,20 @@ func (s *Storage) SaveReport(report *TestReport) (string, error) {
 
 	s.logger.Info("Test report saved", "path", filepath)
 
+	// HTML view alongside the JSON (same basename). Best-effort: the JSON
+	// is the authoritative artifact.
+	if html, err := RenderHTML(report); err != nil {
+		s.logger.Warn("Failed to render HTML report", "error", err)
+	} else if err := os.WriteFile(HTMLPath(filepath), html, 0644); err != nil {
+		s.logger.Warn("Failed to write HTML report", "error", err)
+	}
+	// Likewise the Perfetto trace.
+	if trace, err := RenderTrace(report); err != nil {
+		s.logger.Warn("Failed to render trace", "error", err)
+	} else if err := os.WriteFile(TracePath(filepath), trace, 0644); err != nil {
+		s.logger.Warn("Failed to write trace", "error", err)
+	}
+
 	// Cleanup old reports if necessary
 	if s.keepLastN > 0 {
 		if err := s.cleanupOldReports(); err != nil {
@@ -84,7 +99,7 @@ func (s *Storage) ListReports() ([]ReportSummary, error) {
 
 	summaries := make([]ReportSummary, 0)
 	for _, entry := range entries {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" || isTracePath(entry.Name()) {
 			continue
 		}
 
@@ -104,6 +119,7 @@ func (s *Storage) ListReports() ([]ReportSummary, error) {
 			Duration:     report.Duration,
 			Status:       report.Status,
 			Success:      report.Success,
+			Labels:       report.Labels,
 			Filepath:     path,
 		})
 	}
@@ -135,11 +151,23 @@ func (s *Storage) cleanupOldReports() error {
 		} else {
 			s.logger.Debug("Deleted old report", "path", summary.Filepath)
 		}
+		// Older reports predate the HTML view; a missing file is fine.
+		if err := os.Remove(HTMLPath(summary.Filepath)); err != nil && !os.IsNotExist(err) {
+			s.logger.Warn("Failed to delete old HTML report", "path", HTMLPath(summary.Filepath), "error", err)
+		}
+		if err := os.Remove(TracePath(summary.Filepath)); err != nil && !os.IsNotExist(err) {
+			s.logger.Warn("Failed to delete old trace", "path", TracePath(summary.Filepath), "error", err)
+		}
 	}
 
 	return nil
 }
 
+// HTMLPath maps a report's .json path to its .html sibling.
+func HTMLPath(jsonPath string) string {
+	return strings.TrimSuffix(jsonPath, ".json") + ".html"
+}
+
 // ReportSummary contains a summary of a test report
 type ReportSummary struct {
 	TestID       string     `json:"test_id"`
@@ -147,6 +175,18 @@ type ReportSummary struct {
 	StartTime    time.Time  `json:"start_time"`
 	Duration     string     `json:"duration"`
 	Status       TestStatus `json:"status"`
-	Success      bool       `json:"success"`
-	Filepath     string     `json:"filepath"`
+	Success      bool              `json:"success"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Filepath     string            `json:"filepath"`
+}
+
+// MatchesLabels reports whether the summary carries every key=value pair in
+// selector. An empty selector matches everything.
+func (r ReportSummary) MatchesLabels(selector map[string]string) bool {
+	for k, v := range selector {
+		if got, ok := r.Labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,96 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SuiteReport combines the runs of one ChaosSuite. Each run still has its
+// own TestReport; this is the index over them.
+type SuiteReport struct {
+	Suite     string    `json:"suite"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Duration  string    `json:"duration"`
+	Success   bool      `json:"success"`
+	Passed    int       `json:"passed"`
+	Failed    int       `json:"failed"`
+	Unknown   int       `json:"unknown"`
+	// Message explains a suite cut short by an infrastructure error.
+	Message   string            `json:"message,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Scenarios []SuiteRun        `json:"scenarios"`
+}
+
+// SuiteRun is one scenario run within a suite.
+type SuiteRun struct {
+	Scenario string     `json:"scenario"`
+	Path     string     `json:"path"`
+	TestID   string     `json:"test_id"`
+	Status   TestStatus `json:"status"`
+	Success  bool       `json:"success"`
+	Unknown  bool       `json:"unknown,omitempty"`
+	Message  string     `json:"message,omitempty"`
+	Duration string     `json:"duration"`
+	// CriteriaPassed and CriteriaFailed count the run's success criteria.
+	CriteriaPassed int `json:"criteria_passed"`
+	CriteriaFailed int `json:"criteria_failed"`
+	// Report is the run's saved JSON report.
+	Report string `json:"report,omitempty"`
+}
+
+// AddRun appends report, saved at reportPath, to the suite.
+func (s *SuiteReport) AddRun(path string, report *TestReport, reportPath string) {
+	run := SuiteRun{
+		Scenario: report.ScenarioName,
+		Path:     path,
+		TestID:   report.TestID,
+		Status:   report.Status,
+		Success:  report.Success,
+		Unknown:  report.Unknown,
+		Message:  report.Message,
+		Duration: report.Duration,
+		Report:   reportPath,
+	}
+	for _, c := range report.SuccessCriteria {
+		if c.Passed {
+			run.CriteriaPassed++
+		} else {
+			run.CriteriaFailed++
+		}
+	}
+	switch {
+	case run.Success:
+		s.Passed++
+	case run.Unknown:
+		s.Unknown++
+	default:
+		s.Failed++
+	}
+	s.Scenarios = append(s.Scenarios, run)
+}
+
+// SaveSuiteReport writes the suite report to suites/ under the output
+// directory, apart from the per-run reports that ListReports reads and
+// keep_last_n prunes.
+func (s *Storage) SaveSuiteReport(report *SuiteReport) (string, error) {
+	dir := filepath.Join(s.outputDir, "suites")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create suite report directory: %w", err)
+	}
+	timestamp := report.StartTime.Format("20060102-150405")
+	path := filepath.Join(dir, fmt.Sprintf("suite-%s-%s.json", timestamp, report.Suite))
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal suite report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write suite report file: %w", err)
+	}
+	s.logger.Info("Suite report saved", "path", path)
+	return path, nil
+}
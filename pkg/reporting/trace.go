@@ -0,0 +1,198 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RenderTrace renders a report as a Chrome trace (JSON trace event format),
+// which ui.perfetto.dev and chrome://tracing open directly. Each track is
+// a thread of one process: the run's states, one track per fault and
+// target holding its active window, one per criterion holding its
+// evaluations, and the collateral events and cleanup actions. Criterion
+// values are also counter tracks, so a metric dip lines up visually with
+// the fault windows that overlap it.
+//
+// Timestamps are relative to the report's start; otherData.start_time
+// holds the absolute start.
+func RenderTrace(report *TestReport) ([]byte, error) {
+	t := &traceBuilder{start: report.StartTime, end: report.EndTime}
+	if t.end.IsZero() {
+		t.end = time.Now()
+	}
+	t.meta(0, "process_name", report.ScenarioName+" ("+report.TestID+")")
+
+	states := t.thread("states")
+	at := report.StartTime
+	for _, u := range report.RunnerUsage {
+		// Reports written before phases carried a start time: phases are
+		// contiguous from the run's start.
+		if !u.StartTime.IsZero() {
+			at = u.StartTime
+		}
+		dur := time.Duration(u.DurationSeconds * float64(time.Second))
+		t.span(states, u.Phase, "state", at, at.Add(dur), nil)
+		at = at.Add(dur)
+	}
+
+	for _, f := range report.Faults {
+		for _, in := range f.Injections {
+			tid := t.thread(f.Phase + " → " + in.Target)
+			name := fmt.Sprintf("%s (%s)", f.Phase, f.Type)
+			args := map[string]interface{}{"type": f.Type, "target": in.Target, "container_id": in.ContainerID}
+			if len(f.Parameters) > 0 {
+				args["params"] = f.Parameters
+			}
+			if !in.Installed {
+				args["error"] = in.Error
+				t.instant(tid, name+" failed", "fault", in.InstalledAt, args)
+				continue
+			}
+			if in.InstalledAt.IsZero() {
+				continue
+			}
+			end := in.RemovedAt
+			if end.IsZero() {
+				args["removed"] = false
+				end = t.end
+			}
+			t.span(tid, name, "fault", in.InstalledAt, end, args)
+		}
+	}
+
+	for _, c := range report.SuccessCriteria {
+		tid := t.thread("criterion: " + c.Name)
+		history := c.History
+		if len(history) == 0 && !c.EvalTime.IsZero() {
+			history = []EvaluationPoint{{Time: c.EvalTime, Value: c.Value, Passed: c.Passed}}
+		}
+		for _, h := range history {
+			name := "✓ " + c.Name
+			if !h.Passed {
+				name = "✗ " + c.Name
+			}
+			t.instant(tid, name, "criterion", h.Time, map[string]interface{}{"value": h.Value, "passed": h.Passed})
+			t.counter(c.Name, h.Time, h.Value)
+		}
+	}
+
+	if len(report.CollateralEvents) > 0 {
+		tid := t.thread("collateral")
+		for _, ev := range report.CollateralEvents {
+			args := map[string]interface{}{"container": ev.Container}
+			if ev.ExitCode != nil {
+				args["exit_code"] = *ev.ExitCode
+			}
+			t.instant(tid, ev.Container+" "+ev.Action, "collateral", ev.At, args)
+		}
+	}
+
+	if len(report.CleanupLog) > 0 {
+		tid := t.thread("cleanup")
+		for _, e := range report.CleanupLog {
+			args := map[string]interface{}{"target": e.Target, "success": e.Success}
+			if e.Details != "" {
+				args["details"] = e.Details
+			}
+			t.instant(tid, e.Action, "cleanup", e.Timestamp, args)
+		}
+	}
+
+	data, err := json.MarshalIndent(chromeTrace{
+		TraceEvents:     t.events,
+		DisplayTimeUnit: "ms",
+		OtherData: map[string]string{
+			"test_id":    report.TestID,
+			"scenario":   report.ScenarioName,
+			"start_time": report.StartTime.Format(time.RFC3339Nano),
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render trace: %w", err)
+	}
+	return data, nil
+}
+
+// TracePath maps a report's .json path to its .trace.json sibling.
+func TracePath(jsonPath string) string {
+	return strings.TrimSuffix(jsonPath, ".json") + ".trace.json"
+}
+
+// isTracePath reports whether path is a trace rather than a report.
+func isTracePath(path string) bool {
+	return strings.HasSuffix(path, ".trace.json")
+}
+
+// chromeTrace is the JSON object form of the trace event format.
+type chromeTrace struct {
+	TraceEvents     []traceEvent      `json:"traceEvents"`
+	DisplayTimeUnit string            `json:"displayTimeUnit"`
+	OtherData       map[string]string `json:"otherData"`
+}
+
+// traceEvent is one trace event; ph is X (span), i (instant), C (counter)
+// or M (metadata). ts and dur are microseconds.
+type traceEvent struct {
+	Name  string                 `json:"name"`
+	Cat   string                 `json:"cat,omitempty"`
+	Ph    string                 `json:"ph"`
+	Ts    int64                  `json:"ts"`
+	Dur   int64                  `json:"dur,omitempty"`
+	Pid   int                    `json:"pid"`
+	Tid   int                    `json:"tid"`
+	Scope string                 `json:"s,omitempty"`
+	Args  map[string]interface{} `json:"args,omitempty"`
+}
+
+// tracePid is the single process every track belongs to.
+const tracePid = 1
+
+// traceBuilder accumulates events and numbers tracks in creation order.
+type traceBuilder struct {
+	start, end time.Time
+	events     []traceEvent
+	threads    int
+}
+
+func (t *traceBuilder) ts(at time.Time) int64 {
+	return at.Sub(t.start).Microseconds()
+}
+
+// meta adds a metadata event naming the process (tid 0) or a thread.
+func (t *traceBuilder) meta(tid int, kind, name string) {
+	t.events = append(t.events, traceEvent{Name: kind, Ph: "M", Pid: tracePid, Tid: tid, Args: map[string]interface{}{"name": name}})
+}
+
+// thread starts a new track and returns its tid. Tracks are shown in
+// creation order.
+func (t *traceBuilder) thread(name string) int {
+	t.threads++
+	t.meta(t.threads, "thread_name", name)
+	t.events = append(t.events, traceEvent{Name: "thread_sort_index", Ph: "M", Pid: tracePid, Tid: t.threads, Args: map[string]interface{}{"sort_index": t.threads}})
+	return t.threads
+}
+
+// span adds a complete event. Spans are at least 1µs long so that
+// viewers do not drop them.
+func (t *traceBuilder) span(tid int, name, cat string, from, to time.Time, args map[string]interface{}) {
+	dur := to.Sub(from).Microseconds()
+	if dur < 1 {
+		dur = 1
+	}
+	t.events = append(t.events, traceEvent{Name: name, Cat: cat, Ph: "X", Ts: t.ts(from), Dur: dur, Pid: tracePid, Tid: tid, Args: args})
+}
+
+// instant adds a thread-scoped instant event.
+func (t *traceBuilder) instant(tid int, name, cat string, at time.Time, args map[string]interface{}) {
+	if at.IsZero() {
+		at = t.start
+	}
+	t.events = append(t.events, traceEvent{Name: name, Cat: cat, Ph: "i", Ts: t.ts(at), Pid: tracePid, Tid: tid, Scope: "t", Args: args})
+}
+
+// counter adds a sample to the counter track called name.
+func (t *traceBuilder) counter(name string, at time.Time, value float64) {
+	t.events = append(t.events, traceEvent{Name: name, Ph: "C", Ts: t.ts(at), Pid: tracePid, Args: map[string]interface{}{"value": value}})
+}
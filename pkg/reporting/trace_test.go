@@ -0,0 +1,94 @@
+package reporting
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRenderTrace(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := &TestReport{
+		TestID:       "test-1",
+		ScenarioName: "demo",
+		StartTime:    start,
+		EndTime:      start.Add(10 * time.Minute),
+		RunnerUsage: []PhaseUsageInfo{
+			{Phase: "INJECT", StartTime: start.Add(time.Minute), DurationSeconds: 2},
+			{Phase: "MONITOR", DurationSeconds: 300},
+		},
+		Faults: []FaultInfo{{
+			Phase: "latency",
+			Type:  "network",
+			Injections: []InjectionInfo{
+				{Target: "l2-el-1", Installed: true, InstalledAt: start.Add(62 * time.Second), RemovedAt: start.Add(5 * time.Minute)},
+				{Target: "l2-el-2", Installed: true, InstalledAt: start.Add(62 * time.Second)},
+				{Target: "l2-el-3", Error: "exec failed"},
+			},
+		}},
+		SuccessCriteria: []CriterionResult{{
+			Name: "block_production",
+			History: []EvaluationPoint{
+				{Time: start.Add(2 * time.Minute), Value: 1, Passed: true},
+				{Time: start.Add(3 * time.Minute), Value: 0, Passed: false},
+			},
+		}},
+	}
+
+	data, err := RenderTrace(report)
+	if err != nil {
+		t.Fatalf("RenderTrace: %v", err)
+	}
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(data, &trace); err != nil {
+		t.Fatalf("trace is not valid JSON: %v", err)
+	}
+
+	spans := map[string][]traceEvent{}
+	threads := map[int]string{}
+	var instants, counters int
+	for _, ev := range trace.TraceEvents {
+		switch ev.Ph {
+		case "X":
+			spans[threads[ev.Tid]] = append(spans[threads[ev.Tid]], ev)
+		case "i":
+			instants++
+		case "C":
+			counters++
+		case "M":
+			if ev.Name == "thread_name" {
+				threads[ev.Tid] = ev.Args["name"].(string)
+			}
+		}
+	}
+
+	states := spans["states"]
+	if len(states) != 2 || states[0].Ts != 60e6 || states[1].Ts != 62e6 || states[1].Dur != 300e6 {
+		t.Errorf("states = %+v, want INJECT at 60s and MONITOR at 62s for 300s", states)
+	}
+	if got := spans["latency → l2-el-1"]; len(got) != 1 || got[0].Ts != 62e6 || got[0].Dur != 238e6 {
+		t.Errorf("removed fault span = %+v, want 62s..300s", got)
+	}
+	// Never removed: the window runs to the end of the report.
+	if got := spans["latency → l2-el-2"]; len(got) != 1 || got[0].Ts+got[0].Dur != 600e6 || got[0].Args["removed"] != false {
+		t.Errorf("unremoved fault span = %+v, want it to end at 600s marked removed=false", got)
+	}
+	if got := spans["latency → l2-el-3"]; len(got) != 0 {
+		t.Errorf("failed install got a span: %+v", got)
+	}
+	if instants != 3 || counters != 2 {
+		t.Errorf("got %d instants and %d counters, want 3 (2 evaluations + 1 failed install) and 2", instants, counters)
+	}
+}
+
+func TestTracePath(t *testing.T) {
+	path := "reports/test-20260101-000000-test-1.json"
+	if got := TracePath(path); got != "reports/test-20260101-000000-test-1.trace.json" {
+		t.Errorf("TracePath = %q", got)
+	}
+	if !isTracePath(TracePath(path)) || isTracePath(path) {
+		t.Error("isTracePath does not tell traces from reports")
+	}
+}
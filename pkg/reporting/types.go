@@ -15,10 +15,32 @@ type TestReport struct {
 	EndTime      time.Time `json:"end_time"`
 	Duration     string    `json:"duration"`
 
+	// Scenario annotations (metadata.expected_impact, runbook_url, owner),
+	// so whoever finds the report from a chaos-induced alert has context.
+	ExpectedImpact string `json:"expected_impact,omitempty"`
+	RunbookURL     string `json:"runbook_url,omitempty"`
+	Owner          string `json:"owner,omitempty"`
+
+	// Approval is who confirmed a destructive scenario before INJECT.
+	Approval *ApprovalInfo `json:"approval,omitempty"`
+
 	// Test result
 	Status  TestStatus `json:"status"`
 	Success bool       `json:"success"`
 	Message string     `json:"message,omitempty"`
+	// Unknown is set when the run failed only because critical criteria
+	// could not be judged and the scenario sets treat_unknown_as: unknown.
+	Unknown bool `json:"unknown,omitempty"`
+	// Labels are operator-supplied run metadata (--label key=value), e.g.
+	// release, ticket or CI pipeline, used to attribute and filter runs.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// StuckPhase is the phase that exceeded its configured timeout and was
+	// force-failed, if any.
+	StuckPhase string `json:"stuck_phase,omitempty"`
+
+	// Environment fingerprint (enclave, image digests, host kernel)
+	Environment EnvironmentInfo `json:"environment"`
 
 	// Scenario details
 	Targets []TargetInfo `json:"targets"`
@@ -35,6 +57,25 @@ type TestReport struct {
 	// Success criteria evaluation
 	SuccessCriteria []CriterionResult `json:"success_criteria,omitempty"`
 
+	// BlastRadius compares targeted validators with the untouched ones.
+	BlastRadius *BlastRadiusInfo `json:"blast_radius,omitempty"`
+
+	// CollateralEvents are crashes, OOM kills and restarts of enclave
+	// containers no fault targeted, seen while faults were active.
+	CollateralEvents []CollateralEventInfo `json:"collateral_events,omitempty"`
+
+	// EnclaveResidue lists enclave containers that still carried chaos
+	// artifacts after teardown (execution.verify_enclave_cleanup).
+	EnclaveResidue []ResidueInfo `json:"enclave_residue,omitempty"`
+
+	// RunnerUsage is chaos-runner's own resource usage per phase, for
+	// judging whether the tool itself perturbed the system under test.
+	RunnerUsage []PhaseUsageInfo `json:"runner_usage,omitempty"`
+
+	// Detections are time-to-detect results for the scenario's
+	// spec.detection alerts and criteria.
+	Detections []DetectionInfo `json:"detections,omitempty"`
+
 	// Cleanup audit
 	CleanupSummary cleanup.CleanupSummary `json:"cleanup_summary"`
 	CleanupLog     []cleanup.AuditEntry   `json:"cleanup_log,omitempty"`
@@ -51,6 +92,9 @@ const (
 	StatusCompleted TestStatus = "completed"
 	StatusFailed    TestStatus = "failed"
 	StatusStopped   TestStatus = "stopped"
+	// StatusInterrupted is a run cut short by SIGINT/SIGTERM or the stop
+	// file; the report holds the partial results gathered before it.
+	StatusInterrupted TestStatus = "interrupted"
 )
 
 // TargetInfo contains information about a test target
@@ -59,6 +103,52 @@ type TargetInfo struct {
 	ServiceName string `json:"service_name"`
 	ContainerID string `json:"container_id"`
 	IP          string `json:"ip,omitempty"`
+	// Agent is the chaos-agent the target was reached through; empty for
+	// the local daemon.
+	Agent string `json:"agent,omitempty"`
+}
+
+// EnvironmentInfo identifies the build and host a test ran against, so that
+// historical reports can be compared per Bor/Heimdall image.
+type EnvironmentInfo struct {
+	EnclaveName string `json:"enclave_name,omitempty"`
+	// KurtosisCLIVersion is the Kurtosis CLI and engine version, not the
+	// version of the deployed package.
+	KurtosisCLIVersion string      `json:"kurtosis_cli_version,omitempty"`
+	RunnerVersion      string      `json:"runner_version"`
+	DockerVersion      string      `json:"docker_version,omitempty"`
+	HostKernel         string      `json:"host_kernel,omitempty"`
+	HostOS             string      `json:"host_os,omitempty"`
+	Images             []ImageInfo `json:"images,omitempty"`
+	// Topology is every service in the enclave at DISCOVER time; absent
+	// when targets were not Kurtosis services.
+	Topology *TopologyInfo `json:"topology,omitempty"`
+}
+
+// TopologyInfo snapshots the enclave so results can be compared across
+// devnets of different sizes.
+type TopologyInfo struct {
+	EnclaveID      string         `json:"enclave_id"`
+	ValidatorCount int            `json:"validator_count"`
+	RoleCounts     map[string]int `json:"role_counts"`
+	Services       []ServiceInfo  `json:"services"`
+}
+
+// ServiceInfo is one enclave service with its inferred role.
+type ServiceInfo struct {
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	Image string `json:"image,omitempty"`
+	State string `json:"state,omitempty"`
+}
+
+// ImageInfo records the image a target container was running.
+type ImageInfo struct {
+	Alias       string `json:"alias"`
+	ServiceName string `json:"service_name"`
+	Image       string `json:"image"`
+	ImageID     string `json:"image_id,omitempty"`
+	RepoDigest  string `json:"repo_digest,omitempty"`
 }
 
 // FaultInfo contains information about an injected fault
@@ -71,6 +161,148 @@ type FaultInfo struct {
 	EndTime     time.Time              `json:"end_time,omitempty"`
 	Duration    string                 `json:"duration,omitempty"`
 	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+
+	// Recoveries are per-target restart-to-healthy latencies, measured when
+	// a container_restart/container_kill fault sets verify_health.
+	Recoveries []RecoveryInfo `json:"recoveries,omitempty"`
+
+	// AppliedMethods are the methods the fault actually used per target,
+	// when it can fall back from the requested one (disk_io).
+	AppliedMethods []AppliedMethodInfo `json:"applied_methods,omitempty"`
+
+	// Captures are the pcaps recorded per target when the fault set
+	// capture: true.
+	Captures []CaptureInfo `json:"captures,omitempty"`
+
+	// FailurePolicy is the fault's failure_policy, when set.
+	FailurePolicy string `json:"failure_policy,omitempty"`
+	// Injections are per-target install outcomes.
+	Injections []InjectionInfo `json:"injections,omitempty"`
+}
+
+// InjectionInfo is whether the fault installed on one target.
+type InjectionInfo struct {
+	Target      string `json:"target"`
+	ContainerID string `json:"container_id"`
+	Installed   bool   `json:"installed"`
+	Error       string `json:"error,omitempty"`
+	// InstalledAt and RemovedAt bound the fault's window on the target;
+	// RemovedAt is zero when the fault was never removed.
+	InstalledAt time.Time `json:"installed_at,omitempty"`
+	RemovedAt   time.Time `json:"removed_at,omitempty"`
+}
+
+// CaptureInfo is one target's packet capture from the fault window.
+type CaptureInfo struct {
+	Target string `json:"target"`
+	Path   string `json:"path"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// AppliedMethodInfo is the method one target actually got.
+type AppliedMethodInfo struct {
+	Target   string `json:"target"`
+	Method   string `json:"method"`
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// RecoveryInfo is how long one target took to become healthy again after
+// a restart fault, from container start to the first passing check. The
+// timestamps are Docker's own, from its events.
+type RecoveryInfo struct {
+	Target                  string    `json:"target"`
+	RestartToHealthy        string    `json:"restart_to_healthy"`
+	RestartToHealthySeconds float64   `json:"restart_to_healthy_seconds"`
+	StoppedAt               time.Time `json:"stopped_at"`
+	StartedAt               time.Time `json:"started_at"`
+	HealthyAt               time.Time `json:"healthy_at"`
+}
+
+// CollateralEventInfo is one Docker event on a non-target container.
+type CollateralEventInfo struct {
+	Container string    `json:"container"`
+	Action    string    `json:"action"`
+	At        time.Time `json:"at"`
+	ExitCode  *int      `json:"exit_code,omitempty"`
+}
+
+// ResidueInfo is the chaos state left on one enclave container.
+type ResidueInfo struct {
+	Container   string `json:"container"`
+	ContainerID string `json:"container_id"`
+	Target      bool   `json:"target"`
+	// Artifacts summarises what was found, e.g. "qdiscs on eth0".
+	Artifacts string `json:"artifacts"`
+}
+
+// BlastRadiusInfo quantifies collateral impact: each metric's worst
+// reading since injection for the targets and for the control group of
+// validators no fault touched.
+type BlastRadiusInfo struct {
+	Window  string            `json:"window"`
+	Metrics []BlastMetricInfo `json:"metrics"`
+}
+
+// BlastMetricInfo is one metric compared across the two groups.
+type BlastMetricInfo struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Target      BlastGroupInfo `json:"target"`
+	Control     BlastGroupInfo `json:"control"`
+	// Collateral lists control nodes that degraded relative to their
+	// pre-injection reading.
+	Collateral []BlastNodeInfo `json:"collateral,omitempty"`
+}
+
+// BlastGroupInfo summarises one group's per-node worst readings.
+type BlastGroupInfo struct {
+	Nodes int     `json:"nodes"`
+	Worst float64 `json:"worst"`
+	Mean  float64 `json:"mean"`
+}
+
+// BlastNodeInfo is one collaterally impacted node.
+type BlastNodeInfo struct {
+	Job      string  `json:"job"`
+	Baseline float64 `json:"baseline"`
+	Worst    float64 `json:"worst"`
+}
+
+// PhaseUsageInfo is the runner's CPU, memory and Docker API usage during
+// one phase.
+type PhaseUsageInfo struct {
+	Phase           string    `json:"phase"`
+	StartTime       time.Time `json:"start_time,omitempty"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	CPUSeconds      float64   `json:"cpu_seconds"`
+	CPUPercent      float64   `json:"cpu_percent"`
+	HeapBytes       uint64    `json:"heap_bytes"`
+	MaxRSSBytes     uint64    `json:"max_rss_bytes"`
+	Goroutines      int       `json:"goroutines"`
+	DockerAPICalls  int64     `json:"docker_api_calls"`
+}
+
+// ApprovalInfo records the confirmation of a destructive scenario.
+type ApprovalInfo struct {
+	Method   string `json:"method"` // phrase or token
+	Approver string `json:"approver"`
+	Role     string `json:"role,omitempty"`
+	// TokenSHA256 fingerprints the approval token; the token itself is
+	// not stored.
+	TokenSHA256 string    `json:"token_sha256,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// DetectionInfo is how long after injection one monitoring signal first
+// reflected the fault.
+type DetectionInfo struct {
+	Signal         string    `json:"signal"`
+	Kind           string    `json:"kind"` // alert or criterion
+	Detected       bool      `json:"detected"`
+	DetectedAt     time.Time `json:"detected_at,omitempty"`
+	LatencySeconds float64   `json:"latency_seconds,omitempty"`
+	// PreExisting marks an alert firing since before injection.
+	PreExisting bool `json:"pre_existing,omitempty"`
 }
 
 // CriterionResult contains success criterion evaluation result
@@ -81,8 +313,22 @@ type CriterionResult struct {
 	Query       string    `json:"query,omitempty"`
 	Threshold   string    `json:"threshold,omitempty"`
 	Passed      bool      `json:"passed"`
+	Unknown     bool      `json:"unknown,omitempty"` // could not be judged: its scrape targets were down
 	Value       float64   `json:"value,omitempty"`
 	Message     string    `json:"message"`
 	Critical    bool      `json:"critical"`
 	EvalTime    time.Time `json:"eval_time"`
+
+	// Evaluation history: how many times the criterion was evaluated, how
+	// many of those failed, and every sample in order.
+	Evaluations int               `json:"evaluations,omitempty"`
+	Failures    int               `json:"failures,omitempty"`
+	History     []EvaluationPoint `json:"history,omitempty"`
+}
+
+// EvaluationPoint is one criterion evaluation.
+type EvaluationPoint struct {
+	Time   time.Time `json:"time"`
+	Value  float64   `json:"value"`
+	Passed bool      `json:"passed"`
 }
@@ -0,0 +1,163 @@
+package scenario
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ABGroup is one side of an A/B run: a short name used in reports and as a
+// suffix, and the selector pattern that picks the group's containers.
+type ABGroup struct {
+	Name    string
+	Pattern string
+}
+
+var abGroupName = regexp.MustCompile(`^[a-z0-9][a-z0-9_]*$`)
+
+// ABSuffix is what ABSplit appends to the alias, fault phases and criterion
+// names it duplicates for group g.
+func ABSuffix(g ABGroup) string {
+	return "_" + g.Name
+}
+
+// ABVariant returns a copy of s whose target alias selects g's containers,
+// for running the groups one after another.
+func ABVariant(s *Scenario, alias string, g ABGroup) (*Scenario, error) {
+	if err := checkABGroups(alias, g); err != nil {
+		return nil, err
+	}
+	out := s.clone()
+	i := out.targetIndex(alias)
+	if i < 0 {
+		return nil, fmt.Errorf("scenario has no target alias %q", alias)
+	}
+	out.Spec.Targets[i].Selector.Pattern = g.Pattern
+	return out, nil
+}
+
+// ABSplit returns a copy of s that runs both groups at once. The target
+// alias becomes one target per group, and every fault, target-scoped
+// criterion and log criterion on the alias is duplicated per group, with
+// ABSuffix appended to its alias, phase or name. Criteria that do not
+// reference the alias are shared by both groups.
+func ABSplit(s *Scenario, alias string, a, b ABGroup) (*Scenario, error) {
+	if err := checkABGroups(alias, a, b); err != nil {
+		return nil, err
+	}
+	if a.Name == b.Name {
+		return nil, fmt.Errorf("A/B group names must differ, both are %q", a.Name)
+	}
+	out := s.clone()
+	i := out.targetIndex(alias)
+	if i < 0 {
+		return nil, fmt.Errorf("scenario has no target alias %q", alias)
+	}
+	groups := []ABGroup{a, b}
+
+	var targets []Target
+	for j, t := range out.Spec.Targets {
+		if j != i {
+			targets = append(targets, t)
+			continue
+		}
+		for _, g := range groups {
+			gt := t
+			gt.Alias = alias + ABSuffix(g)
+			gt.Selector.Pattern = g.Pattern
+			targets = append(targets, gt)
+		}
+	}
+	out.Spec.Targets = targets
+
+	var faults []Fault
+	for _, f := range out.Spec.Faults {
+		if f.Target != alias {
+			faults = append(faults, f)
+			continue
+		}
+		for _, g := range groups {
+			gf := f
+			gf.Target = alias + ABSuffix(g)
+			gf.Phase = f.Phase + ABSuffix(g)
+			faults = append(faults, gf)
+		}
+	}
+	out.Spec.Faults = faults
+
+	split := make(map[string]bool)
+	var criteria []SuccessCriterion
+	for _, c := range out.Spec.SuccessCriteria {
+		if c.Target != alias && c.TargetLog != alias {
+			criteria = append(criteria, c)
+			continue
+		}
+		split[c.Name] = true
+		for _, g := range groups {
+			gc := c
+			gc.Name = c.Name + ABSuffix(g)
+			if c.Target == alias {
+				gc.Target = alias + ABSuffix(g)
+			}
+			if c.TargetLog == alias {
+				gc.TargetLog = alias + ABSuffix(g)
+			}
+			criteria = append(criteria, gc)
+		}
+	}
+	out.Spec.SuccessCriteria = criteria
+
+	if d := out.Spec.Detection; d != nil {
+		var names []string
+		for _, name := range d.Criteria {
+			if !split[name] {
+				names = append(names, name)
+				continue
+			}
+			for _, g := range groups {
+				names = append(names, name+ABSuffix(g))
+			}
+		}
+		d.Criteria = names
+	}
+	return out, nil
+}
+
+func checkABGroups(alias string, groups ...ABGroup) error {
+	if alias == "" {
+		return fmt.Errorf("A/B target alias is empty")
+	}
+	for _, g := range groups {
+		if !abGroupName.MatchString(g.Name) {
+			return fmt.Errorf("A/B group name %q must be lowercase letters, digits and underscores", g.Name)
+		}
+		if strings.TrimSpace(g.Pattern) == "" {
+			return fmt.Errorf("A/B group %q has no selector pattern", g.Name)
+		}
+	}
+	return nil
+}
+
+func (s *Scenario) targetIndex(alias string) int {
+	for i, t := range s.Spec.Targets {
+		if t.Alias == alias {
+			return i
+		}
+	}
+	return -1
+}
+
+// clone copies s deeply enough that the copy's targets, faults, criteria
+// and detection can be rewritten without touching s.
+func (s *Scenario) clone() *Scenario {
+	out := *s
+	out.Spec.Targets = append([]Target(nil), s.Spec.Targets...)
+	out.Spec.Faults = append([]Fault(nil), s.Spec.Faults...)
+	out.Spec.SuccessCriteria = append([]SuccessCriterion(nil), s.Spec.SuccessCriteria...)
+	if s.Spec.Detection != nil {
+		d := *s.Spec.Detection
+		d.Criteria = append([]string(nil), d.Criteria...)
+		out.Spec.Detection = &d
+	}
+	return &out
+}
@@ -0,0 +1,96 @@
+package scenario
+
+import "testing"
+
+func abScenario() *Scenario {
+	return &Scenario{Spec: ScenarioSpec{
+		Targets: []Target{
+			{Alias: "bor", Selector: TargetSelector{Type: "kurtosis_service", Pattern: "l2-el-1-bor"}},
+			{Alias: "heimdall", Selector: TargetSelector{Type: "kurtosis_service", Pattern: "l2-cl-1-heimdall"}},
+		},
+		Faults: []Fault{
+			{Phase: "kill", Target: "bor", Type: "container_kill"},
+			{Phase: "lag", Target: "heimdall", Type: "network"},
+		},
+		SuccessCriteria: []SuccessCriterion{
+			{Name: "recovers", Target: "bor", Metric: "chain_head_block"},
+			{Name: "consensus", Query: "up"},
+		},
+		Detection: &DetectionSpec{Criteria: []string{"recovers", "consensus"}},
+	}}
+}
+
+func TestABVariant(t *testing.T) {
+	s := abScenario()
+	v, err := ABVariant(s, "bor", ABGroup{Name: "new", Pattern: "l2-el-2-bor"})
+	if err != nil {
+		t.Fatalf("ABVariant() unexpected error: %v", err)
+	}
+	if got := v.Spec.Targets[0].Selector.Pattern; got != "l2-el-2-bor" {
+		t.Errorf("variant pattern = %q, want l2-el-2-bor", got)
+	}
+	if got := s.Spec.Targets[0].Selector.Pattern; got != "l2-el-1-bor" {
+		t.Errorf("original pattern changed to %q", got)
+	}
+	if _, err := ABVariant(s, "nope", ABGroup{Name: "a", Pattern: "x"}); err == nil {
+		t.Error("ABVariant() expected error for unknown alias")
+	}
+}
+
+func TestABSplit(t *testing.T) {
+	a, b := ABGroup{Name: "old", Pattern: "l2-el-1-bor"}, ABGroup{Name: "new", Pattern: "l2-el-2-bor"}
+	s := abScenario()
+	out, err := ABSplit(s, "bor", a, b)
+	if err != nil {
+		t.Fatalf("ABSplit() unexpected error: %v", err)
+	}
+
+	var aliases, phases, criteria []string
+	for _, tgt := range out.Spec.Targets {
+		aliases = append(aliases, tgt.Alias+"="+tgt.Selector.Pattern)
+	}
+	for _, f := range out.Spec.Faults {
+		phases = append(phases, f.Phase+"@"+f.Target)
+	}
+	for _, c := range out.Spec.SuccessCriteria {
+		criteria = append(criteria, c.Name+"@"+c.Target)
+	}
+
+	checks := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"targets", aliases, []string{"bor_old=l2-el-1-bor", "bor_new=l2-el-2-bor", "heimdall=l2-cl-1-heimdall"}},
+		{"faults", phases, []string{"kill_old@bor_old", "kill_new@bor_new", "lag@heimdall"}},
+		{"criteria", criteria, []string{"recovers_old@bor_old", "recovers_new@bor_new", "consensus@"}},
+		{"detection", out.Spec.Detection.Criteria, []string{"recovers_old", "recovers_new", "consensus"}},
+		{"original detection", s.Spec.Detection.Criteria, []string{"recovers", "consensus"}},
+	}
+	for _, c := range checks {
+		if len(c.got) != len(c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+			continue
+		}
+		for i := range c.want {
+			if c.got[i] != c.want[i] {
+				t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+				break
+			}
+		}
+	}
+
+	errCases := []struct {
+		name string
+		a, b ABGroup
+	}{
+		{"same names", a, ABGroup{Name: "old", Pattern: "x"}},
+		{"bad name", ABGroup{Name: "Old-1", Pattern: "x"}, b},
+		{"no pattern", a, ABGroup{Name: "new"}},
+	}
+	for _, c := range errCases {
+		if _, err := ABSplit(s, "bor", c.a, c.b); err == nil {
+			t.Errorf("%s: ABSplit() expected error", c.name)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Package builtin embeds a curated library of ready-made Polygon PoS
+// scenarios in the binary, so common experiments run without a checkout of
+// the scenarios tree (`chaos-runner run --builtin validator-isolation`).
+package builtin
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed scenarios/*.yaml
+var files embed.FS
+
+// Template is one built-in scenario.
+type Template struct {
+	// Name is the file name without extension, used with --builtin.
+	Name        string
+	Description string
+	Tags        []string
+}
+
+// List returns every built-in scenario, sorted by name.
+func List() ([]Template, error) {
+	entries, err := fs.ReadDir(files, "scenarios")
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]Template, 0, len(entries))
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), path.Ext(e.Name()))
+		data, err := Get(name)
+		if err != nil {
+			return nil, err
+		}
+		var doc struct {
+			Metadata struct {
+				Description string   `yaml:"description"`
+				Tags        []string `yaml:"tags"`
+			} `yaml:"metadata"`
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("built-in scenario %s: %w", name, err)
+		}
+		templates = append(templates, Template{
+			Name:        name,
+			Description: strings.TrimSpace(doc.Metadata.Description),
+			Tags:        doc.Metadata.Tags,
+		})
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Get returns the YAML of the named built-in scenario.
+func Get(name string) ([]byte, error) {
+	data, err := files.ReadFile("scenarios/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown built-in scenario %q (see `chaos-runner builtin list`)", name)
+	}
+	return data, nil
+}
@@ -0,0 +1,49 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario/parser"
+	"github.com/jihwankim/chaos-utils/pkg/scenario/validator"
+)
+
+// Every built-in scenario ships in the binary, so it must parse and pass
+// validation as-is.
+func TestBuiltinScenariosValidate(t *testing.T) {
+	templates, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) == 0 {
+		t.Fatal("no built-in scenarios embedded")
+	}
+
+	for _, tmpl := range templates {
+		t.Run(tmpl.Name, func(t *testing.T) {
+			data, err := Get(tmpl.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			scen, err := parser.New(map[string]string{"ENCLAVE_NAME": "test"}).Parse(data)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if scen.Metadata.Name != tmpl.Name {
+				t.Errorf("metadata.name = %q, want %q", scen.Metadata.Name, tmpl.Name)
+			}
+			v := validator.New()
+			if err := v.Validate(scen); err != nil {
+				t.Fatalf("validate: %v\n%s", err, v.GetReport())
+			}
+			if v.HasWarnings() {
+				t.Errorf("validation warnings:\n%s", v.GetReport())
+			}
+		})
+	}
+}
+
+func TestGetUnknown(t *testing.T) {
+	if _, err := Get("no-such-scenario"); err == nil {
+		t.Error("Get(unknown) succeeded, want error")
+	}
+}
@@ -0,0 +1,68 @@
+package scenario
+
+import (
+	"fmt"
+	"time"
+)
+
+// CaptureParams are accepted by every fault type. capture: true records the
+// targets' traffic with tcpdump in their sidecars for the fault window.
+var CaptureParams = []string{"capture", "capture_max_mb", "capture_max_duration"}
+
+const (
+	// DefaultCaptureMaxMB caps each target's pcap when capture_max_mb is unset.
+	DefaultCaptureMaxMB = 20
+	// DefaultCaptureMaxDuration stops a capture that teardown never reached.
+	DefaultCaptureMaxDuration = 30 * time.Minute
+)
+
+// Capture is a fault's packet capture settings.
+type Capture struct {
+	Enabled bool
+	// MaxBytes caps the pcap written per target.
+	MaxBytes int64
+	// MaxDuration stops tcpdump even if teardown never stops it.
+	MaxDuration time.Duration
+}
+
+// ParseCapture reads the capture params from a fault's params. A bare
+// number for capture_max_duration is seconds.
+func ParseCapture(params map[string]interface{}) (Capture, error) {
+	c := Capture{MaxBytes: DefaultCaptureMaxMB << 20, MaxDuration: DefaultCaptureMaxDuration}
+
+	if raw, present := params["capture"]; present {
+		enabled, ok := raw.(bool)
+		if !ok {
+			return Capture{}, fmt.Errorf("capture: unsupported type %T (expected true or false)", raw)
+		}
+		c.Enabled = enabled
+	}
+
+	if raw, present := params["capture_max_mb"]; present {
+		var mb float64
+		switch v := raw.(type) {
+		case int:
+			mb = float64(v)
+		case float64:
+			mb = v
+		default:
+			return Capture{}, fmt.Errorf("capture_max_mb: unsupported type %T (expected a number)", raw)
+		}
+		if mb <= 0 {
+			return Capture{}, fmt.Errorf("capture_max_mb must be positive")
+		}
+		c.MaxBytes = int64(mb * (1 << 20))
+	}
+
+	if raw, present := params["capture_max_duration"]; present {
+		d, err := ParseDurationParam(raw, time.Second)
+		if err != nil {
+			return Capture{}, fmt.Errorf("capture_max_duration: %w", err)
+		}
+		if d < time.Second {
+			return Capture{}, fmt.Errorf("capture_max_duration must be at least 1s")
+		}
+		c.MaxDuration = d
+	}
+	return c, nil
+}
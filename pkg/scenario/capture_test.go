@@ -0,0 +1,36 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCapture(t *testing.T) {
+	defaults := Capture{MaxBytes: DefaultCaptureMaxMB << 20, MaxDuration: DefaultCaptureMaxDuration}
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		want    Capture
+		wantErr bool
+	}{
+		{"none", nil, defaults, false},
+		{"enabled", map[string]interface{}{"capture": true}, Capture{Enabled: true, MaxBytes: defaults.MaxBytes, MaxDuration: defaults.MaxDuration}, false},
+		{"caps", map[string]interface{}{"capture": true, "capture_max_mb": 5, "capture_max_duration": "10m"}, Capture{Enabled: true, MaxBytes: 5 << 20, MaxDuration: 10 * time.Minute}, false},
+		{"bare duration seconds", map[string]interface{}{"capture_max_duration": 90}, Capture{MaxBytes: defaults.MaxBytes, MaxDuration: 90 * time.Second}, false},
+		{"capture not a bool", map[string]interface{}{"capture": "yes"}, Capture{}, true},
+		{"zero size", map[string]interface{}{"capture_max_mb": 0}, Capture{}, true},
+		{"sub-second duration", map[string]interface{}{"capture_max_duration": "500ms"}, Capture{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCapture(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCapture(%v) error = %v, wantErr %v", tt.params, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCapture(%v) = %+v, want %+v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package scenario
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TargetCount is how many of a selector's matched containers a target
+// takes, resolved against however many DISCOVER finds so scenarios stay
+// correct when the enclave's validator count changes. Accepted forms:
+//
+//	3      a fixed number
+//	"1/3"  a fraction of those found, rounded down
+//	"25%"  a percentage of those found, rounded down
+//	"f"    the BFT fault tolerance floor((n-1)/3): the most validators a
+//	       quorum survives losing
+//	"f+1"  one more than that: the fewest that halt the quorum
+//
+// The zero value takes every match.
+type TargetCount struct {
+	raw string
+	// resolve maps the number of matched containers to the number taken;
+	// nil takes all.
+	resolve func(n int) int
+}
+
+// ParseTargetCount parses one of the forms TargetCount accepts.
+func ParseTargetCount(s string) (TargetCount, error) {
+	s = strings.TrimSpace(s)
+	c := TargetCount{raw: s}
+	switch {
+	case s == "" || s == "0":
+		return TargetCount{}, nil
+	case s == "f":
+		c.resolve = func(n int) int { return (n - 1) / 3 }
+	case s == "f+1":
+		c.resolve = func(n int) int { return (n-1)/3 + 1 }
+	case strings.HasSuffix(s, "%"):
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil || pct <= 0 || pct > 100 {
+			return TargetCount{}, fmt.Errorf("invalid count %q: percentage must be in (0, 100]", s)
+		}
+		c.resolve = func(n int) int { return int(float64(n) * pct / 100) }
+	case strings.Contains(s, "/"):
+		num, den, _ := strings.Cut(s, "/")
+		a, errA := strconv.Atoi(strings.TrimSpace(num))
+		b, errB := strconv.Atoi(strings.TrimSpace(den))
+		if errA != nil || errB != nil || a <= 0 || b <= 0 || a > b {
+			return TargetCount{}, fmt.Errorf("invalid count %q: fraction must be a/b with 0 < a <= b", s)
+		}
+		c.resolve = func(n int) int { return n * a / b }
+	default:
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			return TargetCount{}, fmt.Errorf("invalid count %q (expected a number, \"a/b\", \"N%%\", \"f\" or \"f+1\")", s)
+		}
+		c.resolve = func(int) int { return v }
+	}
+	return c, nil
+}
+
+// IsZero reports whether no count was set.
+func (c TargetCount) IsZero() bool {
+	return c.resolve == nil
+}
+
+// Fixed returns the count when it is a plain number rather than relative
+// to the number matched.
+func (c TargetCount) Fixed() (int, bool) {
+	if c.resolve == nil {
+		return 0, false
+	}
+	v, err := strconv.Atoi(c.raw)
+	return v, err == nil
+}
+
+// String returns the count as written.
+func (c TargetCount) String() string {
+	return c.raw
+}
+
+// Resolve returns how many of n matched containers to take. It errors when
+// the count resolves to zero (e.g. "f" with fewer than four validators),
+// since a target that selects nothing is never intended.
+func (c TargetCount) Resolve(n int) (int, error) {
+	if c.resolve == nil {
+		return n, nil
+	}
+	k := c.resolve(n)
+	if k > n {
+		k = n
+	}
+	if k <= 0 {
+		return 0, fmt.Errorf("count %q resolves to 0 of %d matched container(s)", c.raw, n)
+	}
+	return k, nil
+}
+
+// UnmarshalYAML accepts a bare integer or one of the string forms.
+func (c *TargetCount) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("count must be a number or a string like \"1/3\" or \"f\"")
+	}
+	parsed, err := ParseTargetCount(node.Value)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// MarshalYAML writes the count back as written, as a number when it is one.
+func (c TargetCount) MarshalYAML() (interface{}, error) {
+	if v, err := strconv.Atoi(c.raw); err == nil {
+		return v, nil
+	}
+	return c.raw, nil
+}
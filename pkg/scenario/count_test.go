@@ -0,0 +1,73 @@
+package scenario
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTargetCount_Resolve(t *testing.T) {
+	tests := []struct {
+		count   string
+		n       int
+		want    int
+		wantErr bool
+	}{
+		{"", 5, 5, false},
+		{"2", 5, 2, false},
+		{"8", 5, 5, false},
+		{"f", 4, 1, false},
+		{"f", 7, 2, false},
+		{"f", 3, 0, true},
+		{"f+1", 4, 2, false},
+		{"f+1", 3, 1, false},
+		{"1/3", 6, 2, false},
+		{"1/3", 2, 0, true},
+		{"25%", 8, 2, false},
+		{"100%", 3, 3, false},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseTargetCount(tt.count)
+		if err != nil {
+			t.Fatalf("ParseTargetCount(%q) error = %v", tt.count, err)
+		}
+		got, err := c.Resolve(tt.n)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("count %q of %d: error = %v, wantErr %v", tt.count, tt.n, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("count %q of %d = %d, want %d", tt.count, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestParseTargetCount_Invalid(t *testing.T) {
+	for _, s := range []string{"-1", "two", "2/1", "0/3", "1/0", "0%", "150%", "f+2"} {
+		if _, err := ParseTargetCount(s); err == nil {
+			t.Errorf("ParseTargetCount(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestTargetCount_YAML(t *testing.T) {
+	var target Target
+	if err := yaml.Unmarshal([]byte("alias: v\ncount: 2\n"), &target); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := target.Count.Fixed(); !ok || got != 2 {
+		t.Errorf("count: 2 parsed as %v (fixed=%v)", got, ok)
+	}
+
+	if err := yaml.Unmarshal([]byte("alias: v\ncount: f\n"), &target); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := target.Count.Resolve(10); got != 3 {
+		t.Errorf("count: f of 10 = %d, want 3", got)
+	}
+
+	if err := yaml.Unmarshal([]byte("alias: v\ncount: [1]\n"), &target); err == nil {
+		t.Error("sequence count accepted, want error")
+	}
+}
@@ -0,0 +1,98 @@
+package scenario
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FailurePolicy decides whether a fault that failed to install on some of
+// its targets ends the test. Accepted forms:
+//
+//	abort           any failed target fails the test (the default)
+//	continue        the test goes on with whatever installed
+//	min_targets(N)  the test goes on when at least N targets installed
+//
+// Under continue and min_targets each target is injected on its own, so
+// one target's failure does not stop the rest.
+type FailurePolicy struct {
+	raw string
+	// minTargets is how many targets must install; -1 fails on any error.
+	minTargets int
+}
+
+// ParseFailurePolicy parses one of the forms FailurePolicy accepts.
+func ParseFailurePolicy(s string) (FailurePolicy, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "" || s == "abort":
+		return FailurePolicy{raw: s, minTargets: -1}, nil
+	case s == "continue":
+		return FailurePolicy{raw: s}, nil
+	case strings.HasPrefix(s, "min_targets(") && strings.HasSuffix(s, ")"):
+		n, err := strconv.Atoi(strings.TrimSpace(s[len("min_targets(") : len(s)-1]))
+		if err != nil || n < 1 {
+			return FailurePolicy{}, fmt.Errorf("invalid failure_policy %q: min_targets needs a positive number", s)
+		}
+		return FailurePolicy{raw: s, minTargets: n}, nil
+	}
+	return FailurePolicy{}, fmt.Errorf("invalid failure_policy %q (expected abort, continue or min_targets(N))", s)
+}
+
+// Partial reports whether targets are injected one by one and failures
+// may be tolerated, i.e. the policy is not abort.
+func (p FailurePolicy) Partial() bool {
+	return p.raw != "" && p.minTargets >= 0
+}
+
+// MinTargets returns N for min_targets(N), and 0 otherwise.
+func (p FailurePolicy) MinTargets() int {
+	if p.minTargets < 0 {
+		return 0
+	}
+	return p.minTargets
+}
+
+// Check returns an error when installed of total targets is not enough for
+// the policy to let the test continue.
+func (p FailurePolicy) Check(installed, total int) error {
+	switch {
+	case installed == total:
+		return nil
+	case !p.Partial():
+		return fmt.Errorf("%d of %d target(s) failed", total-installed, total)
+	case installed < p.minTargets:
+		return fmt.Errorf("only %d of %d target(s) installed, %s", installed, total, p.raw)
+	}
+	return nil
+}
+
+// IsZero reports whether no policy was set, so omitempty leaves it out.
+func (p FailurePolicy) IsZero() bool {
+	return p.raw == ""
+}
+
+// String returns the policy as written.
+func (p FailurePolicy) String() string {
+	return p.raw
+}
+
+// UnmarshalYAML accepts one of the string forms.
+func (p *FailurePolicy) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("failure_policy must be abort, continue or min_targets(N)")
+	}
+	parsed, err := ParseFailurePolicy(node.Value)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalYAML writes the policy back as written.
+func (p FailurePolicy) MarshalYAML() (interface{}, error) {
+	return p.raw, nil
+}
@@ -0,0 +1,58 @@
+package scenario
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestFailurePolicy_Check(t *testing.T) {
+	tests := []struct {
+		policy           string
+		installed, total int
+		wantErr          bool
+	}{
+		{"", 3, 3, false},
+		{"", 2, 3, true},
+		{"abort", 2, 3, true},
+		{"continue", 2, 3, false},
+		{"continue", 0, 3, false},
+		{"min_targets(2)", 2, 3, false},
+		{"min_targets(2)", 1, 3, true},
+		{"min_targets(5)", 3, 3, false},
+	}
+	for _, tt := range tests {
+		p, err := ParseFailurePolicy(tt.policy)
+		if err != nil {
+			t.Fatalf("ParseFailurePolicy(%q) error = %v", tt.policy, err)
+		}
+		if err := p.Check(tt.installed, tt.total); (err != nil) != tt.wantErr {
+			t.Errorf("%q with %d/%d installed: error = %v, wantErr %v", tt.policy, tt.installed, tt.total, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFailurePolicy_Parse(t *testing.T) {
+	for _, bad := range []string{"ignore", "min_targets(0)", "min_targets(x)", "min_targets 2"} {
+		if _, err := ParseFailurePolicy(bad); err == nil {
+			t.Errorf("ParseFailurePolicy(%q) succeeded, want error", bad)
+		}
+	}
+
+	var f Fault
+	if err := yaml.Unmarshal([]byte("failure_policy: min_targets(3)"), &f); err != nil {
+		t.Fatal(err)
+	}
+	if !f.FailurePolicy.Partial() || f.FailurePolicy.MinTargets() != 3 {
+		t.Errorf("failure_policy = %q, want partial min_targets(3)", f.FailurePolicy)
+	}
+
+	out, err := yaml.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Fault
+	if err := yaml.Unmarshal(out, &back); err != nil || back.FailurePolicy != f.FailurePolicy {
+		t.Errorf("failure_policy did not round-trip: %s", out)
+	}
+}
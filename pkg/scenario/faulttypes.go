@@ -0,0 +1,260 @@
+package scenario
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// FaultTypeInfo describes one fault type: its canonical name, the aliases
+// scenarios may use instead, the params the injector reads, and what the
+// fault needs from the platform. It is the single source of truth for the
+// validator, the injector dispatch, and anything else that enumerates
+// fault types.
+type FaultTypeInfo struct {
+	// Name is the canonical type string.
+	Name string
+	// Aliases resolve to Name (e.g. "cpu" → "cpu_stress").
+	Aliases []string
+	// Params lists every params key the injector reads.
+	Params []string
+	// OpenParams means params are passed through to something else
+	// (external providers, registered plugins) and not checked by key.
+	OpenParams bool
+
+	// Reversible is true when teardown has something to undo. One-shot
+	// faults (restart, kill, process_kill) are not.
+	Reversible bool
+	// RequiresLinux is true when the fault runs Linux tooling in the
+	// target or its sidecar; false only for Docker-API lifecycle faults
+	// and faults delegated outside the runner.
+	RequiresLinux bool
+	// UsesSidecar is true when injection runs in the target's sidecar
+	// (network namespace tooling) rather than in the target itself.
+	UsesSidecar bool
+	// Pods is true when the fault can target Kubernetes pods: it needs
+	// nothing but the pod's network namespace, which the ephemeral debug
+	// container standing in for the sidecar shares.
+	Pods bool
+	// Umbrella marks legacy category names the validator accepts but
+	// nothing injects; scenarios should use a specific type.
+	Umbrella bool
+}
+
+var (
+	faultTypesMu sync.RWMutex
+	faultTypes   = map[string]*FaultTypeInfo{} // canonical name → info
+	faultAliases = map[string]string{}         // alias or name → canonical name
+)
+
+func init() {
+	for _, info := range builtinFaultTypes {
+		mustRegisterFaultType(info)
+	}
+}
+
+var builtinFaultTypes = []FaultTypeInfo{
+	{
+		Name: "network",
+		Params: []string{"device", "all_interfaces", "profile", "latency", "jitter", "packet_loss", "bandwidth", "reorder",
+			"reorder_correlation", "reorder_gap", "corrupt", "duplicate", "target_ports", "target_proto", "at_boot"},
+		Reversible: true, RequiresLinux: true, UsesSidecar: true, Pods: true,
+	},
+	{
+		Name:       "connection_drop",
+		Params:     []string{"rule_type", "target_ports", "target_proto", "probability", "at_boot"},
+		Reversible: true, RequiresLinux: true, UsesSidecar: true, Pods: true,
+	},
+	{
+		Name:       "network_partition",
+		Params:     []string{"peers", "peer_ips"},
+		Reversible: true, RequiresLinux: true, UsesSidecar: true, Pods: true,
+	},
+	{
+		Name:       "dns",
+		Params:     []string{"delay_ms", "failure_rate", "at_boot"},
+		Reversible: true, RequiresLinux: true, UsesSidecar: true, Pods: true,
+	},
+	{
+		Name:   "container_restart",
+		Params: []string{"grace_period", "restart_delay", "verify_health"},
+	},
+	{
+		Name:   "container_kill",
+		Params: []string{"signal", "restart", "restart_delay", "verify_health"},
+	},
+	{
+		Name:          "drain",
+		Params:        []string{"ports", "grace_window", "grace_period", "verify_health"},
+		RequiresLinux: true, UsesSidecar: true,
+	},
+	{
+		Name:       "scrape_block",
+		Params:     []string{"ports"},
+		Reversible: true, RequiresLinux: true, UsesSidecar: true, Pods: true,
+	},
+	{
+		Name:       "container_pause",
+		Params:     []string{"duration", "unpause"},
+		Reversible: true,
+	},
+	{
+		Name:          "process_kill",
+		Params:        []string{"process_pattern", "signal", "kill_children", "count", "interval"},
+		RequiresLinux: true,
+	},
+	{
+		Name:       "cpu_stress",
+		Aliases:    []string{"cpu"},
+		Params:     []string{"method", "cpu_percent", "cores"},
+		Reversible: true, RequiresLinux: true, UsesSidecar: true,
+	},
+	{
+		Name:       "memory_stress",
+		Aliases:    []string{"memory", "memory_pressure"},
+		Params:     []string{"method", "memory_mb"},
+		Reversible: true, RequiresLinux: true,
+	},
+	{
+		Name:       "disk_io",
+		Params:     []string{"io_latency_ms", "target_path", "operation", "method"},
+		Reversible: true, RequiresLinux: true,
+	},
+	{
+		Name:       "disk_fill",
+		Params:     []string{"fill_percent", "target_path", "size_mb", "file_name"},
+		Reversible: true, RequiresLinux: true,
+	},
+	{
+		Name:       "file_delete",
+		Params:     []string{"target_path", "recursive", "backup_first"},
+		Reversible: true, RequiresLinux: true,
+	},
+	{
+		Name:       "file_corrupt",
+		Params:     []string{"target_path", "backup_first", "corrupt_bytes", "corrupt_offset", "method"},
+		Reversible: true, RequiresLinux: true,
+	},
+	{
+		Name:       "clock_skew",
+		Params:     []string{"offset", "disable_ntp"},
+		Reversible: true, RequiresLinux: true,
+	},
+	{
+		Name: "http_fault",
+		Params: []string{"target_port", "abort_code", "abort_percent", "delay_ms", "delay_percent",
+			"body_override", "header_overrides", "path_pattern"},
+		Reversible: true, RequiresLinux: true, UsesSidecar: true,
+	},
+	{
+		Name:       "corruption_proxy",
+		Params:     []string{"target_port", "rules_yaml"},
+		Reversible: true, RequiresLinux: true, UsesSidecar: true,
+	},
+	{
+		Name:          "p2p_attack",
+		Params:        []string{"attack", "enode_url", "rpc_url", "fork_block", "count", "interval"},
+		RequiresLinux: true, UsesSidecar: true,
+	},
+	{
+		Name:       "txpool_saturation",
+		Params:     []string{"private_key", "rate", "count", "gas_price_gwei", "rpc_port"},
+		Reversible: true, RequiresLinux: true, UsesSidecar: true,
+	},
+	{
+		Name:       "external",
+		OpenParams: true,
+		Reversible: true,
+	},
+	{Name: "disk", Umbrella: true, OpenParams: true},
+	{Name: "process", Umbrella: true, OpenParams: true},
+	{Name: "custom", Umbrella: true, OpenParams: true},
+}
+
+// RegisterFaultType adds a fault type to the registry. It returns an error
+// if the name or any alias is already taken.
+func RegisterFaultType(info FaultTypeInfo) error {
+	if info.Name == "" {
+		return fmt.Errorf("fault type name is required")
+	}
+
+	faultTypesMu.Lock()
+	defer faultTypesMu.Unlock()
+
+	for _, name := range append([]string{info.Name}, info.Aliases...) {
+		if existing, taken := faultAliases[name]; taken {
+			return fmt.Errorf("fault type %q is already registered (as %q)", name, existing)
+		}
+	}
+
+	stored := info
+	faultTypes[info.Name] = &stored
+	faultAliases[info.Name] = info.Name
+	for _, alias := range info.Aliases {
+		faultAliases[alias] = info.Name
+	}
+	return nil
+}
+
+func mustRegisterFaultType(info FaultTypeInfo) {
+	if err := RegisterFaultType(info); err != nil {
+		panic(err)
+	}
+}
+
+// LookupFaultType resolves a type string or alias to its info.
+func LookupFaultType(name string) (FaultTypeInfo, bool) {
+	faultTypesMu.RLock()
+	defer faultTypesMu.RUnlock()
+	canonical, ok := faultAliases[name]
+	if !ok {
+		return FaultTypeInfo{}, false
+	}
+	return *faultTypes[canonical], true
+}
+
+// CanonicalFaultType returns the canonical name for a type or alias, or
+// name unchanged when it is not registered.
+func CanonicalFaultType(name string) string {
+	if info, ok := LookupFaultType(name); ok {
+		return info.Name
+	}
+	return name
+}
+
+// FaultTypes returns every registered fault type sorted by name.
+func FaultTypes() []FaultTypeInfo {
+	faultTypesMu.RLock()
+	defer faultTypesMu.RUnlock()
+	out := make([]FaultTypeInfo, 0, len(faultTypes))
+	for _, info := range faultTypes {
+		out = append(out, *info)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// HasParam reports whether key is a known param for this type, including
+// the SchedulingParams and CaptureParams every type accepts. Types with OpenParams accept any
+// key.
+func (f FaultTypeInfo) HasParam(key string) bool {
+	if f.OpenParams {
+		return true
+	}
+	for _, p := range SchedulingParams {
+		if p == key {
+			return true
+		}
+	}
+	for _, p := range CaptureParams {
+		if p == key {
+			return true
+		}
+	}
+	for _, p := range f.Params {
+		if p == key {
+			return true
+		}
+	}
+	return false
+}
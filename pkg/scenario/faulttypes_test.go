@@ -0,0 +1,54 @@
+package scenario
+
+import "testing"
+
+func TestLookupFaultType(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantName  string
+		wantFound bool
+	}{
+		{"network", "network", true},
+		{"cpu", "cpu_stress", true},
+		{"memory_pressure", "memory_stress", true},
+		{"memory", "memory_stress", true},
+		{"disk_io_stress", "", false},
+	}
+
+	for _, tt := range tests {
+		info, ok := LookupFaultType(tt.name)
+		if ok != tt.wantFound || info.Name != tt.wantName {
+			t.Errorf("LookupFaultType(%q) = (%q, %v), want (%q, %v)", tt.name, info.Name, ok, tt.wantName, tt.wantFound)
+		}
+	}
+
+	if got := CanonicalFaultType("unknown_type"); got != "unknown_type" {
+		t.Errorf("CanonicalFaultType should pass unknown names through, got %q", got)
+	}
+}
+
+func TestRegisterFaultType_RejectsCollisions(t *testing.T) {
+	if err := RegisterFaultType(FaultTypeInfo{Name: "cpu"}); err == nil {
+		t.Error("expected error registering a name that is a built-in alias")
+	}
+	if err := RegisterFaultType(FaultTypeInfo{Name: "test_only_fault", Aliases: []string{"network"}}); err == nil {
+		t.Error("expected error registering an alias that is a built-in name")
+	}
+	if _, ok := LookupFaultType("test_only_fault"); ok {
+		t.Error("a rejected registration must not be partially applied")
+	}
+}
+
+func TestFaultTypeInfo_HasParam(t *testing.T) {
+	network, _ := LookupFaultType("network")
+	if !network.HasParam("packet_loss") || network.HasParam("packet_los") {
+		t.Error("network params not matched exactly")
+	}
+	if !network.HasParam("batch_percent") {
+		t.Error("scheduling params must be accepted by every type")
+	}
+	external, _ := LookupFaultType("external")
+	if !external.HasParam("anything") {
+		t.Error("open-params types must accept any key")
+	}
+}
@@ -0,0 +1,93 @@
+package scenario
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NetworkProfile is a named combination of netem settings modelling a
+// real-world link. Values are applied on the target's egress, so Latency
+// is added one way; the round trip grows by it once per faulted end.
+type NetworkProfile struct {
+	Description string
+	// Latency and Jitter are in milliseconds.
+	Latency int
+	Jitter  int
+	// PacketLoss is a percentage (0-100).
+	PacketLoss float64
+	// Bandwidth is in kbit/s; 0 leaves the rate unlimited.
+	Bandwidth int
+}
+
+// networkProfiles are the curated profiles a network fault selects with
+// `profile: <name>`.
+var networkProfiles = map[string]NetworkProfile{
+	"3g": {
+		Description: "Mobile 3G: high latency, modest jitter and loss, sub-megabit rate",
+		Latency:     100, Jitter: 30, PacketLoss: 1, Bandwidth: 750,
+	},
+	"lte": {
+		Description: "Mobile LTE: moderate latency with cell-handover jitter",
+		Latency:     40, Jitter: 10, PacketLoss: 0.2, Bandwidth: 12000,
+	},
+	"satellite": {
+		Description: "Geostationary satellite: ~600ms one way, some loss",
+		Latency:     600, Jitter: 40, PacketLoss: 0.5, Bandwidth: 5000,
+	},
+	"transatlantic": {
+		Description: "Cross-Atlantic datacenter link (~80ms RTT when both ends are faulted)",
+		Latency:     40, Jitter: 5, PacketLoss: 0.1,
+	},
+	"transpacific": {
+		Description: "Cross-Pacific datacenter link (~150ms RTT when both ends are faulted)",
+		Latency:     75, Jitter: 10, PacketLoss: 0.2,
+	},
+	"lossy_wifi": {
+		Description: "Congested Wi-Fi: low latency, heavy jitter and loss",
+		Latency:     20, Jitter: 15, PacketLoss: 3, Bandwidth: 20000,
+	},
+}
+
+// NetworkProfiles returns the names of all network profiles, sorted.
+func NetworkProfiles() []string {
+	names := make([]string, 0, len(networkProfiles))
+	for name := range networkProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupNetworkProfile returns the named network profile.
+func LookupNetworkProfile(name string) (NetworkProfile, bool) {
+	p, ok := networkProfiles[name]
+	return p, ok
+}
+
+// ExpandNetworkProfile fills the latency, jitter, packet_loss and bandwidth
+// params a network fault leaves unset from its `profile` param, so
+// explicit params override the profile. It is a no-op for other fault
+// types and faults without a profile, and returns an error for an unknown
+// profile name.
+func ExpandNetworkProfile(f *Fault) error {
+	raw, present := f.Params["profile"]
+	if !present || CanonicalFaultType(f.Type) != "network" {
+		return nil
+	}
+	name, _ := raw.(string)
+	p, ok := networkProfiles[name]
+	if !ok {
+		return fmt.Errorf("unknown network profile %v (available: %v)", raw, NetworkProfiles())
+	}
+
+	set := func(key string, v interface{}, zero bool) {
+		if _, exists := f.Params[key]; !exists && !zero {
+			f.Params[key] = v
+		}
+	}
+	set("latency", p.Latency, p.Latency == 0)
+	set("jitter", p.Jitter, p.Jitter == 0)
+	set("packet_loss", p.PacketLoss, p.PacketLoss == 0)
+	set("bandwidth", p.Bandwidth, p.Bandwidth == 0)
+	return nil
+}
@@ -0,0 +1,74 @@
+package scenario
+
+import "testing"
+
+func TestExpandNetworkProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		fault   Fault
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:  "fills from profile",
+			fault: Fault{Type: "network", Params: map[string]interface{}{"profile": "transatlantic"}},
+			want:  map[string]interface{}{"profile": "transatlantic", "latency": 40, "jitter": 5, "packet_loss": 0.1},
+		},
+		{
+			name:  "explicit params override",
+			fault: Fault{Type: "network", Params: map[string]interface{}{"profile": "3g", "latency": "250ms", "bandwidth": 0}},
+			want:  map[string]interface{}{"profile": "3g", "latency": "250ms", "jitter": 30, "packet_loss": 1.0, "bandwidth": 0},
+		},
+		{
+			name:  "other fault types untouched",
+			fault: Fault{Type: "dns", Params: map[string]interface{}{"profile": "3g"}},
+			want:  map[string]interface{}{"profile": "3g"},
+		},
+		{
+			name:    "unknown profile",
+			fault:   Fault{Type: "network", Params: map[string]interface{}{"profile": "carrier_pigeon"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.fault
+			err := ExpandNetworkProfile(&f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandNetworkProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(f.Params) != len(tt.want) {
+				t.Errorf("Params = %v, want %v", f.Params, tt.want)
+			}
+			for k, v := range tt.want {
+				if f.Params[k] != v {
+					t.Errorf("Params[%s] = %v, want %v", k, f.Params[k], v)
+				}
+			}
+		})
+	}
+}
+
+// A profile's netem jitter must not be read as the scheduling
+// stagger_jitter: the fault stays unscheduled and keeps its jitter.
+func TestExpandNetworkProfile_JitterIsNotScheduling(t *testing.T) {
+	f := Fault{Type: "network", Params: map[string]interface{}{"profile": "3g"}}
+	if err := ExpandNetworkProfile(&f); err != nil {
+		t.Fatalf("ExpandNetworkProfile() error = %v", err)
+	}
+
+	schedule, err := ParseSchedule(f.Params)
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+	if schedule.Active() {
+		t.Errorf("ParseSchedule() = %+v, want inactive schedule", schedule)
+	}
+	if got := ParseNetworkParams(f.Params).Jitter; got != 30 {
+		t.Errorf("ParseNetworkParams().Jitter = %d, want 30", got)
+	}
+}
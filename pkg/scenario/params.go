@@ -0,0 +1,163 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseDurationParam converts a raw fault parameter into a time.Duration.
+//
+// Accepts:
+//   - string ("250ms", "2s") → time.ParseDuration
+//   - bare int / int64 / float64 → multiplied by unit
+//
+// unit is the scale of bare numbers: time.Millisecond for the legacy *_ms and
+// latency fields, time.Second for container_pause duration. Strings always
+// carry their own unit, which is the point — `latency: 2` meaning 2ms while
+// `duration: 2` means 2s has bitten more than one scenario author.
+func ParseDurationParam(raw interface{}, unit time.Duration) (time.Duration, error) {
+	switch v := raw.(type) {
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", v, err)
+		}
+		return d, nil
+	case int:
+		return time.Duration(v) * unit, nil
+	case int64:
+		return time.Duration(v) * unit, nil
+	case float64:
+		return time.Duration(v * float64(unit)), nil
+	default:
+		return 0, fmt.Errorf("unsupported type %T (expected duration string like \"250ms\" or a number)", raw)
+	}
+}
+
+// ParseMillisParam is ParseDurationParam for fields that are stored as integer
+// milliseconds downstream (latency, delay_ms, io_latency_ms). Sub-millisecond
+// precision is truncated.
+func ParseMillisParam(raw interface{}) (int, error) {
+	d, err := ParseDurationParam(raw, time.Millisecond)
+	if err != nil {
+		return 0, err
+	}
+	return int(d / time.Millisecond), nil
+}
+
+// ParseListParam converts a raw fault parameter into a list of strings.
+// Accepts a comma-separated string or a YAML list of strings; blank
+// entries are dropped and an absent param is an empty list.
+func ParseListParam(raw interface{}) ([]string, error) {
+	var items []string
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		items = strings.Split(v, ",")
+	case []string:
+		items = v
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported list item %v (expected a string)", item)
+			}
+			items = append(items, s)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %T (expected a comma-separated string or a list)", raw)
+	}
+	var list []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list, nil
+}
+
+// secretParams are fault params that hold credentials, such as
+// txpool_saturation's funded key.
+var secretParams = map[string]bool{"private_key": true}
+
+// RedactParams returns a copy of params with secret values replaced, for
+// anything that leaves the process: reports, audit events and the run
+// state file. Injection itself needs the originals.
+func RedactParams(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		if secretParams[k] {
+			v = "<redacted>"
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// HealthCheckParam is the parsed verify_health param of container_restart
+// and container_kill: what has to pass before a revived container counts as
+// recovered. With neither URL set, the container's Docker healthcheck (if it
+// has one) decides.
+type HealthCheckParam struct {
+	// HTTPURL must answer GET with a 2xx status.
+	HTTPURL string
+	// RPCURL must answer a JSON-RPC call of RPCMethod (default
+	// eth_blockNumber) with a result rather than an error.
+	RPCURL    string
+	RPCMethod string
+	// Timeout bounds the wait (default 5m).
+	Timeout time.Duration
+}
+
+// ParseHealthCheckParam converts a raw verify_health value. Accepts a bool
+// (true = Docker healthcheck only, false = off) or a map with http_url,
+// rpc_url, rpc_method and timeout. Returns nil when verification is off.
+func ParseHealthCheckParam(raw interface{}) (*HealthCheckParam, error) {
+	hc := &HealthCheckParam{RPCMethod: "eth_blockNumber", Timeout: 5 * time.Minute}
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if !v {
+			return nil, nil
+		}
+		return hc, nil
+	case map[string]interface{}:
+		for key, val := range v {
+			switch key {
+			case "http_url", "rpc_url", "rpc_method":
+				s, ok := val.(string)
+				if !ok || s == "" {
+					return nil, fmt.Errorf("%s must be a non-empty string", key)
+				}
+				switch key {
+				case "http_url":
+					hc.HTTPURL = s
+				case "rpc_url":
+					hc.RPCURL = s
+				default:
+					hc.RPCMethod = s
+				}
+			case "timeout":
+				d, err := ParseDurationParam(val, time.Second)
+				if err != nil {
+					return nil, fmt.Errorf("timeout: %w", err)
+				}
+				if d <= 0 {
+					return nil, fmt.Errorf("timeout must be positive")
+				}
+				hc.Timeout = d
+			default:
+				return nil, fmt.Errorf("unknown key %q (expected http_url, rpc_url, rpc_method, timeout)", key)
+			}
+		}
+		return hc, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T (expected true/false or a map)", raw)
+	}
+}
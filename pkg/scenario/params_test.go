@@ -0,0 +1,126 @@
+package scenario
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDurationParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     interface{}
+		unit    time.Duration
+		want    time.Duration
+		wantErr bool
+	}{
+		{"string ms", "250ms", time.Millisecond, 250 * time.Millisecond, false},
+		{"string seconds", "2s", time.Millisecond, 2 * time.Second, false},
+		{"bare int as ms", 500, time.Millisecond, 500 * time.Millisecond, false},
+		{"bare float as ms", 5000.0, time.Millisecond, 5 * time.Second, false},
+		{"bare int as seconds", 45, time.Second, 45 * time.Second, false},
+		{"fractional seconds", 1.5, time.Second, 1500 * time.Millisecond, false},
+		{"int64", int64(3), time.Second, 3 * time.Second, false},
+		{"string without unit", "250", time.Millisecond, 0, true},
+		{"garbage", "fast", time.Millisecond, 0, true},
+		{"bool", true, time.Millisecond, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDurationParam(tt.raw, tt.unit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDurationParam(%v) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDurationParam(%v) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMillisParam(t *testing.T) {
+	got, err := ParseMillisParam("1.5s")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 1500 {
+		t.Errorf("ParseMillisParam(\"1.5s\") = %d, want 1500", got)
+	}
+}
+
+func TestParseHealthCheckParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     interface{}
+		want    *HealthCheckParam
+		wantErr bool
+	}{
+		{"absent", nil, nil, false},
+		{"false", false, nil, false},
+		{"true", true, &HealthCheckParam{RPCMethod: "eth_blockNumber", Timeout: 5 * time.Minute}, false},
+		{"rpc probe", map[string]interface{}{"rpc_url": "http://bor:8545", "timeout": "2m"},
+			&HealthCheckParam{RPCURL: "http://bor:8545", RPCMethod: "eth_blockNumber", Timeout: 2 * time.Minute}, false},
+		{"http probe with bare timeout", map[string]interface{}{"http_url": "http://heimdall:1317/status", "timeout": 90},
+			&HealthCheckParam{HTTPURL: "http://heimdall:1317/status", RPCMethod: "eth_blockNumber", Timeout: 90 * time.Second}, false},
+		{"custom rpc method", map[string]interface{}{"rpc_url": "http://bor:8545", "rpc_method": "eth_syncing"},
+			&HealthCheckParam{RPCURL: "http://bor:8545", RPCMethod: "eth_syncing", Timeout: 5 * time.Minute}, false},
+		{"unknown key", map[string]interface{}{"url": "http://x"}, nil, true},
+		{"empty url", map[string]interface{}{"rpc_url": ""}, nil, true},
+		{"zero timeout", map[string]interface{}{"timeout": 0}, nil, true},
+		{"string", "yes", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHealthCheckParam(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHealthCheckParam(%v) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ParseHealthCheckParam(%v) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseListParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     interface{}
+		want    []string
+		wantErr bool
+	}{
+		{"absent", nil, nil, false},
+		{"csv", "a, b,,c", []string{"a", "b", "c"}, false},
+		{"yaml list", []interface{}{"a", " b "}, []string{"a", "b"}, false},
+		{"string slice", []string{"a"}, []string{"a"}, false},
+		{"number item", []interface{}{"a", 1}, nil, true},
+		{"number", 3, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseListParam(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseListParam(%v) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("ParseListParam(%v) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactParams(t *testing.T) {
+	params := map[string]interface{}{"private_key": "0xabc", "rate": 100}
+	got := RedactParams(params)
+	if got["private_key"] != "<redacted>" || got["rate"] != 100 {
+		t.Errorf("RedactParams() = %v", got)
+	}
+	if params["private_key"] != "0xabc" {
+		t.Error("RedactParams() modified its argument")
+	}
+	if RedactParams(nil) != nil {
+		t.Error("RedactParams(nil) should be nil")
+	}
+}
@@ -1,13 +1,18 @@
 package parser
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
+	"github.com/jihwankim/chaos-utils/pkg/config"
 	"github.com/jihwankim/chaos-utils/pkg/scenario"
 )
 
@@ -15,6 +20,10 @@ import (
 type Parser struct {
 	// Variables for substitution
 	Variables map[string]string
+
+	// Profile is the deployment profile criterion presets are rendered
+	// for; nil renders them for config.DefaultProfile.
+	Profile *config.Profile
 }
 
 // New creates a new parser with optional variables
@@ -27,27 +36,146 @@ func New(variables map[string]string) *Parser {
 	}
 }
 
-// ParseFile parses a scenario from a YAML file
+// StdinPath is the scenario path that reads from standard input.
+const StdinPath = "-"
+
+// ParseFile parses a scenario from a YAML or JSON file, or from stdin when
+// path is StdinPath. Files holding several documents are rejected; use
+// ParseFileAll for those.
 func (p *Parser) ParseFile(path string) (*scenario.Scenario, error) {
-	// Read file
-	data, err := os.ReadFile(path)
+	data, err := readScenario(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+		return nil, err
 	}
-
 	return p.Parse(data)
 }
 
-// Parse parses a scenario from YAML bytes
+// ParseFileAll parses every scenario document in a YAML or JSON file, or
+// stdin when path is StdinPath.
+func (p *Parser) ParseFileAll(path string) ([]*scenario.Scenario, error) {
+	data, err := readScenario(path)
+	if err != nil {
+		return nil, err
+	}
+	return p.ParseAll(data)
+}
+
+// readScenario reads path, or stdin for StdinPath.
+func readScenario(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == StdinPath {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+	return data, nil
+}
+
+// Parse parses a single scenario from YAML or JSON bytes.
 func (p *Parser) Parse(data []byte) (*scenario.Scenario, error) {
+	scenarios, err := p.ParseAll(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(scenarios) > 1 {
+		return nil, fmt.Errorf("input contains %d scenario documents; expected one", len(scenarios))
+	}
+	return scenarios[0], nil
+}
+
+// ParseAll parses every scenario in YAML or JSON bytes. YAML input may hold
+// several ---separated documents; empty documents are skipped. JSON is
+// detected by a leading '{' and uses the same field names as the YAML form.
+func (p *Parser) ParseAll(data []byte) ([]*scenario.Scenario, error) {
 	// Apply variable substitution
-	substituted := p.substituteVariables(string(data))
+	substituted := []byte(p.substituteVariables(string(data)))
+
+	// JSON is converted to YAML rather than decoded directly so durations
+	// ("5m") and the yaml field tags behave identically in both formats.
+	if isJSON(substituted) {
+		var doc interface{}
+		if err := json.Unmarshal(substituted, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		converted, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert JSON scenario: %w", err)
+		}
+		substituted = converted
+	}
+
+	// Decode document by document through yaml.Node so anchors and aliases
+	// are resolved within each document before it reaches the struct.
+	var docs []*yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(substituted))
+	for {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		if len(node.Content) == 0 || node.Content[0].Tag == "!!null" {
+			continue
+		}
+		docs = append(docs, &node)
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no scenario found in input")
+	}
+
+	scenarios := make([]*scenario.Scenario, 0, len(docs))
+	for i, node := range docs {
+		s, err := p.decode(node)
+		if err != nil {
+			if len(docs) > 1 {
+				return nil, fmt.Errorf("document %d: %w", i+1, err)
+			}
+			return nil, err
+		}
+		scenarios = append(scenarios, s)
+	}
+	return scenarios, nil
+}
 
-	// Parse YAML
+// decode turns one YAML document into a validated scenario.
+func (p *Parser) decode(node *yaml.Node) (*scenario.Scenario, error) {
 	var s scenario.Scenario
-	if err := yaml.Unmarshal([]byte(substituted), &s); err != nil {
+	if err := node.Decode(&s); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
+	if s.Kind == scenario.SuiteKind {
+		return nil, fmt.Errorf("%s %q is a suite manifest, not a scenario; run it with --suite", s.Kind, s.Metadata.Name)
+	}
+
+	// Expand query templates, criterion presets, metric shorthands and
+	// windows before validation sees them. $__target stays until targets
+	// are discovered.
+	if err := scenario.ExpandCriterionTemplates(&s); err != nil {
+		return nil, err
+	}
+	for i := range s.Spec.SuccessCriteria {
+		if err := scenario.ExpandCriterionPreset(&s.Spec.SuccessCriteria[i], p.Profile); err != nil {
+			return nil, fmt.Errorf("spec.success_criteria[%d]: %w", i, err)
+		}
+		if err := scenario.ExpandCriterionMetric(&s.Spec.SuccessCriteria[i]); err != nil {
+			return nil, fmt.Errorf("spec.success_criteria[%d]: %w", i, err)
+		}
+		if err := scenario.ExpandCriterionWindow(&s.Spec.SuccessCriteria[i]); err != nil {
+			return nil, fmt.Errorf("spec.success_criteria[%d]: %w", i, err)
+		}
+	}
+
+	for i := range s.Spec.Faults {
+		if err := scenario.ExpandNetworkProfile(&s.Spec.Faults[i]); err != nil {
+			return nil, fmt.Errorf("spec.faults[%d]: %w", i, err)
+		}
+	}
 
 	// Validate required fields
 	if err := p.validateRequiredFields(&s); err != nil {
@@ -57,10 +185,17 @@ func (p *Parser) Parse(data []byte) (*scenario.Scenario, error) {
 	return &s, nil
 }
 
+// isJSON reports whether data looks like a JSON object.
+func isJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
 // substituteVariables replaces ${VAR} and $VAR with values from environment and parser variables
 func (p *Parser) substituteVariables(content string) string {
-	// Pattern matches ${VAR} and $VAR
-	re := regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+	// Pattern matches ${VAR} and $VAR; the braced form also takes the
+	// dotted paths of values files (${targets.rpc.pattern})
+	re := regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_.-]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
 
 	result := re.ReplaceAllStringFunc(content, func(match string) string {
 		// Extract variable name
@@ -150,7 +285,14 @@ func ApplyOverrides(s *scenario.Scenario, overrides map[string]string) error {
 			}
 			s.Spec.Cooldown = duration
 
-		case "enclave", "spec.targets[0].selector.enclave":
+		case "enclave":
+			// Scenarios usually share one enclave across targets (often via
+			// a YAML anchor), so the short form rewrites every target.
+			for i := range s.Spec.Targets {
+				s.Spec.Targets[i].Selector.Enclave = value
+			}
+
+		case "spec.targets[0].selector.enclave":
 			if len(s.Spec.Targets) > 0 {
 				s.Spec.Targets[0].Selector.Enclave = value
 			}
@@ -160,6 +302,15 @@ func ApplyOverrides(s *scenario.Scenario, overrides map[string]string) error {
 		}
 	}
 
+	// Re-render templated queries against the overridden values.
+	if err := scenario.ExpandCriterionTemplates(s); err != nil {
+		return err
+	}
+	for i := range s.Spec.SuccessCriteria {
+		if err := scenario.ExpandCriterionWindow(&s.Spec.SuccessCriteria[i]); err != nil {
+			return fmt.Errorf("spec.success_criteria[%d]: %w", i, err)
+		}
+	}
 	return nil
 }
 
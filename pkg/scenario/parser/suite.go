@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseSuiteFile parses a ChaosSuite manifest. Entry paths are returned
+// resolved against the manifest's directory.
+func (p *Parser) ParseSuiteFile(path string) (*scenario.Suite, error) {
+	data, err := readScenario(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s scenario.Suite
+	if err := yaml.Unmarshal([]byte(p.substituteVariables(string(data))), &s); err != nil {
+		return nil, fmt.Errorf("failed to parse suite: %w", err)
+	}
+	if s.APIVersion == "" {
+		return nil, fmt.Errorf("apiVersion is required")
+	}
+	if s.Kind != scenario.SuiteKind {
+		return nil, fmt.Errorf("kind must be %s, got %q", scenario.SuiteKind, s.Kind)
+	}
+	if s.Metadata.Name == "" {
+		return nil, fmt.Errorf("metadata.name is required")
+	}
+	if len(s.Spec.Scenarios) == 0 {
+		return nil, fmt.Errorf("spec.scenarios is required and must have at least one scenario")
+	}
+
+	dir := "."
+	if path != StdinPath {
+		dir = filepath.Dir(path)
+	}
+	for i := range s.Spec.Scenarios {
+		entry := &s.Spec.Scenarios[i]
+		if entry.Path == "" {
+			return nil, fmt.Errorf("spec.scenarios[%d].path is required", i)
+		}
+		if !filepath.IsAbs(entry.Path) {
+			entry.Path = filepath.Join(dir, entry.Path)
+		}
+	}
+	return &s, nil
+}
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSuiteFile_ResolvesPaths(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "suite.yaml")
+	manifest := `
+apiVersion: chaos.polygon.io/v1
+kind: ChaosSuite
+metadata:
+  name: nightly
+spec:
+  set:
+    duration: ${DURATION}
+  scenarios:
+    - path: network/latency.yaml
+    - path: /abs/kill.yaml
+      set:
+        warmup: 10s
+`
+	if err := os.WriteFile(path, []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(map[string]string{"DURATION": "2m"}).ParseSuiteFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Spec.Scenarios[0].Path, filepath.Join(dir, "network/latency.yaml"); got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+	if got := s.Spec.Scenarios[1].Path; got != "/abs/kill.yaml" {
+		t.Errorf("absolute path = %q, want it unchanged", got)
+	}
+	if got := s.Spec.Set["duration"]; got != "2m" {
+		t.Errorf("set.duration = %q, want substituted 2m", got)
+	}
+	if got := s.Spec.Scenarios[1].Set["warmup"]; got != "10s" {
+		t.Errorf("entry set.warmup = %q, want 10s", got)
+	}
+}
+
+func TestParse_RejectsSuiteManifest(t *testing.T) {
+	_, err := New(nil).Parse([]byte(`
+apiVersion: chaos.polygon.io/v1
+kind: ChaosSuite
+metadata:
+  name: nightly
+spec:
+  scenarios:
+    - path: a.yaml
+`))
+	if err == nil || !strings.Contains(err.Error(), "--suite") {
+		t.Errorf("err = %v, want a pointer to --suite", err)
+	}
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadValues reads values files (--values) into substitution variables.
+// Each file is a YAML mapping; later files override earlier ones, merging
+// nested maps key by key. Nested values are addressed by dotted path:
+//
+//	targets:
+//	  rpc:
+//	    pattern: "l2-el-.*-bor-rpc"
+//	  validators: [l2-cl-1, l2-cl-2]
+//
+// gives ${targets.rpc.pattern} and ${targets.validators.0}. A map or list
+// referenced whole, as ${targets.validators}, is substituted as inline
+// JSON, which YAML reads as a flow sequence or mapping.
+func LoadValues(paths ...string) (map[string]string, error) {
+	merged := map[string]interface{}{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file: %w", err)
+		}
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse values file %s: %w", path, err)
+		}
+		mergeValues(merged, values)
+	}
+
+	vars := make(map[string]string)
+	for k, v := range merged {
+		if err := flattenValue(vars, k, v); err != nil {
+			return nil, err
+		}
+	}
+	return vars, nil
+}
+
+// mergeValues merges src into dst, recursing where both hold a map.
+func mergeValues(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if sub, ok := v.(map[string]interface{}); ok {
+			if existing, ok := dst[k].(map[string]interface{}); ok {
+				mergeValues(existing, sub)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// flattenValue adds v under key, and each map entry or list element under
+// key.<name> or key.<index>.
+func flattenValue(vars map[string]string, key string, v interface{}) error {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, sub := range val {
+			if err := flattenValue(vars, key+"."+k, sub); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, sub := range val {
+			if err := flattenValue(vars, key+"."+strconv.Itoa(i), sub); err != nil {
+				return err
+			}
+		}
+	case nil:
+		vars[key] = ""
+		return nil
+	case string:
+		vars[key] = val
+		return nil
+	default:
+		vars[key] = fmt.Sprint(val)
+		return nil
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("value %s: %w", key, err)
+	}
+	vars[key] = string(encoded)
+	return nil
+}
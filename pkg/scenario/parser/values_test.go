@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeValues(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "values.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadValues_FlattensAndMerges(t *testing.T) {
+	base := writeValues(t, `
+enclave: pos-devnet
+targets:
+  rpc:
+    pattern: l2-el-1-rpc
+    port: 8545
+  validators: [val-1, val-2]
+`)
+	override := writeValues(t, `
+targets:
+  rpc:
+    pattern: l2-el-2-rpc
+`)
+	vars, err := LoadValues(base, override)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"enclave":              "pos-devnet",
+		"targets.rpc.pattern":  "l2-el-2-rpc",
+		"targets.rpc.port":     "8545",
+		"targets.validators.1": "val-2",
+		"targets.validators":   `["val-1","val-2"]`,
+	}
+	for k, v := range want {
+		if vars[k] != v {
+			t.Errorf("%s = %q, want %q", k, vars[k], v)
+		}
+	}
+}
+
+func TestParse_SubstitutesDottedValues(t *testing.T) {
+	vars, err := LoadValues(writeValues(t, `
+duration: 2m
+targets:
+  rpc:
+    pattern: l2-el-1-rpc
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(vars).Parse([]byte(`
+apiVersion: chaos.polygon.io/v1
+kind: ChaosScenario
+metadata:
+  name: values
+spec:
+  duration: ${duration}
+  targets:
+    - selector:
+        type: kurtosis_service
+        pattern: "${targets.rpc.pattern}"
+      alias: rpc
+  faults:
+    - phase: lag
+      target: rpc
+      type: network
+      params:
+        latency: 100
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Spec.Targets[0].Selector.Pattern; got != "l2-el-1-rpc" {
+		t.Errorf("pattern = %q, want l2-el-1-rpc", got)
+	}
+}
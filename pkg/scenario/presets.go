@@ -0,0 +1,196 @@
+package scenario
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+)
+
+// criterionPresets are named success criteria for the Polygon PoS and CDK
+// SLIs scenarios check most often. A criterion with `preset: <name>` is
+// expanded by the parser: any field the scenario leaves empty is taken from
+// the preset, so a scenario can still override the threshold, window, name
+// or description. Critical and the evaluation-timing flags always come from
+// the scenario — whether an SLI is fatal is a per-scenario decision.
+// Windowed queries use $__window, so `window:` on the criterion widens or
+// narrows the preset's range selectors.
+//
+// PoS queries follow docs/metrics-reference.md and exclude validator 4 (the
+// devnet's known-laggy node) from cluster-wide checks. Their job selectors
+// and metric prefixes are placeholders, filled from the deployment profile
+// when the preset is expanded.
+var criterionPresets = map[string]SuccessCriterion{
+	"bor_block_production": {
+		Name:        "bor_block_production",
+		Description: "Every healthy Bor validator is still producing or importing blocks",
+		Type:        "prometheus",
+		Query:       `min(rate(chain_head_block{job=~"<el_jobs>"}[$__window]))`,
+		Window:      time.Minute,
+		Threshold:   "> 0",
+	},
+	"bor_block_height_spread": {
+		Name:        "bor_block_height_spread",
+		Description: "Bor validators agree on the chain head within a few blocks",
+		Type:        "prometheus",
+		Query:       `max(chain_head_block{job=~"<el_jobs>"}) - min(chain_head_block{job=~"<el_jobs>"})`,
+		Threshold:   "< 10",
+	},
+	"heimdall_consensus_progress": {
+		Name:        "heimdall_consensus_progress",
+		Description: "Heimdall consensus height is advancing",
+		Type:        "prometheus",
+		Query:       `sum(increase(<consensus>_consensus_height{job=~"<all_cl_jobs>"}[$__window])) or vector(0)`,
+		Window:      2 * time.Minute,
+		Threshold:   "> 0",
+	},
+	"heimdall_peer_connectivity": {
+		Name:        "heimdall_peer_connectivity",
+		Description: "Every Heimdall validator keeps enough consensus peers",
+		Type:        "prometheus",
+		Query:       `min(<consensus>_p2p_peers{job=~"<all_cl_jobs>"})`,
+		Threshold:   ">= 3",
+	},
+	"heimdall_checkpoint_latency": {
+		Name:        "heimdall_checkpoint_latency",
+		Description: "A new checkpoint reached Bor within the window (15m by default)",
+		Type:        "prometheus",
+		Query:       `max(increase(chain_checkpoint_latest{job=~"<el_jobs>"}[$__window])) or vector(0)`,
+		Window:      15 * time.Minute,
+		Threshold:   "> 0",
+	},
+	"heimdall_milestone_progress": {
+		Name:        "heimdall_milestone_progress",
+		Description: "Heimdall milestone API calls are succeeding",
+		Type:        "prometheus",
+		Query:       `sum(increase(<heimdall>_milestone_api_calls_success_total{job=~"<cl_jobs>"}[$__window])) or vector(0)`,
+		Window:      5 * time.Minute,
+		Threshold:   "> 0",
+	},
+	"heimdall_checkpoint_submission": {
+		Name:        "heimdall_checkpoint_submission",
+		Description: "Heimdall checkpoint submission calls are succeeding",
+		Type:        "prometheus",
+		Query:       `sum(increase(<heimdall>_checkpoint_api_calls_success_total{job=~"<cl_jobs>"}[$__window])) or vector(0)`,
+		Window:      5 * time.Minute,
+		Threshold:   "> 0",
+	},
+	"heimdall_clerk_sync": {
+		Name:        "heimdall_clerk_sync",
+		Description: "Heimdall clerk (L1 state-sync) API calls are succeeding",
+		Type:        "prometheus",
+		Query:       `sum(increase(<heimdall>_clerk_api_calls_success_total{job=~"<cl_jobs>"}[$__window])) or vector(0)`,
+		Window:      5 * time.Minute,
+		Threshold:   "> 0",
+	},
+
+	// Polygon CDK (kurtosis-cdk), measured through panoptichain.
+	"cdk_l2_block_production": {
+		Name:        "cdk_l2_block_production",
+		Description: "The CDK sequencer is producing L2 blocks",
+		Type:        "prometheus",
+		Query:       `sum(increase(panoptichain_rpc_height{job=~".*sequencer.*"}[$__window])) or vector(0)`,
+		Window:      time.Minute,
+		Threshold:   "> 0",
+	},
+	"cdk_batches_sequenced": {
+		Name:        "cdk_batches_sequenced",
+		Description: "Batches are still being sequenced to L1",
+		Type:        "prometheus",
+		Query:       `increase(panoptichain_rpc_zkevm_total_sequenced_batches[$__window])`,
+		Window:      5 * time.Minute,
+		Threshold:   "> 0",
+	},
+	"cdk_batches_verified": {
+		Name:        "cdk_batches_verified",
+		Description: "The aggregator and prover are still verifying batches on L1",
+		Type:        "prometheus",
+		Query:       `increase(panoptichain_rpc_zkevm_total_verified_batches[$__window])`,
+		Window:      10 * time.Minute,
+		Threshold:   "> 0",
+	},
+}
+
+// healthyValidators replaces the "any validator number" wildcard in a
+// profile's job pattern with every validator but 4.
+func healthyValidators(jobPattern string) string {
+	return strings.Replace(jobPattern, ".*", "[1235678]", 1)
+}
+
+// renderPresetQuery fills the placeholders of a preset query from p. It
+// reports false when the query has placeholders and p is not a PoS
+// profile, which has none of those jobs or metrics.
+func renderPresetQuery(query string, p *config.Profile) (string, bool) {
+	if !strings.Contains(query, "<") {
+		return query, true
+	}
+	if p.Stack != config.StackPoS {
+		return "", false
+	}
+	return strings.NewReplacer(
+		"<el_jobs>", healthyValidators(p.ELJobPattern),
+		"<cl_jobs>", healthyValidators(p.CLJobPattern),
+		"<all_cl_jobs>", p.CLJobPattern,
+		"<consensus>", p.ConsensusMetricPrefix,
+		"<heimdall>", p.HeimdallMetricPrefix,
+	).Replace(query), true
+}
+
+// CriterionPresets returns the names of all criterion presets, sorted.
+func CriterionPresets() []string {
+	names := make([]string, 0, len(criterionPresets))
+	for name := range criterionPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExpandCriterionPreset fills the empty fields of c from its preset,
+// rendered for deployment profile p (config.DefaultProfile when nil), then
+// does the same for composite children. It is a no-op for criteria without
+// a preset and returns an error for an unknown preset name or a PoS preset
+// under a non-PoS profile.
+func ExpandCriterionPreset(c *SuccessCriterion, p *config.Profile) error {
+	if p == nil {
+		p = config.DefaultProfile()
+	}
+	if c.Preset != "" {
+		preset, ok := criterionPresets[c.Preset]
+		if !ok {
+			return fmt.Errorf("unknown criterion preset %q (available: %v)", c.Preset, CriterionPresets())
+		}
+		if preset.Query, ok = renderPresetQuery(preset.Query, p); !ok {
+			return fmt.Errorf("criterion preset %q needs a PoS deployment profile, not %s", c.Preset, p.Name)
+		}
+		if c.Name == "" {
+			c.Name = preset.Name
+		}
+		if c.Description == "" {
+			c.Description = preset.Description
+		}
+		if c.Type == "" {
+			c.Type = preset.Type
+		}
+		if c.Query == "" {
+			c.Query = preset.Query
+		}
+		if c.Threshold == "" {
+			c.Threshold = preset.Threshold
+		}
+		if c.Window == 0 {
+			c.Window = preset.Window
+		}
+	}
+
+	for _, children := range [][]SuccessCriterion{c.AllOf, c.AnyOf, c.Weighted} {
+		for i := range children {
+			if err := ExpandCriterionPreset(&children[i], p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
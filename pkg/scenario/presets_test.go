@@ -0,0 +1,83 @@
+package scenario
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jihwankim/chaos-utils/pkg/config"
+)
+
+func TestExpandCriterionPreset(t *testing.T) {
+	c := SuccessCriterion{Preset: "bor_block_production", Threshold: "> 0.2", Critical: true}
+	if err := ExpandCriterionPreset(&c, nil); err != nil {
+		t.Fatalf("ExpandCriterionPreset() unexpected error: %v", err)
+	}
+	if c.Name != "bor_block_production" || c.Type != "prometheus" || c.Query == "" {
+		t.Errorf("preset fields not filled: %+v", c)
+	}
+	if c.Threshold != "> 0.2" || !c.Critical {
+		t.Errorf("scenario overrides lost: threshold=%q critical=%v", c.Threshold, c.Critical)
+	}
+
+	composite := SuccessCriterion{Name: "liveness", Type: "composite", AnyOf: []SuccessCriterion{{Preset: "heimdall_consensus_progress"}}}
+	if err := ExpandCriterionPreset(&composite, nil); err != nil {
+		t.Fatalf("ExpandCriterionPreset() unexpected error: %v", err)
+	}
+	if composite.AnyOf[0].Query == "" {
+		t.Error("composite child preset not expanded")
+	}
+
+	unknown := SuccessCriterion{Preset: "no_such_sli"}
+	if err := ExpandCriterionPreset(&unknown, nil); err == nil {
+		t.Error("ExpandCriterionPreset() expected error for unknown preset")
+	}
+}
+
+func TestExpandCriterionPreset_Profiles(t *testing.T) {
+	tests := []struct {
+		profile string
+		preset  string
+		want    string
+	}{
+		{"pos-heimdall-v2", "bor_block_production", `min(rate(chain_head_block{job=~"l2-el-[1235678]-bor-heimdall-v2-validator"}[$__window]))`},
+		{"pos-heimdall-v1", "bor_block_production", `min(rate(chain_head_block{job=~"l2-el-[1235678]-bor-heimdall-validator"}[$__window]))`},
+		{"pos-heimdall-v2", "heimdall_consensus_progress", `sum(increase(cometbft_consensus_height{job=~"l2-cl-.*-heimdall-v2-bor-validator"}[$__window])) or vector(0)`},
+		{"pos-heimdall-v1", "heimdall_consensus_progress", `sum(increase(tendermint_consensus_height{job=~"l2-cl-.*-heimdall-bor-validator"}[$__window])) or vector(0)`},
+		{"pos-heimdall-v2", "heimdall_clerk_sync", `sum(increase(heimdallv2_clerk_api_calls_success_total{job=~"l2-cl-[1235678]-heimdall-v2-bor-validator"}[$__window])) or vector(0)`},
+		{"pos-heimdall-v1", "heimdall_clerk_sync", `sum(increase(heimdall_clerk_api_calls_success_total{job=~"l2-cl-[1235678]-heimdall-bor-validator"}[$__window])) or vector(0)`},
+		{"cdk-erigon", "cdk_batches_verified", `increase(panoptichain_rpc_zkevm_total_verified_batches[$__window])`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile+"/"+tt.preset, func(t *testing.T) {
+			p, _ := config.LookupProfile(tt.profile)
+			c := SuccessCriterion{Preset: tt.preset}
+			if err := ExpandCriterionPreset(&c, p); err != nil {
+				t.Fatalf("ExpandCriterionPreset() unexpected error: %v", err)
+			}
+			if c.Query != tt.want {
+				t.Errorf("query = %s, want %s", c.Query, tt.want)
+			}
+		})
+	}
+
+	// Every preset renders without leftover placeholders under every PoS
+	// profile, and PoS presets are refused under the CDK profile.
+	for _, name := range config.ProfileNames() {
+		p, _ := config.LookupProfile(name)
+		for _, preset := range CriterionPresets() {
+			c := SuccessCriterion{Preset: preset}
+			err := ExpandCriterionPreset(&c, p)
+			pos := !strings.HasPrefix(preset, "cdk_")
+			switch {
+			case pos && p.Stack != config.StackPoS:
+				if err == nil {
+					t.Errorf("%s/%s: expected an error for a PoS preset", name, preset)
+				}
+			case err != nil:
+				t.Errorf("%s/%s: unexpected error: %v", name, preset, err)
+			case strings.Contains(c.Query, "<"):
+				t.Errorf("%s/%s: unrendered placeholder in %s", name, preset, c.Query)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package scenario
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// SchedulingParams are accepted by every fault type. They control how a
+// fault that resolves to several containers is rolled out across them.
+var SchedulingParams = []string{"stagger", "stagger_jitter", "batch_percent"}
+
+// Schedule rolls a multi-target fault out in batches instead of hitting
+// every target at once.
+type Schedule struct {
+	// BatchPercent is the share of targets per batch (e.g. 25 = a quarter
+	// at a time). 0 means one target per batch when Stagger or Jitter is
+	// set.
+	BatchPercent float64
+	// Stagger is the pause between batches.
+	Stagger time.Duration
+	// Jitter adds a random 0..Jitter to every pause.
+	Jitter time.Duration
+}
+
+// Active reports whether any scheduling param was set.
+func (s Schedule) Active() bool {
+	return s.BatchPercent > 0 || s.Stagger > 0 || s.Jitter > 0
+}
+
+// BatchSize returns how many of n targets each batch holds (at least 1).
+func (s Schedule) BatchSize(n int) int {
+	if s.BatchPercent <= 0 {
+		return 1
+	}
+	size := int(math.Ceil(float64(n) * s.BatchPercent / 100))
+	if size < 1 {
+		size = 1
+	}
+	if size > n {
+		size = n
+	}
+	return size
+}
+
+// ParseSchedule reads the scheduling params from a fault's params. Bare
+// numbers for stagger and stagger_jitter are seconds.
+func ParseSchedule(params map[string]interface{}) (Schedule, error) {
+	var s Schedule
+	for _, d := range []struct {
+		key string
+		dst *time.Duration
+	}{{"stagger", &s.Stagger}, {"stagger_jitter", &s.Jitter}} {
+		raw, present := params[d.key]
+		if !present {
+			continue
+		}
+		v, err := ParseDurationParam(raw, time.Second)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("%s: %w", d.key, err)
+		}
+		if v < 0 {
+			return Schedule{}, fmt.Errorf("%s cannot be negative", d.key)
+		}
+		*d.dst = v
+	}
+
+	if raw, present := params["batch_percent"]; present {
+		switch v := raw.(type) {
+		case int:
+			s.BatchPercent = float64(v)
+		case float64:
+			s.BatchPercent = v
+		default:
+			return Schedule{}, fmt.Errorf("batch_percent: unsupported type %T (expected a number)", raw)
+		}
+		if s.BatchPercent <= 0 || s.BatchPercent > 100 {
+			return Schedule{}, fmt.Errorf("batch_percent must be in (0, 100]")
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,56 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		want    Schedule
+		wantErr bool
+	}{
+		{"none", nil, Schedule{}, false},
+		{"bare stagger seconds", map[string]interface{}{"stagger": 30}, Schedule{Stagger: 30 * time.Second}, false},
+		{"jitter string", map[string]interface{}{"stagger_jitter": "10s"}, Schedule{Jitter: 10 * time.Second}, false},
+		{"batch percent", map[string]interface{}{"batch_percent": 25, "stagger": "1m"}, Schedule{BatchPercent: 25, Stagger: time.Minute}, false},
+		{"batch percent zero", map[string]interface{}{"batch_percent": 0}, Schedule{}, true},
+		{"batch percent over 100", map[string]interface{}{"batch_percent": 150.0}, Schedule{}, true},
+		{"negative jitter", map[string]interface{}{"stagger_jitter": -1}, Schedule{}, true},
+		{"bad stagger", map[string]interface{}{"stagger": "soon"}, Schedule{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSchedule(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSchedule(%v) error = %v, wantErr %v", tt.params, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSchedule(%v) = %+v, want %+v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_BatchSize(t *testing.T) {
+	tests := []struct {
+		percent float64
+		n       int
+		want    int
+	}{
+		{0, 8, 1},
+		{25, 8, 2},
+		{25, 5, 2},
+		{10, 3, 1},
+		{100, 4, 4},
+	}
+
+	for _, tt := range tests {
+		if got := (Schedule{BatchPercent: tt.percent}).BatchSize(tt.n); got != tt.want {
+			t.Errorf("BatchSize(%v%% of %d) = %d, want %d", tt.percent, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package scenario
+
+// SuiteKind is the kind of a suite manifest.
+const SuiteKind = "ChaosSuite"
+
+// Suite is a ChaosSuite manifest: scenario files run one after another,
+// with variables and overrides shared between them.
+type Suite struct {
+	APIVersion string    `yaml:"apiVersion"`
+	Kind       string    `yaml:"kind"`
+	Metadata   Metadata  `yaml:"metadata"`
+	Spec       SuiteSpec `yaml:"spec"`
+}
+
+// SuiteSpec defines the suite's scenarios and shared settings.
+type SuiteSpec struct {
+	// Variables substitute ${...} in every scenario file. --values files
+	// override them.
+	Variables map[string]string `yaml:"variables,omitempty"`
+
+	// Set overrides apply to every scenario, like --set.
+	Set map[string]string `yaml:"set,omitempty"`
+
+	// Scenarios run in the order listed.
+	Scenarios []SuiteEntry `yaml:"scenarios"`
+}
+
+// SuiteEntry is one scenario file in a suite. Every document in the file
+// runs.
+type SuiteEntry struct {
+	// Path is relative to the suite file.
+	Path string `yaml:"path"`
+
+	// Set overrides apply to this entry only, after the suite's.
+	Set map[string]string `yaml:"set,omitempty"`
+}
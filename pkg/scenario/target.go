@@ -0,0 +1,118 @@
+package scenario
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TargetPlaceholder in a prometheus criterion query is replaced, once
+// targets are discovered, by a job label matcher selecting exactly the
+// containers behind the criterion's Target alias — e.g.
+// `increase(chain_head_block{$__target}[5m])` becomes
+// `increase(chain_head_block{job=~"l2-el-1-bor|l2-el-2-bor"}[5m])`.
+const TargetPlaceholder = "$__target"
+
+// ExpandCriterionMetric turns the Metric shorthand into a prometheus query
+// selecting that metric on the criterion's target: `metric: chain_head_block`
+// becomes `query: chain_head_block{$__target}`. Type defaults to prometheus.
+func ExpandCriterionMetric(c *SuccessCriterion) error {
+	if c.Metric != "" {
+		if c.Query != "" {
+			return fmt.Errorf("criterion %q: metric and query are mutually exclusive", c.Name)
+		}
+		if c.Type == "" {
+			c.Type = "prometheus"
+		}
+		c.Query = c.Metric + "{" + TargetPlaceholder + "}"
+	}
+
+	for _, children := range [][]SuccessCriterion{c.AllOf, c.AnyOf, c.Weighted} {
+		for i := range children {
+			if err := ExpandCriterionMetric(&children[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveCriterionTarget returns a copy of c with TargetPlaceholder replaced
+// by the job matcher for its Target's jobs (target alias → Prometheus job
+// names). Composite children without a Target inherit their parent's. The
+// original is left untouched, so callers can resolve against a trial
+// discovery without mutating the scenario.
+func ResolveCriterionTarget(c SuccessCriterion, jobs map[string][]string) (SuccessCriterion, error) {
+	return resolveTarget(c, "", jobs)
+}
+
+func resolveTarget(c SuccessCriterion, inherited string, jobs map[string][]string) (SuccessCriterion, error) {
+	if c.Target == "" {
+		c.Target = inherited
+	}
+
+	if strings.Contains(c.Query, TargetPlaceholder) {
+		if c.Target == "" {
+			return c, fmt.Errorf("criterion %q: query uses %s but target is not set", c.Name, TargetPlaceholder)
+		}
+		names := jobs[c.Target]
+		if len(names) == 0 {
+			return c, fmt.Errorf("criterion %q: target %q matched no containers", c.Name, c.Target)
+		}
+		c.Query = strings.ReplaceAll(c.Query, TargetPlaceholder, JobMatcher(names))
+	}
+
+	var err error
+	if c.AllOf, err = resolveChildren(c.AllOf, c.Target, jobs); err != nil {
+		return c, err
+	}
+	if c.AnyOf, err = resolveChildren(c.AnyOf, c.Target, jobs); err != nil {
+		return c, err
+	}
+	if c.Weighted, err = resolveChildren(c.Weighted, c.Target, jobs); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// resolveChildren resolves into a fresh slice so the caller's children are
+// not modified through the shared backing array.
+func resolveChildren(children []SuccessCriterion, inherited string, jobs map[string][]string) ([]SuccessCriterion, error) {
+	if children == nil {
+		return nil, nil
+	}
+	out := make([]SuccessCriterion, len(children))
+	for i, child := range children {
+		resolved, err := resolveTarget(child, inherited, jobs)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = resolved
+	}
+	return out, nil
+}
+
+// JobMatcher builds a PromQL label matcher for the given job names:
+// job="a" for one, job=~"a|b" for several. Names are deduplicated and
+// sorted so the query is stable across runs.
+func JobMatcher(names []string) string {
+	seen := make(map[string]bool, len(names))
+	var unique []string
+	for _, n := range names {
+		if !seen[n] {
+			seen[n] = true
+			unique = append(unique, n)
+		}
+	}
+	sort.Strings(unique)
+
+	if len(unique) == 1 {
+		return fmt.Sprintf("job=%q", unique[0])
+	}
+	quoted := make([]string, len(unique))
+	for i, n := range unique {
+		quoted[i] = regexp.QuoteMeta(n)
+	}
+	return fmt.Sprintf("job=~%q", strings.Join(quoted, "|"))
+}
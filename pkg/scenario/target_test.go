@@ -0,0 +1,99 @@
+package scenario
+
+import "testing"
+
+func TestResolveCriterionTarget(t *testing.T) {
+	jobs := map[string][]string{
+		"bor":       {"l2-el-2-bor-heimdall-v2-validator", "l2-el-1-bor-heimdall-v2-validator"},
+		"heimdall":  {"l2-cl-1-heimdall-v2-bor-validator"},
+		"duplicate": {"a", "a"},
+	}
+
+	tests := []struct {
+		name      string
+		criterion SuccessCriterion
+		wantQuery string
+		wantChild string
+		wantErr   bool
+	}{
+		{
+			name:      "several containers",
+			criterion: SuccessCriterion{Query: "increase(chain_head_block{$__target}[5m])", Target: "bor"},
+			wantQuery: `increase(chain_head_block{job=~"l2-el-1-bor-heimdall-v2-validator|l2-el-2-bor-heimdall-v2-validator"}[5m])`,
+		},
+		{
+			name:      "one container",
+			criterion: SuccessCriterion{Query: `up{$__target, instance!=""}`, Target: "heimdall"},
+			wantQuery: `up{job="l2-cl-1-heimdall-v2-bor-validator", instance!=""}`,
+		},
+		{
+			name:      "duplicate jobs collapse",
+			criterion: SuccessCriterion{Query: "up{$__target}", Target: "duplicate"},
+			wantQuery: `up{job="a"}`,
+		},
+		{
+			name:      "no placeholder untouched",
+			criterion: SuccessCriterion{Query: "up", Target: "bor"},
+			wantQuery: "up",
+		},
+		{
+			name: "child inherits target",
+			criterion: SuccessCriterion{Type: "composite", Target: "heimdall", AllOf: []SuccessCriterion{
+				{Query: "up{$__target}"},
+			}},
+			wantChild: `up{job="l2-cl-1-heimdall-v2-bor-validator"}`,
+		},
+		{
+			name:      "placeholder without target",
+			criterion: SuccessCriterion{Query: "up{$__target}"},
+			wantErr:   true,
+		},
+		{
+			name:      "target matched nothing",
+			criterion: SuccessCriterion{Query: "up{$__target}", Target: "missing"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.criterion.Query
+			got, err := ResolveCriterionTarget(tt.criterion, jobs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveCriterionTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Query != tt.wantQuery {
+				t.Errorf("Query = %q, want %q", got.Query, tt.wantQuery)
+			}
+			if tt.wantChild != "" {
+				if got.AllOf[0].Query != tt.wantChild {
+					t.Errorf("child Query = %q, want %q", got.AllOf[0].Query, tt.wantChild)
+				}
+				if tt.criterion.AllOf[0].Query != "up{$__target}" {
+					t.Errorf("original child was modified: %q", tt.criterion.AllOf[0].Query)
+				}
+			}
+			if tt.criterion.Query != original {
+				t.Errorf("original Query was modified: %q", tt.criterion.Query)
+			}
+		})
+	}
+}
+
+func TestExpandCriterionMetric(t *testing.T) {
+	c := SuccessCriterion{Name: "head", Metric: "chain_head_block", Target: "bor"}
+	if err := ExpandCriterionMetric(&c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Type != "prometheus" || c.Query != "chain_head_block{$__target}" {
+		t.Errorf("got Type %q Query %q", c.Type, c.Query)
+	}
+
+	both := SuccessCriterion{Name: "head", Metric: "chain_head_block", Query: "up"}
+	if err := ExpandCriterionMetric(&both); err == nil {
+		t.Error("expected error when metric and query are both set")
+	}
+}
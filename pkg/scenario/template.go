@@ -0,0 +1,126 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Criterion queries may be Go templates over the scenario itself, so range
+// selectors and job matchers follow the fault window and targets instead
+// of being hardcoded next to them:
+//
+//	query: increase(chain_head_block{job=~"{{ .Targets.bor.Pattern }}"}[{{ .Spec.Duration }}])
+//
+// Durations render as PromQL durations ("5m"). Aliases and phases that are
+// not valid template identifiers are reached with index:
+// {{ index .Targets "bor-rpc" }}. Unknown keys are errors.
+
+// templateData is what a criterion query template sees.
+type templateData struct {
+	Spec    templateSpec
+	Targets map[string]templateTarget
+	// Faults is keyed by phase; faults without a phase are not included.
+	Faults map[string]templateFault
+}
+
+type templateSpec struct {
+	Duration, Warmup, Cooldown string
+}
+
+type templateTarget struct {
+	Alias, Type, Pattern, Enclave string
+}
+
+type templateFault struct {
+	Target, Type string
+	// Duration is the fault's own duration, or the scenario's when unset.
+	Duration string
+	Params   map[string]interface{}
+}
+
+// ExpandCriterionTemplates renders every templated criterion query in s,
+// composite children included. The source template is kept in
+// QueryTemplate so a later call (after --set overrides change the
+// duration, say) renders it again from the new values. Run it before
+// ExpandCriterionWindow: a template may emit $__window.
+func ExpandCriterionTemplates(s *Scenario) error {
+	data := newTemplateData(s)
+	for i := range s.Spec.SuccessCriteria {
+		if err := expandCriterionTemplate(&s.Spec.SuccessCriteria[i], data); err != nil {
+			return fmt.Errorf("spec.success_criteria[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func expandCriterionTemplate(c *SuccessCriterion, data templateData) error {
+	if c.QueryTemplate == "" && strings.Contains(c.Query, "{{") {
+		c.QueryTemplate = c.Query
+	}
+	if c.QueryTemplate != "" {
+		tmpl, err := template.New(c.Name).Option("missingkey=error").Parse(c.QueryTemplate)
+		if err != nil {
+			return fmt.Errorf("criterion %q: invalid query template: %w", c.Name, err)
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return fmt.Errorf("criterion %q: query template: %w", c.Name, err)
+		}
+		c.Query = b.String()
+	}
+
+	for _, children := range [][]SuccessCriterion{c.AllOf, c.AnyOf, c.Weighted} {
+		for i := range children {
+			if err := expandCriterionTemplate(&children[i], data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newTemplateData(s *Scenario) templateData {
+	data := templateData{
+		Spec: templateSpec{
+			Duration: templateDuration(s.Spec.Duration),
+			Warmup:   templateDuration(s.Spec.Warmup),
+			Cooldown: templateDuration(s.Spec.Cooldown),
+		},
+		Targets: make(map[string]templateTarget, len(s.Spec.Targets)),
+		Faults:  make(map[string]templateFault, len(s.Spec.Faults)),
+	}
+	for _, t := range s.Spec.Targets {
+		data.Targets[t.Alias] = templateTarget{
+			Alias:   t.Alias,
+			Type:    t.Selector.Type,
+			Pattern: t.Selector.Pattern,
+			Enclave: t.Selector.Enclave,
+		}
+	}
+	for _, f := range s.Spec.Faults {
+		if f.Phase == "" {
+			continue
+		}
+		d := f.Duration
+		if d <= 0 {
+			d = s.Spec.Duration
+		}
+		data.Faults[f.Phase] = templateFault{
+			Target:   f.Target,
+			Type:     f.Type,
+			Duration: templateDuration(d),
+			Params:   f.Params,
+		}
+	}
+	return data
+}
+
+// templateDuration is PromDuration with zero rendered as "0s".
+func templateDuration(d time.Duration) string {
+	if d <= 0 {
+		return "0s"
+	}
+	return PromDuration(d)
+}
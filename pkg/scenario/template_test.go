@@ -0,0 +1,89 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandCriterionTemplates(t *testing.T) {
+	base := func(query string) *Scenario {
+		return &Scenario{Spec: ScenarioSpec{
+			Duration: 10 * time.Minute,
+			Targets: []Target{
+				{Alias: "bor", Selector: TargetSelector{Type: "kurtosis_service", Pattern: "l2-el-1-bor"}},
+				{Alias: "bor-rpc", Selector: TargetSelector{Type: "kurtosis_service", Pattern: "l2-el-4-bor"}},
+			},
+			Faults: []Fault{
+				{Phase: "lag", Target: "bor", Type: "network", Duration: 90 * time.Second, Params: map[string]interface{}{"latency": 500}},
+			},
+			SuccessCriteria: []SuccessCriterion{
+				{Name: "c", Query: query},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantQuery string
+		wantErr   bool
+	}{
+		{
+			name:      "spec duration",
+			query:     "increase(x[{{ .Spec.Duration }}])",
+			wantQuery: "increase(x[10m])",
+		},
+		{
+			name:      "target pattern and index",
+			query:     `x{job=~"{{ .Targets.bor.Pattern }}|{{ (index .Targets "bor-rpc").Pattern }}"}`,
+			wantQuery: `x{job=~"l2-el-1-bor|l2-el-4-bor"}`,
+		},
+		{
+			name:      "fault duration and param",
+			query:     "rate(x[{{ .Faults.lag.Duration }}]) > {{ .Faults.lag.Params.latency }}",
+			wantQuery: "rate(x[90s]) > 500",
+		},
+		{
+			name:      "plain query untouched",
+			query:     "up{$__target}",
+			wantQuery: "up{$__target}",
+		},
+		{
+			name:    "unknown alias",
+			query:   "{{ .Targets.heimdall.Pattern }}",
+			wantErr: true,
+		},
+		{
+			name:    "malformed template",
+			query:   "{{ .Spec.Duration",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := base(tt.query)
+			err := ExpandCriterionTemplates(s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandCriterionTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && s.Spec.SuccessCriteria[0].Query != tt.wantQuery {
+				t.Errorf("Query = %q, want %q", s.Spec.SuccessCriteria[0].Query, tt.wantQuery)
+			}
+		})
+	}
+
+	t.Run("re-renders after duration change", func(t *testing.T) {
+		s := base("increase(x[{{ .Spec.Duration }}])")
+		if err := ExpandCriterionTemplates(s); err != nil {
+			t.Fatal(err)
+		}
+		s.Spec.Duration = 30 * time.Minute
+		if err := ExpandCriterionTemplates(s); err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Spec.SuccessCriteria[0].Query; got != "increase(x[30m])" {
+			t.Errorf("Query = %q, want increase(x[30m])", got)
+		}
+	})
+}
@@ -20,6 +20,17 @@ type Metadata struct {
 	Tags        []string `yaml:"tags,omitempty"`
 	Author      string   `yaml:"author,omitempty"`
 	Version     string   `yaml:"version,omitempty"`
+
+	// Annotations for whoever meets this scenario's effects mid-run: what
+	// the chaos is expected to do, where the runbook is, and who to ask.
+	// Shown in reports and GameDay announcements.
+	ExpectedImpact string `yaml:"expected_impact,omitempty"`
+	RunbookURL     string `yaml:"runbook_url,omitempty"`
+	Owner          string `yaml:"owner,omitempty"`
+
+	// Destructive scenarios need a second confirmation before INJECT
+	// (see pkg/approval and the destructive: config section).
+	Destructive bool `yaml:"destructive,omitempty"`
 }
 
 // ScenarioSpec defines the scenario specification
@@ -45,6 +56,12 @@ type ScenarioSpec struct {
 	// Metrics to collect during the test
 	Metrics []string `yaml:"metrics,omitempty"`
 
+	// TreatUnknownAs decides what a criterion the detector could not judge
+	// (query error, no data, blinded scrape target) counts as: "fail" (the
+	// default), "pass", or "unknown", which ends the run with its own
+	// outcome and exit code instead of a pass or a failure.
+	TreatUnknownAs string `yaml:"treat_unknown_as,omitempty"`
+
 	// Execution mode: sequential or parallel
 	ExecutionMode string `yaml:"execution_mode,omitempty"`
 
@@ -53,6 +70,46 @@ type ScenarioSpec struct {
 	// skipped with a clear error if unmet, instead of silently targeting a
 	// devnet too small to exercise the intended fault.
 	Preconditions *Preconditions `yaml:"preconditions,omitempty"`
+
+	// Detection measures how long after injection monitoring first
+	// reflected the fault, to validate alerting coverage rather than
+	// resilience. Optional.
+	Detection *DetectionSpec `yaml:"detection,omitempty"`
+}
+
+// DetectionSpec lists the monitoring signals whose time to detect is
+// recorded. A signal is polled from injection until teardown; the report
+// gives the latency from injection to the first poll that saw it.
+type DetectionSpec struct {
+	// Alerts are Prometheus alert names, detected when they first appear
+	// in ALERTS{alertstate="firing"}. An alert already firing before
+	// injection only counts once it has cleared and fired again.
+	Alerts []string `yaml:"alerts,omitempty"`
+
+	// Criteria are names of success criteria used as detection signals.
+	// A during_fault criterion asserts the fault's effect, so it detects
+	// when it first passes; any other criterion asserts health, so it
+	// detects when it first fails.
+	Criteria []string `yaml:"criteria,omitempty"`
+
+	// Interval is the poll interval and so the resolution of the measured
+	// latency. Defaults to DefaultDetectionInterval.
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// MaxLatency, when set, turns every signal into a critical check: one
+	// that detects later than this, or never, fails the run.
+	MaxLatency time.Duration `yaml:"max_latency,omitempty"`
+}
+
+// DefaultDetectionInterval is DetectionSpec.Interval when unset.
+const DefaultDetectionInterval = 5 * time.Second
+
+// PollInterval returns Interval, or DefaultDetectionInterval when unset.
+func (d *DetectionSpec) PollInterval() time.Duration {
+	if d.Interval > 0 {
+		return d.Interval
+	}
+	return DefaultDetectionInterval
 }
 
 // Preconditions encodes topology requirements for a scenario. A scenario that
@@ -79,22 +136,29 @@ type Target struct {
 	// Alias for referencing this target in faults
 	Alias string `yaml:"alias"`
 
-	// Count limits the number of matching services (0 = all)
-	Count int `yaml:"count,omitempty"`
+	// Count limits how many matching services are targeted: a number, a
+	// fraction ("1/3"), a percentage ("25%"), or a BFT threshold ("f",
+	// "f+1") resolved against the number matched. Empty = all.
+	Count TargetCount `yaml:"count,omitempty"`
 }
 
 // TargetSelector defines how to select target services
 type TargetSelector struct {
-	// Type of selector: kurtosis_service, docker_container
+	// Type of selector: kurtosis_service, docker_container, kubernetes_pod
 	Type string `yaml:"type"`
 
 	// Enclave name for Kurtosis services
 	Enclave string `yaml:"enclave,omitempty"`
 
+	// Namespace of kubernetes_pod targets; empty uses the namespace of
+	// the kubectl context
+	Namespace string `yaml:"namespace,omitempty"`
+
 	// Pattern is a regex pattern for service/container name matching
 	Pattern string `yaml:"pattern,omitempty"`
 
-	// Labels for Docker label-based selection
+	// Labels for Docker label-based selection, or the pod label selector
+	// of kubernetes_pod targets
 	Labels map[string]string `yaml:"labels,omitempty"`
 
 	// ContainerID for direct container targeting
@@ -121,14 +185,26 @@ type Fault struct {
 	// Params are fault-specific parameters
 	Params map[string]interface{} `yaml:"params"`
 
-	// Duration for this specific fault (overrides scenario duration)
+	// Duration for this specific fault (overrides scenario duration). The
+	// fault is removed once it has been installed this long; 0 keeps it
+	// until teardown.
 	Duration time.Duration `yaml:"duration,omitempty"`
 
-	// Delay before injecting this fault
+	// Delay before injecting this fault. Unlike StartAfter it holds up
+	// INJECT, so monitoring starts only once the fault is in.
 	Delay time.Duration `yaml:"delay,omitempty"`
 
+	// StartAfter schedules the fault at this offset from the start of
+	// INJECT. Scheduled faults are installed during MONITOR, so faults can
+	// come and go at different points of the test window.
+	StartAfter time.Duration `yaml:"start_after,omitempty"`
+
 	// ExcludeProducer dynamically excludes the current block producer from targets
 	ExcludeProducer bool `yaml:"exclude_producer,omitempty"`
+
+	// FailurePolicy decides whether the test goes on when the fault fails
+	// to install on some of its targets; see FailurePolicy.
+	FailurePolicy FailurePolicy `yaml:"failure_policy,omitempty"`
 }
 
 // SuccessCriterion defines a success criterion for the test
@@ -139,12 +215,31 @@ type SuccessCriterion struct {
 	// Description of what this checks
 	Description string `yaml:"description,omitempty"`
 
-	// Type: prometheus, log, state_root_consensus
+	// Type: prometheus, log, state_root_consensus, rpc, heimdall_api,
+	// composite
 	Type string `yaml:"type"`
 
+	// Preset names a built-in criterion (e.g. "bor_block_production") the
+	// parser expands into Type, Query and Threshold. Fields set alongside
+	// it override the preset's.
+	Preset string `yaml:"preset,omitempty"`
+
 	// Query for Prometheus-based criteria
 	Query string `yaml:"query,omitempty"`
 
+	// QueryTemplate is the original Query when it is a template over the
+	// scenario ({{ .Spec.Duration }}, …); see ExpandCriterionTemplates.
+	QueryTemplate string `yaml:"-" json:"-"`
+
+	// Target scopes a prometheus query to one target alias: $__target in
+	// Query is replaced after DISCOVER by a job matcher for exactly the
+	// containers the alias resolved to. Composite children inherit it.
+	Target string `yaml:"target,omitempty"`
+
+	// Metric is shorthand for `query: <metric>{$__target}`; set Target
+	// alongside it. Mutually exclusive with Query.
+	Metric string `yaml:"metric,omitempty"`
+
 	// Threshold to compare against (e.g., "> 0", "< 100", "== 0")
 	Threshold string `yaml:"threshold,omitempty"`
 
@@ -154,6 +249,21 @@ type SuccessCriterion struct {
 	// Critical marks this as a critical criterion (test fails if this fails)
 	Critical bool `yaml:"critical,omitempty"`
 
+	// Retries re-runs the evaluation up to this many extra times, spaced by
+	// RetryInterval (default 15s), so a single missed scrape does not fail
+	// the run. RequiredPasses (default 1) of the 1+Retries attempts must
+	// pass. Ignored by the during-fault sampler, which already polls.
+	Retries        int           `yaml:"retries,omitempty"`
+	RequiredPasses int           `yaml:"required_passes,omitempty"`
+	RetryInterval  time.Duration `yaml:"retry_interval,omitempty"`
+
+	// GracePeriod delays evaluation in DETECT until this long after
+	// teardown finished, then additionally waits (up to another GracePeriod)
+	// for every Prometheus target to have been scraped since teardown. Use
+	// for queries whose window must not include the fault period — without
+	// it, the first post-teardown evaluation often sees a stale scrape.
+	GracePeriod time.Duration `yaml:"grace_period,omitempty"`
+
 	// PostFaultOnly skips this criterion during the pre-fault health check.
 	// Use for criteria that verify fault effectiveness (e.g., "partitioned
 	// validator stops advancing") — these are expected to fail before injection.
@@ -166,6 +276,15 @@ type SuccessCriterion struct {
 	// removed the node recovers and the check becomes meaningless.
 	DuringFault bool `yaml:"during_fault,omitempty"`
 
+	// Expect inverts what counts as a pass. ExpectUnknown passes only when
+	// the detector could not judge the criterion because the scrape targets
+	// behind its query were down — used with scrape_block to assert that a
+	// monitoring blind spot is reported as unknown rather than a false pass.
+	// ExpectFailure passes only when the criterion was judged and failed,
+	// i.e. the fault demonstrably degraded what it checks — a self-test
+	// that the injection works.
+	Expect string `yaml:"expect,omitempty"`
+
 	// --- Log-based criteria fields (type: "log") ---
 
 	// Pattern is a regex pattern to search for in container logs.
@@ -184,16 +303,138 @@ type SuccessCriterion struct {
 	// Absence inverts the check: pass if the pattern is NOT found.
 	// Default false = pass if pattern IS found.
 	Absence bool `yaml:"absence,omitempty"`
+
+	// --- RPC criteria fields (type: "rpc") ---
+
+	// Method is the JSON-RPC method called on the EVM RPC endpoint, e.g.
+	// "eth_blockNumber" or "net_peerCount". Its result (a hex quantity,
+	// number or boolean) is compared against Threshold.
+	Method string `yaml:"method,omitempty"`
+
+	// Params are the method's positional parameters.
+	Params []interface{} `yaml:"params,omitempty"`
+
+	// Field picks a value out of an object result by JSON path: dotted
+	// keys, [n] indexes and a final length(), e.g. "number" from
+	// eth_getBlockByNumber. Also used by heimdall_api criteria.
+	Field string `yaml:"field,omitempty"`
+
+	// --- Heimdall API criteria fields (type: "heimdall_api") ---
+
+	// Path is the Heimdall REST endpoint queried, relative to the
+	// discovered API, e.g. "/checkpoints/latest". Field picks the value
+	// compared against Threshold out of its JSON response.
+	Path string `yaml:"path,omitempty"`
+
+	// --- Composite criteria fields (type: "composite") ---
+	// Exactly one of AllOf / AnyOf / Weighted is set. Children are full
+	// criteria (any type, including nested composites); their own
+	// Critical / PostFaultOnly / DuringFault flags are ignored — the
+	// composite's flags govern when and how the group is evaluated.
+
+	// AllOf passes when every child passes (AND).
+	AllOf []SuccessCriterion `yaml:"all_of,omitempty"`
+
+	// AnyOf passes when at least one child passes (OR).
+	AnyOf []SuccessCriterion `yaml:"any_of,omitempty"`
+
+	// Weighted passes when the summed Weight of passing children is at
+	// least MinScore.
+	Weighted []SuccessCriterion `yaml:"weighted,omitempty"`
+	MinScore float64            `yaml:"min_score,omitempty"`
+
+	// Weight is this criterion's contribution when it is a child of a
+	// Weighted composite. Defaults to 1.
+	Weight float64 `yaml:"weight,omitempty"`
+}
+
+// Values of ScenarioSpec.TreatUnknownAs.
+const (
+	UnknownAsFail    = "fail"
+	UnknownAsPass    = "pass"
+	UnknownAsUnknown = "unknown"
+)
+
+// UnknownPolicy returns TreatUnknownAs with its default applied.
+func (s ScenarioSpec) UnknownPolicy() string {
+	if s.TreatUnknownAs == "" {
+		return UnknownAsFail
+	}
+	return s.TreatUnknownAs
+}
+
+// Values of SuccessCriterion.Expect.
+const (
+	// ExpectUnknown asserts that the criterion cannot be judged.
+	ExpectUnknown = "unknown"
+	// ExpectFailure asserts that the criterion is judged and fails.
+	ExpectFailure = "failure"
+)
+
+// Children returns the sub-criteria of a composite criterion, or nil.
+func (c SuccessCriterion) Children() []SuccessCriterion {
+	switch {
+	case len(c.AllOf) > 0:
+		return c.AllOf
+	case len(c.AnyOf) > 0:
+		return c.AnyOf
+	default:
+		return c.Weighted
+	}
+}
+
+// Leaves returns every non-composite criterion reachable from c, depth
+// first. For a non-composite criterion it returns c itself.
+func (c SuccessCriterion) Leaves() []SuccessCriterion {
+	if c.Type != "composite" {
+		return []SuccessCriterion{c}
+	}
+	var leaves []SuccessCriterion
+	for _, child := range c.Children() {
+		leaves = append(leaves, child.Leaves()...)
+	}
+	return leaves
+}
+
+// HasFaultType reports whether any fault in the scenario is of the given
+// canonical type (aliases resolved).
+func (s *Scenario) HasFaultType(name string) bool {
+	for _, fault := range s.Spec.Faults {
+		if CanonicalFaultType(fault.Type) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCriterionType reports whether any success criterion, including
+// children of composites, is of the given type.
+func (s *Scenario) HasCriterionType(typ string) bool {
+	var walk func([]SuccessCriterion) bool
+	walk = func(criteria []SuccessCriterion) bool {
+		for _, c := range criteria {
+			if c.Type == typ || walk(c.AllOf) || walk(c.AnyOf) || walk(c.Weighted) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(s.Spec.SuccessCriteria)
 }
 
 // NetworkFaultParams defines parameters for network faults
 type NetworkFaultParams struct {
 	Device      string  `yaml:"device,omitempty"`
 	Latency     int     `yaml:"latency,omitempty"`
+	Jitter      int     `yaml:"jitter,omitempty"`
 	PacketLoss  float64 `yaml:"packet_loss,omitempty"`
 	Bandwidth   int     `yaml:"bandwidth,omitempty"`
 	TargetPorts string  `yaml:"target_ports,omitempty"`
 	TargetProto string  `yaml:"target_proto,omitempty"`
+
+	Reorder            int `yaml:"reorder,omitempty"`
+	ReorderCorrelation int `yaml:"reorder_correlation,omitempty"`
+	ReorderGap         int `yaml:"reorder_gap,omitempty"`
 }
 
 // ParseNetworkParams converts generic params to NetworkFaultParams
@@ -203,8 +444,15 @@ func ParseNetworkParams(params map[string]interface{}) NetworkFaultParams {
 	if v, ok := params["device"].(string); ok {
 		nfp.Device = v
 	}
-	if v, ok := params["latency"].(int); ok {
-		nfp.Latency = v
+	if v, present := params["latency"]; present {
+		if ms, err := ParseMillisParam(v); err == nil {
+			nfp.Latency = ms
+		}
+	}
+	if v, present := params["jitter"]; present {
+		if ms, err := ParseMillisParam(v); err == nil {
+			nfp.Jitter = ms
+		}
 	}
 	if v, ok := params["packet_loss"].(float64); ok {
 		nfp.PacketLoss = v
@@ -226,6 +474,20 @@ func ParseNetworkParams(params map[string]interface{}) NetworkFaultParams {
 	if v, ok := params["target_proto"].(string); ok {
 		nfp.TargetProto = v
 	}
+	nfp.Reorder = intParam(params["reorder"])
+	nfp.ReorderCorrelation = intParam(params["reorder_correlation"])
+	nfp.ReorderGap = intParam(params["reorder_gap"])
 	return nfp
 }
 
+// intParam reads an int param that YAML or JSON may have decoded as either
+// int or float64; anything else reads as 0.
+func intParam(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
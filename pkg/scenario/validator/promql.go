@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+// QueryRunner is the subset of the Prometheus client needed to dry-run
+// queries. *prometheus.Client satisfies it.
+type QueryRunner interface {
+	QueryLatest(ctx context.Context, query string) ([]prometheus.QueryResult, error)
+}
+
+// ValidateQueries dry-runs every prometheus criterion query and every entry
+// in spec.metrics against a live Prometheus. Call after Validate; findings
+// are appended to Warnings rather than Errors because an empty result can be
+// legitimate before injection (e.g. an error counter that only appears
+// under fault). A query Prometheus rejects outright — bad syntax, unknown
+// function — is still almost certainly a scenario bug, and surfacing it
+// here beats discovering it in DETECT after a full fault window.
+func (v *Validator) ValidateQueries(ctx context.Context, s *scenario.Scenario, q QueryRunner) {
+	for i, criterion := range s.Spec.SuccessCriteria {
+		for _, leaf := range criterion.Leaves() {
+			if leaf.Type != "prometheus" || leaf.Query == "" {
+				continue
+			}
+			field := fmt.Sprintf("spec.success_criteria[%d] (%s)", i, criterion.Name)
+			v.dryRunQuery(ctx, q, field, leaf.Query)
+		}
+	}
+
+	for i, metric := range s.Spec.Metrics {
+		v.dryRunQuery(ctx, q, fmt.Sprintf("spec.metrics[%d]", i), metric)
+	}
+}
+
+func (v *Validator) dryRunQuery(ctx context.Context, q QueryRunner, field, query string) {
+	results, err := q.QueryLatest(ctx, query)
+	if err != nil {
+		v.Warnings = append(v.Warnings, fmt.Sprintf("%s: query rejected by Prometheus: %v", field, err))
+		return
+	}
+	if len(results) == 0 {
+		v.Warnings = append(v.Warnings, fmt.Sprintf("%s: query currently returns no series: %s", field, query))
+	}
+}
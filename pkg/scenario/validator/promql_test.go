@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jihwankim/chaos-utils/pkg/monitoring/prometheus"
+	"github.com/jihwankim/chaos-utils/pkg/scenario"
+)
+
+type fakeQueryRunner map[string][]prometheus.QueryResult
+
+func (f fakeQueryRunner) QueryLatest(ctx context.Context, query string) ([]prometheus.QueryResult, error) {
+	results, ok := f[query]
+	if !ok {
+		return nil, fmt.Errorf("bad_data: parse error")
+	}
+	return results, nil
+}
+
+func TestValidateQueries(t *testing.T) {
+	q := fakeQueryRunner{
+		"up":               {{Value: 1}},
+		"absent_metric":    {},
+		"chain_head_block": {{Value: 100}},
+	}
+
+	s := &scenario.Scenario{
+		Spec: scenario.ScenarioSpec{
+			SuccessCriteria: []scenario.SuccessCriterion{
+				{Name: "ok", Type: "prometheus", Query: "up"},
+				{Name: "empty", Type: "prometheus", Query: "absent_metric"},
+				{Name: "broken", Type: "prometheus", Query: "rate(up[5m"},
+				{Name: "logs", Type: "log", Pattern: "panic"},
+			},
+			Metrics: []string{"chain_head_block", "typo_metric{"},
+		},
+	}
+
+	v := New()
+	v.ValidateQueries(context.Background(), s, q)
+
+	if len(v.Warnings) != 3 {
+		t.Fatalf("expected 3 warnings, got %d: %v", len(v.Warnings), v.Warnings)
+	}
+	if !strings.Contains(v.Warnings[0], "(empty)") || !strings.Contains(v.Warnings[0], "no series") {
+		t.Errorf("warning[0] = %q, want no-series warning for 'empty'", v.Warnings[0])
+	}
+	if !strings.Contains(v.Warnings[1], "(broken)") || !strings.Contains(v.Warnings[1], "rejected") {
+		t.Errorf("warning[1] = %q, want rejected warning for 'broken'", v.Warnings[1])
+	}
+	if !strings.Contains(v.Warnings[2], "spec.metrics[1]") {
+		t.Errorf("warning[2] = %q, want spec.metrics[1]", v.Warnings[2])
+	}
+	if len(v.Errors) != 0 {
+		t.Errorf("ValidateQueries must not add errors, got %v", v.Errors)
+	}
+}
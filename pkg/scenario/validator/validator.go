@@ -2,8 +2,12 @@ package validator
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/jihwankim/chaos-utils/pkg/scenario"
 )
@@ -49,6 +53,9 @@ func (v *Validator) Validate(s *scenario.Scenario) error {
 	// Validate success criteria
 	v.validateSuccessCriteria(s)
 
+	// Validate time-to-detect signals
+	v.validateDetection(s)
+
 	// Check for dangerous scenarios
 	v.checkDangerousScenarios(s)
 
@@ -138,6 +145,22 @@ func (v *Validator) validateMetadata(s *scenario.Scenario) {
 			v.Errors = append(v.Errors, "metadata.name must be lowercase alphanumeric with hyphens")
 		}
 	}
+
+	if s.Metadata.RunbookURL != "" {
+		u, err := url.Parse(s.Metadata.RunbookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("metadata.runbook_url '%s' must be an absolute http(s) URL", s.Metadata.RunbookURL))
+		}
+	}
+	if s.Metadata.Owner != "" && strings.TrimSpace(s.Metadata.Owner) == "" {
+		v.Errors = append(v.Errors, "metadata.owner cannot be blank")
+	}
+	if s.Metadata.ExpectedImpact != "" && strings.TrimSpace(s.Metadata.ExpectedImpact) == "" {
+		v.Errors = append(v.Errors, "metadata.expected_impact cannot be blank")
+	}
+	if s.Metadata.RunbookURL != "" && s.Metadata.Owner == "" {
+		v.Warnings = append(v.Warnings, "metadata.runbook_url is set but metadata.owner is not — on-call has a runbook but no one to ask")
+	}
 }
 
 func (v *Validator) validateSpec(s *scenario.Scenario) {
@@ -201,7 +224,7 @@ func (v *Validator) validateTargetSelector(sel scenario.TargetSelector, index in
 		return
 	}
 
-	validTypes := []string{"kurtosis_service", "docker_container"}
+	validTypes := []string{"kurtosis_service", "docker_container", "kubernetes_pod"}
 	valid := false
 	for _, t := range validTypes {
 		if sel.Type == t {
@@ -229,6 +252,16 @@ func (v *Validator) validateTargetSelector(sel scenario.TargetSelector, index in
 		if sel.Pattern == "" && sel.ContainerID == "" && len(sel.Labels) == 0 {
 			v.Errors = append(v.Errors, fmt.Sprintf("spec.targets[%d].selector must have pattern, container_id, or labels for docker_container type", index))
 		}
+
+	case "kubernetes_pod":
+		if sel.Pattern == "" && len(sel.Labels) == 0 {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.targets[%d].selector must have pattern or labels for kubernetes_pod type", index))
+		}
+		if sel.Pattern != "" {
+			if _, err := regexp.Compile(sel.Pattern); err != nil {
+				v.Errors = append(v.Errors, fmt.Sprintf("spec.targets[%d].selector.pattern is invalid regex: %v", index, err))
+			}
+		}
 	}
 }
 
@@ -240,8 +273,12 @@ func (v *Validator) validateFaults(s *scenario.Scenario) {
 
 	// Build set of valid target aliases
 	validTargets := make(map[string]bool)
+	podTargets := make(map[string]bool)
 	for _, target := range s.Spec.Targets {
 		validTargets[target.Alias] = true
+		if target.Selector.Type == "kubernetes_pod" {
+			podTargets[target.Alias] = true
+		}
 	}
 
 	for i, fault := range s.Spec.Faults {
@@ -265,44 +302,284 @@ func (v *Validator) validateFaults(s *scenario.Scenario) {
 		} else {
 			v.validateFaultParams(fault, i)
 		}
+
+		v.validateFaultTiming(s, fault, i)
+		v.validateFailurePolicy(s, fault, i)
+		if podTargets[fault.Target] {
+			v.validatePodFault(fault, i)
+		}
+		if scenario.CanonicalFaultType(fault.Type) == "network_partition" {
+			v.validatePartitionPeers(validTargets, fault, i)
+		}
+		if scenario.CanonicalFaultType(fault.Type) == "txpool_saturation" {
+			if key, _ := fault.Params["private_key"].(string); key == "" {
+				v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.private_key is required for txpool_saturation (a funded account's key)", i))
+			}
+		}
 	}
 }
 
-func (v *Validator) validateFaultType(fault scenario.Fault, index int) {
-	validTypes := []string{
-		"network",
-		"cpu", "cpu_stress",
-		"memory", "memory_stress", "memory_pressure",
-		"container_restart", "container_kill", "container_pause",
-		"connection_drop",
-		"dns",
-		"process_kill",
-		"disk_io", "disk_fill", "file_delete", "file_corrupt",
-		"clock_skew",
-		"http_fault", "corruption_proxy", "p2p_attack",
-		"disk", "process", "custom",
+// validatePodFault rejects faults Kubernetes pod targets cannot take:
+// only faults confined to the pod's network namespace are injected there,
+// and at_boot needs the Docker restart of the target.
+func (v *Validator) validatePodFault(fault scenario.Fault, index int) {
+	info, ok := scenario.LookupFaultType(fault.Type)
+	if !ok {
+		return
 	}
-	valid := false
-	for _, t := range validTypes {
-		if fault.Type == t {
-			valid = true
-			break
+	if !info.Pods {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].type '%s' is not supported on kubernetes_pod target '%s' (supported: %s)",
+			index, fault.Type, fault.Target, strings.Join(podFaultTypes(), ", ")))
+	}
+	if atBoot, _ := fault.Params["at_boot"].(bool); atBoot {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.at_boot is not supported on kubernetes_pod target '%s'", index, fault.Target))
+	}
+}
+
+// podFaultTypes lists the fault types Kubernetes pods support.
+func podFaultTypes() []string {
+	var names []string
+	for _, info := range scenario.FaultTypes() {
+		if info.Pods {
+			names = append(names, info.Name)
+		}
+	}
+	return names
+}
+
+// validatePartitionPeers requires a network_partition fault to name the
+// other side: peers aliases other than its own target, peer_ips IPv4
+// addresses or CIDRs, or both.
+func (v *Validator) validatePartitionPeers(validTargets map[string]bool, fault scenario.Fault, index int) {
+	aliases, err := scenario.ParseListParam(fault.Params["peers"])
+	if err != nil {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.peers: %v", index, err))
+	}
+	for _, alias := range aliases {
+		switch {
+		case alias == fault.Target:
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.peers cannot include the fault's own target '%s'", index, alias))
+		case !validTargets[alias]:
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.peers '%s' references non-existent target alias", index, alias))
 		}
 	}
 
-	if !valid {
+	ips, err := scenario.ParseListParam(fault.Params["peer_ips"])
+	if err != nil {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.peer_ips: %v", index, err))
+	}
+	for _, ip := range ips {
+		addr := net.ParseIP(ip)
+		if addr == nil {
+			addr, _, _ = net.ParseCIDR(ip)
+		}
+		if addr == nil || addr.To4() == nil {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.peer_ips '%s' is not an IPv4 address or CIDR", index, ip))
+		}
+	}
+
+	if len(aliases) == 0 && len(ips) == 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.peers or peer_ips is required for network_partition", index))
+	}
+}
+
+// validateFailurePolicy rejects partial-failure policies on faults whose
+// targets cannot be injected one by one: scheduled (stagger/batch) faults
+// and drain, whose rolling restart spans all its targets. min_targets(N)
+// must not exceed a fixed target count.
+func (v *Validator) validateFailurePolicy(s *scenario.Scenario, fault scenario.Fault, index int) {
+	if !fault.FailurePolicy.Partial() {
+		return
+	}
+	if schedule, err := scenario.ParseSchedule(fault.Params); err == nil && schedule.Active() {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].failure_policy %s cannot be combined with stagger or batch_percent", index, fault.FailurePolicy))
+	}
+	if scenario.CanonicalFaultType(fault.Type) == "drain" {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].failure_policy is not supported for drain", index))
+	}
+	for _, t := range s.Spec.Targets {
+		if n, ok := t.Count.Fixed(); ok && t.Alias == fault.Target && n > 0 && n < fault.FailurePolicy.MinTargets() {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].failure_policy %s needs more targets than count %d selects", index, fault.FailurePolicy, n))
+		}
+	}
+}
+
+// validateFaultTiming checks a fault's place on the timeline: start_after
+// must fall inside spec.duration, and a fault that outlasts the window is
+// only removed at teardown.
+func (v *Validator) validateFaultTiming(s *scenario.Scenario, fault scenario.Fault, index int) {
+	if fault.StartAfter < 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].start_after cannot be negative", index))
+	}
+	if fault.Duration < 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].duration cannot be negative", index))
+	}
+	if fault.StartAfter > 0 && fault.Delay > 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d] sets both delay and start_after; use start_after", index))
+	}
+	if s.Spec.Duration <= 0 {
+		return
+	}
+	if fault.StartAfter >= s.Spec.Duration {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].start_after (%s) must be less than spec.duration (%s)", index, fault.StartAfter, s.Spec.Duration))
+	} else if fault.Duration > 0 && fault.StartAfter+fault.Duration > s.Spec.Duration {
+		v.Warnings = append(v.Warnings, fmt.Sprintf("spec.faults[%d] runs past spec.duration (%s) and will be removed at teardown", index, s.Spec.Duration))
+	}
+}
+
+func (v *Validator) validateFaultType(fault scenario.Fault, index int) {
+	if _, ok := scenario.LookupFaultType(fault.Type); !ok {
 		v.Warnings = append(v.Warnings, fmt.Sprintf("spec.faults[%d].type '%s' may not be supported", index, fault.Type))
 	}
 }
 
 func (v *Validator) validateFaultParams(fault scenario.Fault, index int) {
-	switch fault.Type {
+	v.validateDurationParams(fault, index)
+	v.validateParamKeys(fault, index)
+	if _, err := scenario.ParseSchedule(fault.Params); err != nil {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.%v", index, err))
+	}
+	if _, err := scenario.ParseCapture(fault.Params); err != nil {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.%v", index, err))
+	}
+
+	switch scenario.CanonicalFaultType(fault.Type) {
 	case "network":
 		v.validateNetworkFaultParams(fault.Params, index)
+		v.validateAtBootParam(fault, index)
+	case "connection_drop", "dns":
+		v.validateAtBootParam(fault, index)
+	case "external":
+		v.validateExternalFaultParams(fault.Params, index)
+	case "container_restart", "container_kill":
+		v.validateHealthCheckParam(fault, index)
+	case "drain":
+		v.validatePortsParam(fault, index)
+		v.validateHealthCheckParam(fault, index)
+	case "scrape_block":
+		v.validatePortsParam(fault, index)
 	// Add more fault type validations as needed
 	}
 }
 
+// validateAtBootParam requires at_boot, when set, to be a bool.
+func (v *Validator) validateAtBootParam(fault scenario.Fault, index int) {
+	if raw, present := fault.Params["at_boot"]; present {
+		if _, ok := raw.(bool); !ok {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.at_boot must be true or false", index))
+		}
+	}
+}
+
+// validatePortsParam requires a non-empty ports param (CSV string or a
+// port number).
+func (v *Validator) validatePortsParam(fault scenario.Fault, index int) {
+	faultType := scenario.CanonicalFaultType(fault.Type)
+	switch ports := fault.Params["ports"].(type) {
+	case int, float64:
+	case string:
+		if strings.TrimSpace(ports) == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.ports is required for %s", index, faultType))
+		}
+	default:
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.ports is required for %s (CSV string or port number)", index, faultType))
+	}
+}
+
+// validateHealthCheckParam checks verify_health, which only makes sense
+// when the fault brings the container back.
+func (v *Validator) validateHealthCheckParam(fault scenario.Fault, index int) {
+	hc, err := scenario.ParseHealthCheckParam(fault.Params["verify_health"])
+	if err != nil {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.verify_health: %v", index, err))
+		return
+	}
+	if hc != nil && scenario.CanonicalFaultType(fault.Type) == "container_kill" {
+		if restart, ok := fault.Params["restart"].(bool); ok && !restart {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.verify_health requires restart: true", index))
+		}
+	}
+}
+
+// validateParamKeys warns about params the injector never reads — almost
+// always a typo (`packet_los`) or a key copied from another fault type,
+// either of which silently turns the fault into a no-op for that setting.
+func (v *Validator) validateParamKeys(fault scenario.Fault, index int) {
+	info, ok := scenario.LookupFaultType(fault.Type)
+	if !ok {
+		return
+	}
+	keys := make([]string, 0, len(fault.Params))
+	for key := range fault.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !info.HasParam(key) {
+			v.Warnings = append(v.Warnings, fmt.Sprintf("spec.faults[%d].params.%s is not a recognised parameter for %s", index, key, info.Name))
+		}
+	}
+}
+
+// validateDurationParams rejects latency/delay values that the injector would
+// fail to parse. Bare numbers keep their legacy unit (ms, or seconds for
+// container_pause duration); strings must be valid time.ParseDuration input.
+func (v *Validator) validateDurationParams(fault scenario.Fault, index int) {
+	check := func(key string, unit time.Duration) {
+		raw, present := fault.Params[key]
+		if !present {
+			return
+		}
+		d, err := scenario.ParseDurationParam(raw, unit)
+		if err != nil {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.%s: %v", index, key, err))
+			return
+		}
+		if d < 0 {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.%s cannot be negative", index, key))
+		}
+	}
+
+	for _, key := range []string{"latency", "jitter", "delay_ms", "io_latency_ms"} {
+		check(key, time.Millisecond)
+	}
+	switch fault.Type {
+	case "container_pause":
+		check("duration", time.Second)
+	case "external":
+		check("timeout", time.Second)
+	case "drain":
+		check("grace_window", time.Second)
+	}
+}
+
+// validateExternalFaultParams checks the provider selection for an external
+// fault; the remaining params belong to the provider and are not inspected.
+func (v *Validator) validateExternalFaultParams(params map[string]interface{}, index int) {
+	provider, _ := params["provider"].(string)
+	switch provider {
+	case "exec":
+		switch cmd := params["command"].(type) {
+		case string:
+			if cmd == "" {
+				v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.command is required for exec provider", index))
+			}
+		case []interface{}:
+			if len(cmd) == 0 {
+				v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.command is required for exec provider", index))
+			}
+		default:
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.command is required for exec provider (string or list)", index))
+		}
+	case "http":
+		if url, _ := params["url"].(string); url == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.url is required for http provider", index))
+		}
+	default:
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.provider must be exec or http", index))
+	}
+}
+
 func (v *Validator) validateNetworkFaultParams(params map[string]interface{}, index int) {
 	nfp := scenario.ParseNetworkParams(params)
 
@@ -321,42 +598,284 @@ func (v *Validator) validateNetworkFaultParams(params map[string]interface{}, in
 		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.bandwidth cannot be negative", index))
 	}
 
+	if nfp.Jitter > 0 && nfp.Latency == 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.jitter requires latency", index))
+	}
+
+	// netem reorders by sending some packets immediately while the rest
+	// wait out the delay, so every reorder knob needs latency.
+	if nfp.Reorder < 0 || nfp.Reorder > 100 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.reorder must be between 0 and 100", index))
+	}
+	if nfp.Reorder > 0 && nfp.Latency == 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.reorder requires latency", index))
+	}
+	if nfp.ReorderCorrelation < 0 || nfp.ReorderCorrelation > 100 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.reorder_correlation must be between 0 and 100", index))
+	}
+	if nfp.ReorderGap < 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.reorder_gap cannot be negative", index))
+	}
+	if (nfp.ReorderCorrelation > 0 || nfp.ReorderGap > 0) && nfp.Reorder == 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.reorder_correlation and reorder_gap require reorder", index))
+	}
+
+	if raw, present := params["all_interfaces"]; present {
+		if all, ok := raw.(bool); !ok {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.faults[%d].params.all_interfaces must be a boolean", index))
+		} else if all && nfp.Device != "" {
+			v.Warnings = append(v.Warnings, fmt.Sprintf("spec.faults[%d].params.device is ignored when all_interfaces is true", index))
+		}
+	}
+
 }
 
 func (v *Validator) validateSuccessCriteria(s *scenario.Scenario) {
+	switch s.Spec.TreatUnknownAs {
+	case "", scenario.UnknownAsFail, scenario.UnknownAsPass, scenario.UnknownAsUnknown:
+	default:
+		v.Errors = append(v.Errors, fmt.Sprintf("spec.treat_unknown_as '%s' is invalid (must be fail, pass, or unknown)", s.Spec.TreatUnknownAs))
+	}
+
+	// Criteria only come out unknown while a scrape_block fault blinds
+	// Prometheus — see detector.DetectBlindSpots.
+	blinded := s.HasFaultType("scrape_block")
+
+	aliases := make(map[string]bool)
+	for _, target := range s.Spec.Targets {
+		aliases[target.Alias] = true
+	}
+
 	for i, criterion := range s.Spec.SuccessCriteria {
-		if criterion.Name == "" {
-			v.Errors = append(v.Errors, fmt.Sprintf("spec.success_criteria[%d].name is required", i))
+		path := fmt.Sprintf("spec.success_criteria[%d]", i)
+		v.validateCriterion(criterion, path, true)
+		v.validateCriterionTarget(criterion, path, "", aliases)
+		if expectsUnknown(criterion) {
+			if !blinded {
+				v.Warnings = append(v.Warnings, fmt.Sprintf("%s: expect: unknown can never pass without a scrape_block fault in the scenario", path))
+			} else if !criterion.DuringFault {
+				v.Warnings = append(v.Warnings, fmt.Sprintf("%s: expect: unknown is only reachable while scrapes are blocked — set during_fault: true", path))
+			}
 		}
+	}
+}
 
-		if criterion.Type == "" {
-			v.Errors = append(v.Errors, fmt.Sprintf("spec.success_criteria[%d].type is required", i))
+// validateCriterionTarget checks that a criterion's target names a
+// scenario target and that $__target queries have one. Composite children
+// inherit their parent's target, as ResolveCriterionTarget does.
+// validateDetection checks spec.detection: at least one signal, criterion
+// names that exist, and non-negative durations.
+func (v *Validator) validateDetection(s *scenario.Scenario) {
+	d := s.Spec.Detection
+	if d == nil {
+		return
+	}
+	if len(d.Alerts) == 0 && len(d.Criteria) == 0 {
+		v.Errors = append(v.Errors, "spec.detection must list at least one alert or criterion")
+	}
+	for i, alert := range d.Alerts {
+		if strings.TrimSpace(alert) == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.detection.alerts[%d] is empty", i))
+		}
+	}
+	names := make(map[string]bool)
+	for _, c := range s.Spec.SuccessCriteria {
+		names[c.Name] = true
+	}
+	for i, name := range d.Criteria {
+		if !names[name] {
+			v.Errors = append(v.Errors, fmt.Sprintf("spec.detection.criteria[%d] '%s' is not a success criterion of this scenario", i, name))
 		}
+	}
+	if d.Interval < 0 {
+		v.Errors = append(v.Errors, "spec.detection.interval cannot be negative")
+	}
+	if d.MaxLatency < 0 {
+		v.Errors = append(v.Errors, "spec.detection.max_latency cannot be negative")
+	}
+}
 
-		// Type-specific validation
-		switch criterion.Type {
-		case "prometheus":
-			if criterion.Query == "" {
-				v.Errors = append(v.Errors, fmt.Sprintf("spec.success_criteria[%d].query is required for prometheus type", i))
-			}
-			if criterion.Threshold == "" {
-				v.Errors = append(v.Errors, fmt.Sprintf("spec.success_criteria[%d].threshold is required for prometheus type", i))
-			}
+func (v *Validator) validateCriterionTarget(c scenario.SuccessCriterion, path, inherited string, aliases map[string]bool) {
+	if c.Target != "" {
+		if !aliases[c.Target] {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.target '%s' references non-existent target alias", path, c.Target))
+		}
+		if c.Type != "prometheus" && c.Type != "composite" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.target only applies to prometheus and composite criteria (log criteria use target_log)", path))
+		} else if c.Type == "prometheus" && !strings.Contains(c.Query, scenario.TargetPlaceholder) {
+			v.Warnings = append(v.Warnings, fmt.Sprintf("%s.target is unused: query does not contain %s", path, scenario.TargetPlaceholder))
+		}
+	} else {
+		c.Target = inherited
+	}
 
-		case "log":
-			if criterion.Pattern == "" {
-				v.Errors = append(v.Errors, fmt.Sprintf("spec.success_criteria[%d].pattern is required for log type", i))
-			}
+	if strings.Contains(c.Query, scenario.TargetPlaceholder) && c.Target == "" {
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.query uses %s but target is not set", path, scenario.TargetPlaceholder))
+	}
 
-		case "state_root_consensus":
-			// no required fields; uses ContainerPattern with a default
+	for i, child := range c.AllOf {
+		v.validateCriterionTarget(child, fmt.Sprintf("%s.all_of[%d]", path, i), c.Target, aliases)
+	}
+	for i, child := range c.AnyOf {
+		v.validateCriterionTarget(child, fmt.Sprintf("%s.any_of[%d]", path, i), c.Target, aliases)
+	}
+	for i, child := range c.Weighted {
+		v.validateCriterionTarget(child, fmt.Sprintf("%s.weighted[%d]", path, i), c.Target, aliases)
+	}
+}
 
-		case "health_check":
-			v.Errors = append(v.Errors, fmt.Sprintf("spec.success_criteria[%d]: health_check criterion type has been removed; use type: prometheus or type: log", i))
+// expectsUnknown reports whether c or any composite child sets
+// expect: unknown.
+func expectsUnknown(c scenario.SuccessCriterion) bool {
+	if c.Expect == scenario.ExpectUnknown {
+		return true
+	}
+	for _, child := range c.Children() {
+		if expectsUnknown(child) {
+			return true
+		}
+	}
+	return false
+}
 
-		default:
-			v.Errors = append(v.Errors, fmt.Sprintf("spec.success_criteria[%d].type '%s' is invalid (must be prometheus, log, or state_root_consensus)", i, criterion.Type))
+// validateCriterion checks one criterion. path is the YAML location used in
+// messages; composite children recurse with e.g. "...[0].any_of[1]". Names
+// are required at the top level only — children are reported by path.
+func (v *Validator) validateCriterion(criterion scenario.SuccessCriterion, path string, topLevel bool) {
+	if topLevel && criterion.Name == "" {
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.name is required", path))
+	}
+
+	if criterion.Type == "" {
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.type is required", path))
+	}
+
+	if criterion.Retries < 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.retries cannot be negative", path))
+	}
+	if criterion.RequiredPasses < 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.required_passes cannot be negative", path))
+	} else if criterion.RequiredPasses > criterion.Retries+1 {
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.required_passes (%d) exceeds the %d attempt(s) allowed by retries", path, criterion.RequiredPasses, criterion.Retries+1))
+	}
+
+	if criterion.Window < 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.window cannot be negative", path))
+	}
+
+	if criterion.GracePeriod < 0 {
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.grace_period cannot be negative", path))
+	} else if criterion.GracePeriod > 0 && criterion.DuringFault {
+		v.Warnings = append(v.Warnings, fmt.Sprintf("%s.grace_period is ignored for during_fault criteria", path))
+	}
+
+	switch criterion.Expect {
+	case "":
+	case scenario.ExpectUnknown:
+		if criterion.Type != "prometheus" && criterion.Type != "composite" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.expect: unknown only applies to prometheus and composite criteria", path))
+		}
+	case scenario.ExpectFailure:
+		if topLevel && !criterion.DuringFault {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.expect: failure requires during_fault: true (it asserts the fault degraded the metric while active)", path))
+		}
+	default:
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.expect '%s' is invalid (must be unknown or failure)", path, criterion.Expect))
+	}
+
+	// Type-specific validation
+	switch criterion.Type {
+	case "prometheus":
+		if criterion.Query == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.query is required for prometheus type", path))
+		}
+		if criterion.Threshold == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.threshold is required for prometheus type", path))
+		}
+
+	case "log":
+		if criterion.Pattern == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.pattern is required for log type", path))
+		}
+
+	case "state_root_consensus":
+		// no required fields; uses ContainerPattern with a default
+
+	case "rpc":
+		if criterion.Method == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.method is required for rpc type", path))
+		}
+		if criterion.Threshold == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.threshold is required for rpc type", path))
+		}
+
+	case "heimdall_api":
+		if criterion.Path == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.path is required for heimdall_api type", path))
+		} else if !strings.HasPrefix(criterion.Path, "/") {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.path '%s' must start with / (it is relative to the Heimdall API)", path, criterion.Path))
+		}
+		if criterion.Field == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.field is required for heimdall_api type", path))
+		}
+		if criterion.Threshold == "" {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.threshold is required for heimdall_api type", path))
+		}
+
+	case "composite":
+		v.validateComposite(criterion, path)
+
+	case "health_check":
+		v.Errors = append(v.Errors, fmt.Sprintf("%s: health_check criterion type has been removed; use type: prometheus or type: log", path))
+
+	default:
+		v.Errors = append(v.Errors, fmt.Sprintf("%s.type '%s' is invalid (must be prometheus, log, state_root_consensus, rpc, heimdall_api, or composite)", path, criterion.Type))
+	}
+}
+
+// validateComposite checks the all_of / any_of / weighted grouping.
+func (v *Validator) validateComposite(criterion scenario.SuccessCriterion, path string) {
+	groups := 0
+	for _, g := range [][]scenario.SuccessCriterion{criterion.AllOf, criterion.AnyOf, criterion.Weighted} {
+		if len(g) > 0 {
+			groups++
+		}
+	}
+	if groups != 1 {
+		v.Errors = append(v.Errors, fmt.Sprintf("%s: composite criterion requires exactly one of all_of, any_of, or weighted", path))
+		return
+	}
+
+	key := "all_of"
+	switch {
+	case len(criterion.AnyOf) > 0:
+		key = "any_of"
+	case len(criterion.Weighted) > 0:
+		key = "weighted"
+	}
+
+	total := 0.0
+	for i, child := range criterion.Children() {
+		childPath := fmt.Sprintf("%s.%s[%d]", path, key, i)
+		v.validateCriterion(child, childPath, false)
+		if child.Weight < 0 {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.weight cannot be negative", childPath))
+		}
+		if child.Weight == 0 {
+			total++
+		} else {
+			total += child.Weight
+		}
+	}
+
+	if key == "weighted" {
+		if criterion.MinScore <= 0 {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.min_score must be > 0 for weighted composites", path))
+		} else if criterion.MinScore > total {
+			v.Errors = append(v.Errors, fmt.Sprintf("%s.min_score %.2f exceeds the total weight %.2f — criterion can never pass", path, criterion.MinScore, total))
 		}
+	} else if criterion.MinScore != 0 {
+		v.Warnings = append(v.Warnings, fmt.Sprintf("%s.min_score is ignored for %s composites", path, key))
 	}
 }
 
@@ -0,0 +1,49 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WindowPlaceholder in a prometheus criterion query is replaced by the
+// criterion's Window, formatted as a PromQL duration, so the range of
+// rate()/increase() selectors is set once per criterion instead of being
+// hardcoded into every query.
+const WindowPlaceholder = "$__window"
+
+// ExpandCriterionWindow substitutes c.Window into c.Query, then does the
+// same for composite children. It returns an error when the query uses the
+// placeholder but no window is set.
+func ExpandCriterionWindow(c *SuccessCriterion) error {
+	if strings.Contains(c.Query, WindowPlaceholder) {
+		if c.Window <= 0 {
+			return fmt.Errorf("criterion %q: query uses %s but window is not set", c.Name, WindowPlaceholder)
+		}
+		c.Query = strings.ReplaceAll(c.Query, WindowPlaceholder, PromDuration(c.Window))
+	}
+
+	for _, children := range [][]SuccessCriterion{c.AllOf, c.AnyOf, c.Weighted} {
+		for i := range children {
+			if err := ExpandCriterionWindow(&children[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PromDuration formats d in the largest whole PromQL unit (1h, 90s, 1500ms).
+// time.Duration.String's "5m0s" is not accepted by older Prometheus.
+func PromDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	case d%time.Second == 0:
+		return fmt.Sprintf("%ds", d/time.Second)
+	default:
+		return fmt.Sprintf("%dms", d/time.Millisecond)
+	}
+}
@@ -0,0 +1,61 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandCriterionWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		criterion SuccessCriterion
+		wantQuery string
+		wantErr   bool
+	}{
+		{
+			name:      "substitutes window",
+			criterion: SuccessCriterion{Query: "increase(x[$__window]) / rate(y[$__window])", Window: 5 * time.Minute},
+			wantQuery: "increase(x[5m]) / rate(y[5m])",
+		},
+		{
+			name:      "literal range untouched",
+			criterion: SuccessCriterion{Query: "increase(x[3m])", Window: 5 * time.Minute},
+			wantQuery: "increase(x[3m])",
+		},
+		{
+			name:      "placeholder without window",
+			criterion: SuccessCriterion{Query: "increase(x[$__window])"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.criterion
+			err := ExpandCriterionWindow(&c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandCriterionWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && c.Query != tt.wantQuery {
+				t.Errorf("Query = %q, want %q", c.Query, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestPromDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{2 * time.Hour, "2h"},
+		{90 * time.Minute, "90m"},
+		{90 * time.Second, "90s"},
+		{1500 * time.Millisecond, "1500ms"},
+	}
+	for _, tt := range tests {
+		if got := PromDuration(tt.d); got != tt.want {
+			t.Errorf("PromDuration(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
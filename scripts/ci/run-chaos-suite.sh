@@ -522,6 +522,7 @@ LONG_COOLDOWN_SCENARIOS="simultaneous-validator-restart three-validator-full-iso
 PASSED=0
 FAILED=0
 ERRORS=0
+UNKNOWN=0
 SKIPPED=0
 TEST_FAILURES=""
 DEVNET_HALTED=false
@@ -543,6 +544,7 @@ emit_scenario_summary() {
     FAILED)    echo "❌ FAILED  ${n} (${dur})${suffix}" ;;
     TIMEOUT)   echo "⏱  TIMEOUT ${n} (${dur})${suffix}" ;;
     ERROR)     echo "⚠️  ERROR   ${n} (${dur})${suffix}" ;;
+    UNKNOWN)   echo "❔ UNKNOWN ${n} (${dur})${suffix}" ;;
     SKIPPED)   echo "⏭  SKIPPED ${n}${suffix}" ;;
     CONFIRMED) echo "🔥 CONFIRMED-BUG ${n}${suffix}" ;;
   esac
@@ -732,6 +734,16 @@ for scenario in ${SELECTED}; do
     TEST_FAILURES="${TEST_FAILURES}${name}"$'\n'
     SUMMARY_LABEL="FAILED"
     SUMMARY_EXTRA="(critical criteria missed — see log ${REPORT_DIR}/${name}.log)"
+  elif [[ ${EXIT_CODE} -eq 3 ]]; then
+    # treat_unknown_as: unknown — critical criteria could not be judged
+    # (query errors, no data). Not a failure, and not an infra error either.
+    echo "::warning::UNKNOWN: ${name} — critical success criteria could not be judged"
+    echo "UNKNOWN ${name}" >> "${REPORT_DIR}/results.txt"
+    UNKNOWN=$((UNKNOWN + 1))
+    CONSECUTIVE_ERRORS=0
+    LAST_ERROR_SCENARIO=""
+    SUMMARY_LABEL="UNKNOWN"
+    SUMMARY_EXTRA="(see log ${REPORT_DIR}/${name}.log)"
   else
     echo "::error::ERROR: ${name} (exit: ${EXIT_CODE})"
     echo "ERROR  ${name}" >> "${REPORT_DIR}/results.txt"
@@ -777,10 +789,10 @@ if [[ "${DEVNET_HALTED}" == "true" ]]; then
   done
 fi
 
-TOTAL=$((PASSED + FAILED + ERRORS + SKIPPED))
+TOTAL=$((PASSED + FAILED + ERRORS + UNKNOWN + SKIPPED))
 echo ""
 echo "═══════════════════════════════════════"
-echo "  RESULTS: ${PASSED}/${TOTAL} passed, ${FAILED} failed, ${ERRORS} errors, ${SKIPPED} skipped"
+echo "  RESULTS: ${PASSED}/${TOTAL} passed, ${FAILED} failed, ${ERRORS} errors, ${UNKNOWN} unknown, ${SKIPPED} skipped"
 echo "═══════════════════════════════════════"
 
 if [[ -n "${TEST_FAILURES}" ]]; then
@@ -813,6 +825,7 @@ echo "REPORT_DIR=${REPORT_DIR}" >> "${GITHUB_ENV}"
   echo "| ✅ Passed | ${PASSED} |"
   echo "| ❌ Failed (critical criteria) | ${FAILED} |"
   echo "| ⚠️ Errors (infra) | ${ERRORS} |"
+  echo "| ❔ Unknown (criteria not judged) | ${UNKNOWN} |"
   echo "| ⏭️ Skipped | ${SKIPPED} |"
   if [[ -n "${TEST_FAILURES}" ]]; then
     echo ""
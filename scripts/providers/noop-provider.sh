@@ -0,0 +1,16 @@
+#!/usr/bin/env bash
+# Minimal external fault provider (docs/external-fault-providers.md).
+# Reads one JSON request on stdin and answers on stdout. Replace the case
+# arms with real inject/remove/verify logic.
+set -euo pipefail
+
+request=$(cat)
+action=$(jq -r .action <<<"$request")
+container=$(jq -r .target.container_id <<<"$request")
+
+case "$action" in
+  inject) echo "{\"ok\":true,\"message\":\"injected into ${container:0:12}\"}" ;;
+  remove) echo '{"ok":true}' ;;
+  verify) echo '{"ok":true,"active":true}' ;;
+  *)      echo "{\"ok\":false,\"message\":\"unknown action $action\"}" ;;
+esac